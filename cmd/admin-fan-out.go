@@ -0,0 +1,108 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Number of admin RPC calls to peers in flight at once unless
+// MINIO_ADMIN_FANOUT says otherwise.
+const defaultAdminFanOutLimit = 32
+
+// fanOutPool - bounds the number of calls in flight across all the
+// fan-outs sharing it.
+type fanOutPool struct {
+	size    int
+	tokens  chan struct{}
+	timeout time.Duration // Deadline of every call.
+}
+
+// newFanOutPool - returns a pool running at most size calls at once,
+// each for at most adminRPCTimeout.
+func newFanOutPool(size int) *fanOutPool {
+	return &fanOutPool{
+		size:    size,
+		tokens:  make(chan struct{}, size),
+		timeout: adminRPCTimeout,
+	}
+}
+
+// run - calls fn for every index in [0, n) and waits for all the calls
+// to return. At most size workers are started however large n is,
+// each taking a token of the pool around every call. fn must return
+// once the context it is passed is done, so that hung calls free
+// their token by the timeout of the pool.
+func (p *fanOutPool) run(ctx context.Context, n int, fn func(ctx context.Context, idx int)) {
+	workers := p.size
+	if n < workers {
+		workers = n
+	}
+
+	idxCh := make(chan int, n)
+	for i := 0; i < n; i++ {
+		idxCh <- i
+	}
+	close(idxCh)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range idxCh {
+				p.call(ctx, idx, fn)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// call - calls fn with a context timing out after the timeout of the
+// pool once a token is taken. Once ctx is done, fn is called without
+// waiting for a token so that it fails right away.
+func (p *fanOutPool) call(ctx context.Context, idx int, fn func(ctx context.Context, idx int)) {
+	select {
+	case p.tokens <- struct{}{}:
+	case <-ctx.Done():
+		fn(ctx, idx)
+		return
+	}
+	defer func() { <-p.tokens }()
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	fn(ctx, idx)
+}
+
+// getAdminFanOutLimit - returns the number of admin RPC calls to peers
+// in flight at once, set by MINIO_ADMIN_FANOUT.
+func getAdminFanOutLimit() int {
+	if limit, err := strconv.Atoi(os.Getenv("MINIO_ADMIN_FANOUT")); err == nil && limit > 0 {
+		return limit
+	}
+	return defaultAdminFanOutLimit
+}
+
+// globalAdminFanOut - shared by all fan-outs across peers so that
+// large clusters do not start one goroutine and one connection per
+// server for every admin request.
+var globalAdminFanOut = newFanOutPool(getAdminFanOutLimit())
@@ -0,0 +1,198 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestFanOutPool - tests every index is run once and no more than
+// size calls are in flight at once.
+func TestFanOutPool(t *testing.T) {
+	testCases := []struct {
+		size int
+		n    int
+	}{
+		{1, 10},
+		{4, 3},
+		{4, 100},
+		{32, 100},
+	}
+
+	for i, testCase := range testCases {
+		pool := newFanOutPool(testCase.size)
+		calls := make([]int, testCase.n)
+		var mu sync.Mutex
+		inFlight, maxInFlight := 0, 0
+		pool.run(context.Background(), testCase.n, func(ctx context.Context, idx int) {
+			mu.Lock()
+			calls[idx]++
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		})
+
+		for idx, count := range calls {
+			if count != 1 {
+				t.Errorf("Test %d: Expected index %d to be run once but was run %d times", i+1, idx, count)
+			}
+		}
+		if maxInFlight > testCase.size {
+			t.Errorf("Test %d: Expected at most %d calls in flight but found %d", i+1, testCase.size, maxInFlight)
+		}
+	}
+}
+
+// TestFanOutPoolDeadline - tests hung calls are given up on by the
+// timeout of the pool, freeing their token for the calls queued
+// behind them, and calls queued once the context is done do not wait
+// for a token.
+func TestFanOutPoolDeadline(t *testing.T) {
+	pool := newFanOutPool(2)
+	pool.timeout = 100 * time.Millisecond
+
+	errs := make([]error, 6)
+	start := time.Now()
+	pool.run(context.Background(), len(errs), func(ctx context.Context, idx int) {
+		// Hangs until its context is done.
+		<-ctx.Done()
+		errs[idx] = ctx.Err()
+	})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Expected hung calls to be given up on by the timeout, took %s", elapsed)
+	}
+	for idx, err := range errs {
+		if err != context.DeadlineExceeded {
+			t.Errorf("Call %d: expected %v, got %v", idx, context.DeadlineExceeded, err)
+		}
+	}
+
+	// All tokens are taken by calls of another fan-out.
+	for i := 0; i < pool.size; i++ {
+		pool.tokens <- struct{}{}
+	}
+	defer func() {
+		for i := 0; i < pool.size; i++ {
+			<-pool.tokens
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	pool.run(ctx, len(errs), func(ctx context.Context, idx int) {
+		errs[idx] = ctx.Err()
+	})
+	for idx, err := range errs {
+		if err != context.DeadlineExceeded {
+			t.Errorf("Call %d: expected %v, got %v", idx, context.DeadlineExceeded, err)
+		}
+	}
+}
+
+// TestGetAdminFanOutLimit - tests MINIO_ADMIN_FANOUT overrides the
+// default limit unless invalid.
+func TestGetAdminFanOutLimit(t *testing.T) {
+	defer os.Unsetenv("MINIO_ADMIN_FANOUT")
+
+	testCases := []struct {
+		env   string
+		limit int
+	}{
+		{"", defaultAdminFanOutLimit},
+		{"8", 8},
+		{"0", defaultAdminFanOutLimit},
+		{"-1", defaultAdminFanOutLimit},
+		{"many", defaultAdminFanOutLimit},
+	}
+
+	for i, testCase := range testCases {
+		os.Setenv("MINIO_ADMIN_FANOUT", testCase.env)
+		if limit := getAdminFanOutLimit(); limit != testCase.limit {
+			t.Errorf("Test %d: Expected limit %d but got %d", i+1, testCase.limit, limit)
+		}
+	}
+}
+
+// peakGoroutineStub - adminCmdRunner recording the highest number of
+// goroutines seen while its Uptime calls were in flight.
+type peakGoroutineStub struct {
+	adminCmdRunner
+	mu   *sync.Mutex
+	peak *int
+}
+
+func (s peakGoroutineStub) Uptime(ctx context.Context) (time.Duration, error) {
+	n := runtime.NumGoroutine()
+	s.mu.Lock()
+	if n > *s.peak {
+		*s.peak = n
+	}
+	s.mu.Unlock()
+	time.Sleep(time.Millisecond)
+	return time.Minute, nil
+}
+
+// BenchmarkPeerFanOut - reports the peak number of goroutines while
+// fetching the uptime of 100 servers, with the default limit and with
+// one call in flight per server.
+func BenchmarkPeerFanOut(b *testing.B) {
+	defer func(isDistXL bool, pool *fanOutPool) {
+		globalIsDistXL = isDistXL
+		globalAdminFanOut = pool
+	}(globalIsDistXL, globalAdminFanOut)
+	globalIsDistXL = true
+
+	const servers = 100
+	for _, limit := range []int{defaultAdminFanOutLimit, servers} {
+		b.Run(fmt.Sprintf("limit-%d", limit), func(b *testing.B) {
+			globalAdminFanOut = newFanOutPool(limit)
+
+			var mu sync.Mutex
+			peak := 0
+			peers := make(adminPeers, servers)
+			for i := range peers {
+				peers[i] = adminPeer{
+					addr:      fmt.Sprintf("server%d:9000", i),
+					cmdRunner: peakGoroutineStub{mu: &mu, peak: &peak},
+				}
+			}
+
+			base := runtime.NumGoroutine()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := getPeerUptimes(context.Background(), peers); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+			b.Logf("peak goroutines: %d", peak-base)
+		})
+	}
+}
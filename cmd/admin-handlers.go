@@ -17,17 +17,25 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
+
+	humanize "github.com/dustin/go-humanize"
 )
 
 const (
 	minioAdminOpHeader = "X-Minio-Operation"
+
+	// Maximum size of config.json sent to set config API.
+	maxConfigSize = 256 * humanize.KiByte
 )
 
 // Type-safe query params.
@@ -43,6 +51,47 @@ const (
 	mgmtMarker       mgmtQueryKey = "marker"
 	mgmtMaxKey       mgmtQueryKey = "max-key"
 	mgmtDryRun       mgmtQueryKey = "dry-run"
+	mgmtExpiry       mgmtQueryKey = "expiry"
+	mgmtMode         mgmtQueryKey = "mode"
+	mgmtLifetime     mgmtQueryKey = "lifetime"
+	mgmtEnabled      mgmtQueryKey = "enabled"
+	mgmtState        mgmtQueryKey = "state"
+	mgmtVersions     mgmtQueryKey = "versions"
+	mgmtTimeout      mgmtQueryKey = "timeout"
+	mgmtHealID       mgmtQueryKey = "id"
+	mgmtWindow       mgmtQueryKey = "window"
+	mgmtUploadID     mgmtQueryKey = "upload-id"
+	mgmtCiphers      mgmtQueryKey = "ciphers"
+	mgmtMaxParts     mgmtQueryKey = "max-parts"
+	mgmtHeader       mgmtQueryKey = "header"
+	mgmtHonorClient  mgmtQueryKey = "honor-client-id"
+	mgmtCount        mgmtQueryKey = "count"
+	mgmtMaxObjects   mgmtQueryKey = "max-objects"
+	mgmtCIDRs        mgmtQueryKey = "cidrs"
+	mgmtTargetBucket mgmtQueryKey = "target-bucket"
+	mgmtOlderThan    mgmtQueryKey = "older-than"
+	mgmtMaxHeals     mgmtQueryKey = "max-heals"
+	mgmtPrincipal    mgmtQueryKey = "principal"
+	mgmtAction       mgmtQueryKey = "action"
+	mgmtResource     mgmtQueryKey = "resource"
+	mgmtRequired     mgmtQueryKey = "required"
+	mgmtAlgorithms   mgmtQueryKey = "algorithms"
+	mgmtMaxRetries   mgmtQueryKey = "max-retries"
+	mgmtBackoff      mgmtQueryKey = "backoff"
+	mgmtDeadLetter   mgmtQueryKey = "dead-letter"
+	mgmtDepth        mgmtQueryKey = "depth"
+	mgmtMinPartSize  mgmtQueryKey = "min-part-size"
+	mgmtMaxPartSize  mgmtQueryKey = "max-part-size"
+	mgmtTagKey       mgmtQueryKey = "tag-key"
+	mgmtTagValue     mgmtQueryKey = "tag-value"
+	mgmtAccessKey    mgmtQueryKey = "access-key"
+	mgmtMaxKeys      mgmtQueryKey = "max-keys"
+	mgmtThresholdMs  mgmtQueryKey = "threshold-ms"
+	mgmtMaxUploads   mgmtQueryKey = "max-uploads"
+	mgmtReadQuorum   mgmtQueryKey = "read-quorum"
+	mgmtWriteQuorum  mgmtQueryKey = "write-quorum"
+	mgmtTTL          mgmtQueryKey = "ttl"
+	mgmtConfirm      mgmtQueryKey = "confirm"
 )
 
 // ServerVersion - server version
@@ -74,7 +123,7 @@ func (adminAPI adminAPIHandlers) ServiceStatusHandler(w http.ResponseWriter, r *
 
 	// Fetch uptimes from all peers. This may fail to due to lack
 	// of read-quorum availability.
-	uptime, err := getPeerUptimes(globalAdminPeers)
+	uptime, err := getPeerUptimes(r.Context(), globalAdminPeers)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		errorIf(err, "Possibly failed to get uptime from majority of servers.")
@@ -114,7 +163,35 @@ func (adminAPI adminAPIHandlers) ServiceRestartHandler(w http.ResponseWriter, r
 	// Reply to the client before restarting minio server.
 	writeSuccessResponseHeadersOnly(w)
 
-	sendServiceCmd(globalAdminPeers, serviceRestart)
+	// The reply is already sent, the client going away must not
+	// cancel the restart and servers which did not restart can only
+	// be logged.
+	ctx, cancel := context.WithTimeout(context.Background(), adminRPCTimeout)
+	defer cancel()
+	errorIf(sendServiceCmd(ctx, globalAdminPeers, serviceRestart), "Unable to restart all servers.")
+}
+
+// ServiceStopHandler - POST /?service
+// HTTP header x-minio-operation: stop
+// ----------
+// Stops minio server gracefully. In a distributed setup, stops all the
+// servers in the cluster.
+func (adminAPI adminAPIHandlers) ServiceStopHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Reply to the client before stopping minio server.
+	writeSuccessResponseHeadersOnly(w)
+
+	// The reply is already sent, the client going away must not
+	// cancel the stop and servers which did not stop can only be
+	// logged.
+	ctx, cancel := context.WithTimeout(context.Background(), adminRPCTimeout)
+	defer cancel()
+	errorIf(sendServiceCmd(ctx, globalAdminPeers, serviceStop), "Unable to stop all servers.")
 }
 
 // setCredsReq request
@@ -172,22 +249,78 @@ func (adminAPI adminAPIHandlers) ServiceCredentialsHandler(w http.ResponseWriter
 	}
 
 	// Notify all other Minio peers to update credentials
-	updateErrs := updateCredsOnPeers(creds)
+	change := configChangeFromContext(r.Context())
+	updateErrs := updateCredsOnPeers(creds, change)
 	for peer, err := range updateErrs {
 		errorIf(err, "Unable to update credentials on peer %s.", peer)
 	}
 
 	// Update local credentials in memory.
 	serverConfig.SetCredential(creds)
-	if err = serverConfig.Save(); err != nil {
+	if err = globalConfigChangeLog.logApplied(change, serverConfig.Save()); err != nil {
 		writeErrorResponse(w, ErrInternalError, r.URL)
 		return
 	}
+	globalClusterEvents.emit(clusterEventConfigChange, "credentials")
 
 	// At this stage, the operation is successful, return 200 OK
 	w.WriteHeader(http.StatusOK)
 }
 
+// ShutdownTimeout - contains the response of get shutdown timeout API.
+type ShutdownTimeout struct {
+	Timeout time.Duration `json:"timeout"`
+}
+
+// GetShutdownTimeoutHandler - GET /?service
+// HTTP header x-minio-operation: get-shutdown-timeout
+// ----------
+// Get the time a server waits for requests in progress to finish when
+// stopped or restarted.
+func (adminAPI adminAPIHandlers) GetShutdownTimeoutHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(ShutdownTimeout{Timeout: getShutdownTimeout()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal shutdown timeout into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetShutdownTimeoutHandler - POST /?service&timeout=duration
+// HTTP header x-minio-operation: set-shutdown-timeout
+// ----------
+// Set the time every server waits for requests in progress to finish
+// when stopped or restarted. A timeout of 0 stops at once, timeouts
+// beyond 5 minutes are lowered to 5 minutes.
+func (adminAPI adminAPIHandlers) SetShutdownTimeoutHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	timeout, err := time.ParseDuration(r.URL.Query().Get(string(mgmtTimeout)))
+	if err != nil || timeout < 0 {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	if err = setPeerShutdownTimeout(r.Context(), globalAdminPeers, timeout); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set shutdown timeout on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
 // ServerProperties holds some server information such as, version, region
 // uptime, etc..
 type ServerProperties struct {
@@ -239,7 +372,7 @@ func (adminAPI adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *htt
 
 	// Fetch uptimes from all peers. This may fail to due to lack
 	// of read-quorum availability.
-	uptime, err := getPeerUptimes(globalAdminPeers)
+	uptime, err := getPeerUptimes(r.Context(), globalAdminPeers)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		errorIf(err, "Unable to get uptime from majority of servers.")
@@ -280,288 +413,3404 @@ func (adminAPI adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *htt
 	writeSuccessResponseJSON(w, jsonBytes)
 }
 
-// validateLockQueryParams - Validates query params for list/clear locks management APIs.
-func validateLockQueryParams(vars url.Values) (string, string, time.Duration, APIErrorCode) {
-	bucket := vars.Get(string(mgmtBucket))
-	prefix := vars.Get(string(mgmtPrefix))
-	durationStr := vars.Get(string(mgmtLockDuration))
-
-	// N B empty bucket name is invalid
-	if !IsValidBucketName(bucket) {
-		return "", "", time.Duration(0), ErrInvalidBucketName
+// ErasureSetStatusHandler - GET /?info
+// HTTP header x-minio-operation: erasure-set-status
+// ----------
+// Get the health of each erasure set i.e, online drives, parity
+// remaining and quorum availability, as agreed upon by all servers.
+func (adminAPI adminAPIHandlers) ErasureSetStatusHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
 	}
-	// empty prefix is valid.
-	if !IsValidObjectPrefix(prefix) {
-		return "", "", time.Duration(0), ErrInvalidObjectName
+
+	if newObjectLayerFn() == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
 	}
 
-	// If older-than parameter was empty then set it to 0s to list
-	// all locks older than now.
-	if durationStr == "" {
-		durationStr = "0s"
+	// Erasure sets are only applicable to single node XL and
+	// distributed XL setup.
+	if !globalIsXL {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
 	}
-	duration, err := time.ParseDuration(durationStr)
+
+	sets, err := getPeerErasureSetStatus(r.Context(), globalAdminPeers)
 	if err != nil {
-		errorIf(err, "Failed to parse duration passed as query value.")
-		return "", "", time.Duration(0), ErrInvalidDuration
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get erasure set status from majority of servers.")
+		return
 	}
 
-	return bucket, prefix, duration, ErrNone
-}
-
-// ListLocksHandler - GET /?lock&bucket=mybucket&prefix=myprefix&duration=duration
-// - bucket is a mandatory query parameter
-// - prefix and older-than are optional query parameters
-// HTTP header x-minio-operation: list
-// ---------
-// Lists locks held on a given bucket, prefix and duration it was held for.
-func (adminAPI adminAPIHandlers) ListLocksHandler(w http.ResponseWriter, r *http.Request) {
-	adminAPIErr := checkRequestAuthType(r, "", "", "")
-	if adminAPIErr != ErrNone {
-		writeErrorResponse(w, adminAPIErr, r.URL)
+	jsonBytes, err := json.Marshal(sets)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal erasure set status into json.")
 		return
 	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
-	vars := r.URL.Query()
-	bucket, prefix, duration, adminAPIErr := validateLockQueryParams(vars)
+// ThrottleStatusHandler - GET /?info
+// HTTP header x-minio-operation: throttle-status
+// ----------
+// Get the limits currently configured on each server along with
+// their utilization. Servers without any configured limit are not
+// listed.
+func (adminAPI adminAPIHandlers) ThrottleStatusHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponse(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Fetch lock information of locks matching bucket/prefix that
-	// are available for longer than duration.
-	volLocks, err := listPeerLocksInfo(globalAdminPeers, bucket, prefix, duration)
+	nodes, err := getPeerThrottleStatus(r.Context(), globalAdminPeers)
 	if err != nil {
-		writeErrorResponse(w, ErrInternalError, r.URL)
-		errorIf(err, "Failed to fetch lock information from remote nodes.")
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get throttle status from majority of servers.")
 		return
 	}
 
-	// Marshal list of locks as json.
-	jsonBytes, err := json.Marshal(volLocks)
+	jsonBytes, err := json.Marshal(nodes)
 	if err != nil {
 		writeErrorResponse(w, ErrInternalError, r.URL)
-		errorIf(err, "Failed to marshal lock information into json.")
+		errorIf(err, "Failed to marshal throttle status into json.")
 		return
 	}
-
-	// Reply with list of locks held on bucket, matching prefix
-	// held longer than duration supplied, as json.
 	writeSuccessResponseJSON(w, jsonBytes)
 }
 
-// ClearLocksHandler - POST /?lock&bucket=mybucket&prefix=myprefix&duration=duration
-// - bucket is a mandatory query parameter
-// - prefix and older-than are optional query parameters
-// HTTP header x-minio-operation: clear
-// ---------
-// Clear locks held on a given bucket, prefix and duration it was held for.
-func (adminAPI adminAPIHandlers) ClearLocksHandler(w http.ResponseWriter, r *http.Request) {
+// APILatencyHandler - GET /?info
+// HTTP header x-minio-operation: api-latency
+// ----------
+// Get p50, p90, p99 and p999 latencies of each S3 API operation
+// across all servers.
+func (adminAPI adminAPIHandlers) APILatencyHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
 	adminAPIErr := checkRequestAuthType(r, "", "", "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponse(w, adminAPIErr, r.URL)
 		return
 	}
 
-	vars := r.URL.Query()
-	bucket, prefix, duration, adminAPIErr := validateLockQueryParams(vars)
-	if adminAPIErr != ErrNone {
-		writeErrorResponse(w, adminAPIErr, r.URL)
-		return
-	}
-
-	// Fetch lock information of locks matching bucket/prefix that
-	// are held for longer than duration.
-	volLocks, err := listPeerLocksInfo(globalAdminPeers, bucket, prefix, duration)
+	percentiles, err := getPeerAPILatency(r.Context(), globalAdminPeers)
 	if err != nil {
-		writeErrorResponse(w, ErrInternalError, r.URL)
-		errorIf(err, "Failed to fetch lock information from remote nodes.")
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get API latency from majority of servers.")
 		return
 	}
 
-	// Marshal list of locks as json.
-	jsonBytes, err := json.Marshal(volLocks)
+	jsonBytes, err := json.Marshal(percentiles)
 	if err != nil {
 		writeErrorResponse(w, ErrInternalError, r.URL)
-		errorIf(err, "Failed to marshal lock information into json.")
+		errorIf(err, "Failed to marshal API latency into json.")
 		return
 	}
-
-	// Remove lock matching bucket/prefix held longer than duration.
-	for _, volLock := range volLocks {
-		globalNSMutex.ForceUnlock(volLock.Bucket, volLock.Object)
-	}
-
-	// Reply with list of locks cleared, as json.
 	writeSuccessResponseJSON(w, jsonBytes)
 }
 
-// validateHealQueryParams - Validates query params for heal list management API.
-func validateHealQueryParams(vars url.Values) (string, string, string, string, int, APIErrorCode) {
-	bucket := vars.Get(string(mgmtBucket))
-	prefix := vars.Get(string(mgmtPrefix))
-	marker := vars.Get(string(mgmtMarker))
-	delimiter := vars.Get(string(mgmtDelimiter))
-	maxKeyStr := vars.Get(string(mgmtMaxKey))
-
-	// N B empty bucket name is invalid
-	if !IsValidBucketName(bucket) {
-		return "", "", "", "", 0, ErrInvalidBucketName
+// BootDiagnosticsHandler - GET /?info
+// HTTP header x-minio-operation: boot-diagnostics
+// ----------
+// Get time taken by each startup phase of every server, the slowest
+// server in each phase is flagged. Servers still starting up report
+// their remaining phases as pending.
+func (adminAPI adminAPIHandlers) BootDiagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
 	}
 
-	// empty prefix is valid.
-	if !IsValidObjectPrefix(prefix) {
-		return "", "", "", "", 0, ErrInvalidObjectName
+	nodes, err := getPeerBootDiagnostics(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get boot diagnostics from majority of servers.")
+		return
 	}
 
-	// check if maxKey is a valid integer.
-	maxKey, err := strconv.Atoi(maxKeyStr)
+	jsonBytes, err := json.Marshal(nodes)
 	if err != nil {
-		return "", "", "", "", 0, ErrInvalidMaxKeys
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal boot diagnostics into json.")
+		return
 	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
-	// Validate prefix, marker, delimiter and maxKey.
-	apiErr := validateListObjectsArgs(prefix, marker, delimiter, maxKey)
-	if apiErr != ErrNone {
-		return "", "", "", "", 0, apiErr
+// VersionClockMatrixHandler - GET /?info
+// HTTP header x-minio-operation: version-clock
+// ----------
+// Get version, commit, boot time and current time of every server in
+// a single round trip, flagging servers running a different version
+// and servers whose clock is skewed.
+func (adminAPI adminAPIHandlers) VersionClockMatrixHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
 	}
 
-	return bucket, prefix, marker, delimiter, maxKey, ErrNone
-}
-
-// ListObjectsHealHandler - GET /?heal&bucket=mybucket&prefix=myprefix&marker=mymarker&delimiter=&mydelimiter&maxKey=1000
-// - bucket is mandatory query parameter
-// - rest are optional query parameters
-// List upto maxKey objects that need healing in a given bucket matching the given prefix.
-func (adminAPI adminAPIHandlers) ListObjectsHealHandler(w http.ResponseWriter, r *http.Request) {
-	// Get object layer instance.
-	objLayer := newObjectLayerFn()
-	if objLayer == nil {
-		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+	matrix, err := getPeerVersionClockMatrix(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get version and clock from majority of servers.")
 		return
 	}
 
-	// Validate request signature.
-	adminAPIErr := checkRequestAuthType(r, "", "", "")
-	if adminAPIErr != ErrNone {
-		writeErrorResponse(w, adminAPIErr, r.URL)
+	jsonBytes, err := json.Marshal(matrix)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal version and clock matrix into json.")
 		return
 	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
-	// Validate query params.
-	vars := r.URL.Query()
-	bucket, prefix, marker, delimiter, maxKey, adminAPIErr := validateHealQueryParams(vars)
+// DisksByStateHandler - GET /?info&state=state
+// HTTP header x-minio-operation: disks-by-state
+// ----------
+// Get the disks of all servers in the given state, one of online,
+// offline, degraded or foreign.
+func (adminAPI adminAPIHandlers) DisksByStateHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponse(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Get the list objects to be healed.
-	objectInfos, err := objLayer.ListObjectsHeal(bucket, prefix, marker, delimiter, maxKey)
+	disks, err := getPeerDisksByState(r.Context(), globalAdminPeers, r.URL.Query().Get(string(mgmtState)))
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get disks by state from majority of servers.")
 		return
 	}
 
-	listResponse := generateListObjectsV1Response(bucket, prefix, marker, delimiter, maxKey, objectInfos)
-	// Write success response.
-	writeSuccessResponseXML(w, encodeResponse(listResponse))
-}
-
-// ListBucketsHealHandler - GET /?heal
-func (adminAPI adminAPIHandlers) ListBucketsHealHandler(w http.ResponseWriter, r *http.Request) {
-	// Get object layer instance.
-	objLayer := newObjectLayerFn()
-	if objLayer == nil {
-		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+	jsonBytes, err := json.Marshal(disks)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal disks into json.")
 		return
 	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
-	// Validate request signature.
+// ClusterEventsHandler - GET /?info
+// HTTP header x-minio-operation: cluster-events
+// ----------
+// Stream events of cluster interest from all servers, such as servers
+// going down, heal operations and loss of quorum, until the client
+// disconnects. Each event is a JSON object terminated by CRLF.
+func (adminAPI adminAPIHandlers) ClusterEventsHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
 	adminAPIErr := checkRequestAuthType(r, "", "", "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponse(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Get the list buckets to be healed.
-	bucketsInfo, err := objLayer.ListBucketsHeal()
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	eventCh, err := subscribePeerEvents(r.Context(), globalAdminPeers, clusterEventPollInterval, doneCh)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to subscribe to events of majority of servers.")
 		return
 	}
 
-	listResponse := generateListBucketsResponse(bucketsInfo)
-	// Write success response.
-	writeSuccessResponseXML(w, encodeResponse(listResponse))
-}
+	// Add all common headers.
+	setCommonHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	w.(http.Flusher).Flush()
 
-// HealBucketHandler - POST /?heal&bucket=mybucket&dry-run
-// - x-minio-operation = bucket
-// - bucket is mandatory query parameter
-// Heal a given bucket, if present.
-func (adminAPI adminAPIHandlers) HealBucketHandler(w http.ResponseWriter, r *http.Request) {
-	// Get object layer instance.
-	objLayer := newObjectLayerFn()
-	if objLayer == nil {
-		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
-		return
+	closeCh := w.(http.CloseNotifier).CloseNotify()
+	for {
+		select {
+		case event := <-eventCh:
+			eventBytes, err := json.Marshal(event)
+			if err != nil {
+				errorIf(err, "Failed to marshal cluster event into json.")
+				return
+			}
+			if _, err = w.Write(append(eventBytes, crlf...)); err != nil {
+				errorIf(err, "Unable to write cluster event to client.")
+				return
+			}
+			w.(http.Flusher).Flush()
+		case <-closeCh:
+			return
+		}
 	}
+}
 
-	// Validate request signature.
+// WaitForQuorumHandler - GET /?info&mode=read|write&timeout=duration
+// HTTP header x-minio-operation: wait-quorum
+// ----------
+// Block until every erasure set has read or write quorum, or until
+// timeout elapses. Returns right away if quorum is already there.
+func (adminAPI adminAPIHandlers) WaitForQuorumHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
 	adminAPIErr := checkRequestAuthType(r, "", "", "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponse(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Validate bucket name and check if it exists.
 	vars := r.URL.Query()
-	bucket := vars.Get(string(mgmtBucket))
-	if err := checkBucketExist(bucket, objLayer); err != nil {
+	timeout, err := time.ParseDuration(vars.Get(string(mgmtTimeout)))
+	if err != nil || timeout <= 0 {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	err = waitForPeerQuorum(ctx, globalAdminPeers, vars.Get(string(mgmtMode)), quorumPollInterval)
+	switch err {
+	case nil:
+		writeSuccessResponseHeadersOnly(w)
+	case context.DeadlineExceeded, context.Canceled:
+		writeErrorResponse(w, ErrAdminQuorumTimeout, r.URL)
+	default:
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+	}
+}
+
+// RPCConnStatsHandler - GET /?info
+// HTTP header x-minio-operation: rpc-conn-stats
+// ----------
+// Get usage of the inter-node RPC connections of all servers, calls
+// in flight and time spent waiting for connections, per remote server.
+func (adminAPI adminAPIHandlers) RPCConnStatsHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	nodes, err := getPeerRPCConnStats(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get RPC connection stats from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(nodes)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal RPC connection stats into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ActiveMultipartBytesHandler - GET /?info
+// HTTP header x-minio-operation: multipart-bytes
+// ----------
+// Get the disk space held by in-progress multipart uploads on every
+// server and in total. Completed and aborted uploads release it.
+func (adminAPI adminAPIHandlers) ActiveMultipartBytesHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	usage, err := getPeerActiveMultipartBytes(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get multipart bytes from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(usage)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal multipart bytes into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// WriteAmplificationHandler - GET /?info
+// HTTP header x-minio-operation: write-amplification
+// ----------
+// Get the bytes written to disk per byte of object data received from
+// clients over the last 15 minutes, on every server and in total.
+// Servers which received no object data report noData instead of a
+// ratio.
+func (adminAPI adminAPIHandlers) WriteAmplificationHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	writeAmp, err := getPeerWriteAmplification(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get write amplification from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(writeAmp)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal write amplification into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ClusterChecksumHandler - GET /?info&bucket=bucket
+// HTTP header x-minio-operation: cluster-checksum
+// ----------
+// Get the checksum of the metadata of all objects of a bucket as seen
+// by every server. Differing checksums mean servers accepted different
+// writes, for instance while the cluster was partitioned.
+func (adminAPI adminAPIHandlers) ClusterChecksumHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	checksum, err := getPeerClusterChecksum(r.Context(), globalAdminPeers, bucket)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get checksum of bucket %s from majority of servers.", bucket)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(checksum)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal cluster checksum into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// RPCErrorBreakdownHandler - GET /?info
+// HTTP header x-minio-operation: rpc-errors
+// ----------
+// Get the errors of admin RPC calls to every server over the last 15
+// minutes by category: timeouts, refused connections, authentication
+// failures and errors returned by the server. Servers mostly failing
+// to authenticate are flagged, their credentials likely differ.
+func (adminAPI adminAPIHandlers) RPCErrorBreakdownHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	breakdown, err := getPeerRPCErrorBreakdown(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get RPC errors from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(breakdown)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal RPC errors into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ScannerImpactHandler - GET /?info
+// HTTP header x-minio-operation: scanner-impact
+// ----------
+// Get the latency of client requests served while heal jobs, usage
+// scans or object recounts were running compared to requests served
+// while idle, per server and for the whole cluster. Estimates out of
+// too few requests in either state are flagged low-confidence.
+func (adminAPI adminAPIHandlers) ScannerImpactHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	impact, err := getPeerScannerImpact(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get scanner impact from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(impact)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal scanner impact into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ClusterSummaryHandler - GET /?info
+// HTTP header x-minio-operation: cluster-summary
+// ----------
+// Get in one call the node count, disk space, object count, running
+// heal jobs, age of the oldest lock and server versions of the whole
+// cluster. Fields depending on servers which could not be reached are
+// listed as partial.
+func (adminAPI adminAPIHandlers) ClusterSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	summary, err := getPeerClusterSummary(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get summary from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(summary)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal cluster summary into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// RebalanceSkewHandler - GET /?info
+// HTTP header x-minio-operation: rebalance-skew
+// ----------
+// Get the spread of disk fill percentages of every erasure set, and
+// whether the set is uneven enough for rebalancing to be worth it.
+func (adminAPI adminAPIHandlers) RebalanceSkewHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Erasure sets are only applicable to single node XL and
+	// distributed XL setup.
+	if !globalIsXL {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	reports, err := getPeerRebalanceSkew(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get disk usage from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(reports)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal rebalance skew into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// StartupErrorsHandler - GET /?info
+// HTTP header x-minio-operation: startup-errors
+// ----------
+// Get the errors every server started in spite of, such as disks which
+// were offline or slow to come online. Errors are recorded once at
+// startup, a clean startup has none.
+func (adminAPI adminAPIHandlers) StartupErrorsHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	startupErrors, err := getPeerStartupErrors(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get startup errors from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(startupErrors)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal startup errors into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// MemSummaryHandler - GET /?info
+// HTTP header x-minio-operation: mem-summary
+// ----------
+// Get the heap usage, latest GC pauses and the allocation sites using
+// the most memory of every server, a quick check without downloading
+// and analyzing a full heap profile.
+func (adminAPI adminAPIHandlers) MemSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	memStats, err := getPeerMemSummary(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get memory summary from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(memStats)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal memory summary into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// VersionSkewReportHandler - GET /?info
+// HTTP header x-minio-operation: version-skew
+// ----------
+// Get the versions run by the servers and, during a rolling upgrade,
+// the order in which to upgrade the remaining servers so that every
+// erasure set keeps write quorum.
+func (adminAPI adminAPIHandlers) VersionSkewReportHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	report, err := getPeerVersionSkewReport(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get version skew from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal version skew report into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// CapacityForecastHandler - GET /?info&window=duration
+// HTTP header x-minio-operation: capacity-forecast
+// ----------
+// Get the days left until the cluster and every server are full, at the
+// growth of the bucket usage measured by the usage scans started within
+// the given window. Buckets need two complete scans to measure growth.
+func (adminAPI adminAPIHandlers) CapacityForecastHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	window, err := time.ParseDuration(r.URL.Query().Get(string(mgmtWindow)))
+	if err != nil || window <= 0 {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	forecast, err := getPeerCapacityForecast(r.Context(), globalAdminPeers, window)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get capacity forecast from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(forecast)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal capacity forecast into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ObjectDistributionHandler - GET /?info&bucket=bucket
+// HTTP header x-minio-operation: object-distribution
+// ----------
+// Get the number and size of the objects of a bucket stored on every
+// erasure set. A set holding far more or far fewer objects than the
+// others points at a problem with how objects are placed on sets.
+func (adminAPI adminAPIHandlers) ObjectDistributionHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Erasure sets are only applicable to single node XL and
+	// distributed XL setup.
+	if !globalIsXL {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	distribution, err := getPeerObjectDistribution(r.Context(), globalAdminPeers, bucket)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get object distribution from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(distribution)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal object distribution into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// RejectReasonsHandler - GET /?info&window=duration
+// HTTP header x-minio-operation: reject-reasons
+// ----------
+// Get the number of requests all servers rejected within the given
+// window, of at most an hour, by reason: quota-exceeded, auth-failed,
+// not-found, quorum-unavailable or other. Mostly auth-failed and
+// not-found rejections point at clients, quorum-unavailable at servers.
+func (adminAPI adminAPIHandlers) RejectReasonsHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	window, err := time.ParseDuration(r.URL.Query().Get(string(mgmtWindow)))
+	if err != nil || window <= 0 || window > rejectReasonMaxWindow {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	reasons, err := getPeerRejectReasons(r.Context(), globalAdminPeers, window)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get rejected requests from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(reasons)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal rejected requests into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SlowRequestLogHandler - GET /?info&threshold-ms=number&count=number
+// HTTP header x-minio-operation: slow-requests
+// ----------
+// Get the last requests served by all servers which took at least the
+// given threshold, of 100ms or more, latest first. Every request comes
+// with the server which served it and the time spent authenticating,
+// waiting on locks and accessing disks.
+func (adminAPI adminAPIHandlers) SlowRequestLogHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	thresholdMs, err := strconv.Atoi(vars.Get(string(mgmtThresholdMs)))
+	if err != nil || time.Duration(thresholdMs)*time.Millisecond < slowRequestMinThreshold {
+		writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+		return
+	}
+	count, err := strconv.Atoi(vars.Get(string(mgmtCount)))
+	if err != nil || count <= 0 || count > slowRequestLogSize {
+		writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+		return
+	}
+
+	requests, err := getPeerSlowRequestLog(r.Context(), globalAdminPeers, thresholdMs, count)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get slow requests from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(requests)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal slow requests into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// DiskIOStatsHandler - GET /?info
+// HTTP header x-minio-operation: disk-io
+// ----------
+// Get the IOPS, throughput, queue depth and IO wait of the disks of all
+// servers, sampled over a couple of seconds. Disks whose queue stayed
+// deep during all samples are flagged as contended. Figures are left
+// out for disks without IO counters, e.g. on other platforms than
+// Linux.
+func (adminAPI adminAPIHandlers) DiskIOStatsHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	disks, err := getPeerDiskIOStats(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get disk IO stats from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(disks)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal disk IO stats into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ClientConnectionsHandler - GET /?info
+// HTTP header x-minio-operation: client-connections
+// ----------
+// Get the connections, requests in progress and bytes transferred of
+// every client, grouped by client IP and added up over all servers.
+// Clients behind trusted proxies are told apart by their forwarded IP.
+func (adminAPI adminAPIHandlers) ClientConnectionsHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	clients, err := getPeerClientConnections(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get client connections from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(clients)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal client connections into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// LastAccessReportHandler - GET /?info&bucket=name&prefix=prefix&older-than=duration
+// HTTP header x-minio-operation: last-access
+// ----------
+// Get the objects of a bucket under a prefix which were not read on any
+// server for longer than the given duration, with their size and last
+// read. Reads are only remembered since the servers started, objects
+// not read since report their creation time instead.
+func (adminAPI adminAPIHandlers) LastAccessReportHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	olderThan, err := time.ParseDuration(vars.Get(string(mgmtOlderThan)))
+	if err != nil || olderThan < 0 {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	coldObjects, err := getPeerLastAccessReport(r.Context(), globalAdminPeers, bucket, vars.Get(string(mgmtPrefix)), olderThan)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get last access times from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(coldObjects)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal last access report into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// DecodeFailureLogHandler - GET /?info&count=number
+// HTTP header x-minio-operation: decode-failures
+// ----------
+// Get the last reads of erasure coded objects which failed on any
+// server as too many shards were missing or corrupt, latest first.
+// Failures of objects healed since are marked resolved, the others
+// point at data which may be lost.
+func (adminAPI adminAPIHandlers) DecodeFailureLogHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	count, err := strconv.Atoi(r.URL.Query().Get(string(mgmtCount)))
+	if err != nil || count <= 0 || count > decodeFailureLogSize {
+		writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+		return
+	}
+
+	failures, err := getPeerDecodeFailureLog(r.Context(), globalAdminPeers, count)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get decode failures from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(failures)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal decode failures into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ShardDiagnosisHandler - GET /?info&bucket=bucket&object=object
+// HTTP header x-minio-operation: shard-diagnosis
+// ----------
+// Get the disk and health of every shard of an object, read by the
+// servers owning the disks, and a verdict: healthy objects need no
+// action, degraded ones a heal reconstructing the shards listed, and
+// unrecoverable ones have too few healthy shards left to heal.
+func (adminAPI adminAPIHandlers) ShardDiagnosisHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Shards are only applicable to single node XL and
+	// distributed XL setup.
+	if !globalIsXL {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	object := vars.Get(string(mgmtObject))
+	if err := checkBucketAndObjectNames(bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	diag, err := getPeerShardDiagnosis(r.Context(), globalAdminPeers, bucket, object)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to diagnose shards of %s/%s.", bucket, object)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(diag)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal shard diagnosis into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// UnusedCredentialsHandler - GET /?info&older-than=duration
+// HTTP header x-minio-operation: unused-credentials
+// ----------
+// Get the access keys which did not authenticate a client request on
+// any server for longer than the given duration, and those which were
+// never used since the servers started.
+func (adminAPI adminAPIHandlers) UnusedCredentialsHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	olderThan, err := time.ParseDuration(r.URL.Query().Get(string(mgmtOlderThan)))
+	if err != nil || olderThan < 0 {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	unused, err := getPeerUnusedCredentials(r.Context(), globalAdminPeers, olderThan)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get credential usage from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(unused)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal unused credentials into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// LockHoldHistogramHandler - GET /?lock
+// HTTP header x-minio-operation: hold-histogram
+// ----------
+// Get percentiles of how long namespace locks were held until released
+// across all servers, and separately of how long locks still held have
+// been held so far.
+func (adminAPI adminAPIHandlers) LockHoldHistogramHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	histograms, err := getPeerLockHoldHistogram(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get lock hold histogram from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(LockHoldPercentiles{
+		Completed: newLatencyPercentiles(histograms.Completed),
+		Held:      newLatencyPercentiles(histograms.Held),
+	})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal lock hold percentiles into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ListUploadPartsHandler - GET /?info&bucket=bucket&object=object&upload-id=id
+// HTTP header x-minio-operation: upload-parts
+// ----------
+// Get number, size, ETag and upload time of all parts of a multipart
+// upload, merged from all servers and ordered by part number.
+func (adminAPI adminAPIHandlers) ListUploadPartsHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	object := vars.Get(string(mgmtObject))
+	if err := checkBucketAndObjectNames(bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	parts, err := listPeerUploadParts(r.Context(), globalAdminPeers, bucket, object, vars.Get(string(mgmtUploadID)))
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(parts)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal upload parts into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// BackendHandler - GET /?info
+// HTTP header x-minio-operation: backend
+// ----------
+// Get the backend, FS or Erasure, and the features it offers. Reports
+// every server's backend and flags servers that disagree, which is a
+// severe misconfiguration.
+func (adminAPI adminAPIHandlers) BackendHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	backend, err := getPeerBackend(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Unable to get backend from majority of servers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(backend)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal backend into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// UsageScan - contains the response of recalculate usage API
+type UsageScan struct {
+	ID string `json:"id"`
+}
+
+// RecalculateUsageHandler - POST /?info&bucket=bucket
+// HTTP header x-minio-operation: recalculate-usage
+// ----------
+// Count the objects of a bucket and their size in the background,
+// spread among all servers. Returns the ID of the scan to poll with
+// scan-progress, the ID of the running scan if the bucket is already
+// being scanned.
+func (adminAPI adminAPIHandlers) RecalculateUsageHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	id, err := recalculatePeerUsage(r.Context(), globalAdminPeers, bucket)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to start usage scan of bucket %s on peers.", bucket)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(UsageScan{ID: id})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal usage scan into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ScanProgressHandler - GET /?info&id=scan-id
+// HTTP header x-minio-operation: scan-progress
+// ----------
+// Get the progress of a usage scan summed over all servers, the
+// counts are final once done.
+func (adminAPI adminAPIHandlers) ScanProgressHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	status, err := getPeerScanProgress(r.Context(), globalAdminPeers, r.URL.Query().Get(string(mgmtHealID)))
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal usage scan status into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// validateLockQueryParams - Validates query params for list/clear locks management APIs.
+func validateLockQueryParams(vars url.Values) (string, string, time.Duration, APIErrorCode) {
+	bucket := vars.Get(string(mgmtBucket))
+	prefix := vars.Get(string(mgmtPrefix))
+	durationStr := vars.Get(string(mgmtLockDuration))
+
+	// N B empty bucket name is invalid
+	if !IsValidBucketName(bucket) {
+		return "", "", time.Duration(0), ErrInvalidBucketName
+	}
+	// empty prefix is valid.
+	if !IsValidObjectPrefix(prefix) {
+		return "", "", time.Duration(0), ErrInvalidObjectName
+	}
+
+	// If older-than parameter was empty then set it to 0s to list
+	// all locks older than now.
+	if durationStr == "" {
+		durationStr = "0s"
+	}
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		errorIf(err, "Failed to parse duration passed as query value.")
+		return "", "", time.Duration(0), ErrInvalidDuration
+	}
+
+	return bucket, prefix, duration, ErrNone
+}
+
+// ListLocksHandler - GET /?lock&bucket=mybucket&prefix=myprefix&duration=duration
+// - bucket is a mandatory query parameter
+// - prefix and older-than are optional query parameters
+// HTTP header x-minio-operation: list
+// ---------
+// Lists locks held on a given bucket, prefix and duration it was held for.
+func (adminAPI adminAPIHandlers) ListLocksHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket, prefix, duration, adminAPIErr := validateLockQueryParams(vars)
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Fetch lock information of locks matching bucket/prefix that
+	// are available for longer than duration.
+	volLocks, err := listPeerLocksInfo(r.Context(), globalAdminPeers, bucket, prefix, duration)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to fetch lock information from remote nodes.")
+		return
+	}
+
+	// Marshal list of locks as json.
+	jsonBytes, err := json.Marshal(volLocks)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal lock information into json.")
+		return
+	}
+
+	// Reply with list of locks held on bucket, matching prefix
+	// held longer than duration supplied, as json.
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ClearLocksHandler - POST /?lock&bucket=mybucket&prefix=myprefix&duration=duration
+// - bucket is a mandatory query parameter
+// - prefix and older-than are optional query parameters
+// HTTP header x-minio-operation: clear
+// ---------
+// Clear locks held on a given bucket, prefix and duration it was held for.
+// Every lock is attempted, the request fails if any could not be cleared
+// from all servers.
+func (adminAPI adminAPIHandlers) ClearLocksHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket, prefix, duration, adminAPIErr := validateLockQueryParams(vars)
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Fetch lock information of locks matching bucket/prefix that
+	// are held for longer than duration.
+	volLocks, err := listPeerLocksInfo(r.Context(), globalAdminPeers, bucket, prefix, duration)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to fetch lock information from remote nodes.")
+		return
+	}
+
+	// Marshal list of locks as json.
+	jsonBytes, err := json.Marshal(volLocks)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal lock information into json.")
+		return
+	}
+
+	// Remove lock matching bucket/prefix held longer than duration
+	// from all servers, once per lock held on several servers. Every
+	// lock is attempted even if some fail.
+	unlocked := make(map[nsParam]bool)
+	var unlockErr error
+	for _, volLock := range volLocks {
+		param := nsParam{volLock.Bucket, volLock.Object}
+		if unlocked[param] {
+			continue
+		}
+		unlocked[param] = true
+		if err = forceUnlockPeers(r.Context(), globalAdminPeers, volLock.Bucket, volLock.Object); err != nil {
+			errorIf(err, "Unable to clear lock on %s.", pathJoin(volLock.Bucket, volLock.Object))
+			unlockErr = err
+		}
+	}
+	if unlockErr != nil {
+		writeErrorResponse(w, toAPIErrorCode(unlockErr), r.URL)
+		return
+	}
+
+	// Reply with list of locks cleared, as json.
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// DumpLockGraphHandler - GET /?lock
+// HTTP header x-minio-operation: graph
+// ---------
+// Builds the wait-for graph of operations holding or blocked on
+// locks across all servers, and reports the deadlock cycles in it.
+func (adminAPI adminAPIHandlers) DumpLockGraphHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	graph, err := getPeerLockGraph(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to fetch lock information from remote nodes.")
+		return
+	}
+
+	// Marshal lock graph as json.
+	jsonBytes, err := json.Marshal(graph)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal lock graph into json.")
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// LockOriginBreakdownHandler - GET /?lock
+// HTTP header x-minio-operation: origin-breakdown
+// ---------
+// Counts the locks held or waited upon across all servers per server,
+// operation and lock type, busiest first, to find the clients or
+// servers flooding locks.
+func (adminAPI adminAPIHandlers) LockOriginBreakdownHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	origins, err := getPeerLockOriginBreakdown(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to fetch lock information from remote nodes.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(origins)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal lock origins into json.")
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// validateHealQueryParams - Validates query params for heal list management API.
+func validateHealQueryParams(vars url.Values) (string, string, string, string, int, APIErrorCode) {
+	bucket := vars.Get(string(mgmtBucket))
+	prefix := vars.Get(string(mgmtPrefix))
+	marker := vars.Get(string(mgmtMarker))
+	delimiter := vars.Get(string(mgmtDelimiter))
+	maxKeyStr := vars.Get(string(mgmtMaxKey))
+
+	// N B empty bucket name is invalid
+	if !IsValidBucketName(bucket) {
+		return "", "", "", "", 0, ErrInvalidBucketName
+	}
+
+	// empty prefix is valid.
+	if !IsValidObjectPrefix(prefix) {
+		return "", "", "", "", 0, ErrInvalidObjectName
+	}
+
+	// check if maxKey is a valid integer.
+	maxKey, err := strconv.Atoi(maxKeyStr)
+	if err != nil {
+		return "", "", "", "", 0, ErrInvalidMaxKeys
+	}
+
+	// Validate prefix, marker, delimiter and maxKey.
+	apiErr := validateListObjectsArgs(prefix, marker, delimiter, maxKey)
+	if apiErr != ErrNone {
+		return "", "", "", "", 0, apiErr
+	}
+
+	return bucket, prefix, marker, delimiter, maxKey, ErrNone
+}
+
+// ListObjectsHealHandler - GET /?heal&bucket=mybucket&prefix=myprefix&marker=mymarker&delimiter=&mydelimiter&maxKey=1000
+// - bucket is mandatory query parameter
+// - rest are optional query parameters
+// List upto maxKey objects that need healing in a given bucket matching the given prefix.
+func (adminAPI adminAPIHandlers) ListObjectsHealHandler(w http.ResponseWriter, r *http.Request) {
+	// Get object layer instance.
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Validate query params.
+	vars := r.URL.Query()
+	bucket, prefix, marker, delimiter, maxKey, adminAPIErr := validateHealQueryParams(vars)
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Get the list objects to be healed.
+	objectInfos, err := objLayer.ListObjectsHeal(bucket, prefix, marker, delimiter, maxKey)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	listResponse := generateListObjectsV1Response(bucket, prefix, marker, delimiter, maxKey, objectInfos)
+	// Write success response.
+	writeSuccessResponseXML(w, encodeResponse(listResponse))
+}
+
+// ListBucketsHealHandler - GET /?heal
+func (adminAPI adminAPIHandlers) ListBucketsHealHandler(w http.ResponseWriter, r *http.Request) {
+	// Get object layer instance.
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Get the list buckets to be healed.
+	bucketsInfo, err := objLayer.ListBucketsHeal()
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	listResponse := generateListBucketsResponse(bucketsInfo)
+	// Write success response.
+	writeSuccessResponseXML(w, encodeResponse(listResponse))
+}
+
+// HealBucketHandler - POST /?heal&bucket=mybucket&dry-run
+// - x-minio-operation = bucket
+// - bucket is mandatory query parameter
+// Heal a given bucket, if present.
+func (adminAPI adminAPIHandlers) HealBucketHandler(w http.ResponseWriter, r *http.Request) {
+	// Get object layer instance.
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Validate bucket name and check if it exists.
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// if dry-run is present in query-params, then only perform validations and return success.
+	if isDryRun(vars) {
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	// Heal the given bucket.
+	globalClusterEvents.emit(clusterEventHealStart, bucket)
+	err := objLayer.HealBucket(bucket)
+	globalClusterEvents.emit(clusterEventHealEnd, bucket)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Return 200 on success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// isDryRun - returns true if dry-run query param was set and false otherwise.
+// otherwise.
+func isDryRun(qval url.Values) bool {
+	if _, dryRun := qval[string(mgmtDryRun)]; dryRun {
+		return true
+	}
+	return false
+}
+
+// HealObjectHandler - POST /?heal&bucket=mybucket&object=myobject&dry-run
+// - x-minio-operation = object
+// - bucket and object are both mandatory query parameters
+// Heal a given object, if present.
+func (adminAPI adminAPIHandlers) HealObjectHandler(w http.ResponseWriter, r *http.Request) {
+	// Get object layer instance.
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	object := vars.Get(string(mgmtObject))
+
+	// Validate bucket and object names.
+	if err := checkBucketAndObjectNames(bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Check if object exists.
+	if _, err := objLayer.GetObjectInfo(bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// if dry-run is set in query params then perform validations
+	// and return success.
+	if isDryRun(vars) {
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	globalClusterEvents.emit(clusterEventHealStart, pathJoin(bucket, object))
+	err := objLayer.HealObject(bucket, object)
+	globalClusterEvents.emit(clusterEventHealEnd, pathJoin(bucket, object))
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Return 200 on success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// HealFormatHandler - POST /?heal&dry-run
+// - x-minio-operation = format
+// - bucket and object are both mandatory query parameters
+// Heal a given object, if present.
+func (adminAPI adminAPIHandlers) HealFormatHandler(w http.ResponseWriter, r *http.Request) {
+	// Get current object layer instance.
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Check if this setup is an erasure code backend, since
+	// heal-format is only applicable to single node XL and
+	// distributed XL setup.
+	if !globalIsXL {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	// if dry-run is set in query-params, return success as
+	// validations are successful so far.
+	vars := r.URL.Query()
+	if isDryRun(vars) {
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	// Create a new set of storage instances to heal format.json.
+	bootstrapDisks, err := initStorageDisks(globalEndpoints)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Heal format.json on available storage.
+	globalClusterEvents.emit(clusterEventHealStart, formatConfigFile)
+	err = healFormatXL(bootstrapDisks)
+	globalClusterEvents.emit(clusterEventHealEnd, formatConfigFile)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Instantiate new object layer with newly formatted storage.
+	newObjectAPI, err := newXLObjects(bootstrapDisks)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Set object layer with newly formatted storage to globalObjectAPI.
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = newObjectAPI
+	globalObjLayerMutex.Unlock()
+
+	// Shutdown storage belonging to old object layer instance.
+	objectAPI.Shutdown()
+
+	// Inform peers to reinitialize storage with newly formatted storage.
+	if err = reInitPeerDisks(r.Context(), globalAdminPeers); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to reinitialize disks on peers.")
+		return
+	}
+
+	// Return 200 on success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// HealDeletePolicy - contains the response of get heal delete policy API
+type HealDeletePolicy struct {
+	Mode string `json:"mode"`
+}
+
+// GetHealDeletePolicyHandler - GET /?heal
+// - x-minio-operation = get-delete-policy
+// Get the mode of dealing with orphaned objects found during heal.
+func (adminAPI adminAPIHandlers) GetHealDeletePolicyHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(HealDeletePolicy{Mode: globalHealDeletePolicy.get()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal heal delete policy into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetHealDeletePolicyHandler - POST /?heal&mode=report-only|delete
+// - x-minio-operation = set-delete-policy
+// Set the mode of dealing with orphaned objects found during heal on
+// all servers. In report-only mode orphaned objects are left in
+// place, in delete mode they are removed.
+func (adminAPI adminAPIHandlers) SetHealDeletePolicyHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	mode := r.URL.Query().Get(string(mgmtMode))
+	if mode != healDeleteReportOnly && mode != healDeleteRemove {
+		writeErrorResponse(w, ErrAdminInvalidHealDeleteMode, r.URL)
+		return
+	}
+
+	if err := setPeerHealDeletePolicy(r.Context(), globalAdminPeers, mode); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set heal delete policy on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// MaxConcurrentHeals - contains the response of get max concurrent
+// heals API.
+type MaxConcurrentHeals struct {
+	Max int `json:"max"`
+}
+
+// GetMaxConcurrentHealsHandler - GET /?heal
+// - x-minio-operation = get-max-concurrent
+// Get the cap of object heals running at once on a server, 0 means
+// no cap.
+func (adminAPI adminAPIHandlers) GetMaxConcurrentHealsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(MaxConcurrentHeals{Max: globalHealLimiter.get()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal max concurrent heals into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetMaxConcurrentHealsHandler - POST /?heal&max-heals=number
+// - x-minio-operation = set-max-concurrent
+// Set the cap of object heals running at once on every server, 0
+// removes the cap. Heals running beyond a lowered cap are left to
+// finish, new heals wait for a free slot.
+func (adminAPI adminAPIHandlers) SetMaxConcurrentHealsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	max, err := strconv.Atoi(r.URL.Query().Get(string(mgmtMaxHeals)))
+	if err != nil || max < 0 {
+		writeErrorResponse(w, ErrAdminInvalidMaxConcurrentHeals, r.URL)
+		return
+	}
+
+	if err = setPeerMaxConcurrentHeals(r.Context(), globalAdminPeers, max); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set max concurrent heals on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// HealJob - contains the response of force heal bucket API
+type HealJob struct {
+	ID string `json:"id"`
+}
+
+// ForceHealBucketHandler - POST /?heal&bucket=mybucket
+// - x-minio-operation = force-bucket
+// - bucket is mandatory query parameter
+// Heal all objects of a bucket in the background, spread among all
+// servers. Returns the ID of the heal job to poll with heal-status,
+// the ID of the running job if the bucket is already being healed.
+func (adminAPI adminAPIHandlers) ForceHealBucketHandler(w http.ResponseWriter, r *http.Request) {
+	// Get object layer instance.
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Healing is only applicable to erasure coded setups.
+	if !globalIsXL {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	// Validate bucket name and check if it exists.
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	id, err := forceHealPeerBucket(r.Context(), globalAdminPeers, bucket)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to start healing bucket %s on peers.", bucket)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(HealJob{ID: id})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal heal job into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// HealStatusHandler - GET /?heal&id=job-id
+// - x-minio-operation = status
+// - id is mandatory query parameter
+// Get the progress of a heal job summed over all servers.
+func (adminAPI adminAPIHandlers) HealStatusHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	status, err := getPeerHealJob(r.Context(), globalAdminPeers, r.URL.Query().Get(string(mgmtHealID)))
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal heal job status into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// HealHistoryHandler - GET /?heal&count=number
+// - x-minio-operation = history
+// - count is an optional query parameter, at most 100
+// Get the last heal jobs completed, newest first, merged over all
+// servers. History is lost when servers restart.
+func (adminAPI adminAPIHandlers) HealHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	n := maxHealHistory
+	if value := r.URL.Query().Get(string(mgmtCount)); value != "" {
+		var err error
+		if n, err = strconv.Atoi(value); err != nil || n <= 0 || n > maxHealHistory {
+			writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+			return
+		}
+	}
+
+	history, err := getPeerHealHistory(r.Context(), globalAdminPeers, n)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to fetch heal history from peers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(history)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal heal history into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// GetConfigHandler - GET /?config
+// - x-minio-operation = get
+// Get config.json of this minio setup.
+func (adminAPI adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// check if objectLayer is initialized, if not return.
+	if newObjectLayerFn() == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Get config.json from all nodes. In a single node setup, it
+	// returns local config.json.
+	configBytes, err := getPeerConfig(r.Context(), globalAdminPeers)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to get config from peers")
+		return
+	}
+
+	writeSuccessResponseJSON(w, configBytes)
+}
+
+// SetConfigResult - contains the response of set config API, the
+// errors of the servers which did not save config.json.
+type SetConfigResult struct {
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// SetConfigHandler - POST /?config
+// - x-minio-operation = set
+// Set config.json of all servers, sent in the request body. Fails,
+// rolling servers back to their previous config, unless a majority of
+// servers saved it. Otherwise replies with the errors of the servers
+// which did not save it. Credentials can not be changed, loggers and
+// notification targets are set up again on restart only.
+func (adminAPI adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// check if objectLayer is initialized, if not return.
+	if newObjectLayerFn() == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// If Content-Length is unknown or zero, deny the request.
+	if r.ContentLength == -1 || r.ContentLength == 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+	// If Content-Length is greater than maximum allowed config size.
+	if r.ContentLength > maxConfigSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	configBytes, err := ioutil.ReadAll(io.LimitReader(r.Body, maxConfigSize))
+	if err != nil {
+		writeErrorResponse(w, ErrAdminInvalidConfig, r.URL)
+		return
+	}
+
+	var result SetConfigResult
+	err = setPeerConfig(r.Context(), globalAdminPeers, configBytes)
+	if peerErrs, ok := err.(PeerErrors); ok {
+		// Saved by a majority of servers, the others are
+		// already logged.
+		result.Errors = make(map[string]string, len(peerErrs))
+		for addr, peerErr := range peerErrs {
+			result.Errors[addr] = peerErr.Error()
+		}
+	} else if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set config on peers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal set config result into json.")
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// PresignExpiry - contains the response of get presign expiry API
+type PresignExpiry struct {
+	Expiry time.Duration `json:"expiry"`
+}
+
+// GetPresignExpiryHandler - GET /?config
+// - x-minio-operation = get-presign-expiry
+// Get the maximum expiry accepted for presigned URLs.
+func (adminAPI adminAPIHandlers) GetPresignExpiryHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(PresignExpiry{Expiry: getMaxPresignExpiry()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal presign expiry into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetPresignExpiryHandler - POST /?config&expiry=duration
+// - x-minio-operation = set-presign-expiry
+// Set the maximum expiry accepted for presigned URLs on all servers.
+// Presigned URLs issued earlier with a longer expiry are rejected
+// once the new limit is in effect.
+func (adminAPI adminAPIHandlers) SetPresignExpiryHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	expiry, err := time.ParseDuration(r.URL.Query().Get(string(mgmtExpiry)))
+	if err != nil || expiry <= 0 || expiry > maxPresignExpiry {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	if err = writeMaxPresignExpiry(objLayer, expiry); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err = setPeerMaxPresignExpiry(r.Context(), globalAdminPeers, expiry); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set presign expiry on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetKeyNamingPolicyHandler - GET /?config
+// - x-minio-operation = get-key-naming-policy
+// Get the naming policy applied to new objects.
+func (adminAPI adminAPIHandlers) GetKeyNamingPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalKeyNamingPolicy.get())
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal object naming policy into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetKeyNamingPolicyHandler - POST /?config
+// - x-minio-operation = set-key-naming-policy
+// Set the naming policy applied to new objects on all servers, the
+// policy is sent as json in the request body. Existing objects stay
+// accessible whatever their name.
+func (adminAPI adminAPIHandlers) SetKeyNamingPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	var policy KeyPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil || !policy.isValid() {
+		writeErrorResponse(w, ErrAdminInvalidKeyPolicy, r.URL)
+		return
+	}
+
+	if err := writeKeyNamingPolicy(objLayer, policy); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := setPeerKeyNamingPolicy(r.Context(), globalAdminPeers, policy); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set object naming policy on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// MultipartLifetime - contains the response of get multipart lifetime API
+type MultipartLifetime struct {
+	Lifetime time.Duration `json:"lifetime"`
+}
+
+// GetMultipartLifetimeHandler - GET /?config
+// - x-minio-operation = get-multipart-lifetime
+// Get the time after which idle multipart uploads are aborted, zero
+// if they are never aborted.
+func (adminAPI adminAPIHandlers) GetMultipartLifetimeHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(MultipartLifetime{Lifetime: globalMultipartLifetime.get()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal multipart lifetime into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetMultipartLifetimeHandler - POST /?config&lifetime=duration
+// - x-minio-operation = set-multipart-lifetime
+// Set the time after which idle multipart uploads are aborted on all
+// servers, zero disables aborting. An upload is idle when no part was
+// uploaded to it for longer than the lifetime.
+func (adminAPI adminAPIHandlers) SetMultipartLifetimeHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	lifetime, err := time.ParseDuration(r.URL.Query().Get(string(mgmtLifetime)))
+	if err != nil || lifetime < 0 {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	if err = setPeerMultipartLifetime(r.Context(), globalAdminPeers, lifetime); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set multipart lifetime on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ConditionalWrites - contains the response of get conditional writes
+// API.
+type ConditionalWrites struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetConditionalWritesHandler - GET /?config
+// - x-minio-operation = get-conditional-writes
+// Get whether If-Match and If-None-Match headers are honored on writes.
+func (adminAPI adminAPIHandlers) GetConditionalWritesHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(ConditionalWrites{Enabled: globalConditionalWrites.get()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal conditional writes into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetConditionalWritesHandler - POST /?config&enabled=bool
+// - x-minio-operation = set-conditional-writes
+// Enable or disable conditional writes on all servers. When disabled
+// writes carrying If-Match or If-None-Match headers are rejected with
+// 501 Not Implemented.
+func (adminAPI adminAPIHandlers) SetConditionalWritesHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	enabled, err := strconv.ParseBool(r.URL.Query().Get(string(mgmtEnabled)))
+	if err != nil {
+		writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+		return
+	}
+
+	if err = writeConditionalWrites(objLayer, enabled); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err = setPeerConditionalWrites(r.Context(), globalAdminPeers, enabled); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set conditional writes on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketTagsHandler - GET /?config&bucket=bucket
+// - x-minio-operation = get-bucket-tags
+// Get the tags of a bucket as a JSON object.
+func (adminAPI adminAPIHandlers) GetBucketTagsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalBucketTags.get(bucket))
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal bucket tags into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetBucketTagsHandler - POST /?config&bucket=bucket
+// - x-minio-operation = set-bucket-tags
+// Replace the tags of a bucket on all servers with the JSON object in
+// the request body, an empty object removes all tags. At most 50 tags
+// are allowed, keys of at most 128 and values of at most 256
+// characters.
+func (adminAPI adminAPIHandlers) SetBucketTagsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	var tags map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&tags); err != nil || validateBucketTags(tags) != nil {
+		writeErrorResponse(w, ErrAdminInvalidBucketTags, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Acquire a write lock on bucket before modifying its configuration.
+	bucketLock := globalNSMutex.NewNSLock(bucket, "")
+	bucketLock.Lock()
+	defer bucketLock.Unlock()
+
+	if err := writeBucketTags(bucket, objLayer, tags); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := setPeerBucketTags(r.Context(), globalAdminPeers, bucket, tags); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set bucket tags on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetImmutabilityWindowHandler - GET /?config&bucket=bucket
+// - x-minio-operation = get-immutability-window
+// Get the time after creation during which objects of a bucket can not
+// be deleted or overwritten, zero if they always can be.
+func (adminAPI adminAPIHandlers) GetImmutabilityWindowHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(ImmutabilityWindow{Window: globalImmutabilityWindows.get(bucket)})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal immutability window into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetImmutabilityWindowHandler - POST /?config&bucket=bucket&window=duration
+// - x-minio-operation = set-immutability-window
+// Set the time after creation during which objects of a bucket can not
+// be deleted or overwritten on all servers, zero removes the window.
+// The window is extended by the clock skew tolerated between servers.
+func (adminAPI adminAPIHandlers) SetImmutabilityWindowHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	window, err := time.ParseDuration(vars.Get(string(mgmtWindow)))
+	if err != nil || window < 0 {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	bucket := vars.Get(string(mgmtBucket))
+	if err = checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Acquire a write lock on bucket before modifying its configuration.
+	bucketLock := globalNSMutex.NewNSLock(bucket, "")
+	bucketLock.Lock()
+	defer bucketLock.Unlock()
+
+	if err = writeImmutabilityWindow(bucket, objLayer, window); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err = setPeerImmutabilityWindow(r.Context(), globalAdminPeers, bucket, window); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set immutability window on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// SignatureVersions - contains the response of get signature versions
+// API.
+type SignatureVersions struct {
+	Versions []string `json:"versions"`
+}
+
+// GetSignatureVersionsHandler - GET /?config
+// - x-minio-operation = get-signature-versions
+// Get the signature versions accepted on signed and presigned requests.
+func (adminAPI adminAPIHandlers) GetSignatureVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(SignatureVersions{Versions: globalAllowedSignatures.get()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal signature versions into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetSignatureVersionsHandler - POST /?config&versions=v2,v4
+// - x-minio-operation = set-signature-versions
+// Set the signature versions accepted on all servers, requests signed
+// or presigned with any other version are rejected with 403 Access
+// Denied. At least one version must be allowed.
+func (adminAPI adminAPIHandlers) SetSignatureVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	var versions []string
+	if value := r.URL.Query().Get(string(mgmtVersions)); value != "" {
+		versions = strings.Split(value, ",")
+	}
+
+	if err := writeAllowedSignatureVersions(objLayer, versions); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := setPeerAllowedSignatureVersions(r.Context(), globalAdminPeers, versions); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set signature versions on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// TLSCiphers - contains the response of get TLS ciphers API.
+type TLSCiphers struct {
+	Suites []string `json:"suites"`
+}
+
+// GetTLSCiphersHandler - GET /?config
+// - x-minio-operation = get-tls-ciphers
+// Get the TLS cipher suites offered, in order of preference.
+func (adminAPI adminAPIHandlers) GetTLSCiphersHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(TLSCiphers{Suites: globalTLSCiphers.get()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal TLS ciphers into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetTLSCiphersHandler - POST /?config&ciphers=suite1,suite2
+// - x-minio-operation = set-tls-ciphers
+// Set the TLS cipher suites offered on all servers, in order of
+// preference, for new connections. At least one suite must be strong.
+func (adminAPI adminAPIHandlers) SetTLSCiphersHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	var suites []string
+	if value := r.URL.Query().Get(string(mgmtCiphers)); value != "" {
+		suites = strings.Split(value, ",")
+	}
+
+	if err := setPeerTLSCiphers(r.Context(), globalAdminPeers, suites); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set TLS ciphers on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// MaxUploadParts - contains the response of get max upload parts API.
+type MaxUploadParts struct {
+	Parts int `json:"parts"`
+}
+
+// GetMaxUploadPartsHandler - GET /?config
+// - x-minio-operation = get-max-upload-parts
+// Get the maximum number of parts accepted when completing a multipart
+// upload.
+func (adminAPI adminAPIHandlers) GetMaxUploadPartsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(MaxUploadParts{Parts: getMaxUploadParts()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal max upload parts into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetMaxUploadPartsHandler - POST /?config&max-parts=number
+// - x-minio-operation = set-max-upload-parts
+// Set the maximum number of parts accepted when completing a multipart
+// upload on all servers, at most 10000. Manifests listing more parts
+// are rejected as soon as the limit is exceeded.
+func (adminAPI adminAPIHandlers) SetMaxUploadPartsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	parts, err := strconv.Atoi(r.URL.Query().Get(string(mgmtMaxParts)))
+	if err != nil || parts <= 0 || parts > maxPartID {
+		writeErrorResponse(w, ErrInvalidMaxParts, r.URL)
+		return
+	}
+
+	if err = setPeerMaxUploadParts(r.Context(), globalAdminPeers, parts); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set max upload parts on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetRequestIDConfigHandler - GET /?config
+// - x-minio-operation = get-request-id-config
+// Get the header carrying the request ID in responses, and whether IDs
+// sent by clients are echoed back.
+func (adminAPI adminAPIHandlers) GetRequestIDConfigHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalRequestIDConfig.get())
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal request ID config into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetRequestIDConfigHandler - POST /?config&header=name&honor-client-id=bool
+// - x-minio-operation = set-request-id-config
+// Set the header carrying the request ID in responses on all servers.
+// When client IDs are honored, an ID sent by the client in that header
+// is echoed back once sanitized instead of generating one, so that
+// requests can be correlated across systems.
+func (adminAPI adminAPIHandlers) SetRequestIDConfigHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	honorClientID, err := strconv.ParseBool(vars.Get(string(mgmtHonorClient)))
+	if err != nil {
+		writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+		return
+	}
+
+	if err = setPeerRequestIDConfig(r.Context(), globalAdminPeers, vars.Get(string(mgmtHeader)), honorClientID); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set request ID config on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketObjectLimitHandler - GET /?config&bucket=bucket
+// - x-minio-operation = get-object-limit
+// Get the maximum number of objects of a bucket, zero if unlimited,
+// along with the objects counted by this server.
+func (adminAPI adminAPIHandlers) GetBucketObjectLimitHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalBucketObjectLimits.get(bucket))
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal bucket object limit into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetBucketObjectLimitHandler - POST /?config&bucket=bucket&max-objects=number
+// - x-minio-operation = set-object-limit
+// Set the maximum number of objects of a bucket on all servers, zero
+// removes the limit. New objects are rejected once the bucket holds as
+// many objects, existing objects can still be overwritten.
+func (adminAPI adminAPIHandlers) SetBucketObjectLimitHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	maxObjects, err := strconv.ParseInt(vars.Get(string(mgmtMaxObjects)), 10, 64)
+	if err != nil || maxObjects < 0 {
+		writeErrorResponse(w, ErrAdminInvalidObjectLimit, r.URL)
+		return
+	}
+
+	bucket := vars.Get(string(mgmtBucket))
+	if err = checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Acquire a write lock on bucket before modifying its configuration.
+	bucketLock := globalNSMutex.NewNSLock(bucket, "")
+	bucketLock.Lock()
+	defer bucketLock.Unlock()
+
+	if err = writeBucketObjectLimit(bucket, objLayer, maxObjects); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err = setPeerBucketObjectLimit(r.Context(), globalAdminPeers, bucket, maxObjects); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set bucket object limit on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// TrustedProxies - contains the response of get trusted proxies API.
+type TrustedProxies struct {
+	CIDRs []string `json:"cidrs"`
+}
+
+// GetTrustedProxiesHandler - GET /?config
+// - x-minio-operation = get-trusted-proxies
+// Get the proxies whose X-Forwarded-For and X-Real-Ip headers are
+// honored.
+func (adminAPI adminAPIHandlers) GetTrustedProxiesHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(TrustedProxies{CIDRs: globalTrustedProxies.get()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal trusted proxies into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetTrustedProxiesHandler - POST /?config&cidrs=cidr1,cidr2
+// - x-minio-operation = set-trusted-proxies
+// Set the proxies, as CIDRs or IP addresses, whose forwarded headers
+// are honored on all servers to find the address of clients. An empty
+// list trusts no proxy.
+func (adminAPI adminAPIHandlers) SetTrustedProxiesHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	cidrs := []string{}
+	if value := r.URL.Query().Get(string(mgmtCIDRs)); value != "" {
+		cidrs = strings.Split(value, ",")
+	}
+
+	if err := writeTrustedProxies(objLayer, cidrs); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := setPeerTrustedProxies(r.Context(), globalAdminPeers, cidrs); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set trusted proxies on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketLoggingHandler - GET /?config&bucket=bucket
+// - x-minio-operation = get-bucket-logging
+// Get the bucket and prefix access to a bucket is logged into.
+func (adminAPI adminAPIHandlers) GetBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalBucketLogging.get(bucket))
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal bucket logging into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetBucketLoggingHandler - POST /?config&bucket=bucket&target-bucket=target&prefix=prefix
+// - x-minio-operation = set-bucket-logging
+// Log access to a bucket on all servers as objects written into
+// another existing bucket under prefix, an empty target disables
+// logging. Servers write the logs they buffered every few minutes.
+func (adminAPI adminAPIHandlers) SetBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	logging := BucketLogging{
+		TargetBucket: vars.Get(string(mgmtTargetBucket)),
+		TargetPrefix: vars.Get(string(mgmtPrefix)),
+	}
+	if err := checkBucketLogging(objLayer, bucket, logging); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Acquire a write lock on bucket before modifying its configuration.
+	bucketLock := globalNSMutex.NewNSLock(bucket, "")
+	bucketLock.Lock()
+	defer bucketLock.Unlock()
+
+	if err := writeBucketLogging(bucket, objLayer, logging); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := setPeerBucketLogging(r.Context(), globalAdminPeers, bucket, logging.TargetBucket, logging.TargetPrefix); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set bucket logging on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// EvaluatePolicyHandler - GET /?config&principal=principal&action=action&resource=resource
+// - x-minio-operation = evaluate-policy
+// Evaluate the current bucket policies as if principal requested
+// action on resource, without changing anything. Returns whether the
+// request would be allowed and the statements which decided so, an
+// explicit Deny wins over any Allow.
+func (adminAPI adminAPIHandlers) EvaluatePolicyHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	if newObjectLayerFn() == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	decision, err := evaluateBucketPolicy(globalBucketPolicies, vars.Get(string(mgmtPrincipal)),
+		vars.Get(string(mgmtAction)), vars.Get(string(mgmtResource)))
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(decision)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal policy decision into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// GetChecksumPolicyHandler - GET /?config
+// - x-minio-operation = get-checksum-policy
+// Get the algorithms of the object checksums verified and stored on
+// upload, and whether uploads must send one.
+func (adminAPI adminAPIHandlers) GetChecksumPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalChecksumPolicy.get())
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal checksum policy into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetChecksumPolicyHandler - POST /?config&required=true|false&algorithms=algo1,algo2
+// - x-minio-operation = set-checksum-policy
+// Set on all servers the algorithms of the object checksums verified
+// and stored on upload, among CRC32, CRC32C, SHA1 and SHA256. When
+// required, uploads without a checksum of one of them are rejected.
+// Uploads whose checksum does not match their data are always rejected.
+func (adminAPI adminAPIHandlers) SetChecksumPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	required, err := strconv.ParseBool(vars.Get(string(mgmtRequired)))
+	if err != nil {
+		writeErrorResponse(w, ErrAdminInvalidChecksumPolicy, r.URL)
+		return
+	}
+	algorithms, err := parseChecksumAlgorithms(strings.Split(vars.Get(string(mgmtAlgorithms)), ","))
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err = writeChecksumPolicy(objLayer, required, algorithms); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err = setPeerChecksumPolicy(r.Context(), globalAdminPeers, required, algorithms); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set checksum policy on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetNotificationRetryHandler - GET /?config
+// - x-minio-operation = get-notification-retry
+// Get how events a notification target failed to receive are sent
+// again.
+func (adminAPI adminAPIHandlers) GetNotificationRetryHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalNotificationRetry.get())
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal notification retry policy into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetNotificationRetryHandler - POST /?config&max-retries=n&backoff=duration&dead-letter=arn
+// - x-minio-operation = set-notification-retry
+// Set on all servers how many times, and how far apart, events a
+// notification target failed to receive are sent again. Events still
+// not received are sent once to the optional dead-letter target, and
+// dropped if it fails too.
+func (adminAPI adminAPIHandlers) SetNotificationRetryHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	maxRetries, err := strconv.Atoi(vars.Get(string(mgmtMaxRetries)))
+	if err != nil {
+		writeErrorResponse(w, ErrAdminInvalidNotificationRetry, r.URL)
+		return
+	}
+	backoff, err := time.ParseDuration(vars.Get(string(mgmtBackoff)))
+	if err != nil {
+		writeErrorResponse(w, ErrInvalidDuration, r.URL)
+		return
+	}
+
+	if err = setPeerNotificationRetry(r.Context(), globalAdminPeers, maxRetries, backoff, vars.Get(string(mgmtDeadLetter))); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set notification retry policy on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// MaxListDepth - contains the response of get max list depth API.
+type MaxListDepth struct {
+	Depth int `json:"depth"`
+}
+
+// GetMaxListDepthHandler - GET /?config
+// - x-minio-operation = get-max-list-depth
+// Get the maximum depth of the entries of listings with a delimiter, 0
+// means no maximum.
+func (adminAPI adminAPIHandlers) GetMaxListDepthHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(MaxListDepth{Depth: getMaxListDepth()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal max list depth into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetMaxListDepthHandler - POST /?config&depth=number
+// - x-minio-operation = set-max-list-depth
+// Set on all servers the maximum depth of the entries of listings with
+// a delimiter, 0 removes it. Objects and common prefixes deeper than
+// the maximum are left out of such listings, which are then sent with
+// the X-Minio-List-Depth-Truncated header. Listings without delimiter
+// are not affected.
+func (adminAPI adminAPIHandlers) SetMaxListDepthHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	depth, err := strconv.Atoi(r.URL.Query().Get(string(mgmtDepth)))
+	if err != nil || depth < 0 {
+		writeErrorResponse(w, ErrAdminInvalidMaxListDepth, r.URL)
+		return
+	}
+
+	if err = setPeerMaxListDepth(r.Context(), globalAdminPeers, depth); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set max list depth on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketPartSizeLimitsHandler - GET /?config&bucket=bucket
+// - x-minio-operation = get-part-size-limits
+// Get the sizes allowed for the parts of multipart uploads to a bucket,
+// the S3 bounds if the bucket has no limits of its own.
+func (adminAPI adminAPIHandlers) GetBucketPartSizeLimitsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalBucketPartSizeLimits.get(bucket))
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal bucket part size limits into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetBucketPartSizeLimitsHandler - POST /?config&bucket=bucket&min-part-size=bytes&max-part-size=bytes
+// - x-minio-operation = set-part-size-limits
+// Set on all servers the sizes allowed for the parts of multipart
+// uploads to a bucket, within the S3 bounds of 5MiB and 5GiB. Both
+// zero removes the limits of the bucket.
+func (adminAPI adminAPIHandlers) SetBucketPartSizeLimitsHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	minPart, err := strconv.ParseInt(vars.Get(string(mgmtMinPartSize)), 10, 64)
+	if err != nil {
+		writeErrorResponse(w, ErrAdminInvalidPartSizeLimits, r.URL)
+		return
+	}
+	maxPart, err := strconv.ParseInt(vars.Get(string(mgmtMaxPartSize)), 10, 64)
+	if err != nil {
+		writeErrorResponse(w, ErrAdminInvalidPartSizeLimits, r.URL)
+		return
+	}
+
+	bucket := vars.Get(string(mgmtBucket))
+	if err = checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err = setPeerBucketPartSizeLimits(r.Context(), globalAdminPeers, bucket, minPart, maxPart); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set bucket part size limits on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetDeleteProtectionTagHandler - GET /?config&bucket=bucket
+// - x-minio-operation = get-delete-protection
+// Get the tag protecting the objects of a bucket carrying it from
+// deletion, no key if none.
+func (adminAPI adminAPIHandlers) GetDeleteProtectionTagHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalDeleteProtectionTags.get(bucket))
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal delete protection tag into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetDeleteProtectionTagHandler - POST /?config&bucket=bucket&tag-key=key&tag-value=value
+// - x-minio-operation = set-delete-protection
+// Set on all servers the tag protecting the objects of a bucket
+// carrying it from deletion, objects are tagged with the user-defined
+// metadata x-amz-meta-<key>. An empty value protects objects carrying
+// the key with any value, an empty key removes the protection. Bulk
+// deletes report protected objects as errors and delete the others.
+func (adminAPI adminAPIHandlers) SetDeleteProtectionTagHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	tag := DeleteProtectionTag{Key: vars.Get(string(mgmtTagKey)), Value: vars.Get(string(mgmtTagValue))}
+	if err := validateDeleteProtectionTag(tag); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	bucket := vars.Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Acquire a write lock on bucket before modifying its configuration.
+	bucketLock := globalNSMutex.NewNSLock(bucket, "")
+	bucketLock.Lock()
+	defer bucketLock.Unlock()
+
+	if err := writeDeleteProtectionTag(bucket, objLayer, tag); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := setPeerDeleteProtectionTag(r.Context(), globalAdminPeers, bucket, tag.Key, tag.Value); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set delete protection tag on peers.")
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ConfigChangeLogHandler - GET /?config&count=number
+// - x-minio-operation = change-log
+// - count is an optional query parameter, at most 1000
+// Get the last config changes made through the admin API, newest
+// first, with the access key which made them and their parameters,
+// secrets redacted. Changes which were not logged on all servers
+// reached are flagged inconsistent. The log is lost when servers
+// restart.
+func (adminAPI adminAPIHandlers) ConfigChangeLogHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	n := configChangeLogSize
+	if value := r.URL.Query().Get(string(mgmtCount)); value != "" {
+		var err error
+		if n, err = strconv.Atoi(value); err != nil || n <= 0 || n > configChangeLogSize {
+			writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+			return
+		}
+	}
+
+	changes, err := getPeerConfigChangeLog(r.Context(), globalAdminPeers, n)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to fetch config change log from peers.")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(changes)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal config change log into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ScanOnWrite - contains the response of get scan on write API.
+type ScanOnWrite struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetScanOnWriteHandler - GET /?config
+// - x-minio-operation = get-scan-on-write
+// Get whether written objects are verified before writes succeed.
+func (adminAPI adminAPIHandlers) GetScanOnWriteHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// if dry-run is present in query-params, then only perform validations and return success.
-	if isDryRun(vars) {
-		writeSuccessResponseHeadersOnly(w)
+	jsonBytes, err := json.Marshal(ScanOnWrite{Enabled: globalScanOnWrite.get()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal scan on write into json.")
 		return
 	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
-	// Heal the given bucket.
-	err := objLayer.HealBucket(bucket)
+// SetScanOnWriteHandler - POST /?config&enabled=bool
+// - x-minio-operation = set-scan-on-write
+// Enable or disable verification of writes on all servers. When
+// enabled the erasure coded shards of every object and part written
+// are read back and checked against their checksums before the write
+// succeeds, adding the latency of reading them. Objects failing the
+// check are not stored.
+func (adminAPI adminAPIHandlers) SetScanOnWriteHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	enabled, err := strconv.ParseBool(r.URL.Query().Get(string(mgmtEnabled)))
 	if err != nil {
+		writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+		return
+	}
+
+	if err = setPeerScanOnWrite(r.Context(), globalAdminPeers, enabled); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set scan on write on peers.")
 		return
 	}
 
-	// Return 200 on success.
 	writeSuccessResponseHeadersOnly(w)
 }
 
-// isDryRun - returns true if dry-run query param was set and false otherwise.
-// otherwise.
-func isDryRun(qval url.Values) bool {
-	if _, dryRun := qval[string(mgmtDryRun)]; dryRun {
-		return true
-	}
-	return false
+// CredentialExpiry - contains the response of get credential expiry
+// API. Expiry is zero if the access key never expires.
+type CredentialExpiry struct {
+	AccessKey string    `json:"accessKey"`
+	Expiry    time.Time `json:"expiry"`
+	Expired   bool      `json:"expired"`
 }
 
-// HealObjectHandler - POST /?heal&bucket=mybucket&object=myobject&dry-run
-// - x-minio-operation = object
-// - bucket and object are both mandatory query parameters
-// Heal a given object, if present.
-func (adminAPI adminAPIHandlers) HealObjectHandler(w http.ResponseWriter, r *http.Request) {
-	// Get object layer instance.
-	objLayer := newObjectLayerFn()
-	if objLayer == nil {
-		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+// GetCredentialExpiryHandler - GET /?config&access-key=key
+// - x-minio-operation = get-credential-expiry
+// Get the time after which the access key is rejected, as seen by the
+// server receiving the request.
+func (adminAPI adminAPIHandlers) GetCredentialExpiryHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	accessKey := r.URL.Query().Get(string(mgmtAccessKey))
+	if accessKey != serverConfig.GetCredential().AccessKey {
+		writeErrorResponse(w, ErrInvalidAccessKeyID, r.URL)
+		return
+	}
+
+	expiry, _ := globalCredentialExpiry.get(accessKey)
+	jsonBytes, err := json.Marshal(CredentialExpiry{
+		AccessKey: accessKey,
+		Expiry:    expiry,
+		Expired:   checkCredentialExpiry(accessKey) != nil,
+	})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal credential expiry into json.")
 		return
 	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
+// SetCredentialExpiryHandler - POST /?config&access-key=key&expiry=time
+// - x-minio-operation = set-credential-expiry
+// Set the time, in RFC 3339 format, after which all servers reject the
+// access key, an expiry in the past rejects it right away. Requests in
+// progress complete. Without expiry the access key never expires. The
+// server credential is the only access key, once it has expired this
+// API is rejected too, so an expiry must be confirmed with
+// confirm=true. The expiry is saved in config.json of every server,
+// removing it there and restarting the servers is then the only way
+// to lift it.
+func (adminAPI adminAPIHandlers) SetCredentialExpiryHandler(w http.ResponseWriter, r *http.Request) {
 	// Validate request signature.
 	adminAPIErr := checkRequestAuthType(r, "", "", "")
 	if adminAPIErr != ErrNone {
@@ -570,50 +3819,191 @@ func (adminAPI adminAPIHandlers) HealObjectHandler(w http.ResponseWriter, r *htt
 	}
 
 	vars := r.URL.Query()
-	bucket := vars.Get(string(mgmtBucket))
-	object := vars.Get(string(mgmtObject))
+	var expiry time.Time
+	if expiryStr := vars.Get(string(mgmtExpiry)); expiryStr != "" {
+		var err error
+		if expiry, err = time.Parse(time.RFC3339, expiryStr); err != nil {
+			writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+			return
+		}
+	}
+	accessKey := vars.Get(string(mgmtAccessKey))
+	if !expiry.IsZero() && accessKey == serverConfig.GetCredential().AccessKey && vars.Get(string(mgmtConfirm)) != "true" {
+		writeErrorResponse(w, ErrAdminCredentialLockout, r.URL)
+		return
+	}
 
-	// Validate bucket and object names.
-	if err := checkBucketAndObjectNames(bucket, object); err != nil {
+	if err := setPeerCredentialExpiry(r.Context(), globalAdminPeers, accessKey, expiry); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set credential expiry on peers.")
 		return
 	}
 
-	// Check if object exists.
-	if _, err := objLayer.GetObjectInfo(bucket, object); err != nil {
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// MultiDeleteLimit - contains the response of get multi-delete limit
+// API.
+type MultiDeleteLimit struct {
+	MaxKeys int `json:"maxKeys"`
+}
+
+// GetMultiDeleteLimitHandler - GET /?config
+// - x-minio-operation = get-multi-delete-limit
+// Get the maximum number of keys of a multi-delete request.
+func (adminAPI adminAPIHandlers) GetMultiDeleteLimitHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(MultiDeleteLimit{MaxKeys: getMultiDeleteLimit()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal multi-delete limit into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetMultiDeleteLimitHandler - POST /?config&max-keys=number
+// - x-minio-operation = set-multi-delete-limit
+// Set on all servers the maximum number of keys of a multi-delete
+// request, at most the 1000 keys allowed by S3. Requests with more keys
+// are rejected as a whole, no object is deleted.
+func (adminAPI adminAPIHandlers) SetMultiDeleteLimitHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	maxKeys, err := strconv.Atoi(r.URL.Query().Get(string(mgmtMaxKeys)))
+	if err != nil || maxKeys < 1 || maxKeys > maxDeleteObjects {
+		writeErrorResponse(w, ErrAdminInvalidMultiDeleteLimit, r.URL)
+		return
+	}
+
+	if err = writeMultiDeleteLimit(objLayer, maxKeys); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
 
-	// if dry-run is set in query params then perform validations
-	// and return success.
-	if isDryRun(vars) {
-		writeSuccessResponseHeadersOnly(w)
+	if err = setPeerMultiDeleteLimit(r.Context(), globalAdminPeers, maxKeys); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set multi-delete limit on peers.")
 		return
 	}
 
-	err := objLayer.HealObject(bucket, object)
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// BackgroundAppend - contains the response of get background append
+// API.
+type BackgroundAppend struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetBackgroundAppendHandler - GET /?config
+// - x-minio-operation = get-background-append
+// Get whether parts of new multipart uploads are appended in the
+// background as they are uploaded.
+func (adminAPI adminAPIHandlers) GetBackgroundAppendHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(BackgroundAppend{Enabled: globalBackgroundAppend.get()})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal background append into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetBackgroundAppendHandler - POST /?config&enabled=bool
+// - x-minio-operation = set-background-append
+// Enable or disable on all servers background append of the parts of
+// multipart uploads to the FS backend. When disabled parts are put
+// together on complete-multipart-upload only. The setting applies to
+// uploads started afterwards, uploads in progress complete the way
+// they started.
+func (adminAPI adminAPIHandlers) SetBackgroundAppendHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	enabled, err := strconv.ParseBool(r.URL.Query().Get(string(mgmtEnabled)))
 	if err != nil {
+		writeErrorResponse(w, ErrInvalidQueryParams, r.URL)
+		return
+	}
+
+	if err = setPeerBackgroundAppend(r.Context(), globalAdminPeers, enabled); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set background append on peers.")
 		return
 	}
 
-	// Return 200 on success.
 	writeSuccessResponseHeadersOnly(w)
 }
 
-// HealFormatHandler - POST /?heal&dry-run
-// - x-minio-operation = format
-// - bucket and object are both mandatory query parameters
-// Heal a given object, if present.
-func (adminAPI adminAPIHandlers) HealFormatHandler(w http.ResponseWriter, r *http.Request) {
-	// Get current object layer instance.
-	objectAPI := newObjectLayerFn()
-	if objectAPI == nil {
+// GetBucketUploadLimitHandler - GET /?config&bucket=bucket
+// - x-minio-operation = get-upload-limit
+// Get the maximum number of multipart uploads in progress in a bucket,
+// zero if unlimited.
+func (adminAPI adminAPIHandlers) GetBucketUploadLimitHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
 		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
 		return
 	}
 
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(BucketUploadLimit{MaxUploads: globalBucketUploadLimits.get(bucket)})
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal bucket upload limit into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetBucketUploadLimitHandler - POST /?config&bucket=bucket&max-uploads=number
+// - x-minio-operation = set-upload-limit
+// Set on all servers the maximum number of multipart uploads in
+// progress in a bucket, zero removes the limit. New uploads are
+// rejected with a retryable error once the bucket has as many uploads
+// in progress, uploads already initiated are not affected.
+func (adminAPI adminAPIHandlers) SetBucketUploadLimitHandler(w http.ResponseWriter, r *http.Request) {
 	// Validate request signature.
 	adminAPIErr := checkRequestAuthType(r, "", "", "")
 	if adminAPIErr != ErrNone {
@@ -621,62 +4011,132 @@ func (adminAPI adminAPIHandlers) HealFormatHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// Check if this setup is an erasure code backend, since
-	// heal-format is only applicable to single node XL and
-	// distributed XL setup.
-	if !globalIsXL {
-		writeErrorResponse(w, ErrNotImplemented, r.URL)
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
 		return
 	}
 
-	// if dry-run is set in query-params, return success as
-	// validations are successful so far.
 	vars := r.URL.Query()
-	if isDryRun(vars) {
-		writeSuccessResponseHeadersOnly(w)
+	maxUploads, err := strconv.Atoi(vars.Get(string(mgmtMaxUploads)))
+	if err != nil || maxUploads < 0 {
+		writeErrorResponse(w, ErrAdminInvalidUploadLimit, r.URL)
 		return
 	}
 
-	// Create a new set of storage instances to heal format.json.
-	bootstrapDisks, err := initStorageDisks(globalEndpoints)
-	if err != nil {
+	bucket := vars.Get(string(mgmtBucket))
+	if err = checkBucketExist(bucket, objLayer); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
 
-	// Heal format.json on available storage.
-	err = healFormatXL(bootstrapDisks)
-	if err != nil {
+	if err = setPeerBucketUploadLimit(r.Context(), globalAdminPeers, bucket, maxUploads); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set bucket upload limit on peers.")
 		return
 	}
 
-	// Instantiate new object layer with newly formatted storage.
-	newObjectAPI, err := newXLObjects(bootstrapDisks)
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetMaintenanceScheduleHandler - GET /?config
+// - x-minio-operation = get-maintenance-schedule
+// Get the recurring maintenance windows, the action taken during them
+// and whether one is in progress.
+func (adminAPI adminAPIHandlers) GetMaintenanceScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalMaintenance.status(time.Now()))
 	if err != nil {
-		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal maintenance schedule into json.")
 		return
 	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
-	// Set object layer with newly formatted storage to globalObjectAPI.
-	globalObjLayerMutex.Lock()
-	globalObjectAPI = newObjectAPI
-	globalObjLayerMutex.Unlock()
+// SetMaintenanceScheduleHandler - POST /?config
+// - x-minio-operation = set-maintenance-schedule
+// Set the recurring maintenance windows of all servers and the action
+// taken during them, read-only or scanner-fast, sent as json in the
+// request body. Malformed schedules and schedules with overlapping
+// windows are rejected, no windows removes the schedule. The schedule
+// is persisted, servers restarting within a window enter or leave
+// maintenance on time.
+func (adminAPI adminAPIHandlers) SetMaintenanceScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
 
-	// Shutdown storage belonging to old object layer instance.
-	objectAPI.Shutdown()
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
 
-	// Inform peers to reinitialize storage with newly formatted storage.
-	reInitPeerDisks(globalAdminPeers)
+	var schedule MaintenanceSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		writeErrorResponse(w, ErrAdminInvalidMaintenanceSchedule, r.URL)
+		return
+	}
+	if _, err := maintenanceSpans(schedule); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := writeMaintenanceSchedule(objLayer, schedule); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := setPeerMaintenanceSchedule(r.Context(), globalAdminPeers, schedule); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		errorIf(err, "Failed to set maintenance schedule on peers.")
+		return
+	}
 
-	// Return 200 on success.
 	writeSuccessResponseHeadersOnly(w)
 }
 
-// GetConfigHandler - GET /?config
-// - x-minio-operation = get
-// Get config.json of this minio setup.
-func (adminAPI adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Request) {
+// GetQuorumOverrideHandler - GET /?config
+// - x-minio-operation = get-quorum-override
+// Get the XL quorum override in effect on this server and when it
+// expires, zero quorums if none.
+func (adminAPI adminAPIHandlers) GetQuorumOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkRequestAuthType(r, "", "", "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponse(w, adminAPIErr, r.URL)
+		return
+	}
+
+	override, _ := globalQuorumOverride.get(time.Now())
+	jsonBytes, err := json.Marshal(override)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		errorIf(err, "Failed to marshal quorum override into json.")
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetQuorumOverrideHandler - POST /?config&read-quorum=number&write-quorum=number&ttl=duration
+// - x-minio-operation = set-quorum-override
+// Override on all servers the read and write quorum of XL for ttl, at
+// most 24h, for recovering data after losing more drives than the
+// quorum tolerates. A zero or missing quorum keeps the computed one,
+// quorums below the number of data drives are rejected as they lose
+// data. A zero ttl removes the override. Objects written while the
+// override is in effect may be inconsistent.
+func (adminAPI adminAPIHandlers) SetQuorumOverrideHandler(w http.ResponseWriter, r *http.Request) {
 	// Validate request signature.
 	adminAPIErr := checkRequestAuthType(r, "", "", "")
 	if adminAPIErr != ErrNone {
@@ -684,20 +4144,35 @@ func (adminAPI adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http
 		return
 	}
 
-	// check if objectLayer is initialized, if not return.
 	if newObjectLayerFn() == nil {
 		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
 		return
 	}
 
-	// Get config.json from all nodes. In a single node setup, it
-	// returns local config.json.
-	configBytes, err := getPeerConfig(globalAdminPeers)
+	vars := r.URL.Query()
+	var quorums [2]int
+	for i, key := range []mgmtQueryKey{mgmtReadQuorum, mgmtWriteQuorum} {
+		if vars.Get(string(key)) == "" {
+			continue
+		}
+		quorum, err := strconv.Atoi(vars.Get(string(key)))
+		if err != nil {
+			writeErrorResponse(w, ErrAdminInvalidQuorumOverride, r.URL)
+			return
+		}
+		quorums[i] = quorum
+	}
+	ttl, err := time.ParseDuration(vars.Get(string(mgmtTTL)))
 	if err != nil {
+		writeErrorResponse(w, ErrAdminInvalidQuorumOverride, r.URL)
+		return
+	}
+
+	if err = setPeerQuorumOverride(r.Context(), globalAdminPeers, quorums[0], quorums[1], ttl); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
-		errorIf(err, "Failed to get config from peers")
+		errorIf(err, "Failed to set quorum override on peers.")
 		return
 	}
 
-	writeSuccessResponseJSON(w, configBytes)
+	writeSuccessResponseHeadersOnly(w)
 }
@@ -18,12 +18,14 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -115,6 +117,7 @@ const (
 	statusCmd cmdType = iota
 	restartCmd
 	setCreds
+	stopCmd
 )
 
 // String - String representation for cmdType
@@ -126,6 +129,8 @@ func (c cmdType) String() string {
 		return "restart"
 	case setCreds:
 		return "set-credentials"
+	case stopCmd:
+		return "stop"
 	}
 	return ""
 }
@@ -140,6 +145,8 @@ func (c cmdType) apiMethod() string {
 		return "POST"
 	case setCreds:
 		return "POST"
+	case stopCmd:
+		return "POST"
 	}
 	return "GET"
 }
@@ -152,6 +159,8 @@ func (c cmdType) toServiceSignal() serviceSignal {
 		return serviceStatus
 	case restartCmd:
 		return serviceRestart
+	case stopCmd:
+		return serviceStop
 	}
 	return serviceStatus
 }
@@ -213,7 +222,7 @@ func testServicesCmdHandler(cmd cmdType, t *testing.T) {
 
 	// Setting up a go routine to simulate ServerMux's
 	// handleServiceSignals for stop and restart commands.
-	if cmd == restartCmd {
+	if cmd == restartCmd || cmd == stopCmd {
 		go testServiceSignalReceiver(cmd, t)
 	}
 	credentials := serverConfig.GetCredential()
@@ -257,6 +266,11 @@ func TestServiceRestartHandler(t *testing.T) {
 	testServicesCmdHandler(restartCmd, t)
 }
 
+// Test for service stop management REST API.
+func TestServiceStopHandler(t *testing.T) {
+	testServicesCmdHandler(stopCmd, t)
+}
+
 // Test for service set creds management REST API.
 func TestServiceSetCreds(t *testing.T) {
 	adminTestBed, err := prepareAdminXLTestBed()
@@ -494,6 +508,75 @@ func TestClearLocksHandler(t *testing.T) {
 	}
 }
 
+// clearLocksStub - adminCmdRunner holding locks, recording the objects
+// it was asked to force unlock and failing to force unlock failObject.
+type clearLocksStub struct {
+	adminCmdRunner
+	locks      []VolumeLockInfo
+	failObject string
+	unlocked   map[string]bool
+}
+
+func (s clearLocksStub) ListLocks(ctx context.Context, bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
+	return s.locks, nil
+}
+
+func (s clearLocksStub) ForceUnlock(ctx context.Context, bucket, object string) error {
+	s.unlocked[object] = true
+	if object == s.failObject {
+		return errDiskNotFound
+	}
+	return nil
+}
+
+// TestClearLocksHandlerFailures - tests every lock is attempted and
+// the request fails if a lock could not be cleared.
+func TestClearLocksHandlerFailures(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	unlocked := make(map[string]bool)
+	globalAdminPeers = adminPeers{{
+		addr: "127.0.0.1:9000",
+		cmdRunner: clearLocksStub{
+			locks: []VolumeLockInfo{
+				{Bucket: "mybucket", Object: "object1"},
+				{Bucket: "mybucket", Object: "object2"},
+				{Bucket: "mybucket", Object: "object3"},
+			},
+			failObject: "object2",
+			unlocked:   unlocked,
+		},
+	}}
+	defer func() {
+		globalAdminPeers = nil
+	}()
+
+	queryVal := mkLockQueryVal("mybucket", "", "1s")
+	req, err := newTestRequest("POST", "/?"+queryVal.Encode(), 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct clear locks request - %v", err)
+	}
+	req.Header.Set(minioAdminOpHeader, "clear")
+	cred := serverConfig.GetCredential()
+	if err = signRequestV4(req, cred.AccessKey, cred.SecretKey); err != nil {
+		t.Fatalf("Failed to sign clear locks request - %v", err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected HTTP status code %d but received %d", http.StatusInternalServerError, rec.Code)
+	}
+	for _, object := range []string{"object1", "object2", "object3"} {
+		if !unlocked[object] {
+			t.Errorf("Expected %s to be force unlocked", object)
+		}
+	}
+}
+
 // Test for lock query param validation helper function.
 func TestValidateLockQueryParams(t *testing.T) {
 	// reset globals.
@@ -964,6 +1047,12 @@ func TestHealFormatHandler(t *testing.T) {
 	}
 	defer adminTestBed.TearDown()
 
+	// Peers reinitializing their disks, the local one only.
+	globalAdminPeers = makeAdminPeers(globalEndpoints)
+	defer func() {
+		globalAdminPeers = nil
+	}()
+
 	// Prepare query params for heal-format mgmt REST API.
 	queryVal := url.Values{}
 	queryVal.Set("heal", "")
@@ -1033,3 +1122,175 @@ func TestGetConfigHandler(t *testing.T) {
 	}
 
 }
+
+// TestConfigChangeLogHandler - tests successful config changes are
+// logged and returned by the change-log API.
+func TestConfigChangeLogHandler(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+	defer resetGlobalShutdownTimeout()
+
+	// Initialize admin peers to make admin RPC calls.
+	eps, err := parseStorageEndpoints([]string{"http://127.0.0.1"})
+	if err != nil {
+		t.Fatalf("Failed to parse storage end point - %v", err)
+	}
+
+	// Set globalMinioAddr to be able to distinguish local endpoints from remote.
+	globalMinioAddr = eps[0].Host
+	initGlobalAdminPeers(eps)
+
+	sendRequest := func(method, resource, operation string, queryVal url.Values) *httptest.ResponseRecorder {
+		queryVal.Set(resource, "")
+		req, rErr := newTestRequest(method, "/?"+queryVal.Encode(), 0, nil)
+		if rErr != nil {
+			t.Fatalf("Failed to construct %s request - %v", operation, rErr)
+		}
+		req.Header.Set(minioAdminOpHeader, operation)
+		cred := serverConfig.GetCredential()
+		if rErr = signRequestV4(req, cred.AccessKey, cred.SecretKey); rErr != nil {
+			t.Fatalf("Failed to sign %s request - %v", operation, rErr)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := sendRequest("POST", "config", "set-max-list-depth", url.Values{"depth": {"3"}}); rec.Code != http.StatusOK {
+		t.Fatalf("Expected set-max-list-depth to succeed, got %d", rec.Code)
+	}
+	// Failed changes are not logged.
+	if rec := sendRequest("POST", "config", "set-max-list-depth", url.Values{"depth": {"-1"}}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected invalid set-max-list-depth to fail, got %d", rec.Code)
+	}
+	// Changes outside of the config resource are logged too.
+	if rec := sendRequest("POST", "service", "set-shutdown-timeout", url.Values{"timeout": {"10s"}}); rec.Code != http.StatusOK {
+		t.Fatalf("Expected set-shutdown-timeout to succeed, got %d", rec.Code)
+	}
+
+	rec := sendRequest("GET", "config", "change-log", url.Values{"count": {"10"}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected change-log to succeed, got %d", rec.Code)
+	}
+	var changes []ConfigChangeStatus
+	if err = json.Unmarshal(rec.Body.Bytes(), &changes); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %v", changes)
+	}
+	if change := changes[0]; change.Operation != "set-shutdown-timeout" || len(change.Diff) != 1 || change.Diff["timeout"] != "10s" {
+		t.Fatalf("Unexpected change %#v", change)
+	}
+	change := changes[1]
+	if change.Operation != "set-max-list-depth" || change.Diff["depth"] != "3" || change.Inconsistent {
+		t.Fatalf("Unexpected change %#v", change)
+	}
+	if change.AccessKey != serverConfig.GetCredential().AccessKey {
+		t.Fatalf("Expected access key %s, got %s", serverConfig.GetCredential().AccessKey, change.AccessKey)
+	}
+
+	if rec = sendRequest("GET", "config", "change-log", url.Values{"count": {"0"}}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected invalid count to fail, got %d", rec.Code)
+	}
+}
+
+// TestCredentialExpiryHandler - tests an access key is rejected once
+// the expiry set on all servers has passed.
+func TestCredentialExpiryHandler(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+	defer resetGlobalCredentialExpiry()
+
+	// Initialize admin peers to make admin RPC calls.
+	eps, err := parseStorageEndpoints([]string{"http://127.0.0.1"})
+	if err != nil {
+		t.Fatalf("Failed to parse storage end point - %v", err)
+	}
+
+	// Set globalMinioAddr to be able to distinguish local endpoints from remote.
+	globalMinioAddr = eps[0].Host
+	initGlobalAdminPeers(eps)
+
+	cred := serverConfig.GetCredential()
+	sendRequest := func(method, operation string, queryVal url.Values) *httptest.ResponseRecorder {
+		queryVal.Set("config", "")
+		req, rErr := newTestRequest(method, "/?"+queryVal.Encode(), 0, nil)
+		if rErr != nil {
+			t.Fatalf("Failed to construct %s request - %v", operation, rErr)
+		}
+		req.Header.Set(minioAdminOpHeader, operation)
+		if rErr = signRequestV4(req, cred.AccessKey, cred.SecretKey); rErr != nil {
+			t.Fatalf("Failed to sign %s request - %v", operation, rErr)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.mux.ServeHTTP(rec, req)
+		return rec
+	}
+	getExpiry := url.Values{"access-key": {cred.AccessKey}}
+
+	if rec := sendRequest("POST", "set-credential-expiry", url.Values{
+		"access-key": {"unknown"},
+		"expiry":     {time.Now().UTC().Add(time.Hour).Format(time.RFC3339)},
+	}); rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected unknown access key to be refused, got %d", rec.Code)
+	}
+	if rec := sendRequest("POST", "set-credential-expiry", url.Values{
+		"access-key": {cred.AccessKey},
+		"expiry":     {"tomorrow"},
+	}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected invalid expiry to be refused, got %d", rec.Code)
+	}
+
+	// Expiring the only credential must be confirmed.
+	if rec := sendRequest("POST", "set-credential-expiry", url.Values{
+		"access-key": {cred.AccessKey},
+		"expiry":     {time.Now().UTC().Add(time.Hour).Format(time.RFC3339)},
+	}); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "XMinioAdminCredentialLockout") {
+		t.Fatalf("Expected unconfirmed expiry to be refused, got %d", rec.Code)
+	}
+
+	// The key is accepted until it expires.
+	if rec := sendRequest("POST", "set-credential-expiry", url.Values{
+		"access-key": {cred.AccessKey},
+		"expiry":     {time.Now().UTC().Add(time.Hour).Format(time.RFC3339)},
+		"confirm":    {"true"},
+	}); rec.Code != http.StatusOK {
+		t.Fatalf("Expected set-credential-expiry to succeed, got %d", rec.Code)
+	}
+	rec := sendRequest("GET", "get-credential-expiry", getExpiry)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected get-credential-expiry to succeed, got %d", rec.Code)
+	}
+	var expiry CredentialExpiry
+	if err = json.Unmarshal(rec.Body.Bytes(), &expiry); err != nil {
+		t.Fatal(err)
+	}
+	if expiry.Expired || expiry.Expiry.IsZero() {
+		t.Fatalf("Expected an expiry not passed yet, got %#v", expiry)
+	}
+
+	// An expiry in the past rejects the key right away, the request
+	// setting it still completes.
+	if rec = sendRequest("POST", "set-credential-expiry", url.Values{
+		"access-key": {cred.AccessKey},
+		"expiry":     {time.Now().UTC().Add(-time.Minute).Format(time.RFC3339)},
+		"confirm":    {"true"},
+	}); rec.Code != http.StatusOK {
+		t.Fatalf("Expected set-credential-expiry to succeed, got %d", rec.Code)
+	}
+	rec = sendRequest("GET", "get-credential-expiry", getExpiry)
+	var errResp APIErrorResponse
+	if err = xml.Unmarshal(rec.Body.Bytes(), &errResp); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusForbidden || errResp.Code != "XMinioAccessKeyExpired" {
+		t.Fatalf("Expected the expired access key to be rejected, got %d %s", rec.Code, errResp.Code)
+	}
+}
@@ -36,10 +36,51 @@ func registerAdminRouter(mux *router.Router) {
 
 	// Service restart
 	adminRouter.Methods("POST").Queries("service", "").Headers(minioAdminOpHeader, "restart").HandlerFunc(adminAPI.ServiceRestartHandler)
+	// Service stop
+	adminRouter.Methods("POST").Queries("service", "").Headers(minioAdminOpHeader, "stop").HandlerFunc(adminAPI.ServiceStopHandler)
 	// Service update credentials
-	adminRouter.Methods("POST").Queries("service", "").Headers(minioAdminOpHeader, "set-credentials").HandlerFunc(adminAPI.ServiceCredentialsHandler)
+	adminRouter.Methods("POST").Queries("service", "").Headers(minioAdminOpHeader, "set-credentials").HandlerFunc(logConfigChange(adminAPI.ServiceCredentialsHandler))
+	// Get shutdown timeout
+	adminRouter.Methods("GET").Queries("service", "").Headers(minioAdminOpHeader, "get-shutdown-timeout").HandlerFunc(adminAPI.GetShutdownTimeoutHandler)
+	// Set shutdown timeout
+	adminRouter.Methods("POST").Queries("service", "").Headers(minioAdminOpHeader, "set-shutdown-timeout").HandlerFunc(logConfigChange(adminAPI.SetShutdownTimeoutHandler))
 
 	// Info operations
+	// Registered ahead of server info, which matches any info
+	// request.
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "erasure-set-status").HandlerFunc(adminAPI.ErasureSetStatusHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "throttle-status").HandlerFunc(adminAPI.ThrottleStatusHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "api-latency").HandlerFunc(adminAPI.APILatencyHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "boot-diagnostics").HandlerFunc(adminAPI.BootDiagnosticsHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "version-clock").HandlerFunc(adminAPI.VersionClockMatrixHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "disks-by-state").HandlerFunc(adminAPI.DisksByStateHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "cluster-events").HandlerFunc(adminAPI.ClusterEventsHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "wait-quorum").HandlerFunc(adminAPI.WaitForQuorumHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "rpc-conn-stats").HandlerFunc(adminAPI.RPCConnStatsHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "multipart-bytes").HandlerFunc(adminAPI.ActiveMultipartBytesHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "upload-parts").HandlerFunc(adminAPI.ListUploadPartsHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "backend").HandlerFunc(adminAPI.BackendHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "write-amplification").HandlerFunc(adminAPI.WriteAmplificationHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "cluster-checksum").HandlerFunc(adminAPI.ClusterChecksumHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "scan-progress").HandlerFunc(adminAPI.ScanProgressHandler)
+	adminRouter.Methods("POST").Queries("info", "").Headers(minioAdminOpHeader, "recalculate-usage").HandlerFunc(adminAPI.RecalculateUsageHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "rpc-errors").HandlerFunc(adminAPI.RPCErrorBreakdownHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "scanner-impact").HandlerFunc(adminAPI.ScannerImpactHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "unused-credentials").HandlerFunc(adminAPI.UnusedCredentialsHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "cluster-summary").HandlerFunc(adminAPI.ClusterSummaryHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "rebalance-skew").HandlerFunc(adminAPI.RebalanceSkewHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "startup-errors").HandlerFunc(adminAPI.StartupErrorsHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "mem-summary").HandlerFunc(adminAPI.MemSummaryHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "version-skew").HandlerFunc(adminAPI.VersionSkewReportHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "capacity-forecast").HandlerFunc(adminAPI.CapacityForecastHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "object-distribution").HandlerFunc(adminAPI.ObjectDistributionHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "reject-reasons").HandlerFunc(adminAPI.RejectReasonsHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "slow-requests").HandlerFunc(adminAPI.SlowRequestLogHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "disk-io").HandlerFunc(adminAPI.DiskIOStatsHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "client-connections").HandlerFunc(adminAPI.ClientConnectionsHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "last-access").HandlerFunc(adminAPI.LastAccessReportHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "decode-failures").HandlerFunc(adminAPI.DecodeFailureLogHandler)
+	adminRouter.Methods("GET").Queries("info", "").Headers(minioAdminOpHeader, "shard-diagnosis").HandlerFunc(adminAPI.ShardDiagnosisHandler)
 	adminRouter.Methods("GET").Queries("info", "").HandlerFunc(adminAPI.ServerInfoHandler)
 
 	/// Lock operations
@@ -48,6 +89,12 @@ func registerAdminRouter(mux *router.Router) {
 	adminRouter.Methods("GET").Queries("lock", "").Headers(minioAdminOpHeader, "list").HandlerFunc(adminAPI.ListLocksHandler)
 	// Clear locks
 	adminRouter.Methods("POST").Queries("lock", "").Headers(minioAdminOpHeader, "clear").HandlerFunc(adminAPI.ClearLocksHandler)
+	// Dump lock graph
+	adminRouter.Methods("GET").Queries("lock", "").Headers(minioAdminOpHeader, "graph").HandlerFunc(adminAPI.DumpLockGraphHandler)
+	// Lock hold histogram
+	adminRouter.Methods("GET").Queries("lock", "").Headers(minioAdminOpHeader, "hold-histogram").HandlerFunc(adminAPI.LockHoldHistogramHandler)
+	// Lock origin breakdown
+	adminRouter.Methods("GET").Queries("lock", "").Headers(minioAdminOpHeader, "origin-breakdown").HandlerFunc(adminAPI.LockOriginBreakdownHandler)
 
 	/// Heal operations
 
@@ -62,9 +109,146 @@ func registerAdminRouter(mux *router.Router) {
 	adminRouter.Methods("POST").Queries("heal", "").Headers(minioAdminOpHeader, "object").HandlerFunc(adminAPI.HealObjectHandler)
 	// Heal Format.
 	adminRouter.Methods("POST").Queries("heal", "").Headers(minioAdminOpHeader, "format").HandlerFunc(adminAPI.HealFormatHandler)
+	// Heal all objects of a bucket.
+	adminRouter.Methods("POST").Queries("heal", "").Headers(minioAdminOpHeader, "force-bucket").HandlerFunc(adminAPI.ForceHealBucketHandler)
+	// Get heal job status.
+	adminRouter.Methods("GET").Queries("heal", "").Headers(minioAdminOpHeader, "status").HandlerFunc(adminAPI.HealStatusHandler)
+	// Get recently completed heal jobs.
+	adminRouter.Methods("GET").Queries("heal", "").Headers(minioAdminOpHeader, "history").HandlerFunc(adminAPI.HealHistoryHandler)
+	// Get heal delete policy.
+	adminRouter.Methods("GET").Queries("heal", "").Headers(minioAdminOpHeader, "get-delete-policy").HandlerFunc(adminAPI.GetHealDeletePolicyHandler)
+	// Set heal delete policy.
+	adminRouter.Methods("POST").Queries("heal", "").Headers(minioAdminOpHeader, "set-delete-policy").HandlerFunc(logConfigChange(adminAPI.SetHealDeletePolicyHandler))
+	// Get the cap of object heals running at once.
+	adminRouter.Methods("GET").Queries("heal", "").Headers(minioAdminOpHeader, "get-max-concurrent").HandlerFunc(adminAPI.GetMaxConcurrentHealsHandler)
+	// Set the cap of object heals running at once.
+	adminRouter.Methods("POST").Queries("heal", "").Headers(minioAdminOpHeader, "set-max-concurrent").HandlerFunc(logConfigChange(adminAPI.SetMaxConcurrentHealsHandler))
 
 	/// Config operations
 
 	// Get config
 	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get").HandlerFunc(adminAPI.GetConfigHandler)
+	// Set config
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set").HandlerFunc(logConfigChange(adminAPI.SetConfigHandler))
+
+	// Get presigned URL expiry limit
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-presign-expiry").HandlerFunc(adminAPI.GetPresignExpiryHandler)
+	// Set presigned URL expiry limit
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-presign-expiry").HandlerFunc(logConfigChange(adminAPI.SetPresignExpiryHandler))
+
+	// Get object naming policy
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-key-naming-policy").HandlerFunc(adminAPI.GetKeyNamingPolicyHandler)
+	// Set object naming policy
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-key-naming-policy").HandlerFunc(logConfigChange(adminAPI.SetKeyNamingPolicyHandler))
+
+	// Get multipart upload lifetime
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-multipart-lifetime").HandlerFunc(adminAPI.GetMultipartLifetimeHandler)
+	// Set multipart upload lifetime
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-multipart-lifetime").HandlerFunc(logConfigChange(adminAPI.SetMultipartLifetimeHandler))
+
+	// Get conditional writes toggle
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-conditional-writes").HandlerFunc(adminAPI.GetConditionalWritesHandler)
+	// Set conditional writes toggle
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-conditional-writes").HandlerFunc(logConfigChange(adminAPI.SetConditionalWritesHandler))
+
+	// Get bucket tags
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-bucket-tags").HandlerFunc(adminAPI.GetBucketTagsHandler)
+	// Set bucket tags
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-bucket-tags").HandlerFunc(logConfigChange(adminAPI.SetBucketTagsHandler))
+
+	// Get bucket immutability window
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-immutability-window").HandlerFunc(adminAPI.GetImmutabilityWindowHandler)
+	// Set bucket immutability window
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-immutability-window").HandlerFunc(logConfigChange(adminAPI.SetImmutabilityWindowHandler))
+
+	// Get allowed signature versions
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-signature-versions").HandlerFunc(adminAPI.GetSignatureVersionsHandler)
+	// Set allowed signature versions
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-signature-versions").HandlerFunc(logConfigChange(adminAPI.SetSignatureVersionsHandler))
+
+	// Get TLS cipher suites
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-tls-ciphers").HandlerFunc(adminAPI.GetTLSCiphersHandler)
+	// Set TLS cipher suites
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-tls-ciphers").HandlerFunc(logConfigChange(adminAPI.SetTLSCiphersHandler))
+
+	// Get max upload parts
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-max-upload-parts").HandlerFunc(adminAPI.GetMaxUploadPartsHandler)
+	// Set max upload parts
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-max-upload-parts").HandlerFunc(logConfigChange(adminAPI.SetMaxUploadPartsHandler))
+
+	// Get request ID configuration
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-request-id-config").HandlerFunc(adminAPI.GetRequestIDConfigHandler)
+	// Set request ID configuration
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-request-id-config").HandlerFunc(logConfigChange(adminAPI.SetRequestIDConfigHandler))
+
+	// Get bucket object limit
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-object-limit").HandlerFunc(adminAPI.GetBucketObjectLimitHandler)
+	// Set bucket object limit
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-object-limit").HandlerFunc(logConfigChange(adminAPI.SetBucketObjectLimitHandler))
+
+	// Get trusted proxies
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-trusted-proxies").HandlerFunc(adminAPI.GetTrustedProxiesHandler)
+	// Set trusted proxies
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-trusted-proxies").HandlerFunc(logConfigChange(adminAPI.SetTrustedProxiesHandler))
+
+	// Get bucket logging
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-bucket-logging").HandlerFunc(adminAPI.GetBucketLoggingHandler)
+	// Set bucket logging
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-bucket-logging").HandlerFunc(logConfigChange(adminAPI.SetBucketLoggingHandler))
+
+	// Evaluate bucket policies without sending a request
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "evaluate-policy").HandlerFunc(adminAPI.EvaluatePolicyHandler)
+
+	// Get object checksum policy
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-checksum-policy").HandlerFunc(adminAPI.GetChecksumPolicyHandler)
+	// Set object checksum policy
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-checksum-policy").HandlerFunc(logConfigChange(adminAPI.SetChecksumPolicyHandler))
+
+	// Get notification retry policy
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-notification-retry").HandlerFunc(adminAPI.GetNotificationRetryHandler)
+	// Set notification retry policy
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-notification-retry").HandlerFunc(logConfigChange(adminAPI.SetNotificationRetryHandler))
+
+	// Get max list depth
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-max-list-depth").HandlerFunc(adminAPI.GetMaxListDepthHandler)
+	// Set max list depth
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-max-list-depth").HandlerFunc(logConfigChange(adminAPI.SetMaxListDepthHandler))
+	// Get bucket part size limits
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-part-size-limits").HandlerFunc(adminAPI.GetBucketPartSizeLimitsHandler)
+	// Set bucket part size limits
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-part-size-limits").HandlerFunc(logConfigChange(adminAPI.SetBucketPartSizeLimitsHandler))
+	// Get bucket delete protection tag
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-delete-protection").HandlerFunc(adminAPI.GetDeleteProtectionTagHandler)
+	// Set bucket delete protection tag
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-delete-protection").HandlerFunc(logConfigChange(adminAPI.SetDeleteProtectionTagHandler))
+	// Get the config changes made on all servers
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "change-log").HandlerFunc(adminAPI.ConfigChangeLogHandler)
+	// Get scan on write
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-scan-on-write").HandlerFunc(adminAPI.GetScanOnWriteHandler)
+	// Set scan on write
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-scan-on-write").HandlerFunc(logConfigChange(adminAPI.SetScanOnWriteHandler))
+	// Get access key expiry
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-credential-expiry").HandlerFunc(adminAPI.GetCredentialExpiryHandler)
+	// Set access key expiry
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-credential-expiry").HandlerFunc(logConfigChange(adminAPI.SetCredentialExpiryHandler))
+	// Get multi-delete limit
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-multi-delete-limit").HandlerFunc(adminAPI.GetMultiDeleteLimitHandler)
+	// Set multi-delete limit
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-multi-delete-limit").HandlerFunc(logConfigChange(adminAPI.SetMultiDeleteLimitHandler))
+	// Get background append
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-background-append").HandlerFunc(adminAPI.GetBackgroundAppendHandler)
+	// Set background append
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-background-append").HandlerFunc(logConfigChange(adminAPI.SetBackgroundAppendHandler))
+	// Get bucket concurrent upload limit
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-upload-limit").HandlerFunc(adminAPI.GetBucketUploadLimitHandler)
+	// Set bucket concurrent upload limit
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-upload-limit").HandlerFunc(logConfigChange(adminAPI.SetBucketUploadLimitHandler))
+	// Get maintenance schedule
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-maintenance-schedule").HandlerFunc(adminAPI.GetMaintenanceScheduleHandler)
+	// Set maintenance schedule
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-maintenance-schedule").HandlerFunc(logConfigChange(adminAPI.SetMaintenanceScheduleHandler))
+	// Get quorum override
+	adminRouter.Methods("GET").Queries("config", "").Headers(minioAdminOpHeader, "get-quorum-override").HandlerFunc(adminAPI.GetQuorumOverrideHandler)
+	// Set quorum override
+	adminRouter.Methods("POST").Queries("config", "").Headers(minioAdminOpHeader, "set-quorum-override").HandlerFunc(logConfigChange(adminAPI.SetQuorumOverrideHandler))
 }
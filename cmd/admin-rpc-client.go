@@ -17,12 +17,15 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/url"
 	"path"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -31,72 +34,216 @@ import (
 type localAdminClient struct {
 }
 
+// adminRPCTimeout - maximum time an admin RPC call to a peer is
+// waited for.
+const adminRPCTimeout = time.Minute
+
 // remoteAdminClient - represents admin operation to be executed
 // remotely, via RPC.
 type remoteAdminClient struct {
 	*AuthRPCClient
 }
 
+// Call - executes an admin RPC call on the remote server, counting the
+// error returned if any. Returns once ctx is done or adminRPCTimeout
+// elapsed, the connection to the server is closed then.
+func (rc remoteAdminClient) Call(ctx context.Context, serviceMethod string, args interface {
+	SetAuthToken(authToken string)
+}, reply interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adminRPCTimeout)
+	defer cancel()
+	err := rc.AuthRPCClient.CallContext(ctx, serviceMethod, args, reply)
+	globalRPCErrors.record(rc.ServerAddr(), err)
+	return err
+}
+
+// newConfigChangeArgs - returns the args of an RPC changing the config,
+// carrying the config change requested through the admin API ctx is
+// carrying.
+func newConfigChangeArgs(ctx context.Context) ConfigChangeArgs {
+	return ConfigChangeArgs{Change: configChangeFromContext(ctx)}
+}
+
 // adminCmdRunner - abstracts local and remote execution of admin
-// commands like service stop and service restart.
+// commands like service stop and service restart. Commands return once
+// ctx is done, whether the command was carried out then is unknown.
+// Remote commands return after adminRPCTimeout at the latest.
 type adminCmdRunner interface {
-	Restart() error
-	ListLocks(bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error)
-	ReInitDisks() error
-	Uptime() (time.Duration, error)
-	GetConfig() ([]byte, error)
+	Restart(ctx context.Context) error
+	Stop(ctx context.Context) error
+	ListLocks(ctx context.Context, bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error)
+	ForceUnlock(ctx context.Context, bucket, object string) error
+	ReInitDisks(ctx context.Context) error
+	Uptime(ctx context.Context) (time.Duration, error)
+	GetConfig(ctx context.Context) ([]byte, error)
+	SetConfig(ctx context.Context, config []byte) error
+	ErasureSetStatus(ctx context.Context) ([]SetStatus, error)
+	SetMaxPresignExpiry(ctx context.Context, expiry time.Duration) error
+	ThrottleStatus(ctx context.Context) ([]ThrottleStatus, error)
+	DumpLocks(ctx context.Context) ([]VolumeLockInfo, error)
+	SetHealDeletePolicy(ctx context.Context, mode string) error
+	APILatency(ctx context.Context) (map[string]LatencyHistogram, error)
+	SetKeyNamingPolicy(ctx context.Context, policy KeyPolicy) error
+	BootDiagnostics(ctx context.Context) (BootTimings, error)
+	SetMultipartLifetime(ctx context.Context, lifetime time.Duration) error
+	VersionClock(ctx context.Context) (ServerVersionClock, error)
+	SetConditionalWrites(ctx context.Context, enabled bool) error
+	DisksByState(ctx context.Context, state string) ([]DiskInfoMsg, error)
+	ClusterEvents(ctx context.Context, since uint64) (ClusterEventBatch, error)
+	SetBucketTags(ctx context.Context, bucket string, tags map[string]string) error
+	SetAllowedSignatureVersions(ctx context.Context, versions []string) error
+	RPCConnStats(ctx context.Context) ([]RPCConnStats, error)
+	ForceHealBucket(ctx context.Context, id, bucket string, part, parts int) error
+	HealJobs(ctx context.Context) ([]HealJobStatus, error)
+	SetImmutabilityWindow(ctx context.Context, bucket string, window time.Duration) error
+	ActiveMultipartBytes(ctx context.Context) (int64, error)
+	LockHoldHistogram(ctx context.Context) (LockHoldHistograms, error)
+	ListParts(ctx context.Context, bucket, object, uploadID string) ([]PartInfo, error)
+	Backend(ctx context.Context) (BackendInfo, error)
+	RecalculateUsage(ctx context.Context, id, bucket string, part, parts int) error
+	ScanProgress(ctx context.Context) ([]UsageScanStatus, error)
+	SetTLSCiphers(ctx context.Context, suites []string) error
+	WriteAmplification(ctx context.Context) (WriteAmplification, error)
+	SetMaxUploadParts(ctx context.Context, parts int) error
+	BucketChecksum(ctx context.Context, bucket string) (BucketChecksum, error)
+	SetRequestIDConfig(ctx context.Context, header string, honorClientID bool) error
+	HealHistory(ctx context.Context, n int) ([]HealRecord, error)
+	RPCErrors(ctx context.Context) ([]PeerRPCErrors, error)
+	SetBucketObjectLimit(ctx context.Context, bucket string, maxObjects int64) error
+	ScannerImpact(ctx context.Context) (ImpactEstimate, error)
+	SetTrustedProxies(ctx context.Context, cidrs []string) error
+	SetBucketLogging(ctx context.Context, bucket string, targetBucket, prefix string) error
+	CredentialUsage(ctx context.Context) ([]CredUsage, error)
+	SetMaxConcurrentHeals(ctx context.Context, max int) error
+	SetShutdownTimeout(ctx context.Context, timeout time.Duration) error
+	SetChecksumPolicy(ctx context.Context, required bool, algorithms []string) error
+	Summary(ctx context.Context) (ServerSummary, error)
+	DiskFill(ctx context.Context) ([]DiskFill, error)
+	SetNotificationRetry(ctx context.Context, maxRetries int, backoff time.Duration, deadLetterTarget string) error
+	SetMaxListDepth(ctx context.Context, depth int) error
+	StartupErrors(ctx context.Context) ([]StartupError, error)
+	MemSummary(ctx context.Context) (MemStats, error)
+	SetBucketPartSizeLimits(ctx context.Context, bucket string, minPart, maxPart int64) error
+	SetDeleteProtectionTag(ctx context.Context, bucket, tagKey, tagValue string) error
+	ConfigChangeLog(ctx context.Context, n int) ([]ConfigChange, error)
+	SetScanOnWrite(ctx context.Context, enabled bool) error
+	SetCredentialExpiry(ctx context.Context, accessKey string, expiry time.Time) error
+	ObjectDistribution(ctx context.Context, bucket string) ([]SetObjects, error)
+	SetMultiDeleteLimit(ctx context.Context, maxKeys int) error
+	RejectReasons(ctx context.Context, window time.Duration) (map[string]int, error)
+	SlowRequestLog(ctx context.Context, thresholdMs int, n int) ([]SlowRequest, error)
+	DiskIOStats(ctx context.Context) ([]DiskIO, error)
+	ClientConnections(ctx context.Context) (map[string]ClientConnStats, error)
+	SetBackgroundAppend(ctx context.Context, enabled bool) error
+	LastAccessTimes(ctx context.Context, bucket, prefix string) (map[string]time.Time, error)
+	SetBucketUploadLimit(ctx context.Context, bucket string, maxUploads int) error
+	DecodeFailureLog(ctx context.Context, n int) (NodeDecodeFailures, error)
+	ShardHealth(ctx context.Context, bucket, object string) ([]ShardHealth, error)
+	SetMaintenanceSchedule(ctx context.Context, schedule MaintenanceSchedule) error
+	SetQuorumOverride(ctx context.Context, readQuorum, writeQuorum int, ttl time.Duration) error
 }
 
 // Restart - Sends a message over channel to the go-routine
 // responsible for restarting the process.
-func (lc localAdminClient) Restart() error {
+func (lc localAdminClient) Restart(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	globalServiceSignalCh <- serviceRestart
 	return nil
 }
 
+// Stop - Sends a message over channel to the go-routine
+// responsible for stopping the process.
+func (lc localAdminClient) Stop(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	globalServiceSignalCh <- serviceStop
+	return nil
+}
+
 // ListLocks - Fetches lock information from local lock instrumentation.
-func (lc localAdminClient) ListLocks(bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
+func (lc localAdminClient) ListLocks(ctx context.Context, bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	return listLocksInfo(bucket, prefix, duration), nil
 }
 
+// ForceUnlock - removes the namespace lock on bucket/object from local
+// lock instrumentation, releasing the distributed lock in a
+// distributed setup. Unlocking a lock not held is not an error.
+func (lc localAdminClient) ForceUnlock(ctx context.Context, bucket, object string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	globalNSMutex.ForceUnlock(bucket, object)
+	return nil
+}
+
 // Restart - Sends restart command to remote server via RPC.
-func (rc remoteAdminClient) Restart() error {
+func (rc remoteAdminClient) Restart(ctx context.Context) error {
+	args := AuthRPCArgs{}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.Restart", &args, &reply)
+}
+
+// Stop - Sends stop command to remote server via RPC.
+func (rc remoteAdminClient) Stop(ctx context.Context) error {
 	args := AuthRPCArgs{}
 	reply := AuthRPCReply{}
-	return rc.Call("Admin.Restart", &args, &reply)
+	return rc.Call(ctx, "Admin.Stop", &args, &reply)
 }
 
 // ListLocks - Sends list locks command to remote server via RPC.
-func (rc remoteAdminClient) ListLocks(bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
+func (rc remoteAdminClient) ListLocks(ctx context.Context, bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
 	listArgs := ListLocksQuery{
 		bucket:   bucket,
 		prefix:   prefix,
 		duration: duration,
 	}
 	var reply ListLocksReply
-	if err := rc.Call("Admin.ListLocks", &listArgs, &reply); err != nil {
+	if err := rc.Call(ctx, "Admin.ListLocks", &listArgs, &reply); err != nil {
 		return nil, err
 	}
 	return reply.volLocks, nil
 }
 
+// ForceUnlock - Sends force unlock command to remote server via RPC.
+func (rc remoteAdminClient) ForceUnlock(ctx context.Context, bucket, object string) error {
+	args := ForceUnlockArgs{Bucket: bucket, Object: object}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.ForceUnlock", &args, &reply)
+}
+
 // ReInitDisks - There is nothing to do here, heal format REST API
 // handler has already formatted and reinitialized the local disks.
-func (lc localAdminClient) ReInitDisks() error {
+func (lc localAdminClient) ReInitDisks(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	return nil
 }
 
 // ReInitDisks - Signals peers via RPC to reinitialize their disks and
 // object layer.
-func (rc remoteAdminClient) ReInitDisks() error {
+func (rc remoteAdminClient) ReInitDisks(ctx context.Context) error {
 	args := AuthRPCArgs{}
 	reply := AuthRPCReply{}
-	return rc.Call("Admin.ReInitDisks", &args, &reply)
+	return rc.Call(ctx, "Admin.ReInitDisks", &args, &reply)
 }
 
 // Uptime - Returns the uptime of this server. Timestamp is taken
 // after object layer is initialized.
-func (lc localAdminClient) Uptime() (time.Duration, error) {
+func (lc localAdminClient) Uptime(ctx context.Context) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
 	if globalBootTime.IsZero() {
 		return time.Duration(0), errServerNotInitialized
 	}
@@ -105,10 +252,10 @@ func (lc localAdminClient) Uptime() (time.Duration, error) {
 }
 
 // Uptime - returns the uptime of the server to which the RPC call is made.
-func (rc remoteAdminClient) Uptime() (time.Duration, error) {
+func (rc remoteAdminClient) Uptime(ctx context.Context) (time.Duration, error) {
 	args := AuthRPCArgs{}
 	reply := UptimeReply{}
-	err := rc.Call("Admin.Uptime", &args, &reply)
+	err := rc.Call(ctx, "Admin.Uptime", &args, &reply)
 	if err != nil {
 		return time.Duration(0), err
 	}
@@ -117,7 +264,10 @@ func (rc remoteAdminClient) Uptime() (time.Duration, error) {
 }
 
 // GetConfig - returns config.json of the local server.
-func (lc localAdminClient) GetConfig() ([]byte, error) {
+func (lc localAdminClient) GetConfig(ctx context.Context) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if serverConfig == nil {
 		return nil, errors.New("config not present")
 	}
@@ -126,366 +276,3571 @@ func (lc localAdminClient) GetConfig() ([]byte, error) {
 }
 
 // GetConfig - returns config.json of the remote server.
-func (rc remoteAdminClient) GetConfig() ([]byte, error) {
+func (rc remoteAdminClient) GetConfig(ctx context.Context) ([]byte, error) {
 	args := AuthRPCArgs{}
 	reply := ConfigReply{}
-	if err := rc.Call("Admin.GetConfig", &args, &reply); err != nil {
+	if err := rc.Call(ctx, "Admin.GetConfig", &args, &reply); err != nil {
 		return nil, err
 	}
 	return reply.Config, nil
 }
 
-// adminPeer - represents an entity that implements Restart methods.
-type adminPeer struct {
-	addr      string
-	cmdRunner adminCmdRunner
+// SetConfig - validates config.json and sets it on the local server.
+func (lc localAdminClient) SetConfig(ctx context.Context, config []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), setServerConfig(config))
 }
 
-// type alias for a collection of adminPeer.
-type adminPeers []adminPeer
-
-// makeAdminPeers - helper function to construct a collection of adminPeer.
-func makeAdminPeers(eps []*url.URL) adminPeers {
-	var servicePeers []adminPeer
-
-	// map to store peers that are already added to ret
-	seenAddr := make(map[string]bool)
+// SetConfig - sets config.json on the remote server.
+func (rc remoteAdminClient) SetConfig(ctx context.Context, config []byte) error {
+	args := SetConfigArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Config: config}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetConfig", &args, &reply)
+}
 
-	// add local (self) as peer in the array
-	servicePeers = append(servicePeers, adminPeer{
-		globalMinioAddr,
-		localAdminClient{},
-	})
-	seenAddr[globalMinioAddr] = true
+// getLocalErasureSetStatus - returns the health of erasure sets as
+// seen from this server.
+func getLocalErasureSetStatus() ([]SetStatus, error) {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return nil, errServerNotInitialized
+	}
 
-	serverCred := serverConfig.GetCredential()
-	// iterate over endpoints to find new remote peers and add
-	// them to ret.
-	for _, ep := range eps {
-		if ep.Host == "" {
-			continue
-		}
+	xl, ok := objLayer.(*xlObjects)
+	if !ok {
+		return nil, errUnsupportedBackend
+	}
+	return xl.ErasureSetStatus(), nil
+}
 
-		// Check if the remote host has been added already
-		if !seenAddr[ep.Host] {
-			cfg := authConfig{
-				accessKey:       serverCred.AccessKey,
-				secretKey:       serverCred.SecretKey,
-				serverAddr:      ep.Host,
-				secureConn:      globalIsSSL,
-				serviceEndpoint: path.Join(minioReservedBucketPath, adminPath),
-				serviceName:     "Admin",
-			}
+// ErasureSetStatus - returns the health of erasure sets as seen by
+// the local server.
+func (lc localAdminClient) ErasureSetStatus(ctx context.Context) ([]SetStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return getLocalErasureSetStatus()
+}
 
-			servicePeers = append(servicePeers, adminPeer{
-				addr:      ep.Host,
-				cmdRunner: &remoteAdminClient{newAuthRPCClient(cfg)},
-			})
-			seenAddr[ep.Host] = true
-		}
+// ErasureSetStatus - returns the health of erasure sets as seen by
+// the remote server.
+func (rc remoteAdminClient) ErasureSetStatus(ctx context.Context) ([]SetStatus, error) {
+	args := AuthRPCArgs{}
+	reply := SetStatusReply{}
+	if err := rc.Call(ctx, "Admin.ErasureSetStatus", &args, &reply); err != nil {
+		return nil, err
 	}
+	return reply.Sets, nil
+}
 
-	return servicePeers
+// SetMultiDeleteLimit - sets the multi-delete limit of the local
+// server.
+func (lc localAdminClient) SetMultiDeleteLimit(ctx context.Context, maxKeys int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), setMultiDeleteLimit(maxKeys))
 }
 
-// Initialize global adminPeer collection.
-func initGlobalAdminPeers(eps []*url.URL) {
-	globalAdminPeers = makeAdminPeers(eps)
+// SetMultiDeleteLimit - sets the multi-delete limit of the remote
+// server.
+func (rc remoteAdminClient) SetMultiDeleteLimit(ctx context.Context, maxKeys int) error {
+	args := MultiDeleteLimitArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), MaxKeys: maxKeys}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetMultiDeleteLimit", &args, &reply)
 }
 
-// invokeServiceCmd - Invoke Restart command.
-func invokeServiceCmd(cp adminPeer, cmd serviceSignal) (err error) {
-	switch cmd {
-	case serviceRestart:
-		err = cp.cmdRunner.Restart()
+// RejectReasons - returns the requests the local server rejected over
+// window, by reason.
+func (lc localAdminClient) RejectReasons(ctx context.Context, window time.Duration) (map[string]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	return err
+	return globalRejectReasons.get(window, time.Now().UTC())
 }
 
-// sendServiceCmd - Invoke Restart command on remote peers
-// adminPeer followed by on the local peer.
-func sendServiceCmd(cps adminPeers, cmd serviceSignal) {
-	// Send service command like stop or restart to all remote nodes and finally run on local node.
-	errs := make([]error, len(cps))
-	var wg sync.WaitGroup
-	remotePeers := cps[1:]
-	for i := range remotePeers {
-		wg.Add(1)
-		go func(idx int) {
-			defer wg.Done()
-			// we use idx+1 because remotePeers slice is 1 position shifted w.r.t cps
-			errs[idx+1] = invokeServiceCmd(remotePeers[idx], cmd)
-		}(i)
+// RejectReasons - returns the requests the remote server rejected over
+// window, by reason.
+func (rc remoteAdminClient) RejectReasons(ctx context.Context, window time.Duration) (map[string]int, error) {
+	args := RejectReasonsArgs{Window: window}
+	reply := RejectReasonsReply{}
+	if err := rc.Call(ctx, "Admin.RejectReasons", &args, &reply); err != nil {
+		return nil, err
 	}
-	wg.Wait()
-	errs[0] = invokeServiceCmd(cps[0], cmd)
+	return reply.Reasons, nil
 }
 
-// listPeerLocksInfo - fetch list of locks held on the given bucket,
-// matching prefix held longer than duration from all peer servers.
-func listPeerLocksInfo(peers adminPeers, bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
-	// Used to aggregate volume lock information from all nodes.
-	allLocks := make([][]VolumeLockInfo, len(peers))
-	errs := make([]error, len(peers))
-	var wg sync.WaitGroup
-	localPeer := peers[0]
-	remotePeers := peers[1:]
-	for i, remotePeer := range remotePeers {
-		wg.Add(1)
-		go func(idx int, remotePeer adminPeer) {
-			defer wg.Done()
-			// `remotePeers` is right-shifted by one position relative to `peers`
-			allLocks[idx], errs[idx] = remotePeer.cmdRunner.ListLocks(bucket, prefix, duration)
-		}(i+1, remotePeer)
+// SlowRequestLog - returns the last requests served by the local
+// server slower than a threshold.
+func (lc localAdminClient) SlowRequestLog(ctx context.Context, thresholdMs int, n int) ([]SlowRequest, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	wg.Wait()
-	allLocks[0], errs[0] = localPeer.cmdRunner.ListLocks(bucket, prefix, duration)
+	return getSlowRequestLog(thresholdMs, n)
+}
 
-	// Summarizing errors received for ListLocks RPC across all
-	// nodes.  N B the possible unavailability of quorum in errors
-	// applies only to distributed setup.
-	errCount, err := reduceErrs(errs, []error{})
-	if err != nil {
-		if errCount >= (len(peers)/2 + 1) {
-			return nil, err
-		}
-		return nil, InsufficientReadQuorum{}
+// SlowRequestLog - returns the last requests served by the remote
+// server slower than a threshold.
+func (rc remoteAdminClient) SlowRequestLog(ctx context.Context, thresholdMs int, n int) ([]SlowRequest, error) {
+	args := SlowRequestLogArgs{ThresholdMs: thresholdMs, N: n}
+	reply := SlowRequestLogReply{}
+	if err := rc.Call(ctx, "Admin.SlowRequestLog", &args, &reply); err != nil {
+		return nil, err
 	}
+	return reply.Requests, nil
+}
 
-	// Group lock information across nodes by (bucket, object)
-	// pair. For readability only.
-	paramLockMap := make(map[nsParam][]VolumeLockInfo)
-	for _, nodeLocks := range allLocks {
-		for _, lockInfo := range nodeLocks {
-			param := nsParam{
-				volume: lockInfo.Bucket,
-				path:   lockInfo.Object,
-			}
-			paramLockMap[param] = append(paramLockMap[param], lockInfo)
-		}
+// DiskIOStats - returns the IO activity of the disks of the local
+// server.
+func (lc localAdminClient) DiskIOStats(ctx context.Context) ([]DiskIO, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	groupedLockInfos := []VolumeLockInfo{}
-	for _, volLocks := range paramLockMap {
-		groupedLockInfos = append(groupedLockInfos, volLocks...)
+	return getLocalDiskIOStats()
+}
+
+// DiskIOStats - returns the IO activity of the disks of the remote
+// server.
+func (rc remoteAdminClient) DiskIOStats(ctx context.Context) ([]DiskIO, error) {
+	args := AuthRPCArgs{}
+	reply := DiskIOStatsReply{}
+	if err := rc.Call(ctx, "Admin.DiskIOStats", &args, &reply); err != nil {
+		return nil, err
 	}
-	return groupedLockInfos, nil
+	return reply.Disks, nil
 }
 
-// reInitPeerDisks - reinitialize disks and object layer on peer servers to use the new format.
-func reInitPeerDisks(peers adminPeers) error {
-	errs := make([]error, len(peers))
+// ClientConnections - returns the activity of the clients of the local
+// server, per client IP.
+func (lc localAdminClient) ClientConnections(ctx context.Context) (map[string]ClientConnStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return getLocalClientConnections(), nil
+}
 
-	// Send ReInitDisks RPC call to all nodes.
-	// for local adminPeer this is a no-op.
-	wg := sync.WaitGroup{}
-	for i, peer := range peers {
-		wg.Add(1)
-		go func(idx int, peer adminPeer) {
-			defer wg.Done()
-			errs[idx] = peer.cmdRunner.ReInitDisks()
-		}(i, peer)
+// ClientConnections - returns the activity of the clients of the
+// remote server, per client IP.
+func (rc remoteAdminClient) ClientConnections(ctx context.Context) (map[string]ClientConnStats, error) {
+	args := AuthRPCArgs{}
+	reply := ClientConnectionsReply{}
+	if err := rc.Call(ctx, "Admin.ClientConnections", &args, &reply); err != nil {
+		return nil, err
 	}
-	wg.Wait()
-	return nil
+	return reply.Clients, nil
 }
 
-// uptimeSlice - used to sort uptimes in chronological order.
-type uptimeSlice []struct {
-	err    error
-	uptime time.Duration
+// SetBackgroundAppend - enables or disables background append of the
+// parts of new multipart uploads on the local server.
+func (lc localAdminClient) SetBackgroundAppend(ctx context.Context, enabled bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	globalBackgroundAppend.set(enabled)
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), nil)
 }
 
-func (ts uptimeSlice) Len() int {
-	return len(ts)
+// SetBackgroundAppend - enables or disables background append of the
+// parts of new multipart uploads on the remote server.
+func (rc remoteAdminClient) SetBackgroundAppend(ctx context.Context, enabled bool) error {
+	args := BackgroundAppendArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Enabled: enabled}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetBackgroundAppend", &args, &reply)
 }
 
-func (ts uptimeSlice) Less(i, j int) bool {
-	return ts[i].uptime < ts[j].uptime
+// LastAccessTimes - returns the last read of the objects of bucket
+// under prefix served by the local server.
+func (lc localAdminClient) LastAccessTimes(ctx context.Context, bucket, prefix string) (map[string]time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return getLocalLastAccessTimes(bucket, prefix), nil
 }
 
-func (ts uptimeSlice) Swap(i, j int) {
-	ts[i], ts[j] = ts[j], ts[i]
+// LastAccessTimes - returns the last read of the objects of bucket
+// under prefix served by the remote server.
+func (rc remoteAdminClient) LastAccessTimes(ctx context.Context, bucket, prefix string) (map[string]time.Time, error) {
+	args := LastAccessTimesArgs{Bucket: bucket, Prefix: prefix}
+	reply := LastAccessTimesReply{}
+	if err := rc.Call(ctx, "Admin.LastAccessTimes", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Accesses, nil
 }
 
-// getPeerUptimes - returns the uptime since the last time read quorum
-// was established on success. Otherwise returns errXLReadQuorum.
-func getPeerUptimes(peers adminPeers) (time.Duration, error) {
-	// In a single node Erasure or FS backend setup the uptime of
-	// the setup is the uptime of the single minio server
-	// instance.
-	if !globalIsDistXL {
-		return time.Now().UTC().Sub(globalBootTime), nil
+// SetBucketUploadLimit - sets the concurrent upload limit of bucket on
+// the local server.
+func (lc localAdminClient) SetBucketUploadLimit(ctx context.Context, bucket string, maxUploads int) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalBucketUploadLimits.set(bucket, maxUploads))
+}
 
-	uptimes := make(uptimeSlice, len(peers))
+// SetBucketUploadLimit - sets the concurrent upload limit of bucket on
+// the remote server.
+func (rc remoteAdminClient) SetBucketUploadLimit(ctx context.Context, bucket string, maxUploads int) error {
+	args := BucketUploadLimitArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Bucket: bucket, MaxUploads: maxUploads}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetBucketUploadLimit", &args, &reply)
+}
 
-	// Get up time of all servers.
-	wg := sync.WaitGroup{}
-	for i, peer := range peers {
-		wg.Add(1)
-		go func(idx int, peer adminPeer) {
-			defer wg.Done()
-			uptimes[idx].uptime, uptimes[idx].err = peer.cmdRunner.Uptime()
-		}(i, peer)
+// DecodeFailureLog - returns the last n decode failures of the local
+// server and the objects it healed.
+func (lc localAdminClient) DecodeFailureLog(ctx context.Context, n int) (NodeDecodeFailures, error) {
+	if err := ctx.Err(); err != nil {
+		return NodeDecodeFailures{}, err
 	}
-	wg.Wait()
+	return getLocalDecodeFailureLog(n)
+}
 
-	// Sort uptimes in chronological order.
-	sort.Sort(uptimes)
+// DecodeFailureLog - returns the last n decode failures of the remote
+// server and the objects it healed.
+func (rc remoteAdminClient) DecodeFailureLog(ctx context.Context, n int) (NodeDecodeFailures, error) {
+	args := DecodeFailureLogArgs{N: n}
+	reply := DecodeFailureLogReply{}
+	if err := rc.Call(ctx, "Admin.DecodeFailureLog", &args, &reply); err != nil {
+		return NodeDecodeFailures{}, err
+	}
+	return reply.Log, nil
+}
 
-	// Pick the readQuorum'th uptime in chronological order. i.e,
-	// the time at which read quorum was (re-)established.
-	readQuorum := len(uptimes) / 2
-	validCount := 0
-	latestUptime := time.Duration(0)
-	for _, uptime := range uptimes {
-		if uptime.err != nil {
-			errorIf(uptime.err, "Unable to fetch uptime")
-			continue
-		}
+// ShardHealth - returns the health of the shards of an object stored
+// on the disks of the local server.
+func (lc localAdminClient) ShardHealth(ctx context.Context, bucket, object string) ([]ShardHealth, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return getLocalShardHealth(bucket, object)
+}
 
-		validCount++
-		if validCount >= readQuorum {
-			latestUptime = uptime.uptime
-			break
-		}
+// ShardHealth - returns the health of the shards of an object stored
+// on the disks of the remote server.
+func (rc remoteAdminClient) ShardHealth(ctx context.Context, bucket, object string) ([]ShardHealth, error) {
+	args := ShardHealthArgs{Bucket: bucket, Object: object}
+	reply := ShardHealthReply{}
+	if err := rc.Call(ctx, "Admin.ShardHealth", &args, &reply); err != nil {
+		return nil, err
 	}
+	return reply.Shards, nil
+}
 
-	// Less than readQuorum "Admin.Uptime" RPC call returned
-	// successfully, so read-quorum unavailable.
-	if validCount < readQuorum {
-		return time.Duration(0), InsufficientReadQuorum{}
+// SetMaintenanceSchedule - sets the maintenance schedule of the local
+// server.
+func (lc localAdminClient) SetMaintenanceSchedule(ctx context.Context, schedule MaintenanceSchedule) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalMaintenance.set(schedule))
+}
 
-	return latestUptime, nil
+// SetMaintenanceSchedule - sets the maintenance schedule of the remote
+// server.
+func (rc remoteAdminClient) SetMaintenanceSchedule(ctx context.Context, schedule MaintenanceSchedule) error {
+	args := MaintenanceScheduleArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Schedule: schedule}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetMaintenanceSchedule", &args, &reply)
 }
 
-// getPeerConfig - Fetches config.json from all nodes in the setup and
-// returns the one that occurs in a majority of them.
-func getPeerConfig(peers adminPeers) ([]byte, error) {
-	if !globalIsDistXL {
-		return peers[0].cmdRunner.GetConfig()
+// SetQuorumOverride - overrides the XL quorum of the local server for
+// ttl.
+func (lc localAdminClient) SetQuorumOverride(ctx context.Context, readQuorum, writeQuorum int, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), setLocalQuorumOverride(readQuorum, writeQuorum, ttl))
+}
 
-	errs := make([]error, len(peers))
-	configs := make([][]byte, len(peers))
-
-	// Get config from all servers.
-	wg := sync.WaitGroup{}
-	for i, peer := range peers {
-		wg.Add(1)
-		go func(idx int, peer adminPeer) {
-			defer wg.Done()
-			configs[idx], errs[idx] = peer.cmdRunner.GetConfig()
-		}(i, peer)
+// SetQuorumOverride - overrides the XL quorum of the remote server for
+// ttl.
+func (rc remoteAdminClient) SetQuorumOverride(ctx context.Context, readQuorum, writeQuorum int, ttl time.Duration) error {
+	args := QuorumOverrideArgs{
+		ConfigChangeArgs: newConfigChangeArgs(ctx),
+		ReadQuorum:       readQuorum,
+		WriteQuorum:      writeQuorum,
+		TTL:              ttl,
 	}
-	wg.Wait()
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetQuorumOverride", &args, &reply)
+}
 
-	// Find the maximally occurring config among peers in a
-	// distributed setup.
+// SetMaxPresignExpiry - sets the presigned URL expiry limit of the
+// local server.
+func (lc localAdminClient) SetMaxPresignExpiry(ctx context.Context, expiry time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), setMaxPresignExpiry(expiry))
+}
 
-	serverConfigs := make([]serverConfigV13, len(peers))
-	for i, configBytes := range configs {
-		if errs[i] != nil {
-			continue
-		}
+// SetMaxPresignExpiry - sets the presigned URL expiry limit of the
+// remote server.
+func (rc remoteAdminClient) SetMaxPresignExpiry(ctx context.Context, expiry time.Duration) error {
+	args := PresignExpiryArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Expiry: expiry}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetMaxPresignExpiry", &args, &reply)
+}
 
-		// Unmarshal the received config files.
-		err := json.Unmarshal(configBytes, &serverConfigs[i])
-		if err != nil {
-			errorIf(err, "Failed to unmarshal serverConfig from ", peers[i].addr)
-			return nil, err
-		}
+// ThrottleStatus - returns the state of limits configured on the
+// local server.
+func (lc localAdminClient) ThrottleStatus(ctx context.Context) ([]ThrottleStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
+	return globalThrottlers.status(), nil
+}
 
-	configJSON, err := getValidServerConfig(serverConfigs, errs)
-	if err != nil {
+// ThrottleStatus - returns the state of limits configured on the
+// remote server.
+func (rc remoteAdminClient) ThrottleStatus(ctx context.Context) ([]ThrottleStatus, error) {
+	args := AuthRPCArgs{}
+	reply := ThrottleStatusReply{}
+	if err := rc.Call(ctx, "Admin.ThrottleStatus", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Throttles, nil
+}
+
+// DumpLocks - returns the state of all locks on the local server.
+func (lc localAdminClient) DumpLocks(ctx context.Context) ([]VolumeLockInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dumpLocksInfo(), nil
+}
+
+// DumpLocks - returns the state of all locks on the remote server.
+func (rc remoteAdminClient) DumpLocks(ctx context.Context) ([]VolumeLockInfo, error) {
+	args := AuthRPCArgs{}
+	reply := DumpLocksReply{}
+	if err := rc.Call(ctx, "Admin.DumpLocks", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.VolLocks, nil
+}
+
+// SetHealDeletePolicy - sets the mode of dealing with orphaned
+// objects on the local server.
+func (lc localAdminClient) SetHealDeletePolicy(ctx context.Context, mode string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalHealDeletePolicy.set(mode))
+}
+
+// SetHealDeletePolicy - sets the mode of dealing with orphaned
+// objects on the remote server.
+func (rc remoteAdminClient) SetHealDeletePolicy(ctx context.Context, mode string) error {
+	args := HealDeletePolicyArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Mode: mode}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetHealDeletePolicy", &args, &reply)
+}
+
+// APILatency - returns latency histograms of S3 API operations
+// served by the local server.
+func (lc localAdminClient) APILatency(ctx context.Context) (map[string]LatencyHistogram, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return globalAPILatency.snapshot(), nil
+}
+
+// APILatency - returns latency histograms of S3 API operations
+// served by the remote server.
+func (rc remoteAdminClient) APILatency(ctx context.Context) (map[string]LatencyHistogram, error) {
+	args := AuthRPCArgs{}
+	reply := APILatencyReply{}
+	if err := rc.Call(ctx, "Admin.APILatency", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Histograms, nil
+}
+
+// SetKeyNamingPolicy - sets the object naming policy of the local
+// server.
+func (lc localAdminClient) SetKeyNamingPolicy(ctx context.Context, policy KeyPolicy) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalKeyNamingPolicy.set(policy))
+}
+
+// SetKeyNamingPolicy - sets the object naming policy of the remote
+// server.
+func (rc remoteAdminClient) SetKeyNamingPolicy(ctx context.Context, policy KeyPolicy) error {
+	args := KeyPolicyArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Policy: policy}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetKeyNamingPolicy", &args, &reply)
+}
+
+// BootDiagnostics - returns time taken by startup phases of the
+// local server.
+func (lc localAdminClient) BootDiagnostics(ctx context.Context) (BootTimings, error) {
+	if err := ctx.Err(); err != nil {
+		return BootTimings{}, err
+	}
+	return globalBootDiagnostics.timings(), nil
+}
+
+// BootDiagnostics - returns time taken by startup phases of the
+// remote server.
+func (rc remoteAdminClient) BootDiagnostics(ctx context.Context) (BootTimings, error) {
+	args := AuthRPCArgs{}
+	reply := BootTimingsReply{}
+	if err := rc.Call(ctx, "Admin.BootDiagnostics", &args, &reply); err != nil {
+		return BootTimings{}, err
+	}
+	return reply.Timings, nil
+}
+
+// SetMultipartLifetime - sets the time after which idle multipart
+// uploads are aborted on the local server.
+func (lc localAdminClient) SetMultipartLifetime(ctx context.Context, lifetime time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalMultipartLifetime.set(lifetime))
+}
+
+// SetMultipartLifetime - sets the time after which idle multipart
+// uploads are aborted on the remote server.
+func (rc remoteAdminClient) SetMultipartLifetime(ctx context.Context, lifetime time.Duration) error {
+	args := MultipartLifetimeArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Lifetime: lifetime}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetMultipartLifetime", &args, &reply)
+}
+
+// VersionClock - returns version and clock of the local server.
+func (lc localAdminClient) VersionClock(ctx context.Context) (ServerVersionClock, error) {
+	if err := ctx.Err(); err != nil {
+		return ServerVersionClock{}, err
+	}
+	return getLocalVersionClock(), nil
+}
+
+// VersionClock - returns version and clock of the remote server.
+func (rc remoteAdminClient) VersionClock(ctx context.Context) (ServerVersionClock, error) {
+	args := AuthRPCArgs{}
+	reply := VersionClockReply{}
+	if err := rc.Call(ctx, "Admin.VersionClock", &args, &reply); err != nil {
+		return ServerVersionClock{}, err
+	}
+	return reply.Info, nil
+}
+
+// SetConditionalWrites - enables or disables conditional writes on
+// the local server.
+func (lc localAdminClient) SetConditionalWrites(ctx context.Context, enabled bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	globalConditionalWrites.set(enabled)
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), nil)
+}
+
+// SetConditionalWrites - enables or disables conditional writes on
+// the remote server.
+func (rc remoteAdminClient) SetConditionalWrites(ctx context.Context, enabled bool) error {
+	args := ConditionalWritesArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Enabled: enabled}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetConditionalWrites", &args, &reply)
+}
+
+// DisksByState - returns the disks of the local server in the given
+// state.
+func (lc localAdminClient) DisksByState(ctx context.Context, state string) ([]DiskInfoMsg, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return getLocalDisksByState(state)
+}
+
+// DisksByState - returns the disks of the remote server in the given
+// state.
+func (rc remoteAdminClient) DisksByState(ctx context.Context, state string) ([]DiskInfoMsg, error) {
+	args := DiskStateArgs{State: state}
+	reply := DisksByStateReply{}
+	if err := rc.Call(ctx, "Admin.DisksByState", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Disks, nil
+}
+
+// ClusterEvents - returns the events of the local server after since.
+func (lc localAdminClient) ClusterEvents(ctx context.Context, since uint64) (ClusterEventBatch, error) {
+	if err := ctx.Err(); err != nil {
+		return ClusterEventBatch{}, err
+	}
+	return globalClusterEvents.since(since), nil
+}
+
+// ClusterEvents - returns the events of the remote server after since.
+func (rc remoteAdminClient) ClusterEvents(ctx context.Context, since uint64) (ClusterEventBatch, error) {
+	args := ClusterEventsArgs{Since: since}
+	reply := ClusterEventsReply{}
+	if err := rc.Call(ctx, "Admin.ClusterEvents", &args, &reply); err != nil {
+		return ClusterEventBatch{}, err
+	}
+	return reply.Batch, nil
+}
+
+// SetBucketTags - updates in-memory tags of bucket on the local
+// server.
+func (lc localAdminClient) SetBucketTags(ctx context.Context, bucket string, tags map[string]string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	globalBucketTags.set(bucket, tags)
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), nil)
+}
+
+// SetBucketTags - updates in-memory tags of bucket on the remote
+// server.
+func (rc remoteAdminClient) SetBucketTags(ctx context.Context, bucket string, tags map[string]string) error {
+	args := BucketTagsArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Bucket: bucket, Tags: tags}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetBucketTags", &args, &reply)
+}
+
+// SetAllowedSignatureVersions - sets the signature versions accepted
+// by the local server.
+func (lc localAdminClient) SetAllowedSignatureVersions(ctx context.Context, versions []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalAllowedSignatures.set(versions))
+}
+
+// SetAllowedSignatureVersions - sets the signature versions accepted
+// by the remote server.
+func (rc remoteAdminClient) SetAllowedSignatureVersions(ctx context.Context, versions []string) error {
+	args := SignatureVersionsArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Versions: versions}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetAllowedSignatureVersions", &args, &reply)
+}
+
+// RPCConnStats - returns usage of the RPC clients of the local server.
+func (lc localAdminClient) RPCConnStats(ctx context.Context) ([]RPCConnStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return globalRPCClients.stats(), nil
+}
+
+// RPCConnStats - returns usage of the RPC clients of the remote
+// server.
+func (rc remoteAdminClient) RPCConnStats(ctx context.Context) ([]RPCConnStats, error) {
+	args := AuthRPCArgs{}
+	reply := RPCConnStatsReply{}
+	if err := rc.Call(ctx, "Admin.RPCConnStats", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Conns, nil
+}
+
+// ForceHealBucket - starts healing the part of bucket assigned to the
+// local server as heal job id.
+func (lc localAdminClient) ForceHealBucket(ctx context.Context, id, bucket string, part, parts int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return startHealJob(globalHealJobs, id, bucket, part, parts)
+}
+
+// ForceHealBucket - starts healing the part of bucket assigned to the
+// remote server as heal job id.
+func (rc remoteAdminClient) ForceHealBucket(ctx context.Context, id, bucket string, part, parts int) error {
+	args := ForceHealBucketArgs{ID: id, Bucket: bucket, Part: part, Parts: parts}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.ForceHealBucket", &args, &reply)
+}
+
+// HealJobs - returns the heal jobs run by the local server.
+func (lc localAdminClient) HealJobs(ctx context.Context) ([]HealJobStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return globalHealJobs.list(), nil
+}
+
+// HealJobs - returns the heal jobs run by the remote server.
+func (rc remoteAdminClient) HealJobs(ctx context.Context) ([]HealJobStatus, error) {
+	args := AuthRPCArgs{}
+	reply := HealJobsReply{}
+	if err := rc.Call(ctx, "Admin.HealJobs", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Jobs, nil
+}
+
+// SetImmutabilityWindow - updates in-memory immutability window of
+// bucket on the local server.
+func (lc localAdminClient) SetImmutabilityWindow(ctx context.Context, bucket string, window time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalImmutabilityWindows.set(bucket, window))
+}
+
+// SetImmutabilityWindow - updates in-memory immutability window of
+// bucket on the remote server.
+func (rc remoteAdminClient) SetImmutabilityWindow(ctx context.Context, bucket string, window time.Duration) error {
+	args := ImmutabilityWindowArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Bucket: bucket, Window: window}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetImmutabilityWindow", &args, &reply)
+}
+
+// ActiveMultipartBytes - returns the bytes held by in-progress
+// multipart uploads on the disks of the local server.
+func (lc localAdminClient) ActiveMultipartBytes(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return getLocalMultipartBytes(globalEndpoints), nil
+}
+
+// ActiveMultipartBytes - returns the bytes held by in-progress
+// multipart uploads on the disks of the remote server.
+func (rc remoteAdminClient) ActiveMultipartBytes(ctx context.Context) (int64, error) {
+	args := AuthRPCArgs{}
+	reply := ActiveMultipartBytesReply{}
+	if err := rc.Call(ctx, "Admin.ActiveMultipartBytes", &args, &reply); err != nil {
+		return 0, err
+	}
+	return reply.Bytes, nil
+}
+
+// LockHoldHistogram - returns how long locks taken by the local server
+// were and are held.
+func (lc localAdminClient) LockHoldHistogram(ctx context.Context) (LockHoldHistograms, error) {
+	if err := ctx.Err(); err != nil {
+		return LockHoldHistograms{}, err
+	}
+	return getLocalLockHoldHistograms(), nil
+}
+
+// LockHoldHistogram - returns how long locks taken by the remote
+// server were and are held.
+func (rc remoteAdminClient) LockHoldHistogram(ctx context.Context) (LockHoldHistograms, error) {
+	args := AuthRPCArgs{}
+	reply := LockHoldHistogramReply{}
+	if err := rc.Call(ctx, "Admin.LockHoldHistogram", &args, &reply); err != nil {
+		return LockHoldHistograms{}, err
+	}
+	return reply.Histograms, nil
+}
+
+// ListParts - returns all parts of a multipart upload as seen by the
+// local server.
+func (lc localAdminClient) ListParts(ctx context.Context, bucket, object, uploadID string) ([]PartInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return listLocalUploadParts(bucket, object, uploadID)
+}
+
+// ListParts - returns all parts of a multipart upload as seen by the
+// remote server.
+func (rc remoteAdminClient) ListParts(ctx context.Context, bucket, object, uploadID string) ([]PartInfo, error) {
+	args := ListPartsArgs{Bucket: bucket, Object: object, UploadID: uploadID}
+	reply := ListPartsReply{}
+	if err := rc.Call(ctx, "Admin.ListParts", &args, &reply); err != nil {
+		return nil, err
+	}
+	if reply.UploadNotFound {
+		return nil, InvalidUploadID{UploadID: uploadID}
+	}
+	return reply.Parts, nil
+}
+
+// Backend - returns the backend of the local server.
+func (lc localAdminClient) Backend(ctx context.Context) (BackendInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return BackendInfo{}, err
+	}
+	return getLocalBackendInfo()
+}
+
+// Backend - returns the backend of the remote server.
+func (rc remoteAdminClient) Backend(ctx context.Context) (BackendInfo, error) {
+	args := AuthRPCArgs{}
+	reply := BackendReply{}
+	if err := rc.Call(ctx, "Admin.Backend", &args, &reply); err != nil {
+		return BackendInfo{}, err
+	}
+	return reply.Info, nil
+}
+
+// RecalculateUsage - starts counting the part of bucket assigned to
+// the local server as usage scan id.
+func (lc localAdminClient) RecalculateUsage(ctx context.Context, id, bucket string, part, parts int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return startUsageScan(globalUsageScans, id, bucket, part, parts)
+}
+
+// RecalculateUsage - starts counting the part of bucket assigned to
+// the remote server as usage scan id.
+func (rc remoteAdminClient) RecalculateUsage(ctx context.Context, id, bucket string, part, parts int) error {
+	args := RecalculateUsageArgs{ID: id, Bucket: bucket, Part: part, Parts: parts}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.RecalculateUsage", &args, &reply)
+}
+
+// ScanProgress - returns the usage scans run by the local server.
+func (lc localAdminClient) ScanProgress(ctx context.Context) ([]UsageScanStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return globalUsageScans.list(), nil
+}
+
+// ScanProgress - returns the usage scans run by the remote server.
+func (rc remoteAdminClient) ScanProgress(ctx context.Context) ([]UsageScanStatus, error) {
+	args := AuthRPCArgs{}
+	reply := ScanProgressReply{}
+	if err := rc.Call(ctx, "Admin.ScanProgress", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Scans, nil
+}
+
+// SetTLSCiphers - sets the TLS cipher suites offered by the local
+// server.
+func (lc localAdminClient) SetTLSCiphers(ctx context.Context, suites []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), setLocalTLSCiphers(suites))
+}
+
+// SetTLSCiphers - sets the TLS cipher suites offered by the remote
+// server.
+func (rc remoteAdminClient) SetTLSCiphers(ctx context.Context, suites []string) error {
+	args := TLSCiphersArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Suites: suites}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetTLSCiphers", &args, &reply)
+}
+
+// WriteAmplification - returns the write amplification of the local
+// server.
+func (lc localAdminClient) WriteAmplification(ctx context.Context) (WriteAmplification, error) {
+	if err := ctx.Err(); err != nil {
+		return WriteAmplification{}, err
+	}
+	return globalWriteAmp.get(time.Now().UTC()), nil
+}
+
+// WriteAmplification - returns the write amplification of the remote
+// server.
+func (rc remoteAdminClient) WriteAmplification(ctx context.Context) (WriteAmplification, error) {
+	args := AuthRPCArgs{}
+	reply := WriteAmplificationReply{}
+	if err := rc.Call(ctx, "Admin.WriteAmplification", &args, &reply); err != nil {
+		return WriteAmplification{}, err
+	}
+	return reply.WriteAmp, nil
+}
+
+// SetMaxUploadParts - sets the limit of parts per upload of the local
+// server.
+func (lc localAdminClient) SetMaxUploadParts(ctx context.Context, parts int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), setMaxUploadParts(parts))
+}
+
+// SetMaxUploadParts - sets the limit of parts per upload of the remote
+// server.
+func (rc remoteAdminClient) SetMaxUploadParts(ctx context.Context, parts int) error {
+	args := MaxUploadPartsArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Parts: parts}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetMaxUploadParts", &args, &reply)
+}
+
+// BucketChecksum - returns the checksum of bucket as seen by the local
+// server.
+func (lc localAdminClient) BucketChecksum(ctx context.Context, bucket string) (BucketChecksum, error) {
+	if err := ctx.Err(); err != nil {
+		return BucketChecksum{}, err
+	}
+	return getLocalBucketChecksum(bucket)
+}
+
+// BucketChecksum - returns the checksum of bucket as seen by the remote
+// server.
+func (rc remoteAdminClient) BucketChecksum(ctx context.Context, bucket string) (BucketChecksum, error) {
+	args := BucketChecksumArgs{Bucket: bucket}
+	reply := BucketChecksumReply{}
+	if err := rc.Call(ctx, "Admin.BucketChecksum", &args, &reply); err != nil {
+		return BucketChecksum{}, err
+	}
+	return reply.Checksum, nil
+}
+
+// SetRequestIDConfig - sets the request ID header of the local server.
+func (lc localAdminClient) SetRequestIDConfig(ctx context.Context, header string, honorClientID bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalRequestIDConfig.set(header, honorClientID))
+}
+
+// SetRequestIDConfig - sets the request ID header of the remote server.
+func (rc remoteAdminClient) SetRequestIDConfig(ctx context.Context, header string, honorClientID bool) error {
+	args := RequestIDConfigArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Header: header, HonorClientID: honorClientID}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetRequestIDConfig", &args, &reply)
+}
+
+// HealHistory - returns the last n heal jobs completed by the local
+// server.
+func (lc localAdminClient) HealHistory(ctx context.Context, n int) ([]HealRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return globalHealJobs.history.last(n), nil
+}
+
+// HealHistory - returns the last n heal jobs completed by the remote
+// server.
+func (rc remoteAdminClient) HealHistory(ctx context.Context, n int) ([]HealRecord, error) {
+	args := HealHistoryArgs{N: n}
+	reply := HealHistoryReply{}
+	if err := rc.Call(ctx, "Admin.HealHistory", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Records, nil
+}
+
+// RPCErrors - returns the errors of admin RPC calls of the local
+// server to its peers.
+func (lc localAdminClient) RPCErrors(ctx context.Context) ([]PeerRPCErrors, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return globalRPCErrors.get(time.Now().UTC()), nil
+}
+
+// RPCErrors - returns the errors of admin RPC calls of the remote
+// server to its peers.
+func (rc remoteAdminClient) RPCErrors(ctx context.Context) ([]PeerRPCErrors, error) {
+	args := AuthRPCArgs{}
+	reply := RPCErrorsReply{}
+	if err := rc.Call(ctx, "Admin.RPCErrors", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Peers, nil
+}
+
+// SetBucketObjectLimit - updates in-memory object limit of bucket on
+// the local server.
+func (lc localAdminClient) SetBucketObjectLimit(ctx context.Context, bucket string, maxObjects int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalBucketObjectLimits.set(bucket, maxObjects))
+}
+
+// SetBucketObjectLimit - updates in-memory object limit of bucket on
+// the remote server.
+func (rc remoteAdminClient) SetBucketObjectLimit(ctx context.Context, bucket string, maxObjects int64) error {
+	args := BucketObjectLimitArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Bucket: bucket, MaxObjects: maxObjects}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetBucketObjectLimit", &args, &reply)
+}
+
+// ScannerImpact - returns the estimated impact of background scans on
+// the latency of requests served by the local server.
+func (lc localAdminClient) ScannerImpact(ctx context.Context) (ImpactEstimate, error) {
+	if err := ctx.Err(); err != nil {
+		return ImpactEstimate{}, err
+	}
+	return globalScannerLatency.estimate(), nil
+}
+
+// ScannerImpact - returns the estimated impact of background scans on
+// the latency of requests served by the remote server.
+func (rc remoteAdminClient) ScannerImpact(ctx context.Context) (ImpactEstimate, error) {
+	args := AuthRPCArgs{}
+	reply := ScannerImpactReply{}
+	if err := rc.Call(ctx, "Admin.ScannerImpact", &args, &reply); err != nil {
+		return ImpactEstimate{}, err
+	}
+	return reply.Impact, nil
+}
+
+// SetTrustedProxies - sets the proxies whose forwarded headers are
+// honored by the local server.
+func (lc localAdminClient) SetTrustedProxies(ctx context.Context, cidrs []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalTrustedProxies.set(cidrs))
+}
+
+// SetTrustedProxies - sets the proxies whose forwarded headers are
+// honored by the remote server.
+func (rc remoteAdminClient) SetTrustedProxies(ctx context.Context, cidrs []string) error {
+	args := TrustedProxiesArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), CIDRs: cidrs}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetTrustedProxies", &args, &reply)
+}
+
+// SetBucketLogging - updates in-memory logging configuration of bucket
+// on the local server.
+func (lc localAdminClient) SetBucketLogging(ctx context.Context, bucket string, targetBucket, prefix string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalBucketLogging.set(bucket, BucketLogging{TargetBucket: targetBucket, TargetPrefix: prefix}))
+}
+
+// SetBucketLogging - updates in-memory logging configuration of bucket
+// on the remote server.
+func (rc remoteAdminClient) SetBucketLogging(ctx context.Context, bucket string, targetBucket, prefix string) error {
+	args := BucketLoggingArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Bucket: bucket, TargetBucket: targetBucket, TargetPrefix: prefix}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetBucketLogging", &args, &reply)
+}
+
+// CredentialUsage - returns the last use of the access keys valid on
+// the local server.
+func (lc localAdminClient) CredentialUsage(ctx context.Context) ([]CredUsage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return serverCredentialUsage(), nil
+}
+
+// CredentialUsage - returns the last use of the access keys valid on
+// the remote server.
+func (rc remoteAdminClient) CredentialUsage(ctx context.Context) ([]CredUsage, error) {
+	args := AuthRPCArgs{}
+	reply := CredentialUsageReply{}
+	if err := rc.Call(ctx, "Admin.CredentialUsage", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Usages, nil
+}
+
+// SetMaxConcurrentHeals - sets the cap of object heals running at once
+// on the local server.
+func (lc localAdminClient) SetMaxConcurrentHeals(ctx context.Context, max int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalHealLimiter.set(max))
+}
+
+// SetMaxConcurrentHeals - sets the cap of object heals running at once
+// on the remote server.
+func (rc remoteAdminClient) SetMaxConcurrentHeals(ctx context.Context, max int) error {
+	args := MaxConcurrentHealsArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Max: max}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetMaxConcurrentHeals", &args, &reply)
+}
+
+// SetShutdownTimeout - sets the shutdown timeout of the local server.
+func (lc localAdminClient) SetShutdownTimeout(ctx context.Context, timeout time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), setShutdownTimeout(timeout))
+}
+
+// SetShutdownTimeout - sets the shutdown timeout of the remote server.
+func (rc remoteAdminClient) SetShutdownTimeout(ctx context.Context, timeout time.Duration) error {
+	args := ShutdownTimeoutArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Timeout: timeout}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetShutdownTimeout", &args, &reply)
+}
+
+// SetChecksumPolicy - sets the object checksum policy of the local
+// server.
+func (lc localAdminClient) SetChecksumPolicy(ctx context.Context, required bool, algorithms []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalChecksumPolicy.set(required, algorithms))
+}
+
+// SetChecksumPolicy - sets the object checksum policy of the remote
+// server.
+func (rc remoteAdminClient) SetChecksumPolicy(ctx context.Context, required bool, algorithms []string) error {
+	args := ChecksumPolicyArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Required: required, Algorithms: algorithms}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetChecksumPolicy", &args, &reply)
+}
+
+// Summary - returns the summary of the local server.
+func (lc localAdminClient) Summary(ctx context.Context) (ServerSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return ServerSummary{}, err
+	}
+	return getLocalServerSummary()
+}
+
+// Summary - returns the summary of the remote server.
+func (rc remoteAdminClient) Summary(ctx context.Context) (ServerSummary, error) {
+	args := AuthRPCArgs{}
+	reply := ServerSummaryReply{}
+	if err := rc.Call(ctx, "Admin.Summary", &args, &reply); err != nil {
+		return ServerSummary{}, err
+	}
+	return reply.Summary, nil
+}
+
+// DiskFill - returns the space used on the disks of the local server.
+func (lc localAdminClient) DiskFill(ctx context.Context) ([]DiskFill, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return getLocalDiskFill()
+}
+
+// DiskFill - returns the space used on the disks of the remote server.
+func (rc remoteAdminClient) DiskFill(ctx context.Context) ([]DiskFill, error) {
+	args := AuthRPCArgs{}
+	reply := DiskFillReply{}
+	if err := rc.Call(ctx, "Admin.DiskFill", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Disks, nil
+}
+
+// SetNotificationRetry - sets the notification retry policy of the
+// local server.
+func (lc localAdminClient) SetNotificationRetry(ctx context.Context, maxRetries int, backoff time.Duration, deadLetterTarget string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalNotificationRetry.set(NotificationRetryPolicy{
+		MaxRetries:       maxRetries,
+		Backoff:          backoff,
+		DeadLetterTarget: deadLetterTarget,
+	}))
+}
+
+// SetNotificationRetry - sets the notification retry policy of the
+// remote server.
+func (rc remoteAdminClient) SetNotificationRetry(ctx context.Context, maxRetries int, backoff time.Duration, deadLetterTarget string) error {
+	args := NotificationRetryArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), MaxRetries: maxRetries, Backoff: backoff, DeadLetterTarget: deadLetterTarget}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetNotificationRetry", &args, &reply)
+}
+
+// SetMaxListDepth - sets the maximum list depth of the local server.
+func (lc localAdminClient) SetMaxListDepth(ctx context.Context, depth int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), setMaxListDepth(depth))
+}
+
+// SetMaxListDepth - sets the maximum list depth of the remote server.
+func (rc remoteAdminClient) SetMaxListDepth(ctx context.Context, depth int) error {
+	args := MaxListDepthArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Depth: depth}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetMaxListDepth", &args, &reply)
+}
+
+// StartupErrors - returns the errors the local server started in
+// spite of.
+func (lc localAdminClient) StartupErrors(ctx context.Context) ([]StartupError, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return globalBootDiagnostics.startupErrors(), nil
+}
+
+// StartupErrors - returns the errors the remote server started in
+// spite of.
+func (rc remoteAdminClient) StartupErrors(ctx context.Context) ([]StartupError, error) {
+	args := AuthRPCArgs{}
+	reply := StartupErrorsReply{}
+	if err := rc.Call(ctx, "Admin.StartupErrors", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Errors, nil
+}
+
+// MemSummary - returns the memory summary of the local server.
+func (lc localAdminClient) MemSummary(ctx context.Context) (MemStats, error) {
+	if err := ctx.Err(); err != nil {
+		return MemStats{}, err
+	}
+	return newMemStats(runtimeMemSource{}), nil
+}
+
+// MemSummary - returns the memory summary of the remote server.
+func (rc remoteAdminClient) MemSummary(ctx context.Context) (MemStats, error) {
+	args := AuthRPCArgs{}
+	reply := MemSummaryReply{}
+	if err := rc.Call(ctx, "Admin.MemSummary", &args, &reply); err != nil {
+		return MemStats{}, err
+	}
+	return reply.Stats, nil
+}
+
+// SetBucketPartSizeLimits - sets the part size limits of bucket on the
+// local server.
+func (lc localAdminClient) SetBucketPartSizeLimits(ctx context.Context, bucket string, minPart, maxPart int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalBucketPartSizeLimits.set(bucket, PartSizeLimits{MinPartSize: minPart, MaxPartSize: maxPart}))
+}
+
+// SetBucketPartSizeLimits - sets the part size limits of bucket on the
+// remote server.
+func (rc remoteAdminClient) SetBucketPartSizeLimits(ctx context.Context, bucket string, minPart, maxPart int64) error {
+	args := BucketPartSizeLimitsArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Bucket: bucket, MinPart: minPart, MaxPart: maxPart}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetBucketPartSizeLimits", &args, &reply)
+}
+
+// SetDeleteProtectionTag - updates in-memory delete protection tag of
+// bucket on the local server.
+func (lc localAdminClient) SetDeleteProtectionTag(ctx context.Context, bucket, tagKey, tagValue string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), globalDeleteProtectionTags.set(bucket, DeleteProtectionTag{Key: tagKey, Value: tagValue}))
+}
+
+// SetDeleteProtectionTag - updates in-memory delete protection tag of
+// bucket on the remote server.
+func (rc remoteAdminClient) SetDeleteProtectionTag(ctx context.Context, bucket, tagKey, tagValue string) error {
+	args := DeleteProtectionTagArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Bucket: bucket, TagKey: tagKey, TagValue: tagValue}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetDeleteProtectionTag", &args, &reply)
+}
+
+// ConfigChangeLog - returns the last n config changes logged by the
+// local server, latest first.
+func (lc localAdminClient) ConfigChangeLog(ctx context.Context, n int) ([]ConfigChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return globalConfigChangeLog.last(n), nil
+}
+
+// ConfigChangeLog - returns the last n config changes logged by the
+// remote server, latest first.
+func (rc remoteAdminClient) ConfigChangeLog(ctx context.Context, n int) ([]ConfigChange, error) {
+	args := ConfigChangeLogArgs{Count: n}
+	reply := ConfigChangeLogReply{}
+	if err := rc.Call(ctx, "Admin.ConfigChangeLog", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Changes, nil
+}
+
+// SetScanOnWrite - enables or disables verification of writes on the
+// local server.
+func (lc localAdminClient) SetScanOnWrite(ctx context.Context, enabled bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	globalScanOnWrite.set(enabled)
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), nil)
+}
+
+// SetScanOnWrite - enables or disables verification of writes on the
+// remote server.
+func (rc remoteAdminClient) SetScanOnWrite(ctx context.Context, enabled bool) error {
+	args := ScanOnWriteArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), Enabled: enabled}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetScanOnWrite", &args, &reply)
+}
+
+// SetCredentialExpiry - sets the expiry of an access key on the local
+// server.
+func (lc localAdminClient) SetCredentialExpiry(ctx context.Context, accessKey string, expiry time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	return globalConfigChangeLog.logApplied(configChangeFromContext(ctx), setCredentialExpiry(accessKey, expiry, now))
+}
+
+// SetCredentialExpiry - sets the expiry of an access key on the remote
+// server, along with the time of the local clock so that the remote
+// server can correct the expiry for clock skew.
+func (rc remoteAdminClient) SetCredentialExpiry(ctx context.Context, accessKey string, expiry time.Time) error {
+	args := CredentialExpiryArgs{ConfigChangeArgs: newConfigChangeArgs(ctx), AccessKey: accessKey, Expiry: expiry, SentAt: time.Now().UTC()}
+	reply := AuthRPCReply{}
+	return rc.Call(ctx, "Admin.SetCredentialExpiry", &args, &reply)
+}
+
+// ObjectDistribution - returns the objects of a bucket stored on the
+// erasure sets of the disks of the local server.
+func (lc localAdminClient) ObjectDistribution(ctx context.Context, bucket string) ([]SetObjects, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return getLocalObjectDistribution(bucket)
+}
+
+// ObjectDistribution - returns the objects of a bucket stored on the
+// erasure sets of the disks of the remote server.
+func (rc remoteAdminClient) ObjectDistribution(ctx context.Context, bucket string) ([]SetObjects, error) {
+	args := ObjectDistributionArgs{Bucket: bucket}
+	reply := ObjectDistributionReply{}
+	if err := rc.Call(ctx, "Admin.ObjectDistribution", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Sets, nil
+}
+
+// adminPeer - represents an entity that implements Restart methods.
+type adminPeer struct {
+	addr      string
+	cmdRunner adminCmdRunner
+}
+
+// type alias for a collection of adminPeer.
+type adminPeers []adminPeer
+
+// makeAdminPeers - helper function to construct a collection of adminPeer.
+func makeAdminPeers(eps []*url.URL) adminPeers {
+	var servicePeers []adminPeer
+
+	// map to store peers that are already added to ret
+	seenAddr := make(map[string]bool)
+
+	// add local (self) as peer in the array
+	servicePeers = append(servicePeers, adminPeer{
+		globalMinioAddr,
+		localAdminClient{},
+	})
+	seenAddr[globalMinioAddr] = true
+
+	serverCred := serverConfig.GetCredential()
+	// iterate over endpoints to find new remote peers and add
+	// them to ret.
+	for _, ep := range eps {
+		if ep.Host == "" {
+			continue
+		}
+
+		// Check if the remote host has been added already
+		if !seenAddr[ep.Host] {
+			cfg := authConfig{
+				accessKey:       serverCred.AccessKey,
+				secretKey:       serverCred.SecretKey,
+				serverAddr:      ep.Host,
+				secureConn:      globalIsSSL,
+				serviceEndpoint: path.Join(minioReservedBucketPath, adminPath),
+				serviceName:     "Admin",
+			}
+
+			servicePeers = append(servicePeers, adminPeer{
+				addr:      ep.Host,
+				cmdRunner: &remoteAdminClient{newAuthRPCClient(cfg)},
+			})
+			seenAddr[ep.Host] = true
+		}
+	}
+
+	return servicePeers
+}
+
+// Initialize global adminPeer collection.
+func initGlobalAdminPeers(eps []*url.URL) {
+	globalAdminPeers = makeAdminPeers(eps)
+}
+
+// invokeServiceCmd - Invoke Restart or Stop command.
+func invokeServiceCmd(ctx context.Context, cp adminPeer, cmd serviceSignal) (err error) {
+	switch cmd {
+	case serviceRestart:
+		err = cp.cmdRunner.Restart(ctx)
+	case serviceStop:
+		err = cp.cmdRunner.Stop(ctx)
+	}
+	return err
+}
+
+// sendServiceCmd - Invoke Restart or Stop command on remote peers
+// adminPeer followed by on the local peer, so that the server
+// issuing the command goes down last. Returns PeerErrors naming the
+// servers which did not acknowledge the command, if any.
+func sendServiceCmd(ctx context.Context, cps adminPeers, cmd serviceSignal) error {
+	// Send service command like stop or restart to all remote nodes and finally run on local node.
+	errs := make([]error, len(cps))
+	var wg sync.WaitGroup
+	remotePeers := cps[1:]
+	for i := range remotePeers {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			// we use idx+1 because remotePeers slice is 1 position shifted w.r.t cps
+			errs[idx+1] = invokeServiceCmd(ctx, remotePeers[idx], cmd)
+		}(i)
+	}
+	wg.Wait()
+	errs[0] = invokeServiceCmd(ctx, cps[0], cmd)
+
+	return newPeerErrors(cps, errs)
+}
+
+// listPeerLocksInfo - fetch list of locks held on the given bucket,
+// matching prefix held longer than duration from all peer servers.
+// Calls still in flight once a majority of servers replied are
+// cancelled, locks held by servers which did not reply by then are
+// left out.
+func listPeerLocksInfo(ctx context.Context, peers adminPeers, bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
+	// Used to aggregate volume lock information from all nodes.
+	allLocks := make([][]VolumeLockInfo, len(peers))
+	errs := forEachPeerUntilQuorum(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) (err error) {
+		allLocks[idx], err = peer.cmdRunner.ListLocks(ctx, bucket, prefix, duration)
+		return err
+	})
+
+	// Summarizing errors received for ListLocks RPC across all
+	// nodes.  N B the possible unavailability of quorum in errors
+	// applies only to distributed setup.
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	// Group lock information across nodes by (bucket, object)
+	// pair. For readability only.
+	paramLockMap := make(map[nsParam][]VolumeLockInfo)
+	for _, nodeLocks := range allLocks {
+		for _, lockInfo := range nodeLocks {
+			param := nsParam{
+				volume: lockInfo.Bucket,
+				path:   lockInfo.Object,
+			}
+			paramLockMap[param] = append(paramLockMap[param], lockInfo)
+		}
+	}
+	groupedLockInfos := []VolumeLockInfo{}
+	for _, volLocks := range paramLockMap {
+		groupedLockInfos = append(groupedLockInfos, volLocks...)
+	}
+	return groupedLockInfos, nil
+}
+
+// forceUnlockPeers - removes the namespace lock on bucket/object from
+// the lock instrumentation of all peers, each releasing the distributed
+// lock again is harmless. Returns PeerErrors naming the peers which
+// could not be reached, if any.
+func forceUnlockPeers(ctx context.Context, peers adminPeers, bucket, object string) error {
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.ForceUnlock(ctx, bucket, object)
+	})
+	return newPeerErrors(peers, errs)
+}
+
+// reInitPeerDisks - reinitialize disks and object layer on peer servers to use the new format.
+// Returns InsufficientWriteQuorum if fewer than a majority of peers, the
+// local one included, reinitialized.
+func reInitPeerDisks(ctx context.Context, peers adminPeers) error {
+	errs := make([]error, len(peers))
+
+	// Send ReInitDisks RPC call to all nodes.
+	// for local adminPeer this is a no-op.
+	wg := sync.WaitGroup{}
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = peer.cmdRunner.ReInitDisks(ctx)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	// The no-op of the local peer counts as a single success, remote
+	// failures leaving no majority are still reported.
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// uptimeSlice - used to sort uptimes in chronological order.
+type uptimeSlice []struct {
+	err    error
+	uptime time.Duration
+}
+
+func (ts uptimeSlice) Len() int {
+	return len(ts)
+}
+
+func (ts uptimeSlice) Less(i, j int) bool {
+	return ts[i].uptime < ts[j].uptime
+}
+
+func (ts uptimeSlice) Swap(i, j int) {
+	ts[i], ts[j] = ts[j], ts[i]
+}
+
+// getPeerUptimes - returns the uptime since the last time read quorum
+// was established on success, the readQuorum'th longest uptime of the
+// servers. Otherwise returns InsufficientReadQuorum. All servers are
+// waited for, each for at most adminRPCTimeout, since the slowest
+// servers may hold the longest uptimes; servers which did not reply
+// count as failed.
+func getPeerUptimes(ctx context.Context, peers adminPeers) (time.Duration, error) {
+	// In a single node Erasure or FS backend setup the uptime of
+	// the setup is the uptime of the single minio server
+	// instance.
+	if !globalIsDistXL {
+		return time.Now().UTC().Sub(globalBootTime), nil
+	}
+
+	uptimes := make(uptimeSlice, len(peers))
+
+	// Get up time of all servers.
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		uptimes[idx].uptime, uptimes[idx].err = peer.cmdRunner.Uptime(ctx)
+	})
+
+	// Sort uptimes longest first, i.e servers in the order they
+	// came up.
+	sort.Sort(sort.Reverse(uptimes))
+
+	// Pick the readQuorum'th longest uptime, i.e the time since the
+	// readQuorum'th server came up and read quorum was
+	// (re-)established.
+	readQuorum := peerQuorum(len(uptimes))
+	validCount := 0
+	latestUptime := time.Duration(0)
+	for _, uptime := range uptimes {
+		if uptime.err != nil {
+			errorIf(uptime.err, "Unable to fetch uptime")
+			continue
+		}
+
+		validCount++
+		if validCount >= readQuorum {
+			latestUptime = uptime.uptime
+			break
+		}
+	}
+
+	// Less than readQuorum "Admin.Uptime" RPC call returned
+	// successfully, so read-quorum unavailable.
+	if validCount < readQuorum {
+		return time.Duration(0), InsufficientReadQuorum{}
+	}
+
+	return latestUptime, nil
+}
+
+// getPeerConfig - Fetches config.json from all nodes in the setup and
+// returns the one that occurs in a majority of them.
+func getPeerConfig(ctx context.Context, peers adminPeers) ([]byte, error) {
+	if !globalIsDistXL {
+		return peers[0].cmdRunner.GetConfig(ctx)
+	}
+
+	// Calls still in flight once a majority of servers returned the
+	// same config are cancelled.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type configResult struct {
+		idx    int
+		config []byte
+		err    error
+	}
+	// Buffered so that calls returning after a majority was found do
+	// not block.
+	resultCh := make(chan configResult, len(peers))
+
+	// Get config from all servers, without waiting for the calls
+	// to return so that a majority can be found early.
+	go forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		config, err := peer.cmdRunner.GetConfig(ctx)
+		resultCh <- configResult{idx, config, err}
+	})
+
+	// Find the maximally occurring config among peers in a
+	// distributed setup, servers which did not reply count as
+	// failed.
+	errs := make([]error, len(peers))
+	for i := range errs {
+		errs[i] = context.Canceled
+	}
+	serverConfigs := make([]serverConfigV13, len(peers))
+	configJSON, err := serverConfigV13{}, errXLWriteQuorum
+	for range peers {
+		result := <-resultCh
+		if errs[result.idx] = result.err; result.err != nil {
+			continue
+		}
+
+		// Unmarshal the received config files.
+		if err = json.Unmarshal(result.config, &serverConfigs[result.idx]); err != nil {
+			errorIf(err, "Failed to unmarshal serverConfig from ", peers[result.idx].addr)
+			return nil, err
+		}
+
+		if configJSON, err = getValidServerConfig(serverConfigs, errs); err == nil {
+			break
+		}
+	}
+	if err != nil {
 		errorIf(err, "Unable to find a valid server config")
 		return nil, traceError(err)
 	}
 
-	// Return the config.json that was present quorum or more
-	// number of disks.
-	return json.Marshal(configJSON)
+	// Return the config.json that was present quorum or more
+	// number of disks.
+	return json.Marshal(configJSON)
+}
+
+// getValidServerConfig - finds the server config that is present in
+// quorum or more number of servers.
+func getValidServerConfig(serverConfigs []serverConfigV13, errs []error) (serverConfigV13, error) {
+	// majority-based quorum
+	quorum := peerQuorum(len(serverConfigs))
+
+	// Count the number of disks a config.json was found in.
+	configCounter := make([]int, len(serverConfigs))
+
+	// We group equal serverConfigs by the lowest index of the
+	// same value;  e.g, let us take the following serverConfigs
+	// in a 4-node setup,
+	// serverConfigs == [c1, c2, c1, c1]
+	// configCounter == [3, 1, 0, 0]
+	// c1, c2 are the only distinct values that appear.  c1 is
+	// identified by 0, the lowest index it appears in and c2 is
+	// identified by 1. So, we need to find the number of times
+	// each of these distinct values occur.
+
+	// Invariants:
+
+	// 1. At the beginning of the i-th iteration, the number of
+	// unique configurations seen so far is equal to the number of
+	// non-zero counter values in config[:i].
+
+	// 2. At the beginning of the i-th iteration, the sum of
+	// elements of configCounter[:i] is equal to the number of
+	// non-error configurations seen so far.
+
+	// For each of the serverConfig ...
+	for i := range serverConfigs {
+		// Skip nodes where getConfig failed.
+		if errs[i] != nil {
+			continue
+		}
+		// Check if it is equal to any of the configurations
+		// seen so far. If j == i is reached then we have an
+		// unseen configuration.
+		for j := 0; j <= i; j++ {
+			if j < i && configCounter[j] == 0 {
+				// serverConfigs[j] is known to be
+				// equal to a value that was already
+				// seen. See example above for
+				// clarity.
+				continue
+			} else if j < i && reflect.DeepEqual(serverConfigs[i], serverConfigs[j]) {
+				// serverConfigs[i] is equal to
+				// serverConfigs[j], update
+				// serverConfigs[j]'s counter since it
+				// is the lower index.
+				configCounter[j]++
+				break
+			} else if j == i {
+				// serverConfigs[i] is equal to no
+				// other value seen before. It is
+				// unique so far.
+				configCounter[i] = 1
+				break
+			} // else invariants specified above are violated.
+		}
+	}
+
+	// We find the maximally occurring server config and check if
+	// there is quorum.
+	var configJSON serverConfigV13
+	maxOccurrence := 0
+	for i, count := range configCounter {
+		if maxOccurrence < count {
+			maxOccurrence = count
+			configJSON = serverConfigs[i]
+		}
+	}
+
+	// If quorum nodes don't agree.
+	if maxOccurrence < quorum {
+		return serverConfigV13{}, errXLWriteQuorum
+	}
+
+	return configJSON, nil
+}
+
+// setPeerConfig - validates config.json and sets it on all peers. If
+// fewer than a majority of peers accepted and saved it, the peers
+// which did are rolled back to their previous config. Otherwise
+// returns PeerErrors naming the peers which did not save it, if any.
+func setPeerConfig(ctx context.Context, peers adminPeers, config []byte) error {
+	// Reject invalid configs before contacting any peer.
+	srvCfg, err := parseServerConfig(config)
+	if err != nil {
+		return err
+	}
+	if err = checkConfigCredential(srvCfg); err != nil {
+		return err
+	}
+
+	// Fetch the config of every peer to roll back to, peers whose
+	// config can not be fetched are left untouched.
+	prevConfigs := make([][]byte, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		prevConfigs[idx], errs[idx] = peer.cmdRunner.GetConfig(ctx)
+		if errs[idx] == nil {
+			errs[idx] = peer.cmdRunner.SetConfig(ctx, config)
+		}
+	})
+
+	if err = reducePeerWriteErrs(peers, errs); err == nil {
+		return newPeerErrors(peers, errs)
+	}
+
+	// Roll back even if ctx is done, the request may have been
+	// cancelled while peers were saving the config.
+	rollbackCtx, cancel := context.WithTimeout(context.Background(), adminRPCTimeout)
+	defer cancel()
+	forEachPeer(rollbackCtx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		if errs[idx] != nil {
+			return
+		}
+		errorIf(peer.cmdRunner.SetConfig(ctx, prevConfigs[idx]), "Unable to roll back config on %s", peer.addr)
+	})
+	return err
+}
+
+// forEachPeer - calls fn concurrently for every peer and waits for
+// all the calls to return. fn receives the index of the peer in
+// peers so that results can be gathered in peer order, and a context
+// derived from ctx timing out after adminRPCTimeout. Calls in flight
+// are bounded by globalAdminFanOut, fn must not fan out across peers
+// itself.
+func forEachPeer(ctx context.Context, peers adminPeers, fn func(ctx context.Context, idx int, peer adminPeer)) {
+	globalAdminFanOut.run(ctx, len(peers), func(ctx context.Context, idx int) {
+		fn(ctx, idx, peers[idx])
+	})
+}
+
+// forEachPeerUntilQuorum - like forEachPeer, but cancels the context
+// passed to the calls still in flight once a majority of peers
+// returned no error, or once so many failed that no majority can.
+// Returns the errors of all the calls, calls cancelled fail with
+// context.Canceled.
+func forEachPeerUntilQuorum(ctx context.Context, peers adminPeers, fn func(ctx context.Context, idx int, peer adminPeer) error) []error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	quorum := peerQuorum(len(peers))
+	errs := make([]error, len(peers))
+	var mu sync.Mutex
+	succeeded, failed := 0, 0
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		err := fn(ctx, idx, peer)
+
+		mu.Lock()
+		defer mu.Unlock()
+		errs[idx] = err
+		if err == nil {
+			succeeded++
+		} else {
+			failed++
+		}
+		if succeeded == quorum || failed == len(peers)-quorum+1 {
+			cancel()
+		}
+	})
+	return errs
+}
+
+// peerQuorum - returns the number of servers out of n which must
+// agree for a cluster-wide admin read or write to succeed, a strict
+// majority. This is the quorum of every fan-out across peers, any two
+// quorums have a server in common.
+func peerQuorum(n int) int {
+	return n/2 + 1
+}
+
+// reducePeerReadErrs - summarizes errors received from a read-only
+// fan-out across peers, applying the same quorum rule as
+// listPeerLocksInfo.
+func reducePeerReadErrs(errs []error) error {
+	errCount, err := reduceErrs(errs, []error{})
+	if errCount < peerQuorum(len(errs)) {
+		return InsufficientReadQuorum{}
+	}
+	return err
+}
+
+// getPeerErasureSetStatus - fetches the health of erasure sets from
+// all peers and combines them into a single cluster-wide view.
+func getPeerErasureSetStatus(ctx context.Context, peers adminPeers) ([]SetStatus, error) {
+	views := make([][]SetStatus, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		views[idx], errs[idx] = peer.cmdRunner.ErasureSetStatus(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	var validViews [][]SetStatus
+	for i, view := range views {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch erasure set status from %s", peers[i].addr)
+			continue
+		}
+		validViews = append(validViews, view)
+	}
+	return mergeSetStatus(validViews)
+}
+
+// mergeSetStatus - combines per-server views of erasure sets. Since
+// drives of a set span servers, a server may fail to reach a drive
+// that others can. A drive is treated as online only when a majority
+// of the servers see it online, and the set is marked inconsistent
+// when servers disagree about any of its drives.
+func mergeSetStatus(views [][]SetStatus) ([]SetStatus, error) {
+	if len(views) == 0 {
+		return nil, InsufficientReadQuorum{}
+	}
+
+	merged := make([]SetStatus, len(views[0]))
+	for s, set := range views[0] {
+		onlineCount := make([]int, len(set.Drives))
+		consistent := true
+		for _, view := range views {
+			if len(view) != len(views[0]) || len(view[s].Drives) != len(set.Drives) {
+				return nil, errors.New("erasure set layout differs between servers")
+			}
+			for d, online := range view[s].Drives {
+				if online {
+					onlineCount[d]++
+				}
+				if online != set.Drives[d] {
+					consistent = false
+				}
+			}
+		}
+
+		drives := make([]bool, len(set.Drives))
+		for d, count := range onlineCount {
+			drives[d] = 2*count > len(views)
+		}
+		merged[s] = newSetStatus(set.Index, drives, set.ParityDrives, set.ReadQuorum, set.WriteQuorum)
+		merged[s].Consistent = consistent
+	}
+	return merged, nil
+}
+
+// PeerErrors - errors returned by the peers which failed a fan-out,
+// keyed by the address of the peer.
+type PeerErrors map[string]error
+
+func (e PeerErrors) Error() string {
+	addrs := make([]string, 0, len(e))
+	for addr := range e {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	msgs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		msgs[i] = fmt.Sprintf("%s: %v", addr, e[addr])
+	}
+	return "Failed on " + strings.Join(msgs, ", ")
+}
+
+// newPeerErrors - returns the non-nil errors in errs as PeerErrors,
+// keyed by the address of the peer at the same index. Returns nil if
+// no peer failed.
+func newPeerErrors(peers adminPeers, errs []error) error {
+	peerErrs := make(PeerErrors)
+	for i, err := range errs {
+		if err != nil {
+			peerErrs[peers[i].addr] = err
+		}
+	}
+	if len(peerErrs) == 0 {
+		return nil
+	}
+	return peerErrs
+}
+
+// reducePeerWriteErrs - summarizes errors received from a fan-out
+// that changes state on peers. The change is taken to be applied
+// only if write quorum of peers acknowledged it.
+func reducePeerWriteErrs(peers adminPeers, errs []error) error {
+	for i, err := range errs {
+		errorIf(err, "Unable to apply change on %s", peers[i].addr)
+	}
+	return reduceQuorumErrs(errs, nil, peerQuorum(len(peers)), InsufficientWriteQuorum{})
+}
+
+// setPeerMaxPresignExpiry - sets the presigned URL expiry limit on
+// all peers.
+func setPeerMaxPresignExpiry(ctx context.Context, peers adminPeers, expiry time.Duration) error {
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetMaxPresignExpiry(ctx, expiry)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerThrottleStatus - fetches the state of configured limits from
+// all peers. Peers with no configured limits are left out.
+func getPeerThrottleStatus(ctx context.Context, peers adminPeers) ([]NodeThrottleStatus, error) {
+	statuses := make([][]ThrottleStatus, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		statuses[idx], errs[idx] = peer.cmdRunner.ThrottleStatus(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	nodes := []NodeThrottleStatus{}
+	for i, throttles := range statuses {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch throttle status from %s", peers[i].addr)
+			continue
+		}
+		if len(throttles) == 0 {
+			continue
+		}
+		nodes = append(nodes, NodeThrottleStatus{
+			Addr:      peers[i].addr,
+			Throttles: throttles,
+		})
+	}
+	return nodes, nil
+}
+
+// getPeerLockGraph - gathers the state of all locks from all peers
+// and builds the cluster wide wait-for graph out of it.
+func getPeerLockGraph(ctx context.Context, peers adminPeers) (LockGraph, error) {
+	allLocks := make([][]VolumeLockInfo, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		allLocks[idx], errs[idx] = peer.cmdRunner.DumpLocks(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return LockGraph{}, err
+	}
+
+	nodeLocks := make(map[string][]VolumeLockInfo)
+	for i, volLocks := range allLocks {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch lock information from %s", peers[i].addr)
+			continue
+		}
+		nodeLocks[peers[i].addr] = volLocks
+	}
+	return buildLockGraph(nodeLocks), nil
+}
+
+// getPeerLockOriginBreakdown - fetches the locks of all peers and
+// counts them per server, operation and lock type.
+func getPeerLockOriginBreakdown(ctx context.Context, peers adminPeers) ([]LockOrigin, error) {
+	allLocks := make([][]VolumeLockInfo, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		allLocks[idx], errs[idx] = peer.cmdRunner.DumpLocks(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	nodeLocks := make(map[string][]VolumeLockInfo)
+	for i, volLocks := range allLocks {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch lock information from %s", peers[i].addr)
+			continue
+		}
+		nodeLocks[peers[i].addr] = volLocks
+	}
+	return newLockOriginBreakdown(nodeLocks), nil
+}
+
+// setPeerHealDeletePolicy - sets the mode of dealing with orphaned
+// objects on all peers.
+func setPeerHealDeletePolicy(ctx context.Context, peers adminPeers, mode string) error {
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetHealDeletePolicy(ctx, mode)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerAPILatency - fetches latency histograms of S3 API operations
+// from all peers and computes cluster wide latency percentiles per
+// operation out of the merged histograms.
+func getPeerAPILatency(ctx context.Context, peers adminPeers) (map[string]LatencyPercentiles, error) {
+	allHistograms := make([]map[string]LatencyHistogram, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		allHistograms[idx], errs[idx] = peer.cmdRunner.APILatency(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]LatencyHistogram)
+	for i, histograms := range allHistograms {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch API latency from %s", peers[i].addr)
+			continue
+		}
+		for api, h := range histograms {
+			if _, ok := merged[api]; !ok {
+				merged[api] = newLatencyHistogram()
+			}
+			merged[api].merge(h)
+		}
+	}
+
+	percentiles := make(map[string]LatencyPercentiles)
+	for api, h := range merged {
+		percentiles[api] = newLatencyPercentiles(h)
+	}
+	return percentiles, nil
+}
+
+// setPeerKeyNamingPolicy - sets the object naming policy on all
+// peers.
+func setPeerKeyNamingPolicy(ctx context.Context, peers adminPeers, policy KeyPolicy) error {
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetKeyNamingPolicy(ctx, policy)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerBootDiagnostics - fetches time taken by startup phases of
+// all peers, flagging the slowest server in each phase.
+func getPeerBootDiagnostics(ctx context.Context, peers adminPeers) ([]NodeBootTimings, error) {
+	timings := make([]BootTimings, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		timings[idx], errs[idx] = peer.cmdRunner.BootDiagnostics(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	nodes := []NodeBootTimings{}
+	for i, timing := range timings {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch boot diagnostics from %s", peers[i].addr)
+			continue
+		}
+		nodes = append(nodes, NodeBootTimings{Addr: peers[i].addr, Timings: timing})
+	}
+	markSlowestBootPhases(nodes)
+	return nodes, nil
+}
+
+// setPeerMultipartLifetime - sets the time after which idle multipart
+// uploads are aborted on all peers.
+func setPeerMultipartLifetime(ctx context.Context, peers adminPeers, lifetime time.Duration) error {
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetMultipartLifetime(ctx, lifetime)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerVersionClockMatrix - fetches version and clock of all peers
+// in a single round trip, flagging peers whose version differs from
+// the first responding peer, which is the local server when it is
+// reachable, and peers whose clock is skewed.
+func getPeerVersionClockMatrix(ctx context.Context, peers adminPeers) ([]PeerVersionClock, error) {
+	infos := make([]ServerVersionClock, len(peers))
+	sent := make([]time.Time, len(peers))
+	received := make([]time.Time, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		sent[idx] = time.Now().UTC()
+		infos[idx], errs[idx] = peer.cmdRunner.VersionClock(ctx)
+		received[idx] = time.Now().UTC()
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	var ref ServerVersionClock
+	for i, err := range errs {
+		if err == nil {
+			ref = infos[i]
+			break
+		}
+	}
+
+	matrix := make([]PeerVersionClock, len(peers))
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch version and clock from %s", peer.addr)
+			matrix[i] = PeerVersionClock{Addr: peer.addr, Error: errs[i].Error()}
+			continue
+		}
+		matrix[i] = newPeerVersionClock(peer.addr, infos[i], ref, sent[i], received[i])
+	}
+	return matrix, nil
+}
+
+// setPeerConditionalWrites - enables or disables conditional writes
+// on all peers.
+func setPeerConditionalWrites(ctx context.Context, peers adminPeers, enabled bool) error {
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetConditionalWrites(ctx, enabled)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerDisksByState - fetches the disks in the given state from all
+// peers. Peers filter their own disks so that only matching disks are
+// sent back.
+func getPeerDisksByState(ctx context.Context, peers adminPeers, state string) ([]DiskInfoMsg, error) {
+	// Reject unknown states before contacting any peer.
+	if !isValidDiskState(state) {
+		return nil, errInvalidDiskState
+	}
+
+	peerDisks := make([][]DiskInfoMsg, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		peerDisks[idx], errs[idx] = peer.cmdRunner.DisksByState(ctx, state)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	disks := []DiskInfoMsg{}
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch %s disks from %s", state, peer.addr)
+			continue
+		}
+		for _, disk := range peerDisks[i] {
+			disk.Addr = peer.addr
+			disks = append(disks, disk)
+		}
+	}
+	return disks, nil
+}
+
+// subscribePeerEvents - returns a single stream of the events of all
+// peers, polled at every interval until doneCh is closed. Events
+// recorded before subscribing are not sent. Peers becoming
+// unreachable or reachable again are reported as node down and up
+// events.
+func subscribePeerEvents(ctx context.Context, peers adminPeers, interval time.Duration, doneCh <-chan struct{}) (<-chan ClusterEvent, error) {
+	cursors := make([]uint64, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		var batch ClusterEventBatch
+		batch, errs[idx] = peer.cmdRunner.ClusterEvents(ctx, 0)
+		cursors[idx] = batch.Last
+	})
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	online := make([]bool, len(peers))
+	for i, err := range errs {
+		online[i] = err == nil
+	}
+
+	eventCh := make(chan ClusterEvent)
+	go func() {
+		defer close(eventCh)
+
+		merger := clusterEventMerger{}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-doneCh:
+				return
+			case <-ticker.C:
+			}
+
+			batches := make([]ClusterEventBatch, len(peers))
+			forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+				batches[idx], errs[idx] = peer.cmdRunner.ClusterEvents(ctx, cursors[idx])
+			})
+
+			var events []ClusterEvent
+			for i, peer := range peers {
+				if errs[i] != nil {
+					if online[i] {
+						online[i] = false
+						events = append(events, ClusterEvent{Type: clusterEventNodeDown, Time: time.Now().UTC(), Node: peer.addr})
+					}
+					continue
+				}
+				if !online[i] {
+					online[i] = true
+					events = append(events, ClusterEvent{Type: clusterEventNodeUp, Time: time.Now().UTC(), Node: peer.addr})
+				}
+				cursors[i] = batches[i].Last
+				for _, event := range batches[i].Events {
+					event.Node = peer.addr
+					if merger.merge(event) {
+						events = append(events, event)
+					}
+				}
+			}
+
+			for _, event := range events {
+				select {
+				case eventCh <- event:
+				case <-doneCh:
+					return
+				}
+			}
+		}
+	}()
+	return eventCh, nil
+}
+
+// setPeerBucketTags - updates in-memory tags of bucket on all peers,
+// tags are expected to be persisted already.
+func setPeerBucketTags(ctx context.Context, peers adminPeers, bucket string, tags map[string]string) error {
+	// Reject invalid tags before contacting any peer.
+	if err := validateBucketTags(tags); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetBucketTags(ctx, bucket, tags)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// setPeerAllowedSignatureVersions - sets the signature versions
+// accepted by all peers.
+func setPeerAllowedSignatureVersions(ctx context.Context, peers adminPeers, versions []string) error {
+	// Reject invalid versions before contacting any peer.
+	if err := validateSignatureVersions(versions); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetAllowedSignatureVersions(ctx, versions)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// Quorum modes waited for by waitForPeerQuorum.
+const (
+	quorumModeRead  = "read"
+	quorumModeWrite = "write"
+)
+
+// Interval at which quorum is checked while waiting for it.
+const quorumPollInterval = time.Second
+
+// errInvalidQuorumMode - quorum mode is neither read nor write.
+var errInvalidQuorumMode = errors.New("Quorum mode must be one of read or write")
+
+// hasSetQuorum - returns true if every erasure set has the quorum of
+// mode.
+func hasSetQuorum(sets []SetStatus, mode string) bool {
+	for _, set := range sets {
+		if mode == quorumModeRead && !set.HasReadQuorum {
+			return false
+		}
+		if mode == quorumModeWrite && !set.HasWriteQuorum {
+			return false
+		}
+	}
+	return true
+}
+
+// waitForPeerQuorum - blocks until every erasure set has read or write
+// quorum, as seen by a majority of peers, checking at every interval.
+// Returns nil right away if quorum is already there, the context error
+// if ctx expires first.
+func waitForPeerQuorum(ctx context.Context, peers adminPeers, mode string, interval time.Duration) error {
+	if mode != quorumModeRead && mode != quorumModeWrite {
+		return errInvalidQuorumMode
+	}
+
+	for {
+		sets, err := getPeerErasureSetStatus(ctx, peers)
+		if err == errUnsupportedBackend {
+			return err
+		}
+		if err == nil && hasSetQuorum(sets, mode) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// getPeerRPCConnStats - fetches usage of the RPC clients of all
+// peers, skipping peers which could not be reached.
+func getPeerRPCConnStats(ctx context.Context, peers adminPeers) ([]NodeRPCConnStats, error) {
+	conns := make([][]RPCConnStats, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		conns[idx], errs[idx] = peer.cmdRunner.RPCConnStats(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	nodes := []NodeRPCConnStats{}
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch RPC connection stats from %s", peer.addr)
+			continue
+		}
+		nodes = append(nodes, NodeRPCConnStats{Addr: peer.addr, Conns: conns[i]})
+	}
+	return nodes, nil
+}
+
+// getPeerHealJobs - fetches the heal jobs of all peers, nil for peers
+// which could not be reached.
+func getPeerHealJobs(ctx context.Context, peers adminPeers) ([][]HealJobStatus, []error) {
+	jobs := make([][]HealJobStatus, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		jobs[idx], errs[idx] = peer.cmdRunner.HealJobs(ctx)
+	})
+	for i, peer := range peers {
+		errorIf(errs[i], "Unable to fetch heal jobs from %s", peer.addr)
+	}
+	return jobs, errs
+}
+
+// getPeerHealJob - returns the progress of heal job id summed over all
+// peers healing a part of it, errNoSuchHealJob if no peer knows it.
+func getPeerHealJob(ctx context.Context, peers adminPeers, id string) (HealJobStatus, error) {
+	jobs, errs := getPeerHealJobs(ctx, peers)
+	if err := reducePeerReadErrs(errs); err != nil {
+		return HealJobStatus{}, err
+	}
+
+	status := HealJobStatus{ID: id, Done: true}
+	found := false
+	for _, peerJobs := range jobs {
+		for _, job := range peerJobs {
+			if job.ID != id {
+				continue
+			}
+			if !found || job.Started.Before(status.Started) {
+				status.Started = job.Started
+			}
+			found = true
+			status.Bucket = job.Bucket
+			status.Queued += job.Queued
+			status.Healed += job.Healed
+			status.Failed += job.Failed
+			status.Done = status.Done && job.Done
+			if job.Error != "" {
+				status.Error = job.Error
+			}
+		}
+	}
+	if !found {
+		return HealJobStatus{}, errNoSuchHealJob
+	}
+	return status, nil
+}
+
+// forceHealPeerBucket - heals all objects of bucket, spreading them
+// among the reachable peers, and returns the ID of the heal job. If
+// bucket is already being healed the ID of that job is returned.
+func forceHealPeerBucket(ctx context.Context, peers adminPeers, bucket string) (string, error) {
+	// Serialize heal requests of bucket so that only one job is
+	// started.
+	jobLock := globalNSMutex.NewNSLock(minioMetaBucket, pathJoin(healJobsPrefix, bucket))
+	jobLock.Lock()
+	defer jobLock.Unlock()
+
+	jobs, errs := getPeerHealJobs(ctx, peers)
+	if err := reducePeerReadErrs(errs); err != nil {
+		return "", err
+	}
+	for _, peerJobs := range jobs {
+		for _, job := range peerJobs {
+			if job.Bucket == bucket && !job.Done {
+				return job.ID, nil
+			}
+		}
+	}
+
+	// Objects are spread among the peers which could be reached.
+	var healers adminPeers
+	for i, peer := range peers {
+		if errs[i] == nil {
+			healers = append(healers, peer)
+		}
+	}
+
+	id := mustGetUUID()
+	errs = make([]error, len(healers))
+	forEachPeer(ctx, healers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.ForceHealBucket(ctx, id, bucket, idx, len(healers))
+	})
+	if err := reducePeerWriteErrs(healers, errs); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// setPeerImmutabilityWindow - updates in-memory immutability window of
+// bucket on all peers, the window is expected to be persisted already.
+func setPeerImmutabilityWindow(ctx context.Context, peers adminPeers, bucket string, window time.Duration) error {
+	// Reject invalid windows before contacting any peer.
+	if window < 0 {
+		return errInvalidImmutabilityWindow
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetImmutabilityWindow(ctx, bucket, window)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerActiveMultipartBytes - fetches the bytes held by in-progress
+// multipart uploads on all peers along with their total, skipping peers
+// which could not be reached.
+func getPeerActiveMultipartBytes(ctx context.Context, peers adminPeers) (ClusterMultipartBytes, error) {
+	sizes := make([]int64, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		sizes[idx], errs[idx] = peer.cmdRunner.ActiveMultipartBytes(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return ClusterMultipartBytes{}, err
+	}
+
+	usage := ClusterMultipartBytes{Nodes: []NodeMultipartBytes{}}
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch multipart bytes from %s", peer.addr)
+			continue
+		}
+		usage.Total += sizes[i]
+		usage.Nodes = append(usage.Nodes, NodeMultipartBytes{Addr: peer.addr, Bytes: sizes[i]})
+	}
+	return usage, nil
+}
+
+// getPeerLockHoldHistogram - fetches lock hold histograms from all peers
+// and merges them, skipping peers which could not be reached.
+func getPeerLockHoldHistogram(ctx context.Context, peers adminPeers) (LockHoldHistograms, error) {
+	allHistograms := make([]LockHoldHistograms, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		allHistograms[idx], errs[idx] = peer.cmdRunner.LockHoldHistogram(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return LockHoldHistograms{}, err
+	}
+
+	merged := newLockHoldHistograms()
+	for i, histograms := range allHistograms {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch lock hold histogram from %s", peers[i].addr)
+			continue
+		}
+		merged.merge(histograms)
+	}
+	return merged, nil
+}
+
+// listPeerUploadParts - fetches the parts of a multipart upload from
+// all peers and merges them ordered by part number. A part reported
+// differently by peers is taken from the peer which saw it uploaded
+// last. Returns InvalidUploadID if no peer knows the upload.
+func listPeerUploadParts(ctx context.Context, peers adminPeers, bucket, object, uploadID string) ([]PartInfo, error) {
+	allParts := make([][]PartInfo, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		allParts[idx], errs[idx] = peer.cmdRunner.ListParts(ctx, bucket, object, uploadID)
+	})
+
+	// Peers not knowing the upload still answered.
+	found := false
+	readErrs := make([]error, len(peers))
+	for i, err := range errs {
+		if _, ok := err.(InvalidUploadID); ok {
+			continue
+		}
+		readErrs[i] = err
+		found = found || err == nil
+	}
+	if err := reducePeerReadErrs(readErrs); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, InvalidUploadID{UploadID: uploadID}
+	}
+
+	merged := make(map[int]PartInfo)
+	for i, parts := range allParts {
+		if readErrs[i] != nil {
+			errorIf(readErrs[i], "Unable to list parts of upload %s from %s", uploadID, peers[i].addr)
+			continue
+		}
+		for _, part := range parts {
+			if prev, ok := merged[part.PartNumber]; !ok || part.LastModified.After(prev.LastModified) {
+				merged[part.PartNumber] = part
+			}
+		}
+	}
+
+	parts := make([]PartInfo, 0, len(merged))
+	for _, part := range merged {
+		parts = append(parts, part)
+	}
+	sort.Sort(partsByNumber(parts))
+	return parts, nil
+}
+
+// getPeerBackend - fetches the backend of all peers, skipping peers
+// which could not be reached, and checks that they all agree with the
+// first responding peer.
+func getPeerBackend(ctx context.Context, peers adminPeers) (ClusterBackend, error) {
+	infos := make([]BackendInfo, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		infos[idx], errs[idx] = peer.cmdRunner.Backend(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return ClusterBackend{}, err
+	}
+
+	cluster := ClusterBackend{Consistent: true, Nodes: []NodeBackend{}}
+	var ref *BackendInfo
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch backend from %s", peer.addr)
+			continue
+		}
+		info := infos[i]
+		if ref == nil {
+			ref = &infos[i]
+			cluster.Backend = backendTypeName(info.Type)
+			cluster.Capabilities = info.Capabilities.names()
+		} else if info != *ref {
+			cluster.Consistent = false
+		}
+		cluster.Nodes = append(cluster.Nodes, NodeBackend{
+			Addr:         peer.addr,
+			Backend:      backendTypeName(info.Type),
+			Capabilities: info.Capabilities.names(),
+		})
+	}
+	if !cluster.Consistent {
+		errorIf(errMixedBackends, "Servers report different backends %v, all servers must be started with the same disks.", cluster.Nodes)
+	}
+	return cluster, nil
+}
+
+// getPeerUsageScans - fetches the usage scans of all peers, nil for
+// peers which could not be reached.
+func getPeerUsageScans(ctx context.Context, peers adminPeers) ([][]UsageScanStatus, []error) {
+	scans := make([][]UsageScanStatus, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		scans[idx], errs[idx] = peer.cmdRunner.ScanProgress(ctx)
+	})
+	for i, peer := range peers {
+		errorIf(errs[i], "Unable to fetch usage scans from %s", peer.addr)
+	}
+	return scans, errs
+}
+
+// getPeerScanProgress - returns the progress of usage scan id summed over
+// all peers counting a part of it, errNoSuchUsageScan if no peer knows
+// it.
+func getPeerScanProgress(ctx context.Context, peers adminPeers, id string) (UsageScanStatus, error) {
+	scans, errs := getPeerUsageScans(ctx, peers)
+	if err := reducePeerReadErrs(errs); err != nil {
+		return UsageScanStatus{}, err
+	}
+
+	status := UsageScanStatus{ID: id, Done: true}
+	found := false
+	for _, peerScans := range scans {
+		for _, scan := range peerScans {
+			if scan.ID != id {
+				continue
+			}
+			if !found || scan.Started.Before(status.Started) {
+				status.Started = scan.Started
+			}
+			found = true
+			status.Bucket = scan.Bucket
+			status.Objects += scan.Objects
+			status.Bytes += scan.Bytes
+			status.Done = status.Done && scan.Done
+			if scan.Error != "" {
+				status.Error = scan.Error
+			}
+		}
+	}
+	if !found {
+		return UsageScanStatus{}, errNoSuchUsageScan
+	}
+	return status, nil
+}
+
+// recalculatePeerUsage - counts the objects of bucket and their size,
+// spreading them among the reachable peers, and returns the ID of the
+// usage scan. If bucket is already being scanned the ID of that scan
+// is returned.
+func recalculatePeerUsage(ctx context.Context, peers adminPeers, bucket string) (string, error) {
+	// Serialize scan requests of bucket so that only one scan is
+	// started.
+	scanLock := globalNSMutex.NewNSLock(minioMetaBucket, pathJoin(usageScansPrefix, bucket))
+	scanLock.Lock()
+	defer scanLock.Unlock()
+
+	scans, errs := getPeerUsageScans(ctx, peers)
+	if err := reducePeerReadErrs(errs); err != nil {
+		return "", err
+	}
+	for _, peerScans := range scans {
+		for _, scan := range peerScans {
+			if scan.Bucket == bucket && !scan.Done {
+				return scan.ID, nil
+			}
+		}
+	}
+
+	// Objects are spread among the peers which could be reached.
+	var scanners adminPeers
+	for i, peer := range peers {
+		if errs[i] == nil {
+			scanners = append(scanners, peer)
+		}
+	}
+
+	id := mustGetUUID()
+	errs = make([]error, len(scanners))
+	forEachPeer(ctx, scanners, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.RecalculateUsage(ctx, id, bucket, idx, len(scanners))
+	})
+	if err := reducePeerWriteErrs(scanners, errs); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// setPeerTLSCiphers - sets the TLS cipher suites offered by all peers.
+func setPeerTLSCiphers(ctx context.Context, peers adminPeers, suites []string) error {
+	// Reject invalid or weak suites before contacting any peer.
+	if _, err := parseTLSCiphers(suites); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetTLSCiphers(ctx, suites)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerWriteAmplification - fetches the write amplification of all
+// peers, skipping peers which could not be reached. The cluster figure
+// is computed out of the bytes of all peers rather than averaging
+// their ratios, so that idle peers do not skew it.
+func getPeerWriteAmplification(ctx context.Context, peers adminPeers) (ClusterWriteAmplification, error) {
+	amps := make([]WriteAmplification, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		amps[idx], errs[idx] = peer.cmdRunner.WriteAmplification(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return ClusterWriteAmplification{}, err
+	}
+
+	var clientBytes, diskBytes int64
+	nodes := []NodeWriteAmplification{}
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch write amplification from %s", peer.addr)
+			continue
+		}
+		clientBytes += amps[i].ClientBytes
+		diskBytes += amps[i].DiskBytes
+		nodes = append(nodes, NodeWriteAmplification{Addr: peer.addr, WriteAmplification: amps[i]})
+	}
+	return ClusterWriteAmplification{
+		WriteAmplification: newWriteAmplification(clientBytes, diskBytes),
+		Nodes:              nodes,
+	}, nil
+}
+
+// setPeerMaxUploadParts - sets the limit of parts per upload on all
+// peers.
+func setPeerMaxUploadParts(ctx context.Context, peers adminPeers, parts int) error {
+	// Reject invalid limits before contacting any peer.
+	if parts <= 0 || parts > maxPartID {
+		return errInvalidArgument
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetMaxUploadParts(ctx, parts)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerClusterChecksum - fetches the checksum of bucket from all
+// peers, skipping peers which could not be reached. Peers reporting
+// different checksums accepted different writes, as happens when
+// partitioned servers keep serving on both sides.
+func getPeerClusterChecksum(ctx context.Context, peers adminPeers, bucket string) (ClusterChecksum, error) {
+	checksums := make([]BucketChecksum, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		checksums[idx], errs[idx] = peer.cmdRunner.BucketChecksum(ctx, bucket)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return ClusterChecksum{}, err
+	}
+
+	cluster := ClusterChecksum{Bucket: bucket, Consistent: true, Nodes: []NodeBucketChecksum{}}
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch checksum of bucket %s from %s", bucket, peer.addr)
+			continue
+		}
+		if len(cluster.Nodes) > 0 && cluster.Nodes[0].Checksum != checksums[i].Checksum {
+			cluster.Consistent = false
+		}
+		cluster.Nodes = append(cluster.Nodes, NodeBucketChecksum{Addr: peer.addr, BucketChecksum: checksums[i]})
+	}
+	return cluster, nil
+}
+
+// setPeerRequestIDConfig - sets the header carrying the request ID on
+// all peers, and whether client supplied IDs are echoed back.
+func setPeerRequestIDConfig(ctx context.Context, peers adminPeers, header string, honorClientID bool) error {
+	// Reject invalid header names before contacting any peer.
+	if !isValidRequestIDHeader(header) {
+		return errInvalidRequestIDHeader
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetRequestIDConfig(ctx, header, honorClientID)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerHealHistory - returns the last n heal jobs completed, newest
+// first, skipping peers which could not be reached. Heal jobs are
+// spread among peers, the records of every peer healing a part of a
+// job are merged into one.
+func getPeerHealHistory(ctx context.Context, peers adminPeers, n int) ([]HealRecord, error) {
+	if n <= 0 || n > maxHealHistory {
+		return nil, errInvalidArgument
+	}
+
+	records := make([][]HealRecord, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		records[idx], errs[idx] = peer.cmdRunner.HealHistory(ctx, n)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]*HealRecord)
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch heal history from %s", peer.addr)
+			continue
+		}
+		for _, record := range records[i] {
+			job, ok := merged[record.ID]
+			if !ok {
+				record := record
+				merged[record.ID] = &record
+				continue
+			}
+			if record.Started.Before(job.Started) {
+				job.Started = record.Started
+			}
+			if record.Finished.After(job.Finished) {
+				job.Finished = record.Finished
+			}
+			job.Healed += record.Healed
+			job.Failed += record.Failed
+			job.Bytes += record.Bytes
+			if record.Error != "" {
+				job.Error = record.Error
+			}
+		}
+	}
+
+	history := make([]HealRecord, 0, len(merged))
+	for _, job := range merged {
+		job.Duration = job.Finished.Sub(job.Started)
+		job.Outcome = healOutcome(*job)
+		history = append(history, *job)
+	}
+	sort.Sort(byHealFinished(history))
+	if len(history) > n {
+		history = history[:n]
+	}
+	return history, nil
+}
+
+// getPeerRPCErrorBreakdown - returns the errors of admin RPC calls to
+// each peer, summed over the calls made by all peers since any of them
+// may coordinate admin requests, skipping peers which could not be
+// reached. Peers failing to authenticate are highlighted.
+func getPeerRPCErrorBreakdown(ctx context.Context, peers adminPeers) ([]PeerRPCErrors, error) {
+	reports := make([][]PeerRPCErrors, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		reports[idx], errs[idx] = peer.cmdRunner.RPCErrors(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	byAddr := make(map[string]*PeerRPCErrors)
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch RPC errors from %s", peer.addr)
+			continue
+		}
+		for _, report := range reports[i] {
+			target, ok := byAddr[report.Addr]
+			if !ok {
+				target = &PeerRPCErrors{Addr: report.Addr}
+				byAddr[report.Addr] = target
+			}
+			target.add(report.RPCErrorCounts)
+		}
+	}
+
+	breakdown := make([]PeerRPCErrors, 0, len(byAddr))
+	for _, target := range byAddr {
+		target.CredentialDrift = isCredentialDrift(target.RPCErrorCounts)
+		breakdown = append(breakdown, *target)
+	}
+	sort.Sort(byPeerRPCErrorsAddr(breakdown))
+	return breakdown, nil
+}
+
+// setPeerBucketObjectLimit - updates in-memory object limit of bucket
+// on all peers, the limit is expected to be persisted already. Every
+// peer counts the objects of bucket on its own.
+func setPeerBucketObjectLimit(ctx context.Context, peers adminPeers, bucket string, maxObjects int64) error {
+	// Reject invalid limits before contacting any peer.
+	if maxObjects < 0 {
+		return errInvalidObjectLimit
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetBucketObjectLimit(ctx, bucket, maxObjects)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerScannerImpact - fetches the scanner impact of all peers,
+// skipping peers which could not be reached. The cluster estimate is
+// computed out of the merged latency histograms of all peers, so that
+// it is confident even if no single peer served enough requests.
+func getPeerScannerImpact(ctx context.Context, peers adminPeers) (ClusterImpactEstimate, error) {
+	estimates := make([]ImpactEstimate, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		estimates[idx], errs[idx] = peer.cmdRunner.ScannerImpact(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return ClusterImpactEstimate{}, err
+	}
+
+	scanning, idle := newLatencyHistogram(), newLatencyHistogram()
+	nodes := []NodeImpactEstimate{}
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch scanner impact from %s", peer.addr)
+			continue
+		}
+		scanning.merge(estimates[i].Scanning)
+		idle.merge(estimates[i].Idle)
+		nodes = append(nodes, NodeImpactEstimate{Addr: peer.addr, ImpactEstimate: estimates[i]})
+	}
+	return ClusterImpactEstimate{
+		ImpactEstimate: newImpactEstimate(scanning, idle),
+		Nodes:          nodes,
+	}, nil
+}
+
+// setPeerTrustedProxies - sets the proxies whose forwarded headers are
+// honored by all peers.
+func setPeerTrustedProxies(ctx context.Context, peers adminPeers, cidrs []string) error {
+	// Reject invalid proxies before contacting any peer.
+	if _, err := parseTrustedProxies(cidrs); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetTrustedProxies(ctx, cidrs)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// setPeerBucketLogging - updates in-memory logging configuration of
+// bucket on all peers, the configuration is expected to be persisted
+// already.
+func setPeerBucketLogging(ctx context.Context, peers adminPeers, bucket string, targetBucket, prefix string) error {
+	// Reject invalid configurations before contacting any peer.
+	if err := validateBucketLogging(bucket, BucketLogging{TargetBucket: targetBucket, TargetPrefix: prefix}); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetBucketLogging(ctx, bucket, targetBucket, prefix)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerUnusedCredentials - returns the access keys not used on any
+// peer for more than olderThan, or never used, skipping peers which
+// could not be reached. Clients may use a key through any peer, so
+// the most recent use across all peers is what counts.
+func getPeerUnusedCredentials(ctx context.Context, peers adminPeers, olderThan time.Duration) ([]CredUsage, error) {
+	if olderThan < 0 {
+		return nil, errInvalidArgument
+	}
+
+	allUsages := make([][]CredUsage, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		allUsages[idx], errs[idx] = peer.cmdRunner.CredentialUsage(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	var accessKeys []string
+	byKey := make(map[string]*CredUsage)
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch credential usage from %s", peer.addr)
+			continue
+		}
+		for _, usage := range allUsages[i] {
+			merged, ok := byKey[usage.AccessKey]
+			if !ok {
+				merged = &CredUsage{AccessKey: usage.AccessKey, NeverUsed: true}
+				byKey[usage.AccessKey] = merged
+				accessKeys = append(accessKeys, usage.AccessKey)
+			}
+			if !usage.NeverUsed && (merged.NeverUsed || usage.LastUsed.After(merged.LastUsed)) {
+				merged.LastUsed = usage.LastUsed
+				merged.NeverUsed = false
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	sort.Strings(accessKeys)
+	unused := []CredUsage{}
+	for _, accessKey := range accessKeys {
+		if usage := *byKey[accessKey]; usage.isUnused(olderThan, now) {
+			unused = append(unused, usage)
+		}
+	}
+	return unused, nil
+}
+
+// setPeerMaxConcurrentHeals - sets the cap of object heals running at
+// once on every peer.
+func setPeerMaxConcurrentHeals(ctx context.Context, peers adminPeers, max int) error {
+	// Reject invalid caps before contacting any peer.
+	if max < 0 {
+		return errInvalidArgument
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetMaxConcurrentHeals(ctx, max)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// setPeerShutdownTimeout - sets the shutdown timeout on all peers.
+func setPeerShutdownTimeout(ctx context.Context, peers adminPeers, timeout time.Duration) error {
+	// Reject invalid timeouts before contacting any peer.
+	if timeout < 0 {
+		return errInvalidArgument
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetShutdownTimeout(ctx, timeout)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// setPeerChecksumPolicy - sets the object checksum policy on all peers.
+func setPeerChecksumPolicy(ctx context.Context, peers adminPeers, required bool, algorithms []string) error {
+	// Reject invalid policies before contacting any peer.
+	if _, err := parseChecksumAlgorithms(algorithms); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetChecksumPolicy(ctx, required, algorithms)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerClusterSummary - fetches the summary of all peers at once and
+// merges them, skipping peers which could not be reached. Fields
+// depending on the skipped peers are flagged partial.
+func getPeerClusterSummary(ctx context.Context, peers adminPeers) (ClusterSummary, error) {
+	summaries := make([]ServerSummary, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		summaries[idx], errs[idx] = peer.cmdRunner.Summary(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return ClusterSummary{}, err
+	}
+
+	addrs := make([]string, len(peers))
+	for i, peer := range peers {
+		addrs[i] = peer.addr
+		errorIf(errs[i], "Unable to fetch summary from %s", peer.addr)
+	}
+	return newClusterSummary(addrs, summaries, errs, time.Now().UTC()), nil
+}
+
+// getPeerRebalanceSkew - fetches the space used on the disks of all
+// peers and returns the skew of every erasure set. Disks of peers
+// which could not be reached are left out.
+func getPeerRebalanceSkew(ctx context.Context, peers adminPeers) ([]SkewReport, error) {
+	peerFills := make([][]DiskFill, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		peerFills[idx], errs[idx] = peer.cmdRunner.DiskFill(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	var fills []DiskFill
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch disk usage from %s", peer.addr)
+			continue
+		}
+		fills = append(fills, peerFills[i]...)
+	}
+	return newSkewReports(fills), nil
+}
+
+// setPeerNotificationRetry - sets the notification retry policy on all
+// peers.
+func setPeerNotificationRetry(ctx context.Context, peers adminPeers, maxRetries int, backoff time.Duration, deadLetterTarget string) error {
+	// Reject invalid policies before contacting any peer.
+	policy := NotificationRetryPolicy{MaxRetries: maxRetries, Backoff: backoff, DeadLetterTarget: deadLetterTarget}
+	if err := validateNotificationRetry(policy); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetNotificationRetry(ctx, maxRetries, backoff, deadLetterTarget)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// setPeerMaxListDepth - sets the maximum list depth on all peers.
+func setPeerMaxListDepth(ctx context.Context, peers adminPeers, depth int) error {
+	// Reject invalid depths before contacting any peer.
+	if depth < 0 {
+		return errInvalidArgument
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetMaxListDepth(ctx, depth)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerStartupErrors - fetches the errors all peers started in spite
+// of, an empty slice if none did.
+func getPeerStartupErrors(ctx context.Context, peers adminPeers) ([]StartupError, error) {
+	peerErrors := make([][]StartupError, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		peerErrors[idx], errs[idx] = peer.cmdRunner.StartupErrors(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	startupErrors := []StartupError{}
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch startup errors from %s", peer.addr)
+			continue
+		}
+		for _, startupErr := range peerErrors[i] {
+			startupErr.Addr = peer.addr
+			startupErrors = append(startupErrors, startupErr)
+		}
+	}
+	return startupErrors, nil
+}
+
+// getPeerMemSummary - fetches the memory summary of all peers, peers
+// which could not be reached are left out.
+func getPeerMemSummary(ctx context.Context, peers adminPeers) ([]MemStats, error) {
+	peerStats := make([]MemStats, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		peerStats[idx], errs[idx] = peer.cmdRunner.MemSummary(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	memStats := []MemStats{}
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch memory summary from %s", peer.addr)
+			continue
+		}
+		stats := peerStats[i]
+		stats.Addr = peer.addr
+		memStats = append(memStats, stats)
+	}
+	return memStats, nil
+}
+
+// setPeerBucketPartSizeLimits - sets the part size limits of bucket on
+// all peers, both zero removes them.
+func setPeerBucketPartSizeLimits(ctx context.Context, peers adminPeers, bucket string, minPart, maxPart int64) error {
+	// Reject limits out of the S3 bounds before contacting any peer.
+	limits := PartSizeLimits{MinPartSize: minPart, MaxPartSize: maxPart}
+	if limits != (PartSizeLimits{}) {
+		if err := validatePartSizeLimits(limits); err != nil {
+			return err
+		}
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetBucketPartSizeLimits(ctx, bucket, minPart, maxPart)
+	})
+	return reducePeerWriteErrs(peers, errs)
 }
 
-// getValidServerConfig - finds the server config that is present in
-// quorum or more number of servers.
-func getValidServerConfig(serverConfigs []serverConfigV13, errs []error) (serverConfigV13, error) {
-	// majority-based quorum
-	quorum := len(serverConfigs)/2 + 1
+// setPeerDeleteProtectionTag - updates in-memory delete protection tag
+// of bucket on all peers, no key removes it.
+func setPeerDeleteProtectionTag(ctx context.Context, peers adminPeers, bucket, tagKey, tagValue string) error {
+	// Reject invalid tags before contacting any peer.
+	if err := validateDeleteProtectionTag(DeleteProtectionTag{Key: tagKey, Value: tagValue}); err != nil {
+		return err
+	}
 
-	// Count the number of disks a config.json was found in.
-	configCounter := make([]int, len(serverConfigs))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetDeleteProtectionTag(ctx, bucket, tagKey, tagValue)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
 
-	// We group equal serverConfigs by the lowest index of the
-	// same value;  e.g, let us take the following serverConfigs
-	// in a 4-node setup,
-	// serverConfigs == [c1, c2, c1, c1]
-	// configCounter == [3, 1, 0, 0]
-	// c1, c2 are the only distinct values that appear.  c1 is
-	// identified by 0, the lowest index it appears in and c2 is
-	// identified by 1. So, we need to find the number of times
-	// each of these distinct values occur.
+// getPeerConfigChangeLog - fetches the last n config changes logged by
+// all peers, latest first, flagging changes some peers reached did not
+// log.
+func getPeerConfigChangeLog(ctx context.Context, peers adminPeers, n int) ([]ConfigChangeStatus, error) {
+	if n <= 0 || n > configChangeLogSize {
+		return nil, errInvalidArgument
+	}
 
-	// Invariants:
+	logs := make([][]ConfigChange, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		logs[idx], errs[idx] = peer.cmdRunner.ConfigChangeLog(ctx, n)
+	})
 
-	// 1. At the beginning of the i-th iteration, the number of
-	// unique configurations seen so far is equal to the number of
-	// non-zero counter values in config[:i].
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
 
-	// 2. At the beginning of the i-th iteration, the sum of
-	// elements of configCounter[:i] is equal to the number of
-	// non-error configurations seen so far.
+	addrs := make([]string, len(peers))
+	for i, peer := range peers {
+		addrs[i] = peer.addr
+		errorIf(errs[i], "Unable to fetch config change log from %s", peer.addr)
+	}
+	return reconcileConfigChanges(addrs, logs, errs, n), nil
+}
 
-	// For each of the serverConfig ...
-	for i := range serverConfigs {
-		// Skip nodes where getConfig failed.
+// getPeerVersionSkewReport - fetches the version and the online drives
+// of all peers and returns the version skew of the cluster, with the
+// order in which to upgrade the peers behind while keeping write
+// quorum of every erasure set.
+func getPeerVersionSkewReport(ctx context.Context, peers adminPeers) (VersionSkewReport, error) {
+	summaries := make([]ServerSummary, len(peers))
+	peerFills := make([][]DiskFill, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		summaries[idx], errs[idx] = peer.cmdRunner.Summary(ctx)
+		if errs[idx] == nil && summaries[idx].Storage.Backend.Type == Erasure {
+			peerFills[idx], errs[idx] = peer.cmdRunner.DiskFill(ctx)
+		}
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return VersionSkewReport{}, err
+	}
+
+	var servers []serverTopology
+	var unreachable []string
+	writeQuorum := 0
+	for i, peer := range peers {
 		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch version and drives from %s", peer.addr)
+			unreachable = append(unreachable, peer.addr)
 			continue
 		}
-		// Check if it is equal to any of the configurations
-		// seen so far. If j == i is reached then we have an
-		// unseen configuration.
-		for j := 0; j <= i; j++ {
-			if j < i && configCounter[j] == 0 {
-				// serverConfigs[j] is known to be
-				// equal to a value that was already
-				// seen. See example above for
-				// clarity.
-				continue
-			} else if j < i && reflect.DeepEqual(serverConfigs[i], serverConfigs[j]) {
-				// serverConfigs[i] is equal to
-				// serverConfigs[j], update
-				// serverConfigs[j]'s counter since it
-				// is the lower index.
-				configCounter[j]++
-				break
-			} else if j == i {
-				// serverConfigs[i] is equal to no
-				// other value seen before. It is
-				// unique so far.
-				configCounter[i] = 1
-				break
-			} // else invariants specified above are violated.
+		server := serverTopology{
+			Addr:    peer.addr,
+			Version: summaries[i].Version,
+			Drives:  make(map[int]int),
 		}
+		for _, fill := range peerFills[i] {
+			server.Drives[fill.Set]++
+		}
+		servers = append(servers, server)
+		writeQuorum = summaries[i].Storage.Backend.WriteQuorum
 	}
+	return newVersionSkewReport(servers, unreachable, writeQuorum), nil
+}
 
-	// We find the maximally occurring server config and check if
-	// there is quorum.
-	var configJSON serverConfigV13
-	maxOccurrence := 0
-	for i, count := range configCounter {
-		if maxOccurrence < count {
-			maxOccurrence = count
-			configJSON = serverConfigs[i]
+// setPeerScanOnWrite - enables or disables verification of writes on
+// all peers.
+func setPeerScanOnWrite(ctx context.Context, peers adminPeers, enabled bool) error {
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetScanOnWrite(ctx, enabled)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerCapacityForecast - fetches the usage scans and the free space
+// of all peers and projects the days left until the cluster and every
+// peer are full, at the growth measured by the scans started within
+// window. Peers which could not be reached are left out.
+func getPeerCapacityForecast(ctx context.Context, peers adminPeers, window time.Duration) (ClusterCapacityForecast, error) {
+	if window <= 0 {
+		return ClusterCapacityForecast{}, errInvalidArgument
+	}
+
+	summaries := make([]ServerSummary, len(peers))
+	peerFills := make([][]DiskFill, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		summaries[idx], errs[idx] = peer.cmdRunner.Summary(ctx)
+		if errs[idx] == nil && summaries[idx].Storage.Backend.Type == Erasure {
+			peerFills[idx], errs[idx] = peer.cmdRunner.DiskFill(ctx)
 		}
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return ClusterCapacityForecast{}, err
 	}
 
-	// If quorum nodes don't agree.
-	if maxOccurrence < quorum {
-		return serverConfigV13{}, errXLWriteQuorum
+	var nodes []nodeCapacity
+	var unreachable []string
+	var storage StorageInfo
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch usage scans and free space from %s", peer.addr)
+			unreachable = append(unreachable, peer.addr)
+			continue
+		}
+		if len(nodes) == 0 {
+			storage = summaries[i].Storage
+		}
+		node := nodeCapacity{Addr: peer.addr, Scans: summaries[i].UsageScans, Free: summaries[i].Storage.Free}
+		if summaries[i].Storage.Backend.Type == Erasure {
+			node.Disks = len(peerFills[i])
+			node.Free = 0
+			for _, fill := range peerFills[i] {
+				node.Free += fill.Total - fill.Used
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return newClusterCapacityForecast(nodes, unreachable, storage, window, time.Now().UTC()), nil
+}
+
+// setPeerCredentialExpiry - sets the expiry of an access key on all
+// peers, the zero time removes it.
+func setPeerCredentialExpiry(ctx context.Context, peers adminPeers, accessKey string, expiry time.Time) error {
+	// Reject unknown access keys before contacting any peer.
+	if accessKey != serverConfig.GetCredential().AccessKey {
+		return errInvalidAccessKeyID
 	}
 
-	return configJSON, nil
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetCredentialExpiry(ctx, accessKey, expiry)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerObjectDistribution - fetches the objects of bucket stored on
+// the erasure sets of the disks of all peers and merges them into the
+// distribution of the bucket across sets. Peers which could not be
+// reached are left out, the sets they hold may be missing.
+func getPeerObjectDistribution(ctx context.Context, peers adminPeers, bucket string) (ObjectDistribution, error) {
+	peerSets := make([][]SetObjects, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		peerSets[idx], errs[idx] = peer.cmdRunner.ObjectDistribution(ctx, bucket)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return ObjectDistribution{}, err
+	}
+
+	var views [][]SetObjects
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch object distribution from %s", peer.addr)
+			continue
+		}
+		views = append(views, peerSets[i])
+	}
+	return newObjectDistribution(bucket, views), nil
+}
+
+// setPeerMultiDeleteLimit - sets the multi-delete limit on all peers.
+func setPeerMultiDeleteLimit(ctx context.Context, peers adminPeers, maxKeys int) error {
+	// Reject invalid limits before contacting any peer.
+	if maxKeys < 1 || maxKeys > maxDeleteObjects {
+		return errInvalidArgument
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetMultiDeleteLimit(ctx, maxKeys)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerRejectReasons - fetches the requests all peers rejected over
+// window and adds them up by reason. Peers which could not be reached
+// are left out.
+func getPeerRejectReasons(ctx context.Context, peers adminPeers, window time.Duration) (map[string]int, error) {
+	// Reject invalid windows before contacting any peer.
+	if window <= 0 || window > rejectReasonMaxWindow {
+		return nil, errInvalidArgument
+	}
+
+	peerReasons := make([]map[string]int, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		peerReasons[idx], errs[idx] = peer.cmdRunner.RejectReasons(ctx, window)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[string]int)
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch rejected requests from %s", peer.addr)
+			continue
+		}
+		for reason, n := range peerReasons[i] {
+			reasons[reason] += n
+		}
+	}
+	return reasons, nil
+}
+
+// getPeerSlowRequestLog - fetches the last n requests slower than
+// thresholdMs milliseconds served by all peers, and returns the last n
+// of them latest first, each with the address of the peer which served
+// it. Peers which could not be reached are left out.
+func getPeerSlowRequestLog(ctx context.Context, peers adminPeers, thresholdMs, n int) ([]SlowRequest, error) {
+	// Reject invalid queries before contacting any peer.
+	threshold := time.Duration(thresholdMs) * time.Millisecond
+	if threshold < slowRequestMinThreshold || n <= 0 || n > slowRequestLogSize {
+		return nil, errInvalidArgument
+	}
+
+	logs := make([][]SlowRequest, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		logs[idx], errs[idx] = peer.cmdRunner.SlowRequestLog(ctx, thresholdMs, n)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch slow requests from %s", peer.addr)
+			logs[i] = nil
+			continue
+		}
+		for j := range logs[i] {
+			logs[i][j].Node = peer.addr
+		}
+	}
+	return mergeSlowRequests(logs, n), nil
+}
+
+// getPeerDiskIOStats - fetches the IO activity of the disks of all
+// peers, sorted by peer address and endpoint. Peers which could not be
+// reached are left out.
+func getPeerDiskIOStats(ctx context.Context, peers adminPeers) ([]DiskIO, error) {
+	peerStats := make([][]DiskIO, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		peerStats[idx], errs[idx] = peer.cmdRunner.DiskIOStats(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string][]DiskIO)
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch disk IO stats from %s", peer.addr)
+			continue
+		}
+		nodes[peer.addr] = peerStats[i]
+	}
+	return mergeDiskIOStats(nodes), nil
+}
+
+// getPeerClientConnections - fetches the activity of the clients of
+// all peers, per client IP. A client sending requests to several
+// servers has its activity on each added up. Peers which could not be
+// reached are left out.
+func getPeerClientConnections(ctx context.Context, peers adminPeers) (map[string]ClientConnStats, error) {
+	views := make([]map[string]ClientConnStats, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		views[idx], errs[idx] = peer.cmdRunner.ClientConnections(ctx)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch client connections from %s", peer.addr)
+			views[i] = nil
+		}
+	}
+	return mergeClientConnections(views), nil
+}
+
+// setPeerBackgroundAppend - enables or disables background append of
+// the parts of new multipart uploads on all peers.
+func setPeerBackgroundAppend(ctx context.Context, peers adminPeers, enabled bool) error {
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetBackgroundAppend(ctx, enabled)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerLastAccessReport - returns the objects of bucket under prefix
+// not read on any peer within olderThan, with their last read. Clients
+// may read an object through any peer, so the most recent read across
+// all peers is what counts. Peers which could not be reached are left
+// out, objects they served recently may be reported.
+func getPeerLastAccessReport(ctx context.Context, peers adminPeers, bucket, prefix string, olderThan time.Duration) ([]ColdObject, error) {
+	if olderThan < 0 {
+		return nil, errInvalidArgument
+	}
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return nil, errServerNotInitialized
+	}
+
+	views := make([]map[string]time.Time, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		views[idx], errs[idx] = peer.cmdRunner.LastAccessTimes(ctx, bucket, prefix)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch last access times from %s", peer.addr)
+			views[i] = nil
+		}
+	}
+	return lastAccessReport(objLayer, bucket, prefix, mergeLastAccessTimes(views), olderThan, time.Now().UTC())
+}
+
+// setPeerBucketUploadLimit - sets the concurrent upload limit of bucket
+// on all peers.
+func setPeerBucketUploadLimit(ctx context.Context, peers adminPeers, bucket string, maxUploads int) error {
+	// Reject invalid limits before contacting any peer.
+	if maxUploads < 0 {
+		return errInvalidUploadLimit
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetBucketUploadLimit(ctx, bucket, maxUploads)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// getPeerDecodeFailureLog - fetches the last n decode failures of all
+// peers, latest first. Failures are resolved by heals of any peer.
+// Peers which could not be reached are left out.
+func getPeerDecodeFailureLog(ctx context.Context, peers adminPeers, n int) ([]DecodeFailure, error) {
+	if n <= 0 || n > decodeFailureLogSize {
+		return nil, errInvalidArgument
+	}
+
+	views := make([]NodeDecodeFailures, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		views[idx], errs[idx] = peer.cmdRunner.DecodeFailureLog(ctx, n)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return nil, err
+	}
+
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch decode failures from %s", peer.addr)
+			views[i] = NodeDecodeFailures{}
+			continue
+		}
+		for j := range views[i].Failures {
+			views[i].Failures[j].Node = peer.addr
+		}
+	}
+	return mergeDecodeFailures(views, n), nil
+}
+
+// getPeerShardDiagnosis - fetches the health of the shards of object
+// from the peers owning their disks, and suggests how to repair the
+// object. Disks of peers which could not be reached are offline.
+func getPeerShardDiagnosis(ctx context.Context, peers adminPeers, bucket, object string) (ShardDiag, error) {
+	views := make([][]ShardHealth, len(peers))
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		views[idx], errs[idx] = peer.cmdRunner.ShardHealth(ctx, bucket, object)
+	})
+
+	if err := reducePeerReadErrs(errs); err != nil {
+		return ShardDiag{}, err
+	}
+
+	for i, peer := range peers {
+		if errs[i] != nil {
+			errorIf(errs[i], "Unable to fetch shard health from %s", peer.addr)
+			views[i] = nil
+		}
+	}
+	return newShardDiag(bucket, object, globalEndpoints, views)
+}
+
+// setPeerMaintenanceSchedule - sets the maintenance schedule on all
+// peers.
+func setPeerMaintenanceSchedule(ctx context.Context, peers adminPeers, schedule MaintenanceSchedule) error {
+	// Reject malformed schedules before contacting any peer.
+	if _, err := maintenanceSpans(schedule); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetMaintenanceSchedule(ctx, schedule)
+	})
+	return reducePeerWriteErrs(peers, errs)
+}
+
+// setPeerQuorumOverride - overrides the XL quorum on all peers for ttl,
+// a zero ttl removes the override.
+func setPeerQuorumOverride(ctx context.Context, peers adminPeers, readQuorum, writeQuorum int, ttl time.Duration) error {
+	// Reject invalid overrides before contacting any peer.
+	if err := validateQuorumOverride(readQuorum, writeQuorum, ttl); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(peers))
+	forEachPeer(ctx, peers, func(ctx context.Context, idx int, peer adminPeer) {
+		errs[idx] = peer.cmdRunner.SetQuorumOverride(ctx, readQuorum, writeQuorum, ttl)
+	})
+	return reducePeerWriteErrs(peers, errs)
 }
@@ -17,9 +17,19 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 var (
@@ -258,3 +268,4099 @@ func TestGetValidServerConfig(t *testing.T) {
 		t.Errorf("Expected to fail due to lack of quorum but received %v", err)
 	}
 }
+
+// uptimeStub - adminCmdRunner returning a fixed uptime or err after
+// delay.
+type uptimeStub struct {
+	adminCmdRunner
+	uptime time.Duration
+	err    error
+	delay  time.Duration
+}
+
+func (s uptimeStub) Uptime(ctx context.Context) (time.Duration, error) {
+	time.Sleep(s.delay)
+	return s.uptime, s.err
+}
+
+// TestPeerUptimesSlowPeers - tests getPeerUptimes waits for the
+// slowest servers, which may hold the longest uptimes.
+func TestPeerUptimesSlowPeers(t *testing.T) {
+	defer func(isDistXL bool) {
+		globalIsDistXL = isDistXL
+	}(globalIsDistXL)
+	globalIsDistXL = true
+
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: uptimeStub{uptime: 100 * time.Minute}},
+		{addr: "server1:9000", cmdRunner: uptimeStub{uptime: 90 * time.Minute}},
+		{addr: "server2:9000", cmdRunner: uptimeStub{uptime: 80 * time.Minute, delay: 100 * time.Millisecond}},
+		{addr: "server3:9000", cmdRunner: uptimeStub{uptime: 5 * time.Minute}},
+	}
+	uptime, err := getPeerUptimes(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The 3rd longest uptime of all 4 servers.
+	if uptime != 80*time.Minute {
+		t.Fatalf("Expected uptime %s, got %s", 80*time.Minute, uptime)
+	}
+}
+
+// TestPeerQuorumAgreement - tests getPeerUptimes and
+// getValidServerConfig agree on whether quorum exists for the same
+// failed servers.
+func TestPeerQuorumAgreement(t *testing.T) {
+	defer func(isDistXL bool) {
+		globalIsDistXL = isDistXL
+	}(globalIsDistXL)
+	globalIsDistXL = true
+
+	var config serverConfigV13
+	if err := json.Unmarshal(config1, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		servers int
+		failed  int
+		quorum  bool
+	}{
+		{1, 0, true},
+		{1, 1, false},
+		{4, 0, true},
+		{4, 1, true},
+		{4, 2, false},
+		{4, 3, false},
+		{8, 3, true},
+		{8, 4, false},
+		{16, 0, true},
+		{16, 7, true},
+		{16, 8, false},
+		{16, 16, false},
+	}
+	for i, testCase := range testCases {
+		peers := make(adminPeers, testCase.servers)
+		serverConfigs := make([]serverConfigV13, testCase.servers)
+		errs := make([]error, testCase.servers)
+		for j := range peers {
+			stub := uptimeStub{uptime: time.Duration(j+1) * time.Minute}
+			if j < testCase.failed {
+				stub.err = errDiskNotFound
+				errs[j] = errDiskNotFound
+			} else {
+				serverConfigs[j] = config
+			}
+			peers[j] = adminPeer{addr: fmt.Sprintf("server%d:9000", j), cmdRunner: stub}
+		}
+
+		uptime, uErr := getPeerUptimes(context.Background(), peers)
+		if (uErr == nil) != testCase.quorum {
+			t.Errorf("Test %d: expected uptime quorum %v, got %v", i+1, testCase.quorum, uErr)
+		}
+		if _, cErr := getValidServerConfig(serverConfigs, errs); (cErr == nil) != testCase.quorum {
+			t.Errorf("Test %d: expected config quorum %v, got %v", i+1, testCase.quorum, cErr)
+		}
+
+		// The uptime is the quorum'th longest of the servers which
+		// replied.
+		if expected := time.Duration(testCase.servers-peerQuorum(testCase.servers)+1) * time.Minute; uErr == nil && uptime != expected {
+			t.Errorf("Test %d: expected uptime %s, got %s", i+1, expected, uptime)
+		}
+	}
+}
+
+// TestReducePeerReadErrs - tests reducePeerReadErrs requires a majority
+// of peers to agree, whether on success or on an error.
+func TestReducePeerReadErrs(t *testing.T) {
+	testCases := []struct {
+		errs []error
+		err  error
+	}{
+		{[]error{nil}, nil},
+		{[]error{errDiskNotFound}, errDiskNotFound},
+		{[]error{nil, errDiskNotFound}, InsufficientReadQuorum{}},
+		{[]error{errDiskNotFound, nil}, InsufficientReadQuorum{}},
+		{[]error{nil, nil, errDiskNotFound}, nil},
+		// nil is the plurality but not a majority.
+		{[]error{nil, nil, errDiskNotFound, errDiskFull, errFaultyDisk}, InsufficientReadQuorum{}},
+		{[]error{errDiskNotFound, errDiskNotFound, nil}, errDiskNotFound},
+		{[]error{nil, nil, errDiskNotFound, errDiskNotFound}, InsufficientReadQuorum{}},
+	}
+	for i, testCase := range testCases {
+		for j := 0; j < 100; j++ {
+			if err := reducePeerReadErrs(testCase.errs); err != testCase.err {
+				t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+			}
+		}
+	}
+}
+
+// TestNewPeerErrors - tests newPeerErrors keys the errors by peer
+// address and returns nil if no peer failed.
+func TestNewPeerErrors(t *testing.T) {
+	peers := adminPeers{{addr: "server0:9000"}, {addr: "server1:9000"}, {addr: "server2:9000"}}
+	if err := newPeerErrors(peers, []error{nil, nil, nil}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	err := newPeerErrors(peers, []error{errDiskNotFound, nil, errServerNotInitialized})
+	expected := PeerErrors{"server0:9000": errDiskNotFound, "server2:9000": errServerNotInitialized}
+	if !reflect.DeepEqual(err, expected) {
+		t.Fatalf("Expected %v, got %v", expected, err)
+	}
+	// Servers are named in address order.
+	if msg := fmt.Sprintf("Failed on server0:9000: %v, server2:9000: %v", errDiskNotFound, errServerNotInitialized); err.Error() != msg {
+		t.Fatalf("Expected %q, got %q", msg, err.Error())
+	}
+}
+
+// setStatusStub - adminCmdRunner returning a fixed erasure set view.
+type setStatusStub struct {
+	adminCmdRunner
+	sets []SetStatus
+	err  error
+}
+
+func (s setStatusStub) ErasureSetStatus(ctx context.Context) ([]SetStatus, error) {
+	return s.sets, s.err
+}
+
+// TestGetPeerErasureSetStatus - test for getPeerErasureSetStatus.
+func TestGetPeerErasureSetStatus(t *testing.T) {
+	// A set of 4 drives with 2 parity drives, read quorum 2 and
+	// write quorum 3, as seen by a particular server.
+	view := func(drives ...bool) []SetStatus {
+		return []SetStatus{newSetStatus(0, drives, 2, 2, 3)}
+	}
+	makePeers := func(stubs ...setStatusStub) adminPeers {
+		var peers adminPeers
+		for i, stub := range stubs {
+			peers = append(peers, adminPeer{
+				addr:      fmt.Sprintf("server%d:9000", i),
+				cmdRunner: stub,
+			})
+		}
+		return peers
+	}
+
+	testCases := []struct {
+		peers           adminPeers
+		expectedErr     error
+		expectedOnline  int
+		parityRemaining int
+		readQuorum      bool
+		writeQuorum     bool
+		consistent      bool
+	}{
+		// All drives online on all servers.
+		{
+			peers: makePeers(
+				setStatusStub{sets: view(true, true, true, true)},
+				setStatusStub{sets: view(true, true, true, true)},
+				setStatusStub{sets: view(true, true, true, true)},
+			),
+			expectedOnline:  4,
+			parityRemaining: 2,
+			readQuorum:      true,
+			writeQuorum:     true,
+			consistent:      true,
+		},
+		// Two drives offline, one of them reachable from a
+		// single server only.
+		{
+			peers: makePeers(
+				setStatusStub{sets: view(true, true, false, false)},
+				setStatusStub{sets: view(true, true, false, true)},
+				setStatusStub{sets: view(true, true, false, false)},
+			),
+			expectedOnline:  2,
+			parityRemaining: 0,
+			readQuorum:      true,
+			writeQuorum:     false,
+			consistent:      false,
+		},
+		// One drive offline, one server unreachable.
+		{
+			peers: makePeers(
+				setStatusStub{sets: view(true, false, true, true)},
+				setStatusStub{err: errDiskNotFound},
+				setStatusStub{sets: view(true, false, true, true)},
+			),
+			expectedOnline:  3,
+			parityRemaining: 1,
+			readQuorum:      true,
+			writeQuorum:     true,
+			consistent:      true,
+		},
+		// Majority of servers unreachable.
+		{
+			peers: makePeers(
+				setStatusStub{sets: view(true, true, true, true)},
+				setStatusStub{err: errDiskNotFound},
+				setStatusStub{err: errDiskNotFound},
+			),
+			expectedErr: errDiskNotFound,
+		},
+	}
+
+	for i, test := range testCases {
+		sets, err := getPeerErasureSetStatus(context.Background(), test.peers)
+		if err != test.expectedErr {
+			t.Fatalf("Test %d: Expected error %v but received %v", i+1, test.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if len(sets) != 1 {
+			t.Fatalf("Test %d: Expected 1 erasure set but received %d", i+1, len(sets))
+		}
+		set := sets[0]
+		if set.OnlineDrives != test.expectedOnline {
+			t.Errorf("Test %d: Expected %d online drives but received %d", i+1, test.expectedOnline, set.OnlineDrives)
+		}
+		if set.ParityRemaining != test.parityRemaining {
+			t.Errorf("Test %d: Expected %d parity remaining but received %d", i+1, test.parityRemaining, set.ParityRemaining)
+		}
+		if set.HasReadQuorum != test.readQuorum || set.HasWriteQuorum != test.writeQuorum {
+			t.Errorf("Test %d: Expected read/write quorum %v/%v but received %v/%v", i+1,
+				test.readQuorum, test.writeQuorum, set.HasReadQuorum, set.HasWriteQuorum)
+		}
+		if set.Consistent != test.consistent {
+			t.Errorf("Test %d: Expected consistent to be %v", i+1, test.consistent)
+		}
+	}
+}
+
+// throttleStatusStub - adminCmdRunner reporting the limits of its
+// own registry.
+type throttleStatusStub struct {
+	adminCmdRunner
+	registry *throttlers
+	err      error
+}
+
+func (s throttleStatusStub) ThrottleStatus(ctx context.Context) ([]ThrottleStatus, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.registry.status(), nil
+}
+
+// TestGetPeerThrottleStatus - test for getPeerThrottleStatus.
+func TestGetPeerThrottleStatus(t *testing.T) {
+	configured := newTestThrottlers(map[string]throttler{
+		"anonymous": testThrottler{100, 25},
+		"bandwidth": testThrottler{0, 0},
+	})
+	unconfigured := newTestThrottlers(map[string]throttler{
+		"anonymous": testThrottler{0, 0},
+		"bandwidth": testThrottler{0, 0},
+	})
+	makePeers := func(stubs ...throttleStatusStub) adminPeers {
+		var peers adminPeers
+		for i, stub := range stubs {
+			peers = append(peers, adminPeer{
+				addr:      fmt.Sprintf("server%d:9000", i),
+				cmdRunner: stub,
+			})
+		}
+		return peers
+	}
+	active := []ThrottleStatus{
+		{Name: "anonymous", Limit: 100, InUse: 25, Utilization: 0.25},
+	}
+
+	testCases := []struct {
+		peers       adminPeers
+		expected    []NodeThrottleStatus
+		expectedErr error
+	}{
+		// Test 1: only servers with configured limits are listed.
+		{
+			peers: makePeers(
+				throttleStatusStub{registry: configured},
+				throttleStatusStub{registry: unconfigured},
+				throttleStatusStub{registry: configured},
+			),
+			expected: []NodeThrottleStatus{
+				{Addr: "server0:9000", Throttles: active},
+				{Addr: "server2:9000", Throttles: active},
+			},
+		},
+		// Test 2: no limits configured anywhere.
+		{
+			peers: makePeers(
+				throttleStatusStub{registry: unconfigured},
+				throttleStatusStub{registry: unconfigured},
+			),
+			expected: []NodeThrottleStatus{},
+		},
+		// Test 3: an unreachable server is left out.
+		{
+			peers: makePeers(
+				throttleStatusStub{registry: configured},
+				throttleStatusStub{err: errDiskNotFound},
+				throttleStatusStub{registry: configured},
+			),
+			expected: []NodeThrottleStatus{
+				{Addr: "server0:9000", Throttles: active},
+				{Addr: "server2:9000", Throttles: active},
+			},
+		},
+		// Test 4: majority of servers unreachable.
+		{
+			peers: makePeers(
+				throttleStatusStub{registry: configured},
+				throttleStatusStub{err: errDiskNotFound},
+				throttleStatusStub{err: errDiskNotFound},
+			),
+			expectedErr: errDiskNotFound,
+		},
+	}
+
+	for i, test := range testCases {
+		nodes, err := getPeerThrottleStatus(context.Background(), test.peers)
+		if err != test.expectedErr {
+			t.Errorf("Test %d: Expected error %v, got %v", i+1, test.expectedErr, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(nodes, test.expected) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, test.expected, nodes)
+		}
+	}
+}
+
+// apiLatencyStub - adminCmdRunner returning fixed latency histograms.
+type apiLatencyStub struct {
+	adminCmdRunner
+	histograms map[string]LatencyHistogram
+	err        error
+}
+
+func (s apiLatencyStub) APILatency(ctx context.Context) (map[string]LatencyHistogram, error) {
+	return s.histograms, s.err
+}
+
+// TestGetPeerAPILatency - test for getPeerAPILatency.
+func TestGetPeerAPILatency(t *testing.T) {
+	insufficient := LatencyPercentile{InsufficientData: true}
+	// Half the requests are fast on one server, the other half
+	// slow on another, only the merged histogram shows both.
+	fast := map[string]LatencyHistogram{
+		"GetObject": newTestLatencyHistogram(map[time.Duration]uint64{time.Millisecond: 500}),
+		"PutObject": newTestLatencyHistogram(map[time.Duration]uint64{time.Millisecond: 5}),
+	}
+	slow := map[string]LatencyHistogram{
+		"GetObject": newTestLatencyHistogram(map[time.Duration]uint64{time.Second: 500}),
+	}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: apiLatencyStub{histograms: fast}},
+		{addr: "server1:9000", cmdRunner: apiLatencyStub{histograms: slow}},
+		{addr: "server2:9000", cmdRunner: apiLatencyStub{err: errDiskNotFound}},
+	}
+
+	expected := map[string]LatencyPercentiles{
+		"GetObject": {
+			Samples: 1000,
+			P50:     latencyBound(time.Millisecond),
+			P90:     latencyBound(time.Second),
+			P99:     latencyBound(time.Second),
+			P999:    insufficient,
+		},
+		"PutObject": {
+			Samples: 5,
+			P50:     insufficient, P90: insufficient, P99: insufficient, P999: insufficient,
+		},
+	}
+	percentiles, err := getPeerAPILatency(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(percentiles, expected) {
+		t.Fatalf("Expected %v, got %v", expected, percentiles)
+	}
+
+	// Majority of servers unreachable.
+	peers[1].cmdRunner = apiLatencyStub{err: errDiskNotFound}
+	if _, err = getPeerAPILatency(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// bootDiagnosticsStub - adminCmdRunner returning fixed boot timings.
+type bootDiagnosticsStub struct {
+	adminCmdRunner
+	timings BootTimings
+	err     error
+}
+
+func (s bootDiagnosticsStub) BootDiagnostics(ctx context.Context) (BootTimings, error) {
+	return s.timings, s.err
+}
+
+// TestGetPeerBootDiagnostics - test for getPeerBootDiagnostics.
+func TestGetPeerBootDiagnostics(t *testing.T) {
+	// bootTimings - returns timings with durations of phases in
+	// seconds, negative durations are of pending phases.
+	bootTimings := func(durations ...int) BootTimings {
+		timings := BootTimings{}
+		for i, d := range durations {
+			phase := BootPhase{Name: bootPhases[i]}
+			if d < 0 {
+				phase.Pending = true
+			} else {
+				phase.Duration = time.Duration(d) * time.Second
+			}
+			timings.Phases = append(timings.Phases, phase)
+		}
+		return timings
+	}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: bootDiagnosticsStub{timings: bootTimings(1, 2, 1, 3)}},
+		{addr: "server1:9000", cmdRunner: bootDiagnosticsStub{timings: bootTimings(1, 30, 2, 4)}},
+		// Still waiting for disk format check to finish.
+		{addr: "server2:9000", cmdRunner: bootDiagnosticsStub{timings: bootTimings(2, 5, -1, -1)}},
+		{addr: "server3:9000", cmdRunner: bootDiagnosticsStub{err: errDiskNotFound}},
+	}
+
+	nodes, err := getPeerBootDiagnostics(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("Expected timings of 3 servers, got %d", len(nodes))
+	}
+
+	// Slowest server expected per phase.
+	expectedSlowest := []string{"server2:9000", "server1:9000", "server1:9000", "server1:9000"}
+	for p, addr := range expectedSlowest {
+		for _, node := range nodes {
+			if slowest := node.Timings.Phases[p].Slowest; slowest != (node.Addr == addr) {
+				t.Errorf("Phase %s: Expected slowest of %s to be %v", bootPhases[p], node.Addr, !slowest)
+			}
+		}
+	}
+	// Partially booted server reports its remaining phases as pending.
+	for _, phase := range nodes[2].Timings.Phases[2:] {
+		if !phase.Pending {
+			t.Errorf("Expected %s of server2:9000 to be pending", phase.Name)
+		}
+	}
+
+	// Majority of servers unreachable.
+	peers[0].cmdRunner = bootDiagnosticsStub{err: errDiskNotFound}
+	peers[1].cmdRunner = bootDiagnosticsStub{err: errDiskNotFound}
+	if _, err = getPeerBootDiagnostics(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// versionClockStub - adminCmdRunner returning version and clock,
+// offset by skew from the actual time.
+type versionClockStub struct {
+	adminCmdRunner
+	version      string
+	skew         time.Duration
+	clockFailure bool
+	err          error
+}
+
+func (s versionClockStub) VersionClock(ctx context.Context) (ServerVersionClock, error) {
+	if s.err != nil {
+		return ServerVersionClock{}, s.err
+	}
+	info := ServerVersionClock{Version: s.version, CommitID: "commit-" + s.version}
+	if !s.clockFailure {
+		info.CurrentTime = time.Now().UTC().Add(s.skew)
+	}
+	return info, nil
+}
+
+// TestGetPeerVersionClockMatrix - test for getPeerVersionClockMatrix.
+func TestGetPeerVersionClockMatrix(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: versionClockStub{version: "v1"}},
+		{addr: "server1:9000", cmdRunner: versionClockStub{version: "v1", skew: -time.Minute}},
+		// Skewed clock and mismatched version.
+		{addr: "server2:9000", cmdRunner: versionClockStub{version: "v2", skew: 20 * time.Minute}},
+		// Version available but clock is not.
+		{addr: "server3:9000", cmdRunner: versionClockStub{version: "v2", clockFailure: true}},
+		{addr: "server4:9000", cmdRunner: versionClockStub{err: errDiskNotFound}},
+	}
+
+	matrix, err := getPeerVersionClockMatrix(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matrix) != len(peers) {
+		t.Fatalf("Expected %d entries, got %d", len(peers), len(matrix))
+	}
+
+	testCases := []struct {
+		version          string
+		versionMismatch  bool
+		clockSkewed      bool
+		clockUnavailable bool
+		unreachable      bool
+	}{
+		{"v1", false, false, false, false},
+		{"v1", false, false, false, false},
+		{"v2", true, true, false, false},
+		{"v2", true, false, true, false},
+		{"", false, false, false, true},
+	}
+	for i, test := range testCases {
+		entry := matrix[i]
+		if entry.Addr != peers[i].addr {
+			t.Errorf("Test %d: Expected addr %s, got %s", i+1, peers[i].addr, entry.Addr)
+		}
+		if entry.Version != test.version {
+			t.Errorf("Test %d: Expected version %s, got %s", i+1, test.version, entry.Version)
+		}
+		if entry.VersionMismatch != test.versionMismatch {
+			t.Errorf("Test %d: Expected version mismatch to be %v", i+1, test.versionMismatch)
+		}
+		if entry.ClockSkewed != test.clockSkewed {
+			t.Errorf("Test %d: Expected clock skewed to be %v, skew %v", i+1, test.clockSkewed, entry.ClockSkew)
+		}
+		if entry.ClockUnavailable != test.clockUnavailable {
+			t.Errorf("Test %d: Expected clock unavailable to be %v", i+1, test.clockUnavailable)
+		}
+		if (entry.Error != "") != test.unreachable {
+			t.Errorf("Test %d: Expected unreachable to be %v, got error %q", i+1, test.unreachable, entry.Error)
+		}
+	}
+
+	// Majority of servers unreachable.
+	for i := 0; i < 3; i++ {
+		peers[i].cmdRunner = versionClockStub{err: errDiskNotFound}
+	}
+	if _, err = getPeerVersionClockMatrix(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// disksByStateStub - adminCmdRunner filtering a fixed set of disks by
+// state, counting the calls made to it.
+type disksByStateStub struct {
+	adminCmdRunner
+	disks []DiskInfoMsg
+	calls *int32
+}
+
+func (s disksByStateStub) DisksByState(ctx context.Context, state string) ([]DiskInfoMsg, error) {
+	atomic.AddInt32(s.calls, 1)
+	disks := []DiskInfoMsg{}
+	for _, disk := range s.disks {
+		if disk.State == state {
+			disks = append(disks, disk)
+		}
+	}
+	return disks, nil
+}
+
+// TestGetPeerDisksByState - test for getPeerDisksByState.
+func TestGetPeerDisksByState(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr: fmt.Sprintf("server%d:9000", i),
+			cmdRunner: disksByStateStub{
+				disks: []DiskInfoMsg{
+					{Endpoint: fmt.Sprintf("/disk%d-1", i), State: diskStateOnline},
+					{Endpoint: fmt.Sprintf("/disk%d-2", i), State: diskStateDegraded},
+				},
+				calls: &calls,
+			},
+		}
+	}
+	peers[1].cmdRunner = disksByStateStub{
+		disks: []DiskInfoMsg{
+			{Endpoint: "/disk1-1", State: diskStateOffline},
+			{Endpoint: "/disk1-2", State: diskStateOnline},
+		},
+		calls: &calls,
+	}
+	peers[3].cmdRunner = disksByStateStub{
+		disks: []DiskInfoMsg{
+			{Endpoint: "/disk3-1", State: diskStateOffline},
+			{Endpoint: "/disk3-2", State: diskStateOffline},
+		},
+		calls: &calls,
+	}
+
+	disks, err := getPeerDisksByState(context.Background(), peers, diskStateOffline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []DiskInfoMsg{
+		{Addr: "server1:9000", Endpoint: "/disk1-1", State: diskStateOffline},
+		{Addr: "server3:9000", Endpoint: "/disk3-1", State: diskStateOffline},
+		{Addr: "server3:9000", Endpoint: "/disk3-2", State: diskStateOffline},
+	}
+	if !reflect.DeepEqual(disks, expected) {
+		t.Fatalf("Expected %v, got %v", expected, disks)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Unknown states must be rejected before fan-out.
+	calls = 0
+	if _, err = getPeerDisksByState(context.Background(), peers, "unhealthy"); err != errInvalidDiskState {
+		t.Fatalf("Expected %v, got %v", errInvalidDiskState, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no peer calls for invalid state, got %d", calls)
+	}
+	if code := toAPIErrorCode(errInvalidDiskState); code != ErrAdminInvalidDiskState {
+		t.Fatalf("Expected %v, got %v", ErrAdminInvalidDiskState, code)
+	}
+}
+
+// clusterEventsStub - adminCmdRunner serving events of its own event
+// log.
+type clusterEventsStub struct {
+	adminCmdRunner
+	log *clusterEventLog
+	err error
+}
+
+func (s clusterEventsStub) ClusterEvents(ctx context.Context, since uint64) (ClusterEventBatch, error) {
+	if s.err != nil {
+		return ClusterEventBatch{}, s.err
+	}
+	return s.log.since(since), nil
+}
+
+// TestSubscribePeerEvents - test for subscribePeerEvents.
+func TestSubscribePeerEvents(t *testing.T) {
+	logs := make([]*clusterEventLog, 4)
+	peers := make(adminPeers, len(logs))
+	for i := range logs {
+		logs[i] = &clusterEventLog{}
+		// Events before subscribing are not sent.
+		logs[i].emit(clusterEventHealStart, "old")
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: clusterEventsStub{log: logs[i]},
+		}
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	eventCh, err := subscribePeerEvents(context.Background(), peers, 10*time.Millisecond, doneCh)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receiveEvents := func(n int) (map[string]int, map[string]bool) {
+		counts := make(map[string]int)
+		healNodes := make(map[string]bool)
+		for received := 0; received < n; received++ {
+			select {
+			case event := <-eventCh:
+				counts[event.Type]++
+				if event.Type == clusterEventHealStart {
+					if event.Detail != "bucket" {
+						t.Fatalf("Unexpected heal event %v", event)
+					}
+					healNodes[event.Node] = true
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Timed out waiting for events, got %v", counts)
+			}
+		}
+		return counts, healNodes
+	}
+
+	// Every server loses quorum, two of them heal.
+	for _, log := range logs {
+		log.emit(clusterEventQuorumLost, "")
+	}
+	logs[1].emit(clusterEventHealStart, "bucket")
+	logs[2].emit(clusterEventHealStart, "bucket")
+	counts, healNodes := receiveEvents(3)
+	if counts[clusterEventQuorumLost] != 1 {
+		t.Fatalf("Expected quorum lost once, got %v", counts)
+	}
+	if !healNodes["server1:9000"] || !healNodes["server2:9000"] {
+		t.Fatalf("Expected node local heal events of server1 and server2, got %v", healNodes)
+	}
+
+	// Every server has quorum again.
+	for _, log := range logs {
+		log.emit(clusterEventQuorumRestored, "")
+	}
+	if counts, _ = receiveEvents(1); counts[clusterEventQuorumRestored] != 1 {
+		t.Fatalf("Expected quorum restored once, got %v", counts)
+	}
+
+	// No more events must follow.
+	select {
+	case event := <-eventCh:
+		t.Fatalf("Unexpected event %v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Majority of servers unreachable.
+	unreachablePeers := make(adminPeers, len(peers))
+	copy(unreachablePeers, peers)
+	for i := 0; i < 3; i++ {
+		unreachablePeers[i].cmdRunner = clusterEventsStub{err: errDiskNotFound}
+	}
+	if _, err = subscribePeerEvents(context.Background(), unreachablePeers, time.Second, doneCh); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// bucketTagsStub - adminCmdRunner keeping bucket tags of its own.
+type bucketTagsStub struct {
+	adminCmdRunner
+	tags *bucketTags
+}
+
+func (s bucketTagsStub) SetBucketTags(ctx context.Context, bucket string, tags map[string]string) error {
+	s.tags.set(bucket, tags)
+	return nil
+}
+
+// TestSetPeerBucketTags - test for setPeerBucketTags.
+func TestSetPeerBucketTags(t *testing.T) {
+	peerTags := []*bucketTags{globalBucketTags}
+	peers := adminPeers{{addr: "server0:9000", cmdRunner: localAdminClient{}}}
+	for i := 1; i < 4; i++ {
+		peerTags = append(peerTags, newBucketTags(nil))
+		peers = append(peers, adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: bucketTagsStub{tags: peerTags[i]},
+		})
+	}
+
+	tags := map[string]string{"team": "storage"}
+	if err := setPeerBucketTags(context.Background(), peers, "bucket", tags); err != nil {
+		t.Fatal(err)
+	}
+	for i, pt := range peerTags {
+		if got := pt.get("bucket"); !reflect.DeepEqual(got, tags) {
+			t.Errorf("Peer %d: Expected %v, got %v", i, tags, got)
+		}
+	}
+
+	// Invalid tags are rejected before fan-out.
+	invalidTags := map[string]string{"": "empty key"}
+	if err := setPeerBucketTags(context.Background(), peers, "bucket", invalidTags); err != errInvalidBucketTags {
+		t.Fatalf("Expected %v, got %v", errInvalidBucketTags, err)
+	}
+	for i, pt := range peerTags {
+		if got := pt.get("bucket"); !reflect.DeepEqual(got, tags) {
+			t.Errorf("Peer %d: Expected tags to be unchanged %v, got %v", i, tags, got)
+		}
+	}
+
+	// No tags removes them everywhere.
+	if err := setPeerBucketTags(context.Background(), peers, "bucket", nil); err != nil {
+		t.Fatal(err)
+	}
+	for i, pt := range peerTags {
+		if got := pt.get("bucket"); len(got) != 0 {
+			t.Errorf("Peer %d: Expected no tags, got %v", i, got)
+		}
+	}
+}
+
+// signatureVersionsStub - adminCmdRunner counting calls to set
+// allowed signature versions.
+type signatureVersionsStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s signatureVersionsStub) SetAllowedSignatureVersions(ctx context.Context, versions []string) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerAllowedSignatureVersions - test for
+// setPeerAllowedSignatureVersions.
+func TestSetPeerAllowedSignatureVersions(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: signatureVersionsStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerAllowedSignatureVersions(context.Background(), peers, []string{signatureVersionV4}); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// An empty allow-list is refused before fan-out.
+	calls = 0
+	if err := setPeerAllowedSignatureVersions(context.Background(), peers, nil); err != errInvalidSignatureVersions {
+		t.Fatalf("Expected %v, got %v", errInvalidSignatureVersions, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no peer calls, got %d", calls)
+	}
+}
+
+// recoveringSetStub - adminCmdRunner reporting an erasure set with a
+// single online drive until recoverAfter calls were made, all drives
+// online afterwards. A negative recoverAfter never recovers.
+type recoveringSetStub struct {
+	adminCmdRunner
+	calls        *int32
+	recoverAfter int32
+}
+
+func (s recoveringSetStub) ErasureSetStatus(ctx context.Context) ([]SetStatus, error) {
+	calls := atomic.AddInt32(s.calls, 1)
+	drives := []bool{true, false, false, false}
+	if s.recoverAfter >= 0 && calls > s.recoverAfter {
+		drives = []bool{true, true, true, true}
+	}
+	return []SetStatus{newSetStatus(0, drives, 2, 2, 3)}, nil
+}
+
+// newRecoveringPeers - returns 4 peers sharing a recoveringSetStub.
+func newRecoveringPeers(calls *int32, recoverAfter int32) adminPeers {
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: recoveringSetStub{calls: calls, recoverAfter: recoverAfter},
+		}
+	}
+	return peers
+}
+
+// TestWaitForPeerQuorum - test for waitForPeerQuorum.
+func TestWaitForPeerQuorum(t *testing.T) {
+	// Quorum already there returns after a single check.
+	var calls int32
+	peers := newRecoveringPeers(&calls, 0)
+	if err := waitForPeerQuorum(context.Background(), peers, quorumModeWrite, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected a single check of %d peers, got %d calls", len(peers), calls)
+	}
+
+	// Degraded for three rounds of checks, then recovers.
+	calls = 0
+	peers = newRecoveringPeers(&calls, int32(3*len(peers)))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := waitForPeerQuorum(ctx, peers, quorumModeRead, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != 4*len(peers) {
+		t.Fatalf("Expected four checks of %d peers, got %d calls", len(peers), calls)
+	}
+
+	// Never recovers.
+	calls = 0
+	peers = newRecoveringPeers(&calls, -1)
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := waitForPeerQuorum(ctx, peers, quorumModeWrite, 10*time.Millisecond); err != context.DeadlineExceeded {
+		t.Fatalf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// Unknown modes are rejected without checking.
+	calls = 0
+	if err := waitForPeerQuorum(context.Background(), peers, "majority", time.Hour); err != errInvalidQuorumMode {
+		t.Fatalf("Expected %v, got %v", errInvalidQuorumMode, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no checks, got %d calls", calls)
+	}
+}
+
+// rpcConnStatsStub - adminCmdRunner returning fixed RPC connection
+// stats.
+type rpcConnStatsStub struct {
+	adminCmdRunner
+	conns []RPCConnStats
+	err   error
+}
+
+func (s rpcConnStatsStub) RPCConnStats(ctx context.Context) ([]RPCConnStats, error) {
+	return s.conns, s.err
+}
+
+// TestGetPeerRPCConnStats - test for getPeerRPCConnStats.
+func TestGetPeerRPCConnStats(t *testing.T) {
+	busy := []RPCConnStats{{Addr: "server1:9000", Clients: 2, Connected: 2, Busy: 40, Calls: 1000, AvgConnWait: time.Second}}
+	idle := []RPCConnStats{{Addr: "server0:9000", Clients: 2, Connected: 2, Calls: 10}}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: rpcConnStatsStub{conns: busy}},
+		{addr: "server1:9000", cmdRunner: rpcConnStatsStub{conns: idle}},
+		{addr: "server2:9000", cmdRunner: rpcConnStatsStub{err: errDiskNotFound}},
+	}
+
+	nodes, err := getPeerRPCConnStats(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []NodeRPCConnStats{
+		{Addr: "server0:9000", Conns: busy},
+		{Addr: "server1:9000", Conns: idle},
+	}
+	if !reflect.DeepEqual(nodes, expected) {
+		t.Fatalf("Expected %v, got %v", expected, nodes)
+	}
+
+	peers[1].cmdRunner = rpcConnStatsStub{err: errDiskNotFound}
+	if _, err = getPeerRPCConnStats(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// healJobsStub - adminCmdRunner running heal jobs in its own registry,
+// jobs block until release is closed if set.
+type healJobsStub struct {
+	adminCmdRunner
+	jobs     *healJobs
+	objLayer ObjectLayer
+	release  chan struct{}
+}
+
+func (s healJobsStub) ForceHealBucket(ctx context.Context, id, bucket string, part, parts int) error {
+	s.jobs.start(id, bucket, func(record func(size int64, err error)) error {
+		if s.release != nil {
+			<-s.release
+		}
+		return healBucketObjects(s.objLayer, bucket, part, parts, record)
+	})
+	return nil
+}
+
+func (s healJobsStub) HealJobs(ctx context.Context) ([]HealJobStatus, error) {
+	return s.jobs.list(), nil
+}
+
+func (s healJobsStub) HealHistory(ctx context.Context, n int) ([]HealRecord, error) {
+	return s.jobs.history.last(n), nil
+}
+
+// waitForHealJob - polls heal job id until it is done.
+func waitForHealJob(t *testing.T, peers adminPeers, id string) HealJobStatus {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		status, err := getPeerHealJob(context.Background(), peers, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Done {
+			return status
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Heal job %s not done in time: %v", id, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestForceHealPeerBucket - test for forceHealPeerBucket.
+func TestForceHealPeerBucket(t *testing.T) {
+	root, err := newTestConfig("us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	initNSLock(false)
+
+	objLayer, dirs, err := prepareXL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(dirs)
+
+	bucket := "heal-bucket"
+	if err = objLayer.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+	objects := 20
+	for i := 0; i < objects; i++ {
+		object := fmt.Sprintf("dir%d/object%d", i%3, i)
+		if _, err = objLayer.PutObject(bucket, object, 4, strings.NewReader("data"), nil, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	newPeers := func(release chan struct{}) adminPeers {
+		peers := make(adminPeers, 3)
+		for i := range peers {
+			peers[i] = adminPeer{
+				addr:      fmt.Sprintf("server%d:9000", i),
+				cmdRunner: healJobsStub{jobs: newHealJobs(), objLayer: objLayer, release: release},
+			}
+		}
+		return peers
+	}
+
+	// Every object must be queued once, spread among all peers.
+	peers := newPeers(nil)
+	id, err := forceHealPeerBucket(context.Background(), peers, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := waitForHealJob(t, peers, id)
+	if status.Bucket != bucket || status.Queued != objects || status.Healed != objects || status.Failed != 0 || status.Error != "" {
+		t.Fatalf("Expected %d healed objects of %s, got %v", objects, bucket, status)
+	}
+	for _, peer := range peers {
+		jobs, _ := peer.cmdRunner.HealJobs(context.Background())
+		if len(jobs) != 1 || jobs[0].ID != id {
+			t.Fatalf("Expected job %s on %s, got %v", id, peer.addr, jobs)
+		}
+	}
+	if _, err = getPeerHealJob(context.Background(), peers, "unknown"); err != errNoSuchHealJob {
+		t.Fatalf("Expected %v, got %v", errNoSuchHealJob, err)
+	}
+
+	// Requests while the bucket is being healed must get the ID of
+	// the running job.
+	release := make(chan struct{})
+	peers = newPeers(release)
+	id, err = forceHealPeerBucket(context.Background(), peers, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 4)
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], errs[i] = forceHealPeerBucket(context.Background(), peers, bucket)
+		}(i)
+	}
+	wg.Wait()
+	for i := range ids {
+		if errs[i] != nil || ids[i] != id {
+			t.Fatalf("Expected running job %s, got %s, %v", id, ids[i], errs[i])
+		}
+	}
+	close(release)
+	status = waitForHealJob(t, peers, id)
+	if status.Healed != objects {
+		t.Fatalf("Expected %d healed objects, got %v", objects, status)
+	}
+
+	// Once done healing the bucket again starts a new job.
+	newID, err := forceHealPeerBucket(context.Background(), peers, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newID == id {
+		t.Fatalf("Expected a new job once %s is done", id)
+	}
+	waitForHealJob(t, peers, newID)
+
+	// Both jobs are in the history, newest first, with the objects
+	// healed by all peers.
+	history, err := getPeerHealHistory(context.Background(), peers, maxHealHistory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].ID != newID || history[1].ID != id {
+		t.Fatalf("Expected jobs %s and %s, got %v", newID, id, history)
+	}
+	for _, record := range history {
+		if record.Healed != objects || record.Bytes != int64(4*objects) || record.Outcome != healOutcomeSuccess {
+			t.Fatalf("Expected %d healed objects of %d bytes, got %v", objects, 4*objects, record)
+		}
+	}
+}
+
+// healHistoryStub - adminCmdRunner returning a fixed heal history or
+// an error.
+type healHistoryStub struct {
+	adminCmdRunner
+	records []HealRecord
+	err     error
+}
+
+func (s healHistoryStub) HealHistory(ctx context.Context, n int) ([]HealRecord, error) {
+	if len(s.records) > n {
+		return s.records[:n], s.err
+	}
+	return s.records, s.err
+}
+
+// TestGetPeerHealHistory - test for getPeerHealHistory.
+func TestGetPeerHealHistory(t *testing.T) {
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := func(id string, started, finished int, healed, failed int, bytes int64, errMsg string) HealRecord {
+		return HealRecord{
+			ID:       id,
+			Bucket:   "bucket",
+			Started:  start.Add(time.Duration(started) * time.Minute),
+			Finished: start.Add(time.Duration(finished) * time.Minute),
+			Healed:   healed,
+			Failed:   failed,
+			Bytes:    bytes,
+			Error:    errMsg,
+		}
+	}
+
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: healHistoryStub{records: []HealRecord{
+			record("job3", 20, 22, 5, 0, 50, ""),
+			record("job2", 10, 12, 3, 1, 30, ""),
+			record("job1", 0, 5, 10, 0, 100, ""),
+		}}},
+		{addr: "server1:9000", cmdRunner: healHistoryStub{records: []HealRecord{
+			record("job3", 20, 21, 2, 0, 20, ""),
+			record("job2", 11, 15, 4, 0, 40, ""),
+			record("job1", 1, 3, 0, 0, 0, "disk not found"),
+		}}},
+		{addr: "server2:9000", cmdRunner: healHistoryStub{err: errDiskNotFound}},
+	}
+
+	history, err := getPeerHealHistory(context.Background(), peers, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []HealRecord{
+		record("job3", 20, 22, 7, 0, 70, ""),
+		record("job2", 10, 15, 7, 1, 70, ""),
+	}
+	expected[0].Duration, expected[0].Outcome = 2*time.Minute, healOutcomeSuccess
+	expected[1].Duration, expected[1].Outcome = 5*time.Minute, healOutcomePartial
+	if !reflect.DeepEqual(history, expected) {
+		t.Fatalf("Expected %v, got %v", expected, history)
+	}
+
+	// A job aborted on any peer failed.
+	if history, err = getPeerHealHistory(context.Background(), peers, 3); err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 || history[2].ID != "job1" || history[2].Outcome != healOutcomeFailed ||
+		history[2].Healed != 10 || history[2].Duration != 5*time.Minute {
+		t.Fatalf("Expected failed job1, got %v", history)
+	}
+
+	for _, n := range []int{0, maxHealHistory + 1} {
+		if _, err = getPeerHealHistory(context.Background(), peers, n); err != errInvalidArgument {
+			t.Fatalf("%d records: expected %v, got %v", n, errInvalidArgument, err)
+		}
+	}
+
+	// Read quorum is lost with a single reachable peer.
+	peers[1].cmdRunner = healHistoryStub{err: errDiskNotFound}
+	if _, err = getPeerHealHistory(context.Background(), peers, 2); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// immutabilityWindowStub - adminCmdRunner keeping immutability windows
+// in its own registry.
+type immutabilityWindowStub struct {
+	adminCmdRunner
+	windows *immutabilityWindows
+}
+
+func (s immutabilityWindowStub) SetImmutabilityWindow(ctx context.Context, bucket string, window time.Duration) error {
+	return s.windows.set(bucket, window)
+}
+
+// TestSetPeerImmutabilityWindow - test for setPeerImmutabilityWindow.
+func TestSetPeerImmutabilityWindow(t *testing.T) {
+	peerWindows := []*immutabilityWindows{globalImmutabilityWindows}
+	peers := adminPeers{{addr: "server0:9000", cmdRunner: localAdminClient{}}}
+	for i := 1; i < 4; i++ {
+		peerWindows = append(peerWindows, newImmutabilityWindows(nil))
+		peers = append(peers, adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: immutabilityWindowStub{windows: peerWindows[i]},
+		})
+	}
+	defer globalImmutabilityWindows.set("bucket", 0)
+
+	if err := setPeerImmutabilityWindow(context.Background(), peers, "bucket", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	for i, pw := range peerWindows {
+		if window := pw.get("bucket"); window != time.Hour {
+			t.Errorf("Peer %d: Expected %v, got %v", i, time.Hour, window)
+		}
+	}
+
+	// Negative windows are rejected before fan-out.
+	if err := setPeerImmutabilityWindow(context.Background(), peers, "bucket", -time.Hour); err != errInvalidImmutabilityWindow {
+		t.Fatalf("Expected %v, got %v", errInvalidImmutabilityWindow, err)
+	}
+	for i, pw := range peerWindows {
+		if window := pw.get("bucket"); window != time.Hour {
+			t.Errorf("Peer %d: Expected window to be unchanged %v, got %v", i, time.Hour, window)
+		}
+	}
+
+	// Zero removes the window everywhere.
+	if err := setPeerImmutabilityWindow(context.Background(), peers, "bucket", 0); err != nil {
+		t.Fatal(err)
+	}
+	for i, pw := range peerWindows {
+		if window := pw.get("bucket"); window != 0 {
+			t.Errorf("Peer %d: Expected no window, got %v", i, window)
+		}
+	}
+}
+
+// multipartBytesStub - adminCmdRunner returning a fixed amount of
+// multipart bytes or an error.
+type multipartBytesStub struct {
+	adminCmdRunner
+	bytes int64
+	err   error
+}
+
+func (s multipartBytesStub) ActiveMultipartBytes(ctx context.Context) (int64, error) {
+	return s.bytes, s.err
+}
+
+// TestGetPeerActiveMultipartBytes - test for
+// getPeerActiveMultipartBytes.
+func TestGetPeerActiveMultipartBytes(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: multipartBytesStub{bytes: 1024}},
+		{addr: "server1:9000", cmdRunner: multipartBytesStub{bytes: 0}},
+		{addr: "server2:9000", cmdRunner: multipartBytesStub{bytes: 4096}},
+		{addr: "server3:9000", cmdRunner: multipartBytesStub{err: errDiskNotFound}},
+	}
+
+	usage, err := getPeerActiveMultipartBytes(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ClusterMultipartBytes{
+		Total: 5120,
+		Nodes: []NodeMultipartBytes{
+			{Addr: "server0:9000", Bytes: 1024},
+			{Addr: "server1:9000", Bytes: 0},
+			{Addr: "server2:9000", Bytes: 4096},
+		},
+	}
+	if !reflect.DeepEqual(usage, expected) {
+		t.Fatalf("Expected %v, got %v", expected, usage)
+	}
+
+	// Uploads completed meanwhile drop out of the total.
+	peers[2].cmdRunner = multipartBytesStub{bytes: 0}
+	if usage, err = getPeerActiveMultipartBytes(context.Background(), peers); err != nil {
+		t.Fatal(err)
+	}
+	if usage.Total != 1024 {
+		t.Fatalf("Expected a total of %d, got %d", 1024, usage.Total)
+	}
+
+	peers[0].cmdRunner = multipartBytesStub{err: errDiskNotFound}
+	peers[1].cmdRunner = multipartBytesStub{err: errDiskNotFound}
+	if _, err = getPeerActiveMultipartBytes(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// lockHoldStub - adminCmdRunner returning fixed lock hold histograms
+// or an error.
+type lockHoldStub struct {
+	adminCmdRunner
+	completed []time.Duration
+	held      []time.Duration
+	err       error
+}
+
+func (s lockHoldStub) LockHoldHistogram(ctx context.Context) (LockHoldHistograms, error) {
+	if s.err != nil {
+		return LockHoldHistograms{}, s.err
+	}
+	histograms := newLockHoldHistograms()
+	for _, d := range s.completed {
+		histograms.Completed.add(d)
+	}
+	for _, d := range s.held {
+		histograms.Held.add(d)
+	}
+	return histograms, nil
+}
+
+// TestGetPeerLockHoldHistogram - test for getPeerLockHoldHistogram.
+func TestGetPeerLockHoldHistogram(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: lockHoldStub{completed: []time.Duration{time.Millisecond, time.Second}}},
+		{addr: "server1:9000", cmdRunner: lockHoldStub{completed: []time.Duration{time.Millisecond}, held: []time.Duration{time.Minute}}},
+		{addr: "server2:9000", cmdRunner: lockHoldStub{err: errDiskNotFound}},
+	}
+
+	histograms, err := getPeerLockHoldHistogram(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := newLockHoldHistograms()
+	for _, d := range []time.Duration{time.Millisecond, time.Second, time.Millisecond} {
+		expected.Completed.add(d)
+	}
+	expected.Held.add(time.Minute)
+	if !reflect.DeepEqual(histograms, expected) {
+		t.Fatalf("Expected %v, got %v", expected, histograms)
+	}
+
+	peers[1].cmdRunner = lockHoldStub{err: errDiskNotFound}
+	if _, err = getPeerLockHoldHistogram(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// uploadPartsStub - adminCmdRunner returning fixed upload parts or an
+// error.
+type uploadPartsStub struct {
+	adminCmdRunner
+	parts []PartInfo
+	err   error
+}
+
+func (s uploadPartsStub) ListParts(ctx context.Context, bucket, object, uploadID string) ([]PartInfo, error) {
+	return s.parts, s.err
+}
+
+// TestListPeerUploadParts - test for listPeerUploadParts.
+func TestListPeerUploadParts(t *testing.T) {
+	uploaded := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	part1 := PartInfo{PartNumber: 1, Size: 5, ETag: "etag1", LastModified: uploaded}
+	part2 := PartInfo{PartNumber: 2, Size: 5, ETag: "etag2", LastModified: uploaded}
+	part3 := PartInfo{PartNumber: 3, Size: 1, ETag: "etag3", LastModified: uploaded}
+	// Part 2 uploaded again through another server.
+	part2Again := PartInfo{PartNumber: 2, Size: 6, ETag: "etag2-again", LastModified: uploaded.Add(time.Minute)}
+	notFound := InvalidUploadID{UploadID: "upload"}
+
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: uploadPartsStub{parts: []PartInfo{part3, part1}}},
+		{addr: "server1:9000", cmdRunner: uploadPartsStub{parts: []PartInfo{part2Again}}},
+		{addr: "server2:9000", cmdRunner: uploadPartsStub{parts: []PartInfo{part2, part1}}},
+		{addr: "server3:9000", cmdRunner: uploadPartsStub{err: notFound}},
+	}
+
+	parts, err := listPeerUploadParts(context.Background(), peers, "bucket", "object", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []PartInfo{part1, part2Again, part3}
+	if !reflect.DeepEqual(parts, expected) {
+		t.Fatalf("Expected %v, got %v", expected, parts)
+	}
+
+	// Unknown to every server.
+	for i := range peers {
+		peers[i].cmdRunner = uploadPartsStub{err: notFound}
+	}
+	if _, err = listPeerUploadParts(context.Background(), peers, "bucket", "object", "upload"); err != notFound {
+		t.Fatalf("Expected %v, got %v", notFound, err)
+	}
+	if code := toAPIErrorCode(notFound); code != ErrNoSuchUpload {
+		t.Fatalf("Expected %v, got %v", ErrNoSuchUpload, code)
+	}
+
+	// Too few servers reachable.
+	peers[0].cmdRunner = uploadPartsStub{err: errDiskNotFound}
+	peers[1].cmdRunner = uploadPartsStub{err: errDiskNotFound}
+	peers[2].cmdRunner = uploadPartsStub{err: errDiskNotFound}
+	if _, err = listPeerUploadParts(context.Background(), peers, "bucket", "object", "upload"); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// backendStub - adminCmdRunner returning a fixed backend or an error.
+type backendStub struct {
+	adminCmdRunner
+	info BackendInfo
+	err  error
+}
+
+func (s backendStub) Backend(ctx context.Context) (BackendInfo, error) {
+	return s.info, s.err
+}
+
+// TestGetPeerBackend - test for getPeerBackend.
+func TestGetPeerBackend(t *testing.T) {
+	erasure := BackendInfo{Type: Erasure, Capabilities: backendCapHealing}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: backendStub{info: erasure}},
+		{addr: "server1:9000", cmdRunner: backendStub{err: errDiskNotFound}},
+		{addr: "server2:9000", cmdRunner: backendStub{info: erasure}},
+	}
+
+	backend, err := getPeerBackend(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ClusterBackend{
+		Backend:      "Erasure",
+		Capabilities: []string{"healing"},
+		Consistent:   true,
+		Nodes: []NodeBackend{
+			{Addr: "server0:9000", Backend: "Erasure", Capabilities: []string{"healing"}},
+			{Addr: "server2:9000", Backend: "Erasure", Capabilities: []string{"healing"}},
+		},
+	}
+	if !reflect.DeepEqual(backend, expected) {
+		t.Fatalf("Expected %v, got %v", expected, backend)
+	}
+
+	// A server running FS next to erasure coded servers.
+	peers[1].cmdRunner = backendStub{info: BackendInfo{Type: FS}}
+	if backend, err = getPeerBackend(context.Background(), peers); err != nil {
+		t.Fatal(err)
+	}
+	if backend.Consistent {
+		t.Fatalf("Expected mixed backends to be flagged, got %v", backend)
+	}
+	if backend.Backend != "Erasure" || backend.Nodes[1].Backend != "FS" {
+		t.Fatalf("Expected server1 to be reported as FS, got %v", backend)
+	}
+
+	peers[0].cmdRunner = backendStub{err: errDiskNotFound}
+	peers[1].cmdRunner = backendStub{err: errDiskNotFound}
+	if _, err = getPeerBackend(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// usageScansStub - adminCmdRunner running usage scans in its own
+// registry, scans block until release is closed if set.
+type usageScansStub struct {
+	adminCmdRunner
+	scans    *usageScans
+	objLayer ObjectLayer
+	release  chan struct{}
+}
+
+func (s usageScansStub) RecalculateUsage(ctx context.Context, id, bucket string, part, parts int) error {
+	s.scans.start(id, bucket, func(record func(size int64)) error {
+		if s.release != nil {
+			<-s.release
+		}
+		return scanBucketUsage(s.objLayer, bucket, part, parts, 0, record)
+	})
+	return nil
+}
+
+func (s usageScansStub) ScanProgress(ctx context.Context) ([]UsageScanStatus, error) {
+	return s.scans.list(), nil
+}
+
+// waitForUsageScan - polls usage scan id until it is done.
+func waitForUsageScan(t *testing.T, peers adminPeers, id string) UsageScanStatus {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		status, err := getPeerScanProgress(context.Background(), peers, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Done {
+			return status
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Usage scan %s not done in time: %v", id, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestRecalculatePeerUsage - test for recalculatePeerUsage.
+func TestRecalculatePeerUsage(t *testing.T) {
+	root, err := newTestConfig("us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	initNSLock(false)
+
+	objLayer, dirs, err := prepareXL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(dirs)
+
+	bucket := "usage-bucket"
+	if err = objLayer.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+	objects := 20
+	for i := 0; i < objects; i++ {
+		object := fmt.Sprintf("dir%d/object%d", i%3, i)
+		if _, err = objLayer.PutObject(bucket, object, 4, strings.NewReader("data"), nil, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	newPeers := func(release chan struct{}) adminPeers {
+		peers := make(adminPeers, 3)
+		for i := range peers {
+			peers[i] = adminPeer{
+				addr:      fmt.Sprintf("server%d:9000", i),
+				cmdRunner: usageScansStub{scans: newUsageScans(), objLayer: objLayer, release: release},
+			}
+		}
+		return peers
+	}
+
+	// Every object must be counted once, spread among all peers.
+	peers := newPeers(nil)
+	id, err := recalculatePeerUsage(context.Background(), peers, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := waitForUsageScan(t, peers, id)
+	if status.Bucket != bucket || status.Objects != int64(objects) || status.Bytes != int64(4*objects) || status.Error != "" {
+		t.Fatalf("Expected %d objects of %s, got %v", objects, bucket, status)
+	}
+	for _, peer := range peers {
+		scans, _ := peer.cmdRunner.ScanProgress(context.Background())
+		if len(scans) != 1 || scans[0].ID != id {
+			t.Fatalf("Expected scan %s on %s, got %v", id, peer.addr, scans)
+		}
+	}
+	if _, err = getPeerScanProgress(context.Background(), peers, "unknown"); err != errNoSuchUsageScan {
+		t.Fatalf("Expected %v, got %v", errNoSuchUsageScan, err)
+	}
+
+	// Requests while the bucket is being scanned must get the ID of
+	// the running scan, whose progress can be polled meanwhile.
+	release := make(chan struct{})
+	peers = newPeers(release)
+	id, err = recalculatePeerUsage(context.Background(), peers, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 4)
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], errs[i] = recalculatePeerUsage(context.Background(), peers, bucket)
+		}(i)
+	}
+	wg.Wait()
+	for i := range ids {
+		if errs[i] != nil || ids[i] != id {
+			t.Fatalf("Expected running scan %s, got %s, %v", id, ids[i], errs[i])
+		}
+	}
+	status, err = getPeerScanProgress(context.Background(), peers, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Done || status.Objects != 0 {
+		t.Fatalf("Expected scan %s to be running, got %v", id, status)
+	}
+	close(release)
+	status = waitForUsageScan(t, peers, id)
+	if status.Objects != int64(objects) {
+		t.Fatalf("Expected %d objects, got %v", objects, status)
+	}
+
+	// Once done recalculating the bucket again starts a new scan.
+	newID, err := recalculatePeerUsage(context.Background(), peers, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newID == id {
+		t.Fatalf("Expected a new scan once %s is done", id)
+	}
+	waitForUsageScan(t, peers, newID)
+}
+
+// tlsCiphersStub - adminCmdRunner counting calls to set TLS cipher
+// suites.
+type tlsCiphersStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s tlsCiphersStub) SetTLSCiphers(ctx context.Context, suites []string) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerTLSCiphers - test for setPeerTLSCiphers.
+func TestSetPeerTLSCiphers(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: tlsCiphersStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerTLSCiphers(context.Background(), peers, []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Empty, unknown and weak suites are refused before fan-out.
+	testCases := []struct {
+		suites []string
+		err    error
+	}{
+		{nil, errInvalidTLSCiphers},
+		{[]string{"TLS_UNKNOWN"}, errInvalidTLSCiphers},
+		{[]string{"TLS_RSA_WITH_RC4_128_SHA"}, errWeakTLSCiphers},
+	}
+	for i, testCase := range testCases {
+		calls = 0
+		if err := setPeerTLSCiphers(context.Background(), peers, testCase.suites); err != testCase.err {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+		}
+		if calls != 0 {
+			t.Fatalf("Test %d: expected no peer calls, got %d", i+1, calls)
+		}
+	}
+}
+
+// writeAmpStub - adminCmdRunner returning fixed byte counters or an
+// error.
+type writeAmpStub struct {
+	adminCmdRunner
+	clientBytes, diskBytes int64
+	err                    error
+}
+
+func (s writeAmpStub) WriteAmplification(ctx context.Context) (WriteAmplification, error) {
+	return newWriteAmplification(s.clientBytes, s.diskBytes), s.err
+}
+
+// TestGetPeerWriteAmplification - test for getPeerWriteAmplification.
+func TestGetPeerWriteAmplification(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: writeAmpStub{clientBytes: 1024, diskBytes: 1024}},
+		{addr: "server1:9000", cmdRunner: writeAmpStub{clientBytes: 0, diskBytes: 2048}},
+		{addr: "server2:9000", cmdRunner: writeAmpStub{clientBytes: 1024, diskBytes: 1024}},
+		{addr: "server3:9000", cmdRunner: writeAmpStub{err: errDiskNotFound}},
+	}
+
+	writeAmp, err := getPeerWriteAmplification(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ClusterWriteAmplification{
+		WriteAmplification: WriteAmplification{ClientBytes: 2048, DiskBytes: 4096, Ratio: 2},
+		Nodes: []NodeWriteAmplification{
+			{Addr: "server0:9000", WriteAmplification: WriteAmplification{ClientBytes: 1024, DiskBytes: 1024, Ratio: 1}},
+			{Addr: "server1:9000", WriteAmplification: WriteAmplification{DiskBytes: 2048, NoData: true}},
+			{Addr: "server2:9000", WriteAmplification: WriteAmplification{ClientBytes: 1024, DiskBytes: 1024, Ratio: 1}},
+		},
+	}
+	if !reflect.DeepEqual(writeAmp, expected) {
+		t.Fatalf("Expected %v, got %v", expected, writeAmp)
+	}
+
+	// A cluster which received no object data has no figure.
+	peers[0].cmdRunner = writeAmpStub{}
+	peers[2].cmdRunner = writeAmpStub{}
+	if writeAmp, err = getPeerWriteAmplification(context.Background(), peers); err != nil {
+		t.Fatal(err)
+	}
+	if !writeAmp.NoData || writeAmp.Ratio != 0 {
+		t.Fatalf("Expected no data, got %v", writeAmp.WriteAmplification)
+	}
+
+	peers[0].cmdRunner = writeAmpStub{err: errDiskNotFound}
+	peers[1].cmdRunner = writeAmpStub{err: errDiskNotFound}
+	if _, err = getPeerWriteAmplification(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// maxUploadPartsStub - adminCmdRunner counting calls to set the limit
+// of parts per upload.
+type maxUploadPartsStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s maxUploadPartsStub) SetMaxUploadParts(ctx context.Context, parts int) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerMaxUploadParts - test for setPeerMaxUploadParts.
+func TestSetPeerMaxUploadParts(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: maxUploadPartsStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerMaxUploadParts(context.Background(), peers, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Limits out of range are refused before fan-out.
+	for _, parts := range []int{0, maxPartID + 1} {
+		calls = 0
+		if err := setPeerMaxUploadParts(context.Background(), peers, parts); err != errInvalidArgument {
+			t.Fatalf("%d parts: expected %v, got %v", parts, errInvalidArgument, err)
+		}
+		if calls != 0 {
+			t.Fatalf("%d parts: expected no peer calls, got %d", parts, calls)
+		}
+	}
+}
+
+// bucketChecksumStub - adminCmdRunner returning a fixed bucket
+// checksum or an error.
+type bucketChecksumStub struct {
+	adminCmdRunner
+	checksum BucketChecksum
+	err      error
+}
+
+func (s bucketChecksumStub) BucketChecksum(ctx context.Context, bucket string) (BucketChecksum, error) {
+	return s.checksum, s.err
+}
+
+// TestGetPeerClusterChecksum - test for getPeerClusterChecksum.
+func TestGetPeerClusterChecksum(t *testing.T) {
+	modTime := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	var c bucketChecksum
+	c.add("a", "etag-a", 1, modTime)
+	same := c.result()
+	// A server which accepted a write the others did not see.
+	c.add("b", "etag-b", 1, modTime)
+	extra := c.result()
+
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: bucketChecksumStub{checksum: same}},
+		{addr: "server1:9000", cmdRunner: bucketChecksumStub{checksum: same}},
+		{addr: "server2:9000", cmdRunner: bucketChecksumStub{checksum: same}},
+		{addr: "server3:9000", cmdRunner: bucketChecksumStub{err: errDiskNotFound}},
+	}
+	cluster, err := getPeerClusterChecksum(context.Background(), peers, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cluster.Consistent || len(cluster.Nodes) != 3 || cluster.Bucket != "bucket" {
+		t.Fatalf("Expected 3 consistent servers, got %v", cluster)
+	}
+
+	peers[1].cmdRunner = bucketChecksumStub{checksum: extra}
+	if cluster, err = getPeerClusterChecksum(context.Background(), peers, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if cluster.Consistent {
+		t.Fatalf("Expected servers to diverge, got %v", cluster)
+	}
+	if cluster.Nodes[1].Checksum == cluster.Nodes[0].Checksum || cluster.Nodes[2].Checksum != cluster.Nodes[0].Checksum {
+		t.Fatalf("Expected server1 alone to diverge, got %v", cluster.Nodes)
+	}
+
+	peers[0].cmdRunner = bucketChecksumStub{err: errDiskNotFound}
+	peers[2].cmdRunner = bucketChecksumStub{err: errDiskNotFound}
+	if _, err = getPeerClusterChecksum(context.Background(), peers, "bucket"); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// requestIDConfigStub - adminCmdRunner counting calls to set the
+// request ID configuration.
+type requestIDConfigStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s requestIDConfigStub) SetRequestIDConfig(ctx context.Context, header string, honorClientID bool) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerRequestIDConfig - test for setPeerRequestIDConfig.
+func TestSetPeerRequestIDConfig(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: requestIDConfigStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerRequestIDConfig(context.Background(), peers, "X-Request-Id", true); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Invalid header names are refused before fan-out.
+	for _, header := range []string{"", "X-Request Id", "Content-Length"} {
+		calls = 0
+		if err := setPeerRequestIDConfig(context.Background(), peers, header, true); err != errInvalidRequestIDHeader {
+			t.Fatalf("%q: expected %v, got %v", header, errInvalidRequestIDHeader, err)
+		}
+		if calls != 0 {
+			t.Fatalf("%q: expected no peer calls, got %d", header, calls)
+		}
+	}
+}
+
+// rpcErrorsStub - adminCmdRunner returning fixed admin RPC errors or
+// an error.
+type rpcErrorsStub struct {
+	adminCmdRunner
+	peers []PeerRPCErrors
+	err   error
+}
+
+func (s rpcErrorsStub) RPCErrors(ctx context.Context) ([]PeerRPCErrors, error) {
+	return s.peers, s.err
+}
+
+// TestGetPeerRPCErrorBreakdown - test for getPeerRPCErrorBreakdown.
+func TestGetPeerRPCErrorBreakdown(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: rpcErrorsStub{peers: []PeerRPCErrors{
+			{Addr: "server1:9000", RPCErrorCounts: RPCErrorCounts{Timeout: 2, ApplicationError: 1}},
+			{Addr: "server3:9000", RPCErrorCounts: RPCErrorCounts{AuthFailure: 2}},
+		}}},
+		{addr: "server1:9000", cmdRunner: rpcErrorsStub{peers: []PeerRPCErrors{
+			{Addr: "server2:9000", RPCErrorCounts: RPCErrorCounts{ConnectionRefused: 4}},
+			// Too few to flag on its own, flagged once summed.
+			{Addr: "server3:9000", RPCErrorCounts: RPCErrorCounts{AuthFailure: 2, Timeout: 1}},
+		}}},
+		{addr: "server2:9000", cmdRunner: rpcErrorsStub{peers: []PeerRPCErrors{
+			{Addr: "server1:9000", RPCErrorCounts: RPCErrorCounts{Timeout: 1, AuthFailure: 1}},
+		}}},
+		{addr: "server3:9000", cmdRunner: rpcErrorsStub{err: errDiskNotFound}},
+	}
+
+	breakdown, err := getPeerRPCErrorBreakdown(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []PeerRPCErrors{
+		{Addr: "server1:9000", RPCErrorCounts: RPCErrorCounts{Timeout: 3, AuthFailure: 1, ApplicationError: 1}},
+		{Addr: "server2:9000", RPCErrorCounts: RPCErrorCounts{ConnectionRefused: 4}},
+		{Addr: "server3:9000", RPCErrorCounts: RPCErrorCounts{Timeout: 1, AuthFailure: 4}, CredentialDrift: true},
+	}
+	if !reflect.DeepEqual(breakdown, expected) {
+		t.Fatalf("Expected %v, got %v", expected, breakdown)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	peers[1].cmdRunner = rpcErrorsStub{err: errDiskNotFound}
+	peers[2].cmdRunner = rpcErrorsStub{err: errDiskNotFound}
+	if _, err = getPeerRPCErrorBreakdown(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// bucketObjectLimitStub - adminCmdRunner keeping bucket object limits
+// in its own registry.
+type bucketObjectLimitStub struct {
+	adminCmdRunner
+	limits *bucketObjectLimits
+}
+
+func (s bucketObjectLimitStub) SetBucketObjectLimit(ctx context.Context, bucket string, maxObjects int64) error {
+	return s.limits.set(bucket, maxObjects)
+}
+
+// TestSetPeerBucketObjectLimit - test for setPeerBucketObjectLimit.
+func TestSetPeerBucketObjectLimit(t *testing.T) {
+	defer resetGlobalBucketObjectLimits()
+
+	peerLimits := []*bucketObjectLimits{globalBucketObjectLimits}
+	peers := adminPeers{{addr: "server0:9000", cmdRunner: localAdminClient{}}}
+	for i := 1; i < 4; i++ {
+		peerLimits = append(peerLimits, newBucketObjectLimits(nil))
+		peers = append(peers, adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: bucketObjectLimitStub{limits: peerLimits[i]},
+		})
+	}
+
+	if err := setPeerBucketObjectLimit(context.Background(), peers, "bucket", 100); err != nil {
+		t.Fatal(err)
+	}
+	for i, pl := range peerLimits {
+		if limit := pl.get("bucket"); limit.MaxObjects != 100 {
+			t.Errorf("Peer %d: Expected a limit of 100 objects, got %v", i, limit)
+		}
+	}
+
+	// Negative limits are rejected before fan-out.
+	if err := setPeerBucketObjectLimit(context.Background(), peers, "bucket", -1); err != errInvalidObjectLimit {
+		t.Fatalf("Expected %v, got %v", errInvalidObjectLimit, err)
+	}
+	for i, pl := range peerLimits {
+		if limit := pl.get("bucket"); limit.MaxObjects != 100 {
+			t.Errorf("Peer %d: Expected limit to be unchanged, got %v", i, limit)
+		}
+	}
+
+	// Zero removes the limit everywhere.
+	if err := setPeerBucketObjectLimit(context.Background(), peers, "bucket", 0); err != nil {
+		t.Fatal(err)
+	}
+	for i, pl := range peerLimits {
+		if pl.hasLimit("bucket") {
+			t.Errorf("Peer %d: Expected no limit", i)
+		}
+	}
+}
+
+// scannerImpactStub - adminCmdRunner returning a fixed scanner impact
+// or an error.
+type scannerImpactStub struct {
+	adminCmdRunner
+	impact ImpactEstimate
+	err    error
+}
+
+func (s scannerImpactStub) ScannerImpact(ctx context.Context) (ImpactEstimate, error) {
+	return s.impact, s.err
+}
+
+// TestGetPeerScannerImpact - test for getPeerScannerImpact.
+func TestGetPeerScannerImpact(t *testing.T) {
+	ms := time.Millisecond
+	// No peer has a baseline on its own, all together do.
+	newStub := func(scanning, idle time.Duration) scannerImpactStub {
+		return scannerImpactStub{impact: newImpactEstimate(
+			newTestLatencyHistogram(map[time.Duration]uint64{scanning: 60}),
+			newTestLatencyHistogram(map[time.Duration]uint64{idle: 60}),
+		)}
+	}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: newStub(20*ms, 10*ms)},
+		{addr: "server1:9000", cmdRunner: newStub(20*ms, 10*ms)},
+		{addr: "server2:9000", cmdRunner: newStub(20*ms, 10*ms)},
+		{addr: "server3:9000", cmdRunner: scannerImpactStub{err: errDiskNotFound}},
+	}
+
+	impact, err := getPeerScannerImpact(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(impact.Nodes) != 3 {
+		t.Fatalf("Expected 3 nodes, got %d", len(impact.Nodes))
+	}
+	for _, node := range impact.Nodes {
+		if !node.LowConfidence {
+			t.Errorf("%s: expected low confidence out of 60 samples", node.Addr)
+		}
+	}
+	if impact.LowConfidence {
+		t.Fatal("Expected cluster estimate to be confident")
+	}
+	if impact.ScanningSamples != 180 || impact.IdleSamples != 180 {
+		t.Fatalf("Expected 180 samples each, got %d and %d", impact.ScanningSamples, impact.IdleSamples)
+	}
+	expected := latencyBound(20*ms).Latency - latencyBound(10*ms).Latency
+	if impact.P50Delta != expected {
+		t.Fatalf("Expected p50 delta %v, got %v", expected, impact.P50Delta)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	peers[1].cmdRunner = scannerImpactStub{err: errDiskNotFound}
+	peers[2].cmdRunner = scannerImpactStub{err: errDiskNotFound}
+	if _, err = getPeerScannerImpact(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// trustedProxiesStub - adminCmdRunner keeping trusted proxies of its
+// own.
+type trustedProxiesStub struct {
+	adminCmdRunner
+	proxies *trustedProxies
+}
+
+func (s trustedProxiesStub) SetTrustedProxies(ctx context.Context, cidrs []string) error {
+	return s.proxies.set(cidrs)
+}
+
+// TestSetPeerTrustedProxies - test for setPeerTrustedProxies.
+func TestSetPeerTrustedProxies(t *testing.T) {
+	defer resetGlobalTrustedProxies()
+
+	peerProxies := []*trustedProxies{globalTrustedProxies}
+	peers := adminPeers{{addr: "server0:9000", cmdRunner: localAdminClient{}}}
+	for i := 1; i < 4; i++ {
+		peerProxies = append(peerProxies, &trustedProxies{})
+		peers = append(peers, adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: trustedProxiesStub{proxies: peerProxies[i]},
+		})
+	}
+
+	cidrs := []string{"10.0.0.0/8"}
+	if err := setPeerTrustedProxies(context.Background(), peers, cidrs); err != nil {
+		t.Fatal(err)
+	}
+	for i, proxies := range peerProxies {
+		if got := proxies.get(); !reflect.DeepEqual(got, cidrs) {
+			t.Errorf("Peer %d: expected %v, got %v", i, cidrs, got)
+		}
+	}
+
+	// Invalid proxies are refused before fan-out.
+	if err := setPeerTrustedProxies(context.Background(), peers, []string{"10.0.0.0/8", "not-a-cidr"}); err != errInvalidTrustedProxies {
+		t.Fatalf("Expected %v, got %v", errInvalidTrustedProxies, err)
+	}
+	for i, proxies := range peerProxies {
+		if got := proxies.get(); !reflect.DeepEqual(got, cidrs) {
+			t.Errorf("Peer %d: expected %v to be kept, got %v", i, cidrs, got)
+		}
+	}
+}
+
+// bucketLoggingStub - adminCmdRunner keeping bucket logging
+// configurations of its own.
+type bucketLoggingStub struct {
+	adminCmdRunner
+	logging *bucketLogging
+}
+
+func (s bucketLoggingStub) SetBucketLogging(ctx context.Context, bucket string, targetBucket, prefix string) error {
+	return s.logging.set(bucket, BucketLogging{TargetBucket: targetBucket, TargetPrefix: prefix})
+}
+
+// TestSetPeerBucketLogging - test for setPeerBucketLogging.
+func TestSetPeerBucketLogging(t *testing.T) {
+	defer resetGlobalBucketLogging()
+
+	peerLogging := []*bucketLogging{globalBucketLogging}
+	peers := adminPeers{{addr: "server0:9000", cmdRunner: localAdminClient{}}}
+	for i := 1; i < 4; i++ {
+		peerLogging = append(peerLogging, newBucketLogging(nil))
+		peers = append(peers, adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: bucketLoggingStub{logging: peerLogging[i]},
+		})
+	}
+
+	expected := BucketLogging{TargetBucket: "target", TargetPrefix: "logs/"}
+	if err := setPeerBucketLogging(context.Background(), peers, "source", "target", "logs/"); err != nil {
+		t.Fatal(err)
+	}
+	for i, logging := range peerLogging {
+		if got := logging.get("source"); got != expected {
+			t.Errorf("Peer %d: expected %v, got %v", i, expected, got)
+		}
+	}
+
+	// Logging into itself is refused before fan-out.
+	if err := setPeerBucketLogging(context.Background(), peers, "source", "source", ""); err != errBucketLoggingToSelf {
+		t.Fatalf("Expected %v, got %v", errBucketLoggingToSelf, err)
+	}
+	for i, logging := range peerLogging {
+		if got := logging.get("source"); got != expected {
+			t.Errorf("Peer %d: expected %v to be kept, got %v", i, expected, got)
+		}
+	}
+
+	// An empty target disables logging.
+	if err := setPeerBucketLogging(context.Background(), peers, "source", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	for i, logging := range peerLogging {
+		if logging.isLogged("source") {
+			t.Errorf("Peer %d: expected logging to be disabled", i)
+		}
+	}
+}
+
+// credentialUsageStub - adminCmdRunner returning fixed credential
+// usage or an error.
+type credentialUsageStub struct {
+	adminCmdRunner
+	usages []CredUsage
+	err    error
+}
+
+func (s credentialUsageStub) CredentialUsage(ctx context.Context) ([]CredUsage, error) {
+	return s.usages, s.err
+}
+
+// TestGetPeerUnusedCredentials - test for getPeerUnusedCredentials.
+func TestGetPeerUnusedCredentials(t *testing.T) {
+	now := time.Now().UTC()
+	recent, old := now.Add(-time.Hour), now.Add(-30*24*time.Hour)
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: credentialUsageStub{usages: []CredUsage{
+			{AccessKey: "active", LastUsed: old},
+			{AccessKey: "stale", LastUsed: old},
+			{AccessKey: "never", NeverUsed: true},
+		}}},
+		{addr: "server1:9000", cmdRunner: credentialUsageStub{usages: []CredUsage{
+			// Used recently on this peer only, most recent use wins.
+			{AccessKey: "active", LastUsed: recent},
+			{AccessKey: "stale", NeverUsed: true},
+			{AccessKey: "never", NeverUsed: true},
+		}}},
+		{addr: "server2:9000", cmdRunner: credentialUsageStub{usages: []CredUsage{
+			{AccessKey: "active", NeverUsed: true},
+			{AccessKey: "stale", LastUsed: old.Add(-time.Hour)},
+		}}},
+		{addr: "server3:9000", cmdRunner: credentialUsageStub{err: errDiskNotFound}},
+	}
+
+	unused, err := getPeerUnusedCredentials(context.Background(), peers, 7*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []CredUsage{
+		{AccessKey: "never", NeverUsed: true},
+		{AccessKey: "stale", LastUsed: old},
+	}
+	if !reflect.DeepEqual(unused, expected) {
+		t.Fatalf("Expected %v, got %v", expected, unused)
+	}
+
+	// Keys used at all are left out with a long enough threshold.
+	if unused, err = getPeerUnusedCredentials(context.Background(), peers, 365*24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if len(unused) != 1 || unused[0].AccessKey != "never" {
+		t.Fatalf("Expected only the never used key, got %v", unused)
+	}
+
+	if _, err = getPeerUnusedCredentials(context.Background(), peers, -time.Hour); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	peers[1].cmdRunner = credentialUsageStub{err: errDiskNotFound}
+	peers[2].cmdRunner = credentialUsageStub{err: errDiskNotFound}
+	if _, err = getPeerUnusedCredentials(context.Background(), peers, time.Hour); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// maxConcurrentHealsStub - adminCmdRunner counting calls to set the
+// cap of object heals running at once.
+type maxConcurrentHealsStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s maxConcurrentHealsStub) SetMaxConcurrentHeals(ctx context.Context, max int) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerMaxConcurrentHeals - test for setPeerMaxConcurrentHeals.
+func TestSetPeerMaxConcurrentHeals(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: maxConcurrentHealsStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerMaxConcurrentHeals(context.Background(), peers, 4); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Negative caps are refused before fan-out.
+	calls = 0
+	if err := setPeerMaxConcurrentHeals(context.Background(), peers, -1); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no peer calls, got %d", calls)
+	}
+}
+
+// shutdownTimeoutStub - adminCmdRunner counting calls to set the
+// shutdown timeout.
+type shutdownTimeoutStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s shutdownTimeoutStub) SetShutdownTimeout(ctx context.Context, timeout time.Duration) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerShutdownTimeout - test for setPeerShutdownTimeout.
+func TestSetPeerShutdownTimeout(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: shutdownTimeoutStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerShutdownTimeout(context.Background(), peers, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Negative timeouts are refused before fan-out.
+	calls = 0
+	if err := setPeerShutdownTimeout(context.Background(), peers, -time.Second); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no peer calls, got %d", calls)
+	}
+}
+
+// checksumPolicyStub - adminCmdRunner counting calls to set the object
+// checksum policy.
+type checksumPolicyStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s checksumPolicyStub) SetChecksumPolicy(ctx context.Context, required bool, algorithms []string) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerChecksumPolicy - test for setPeerChecksumPolicy.
+func TestSetPeerChecksumPolicy(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: checksumPolicyStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerChecksumPolicy(context.Background(), peers, true, []string{"SHA256"}); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Unsupported algorithms are refused before fan-out.
+	calls = 0
+	if err := setPeerChecksumPolicy(context.Background(), peers, true, []string{"MD5"}); err != errInvalidChecksumPolicy {
+		t.Fatalf("Expected %v, got %v", errInvalidChecksumPolicy, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no peer calls, got %d", calls)
+	}
+}
+
+// summaryStub - adminCmdRunner returning a fixed server summary or an
+// error.
+type summaryStub struct {
+	adminCmdRunner
+	summary ServerSummary
+	err     error
+}
+
+func (s summaryStub) Summary(ctx context.Context) (ServerSummary, error) {
+	return s.summary, s.err
+}
+
+// TestGetPeerClusterSummary - test for getPeerClusterSummary.
+func TestGetPeerClusterSummary(t *testing.T) {
+	now := time.Now().UTC()
+	storage := StorageInfo{Total: 1000, Free: 400}
+	storage.Backend.OnlineDisks = 7
+	storage.Backend.OfflineDisks = 1
+	// Both parts of the latest scan of bucket1 counted 10 objects, an
+	// older scan and a running one are not counted.
+	scans := func(objects int64) []UsageScanStatus {
+		return []UsageScanStatus{
+			{ID: "old", Bucket: "bucket1", Started: now.Add(-2 * time.Hour), Objects: 100, Done: true},
+			{ID: "latest", Bucket: "bucket1", Started: now.Add(-time.Hour), Objects: objects, Done: true},
+			{ID: "running", Bucket: "bucket2", Started: now, Objects: 50},
+		}
+	}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: summaryStub{summary: ServerSummary{
+			Version: "v1", CommitID: "c1", Storage: storage,
+			HealJobs:   []HealJobStatus{{ID: "job1"}},
+			OldestLock: now.Add(-time.Minute),
+			UsageScans: scans(4),
+		}}},
+		{addr: "server1:9000", cmdRunner: summaryStub{summary: ServerSummary{
+			Version: "v1", CommitID: "c1", Storage: storage,
+			// Part of the same heal job plus one of its own.
+			HealJobs:   []HealJobStatus{{ID: "job1"}, {ID: "job2"}},
+			OldestLock: now.Add(-time.Hour),
+			UsageScans: scans(6),
+		}}},
+		{addr: "server2:9000", cmdRunner: summaryStub{summary: ServerSummary{
+			Version: "v1", CommitID: "c1", Storage: storage,
+		}}},
+		{addr: "server3:9000", cmdRunner: summaryStub{err: errDiskNotFound}},
+	}
+
+	summary, err := getPeerClusterSummary(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Nodes != 4 || summary.OnlineNodes != 3 || !reflect.DeepEqual(summary.OfflineNodes, []string{"server3:9000"}) {
+		t.Fatalf("Expected 3 of 4 nodes online, server3 offline, got %v", summary)
+	}
+	if summary.Total != 1000 || summary.Used != 600 || summary.OnlineDisks != 7 || summary.OfflineDisks != 1 {
+		t.Fatalf("Expected capacity of a single server, got %v", summary)
+	}
+	if summary.Objects != 10 {
+		t.Fatalf("Expected 10 objects, got %d", summary.Objects)
+	}
+	if summary.ActiveHeals != 2 {
+		t.Fatalf("Expected 2 active heals, got %d", summary.ActiveHeals)
+	}
+	if summary.OldestLockAge < time.Hour {
+		t.Fatalf("Expected oldest lock age of at least an hour, got %v", summary.OldestLockAge)
+	}
+	if !summary.VersionConsistent || !reflect.DeepEqual(summary.Versions, []string{"v1 (c1)"}) {
+		t.Fatalf("Expected consistent versions, got %v", summary.Versions)
+	}
+	partial := []string{summaryObjects, summaryActiveHeals, summaryOldestLockAge, summaryVersionConsistent}
+	if !reflect.DeepEqual(summary.Partial, partial) {
+		t.Fatalf("Expected partial fields %v, got %v", partial, summary.Partial)
+	}
+
+	// Nothing is partial with all servers reached, a different
+	// version is noticed.
+	peers[3].cmdRunner = summaryStub{summary: ServerSummary{Version: "v2", CommitID: "c2", Storage: storage}}
+	if summary, err = getPeerClusterSummary(context.Background(), peers); err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Partial) != 0 || summary.VersionConsistent || len(summary.Versions) != 2 {
+		t.Fatalf("Expected complete summary with inconsistent versions, got %v", summary)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	for i := 1; i < len(peers); i++ {
+		peers[i].cmdRunner = summaryStub{err: errDiskNotFound}
+	}
+	if _, err = getPeerClusterSummary(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// diskFillStub - adminCmdRunner returning fixed disk usage or an
+// error.
+type diskFillStub struct {
+	adminCmdRunner
+	fills []DiskFill
+	err   error
+}
+
+func (s diskFillStub) DiskFill(ctx context.Context) ([]DiskFill, error) {
+	return s.fills, s.err
+}
+
+// TestGetPeerRebalanceSkew - test for getPeerRebalanceSkew.
+func TestGetPeerRebalanceSkew(t *testing.T) {
+	// The fullest disk of the set is on the second server.
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: diskFillStub{fills: diskFills(0, 300, 300)}},
+		{addr: "server1:9000", cmdRunner: diskFillStub{fills: diskFills(0, 300, 700)}},
+		{addr: "server2:9000", cmdRunner: diskFillStub{err: errDiskNotFound}},
+	}
+	reports, err := getPeerRebalanceSkew(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].Disks != 4 || reports[0].MaxFill != 70 || !reports[0].RebalanceRecommended {
+		t.Fatalf("Expected skew of 4 disks to be reported, got %v", reports)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	peers[1].cmdRunner = diskFillStub{err: errDiskNotFound}
+	if _, err = getPeerRebalanceSkew(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// notificationRetryStub - adminCmdRunner counting calls to set the
+// notification retry policy.
+type notificationRetryStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s notificationRetryStub) SetNotificationRetry(ctx context.Context, maxRetries int, backoff time.Duration, deadLetterTarget string) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerNotificationRetry - test for setPeerNotificationRetry.
+func TestSetPeerNotificationRetry(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: notificationRetryStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerNotificationRetry(context.Background(), peers, 3, time.Second, ""); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Invalid policies are refused before fan-out.
+	calls = 0
+	if err := setPeerNotificationRetry(context.Background(), peers, -1, time.Second, ""); err != errInvalidNotificationRetry {
+		t.Fatalf("Expected %v, got %v", errInvalidNotificationRetry, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no peer calls, got %d", calls)
+	}
+}
+
+// dumpLocksStub - adminCmdRunner returning fixed lock information or
+// an error.
+type dumpLocksStub struct {
+	adminCmdRunner
+	volLocks []VolumeLockInfo
+	err      error
+}
+
+func (s dumpLocksStub) DumpLocks(ctx context.Context) ([]VolumeLockInfo, error) {
+	return s.volLocks, s.err
+}
+
+// TestGetPeerLockOriginBreakdown - test for getPeerLockOriginBreakdown.
+func TestGetPeerLockOriginBreakdown(t *testing.T) {
+	volLocks := func(sources ...string) []VolumeLockInfo {
+		volLock := VolumeLockInfo{Bucket: "bucket", Object: "object"}
+		for _, source := range sources {
+			volLock.LockDetailsOnObject = append(volLock.LockDetailsOnObject,
+				OpsLockState{LockSource: source, LockType: debugWLockStr, Status: runningStatus})
+		}
+		return []VolumeLockInfo{volLock}
+	}
+	putObject := "[object-handlers.go:480:objectAPIHandlers.PutObjectHandler()]"
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: dumpLocksStub{volLocks: volLocks(putObject, putObject, "")}},
+		{addr: "server1:9000", cmdRunner: dumpLocksStub{volLocks: volLocks(putObject)}},
+		{addr: "server2:9000", cmdRunner: dumpLocksStub{err: errDiskNotFound}},
+	}
+
+	origins, err := getPeerLockOriginBreakdown(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []LockOrigin{
+		{Node: "server0:9000", Operation: "PutObject", Type: "WLock", Count: 2},
+		{Node: "server0:9000", Operation: unknownLockOrigin, Type: "WLock", Count: 1},
+		{Node: "server1:9000", Operation: "PutObject", Type: "WLock", Count: 1},
+	}
+	if !reflect.DeepEqual(origins, expected) {
+		t.Fatalf("Expected %v, got %v", expected, origins)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	peers[1].cmdRunner = dumpLocksStub{err: errDiskNotFound}
+	if _, err = getPeerLockOriginBreakdown(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// maxListDepthStub - adminCmdRunner counting calls to set the maximum
+// list depth.
+type maxListDepthStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s maxListDepthStub) SetMaxListDepth(ctx context.Context, depth int) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerMaxListDepth - test for setPeerMaxListDepth.
+func TestSetPeerMaxListDepth(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: maxListDepthStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerMaxListDepth(context.Background(), peers, 3); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Negative depths are refused before fan-out.
+	calls = 0
+	if err := setPeerMaxListDepth(context.Background(), peers, -1); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no peer calls, got %d", calls)
+	}
+}
+
+// startupErrorsStub - adminCmdRunner returning fixed startup errors or
+// an error.
+type startupErrorsStub struct {
+	adminCmdRunner
+	startupErrors []StartupError
+	err           error
+}
+
+func (s startupErrorsStub) StartupErrors(ctx context.Context) ([]StartupError, error) {
+	return s.startupErrors, s.err
+}
+
+// TestGetPeerStartupErrors - test for getPeerStartupErrors.
+func TestGetPeerStartupErrors(t *testing.T) {
+	offline := StartupError{Phase: bootPhaseFirstQuorum, Source: "/mnt/disk2", Error: errDiskNotFound.Error()}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: startupErrorsStub{startupErrors: []StartupError{}}},
+		{addr: "server1:9000", cmdRunner: startupErrorsStub{startupErrors: []StartupError{offline}}},
+		{addr: "server2:9000", cmdRunner: startupErrorsStub{err: errDiskNotFound}},
+	}
+
+	startupErrors, err := getPeerStartupErrors(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline.Addr = "server1:9000"
+	if !reflect.DeepEqual(startupErrors, []StartupError{offline}) {
+		t.Fatalf("Expected %v, got %v", []StartupError{offline}, startupErrors)
+	}
+
+	// Clean startups give an empty result, not an error.
+	peers[1].cmdRunner = startupErrorsStub{}
+	startupErrors, err = getPeerStartupErrors(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if startupErrors == nil || len(startupErrors) != 0 {
+		t.Fatalf("Expected no startup errors, got %#v", startupErrors)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	peers[1].cmdRunner = startupErrorsStub{err: errDiskNotFound}
+	if _, err = getPeerStartupErrors(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// memSummaryStub - admin command runner returning a fixed memory
+// summary or error.
+type memSummaryStub struct {
+	adminCmdRunner
+	stats MemStats
+	err   error
+}
+
+func (s memSummaryStub) MemSummary(ctx context.Context) (MemStats, error) {
+	return s.stats, s.err
+}
+
+// TestGetPeerMemSummary - test for getPeerMemSummary.
+func TestGetPeerMemSummary(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: memSummaryStub{stats: MemStats{HeapAlloc: 1 << 20}}},
+		{addr: "server1:9000", cmdRunner: memSummaryStub{stats: MemStats{HeapAlloc: 2 << 20}}},
+		{addr: "server2:9000", cmdRunner: memSummaryStub{err: errDiskNotFound}},
+	}
+
+	memStats, err := getPeerMemSummary(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []MemStats{
+		{Addr: "server0:9000", HeapAlloc: 1 << 20},
+		{Addr: "server1:9000", HeapAlloc: 2 << 20},
+	}
+	if !reflect.DeepEqual(memStats, expected) {
+		t.Fatalf("Expected %v, got %v", expected, memStats)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	peers[1].cmdRunner = memSummaryStub{err: errDiskNotFound}
+	if _, err = getPeerMemSummary(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+// bucketPartSizeLimitsStub - adminCmdRunner counting calls to set the
+// part size limits of a bucket.
+type bucketPartSizeLimitsStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s bucketPartSizeLimitsStub) SetBucketPartSizeLimits(ctx context.Context, bucket string, minPart, maxPart int64) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerBucketPartSizeLimits - test for setPeerBucketPartSizeLimits.
+func TestSetPeerBucketPartSizeLimits(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: bucketPartSizeLimitsStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerBucketPartSizeLimits(context.Background(), peers, "bucket", 2*minPartSize, maxObjectSize/2); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Removing the limits is sent to all peers too.
+	calls = 0
+	if err := setPeerBucketPartSizeLimits(context.Background(), peers, "bucket", 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Limits out of the S3 bounds are refused before fan-out.
+	calls = 0
+	if err := setPeerBucketPartSizeLimits(context.Background(), peers, "bucket", minPartSize-1, maxObjectSize/2); err != errInvalidPartSizeLimits {
+		t.Fatalf("Expected %v, got %v", errInvalidPartSizeLimits, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no peer calls, got %d", calls)
+	}
+}
+
+// deleteProtectionStub - adminCmdRunner keeping delete protection tags
+// in memory.
+type deleteProtectionStub struct {
+	adminCmdRunner
+	tags *deleteProtectionTags
+}
+
+func (s deleteProtectionStub) SetDeleteProtectionTag(ctx context.Context, bucket, tagKey, tagValue string) error {
+	return s.tags.set(bucket, DeleteProtectionTag{Key: tagKey, Value: tagValue})
+}
+
+// TestSetPeerDeleteProtectionTag - test for setPeerDeleteProtectionTag.
+func TestSetPeerDeleteProtectionTag(t *testing.T) {
+	peerTags := make([]*deleteProtectionTags, 4)
+	peers := make(adminPeers, len(peerTags))
+	for i := range peers {
+		peerTags[i] = newDeleteProtectionTags(nil)
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: deleteProtectionStub{tags: peerTags[i]},
+		}
+	}
+
+	if err := setPeerDeleteProtectionTag(context.Background(), peers, "bucket", "retain", "yes"); err != nil {
+		t.Fatal(err)
+	}
+	for i, tags := range peerTags {
+		if tag := tags.get("bucket"); tag != (DeleteProtectionTag{Key: "retain", Value: "yes"}) {
+			t.Fatalf("Peer %d: unexpected tag %v", i, tag)
+		}
+	}
+
+	// Invalid tags are refused before fan-out.
+	if err := setPeerDeleteProtectionTag(context.Background(), peers, "bucket", "", "no"); err != errInvalidDeleteProtection {
+		t.Fatalf("Expected %v, got %v", errInvalidDeleteProtection, err)
+	}
+	for i, tags := range peerTags {
+		if tag := tags.get("bucket"); tag.Value != "yes" {
+			t.Fatalf("Peer %d: expected tag to be kept, got %v", i, tag)
+		}
+	}
+
+	// No key removes the tag everywhere.
+	if err := setPeerDeleteProtectionTag(context.Background(), peers, "bucket", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	for i, tags := range peerTags {
+		if tag := tags.get("bucket"); tag.Key != "" {
+			t.Fatalf("Peer %d: expected no tag, got %v", i, tag)
+		}
+	}
+}
+
+// configChangeLogStub - adminCmdRunner keeping a config change log,
+// logging the changes of the max list depth it applied.
+type configChangeLogStub struct {
+	adminCmdRunner
+	log *configChangeLog
+	err error
+}
+
+func (s configChangeLogStub) SetMaxListDepth(ctx context.Context, depth int) error {
+	return s.log.logApplied(configChangeFromContext(ctx), s.err)
+}
+
+func (s configChangeLogStub) ConfigChangeLog(ctx context.Context, n int) ([]ConfigChange, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.log.last(n), nil
+}
+
+// TestGetPeerConfigChangeLog - tests config changes are logged by the
+// peers applying them and reconciled by getPeerConfigChangeLog.
+func TestGetPeerConfigChangeLog(t *testing.T) {
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: configChangeLogStub{log: &configChangeLog{}},
+		}
+	}
+	setMaxListDepth := func(change ConfigChange) error {
+		ctx := context.WithValue(context.Background(), configChangeKey{}, &change)
+		return setPeerMaxListDepth(ctx, peers, 3)
+	}
+
+	now := time.Now().UTC()
+	if err := setMaxListDepth(ConfigChange{ID: "applied", Time: now.Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	// Setting the max list depth fails on server3:9000 for the next
+	// change, which still succeeds with write quorum.
+	online := peers[3].cmdRunner.(configChangeLogStub)
+	peers[3].cmdRunner = configChangeLogStub{log: online.log, err: errDiskNotFound}
+	if err := setMaxListDepth(ConfigChange{ID: "partial", Time: now}); err != nil {
+		t.Fatal(err)
+	}
+	peers[3].cmdRunner = online
+
+	changes, err := getPeerConfigChangeLog(context.Background(), peers, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].ID != "partial" || changes[1].ID != "applied" {
+		t.Fatalf("Expected both changes latest first, got %v", changes)
+	}
+	if !changes[0].Inconsistent || !reflect.DeepEqual(changes[0].MissingNodes, []string{"server3:9000"}) {
+		t.Fatalf("Expected the partial change to be flagged, got %#v", changes[0])
+	}
+	if changes[1].Inconsistent || len(changes[1].Nodes) != len(peers) {
+		t.Fatalf("Expected the applied change to be consistent, got %#v", changes[1])
+	}
+
+	if _, err = getPeerConfigChangeLog(context.Background(), peers, 0); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	for i := 1; i < len(peers); i++ {
+		peers[i].cmdRunner = configChangeLogStub{err: errDiskNotFound}
+	}
+	if _, err = getPeerConfigChangeLog(context.Background(), peers, 10); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+type versionSkewStub struct {
+	adminCmdRunner
+	version string
+	fills   []DiskFill
+	err     error
+}
+
+func (s versionSkewStub) Summary(ctx context.Context) (ServerSummary, error) {
+	summary := ServerSummary{Version: s.version}
+	summary.Storage.Backend.Type = Erasure
+	summary.Storage.Backend.WriteQuorum = 3
+	return summary, s.err
+}
+
+func (s versionSkewStub) DiskFill(ctx context.Context) ([]DiskFill, error) {
+	return s.fills, s.err
+}
+
+// TestGetPeerVersionSkewReport - test for getPeerVersionSkewReport.
+func TestGetPeerVersionSkewReport(t *testing.T) {
+	// A set of 4 drives spread over 4 servers, it can lose a drive.
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr: fmt.Sprintf("server%d:9000", i),
+			cmdRunner: versionSkewStub{
+				version: "2017-06-13T19-01-01Z",
+				fills:   []DiskFill{{Set: 0, Endpoint: fmt.Sprintf("http://server%d:9000/disk", i)}},
+			},
+		}
+	}
+	peers[0].cmdRunner = versionSkewStub{version: "2017-07-24T18-27-35Z", fills: []DiskFill{{Set: 0}}}
+	peers[3].cmdRunner = versionSkewStub{err: errDiskNotFound}
+
+	report, err := getPeerVersionSkewReport(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Status != versionSkewUpgrading || report.TargetVersion != "2017-07-24T18-27-35Z" {
+		t.Fatalf("Expected an upgrade in progress, got %#v", report)
+	}
+	// server3:9000 being offline already, no other server can be
+	// taken offline without losing write quorum.
+	if !reflect.DeepEqual(report.Unreachable, []string{"server3:9000"}) ||
+		!reflect.DeepEqual(report.Blocked, []string{"server1:9000", "server2:9000"}) {
+		t.Fatalf("Expected server1 and server2 blocked by the unreachable server3, got %#v", report)
+	}
+
+	// Read quorum is lost with most peers unreachable.
+	peers[1].cmdRunner = versionSkewStub{err: errDiskNotFound}
+	peers[2].cmdRunner = versionSkewStub{err: errDiskNotFound}
+	if _, err = getPeerVersionSkewReport(context.Background(), peers); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+type capacityForecastStub struct {
+	adminCmdRunner
+	scans []UsageScanStatus
+	err   error
+}
+
+func (s capacityForecastStub) Summary(ctx context.Context) (ServerSummary, error) {
+	summary := ServerSummary{UsageScans: s.scans}
+	summary.Storage.Backend.Type = FS
+	summary.Storage.Free = 100
+	return summary, s.err
+}
+
+// TestGetPeerCapacityForecast - test for getPeerCapacityForecast.
+func TestGetPeerCapacityForecast(t *testing.T) {
+	now := time.Now().UTC()
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: capacityForecastStub{scans: []UsageScanStatus{
+			{ID: "1", Bucket: "bucket", Started: now.Add(-2 * oneDay), Bytes: 0, Done: true},
+			{ID: "2", Bucket: "bucket", Started: now, Bytes: 20, Done: true},
+		}}},
+		{addr: "server1:9000", cmdRunner: capacityForecastStub{}},
+		{addr: "server2:9000", cmdRunner: capacityForecastStub{err: errDiskNotFound}},
+	}
+
+	forecast, err := getPeerCapacityForecast(context.Background(), peers, 7*oneDay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forecast.Status != capacityForecastOK || forecast.GrowthPerDay != 10 || forecast.DaysUntilFull != 10 {
+		t.Fatalf("Expected the cluster full in 10 days, got %#v", forecast)
+	}
+	if len(forecast.Nodes) != 2 || !reflect.DeepEqual(forecast.Unreachable, []string{"server2:9000"}) {
+		t.Fatalf("Expected 2 server forecasts and server2 unreachable, got %#v", forecast)
+	}
+
+	if _, err = getPeerCapacityForecast(context.Background(), peers, 0); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+}
+
+// credentialExpiryStub - adminCmdRunner counting calls to set the
+// expiry of an access key.
+type credentialExpiryStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s credentialExpiryStub) SetCredentialExpiry(ctx context.Context, accessKey string, expiry time.Time) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerCredentialExpiry - test for setPeerCredentialExpiry.
+func TestSetPeerCredentialExpiry(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: credentialExpiryStub{calls: &calls},
+		}
+	}
+
+	accessKey := serverConfig.GetCredential().AccessKey
+	if err = setPeerCredentialExpiry(context.Background(), peers, accessKey, time.Now().UTC()); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Unknown access keys are refused before fan-out.
+	calls = 0
+	if err = setPeerCredentialExpiry(context.Background(), peers, "unknown", time.Now().UTC()); err != errInvalidAccessKeyID {
+		t.Fatalf("Expected %v, got %v", errInvalidAccessKeyID, err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no peer calls, got %d", calls)
+	}
+}
+
+// objectDistributionStub - adminCmdRunner returning a fixed view of the
+// objects of a bucket on the erasure sets of a server.
+type objectDistributionStub struct {
+	adminCmdRunner
+	sets []SetObjects
+	err  error
+}
+
+func (s objectDistributionStub) ObjectDistribution(ctx context.Context, bucket string) ([]SetObjects, error) {
+	return s.sets, s.err
+}
+
+// TestGetPeerObjectDistribution - test for getPeerObjectDistribution.
+func TestGetPeerObjectDistribution(t *testing.T) {
+	// Both servers hold disks of both sets, objects are skewed to
+	// set 0 and one server lags behind on set 1.
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: objectDistributionStub{sets: []SetObjects{
+			{Set: 0, Objects: 90, Bytes: 900},
+			{Set: 1, Objects: 8, Bytes: 80},
+		}}},
+		{addr: "server1:9000", cmdRunner: objectDistributionStub{sets: []SetObjects{
+			{Set: 0, Objects: 90, Bytes: 900},
+			{Set: 1, Objects: 10, Bytes: 100},
+		}}},
+		{addr: "server2:9000", cmdRunner: objectDistributionStub{err: errDiskNotFound}},
+	}
+
+	distribution, err := getPeerObjectDistribution(context.Background(), peers, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Views of a set are merged, not added up.
+	if distribution.Objects != 100 || distribution.Bytes != 1000 {
+		t.Fatalf("Expected 100 objects of 1000 bytes, got %#v", distribution)
+	}
+	if len(distribution.Sets) != 2 || distribution.Sets[0].Percent != 90 || distribution.Sets[1].Percent != 10 {
+		t.Fatalf("Expected 90%% of the objects on set 0, got %#v", distribution.Sets)
+	}
+	if !distribution.Imbalanced {
+		t.Fatal("Expected the distribution to be imbalanced")
+	}
+}
+
+// multiDeleteLimitStub - adminCmdRunner counting calls to set the
+// multi-delete limit.
+type multiDeleteLimitStub struct {
+	adminCmdRunner
+	calls *int32
+}
+
+func (s multiDeleteLimitStub) SetMultiDeleteLimit(ctx context.Context, maxKeys int) error {
+	atomic.AddInt32(s.calls, 1)
+	return nil
+}
+
+// TestSetPeerMultiDeleteLimit - test for setPeerMultiDeleteLimit.
+func TestSetPeerMultiDeleteLimit(t *testing.T) {
+	var calls int32
+	peers := make(adminPeers, 4)
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: multiDeleteLimitStub{calls: &calls},
+		}
+	}
+
+	if err := setPeerMultiDeleteLimit(context.Background(), peers, 100); err != nil {
+		t.Fatal(err)
+	}
+	if int(calls) != len(peers) {
+		t.Fatalf("Expected %d peer calls, got %d", len(peers), calls)
+	}
+
+	// Limits outside of what S3 allows are refused before fan-out.
+	for _, maxKeys := range []int{0, maxDeleteObjects + 1} {
+		calls = 0
+		if err := setPeerMultiDeleteLimit(context.Background(), peers, maxKeys); err != errInvalidArgument {
+			t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+		}
+		if calls != 0 {
+			t.Fatalf("Expected no peer calls, got %d", calls)
+		}
+	}
+}
+
+// rejectReasonsStub - adminCmdRunner returning fixed rejected requests.
+type rejectReasonsStub struct {
+	adminCmdRunner
+	reasons map[string]int
+	err     error
+}
+
+func (s rejectReasonsStub) RejectReasons(ctx context.Context, window time.Duration) (map[string]int, error) {
+	return s.reasons, s.err
+}
+
+// TestGetPeerRejectReasons - test for getPeerRejectReasons.
+func TestGetPeerRejectReasons(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: rejectReasonsStub{reasons: map[string]int{"auth-failed": 3, "not-found": 1}}},
+		{addr: "server1:9000", cmdRunner: rejectReasonsStub{reasons: map[string]int{"quorum-unavailable": 2, "not-found": 4}}},
+		{addr: "server2:9000", cmdRunner: rejectReasonsStub{reasons: map[string]int{}}},
+		{addr: "server3:9000", cmdRunner: rejectReasonsStub{err: errDiskNotFound}},
+	}
+
+	reasons, err := getPeerRejectReasons(context.Background(), peers, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]int{"auth-failed": 3, "not-found": 5, "quorum-unavailable": 2}
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Fatalf("Expected %v, got %v", expected, reasons)
+	}
+
+	if _, err = getPeerRejectReasons(context.Background(), peers, 2*rejectReasonMaxWindow); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+}
+
+// slowRequestLogStub - adminCmdRunner returning fixed slow requests.
+type slowRequestLogStub struct {
+	adminCmdRunner
+	requests []SlowRequest
+	err      error
+}
+
+func (s slowRequestLogStub) SlowRequestLog(ctx context.Context, thresholdMs int, n int) ([]SlowRequest, error) {
+	return s.requests, s.err
+}
+
+// TestGetPeerSlowRequestLog - test for getPeerSlowRequestLog.
+func TestGetPeerSlowRequestLog(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: slowRequestLogStub{requests: []SlowRequest{
+			{Time: start.Add(3 * time.Second), Path: "/b/3"},
+			{Time: start.Add(time.Second), Path: "/b/1"},
+		}}},
+		{addr: "server1:9000", cmdRunner: slowRequestLogStub{requests: []SlowRequest{
+			{Time: start.Add(2 * time.Second), Path: "/b/2"},
+			{Time: start, Path: "/b/0"},
+		}}},
+		{addr: "server2:9000", cmdRunner: slowRequestLogStub{err: errDiskNotFound}},
+	}
+
+	requests, err := getPeerSlowRequestLog(context.Background(), peers, 100, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []SlowRequest{
+		{Node: "server0:9000", Time: start.Add(3 * time.Second), Path: "/b/3"},
+		{Node: "server1:9000", Time: start.Add(2 * time.Second), Path: "/b/2"},
+		{Node: "server0:9000", Time: start.Add(time.Second), Path: "/b/1"},
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Fatalf("Expected %v, got %v", expected, requests)
+	}
+
+	if _, err = getPeerSlowRequestLog(context.Background(), peers, 1, 3); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+}
+
+// diskIOStub - adminCmdRunner returning fixed disk IO stats.
+type diskIOStub struct {
+	adminCmdRunner
+	disks []DiskIO
+	err   error
+}
+
+func (s diskIOStub) DiskIOStats(ctx context.Context) ([]DiskIO, error) {
+	return s.disks, s.err
+}
+
+// TestGetPeerDiskIOStats - test for getPeerDiskIOStats.
+func TestGetPeerDiskIOStats(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server1:9000", cmdRunner: diskIOStub{disks: []DiskIO{
+			{Endpoint: "/disk2", Status: diskIOUnavailable},
+			{Endpoint: "/disk1", Status: diskIOAvailable, QueueDepth: 12, HighQueueDepth: true},
+		}}},
+		{addr: "server0:9000", cmdRunner: diskIOStub{disks: []DiskIO{
+			{Endpoint: "/disk1", Status: diskIOAvailable, QueueDepth: 1},
+		}}},
+		{addr: "server2:9000", cmdRunner: diskIOStub{err: errDiskNotFound}},
+	}
+
+	disks, err := getPeerDiskIOStats(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []DiskIO{
+		{Node: "server0:9000", Endpoint: "/disk1", Status: diskIOAvailable, QueueDepth: 1},
+		{Node: "server1:9000", Endpoint: "/disk1", Status: diskIOAvailable, QueueDepth: 12, HighQueueDepth: true},
+		{Node: "server1:9000", Endpoint: "/disk2", Status: diskIOUnavailable},
+	}
+	if !reflect.DeepEqual(disks, expected) {
+		t.Fatalf("Expected %v, got %v", expected, disks)
+	}
+}
+
+// clientConnsStub - adminCmdRunner returning fixed client activity.
+type clientConnsStub struct {
+	adminCmdRunner
+	clients map[string]ClientConnStats
+	err     error
+}
+
+func (s clientConnsStub) ClientConnections(ctx context.Context) (map[string]ClientConnStats, error) {
+	return s.clients, s.err
+}
+
+// TestGetPeerClientConnections - test for getPeerClientConnections.
+func TestGetPeerClientConnections(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: clientConnsStub{clients: map[string]ClientConnStats{
+			"10.0.0.1": {Connections: 2, ActiveRequests: 3, Requests: 10, BytesIn: 100, BytesOut: 1000},
+			"10.0.0.2": {Requests: 1, BytesOut: 10},
+		}}},
+		{addr: "server1:9000", cmdRunner: clientConnsStub{clients: map[string]ClientConnStats{
+			"10.0.0.1": {Connections: 1, ActiveRequests: 1, Requests: 5, BytesIn: 50, BytesOut: 500},
+		}}},
+		{addr: "server2:9000", cmdRunner: clientConnsStub{err: errDiskNotFound}},
+	}
+
+	clients, err := getPeerClientConnections(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]ClientConnStats{
+		"10.0.0.1": {Connections: 3, ActiveRequests: 4, Requests: 15, BytesIn: 150, BytesOut: 1500},
+		"10.0.0.2": {Requests: 1, BytesOut: 10},
+	}
+	if !reflect.DeepEqual(clients, expected) {
+		t.Fatalf("Expected %v, got %v", expected, clients)
+	}
+
+	peers = adminPeers{
+		{addr: "server0:9000", cmdRunner: clientConnsStub{err: errDiskNotFound}},
+		{addr: "server1:9000", cmdRunner: clientConnsStub{err: errDiskNotFound}},
+	}
+	if _, err = getPeerClientConnections(context.Background(), peers); err == nil {
+		t.Fatal("Expected an error when no peer could be reached")
+	}
+}
+
+// backgroundAppendStub - adminCmdRunner keeping a background append
+// toggle of its own.
+type backgroundAppendStub struct {
+	adminCmdRunner
+	toggle *backgroundAppendToggle
+}
+
+func (s backgroundAppendStub) SetBackgroundAppend(ctx context.Context, enabled bool) error {
+	s.toggle.set(enabled)
+	return nil
+}
+
+// TestSetPeerBackgroundAppend - test for setPeerBackgroundAppend.
+func TestSetPeerBackgroundAppend(t *testing.T) {
+	toggles := make([]*backgroundAppendToggle, 4)
+	peers := make(adminPeers, len(toggles))
+	for i := range peers {
+		toggles[i] = &backgroundAppendToggle{enabled: true}
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: backgroundAppendStub{toggle: toggles[i]},
+		}
+	}
+
+	for _, enabled := range []bool{false, true} {
+		if err := setPeerBackgroundAppend(context.Background(), peers, enabled); err != nil {
+			t.Fatal(err)
+		}
+		for i, toggle := range toggles {
+			if toggle.get() != enabled {
+				t.Fatalf("Peer %d: expected %v, got %v", i, enabled, toggle.get())
+			}
+		}
+	}
+}
+
+// lastAccessStub - adminCmdRunner returning fixed last reads.
+type lastAccessStub struct {
+	adminCmdRunner
+	accesses map[string]time.Time
+	err      error
+}
+
+func (s lastAccessStub) LastAccessTimes(ctx context.Context, bucket, prefix string) (map[string]time.Time, error) {
+	return s.accesses, s.err
+}
+
+// TestGetPeerLastAccessReport - test for getPeerLastAccessReport.
+func TestGetPeerLastAccessReport(t *testing.T) {
+	ExecObjectLayerTest(t, testGetPeerLastAccessReport)
+}
+
+func testGetPeerLastAccessReport(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer resetGlobalObjectAPI()
+
+	bucket := getRandomBucketName()
+	if err := obj.MakeBucket(bucket); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	created := make(map[string]time.Time)
+	for _, object := range []string{"dir/cold", "dir/hot", "dir/stale", "other"} {
+		objInfo, err := obj.PutObject(bucket, object, int64(len("data")), bytes.NewReader([]byte("data")), nil, "")
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		created[object] = objInfo.ModTime
+	}
+
+	// Reads in the future are recent whatever olderThan, reads before
+	// creation do not count.
+	future := time.Now().UTC().Add(time.Hour)
+	past := created["dir/stale"].Add(-time.Hour)
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: lastAccessStub{accesses: map[string]time.Time{
+			"dir/hot":   past,
+			"dir/stale": past,
+		}}},
+		{addr: "server1:9000", cmdRunner: lastAccessStub{accesses: map[string]time.Time{
+			"dir/hot": future,
+		}}},
+		{addr: "server2:9000", cmdRunner: lastAccessStub{err: errDiskNotFound}},
+	}
+
+	coldObjects, err := getPeerLastAccessReport(context.Background(), peers, bucket, "dir/", 0)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	expected := []ColdObject{
+		{Object: "dir/cold", Size: 4, LastAccess: created["dir/cold"], NeverAccessed: true},
+		{Object: "dir/stale", Size: 4, LastAccess: created["dir/stale"], NeverAccessed: true},
+	}
+	if len(coldObjects) != len(expected) {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, expected, coldObjects)
+	}
+	for i := range expected {
+		if coldObjects[i].Object != expected[i].Object || coldObjects[i].Size != expected[i].Size ||
+			!coldObjects[i].LastAccess.Equal(expected[i].LastAccess) || coldObjects[i].NeverAccessed != expected[i].NeverAccessed {
+			t.Fatalf("%s: Expected %v, got %v", instanceType, expected, coldObjects)
+		}
+	}
+
+	if _, err = getPeerLastAccessReport(context.Background(), peers, bucket, "", -time.Second); err != errInvalidArgument {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, errInvalidArgument, err)
+	}
+}
+
+// uploadLimitStub - adminCmdRunner keeping upload limits of its own.
+type uploadLimitStub struct {
+	adminCmdRunner
+	limits *bucketUploadLimits
+}
+
+func (s uploadLimitStub) SetBucketUploadLimit(ctx context.Context, bucket string, maxUploads int) error {
+	return s.limits.set(bucket, maxUploads)
+}
+
+// TestSetPeerBucketUploadLimit - test for setPeerBucketUploadLimit.
+func TestSetPeerBucketUploadLimit(t *testing.T) {
+	limits := make([]*bucketUploadLimits, 4)
+	peers := make(adminPeers, len(limits))
+	for i := range peers {
+		limits[i] = newBucketUploadLimits()
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: uploadLimitStub{limits: limits[i]},
+		}
+	}
+
+	if err := setPeerBucketUploadLimit(context.Background(), peers, "bucket", 10); err != nil {
+		t.Fatal(err)
+	}
+	for i, l := range limits {
+		if maxUploads := l.get("bucket"); maxUploads != 10 {
+			t.Fatalf("Peer %d: expected 10, got %d", i, maxUploads)
+		}
+	}
+
+	// Negative limits are refused before fan-out.
+	if err := setPeerBucketUploadLimit(context.Background(), peers, "bucket", -1); err != errInvalidUploadLimit {
+		t.Fatalf("Expected %v, got %v", errInvalidUploadLimit, err)
+	}
+	if maxUploads := limits[0].get("bucket"); maxUploads != 10 {
+		t.Fatalf("Expected 10, got %d", maxUploads)
+	}
+}
+
+// decodeFailureStub - adminCmdRunner returning fixed decode failures.
+type decodeFailureStub struct {
+	adminCmdRunner
+	log NodeDecodeFailures
+	err error
+}
+
+func (s decodeFailureStub) DecodeFailureLog(ctx context.Context, n int) (NodeDecodeFailures, error) {
+	return s.log, s.err
+}
+
+// TestGetPeerDecodeFailureLog - test for getPeerDecodeFailureLog.
+func TestGetPeerDecodeFailureLog(t *testing.T) {
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: decodeFailureStub{log: NodeDecodeFailures{
+			Failures: []DecodeFailure{
+				{Time: now, Bucket: "bucket", Object: "healed", Missing: 9},
+				{Time: now.Add(time.Second), Bucket: "bucket", Object: "lost", Corrupt: 9},
+			},
+		}}},
+		{addr: "server1:9000", cmdRunner: decodeFailureStub{log: NodeDecodeFailures{
+			Heals: []ObjectHeal{{Time: now.Add(time.Minute), Bucket: "bucket", Object: "healed"}},
+		}}},
+		{addr: "server2:9000", cmdRunner: decodeFailureStub{err: errDiskNotFound}},
+	}
+
+	failures, err := getPeerDecodeFailureLog(context.Background(), peers, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []DecodeFailure{
+		{Node: "server0:9000", Time: now.Add(time.Second), Bucket: "bucket", Object: "lost", Corrupt: 9},
+		{Node: "server0:9000", Time: now, Bucket: "bucket", Object: "healed", Missing: 9, Resolved: true, ResolvedAt: now.Add(time.Minute)},
+	}
+	if !reflect.DeepEqual(failures, expected) {
+		t.Fatalf("Expected %v, got %v", expected, failures)
+	}
+
+	if _, err = getPeerDecodeFailureLog(context.Background(), peers, 0); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+}
+
+// shardHealthStub - adminCmdRunner returning fixed shard health.
+type shardHealthStub struct {
+	adminCmdRunner
+	shards []ShardHealth
+	err    error
+}
+
+func (s shardHealthStub) ShardHealth(ctx context.Context, bucket, object string) ([]ShardHealth, error) {
+	return s.shards, s.err
+}
+
+// TestGetPeerShardDiagnosis - tests a diagnosis needs a majority of
+// peers and disks of unreachable peers are reported offline.
+func TestGetPeerShardDiagnosis(t *testing.T) {
+	defer resetGlobalEndpoints()
+	globalEndpoints = []*url.URL{
+		{Scheme: "http", Host: "server0:9000", Path: "/disk0"},
+		{Scheme: "http", Host: "server0:9000", Path: "/disk1"},
+		{Scheme: "http", Host: "server1:9000", Path: "/disk0"},
+		{Scheme: "http", Host: "server1:9000", Path: "/disk1"},
+	}
+	modTime := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	healthy := func(i int) ShardHealth {
+		return ShardHealth{
+			Shard:        i%2 + 1,
+			Disk:         globalEndpoints[i].String(),
+			State:        shardHealthy,
+			ModTime:      modTime,
+			DiskIndex:    i,
+			DataBlocks:   2,
+			Distribution: []int{2, 1, 4, 3},
+		}
+	}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: shardHealthStub{shards: []ShardHealth{healthy(0), healthy(1)}}},
+		{addr: "server1:9000", cmdRunner: shardHealthStub{err: errDiskNotFound}},
+	}
+
+	// One of two peers is not a majority, whichever error is
+	// counted first.
+	for i := 0; i < 100; i++ {
+		if _, err := getPeerShardDiagnosis(context.Background(), peers, "bucket", "object"); err != (InsufficientReadQuorum{}) {
+			t.Fatalf("Expected %v, got %v", InsufficientReadQuorum{}, err)
+		}
+	}
+
+	diag, err := newShardDiag("bucket", "object", globalEndpoints, [][]ShardHealth{{healthy(0), healthy(1)}, nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var states []string
+	for _, shard := range diag.Shards {
+		states = append(states, shard.State)
+	}
+	expected := []string{shardHealthy, shardHealthy, shardOffline, shardOffline}
+	if !reflect.DeepEqual(states, expected) {
+		t.Fatalf("Expected shard states %v, got %v", expected, states)
+	}
+	if diag.Verdict != shardDiagDegraded || !reflect.DeepEqual(diag.Reconstruct, []int{3, 4}) ||
+		diag.Suggestion != "heal the object to reconstruct shards 3, 4, bring the disks of shards 3, 4 back online first" {
+		t.Fatalf("Unexpected diagnosis %#v", diag)
+	}
+}
+
+// maintenanceStub - adminCmdRunner setting its own maintenance
+// schedule.
+type maintenanceStub struct {
+	adminCmdRunner
+	m *maintenance
+}
+
+func (s maintenanceStub) SetMaintenanceSchedule(ctx context.Context, schedule MaintenanceSchedule) error {
+	return s.m.set(schedule)
+}
+
+// TestSetPeerMaintenanceSchedule - test for setPeerMaintenanceSchedule.
+func TestSetPeerMaintenanceSchedule(t *testing.T) {
+	schedules := make([]*maintenance, 4)
+	peers := make(adminPeers, len(schedules))
+	for i := range peers {
+		schedules[i] = &maintenance{}
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: maintenanceStub{m: schedules[i]},
+		}
+	}
+
+	schedule := MaintenanceSchedule{
+		Windows: []MaintenanceWindow{{Cron: "0 2 * * 6", Duration: 2 * time.Hour}},
+		Action:  maintenanceReadOnly,
+	}
+	if err := setPeerMaintenanceSchedule(context.Background(), peers, schedule); err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range schedules {
+		if got := m.get(); !reflect.DeepEqual(got, schedule) {
+			t.Fatalf("Peer %d: expected %v, got %v", i, schedule, got)
+		}
+	}
+
+	// Overlapping windows are refused before fan-out.
+	overlapping := MaintenanceSchedule{
+		Windows: []MaintenanceWindow{
+			{Cron: "0 2 * * 6", Duration: 2 * time.Hour},
+			{Cron: "0 3 * * 6", Duration: time.Hour},
+		},
+		Action: maintenanceReadOnly,
+	}
+	if err := setPeerMaintenanceSchedule(context.Background(), peers, overlapping); err != errInvalidMaintenanceSchedule {
+		t.Fatalf("Expected %v, got %v", errInvalidMaintenanceSchedule, err)
+	}
+	if got := schedules[0].get(); !reflect.DeepEqual(got, schedule) {
+		t.Fatalf("Expected %v, got %v", schedule, got)
+	}
+}
+
+type quorumOverrideStub struct {
+	adminCmdRunner
+	ttls []time.Duration
+	idx  int
+}
+
+func (s quorumOverrideStub) SetQuorumOverride(ctx context.Context, readQuorum, writeQuorum int, ttl time.Duration) error {
+	s.ttls[s.idx] = ttl
+	return nil
+}
+
+// TestSetPeerQuorumOverride - test for setPeerQuorumOverride.
+func TestSetPeerQuorumOverride(t *testing.T) {
+	ttls := make([]time.Duration, 4)
+	peers := make(adminPeers, len(ttls))
+	for i := range peers {
+		peers[i] = adminPeer{
+			addr:      fmt.Sprintf("server%d:9000", i),
+			cmdRunner: quorumOverrideStub{ttls: ttls, idx: i},
+		}
+	}
+
+	if err := setPeerQuorumOverride(context.Background(), peers, 0, 8, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	for i, ttl := range ttls {
+		if ttl != time.Hour {
+			t.Fatalf("Peer %d: expected ttl %s, got %s", i, time.Hour, ttl)
+		}
+	}
+
+	// Invalid overrides are refused before fan-out.
+	if err := setPeerQuorumOverride(context.Background(), peers, 0, 8, 25*time.Hour); err != errInvalidQuorumOverride {
+		t.Fatalf("Expected %v, got %v", errInvalidQuorumOverride, err)
+	}
+	if err := setPeerQuorumOverride(context.Background(), peers, -1, 8, time.Minute); err != errInvalidQuorumOverride {
+		t.Fatalf("Expected %v, got %v", errInvalidQuorumOverride, err)
+	}
+	if ttls[0] != time.Hour {
+		t.Fatalf("Expected ttl %s, got %s", time.Hour, ttls[0])
+	}
+}
+
+// reInitDisksStub - adminCmdRunner failing ReInitDisks with err and
+// recording it was called.
+type reInitDisksStub struct {
+	adminCmdRunner
+	called []bool
+	idx    int
+	err    error
+}
+
+func (s reInitDisksStub) ReInitDisks(ctx context.Context) error {
+	s.called[s.idx] = true
+	return s.err
+}
+
+// TestReInitPeerDisks - test for reInitPeerDisks.
+func TestReInitPeerDisks(t *testing.T) {
+	testCases := []struct {
+		// Errors of the peers, the first one being the local peer.
+		errs        []error
+		expectedErr error
+	}{
+		{[]error{nil}, nil},
+		{[]error{nil, nil, nil, nil}, nil},
+		{[]error{nil, nil, nil, errDiskNotFound}, nil},
+		// The local peer's no-op does not mask a remote failure.
+		{[]error{nil, errDiskNotFound}, InsufficientWriteQuorum{}},
+		{[]error{nil, nil, errDiskNotFound, errFaultyRemoteDisk}, InsufficientWriteQuorum{}},
+		{[]error{nil, errDiskNotFound, errDiskNotFound, errDiskNotFound}, errDiskNotFound},
+	}
+	for i, testCase := range testCases {
+		called := make([]bool, len(testCase.errs))
+		peers := make(adminPeers, len(testCase.errs))
+		for j, err := range testCase.errs {
+			peers[j] = adminPeer{
+				addr:      fmt.Sprintf("server%d:9000", j),
+				cmdRunner: reInitDisksStub{called: called, idx: j, err: err},
+			}
+		}
+		err := errorCause(reInitPeerDisks(context.Background(), peers))
+		if err != testCase.expectedErr {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		for j, ok := range called {
+			if !ok {
+				t.Errorf("Test %d: peer %d was not asked to reinitialize its disks", i+1, j)
+			}
+		}
+	}
+}
+
+// configStub - adminCmdRunner keeping its own config.json, failing
+// SetConfig with err.
+type configStub struct {
+	adminCmdRunner
+	configs [][]byte
+	idx     int
+	err     error
+}
+
+func (s configStub) GetConfig(ctx context.Context) ([]byte, error) {
+	return s.configs[s.idx], nil
+}
+
+func (s configStub) SetConfig(ctx context.Context, config []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.configs[s.idx] = config
+	return nil
+}
+
+// TestSetPeerConfig - test for setPeerConfig.
+func TestSetPeerConfig(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("Init Test config failed")
+	}
+	// remove the root directory after the test ends.
+	defer removeAll(rootPath)
+
+	// config1 and config2 have the credentials of this server.
+	serverConfig.SetCredential(credential{AccessKey: "minio", SecretKey: "minio123"})
+
+	configs := make([][]byte, 4)
+	makePeers := func(errs ...error) adminPeers {
+		peers := make(adminPeers, len(errs))
+		for i, err := range errs {
+			configs[i] = config1
+			peers[i] = adminPeer{
+				addr:      fmt.Sprintf("server%d:9000", i),
+				cmdRunner: configStub{configs: configs, idx: i, err: err},
+			}
+		}
+		return peers
+	}
+
+	// Peers which did not save the config are named.
+	err = setPeerConfig(context.Background(), makePeers(nil, nil, nil, errDiskNotFound), config2)
+	if !reflect.DeepEqual(err, PeerErrors{"server3:9000": errDiskNotFound}) {
+		t.Fatalf("Expected %v, got %v", PeerErrors{"server3:9000": errDiskNotFound}, err)
+	}
+	for i, config := range configs[:3] {
+		if !bytes.Equal(config, config2) {
+			t.Fatalf("Peer %d: expected config2, got %s", i, config)
+		}
+	}
+
+	// No error if all peers saved it.
+	if err = setPeerConfig(context.Background(), makePeers(nil, nil, nil, nil), config2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Peers which saved the config are rolled back without a majority.
+	err = setPeerConfig(context.Background(), makePeers(nil, nil, errDiskNotFound, errDiskNotFound), config2)
+	if errorCause(err) != (InsufficientWriteQuorum{}) {
+		t.Fatalf("Expected %v, got %v", InsufficientWriteQuorum{}, err)
+	}
+	for i, config := range configs {
+		if !bytes.Equal(config, config1) {
+			t.Fatalf("Peer %d: expected config1, got %s", i, config)
+		}
+	}
+
+	// Invalid configs are refused before fan-out.
+	invalid := bytes.Replace(config2, []byte(`"version": "13"`), []byte(`"version": "12"`), 1)
+	if err = setPeerConfig(context.Background(), makePeers(nil, nil, nil, nil), invalid); err != errInvalidConfig {
+		t.Fatalf("Expected %v, got %v", errInvalidConfig, err)
+	}
+	if !bytes.Equal(configs[0], config1) {
+		t.Fatalf("Expected config1, got %s", configs[0])
+	}
+
+	// So are configs changing the credentials.
+	serverConfig.SetCredential(credential{AccessKey: "minio", SecretKey: "minio1234"})
+	if err = setPeerConfig(context.Background(), makePeers(nil, nil, nil, nil), config2); err != errConfigCredentialChanged {
+		t.Fatalf("Expected %v, got %v", errConfigCredentialChanged, err)
+	}
+	if !bytes.Equal(configs[0], config1) {
+		t.Fatalf("Expected config1, got %s", configs[0])
+	}
+}
+
+// hangingPeerStub - adminCmdRunner never replying until its context is
+// done, closing cancelled then.
+type hangingPeerStub struct {
+	adminCmdRunner
+	cancelled chan struct{}
+}
+
+func (s hangingPeerStub) wait(ctx context.Context) error {
+	<-ctx.Done()
+	close(s.cancelled)
+	return ctx.Err()
+}
+
+func (s hangingPeerStub) GetConfig(ctx context.Context) ([]byte, error) {
+	return nil, s.wait(ctx)
+}
+
+func (s hangingPeerStub) Uptime(ctx context.Context) (time.Duration, error) {
+	return 0, s.wait(ctx)
+}
+
+func (s hangingPeerStub) ListLocks(ctx context.Context, bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
+	return nil, s.wait(ctx)
+}
+
+// replyingPeerStub - adminCmdRunner replying right away.
+type replyingPeerStub struct {
+	adminCmdRunner
+	config []byte
+}
+
+func (s replyingPeerStub) GetConfig(ctx context.Context) ([]byte, error) {
+	return s.config, nil
+}
+
+func (s replyingPeerStub) Uptime(ctx context.Context) (time.Duration, error) {
+	return time.Hour, nil
+}
+
+func (s replyingPeerStub) ListLocks(ctx context.Context, bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
+	return []VolumeLockInfo{{Bucket: bucket, Object: "object"}}, nil
+}
+
+// failingPeerStub - adminCmdRunner failing with err right away.
+type failingPeerStub struct {
+	adminCmdRunner
+	err error
+}
+
+func (s failingPeerStub) ListLocks(ctx context.Context, bucket, prefix string, duration time.Duration) ([]VolumeLockInfo, error) {
+	return nil, s.err
+}
+
+// TestGetPeerConfigQuorum - tests getPeerConfig cancels the calls in
+// flight once a majority of servers returned the same config.
+func TestGetPeerConfigQuorum(t *testing.T) {
+	defer func(isDistXL bool) {
+		globalIsDistXL = isDistXL
+	}(globalIsDistXL)
+	globalIsDistXL = true
+
+	hanging := hangingPeerStub{cancelled: make(chan struct{})}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: replyingPeerStub{config: config1}},
+		{addr: "server1:9000", cmdRunner: replyingPeerStub{config: config1}},
+		{addr: "server2:9000", cmdRunner: replyingPeerStub{config: config1}},
+		{addr: "server3:9000", cmdRunner: hanging},
+	}
+	configBytes, err := getPeerConfig(context.Background(), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config serverConfigV13
+	if err = json.Unmarshal(configBytes, &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Region != "us-east-1" {
+		t.Fatalf("Expected region us-east-1, got %s", config.Region)
+	}
+	select {
+	case <-hanging.cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the call to the hanging server to be cancelled")
+	}
+}
+
+// TestPeerFanOutQuorum - tests fan-out helpers cancel the call to a
+// hanging server once a majority of servers replied, returning the
+// replies of the others.
+func TestPeerFanOutQuorum(t *testing.T) {
+	defer func(isDistXL bool) {
+		globalIsDistXL = isDistXL
+	}(globalIsDistXL)
+	globalIsDistXL = true
+
+	hanging := hangingPeerStub{cancelled: make(chan struct{})}
+	peers := adminPeers{
+		{addr: "server0:9000", cmdRunner: replyingPeerStub{}},
+		{addr: "server1:9000", cmdRunner: replyingPeerStub{}},
+		{addr: "server2:9000", cmdRunner: replyingPeerStub{}},
+		{addr: "server3:9000", cmdRunner: hanging},
+	}
+	locks, err := listPeerLocksInfo(context.Background(), peers, "bucket", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One lock from each server which replied.
+	if len(locks) != 3 || locks[0].Bucket != "bucket" || locks[0].Object != "object" {
+		t.Fatalf("Unexpected locks %v", locks)
+	}
+	select {
+	case <-hanging.cancelled:
+	default:
+		t.Fatal("Expected the call to the hanging server to be cancelled")
+	}
+
+	// Calls are cancelled too once no majority can reply.
+	hanging = hangingPeerStub{cancelled: make(chan struct{})}
+	peers = adminPeers{
+		{addr: "server0:9000", cmdRunner: failingPeerStub{err: errDiskNotFound}},
+		{addr: "server1:9000", cmdRunner: failingPeerStub{err: errDiskNotFound}},
+		{addr: "server2:9000", cmdRunner: hanging},
+	}
+	if _, err = listPeerLocksInfo(context.Background(), peers, "bucket", "", 0); err != errDiskNotFound {
+		t.Fatalf("Expected %v, got %v", errDiskNotFound, err)
+	}
+	select {
+	case <-hanging.cancelled:
+	default:
+		t.Fatal("Expected the call to the hanging server to be cancelled")
+	}
+}
+
+// TestAdminClientCancelled - tests local and remote admin clients
+// return once their context is done.
+func TestAdminClientCancelled(t *testing.T) {
+	defer resetGlobalQuorumOverride()
+	defer resetGlobalRPCErrors()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// Local global state is not touched.
+	err := localAdminClient{}.SetQuorumOverride(ctx, 8, 8, time.Hour)
+	if err != context.Canceled {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+	if _, ok := globalQuorumOverride.get(time.Now()); ok {
+		t.Fatal("Expected no quorum override")
+	}
+
+	// A server accepting connections but never replying.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptDone := make(chan struct{})
+	go func() {
+		defer close(acceptDone)
+		var conns []net.Conn
+		for {
+			conn, aErr := listener.Accept()
+			if aErr != nil {
+				for _, conn = range conns {
+					conn.Close()
+				}
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+	// Shut the server down before returning.
+	defer func() {
+		listener.Close()
+		<-acceptDone
+	}()
+
+	rc := remoteAdminClient{newAuthRPCClient(authConfig{
+		serverAddr:       listener.Addr().String(),
+		serviceEndpoint:  path.Join(minioReservedBucketPath, adminPath),
+		serviceName:      "Admin",
+		disableReconnect: true,
+	})}
+	defer rc.Close()
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err = rc.Uptime(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Expected the call to return by the deadline, took %s", elapsed)
+	}
+}
+
+// forceUnlockStub - adminCmdRunner recording the locks it was asked to
+// force unlock, failing with err.
+type forceUnlockStub struct {
+	adminCmdRunner
+	unlocked []string
+	idx      int
+	err      error
+}
+
+func (s forceUnlockStub) ForceUnlock(ctx context.Context, bucket, object string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.unlocked[s.idx] = pathJoin(bucket, object)
+	return nil
+}
+
+// TestForceUnlockPeers - test for forceUnlockPeers.
+func TestForceUnlockPeers(t *testing.T) {
+	unlocked := make([]string, 4)
+	makePeers := func(errs ...error) adminPeers {
+		peers := make(adminPeers, len(errs))
+		for i, err := range errs {
+			peers[i] = adminPeer{
+				addr:      fmt.Sprintf("server%d:9000", i),
+				cmdRunner: forceUnlockStub{unlocked: unlocked, idx: i, err: err},
+			}
+		}
+		return peers
+	}
+
+	if err := forceUnlockPeers(context.Background(), makePeers(nil, nil, nil, nil), "bucket", "object"); err != nil {
+		t.Fatal(err)
+	}
+	for i, lock := range unlocked {
+		if lock != "bucket/object" {
+			t.Fatalf("Peer %d: expected bucket/object to be unlocked, got %q", i, lock)
+		}
+	}
+
+	// A single unreachable peer fails the unlock.
+	err := forceUnlockPeers(context.Background(), makePeers(nil, nil, nil, errDiskNotFound), "bucket", "object")
+	if expected := (PeerErrors{"server3:9000": errDiskNotFound}); !reflect.DeepEqual(err, expected) {
+		t.Fatalf("Expected %v, got %v", expected, err)
+	}
+}
+
+// TestLocalForceUnlock - tests force unlocking removes a lock from the
+// local lock instrumentation, and that unlocking a lock not held is not
+// an error.
+func TestLocalForceUnlock(t *testing.T) {
+	initNSLock(false)
+
+	globalNSMutex.Lock("bucket", "object", "op")
+	if _, ok := globalNSMutex.lockMap[nsParam{"bucket", "object"}]; !ok {
+		t.Fatal("Expected lock to be held")
+	}
+	for i := 0; i < 2; i++ {
+		if err := (localAdminClient{}).ForceUnlock(context.Background(), "bucket", "object"); err != nil {
+			t.Fatalf("Unlock %d: %v", i+1, err)
+		}
+	}
+	if _, ok := globalNSMutex.lockMap[nsParam{"bucket", "object"}]; ok {
+		t.Fatal("Expected lock to be removed")
+	}
+	if locks := listLocksInfo("bucket", "", 0); len(locks) != 0 {
+		t.Fatalf("Expected no locks, got %v", locks)
+	}
+}
+
+// serviceCmdStub - adminCmdRunner recording the order in which
+// service commands reach the servers, failing them with err.
+type serviceCmdStub struct {
+	adminCmdRunner
+	addr  string
+	mu    *sync.Mutex
+	calls *[]string
+	err   error
+}
+
+func (s serviceCmdStub) record(cmd string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*s.calls = append(*s.calls, cmd+" "+s.addr)
+	return s.err
+}
+
+func (s serviceCmdStub) Restart(ctx context.Context) error {
+	return s.record("restart")
+}
+
+func (s serviceCmdStub) Stop(ctx context.Context) error {
+	return s.record("stop")
+}
+
+// TestSendServiceCmd - tests restart and stop reach all remote
+// servers before the local one.
+func TestSendServiceCmd(t *testing.T) {
+	testCases := []struct {
+		cmd  serviceSignal
+		name string
+	}{
+		{serviceRestart, "restart"},
+		{serviceStop, "stop"},
+	}
+
+	for i, testCase := range testCases {
+		var mu sync.Mutex
+		var calls []string
+		peers := make(adminPeers, 4)
+		for j := range peers {
+			addr := fmt.Sprintf("server%d:9000", j)
+			peers[j] = adminPeer{
+				addr:      addr,
+				cmdRunner: serviceCmdStub{addr: addr, mu: &mu, calls: &calls},
+			}
+		}
+
+		if err := sendServiceCmd(context.Background(), peers, testCase.cmd); err != nil {
+			t.Fatalf("Test %d: Expected no error but got %v", i+1, err)
+		}
+		if len(calls) != len(peers) {
+			t.Fatalf("Test %d: Expected %d calls but got %v", i+1, len(peers), calls)
+		}
+		for _, call := range calls {
+			if !strings.HasPrefix(call, testCase.name+" ") {
+				t.Errorf("Test %d: Expected only %s commands but got %v", i+1, testCase.name, calls)
+			}
+		}
+		if expected := testCase.name + " " + peers[0].addr; calls[len(calls)-1] != expected {
+			t.Errorf("Test %d: Expected local server to be last, got %v", i+1, calls)
+		}
+	}
+}
+
+// TestSendServiceCmdErrors - tests the PeerErrors returned by
+// sendServiceCmd name exactly the servers which failed.
+func TestSendServiceCmdErrors(t *testing.T) {
+	testCases := []struct {
+		failed []int
+	}{
+		{nil},
+		{[]int{0}},
+		{[]int{2}},
+		{[]int{1, 3}},
+		{[]int{0, 1, 2, 3}},
+	}
+
+	for i, testCase := range testCases {
+		var mu sync.Mutex
+		var calls []string
+		peers := make(adminPeers, 4)
+		for j := range peers {
+			addr := fmt.Sprintf("server%d:9000", j)
+			peers[j] = adminPeer{
+				addr:      addr,
+				cmdRunner: serviceCmdStub{addr: addr, mu: &mu, calls: &calls},
+			}
+		}
+		expected := make(PeerErrors)
+		for _, j := range testCase.failed {
+			stub := peers[j].cmdRunner.(serviceCmdStub)
+			stub.err = errServerNotInitialized
+			peers[j].cmdRunner = stub
+			expected[peers[j].addr] = errServerNotInitialized
+		}
+
+		err := sendServiceCmd(context.Background(), peers, serviceRestart)
+		if len(calls) != len(peers) {
+			t.Errorf("Test %d: Expected all %d servers to be called but got %v", i+1, len(peers), calls)
+		}
+		if len(expected) == 0 {
+			if err != nil {
+				t.Errorf("Test %d: Expected no error but got %v", i+1, err)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(err, expected) {
+			t.Errorf("Test %d: Expected error %v but got %v", i+1, expected, err)
+		}
+	}
+}
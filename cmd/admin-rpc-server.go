@@ -43,6 +43,13 @@ type ListLocksQuery struct {
 	duration time.Duration
 }
 
+// ForceUnlockArgs - wraps the lock to force unlock sent over RPC.
+type ForceUnlockArgs struct {
+	AuthRPCArgs
+	Bucket string
+	Object string
+}
+
 // ListLocksReply - wraps ListLocks response over RPC.
 type ListLocksReply struct {
 	AuthRPCReply
@@ -61,6 +68,556 @@ type ConfigReply struct {
 	Config []byte // json-marshalled bytes of serverConfigV13
 }
 
+// SetConfigArgs - wraps the server config sent over RPC.
+type SetConfigArgs struct {
+	ConfigChangeArgs
+	Config []byte // json-marshalled bytes of serverConfigV13
+}
+
+// PresignExpiryArgs - wraps the presigned URL expiry limit sent over RPC.
+type PresignExpiryArgs struct {
+	ConfigChangeArgs
+	Expiry time.Duration
+}
+
+// SetStatusReply - wraps the erasure set status response over RPC.
+type SetStatusReply struct {
+	AuthRPCReply
+	Sets []SetStatus
+}
+
+// ThrottleStatusReply - wraps the throttle status response over RPC.
+type ThrottleStatusReply struct {
+	AuthRPCReply
+	Throttles []ThrottleStatus
+}
+
+// DumpLocksReply - wraps the state of all locks sent over RPC.
+type DumpLocksReply struct {
+	AuthRPCReply
+	VolLocks []VolumeLockInfo
+}
+
+// HealDeletePolicyArgs - wraps the heal delete mode sent over RPC.
+type HealDeletePolicyArgs struct {
+	ConfigChangeArgs
+	Mode string
+}
+
+// APILatencyReply - wraps the API latency histograms sent over RPC.
+type APILatencyReply struct {
+	AuthRPCReply
+	Histograms map[string]LatencyHistogram
+}
+
+// KeyPolicyArgs - wraps the object naming policy sent over RPC.
+type KeyPolicyArgs struct {
+	ConfigChangeArgs
+	Policy KeyPolicy
+}
+
+// BootTimingsReply - wraps the boot diagnostics sent over RPC.
+type BootTimingsReply struct {
+	AuthRPCReply
+	Timings BootTimings
+}
+
+// MultipartLifetimeArgs - wraps the multipart upload lifetime sent
+// over RPC.
+type MultipartLifetimeArgs struct {
+	ConfigChangeArgs
+	Lifetime time.Duration
+}
+
+// VersionClockReply - wraps the version and clock of a server sent
+// over RPC.
+type VersionClockReply struct {
+	AuthRPCReply
+	Info ServerVersionClock
+}
+
+// ConditionalWritesArgs - wraps the conditional writes toggle sent
+// over RPC.
+type ConditionalWritesArgs struct {
+	ConfigChangeArgs
+	Enabled bool
+}
+
+// DiskStateArgs - wraps the disk state filter sent over RPC.
+type DiskStateArgs struct {
+	AuthRPCArgs
+	State string
+}
+
+// DisksByStateReply - wraps the disks matching a state sent over RPC.
+type DisksByStateReply struct {
+	AuthRPCReply
+	Disks []DiskInfoMsg
+}
+
+// ClusterEventsArgs - wraps the sequence number after which events
+// are requested over RPC.
+type ClusterEventsArgs struct {
+	AuthRPCArgs
+	Since uint64
+}
+
+// ClusterEventsReply - wraps the events of a server sent over RPC.
+type ClusterEventsReply struct {
+	AuthRPCReply
+	Batch ClusterEventBatch
+}
+
+// BucketTagsArgs - wraps the tags of a bucket sent over RPC.
+type BucketTagsArgs struct {
+	ConfigChangeArgs
+	Bucket string
+	Tags   map[string]string
+}
+
+// SignatureVersionsArgs - wraps the allowed signature versions sent
+// over RPC.
+type SignatureVersionsArgs struct {
+	ConfigChangeArgs
+	Versions []string
+}
+
+// RPCConnStatsReply - wraps usage of the RPC clients of a server sent
+// over RPC.
+type RPCConnStatsReply struct {
+	AuthRPCReply
+	Conns []RPCConnStats
+}
+
+// ForceHealBucketArgs - wraps the heal job and the part of the bucket
+// to heal sent over RPC.
+type ForceHealBucketArgs struct {
+	AuthRPCArgs
+	ID     string
+	Bucket string
+	Part   int
+	Parts  int
+}
+
+// HealJobsReply - wraps the heal jobs of a server sent over RPC.
+type HealJobsReply struct {
+	AuthRPCReply
+	Jobs []HealJobStatus
+}
+
+// ImmutabilityWindowArgs - wraps the immutability window of a bucket
+// sent over RPC.
+type ImmutabilityWindowArgs struct {
+	ConfigChangeArgs
+	Bucket string
+	Window time.Duration
+}
+
+// ActiveMultipartBytesReply - wraps the bytes held by in-progress
+// multipart uploads of a server sent over RPC.
+type ActiveMultipartBytesReply struct {
+	AuthRPCReply
+	Bytes int64
+}
+
+// LockHoldHistogramReply - wraps lock hold histograms of a server sent
+// over RPC.
+type LockHoldHistogramReply struct {
+	AuthRPCReply
+	Histograms LockHoldHistograms
+}
+
+// ListPartsArgs - wraps the multipart upload whose parts are listed
+// sent over RPC.
+type ListPartsArgs struct {
+	AuthRPCArgs
+	Bucket   string
+	Object   string
+	UploadID string
+}
+
+// ListPartsReply - wraps the parts of a multipart upload sent over
+// RPC. Error types are lost over RPC, so an unknown upload is flagged
+// instead.
+type ListPartsReply struct {
+	AuthRPCReply
+	Parts          []PartInfo
+	UploadNotFound bool
+}
+
+// BackendReply - wraps the backend of a server sent over RPC.
+type BackendReply struct {
+	AuthRPCReply
+	Info BackendInfo
+}
+
+// RecalculateUsageArgs - wraps the usage scan and the part of the
+// bucket to count sent over RPC.
+type RecalculateUsageArgs struct {
+	AuthRPCArgs
+	ID     string
+	Bucket string
+	Part   int
+	Parts  int
+}
+
+// ScanProgressReply - wraps the usage scans of a server sent over RPC.
+type ScanProgressReply struct {
+	AuthRPCReply
+	Scans []UsageScanStatus
+}
+
+// TLSCiphersArgs - wraps the TLS cipher suites sent over RPC.
+type TLSCiphersArgs struct {
+	ConfigChangeArgs
+	Suites []string
+}
+
+// WriteAmplificationReply - wraps the write amplification of a server
+// sent over RPC.
+type WriteAmplificationReply struct {
+	AuthRPCReply
+	WriteAmp WriteAmplification
+}
+
+// MaxUploadPartsArgs - wraps the limit of parts per upload sent over
+// RPC.
+type MaxUploadPartsArgs struct {
+	ConfigChangeArgs
+	Parts int
+}
+
+// BucketChecksumArgs - wraps the bucket to checksum sent over RPC.
+type BucketChecksumArgs struct {
+	AuthRPCArgs
+	Bucket string
+}
+
+// BucketChecksumReply - wraps the checksum of a bucket sent over RPC.
+type BucketChecksumReply struct {
+	AuthRPCReply
+	Checksum BucketChecksum
+}
+
+// RequestIDConfigArgs - wraps the request ID configuration sent over
+// RPC.
+type RequestIDConfigArgs struct {
+	ConfigChangeArgs
+	Header        string
+	HonorClientID bool
+}
+
+// HealHistoryArgs - wraps the number of heal records requested over
+// RPC.
+type HealHistoryArgs struct {
+	AuthRPCArgs
+	N int
+}
+
+// HealHistoryReply - wraps the heal history of a server sent over RPC.
+type HealHistoryReply struct {
+	AuthRPCReply
+	Records []HealRecord
+}
+
+// RPCErrorsReply - wraps the admin RPC errors of a server sent over
+// RPC.
+type RPCErrorsReply struct {
+	AuthRPCReply
+	Peers []PeerRPCErrors
+}
+
+// BucketObjectLimitArgs - wraps the object limit of a bucket sent over
+// RPC.
+type BucketObjectLimitArgs struct {
+	ConfigChangeArgs
+	Bucket     string
+	MaxObjects int64
+}
+
+// ScannerImpactReply - wraps the scanner impact estimate of a server
+// sent over RPC.
+type ScannerImpactReply struct {
+	AuthRPCReply
+	Impact ImpactEstimate
+}
+
+// TrustedProxiesArgs - wraps the trusted proxies sent over RPC.
+type TrustedProxiesArgs struct {
+	ConfigChangeArgs
+	CIDRs []string
+}
+
+// BucketLoggingArgs - wraps the logging configuration of a bucket sent
+// over RPC.
+type BucketLoggingArgs struct {
+	ConfigChangeArgs
+	Bucket       string
+	TargetBucket string
+	TargetPrefix string
+}
+
+// CredentialUsageReply - wraps the credential usage of a server sent
+// over RPC.
+type CredentialUsageReply struct {
+	AuthRPCReply
+	Usages []CredUsage
+}
+
+// MaxConcurrentHealsArgs - wraps the cap of object heals running at
+// once sent over RPC.
+type MaxConcurrentHealsArgs struct {
+	ConfigChangeArgs
+	Max int
+}
+
+// ShutdownTimeoutArgs - wraps the shutdown timeout sent over RPC.
+type ShutdownTimeoutArgs struct {
+	ConfigChangeArgs
+	Timeout time.Duration
+}
+
+// ChecksumPolicyArgs - wraps the object checksum policy sent over RPC.
+type ChecksumPolicyArgs struct {
+	ConfigChangeArgs
+	Required   bool
+	Algorithms []string
+}
+
+// ServerSummaryReply - wraps the summary of a server sent over RPC.
+type ServerSummaryReply struct {
+	AuthRPCReply
+	Summary ServerSummary
+}
+
+// DiskFillReply - wraps the space used on the disks of a server sent
+// over RPC.
+type DiskFillReply struct {
+	AuthRPCReply
+	Disks []DiskFill
+}
+
+// NotificationRetryArgs - wraps the notification retry policy sent
+// over RPC.
+type NotificationRetryArgs struct {
+	ConfigChangeArgs
+	MaxRetries       int
+	Backoff          time.Duration
+	DeadLetterTarget string
+}
+
+// MaxListDepthArgs - wraps the maximum list depth sent over RPC.
+type MaxListDepthArgs struct {
+	ConfigChangeArgs
+	Depth int
+}
+
+// StartupErrorsReply - wraps the errors a server started in spite of
+// sent over RPC.
+type StartupErrorsReply struct {
+	AuthRPCReply
+	Errors []StartupError
+}
+
+// MemSummaryReply - wraps the memory summary of a server sent over
+// RPC.
+type MemSummaryReply struct {
+	AuthRPCReply
+	Stats MemStats
+}
+
+// BucketPartSizeLimitsArgs - wraps the part size limits of a bucket
+// sent over RPC.
+type BucketPartSizeLimitsArgs struct {
+	ConfigChangeArgs
+	Bucket  string
+	MinPart int64
+	MaxPart int64
+}
+
+// DeleteProtectionTagArgs - wraps the delete protection tag of a
+// bucket sent over RPC.
+type DeleteProtectionTagArgs struct {
+	ConfigChangeArgs
+	Bucket   string
+	TagKey   string
+	TagValue string
+}
+
+// ConfigChangeArgs - args of RPCs changing the config, wrapping the
+// config change requested through the admin API if any, logged by the
+// server once it applied it.
+type ConfigChangeArgs struct {
+	AuthRPCArgs
+	Change *ConfigChange
+}
+
+// ConfigChangeLogArgs - wraps the number of config changes to fetch
+// sent over RPC.
+type ConfigChangeLogArgs struct {
+	AuthRPCArgs
+	Count int
+}
+
+// ConfigChangeLogReply - wraps the config changes logged by a server
+// sent over RPC.
+type ConfigChangeLogReply struct {
+	AuthRPCReply
+	Changes []ConfigChange
+}
+
+// ScanOnWriteArgs - wraps the scan on write toggle sent over RPC.
+type ScanOnWriteArgs struct {
+	ConfigChangeArgs
+	Enabled bool
+}
+
+// CredentialExpiryArgs - wraps the expiry of an access key sent over
+// RPC, along with the time the sender's clock read when sending it.
+type CredentialExpiryArgs struct {
+	ConfigChangeArgs
+	AccessKey string
+	Expiry    time.Time
+	SentAt    time.Time
+}
+
+// ObjectDistributionArgs - wraps the bucket whose objects are counted
+// per erasure set sent over RPC.
+type ObjectDistributionArgs struct {
+	AuthRPCArgs
+	Bucket string
+}
+
+// ObjectDistributionReply - wraps the objects of a bucket stored on the
+// erasure sets of a server sent over RPC.
+type ObjectDistributionReply struct {
+	AuthRPCReply
+	Sets []SetObjects
+}
+
+// MultiDeleteLimitArgs - wraps the multi-delete limit sent over RPC.
+type MultiDeleteLimitArgs struct {
+	ConfigChangeArgs
+	MaxKeys int
+}
+
+// RejectReasonsArgs - wraps the window rejected requests are counted
+// over sent over RPC.
+type RejectReasonsArgs struct {
+	AuthRPCArgs
+	Window time.Duration
+}
+
+// RejectReasonsReply - wraps the requests a server rejected, by reason,
+// sent over RPC.
+type RejectReasonsReply struct {
+	AuthRPCReply
+	Reasons map[string]int
+}
+
+// SlowRequestLogArgs - wraps the threshold and number of slow requests
+// to return sent over RPC.
+type SlowRequestLogArgs struct {
+	AuthRPCArgs
+	ThresholdMs int
+	N           int
+}
+
+// SlowRequestLogReply - wraps the slow requests of a server sent over
+// RPC.
+type SlowRequestLogReply struct {
+	AuthRPCReply
+	Requests []SlowRequest
+}
+
+// DiskIOStatsReply - wraps the IO activity of the disks of a server
+// sent over RPC.
+type DiskIOStatsReply struct {
+	AuthRPCReply
+	Disks []DiskIO
+}
+
+// ClientConnectionsReply - wraps the activity of the clients of a
+// server sent over RPC.
+type ClientConnectionsReply struct {
+	AuthRPCReply
+	Clients map[string]ClientConnStats
+}
+
+// BackgroundAppendArgs - wraps the background append toggle sent over
+// RPC.
+type BackgroundAppendArgs struct {
+	ConfigChangeArgs
+	Enabled bool
+}
+
+// LastAccessTimesArgs - wraps the bucket and prefix whose last reads
+// are requested over RPC.
+type LastAccessTimesArgs struct {
+	AuthRPCArgs
+	Bucket string
+	Prefix string
+}
+
+// LastAccessTimesReply - wraps the last reads of objects served by a
+// server sent over RPC.
+type LastAccessTimesReply struct {
+	AuthRPCReply
+	Accesses map[string]time.Time
+}
+
+// BucketUploadLimitArgs - wraps the concurrent upload limit of a bucket
+// sent over RPC.
+type BucketUploadLimitArgs struct {
+	ConfigChangeArgs
+	Bucket     string
+	MaxUploads int
+}
+
+// DecodeFailureLogArgs - wraps the number of decode failures requested
+// over RPC.
+type DecodeFailureLogArgs struct {
+	AuthRPCArgs
+	N int
+}
+
+// DecodeFailureLogReply - wraps the decode failures and object heals
+// of a server sent over RPC.
+type DecodeFailureLogReply struct {
+	AuthRPCReply
+	Log NodeDecodeFailures
+}
+
+// ShardHealthArgs - wraps the object whose shards are diagnosed sent
+// over RPC.
+type ShardHealthArgs struct {
+	AuthRPCArgs
+	Bucket string
+	Object string
+}
+
+// ShardHealthReply - wraps the health of the shards of an object
+// stored on the disks of a server sent over RPC.
+type ShardHealthReply struct {
+	AuthRPCReply
+	Shards []ShardHealth
+}
+
+// MaintenanceScheduleArgs - wraps the maintenance schedule sent over
+// RPC.
+type MaintenanceScheduleArgs struct {
+	ConfigChangeArgs
+	Schedule MaintenanceSchedule
+}
+
+// QuorumOverrideArgs - wraps the quorum override sent over RPC.
+type QuorumOverrideArgs struct {
+	ConfigChangeArgs
+	ReadQuorum  int
+	WriteQuorum int
+	TTL         time.Duration
+}
+
 // Restart - Restart this instance of minio server.
 func (s *adminCmd) Restart(args *AuthRPCArgs, reply *AuthRPCReply) error {
 	if err := args.IsAuthenticated(); err != nil {
@@ -71,6 +628,16 @@ func (s *adminCmd) Restart(args *AuthRPCArgs, reply *AuthRPCReply) error {
 	return nil
 }
 
+// Stop - Stop this instance of minio server.
+func (s *adminCmd) Stop(args *AuthRPCArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	globalServiceSignalCh <- serviceStop
+	return nil
+}
+
 // ListLocks - lists locks held by requests handled by this server instance.
 func (s *adminCmd) ListLocks(query *ListLocksQuery, reply *ListLocksReply) error {
 	if err := query.IsAuthenticated(); err != nil {
@@ -81,6 +648,17 @@ func (s *adminCmd) ListLocks(query *ListLocksQuery, reply *ListLocksReply) error
 	return nil
 }
 
+// ForceUnlock - removes a namespace lock from the lock instrumentation
+// of this server.
+func (s *adminCmd) ForceUnlock(args *ForceUnlockArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	globalNSMutex.ForceUnlock(args.Bucket, args.Object)
+	return nil
+}
+
 // ReInitDisk - reinitialize storage disks and object layer to use the
 // new format.
 func (s *adminCmd) ReInitDisks(args *AuthRPCArgs, reply *AuthRPCReply) error {
@@ -158,6 +736,728 @@ func (s *adminCmd) GetConfig(args *AuthRPCArgs, reply *ConfigReply) error {
 	return nil
 }
 
+// SetConfig - validates and sets the config.json of this server.
+func (s *adminCmd) SetConfig(args *SetConfigArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, setServerConfig(args.Config))
+}
+
+// ErasureSetStatus - returns the health of erasure sets as seen by
+// this server.
+func (s *adminCmd) ErasureSetStatus(args *AuthRPCArgs, reply *SetStatusReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	sets, err := getLocalErasureSetStatus()
+	if err != nil {
+		return err
+	}
+
+	reply.Sets = sets
+	return nil
+}
+
+// SetMultiDeleteLimit - sets the multi-delete limit of this server.
+func (s *adminCmd) SetMultiDeleteLimit(args *MultiDeleteLimitArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, setMultiDeleteLimit(args.MaxKeys))
+}
+
+// RejectReasons - returns the requests this server rejected over a
+// window, by reason.
+func (s *adminCmd) RejectReasons(args *RejectReasonsArgs, reply *RejectReasonsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reasons, err := globalRejectReasons.get(args.Window, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	reply.Reasons = reasons
+	return nil
+}
+
+// SlowRequestLog - returns the last requests served by this server
+// slower than a threshold.
+func (s *adminCmd) SlowRequestLog(args *SlowRequestLogArgs, reply *SlowRequestLogReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	requests, err := getSlowRequestLog(args.ThresholdMs, args.N)
+	if err != nil {
+		return err
+	}
+	reply.Requests = requests
+	return nil
+}
+
+// DiskIOStats - returns the IO activity of the disks of this server.
+func (s *adminCmd) DiskIOStats(args *AuthRPCArgs, reply *DiskIOStatsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	disks, err := getLocalDiskIOStats()
+	if err != nil {
+		return err
+	}
+	reply.Disks = disks
+	return nil
+}
+
+// ClientConnections - returns the activity of the clients of this
+// server.
+func (s *adminCmd) ClientConnections(args *AuthRPCArgs, reply *ClientConnectionsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Clients = getLocalClientConnections()
+	return nil
+}
+
+// SetBackgroundAppend - enables or disables background append of the
+// parts of new multipart uploads on this server.
+func (s *adminCmd) SetBackgroundAppend(args *BackgroundAppendArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	globalBackgroundAppend.set(args.Enabled)
+	return globalConfigChangeLog.logApplied(args.Change, nil)
+}
+
+// LastAccessTimes - returns the last read of the objects of a bucket
+// under a prefix served by this server.
+func (s *adminCmd) LastAccessTimes(args *LastAccessTimesArgs, reply *LastAccessTimesReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Accesses = getLocalLastAccessTimes(args.Bucket, args.Prefix)
+	return nil
+}
+
+// SetBucketUploadLimit - sets the concurrent upload limit of a bucket
+// on this server.
+func (s *adminCmd) SetBucketUploadLimit(args *BucketUploadLimitArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalBucketUploadLimits.set(args.Bucket, args.MaxUploads))
+}
+
+// DecodeFailureLog - returns the last decode failures of this server
+// and the objects it healed.
+func (s *adminCmd) DecodeFailureLog(args *DecodeFailureLogArgs, reply *DecodeFailureLogReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	log, err := getLocalDecodeFailureLog(args.N)
+	if err != nil {
+		return err
+	}
+	reply.Log = log
+	return nil
+}
+
+// ShardHealth - returns the health of the shards of an object stored
+// on the disks of this server.
+func (s *adminCmd) ShardHealth(args *ShardHealthArgs, reply *ShardHealthReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	shards, err := getLocalShardHealth(args.Bucket, args.Object)
+	if err != nil {
+		return err
+	}
+	reply.Shards = shards
+	return nil
+}
+
+// SetMaintenanceSchedule - sets the maintenance schedule of this
+// server.
+func (s *adminCmd) SetMaintenanceSchedule(args *MaintenanceScheduleArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalMaintenance.set(args.Schedule))
+}
+
+// SetQuorumOverride - overrides the XL quorum of this server.
+func (s *adminCmd) SetQuorumOverride(args *QuorumOverrideArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, setLocalQuorumOverride(args.ReadQuorum, args.WriteQuorum, args.TTL))
+}
+
+// SetMaxPresignExpiry - sets the presigned URL expiry limit of this
+// server.
+func (s *adminCmd) SetMaxPresignExpiry(args *PresignExpiryArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, setMaxPresignExpiry(args.Expiry))
+}
+
+// ThrottleStatus - returns the state of limits configured on this
+// server.
+func (s *adminCmd) ThrottleStatus(args *AuthRPCArgs, reply *ThrottleStatusReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Throttles = globalThrottlers.status()
+	return nil
+}
+
+// DumpLocks - returns the state of all locks held or waited upon by
+// requests handled by this server instance.
+func (s *adminCmd) DumpLocks(args *AuthRPCArgs, reply *DumpLocksReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.VolLocks = dumpLocksInfo()
+	return nil
+}
+
+// SetHealDeletePolicy - sets the mode of dealing with orphaned
+// objects on this server.
+func (s *adminCmd) SetHealDeletePolicy(args *HealDeletePolicyArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalHealDeletePolicy.set(args.Mode))
+}
+
+// APILatency - returns latency histograms of S3 API operations
+// served by this server.
+func (s *adminCmd) APILatency(args *AuthRPCArgs, reply *APILatencyReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Histograms = globalAPILatency.snapshot()
+	return nil
+}
+
+// SetKeyNamingPolicy - sets the object naming policy of this server.
+func (s *adminCmd) SetKeyNamingPolicy(args *KeyPolicyArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalKeyNamingPolicy.set(args.Policy))
+}
+
+// BootDiagnostics - returns time taken by startup phases of this
+// server, phases which have not completed yet are marked pending.
+func (s *adminCmd) BootDiagnostics(args *AuthRPCArgs, reply *BootTimingsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Timings = globalBootDiagnostics.timings()
+	return nil
+}
+
+// SetMultipartLifetime - sets the time after which idle multipart
+// uploads are aborted on this server.
+func (s *adminCmd) SetMultipartLifetime(args *MultipartLifetimeArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalMultipartLifetime.set(args.Lifetime))
+}
+
+// VersionClock - returns version and clock of this server.
+func (s *adminCmd) VersionClock(args *AuthRPCArgs, reply *VersionClockReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Info = getLocalVersionClock()
+	return nil
+}
+
+// SetConditionalWrites - enables or disables conditional writes on
+// this server.
+func (s *adminCmd) SetConditionalWrites(args *ConditionalWritesArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	globalConditionalWrites.set(args.Enabled)
+	return globalConfigChangeLog.logApplied(args.Change, nil)
+}
+
+// DisksByState - returns the disks of this server in the given state.
+func (s *adminCmd) DisksByState(args *DiskStateArgs, reply *DisksByStateReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	disks, err := getLocalDisksByState(args.State)
+	if err != nil {
+		return err
+	}
+	reply.Disks = disks
+	return nil
+}
+
+// ClusterEvents - returns the events of this server after args.Since.
+func (s *adminCmd) ClusterEvents(args *ClusterEventsArgs, reply *ClusterEventsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Batch = globalClusterEvents.since(args.Since)
+	return nil
+}
+
+// SetBucketTags - updates in-memory tags of a bucket on this server.
+func (s *adminCmd) SetBucketTags(args *BucketTagsArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	if err := validateBucketTags(args.Tags); err != nil {
+		return err
+	}
+	globalBucketTags.set(args.Bucket, args.Tags)
+	return globalConfigChangeLog.logApplied(args.Change, nil)
+}
+
+// SetAllowedSignatureVersions - sets the signature versions accepted
+// by this server.
+func (s *adminCmd) SetAllowedSignatureVersions(args *SignatureVersionsArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalAllowedSignatures.set(args.Versions))
+}
+
+// RPCConnStats - returns usage of the RPC clients of this server.
+func (s *adminCmd) RPCConnStats(args *AuthRPCArgs, reply *RPCConnStatsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Conns = globalRPCClients.stats()
+	return nil
+}
+
+// ForceHealBucket - starts healing the part of a bucket assigned to
+// this server.
+func (s *adminCmd) ForceHealBucket(args *ForceHealBucketArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return startHealJob(globalHealJobs, args.ID, args.Bucket, args.Part, args.Parts)
+}
+
+// HealJobs - returns the heal jobs run by this server.
+func (s *adminCmd) HealJobs(args *AuthRPCArgs, reply *HealJobsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Jobs = globalHealJobs.list()
+	return nil
+}
+
+// SetImmutabilityWindow - updates in-memory immutability window of a
+// bucket on this server.
+func (s *adminCmd) SetImmutabilityWindow(args *ImmutabilityWindowArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalImmutabilityWindows.set(args.Bucket, args.Window))
+}
+
+// ActiveMultipartBytes - returns the bytes held by in-progress
+// multipart uploads on the disks of this server.
+func (s *adminCmd) ActiveMultipartBytes(args *AuthRPCArgs, reply *ActiveMultipartBytesReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Bytes = getLocalMultipartBytes(globalEndpoints)
+	return nil
+}
+
+// LockHoldHistogram - returns how long locks taken by this server were
+// and are held.
+func (s *adminCmd) LockHoldHistogram(args *AuthRPCArgs, reply *LockHoldHistogramReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Histograms = getLocalLockHoldHistograms()
+	return nil
+}
+
+// ListParts - returns all parts of a multipart upload as seen by this
+// server.
+func (s *adminCmd) ListParts(args *ListPartsArgs, reply *ListPartsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	parts, err := listLocalUploadParts(args.Bucket, args.Object, args.UploadID)
+	if _, ok := err.(InvalidUploadID); ok {
+		reply.UploadNotFound = true
+		return nil
+	}
+	reply.Parts = parts
+	return err
+}
+
+// Backend - returns the backend of this server.
+func (s *adminCmd) Backend(args *AuthRPCArgs, reply *BackendReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	info, err := getLocalBackendInfo()
+	reply.Info = info
+	return err
+}
+
+// RecalculateUsage - starts counting the part of a bucket assigned to
+// this server.
+func (s *adminCmd) RecalculateUsage(args *RecalculateUsageArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return startUsageScan(globalUsageScans, args.ID, args.Bucket, args.Part, args.Parts)
+}
+
+// ScanProgress - returns the usage scans run by this server.
+func (s *adminCmd) ScanProgress(args *AuthRPCArgs, reply *ScanProgressReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Scans = globalUsageScans.list()
+	return nil
+}
+
+// SetTLSCiphers - sets the TLS cipher suites offered by this server.
+func (s *adminCmd) SetTLSCiphers(args *TLSCiphersArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, setLocalTLSCiphers(args.Suites))
+}
+
+// WriteAmplification - returns the write amplification of this server.
+func (s *adminCmd) WriteAmplification(args *AuthRPCArgs, reply *WriteAmplificationReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.WriteAmp = globalWriteAmp.get(time.Now().UTC())
+	return nil
+}
+
+// SetMaxUploadParts - sets the limit of parts per upload of this
+// server.
+func (s *adminCmd) SetMaxUploadParts(args *MaxUploadPartsArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, setMaxUploadParts(args.Parts))
+}
+
+// BucketChecksum - returns the checksum of a bucket as seen by this
+// server.
+func (s *adminCmd) BucketChecksum(args *BucketChecksumArgs, reply *BucketChecksumReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	checksum, err := getLocalBucketChecksum(args.Bucket)
+	if err != nil {
+		return err
+	}
+	reply.Checksum = checksum
+	return nil
+}
+
+// SetRequestIDConfig - sets the request ID header of this server.
+func (s *adminCmd) SetRequestIDConfig(args *RequestIDConfigArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalRequestIDConfig.set(args.Header, args.HonorClientID))
+}
+
+// HealHistory - returns the last heal jobs completed by this server.
+func (s *adminCmd) HealHistory(args *HealHistoryArgs, reply *HealHistoryReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Records = globalHealJobs.history.last(args.N)
+	return nil
+}
+
+// RPCErrors - returns the errors of admin RPC calls of this server to
+// its peers.
+func (s *adminCmd) RPCErrors(args *AuthRPCArgs, reply *RPCErrorsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Peers = globalRPCErrors.get(time.Now().UTC())
+	return nil
+}
+
+// SetBucketObjectLimit - updates in-memory object limit of a bucket on
+// this server.
+func (s *adminCmd) SetBucketObjectLimit(args *BucketObjectLimitArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalBucketObjectLimits.set(args.Bucket, args.MaxObjects))
+}
+
+// ScannerImpact - returns the estimated impact of background scans on
+// the latency of requests served by this server.
+func (s *adminCmd) ScannerImpact(args *AuthRPCArgs, reply *ScannerImpactReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Impact = globalScannerLatency.estimate()
+	return nil
+}
+
+// SetTrustedProxies - sets the proxies whose forwarded headers are
+// honored by this server.
+func (s *adminCmd) SetTrustedProxies(args *TrustedProxiesArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalTrustedProxies.set(args.CIDRs))
+}
+
+// SetBucketLogging - updates in-memory logging configuration of a
+// bucket on this server.
+func (s *adminCmd) SetBucketLogging(args *BucketLoggingArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalBucketLogging.set(args.Bucket, BucketLogging{TargetBucket: args.TargetBucket, TargetPrefix: args.TargetPrefix}))
+}
+
+// CredentialUsage - returns the last use of the access keys valid on
+// this server.
+func (s *adminCmd) CredentialUsage(args *AuthRPCArgs, reply *CredentialUsageReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Usages = serverCredentialUsage()
+	return nil
+}
+
+// SetMaxConcurrentHeals - sets the cap of object heals running at once
+// on this server.
+func (s *adminCmd) SetMaxConcurrentHeals(args *MaxConcurrentHealsArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalHealLimiter.set(args.Max))
+}
+
+// SetShutdownTimeout - sets the shutdown timeout of this server.
+func (s *adminCmd) SetShutdownTimeout(args *ShutdownTimeoutArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, setShutdownTimeout(args.Timeout))
+}
+
+// SetChecksumPolicy - sets the object checksum policy of this server.
+func (s *adminCmd) SetChecksumPolicy(args *ChecksumPolicyArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalChecksumPolicy.set(args.Required, args.Algorithms))
+}
+
+// Summary - returns the summary of this server.
+func (s *adminCmd) Summary(args *AuthRPCArgs, reply *ServerSummaryReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	summary, err := getLocalServerSummary()
+	if err != nil {
+		return err
+	}
+	reply.Summary = summary
+	return nil
+}
+
+// DiskFill - returns the space used on the disks of this server.
+func (s *adminCmd) DiskFill(args *AuthRPCArgs, reply *DiskFillReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	disks, err := getLocalDiskFill()
+	if err != nil {
+		return err
+	}
+	reply.Disks = disks
+	return nil
+}
+
+// SetNotificationRetry - sets the notification retry policy of this
+// server.
+func (s *adminCmd) SetNotificationRetry(args *NotificationRetryArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalNotificationRetry.set(NotificationRetryPolicy{
+		MaxRetries:       args.MaxRetries,
+		Backoff:          args.Backoff,
+		DeadLetterTarget: args.DeadLetterTarget,
+	}))
+}
+
+// SetMaxListDepth - sets the maximum list depth of this server.
+func (s *adminCmd) SetMaxListDepth(args *MaxListDepthArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, setMaxListDepth(args.Depth))
+}
+
+// StartupErrors - returns the errors this server started in spite of.
+func (s *adminCmd) StartupErrors(args *AuthRPCArgs, reply *StartupErrorsReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Errors = globalBootDiagnostics.startupErrors()
+	return nil
+}
+
+// MemSummary - returns the memory summary of this server.
+func (s *adminCmd) MemSummary(args *AuthRPCArgs, reply *MemSummaryReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Stats = newMemStats(runtimeMemSource{})
+	return nil
+}
+
+// SetBucketPartSizeLimits - sets the part size limits of a bucket on
+// this server.
+func (s *adminCmd) SetBucketPartSizeLimits(args *BucketPartSizeLimitsArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalBucketPartSizeLimits.set(args.Bucket, PartSizeLimits{MinPartSize: args.MinPart, MaxPartSize: args.MaxPart}))
+}
+
+// SetDeleteProtectionTag - updates in-memory delete protection tag of
+// a bucket on this server.
+func (s *adminCmd) SetDeleteProtectionTag(args *DeleteProtectionTagArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, globalDeleteProtectionTags.set(args.Bucket, DeleteProtectionTag{Key: args.TagKey, Value: args.TagValue}))
+}
+
+// ConfigChangeLog - returns the last config changes logged by this
+// server, latest first.
+func (s *adminCmd) ConfigChangeLog(args *ConfigChangeLogArgs, reply *ConfigChangeLogReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	reply.Changes = globalConfigChangeLog.last(args.Count)
+	return nil
+}
+
+// SetScanOnWrite - enables or disables verification of writes on this
+// server.
+func (s *adminCmd) SetScanOnWrite(args *ScanOnWriteArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	globalScanOnWrite.set(args.Enabled)
+	return globalConfigChangeLog.logApplied(args.Change, nil)
+}
+
+// SetCredentialExpiry - sets the expiry of an access key on this
+// server.
+func (s *adminCmd) SetCredentialExpiry(args *CredentialExpiryArgs, reply *AuthRPCReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	return globalConfigChangeLog.logApplied(args.Change, setCredentialExpiry(args.AccessKey, args.Expiry, args.SentAt))
+}
+
+// ObjectDistribution - returns the objects of a bucket stored on the
+// erasure sets of the disks of this server.
+func (s *adminCmd) ObjectDistribution(args *ObjectDistributionArgs, reply *ObjectDistributionReply) error {
+	if err := args.IsAuthenticated(); err != nil {
+		return err
+	}
+
+	sets, err := getLocalObjectDistribution(args.Bucket)
+	if err != nil {
+		return err
+	}
+	reply.Sets = sets
+	return nil
+}
+
 // registerAdminRPCRouter - registers RPC methods for service status,
 // stop and restart commands.
 func registerAdminRPCRouter(mux *router.Router) error {
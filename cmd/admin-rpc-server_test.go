@@ -60,6 +60,10 @@ func testAdminCmd(cmd cmdType, t *testing.T) {
 		if err = adminServer.Restart(&ga, &genReply); err != nil {
 			t.Errorf("restartCmd: Expected: <nil>, got: %v", err)
 		}
+	case stopCmd:
+		if err = adminServer.Stop(&ga, &genReply); err != nil {
+			t.Errorf("stopCmd: Expected: <nil>, got: %v", err)
+		}
 	}
 }
 
@@ -68,6 +72,11 @@ func TestAdminRestart(t *testing.T) {
 	testAdminCmd(restartCmd, t)
 }
 
+// TestAdminStop - test for Admin.Stop RPC service.
+func TestAdminStop(t *testing.T) {
+	testAdminCmd(stopCmd, t)
+}
+
 // TestReInitDisks - test for Admin.ReInitDisks RPC service.
 func TestReInitDisks(t *testing.T) {
 	// Reset global variables to start afresh.
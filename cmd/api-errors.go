@@ -103,6 +103,7 @@ const (
 	ErrMalformedCredentialRegion
 	ErrMalformedExpires
 	ErrNegativeExpires
+	ErrMaximumExpires
 	ErrAuthHeaderEmpty
 	ErrExpiredPresignRequest
 	ErrRequestNotReadyYet
@@ -137,6 +138,7 @@ const (
 	ErrObjectExistsAsDirectory
 	ErrPolicyNesting
 	ErrInvalidObjectName
+	ErrObjectNameNotAllowed
 	ErrServerNotInitialized
 	// Add new extended error codes here.
 	// Please open a https://github.com/minio/minio/issues before adding
@@ -144,6 +146,46 @@ const (
 
 	ErrAdminInvalidAccessKey
 	ErrAdminInvalidSecretKey
+	ErrAdminInvalidHealDeleteMode
+	ErrAdminInvalidKeyPolicy
+	ErrAdminInvalidDiskState
+	ErrAdminInvalidBucketTags
+	ErrAdminInvalidSignatureVersions
+	ErrAdminInvalidQuorumMode
+	ErrAdminQuorumTimeout
+	ErrAdminNoSuchHealJob
+	ErrObjectImmutable
+	ErrAdminNoSuchUsageScan
+	ErrAdminInvalidTLSCiphers
+	ErrTooManyParts
+	ErrAdminInvalidRequestIDHeader
+	ErrAdminInvalidObjectLimit
+	ErrBucketObjectLimitExceeded
+	ErrAdminInvalidTrustedProxies
+	ErrAdminInvalidBucketLogging
+	ErrAdminInvalidMaxConcurrentHeals
+	ErrAdminInvalidPolicyEvaluation
+	ErrAdminInvalidChecksumPolicy
+	ErrMissingChecksum
+	ErrInvalidChecksum
+	ErrChecksumMismatch
+	ErrAdminInvalidNotificationRetry
+	ErrAdminInvalidMaxListDepth
+	ErrAdminInvalidPartSizeLimits
+	ErrAdminInvalidDeleteProtection
+	ErrObjectDeleteProtected
+	ErrWriteVerification
+	ErrAccessKeyExpired
+	ErrAdminInvalidMultiDeleteLimit
+	ErrTooManyDeleteObjects
+	ErrAdminInvalidUploadLimit
+	ErrTooManyUploads
+	ErrAdminInvalidMaintenanceSchedule
+	ErrMaintenanceReadOnly
+	ErrAdminInvalidQuorumOverride
+	ErrAdminInvalidConfig
+	ErrAdminCredentialLockout
+	ErrAdminConfigCredentialChanged
 )
 
 // error code to APIError structure, these fields carry respective
@@ -446,6 +488,11 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "X-Amz-Expires must be non-negative",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrMaximumExpires: {
+		Code:           "AuthorizationQueryParametersError",
+		Description:    "X-Amz-Expires must be less than the maximum presigned URL expiry configured on the server",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrAuthHeaderEmpty: {
 		Code:           "InvalidArgument",
 		Description:    "Authorization header is invalid -- one and only one ' ' (space) required.",
@@ -578,6 +625,11 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "Object name contains unsupported characters. Unsupported characters are `^*|\\\"",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrObjectNameNotAllowed: {
+		Code:           "XMinioObjectNameNotAllowed",
+		Description:    "Object name is not allowed by the object naming policy of the server.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrServerNotInitialized: {
 		Code:           "XMinioServerNotInitialized",
 		Description:    "Server not initialized, please try again.",
@@ -593,6 +645,206 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "The secret key is invalid.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrAdminInvalidHealDeleteMode: {
+		Code:           "XMinioAdminInvalidHealDeleteMode",
+		Description:    "The heal delete mode must be one of report-only or delete.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidKeyPolicy: {
+		Code:           "XMinioAdminInvalidKeyPolicy",
+		Description:    "The object naming policy is malformed or invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidDiskState: {
+		Code:           "XMinioAdminInvalidDiskState",
+		Description:    "The disk state must be one of online, offline, degraded or foreign.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidBucketTags: {
+		Code:           "XMinioAdminInvalidBucketTags",
+		Description:    "The bucket tags are malformed or exceed the allowed limits.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidSignatureVersions: {
+		Code:           "XMinioAdminInvalidSignatureVersions",
+		Description:    "The allowed signature versions must be a non empty list of v2 and v4.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidQuorumMode: {
+		Code:           "XMinioAdminInvalidQuorumMode",
+		Description:    "The quorum mode must be one of read or write.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminQuorumTimeout: {
+		Code:           "XMinioAdminQuorumTimeout",
+		Description:    "Quorum was not established before the timeout.",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	ErrAdminNoSuchHealJob: {
+		Code:           "XMinioAdminNoSuchHealJob",
+		Description:    "The specified heal job does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrObjectImmutable: {
+		Code:           "XMinioObjectImmutable",
+		Description:    "Object can not be deleted or overwritten within the immutability window of its bucket.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAdminNoSuchUsageScan: {
+		Code:           "XMinioAdminNoSuchUsageScan",
+		Description:    "The specified usage scan does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminInvalidTLSCiphers: {
+		Code:           "XMinioAdminInvalidTLSCiphers",
+		Description:    "TLS cipher suites must be supported, with at least one strong suite.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrTooManyParts: {
+		Code:           "InvalidArgument",
+		Description:    "The number of parts exceeds the maximum allowed for a multipart upload.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidRequestIDHeader: {
+		Code:           "XMinioAdminInvalidRequestIDHeader",
+		Description:    "Request ID header must be a valid header name not used by the server.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidObjectLimit: {
+		Code:           "XMinioAdminInvalidObjectLimit",
+		Description:    "Object limit must be a non negative number of objects.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrBucketObjectLimitExceeded: {
+		Code:           "XMinioBucketObjectLimitExceeded",
+		Description:    "Bucket holds the maximum number of objects allowed, only existing objects can be overwritten.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAdminInvalidTrustedProxies: {
+		Code:           "XMinioAdminInvalidTrustedProxies",
+		Description:    "Trusted proxies must be CIDRs or IP addresses.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidBucketLogging: {
+		Code:           "XMinioAdminInvalidBucketLogging",
+		Description:    "Bucket access must be logged into another bucket, under a valid object prefix.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidMaxConcurrentHeals: {
+		Code:           "XMinioAdminInvalidMaxConcurrentHeals",
+		Description:    "Maximum concurrent heals must be a non negative number, 0 means no limit.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidPolicyEvaluation: {
+		Code:           "XMinioAdminInvalidPolicyEvaluation",
+		Description:    "Policies can only be evaluated for principal * or the server access key, a supported action and a bucket resource.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidChecksumPolicy: {
+		Code:           "XMinioAdminInvalidChecksumPolicy",
+		Description:    "Checksum policy must name one or more of the CRC32, CRC32C, SHA1 and SHA256 algorithms.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrMissingChecksum: {
+		Code:           "InvalidRequest",
+		Description:    "Uploads must send a x-amz-checksum header of a supported algorithm.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidChecksum: {
+		Code:           "InvalidRequest",
+		Description:    "Value for x-amz-checksum header is not a base64 encoded digest of its algorithm.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrChecksumMismatch: {
+		Code:           "BadDigest",
+		Description:    "The x-amz-checksum you specified did not match the checksum of the received data.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidNotificationRetry: {
+		Code:           "XMinioAdminInvalidNotificationRetry",
+		Description:    "Notification retry policy needs non-negative retries and backoff, and an enabled dead-letter target.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidMaxListDepth: {
+		Code:           "XMinioAdminInvalidMaxListDepth",
+		Description:    "Maximum list depth must be a non negative number, 0 means no limit.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidPartSizeLimits: {
+		Code:           "XMinioAdminInvalidPartSizeLimits",
+		Description:    "Part size limits must be within 5MiB and 5GiB, the minimum not above the maximum.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidDeleteProtection: {
+		Code:           "XMinioAdminInvalidDeleteProtection",
+		Description:    "Delete protection tag needs a key valid as a header name.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrObjectDeleteProtected: {
+		Code:           "XMinioObjectDeleteProtected",
+		Description:    "Object carries the delete protection tag of its bucket and can not be deleted.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrWriteVerification: {
+		Code:           "XMinioWriteVerificationFailed",
+		Description:    "The object written failed integrity verification and was not stored, please retry.",
+		HTTPStatusCode: http.StatusInternalServerError,
+	},
+	ErrAccessKeyExpired: {
+		Code:           "XMinioAccessKeyExpired",
+		Description:    "The access key you provided has expired.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAdminInvalidMultiDeleteLimit: {
+		Code:           "XMinioAdminInvalidMultiDeleteLimit",
+		Description:    "Multi-delete limit must be within 1 and 1000 keys.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrTooManyDeleteObjects: {
+		Code:           "XMinioTooManyDeleteObjects",
+		Description:    "The request has more keys to delete than the server allows, please send fewer keys per request.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidUploadLimit: {
+		Code:           "XMinioAdminInvalidUploadLimit",
+		Description:    "Concurrent upload limit must not be negative.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrTooManyUploads: {
+		Code:           "XMinioTooManyUploads",
+		Description:    "The bucket has as many multipart uploads in progress as allowed, please retry later.",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	ErrAdminInvalidMaintenanceSchedule: {
+		Code:           "XMinioAdminInvalidMaintenanceSchedule",
+		Description:    "Maintenance schedule is malformed or has overlapping windows.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrMaintenanceReadOnly: {
+		Code:           "XMinioMaintenanceReadOnly",
+		Description:    "The server is in a read-only maintenance window, please retry later.",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	ErrAdminInvalidQuorumOverride: {
+		Code:           "XMinioAdminInvalidQuorumOverride",
+		Description:    "Quorum override must be within the number of data drives and of all drives, and last at most 24 hours.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidConfig: {
+		Code:           "XMinioAdminInvalidConfig",
+		Description:    "config.json is malformed, of another version or has invalid credentials.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminCredentialLockout: {
+		Code:           "XMinioAdminCredentialLockout",
+		Description:    "The server credential is the only access key, admin requests are rejected too once it expires. Set confirm=true to expire it anyway.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminConfigCredentialChanged: {
+		Code:           "XMinioAdminConfigCredentialChanged",
+		Description:    "config.json can not change the credentials of the server.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	// Add your error structure here.
 }
@@ -620,6 +872,72 @@ func toAPIErrorCode(err error) (apiErr APIErrorCode) {
 		apiErr = ErrAdminInvalidAccessKey
 	case errInvalidSecretKeyLength:
 		apiErr = ErrAdminInvalidSecretKey
+	case errInvalidDiskState:
+		apiErr = ErrAdminInvalidDiskState
+	case errInvalidBucketTags:
+		apiErr = ErrAdminInvalidBucketTags
+	case errInvalidSignatureVersions:
+		apiErr = ErrAdminInvalidSignatureVersions
+	case errInvalidQuorumMode:
+		apiErr = ErrAdminInvalidQuorumMode
+	case errNoSuchHealJob:
+		apiErr = ErrAdminNoSuchHealJob
+	case errInvalidImmutabilityWindow:
+		apiErr = ErrInvalidDuration
+	case errObjectImmutable:
+		apiErr = ErrObjectImmutable
+	case errNoSuchUsageScan:
+		apiErr = ErrAdminNoSuchUsageScan
+	case errInvalidTLSCiphers, errWeakTLSCiphers:
+		apiErr = ErrAdminInvalidTLSCiphers
+	case errTooManyParts:
+		apiErr = ErrTooManyParts
+	case errInvalidRequestIDHeader:
+		apiErr = ErrAdminInvalidRequestIDHeader
+	case errInvalidObjectLimit:
+		apiErr = ErrAdminInvalidObjectLimit
+	case errBucketObjectLimit:
+		apiErr = ErrBucketObjectLimitExceeded
+	case errInvalidTrustedProxies:
+		apiErr = ErrAdminInvalidTrustedProxies
+	case errBucketLoggingToSelf, errInvalidBucketLoggingPrefix:
+		apiErr = ErrAdminInvalidBucketLogging
+	case errInvalidPolicyEvaluation:
+		apiErr = ErrAdminInvalidPolicyEvaluation
+	case errInvalidChecksumPolicy:
+		apiErr = ErrAdminInvalidChecksumPolicy
+	case errMissingChecksum:
+		apiErr = ErrMissingChecksum
+	case errInvalidChecksum:
+		apiErr = ErrInvalidChecksum
+	case errChecksumMismatch:
+		apiErr = ErrChecksumMismatch
+	case errInvalidNotificationRetry:
+		apiErr = ErrAdminInvalidNotificationRetry
+	case errInvalidPartSizeLimits:
+		apiErr = ErrAdminInvalidPartSizeLimits
+	case errInvalidDeleteProtection:
+		apiErr = ErrAdminInvalidDeleteProtection
+	case errObjectDeleteProtected:
+		apiErr = ErrObjectDeleteProtected
+	case errWriteVerification:
+		apiErr = ErrWriteVerification
+	case errInvalidAccessKeyID:
+		apiErr = ErrInvalidAccessKeyID
+	case errCredentialExpired:
+		apiErr = ErrAccessKeyExpired
+	case errInvalidUploadLimit:
+		apiErr = ErrAdminInvalidUploadLimit
+	case errTooManyUploads:
+		apiErr = ErrTooManyUploads
+	case errInvalidMaintenanceSchedule:
+		apiErr = ErrAdminInvalidMaintenanceSchedule
+	case errInvalidQuorumOverride:
+		apiErr = ErrAdminInvalidQuorumOverride
+	case errInvalidConfig:
+		apiErr = ErrAdminInvalidConfig
+	case errConfigCredentialChanged:
+		apiErr = ErrAdminConfigCredentialChanged
 	}
 
 	if apiErr != ErrNone {
@@ -650,6 +968,8 @@ func toAPIErrorCode(err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchKey
 	case ObjectNameInvalid:
 		apiErr = ErrInvalidObjectName
+	case ObjectNameNotAllowed:
+		apiErr = ErrObjectNameNotAllowed
 	case InvalidUploadID:
 		apiErr = ErrNoSuchUpload
 	case InvalidPart:
@@ -33,8 +33,12 @@ func mustGetRequestID(t time.Time) string {
 
 // Write http common headers
 func setCommonHeaders(w http.ResponseWriter) {
-	// Set unique request ID for each reply.
-	w.Header().Set(responseRequestIDKey, mustGetRequestID(time.Now().UTC()))
+	// Set unique request ID for each reply, unless already set when
+	// the request came in.
+	header := globalRequestIDConfig.get().Header
+	if w.Header().Get(header) == "" {
+		w.Header().Set(header, mustGetRequestID(time.Now().UTC()))
+	}
 	w.Header().Set("Server", globalServerUserAgent)
 	w.Header().Set("Accept-Ranges", "bytes")
 }
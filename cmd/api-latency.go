@@ -0,0 +1,190 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Upper bounds of latency histogram buckets, growing by 25% from
+// 100 microseconds up to a few minutes. Every server uses the same
+// bounds so that histograms can be merged by adding up counts.
+var latencyBuckets = func() []time.Duration {
+	var buckets []time.Duration
+	for bound := 100 * time.Microsecond; bound < 5*time.Minute; bound = bound * 5 / 4 {
+		buckets = append(buckets, bound)
+	}
+	return buckets
+}()
+
+// A percentile is only reported when at least this many samples are
+// at or above it, otherwise it would be decided by a handful of
+// requests.
+const minLatencyTailSamples = 10
+
+// LatencyHistogram - count of requests per latency bucket. Counts[i]
+// is the number of requests which took at most latencyBuckets[i],
+// the last count is of requests which took longer than all bounds.
+type LatencyHistogram struct {
+	Counts []uint64
+}
+
+// newLatencyHistogram - returns an empty histogram.
+func newLatencyHistogram() LatencyHistogram {
+	return LatencyHistogram{Counts: make([]uint64, len(latencyBuckets)+1)}
+}
+
+// add - records a request which took latency.
+func (h LatencyHistogram) add(latency time.Duration) {
+	i := 0
+	for ; i < len(latencyBuckets); i++ {
+		if latency <= latencyBuckets[i] {
+			break
+		}
+	}
+	h.Counts[i]++
+}
+
+// merge - adds counts of other to h.
+func (h LatencyHistogram) merge(other LatencyHistogram) {
+	for i := range h.Counts {
+		if i < len(other.Counts) {
+			h.Counts[i] += other.Counts[i]
+		}
+	}
+}
+
+// samples - returns the total number of requests recorded.
+func (h LatencyHistogram) samples() (total uint64) {
+	for _, count := range h.Counts {
+		total += count
+	}
+	return total
+}
+
+// percentile - returns the latency under which q of the requests
+// completed. Since only bucket counts are kept the bucket's upper
+// bound is returned, overestimating by at most 25%.
+func (h LatencyHistogram) percentile(q float64) LatencyPercentile {
+	total := h.samples()
+	// Number of samples at or above the percentile, the small
+	// addend counters floating point error in 1-q.
+	tail := uint64(float64(total)*(1-q) + 1e-6)
+	if tail < minLatencyTailSamples {
+		return LatencyPercentile{InsufficientData: true}
+	}
+
+	var count uint64
+	for i, c := range h.Counts {
+		count += c
+		if float64(count) >= q*float64(total) {
+			if i == len(latencyBuckets) {
+				// Slower than all the bounds, nothing better
+				// to report than the largest bound.
+				i--
+			}
+			return LatencyPercentile{Latency: latencyBuckets[i]}
+		}
+	}
+	return LatencyPercentile{Latency: latencyBuckets[len(latencyBuckets)-1]}
+}
+
+// LatencyPercentile - a latency percentile, when there are too few
+// requests to compute it InsufficientData is set instead.
+type LatencyPercentile struct {
+	Latency          time.Duration `json:"latency"`
+	InsufficientData bool          `json:"insufficientData"`
+}
+
+// LatencyPercentiles - latency percentiles of an API operation.
+type LatencyPercentiles struct {
+	Samples uint64            `json:"samples"`
+	P50     LatencyPercentile `json:"p50"`
+	P90     LatencyPercentile `json:"p90"`
+	P99     LatencyPercentile `json:"p99"`
+	P999    LatencyPercentile `json:"p999"`
+}
+
+// newLatencyPercentiles - computes latency percentiles out of h.
+func newLatencyPercentiles(h LatencyHistogram) LatencyPercentiles {
+	return LatencyPercentiles{
+		Samples: h.samples(),
+		P50:     h.percentile(0.5),
+		P90:     h.percentile(0.9),
+		P99:     h.percentile(0.99),
+		P999:    h.percentile(0.999),
+	}
+}
+
+// apiLatency - latency histograms of requests served by this server,
+// per S3 API operation.
+type apiLatency struct {
+	mutex      sync.Mutex
+	histograms map[string]LatencyHistogram
+}
+
+var globalAPILatency = &apiLatency{histograms: make(map[string]LatencyHistogram)}
+
+// record - records a request to api which took latency.
+func (l *apiLatency) record(api string, latency time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	h, ok := l.histograms[api]
+	if !ok {
+		h = newLatencyHistogram()
+		l.histograms[api] = h
+	}
+	h.add(latency)
+}
+
+// snapshot - returns a copy of all histograms.
+func (l *apiLatency) snapshot() map[string]LatencyHistogram {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	histograms := make(map[string]LatencyHistogram)
+	for api, h := range l.histograms {
+		c := newLatencyHistogram()
+		c.merge(h)
+		histograms[api] = c
+	}
+	return histograms
+}
+
+// timedAPIHandler - records the latency of every request served by
+// f under the name of the API operation, and whether a background scan
+// was running when the request came in. Slow requests are logged along
+// with the time spent in each phase. Requests to buckets with logging
+// enabled are logged too, and the activity of every client is tracked.
+func timedAPIHandler(api string, f http.HandlerFunc) http.HandlerFunc {
+	f = clientConnHandler(accessLoggedHandler(api, f))
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		scanning := globalScannerActivity.active()
+		r, times := withRequestPhaseTimes(r)
+		f(w, r)
+		latency := time.Since(start)
+		globalAPILatency.record(api, latency)
+		globalScannerLatency.record(scanning, latency)
+		if latency >= slowRequestMinThreshold {
+			globalSlowRequests.record(newSlowRequest(api, r, start, latency, times))
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// latencyBound - returns the upper bound of the histogram bucket
+// latency falls in.
+func latencyBound(latency time.Duration) LatencyPercentile {
+	for _, bound := range latencyBuckets {
+		if latency <= bound {
+			return LatencyPercentile{Latency: bound}
+		}
+	}
+	return LatencyPercentile{Latency: latencyBuckets[len(latencyBuckets)-1]}
+}
+
+// newTestLatencyHistogram - returns a histogram with count samples
+// of every latency in samples.
+func newTestLatencyHistogram(samples map[time.Duration]uint64) LatencyHistogram {
+	h := newLatencyHistogram()
+	for latency, count := range samples {
+		for i := uint64(0); i < count; i++ {
+			h.add(latency)
+		}
+	}
+	return h
+}
+
+// TestLatencyPercentiles - tests percentile computation out of
+// latency histograms.
+func TestLatencyPercentiles(t *testing.T) {
+	insufficient := LatencyPercentile{InsufficientData: true}
+
+	testCases := []struct {
+		samples  map[time.Duration]uint64
+		expected LatencyPercentiles
+	}{
+		// Test 1: no samples.
+		{
+			samples: nil,
+			expected: LatencyPercentiles{
+				P50: insufficient, P90: insufficient, P99: insufficient, P999: insufficient,
+			},
+		},
+		// Test 2: too few samples for any percentile.
+		{
+			samples: map[time.Duration]uint64{time.Millisecond: 19},
+			expected: LatencyPercentiles{
+				Samples: 19,
+				P50:     insufficient, P90: insufficient, P99: insufficient, P999: insufficient,
+			},
+		},
+		// Test 3: enough samples up to p99.
+		{
+			samples: map[time.Duration]uint64{
+				time.Millisecond:       900,
+				10 * time.Millisecond:  90,
+				100 * time.Millisecond: 10,
+			},
+			expected: LatencyPercentiles{
+				Samples: 1000,
+				P50:     latencyBound(time.Millisecond),
+				P90:     latencyBound(time.Millisecond),
+				P99:     latencyBound(10 * time.Millisecond),
+				P999:    insufficient,
+			},
+		},
+		// Test 4: requests slower than the largest bucket.
+		{
+			samples: map[time.Duration]uint64{time.Hour: 100},
+			expected: LatencyPercentiles{
+				Samples: 100,
+				P50:     latencyBound(time.Hour),
+				P90:     latencyBound(time.Hour),
+				P99:     insufficient,
+				P999:    insufficient,
+			},
+		},
+	}
+
+	for i, test := range testCases {
+		percentiles := newLatencyPercentiles(newTestLatencyHistogram(test.samples))
+		if !reflect.DeepEqual(percentiles, test.expected) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, test.expected, percentiles)
+		}
+	}
+}
+
+// TestTimedAPIHandler - tests that latency of requests is recorded
+// under the API operation.
+func TestTimedAPIHandler(t *testing.T) {
+	defer resetGlobalAPILatency()
+
+	handler := timedAPIHandler("GetObject", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	for i := 0; i < 3; i++ {
+		handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/bucket/object", nil))
+	}
+
+	histograms := globalAPILatency.snapshot()
+	if len(histograms) != 1 {
+		t.Fatalf("Expected histogram of a single operation, got %d", len(histograms))
+	}
+	if samples := histograms["GetObject"].samples(); samples != 3 {
+		t.Fatalf("Expected 3 samples, got %d", samples)
+	}
+}
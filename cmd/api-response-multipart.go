@@ -23,7 +23,7 @@ type completeMultipartAPIError struct {
 	// Proposed size represents uploaded size of the part.
 	ProposedSize int64
 	// Minimum size allowed epresents the minimum size allowed per
-	// part. Defaults to 5MB.
+	// part. Defaults to 5MB, buckets may set a larger one.
 	MinSizeAllowed int64
 	// Part number of the part which is incorrect.
 	PartNumber int
@@ -35,17 +35,18 @@ type completeMultipartAPIError struct {
 
 // writeErrorResponsePartTooSmall - function is used specifically to
 // construct a proper error response during CompleteMultipartUpload
-// when one of the parts is < 5MB.
+// when one of the parts is below the minimum part size of bucket.
 // The requirement comes due to the fact that generic ErrorResponse
 // XML doesn't carry the additional fields required to send this
 // error. So we construct a new type which lies well within the scope
 // of this function.
-func writePartSmallErrorResponse(w http.ResponseWriter, r *http.Request, err PartTooSmall) {
+func writePartSmallErrorResponse(w http.ResponseWriter, r *http.Request, bucket string, err PartTooSmall) {
 
 	apiError := getAPIError(toAPIErrorCode(err))
 	// Generate complete multipart error response.
 	errorResponse := getAPIErrorResponse(apiError, r.URL.Path)
-	cmpErrResp := completeMultipartAPIError{err.PartSize, int64(5242880), err.PartNumber, err.PartETag, errorResponse}
+	minPartSize := globalBucketPartSizeLimits.get(bucket).MinPartSize
+	cmpErrResp := completeMultipartAPIError{err.PartSize, minPartSize, err.PartNumber, err.PartETag, errorResponse}
 	encodedErrorResponse := encodeResponse(cmpErrResp)
 
 	// respond with 400 bad request.
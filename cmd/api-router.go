@@ -36,66 +36,70 @@ func registerAPIRouter(mux *router.Router) {
 	// Bucket router
 	bucket := apiRouter.PathPrefix("/{bucket}").Subrouter()
 
+	// Latency of all operations except ListenBucketNotification, which
+	// is held open for as long as the client listens, is recorded by
+	// timedAPIHandler.
+
 	/// Object operations
 
 	// HeadObject
-	bucket.Methods("HEAD").Path("/{object:.+}").HandlerFunc(api.HeadObjectHandler)
+	bucket.Methods("HEAD").Path("/{object:.+}").HandlerFunc(timedAPIHandler("HeadObject", api.HeadObjectHandler))
 	// CopyObjectPart
-	bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(api.CopyObjectPartHandler).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
+	bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(timedAPIHandler("CopyObjectPart", api.CopyObjectPartHandler)).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
 	// PutObjectPart
-	bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectPartHandler).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
+	bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(timedAPIHandler("PutObjectPart", api.PutObjectPartHandler)).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
 	// ListObjectPxarts
-	bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.ListObjectPartsHandler).Queries("uploadId", "{uploadId:.*}")
+	bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(timedAPIHandler("ListObjectParts", api.ListObjectPartsHandler)).Queries("uploadId", "{uploadId:.*}")
 	// CompleteMultipartUpload
-	bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(api.CompleteMultipartUploadHandler).Queries("uploadId", "{uploadId:.*}")
+	bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(timedAPIHandler("CompleteMultipartUpload", api.CompleteMultipartUploadHandler)).Queries("uploadId", "{uploadId:.*}")
 	// NewMultipartUpload
-	bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(api.NewMultipartUploadHandler).Queries("uploads", "")
+	bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(timedAPIHandler("NewMultipartUpload", api.NewMultipartUploadHandler)).Queries("uploads", "")
 	// AbortMultipartUpload
-	bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(api.AbortMultipartUploadHandler).Queries("uploadId", "{uploadId:.*}")
+	bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(timedAPIHandler("AbortMultipartUpload", api.AbortMultipartUploadHandler)).Queries("uploadId", "{uploadId:.*}")
 	// GetObject
-	bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectHandler)
+	bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(timedAPIHandler("GetObject", api.GetObjectHandler))
 	// CopyObject
-	bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(api.CopyObjectHandler)
+	bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(timedAPIHandler("CopyObject", api.CopyObjectHandler))
 	// PutObject
-	bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectHandler)
+	bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(timedAPIHandler("PutObject", api.PutObjectHandler))
 	// DeleteObject
-	bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(api.DeleteObjectHandler)
+	bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(timedAPIHandler("DeleteObject", api.DeleteObjectHandler))
 
 	/// Bucket operations
 
 	// GetBucketLocation
-	bucket.Methods("GET").HandlerFunc(api.GetBucketLocationHandler).Queries("location", "")
+	bucket.Methods("GET").HandlerFunc(timedAPIHandler("GetBucketLocation", api.GetBucketLocationHandler)).Queries("location", "")
 	// GetBucketPolicy
-	bucket.Methods("GET").HandlerFunc(api.GetBucketPolicyHandler).Queries("policy", "")
+	bucket.Methods("GET").HandlerFunc(timedAPIHandler("GetBucketPolicy", api.GetBucketPolicyHandler)).Queries("policy", "")
 	// GetBucketNotification
-	bucket.Methods("GET").HandlerFunc(api.GetBucketNotificationHandler).Queries("notification", "")
+	bucket.Methods("GET").HandlerFunc(timedAPIHandler("GetBucketNotification", api.GetBucketNotificationHandler)).Queries("notification", "")
 	// ListenBucketNotification
 	bucket.Methods("GET").HandlerFunc(api.ListenBucketNotificationHandler).Queries("events", "{events:.*}")
 	// ListMultipartUploads
-	bucket.Methods("GET").HandlerFunc(api.ListMultipartUploadsHandler).Queries("uploads", "")
+	bucket.Methods("GET").HandlerFunc(timedAPIHandler("ListMultipartUploads", api.ListMultipartUploadsHandler)).Queries("uploads", "")
 	// ListObjectsV2
-	bucket.Methods("GET").HandlerFunc(api.ListObjectsV2Handler).Queries("list-type", "2")
+	bucket.Methods("GET").HandlerFunc(timedAPIHandler("ListObjectsV2", api.ListObjectsV2Handler)).Queries("list-type", "2")
 	// ListObjectsV1 (Legacy)
-	bucket.Methods("GET").HandlerFunc(api.ListObjectsV1Handler)
+	bucket.Methods("GET").HandlerFunc(timedAPIHandler("ListObjectsV1", api.ListObjectsV1Handler))
 	// PutBucketPolicy
-	bucket.Methods("PUT").HandlerFunc(api.PutBucketPolicyHandler).Queries("policy", "")
+	bucket.Methods("PUT").HandlerFunc(timedAPIHandler("PutBucketPolicy", api.PutBucketPolicyHandler)).Queries("policy", "")
 	// PutBucketNotification
-	bucket.Methods("PUT").HandlerFunc(api.PutBucketNotificationHandler).Queries("notification", "")
+	bucket.Methods("PUT").HandlerFunc(timedAPIHandler("PutBucketNotification", api.PutBucketNotificationHandler)).Queries("notification", "")
 	// PutBucket
-	bucket.Methods("PUT").HandlerFunc(api.PutBucketHandler)
+	bucket.Methods("PUT").HandlerFunc(timedAPIHandler("PutBucket", api.PutBucketHandler))
 	// HeadBucket
-	bucket.Methods("HEAD").HandlerFunc(api.HeadBucketHandler)
+	bucket.Methods("HEAD").HandlerFunc(timedAPIHandler("HeadBucket", api.HeadBucketHandler))
 	// PostPolicy
-	bucket.Methods("POST").HeadersRegexp("Content-Type", "multipart/form-data*").HandlerFunc(api.PostPolicyBucketHandler)
+	bucket.Methods("POST").HeadersRegexp("Content-Type", "multipart/form-data*").HandlerFunc(timedAPIHandler("PostPolicyBucket", api.PostPolicyBucketHandler))
 	// DeleteMultipleObjects
-	bucket.Methods("POST").HandlerFunc(api.DeleteMultipleObjectsHandler)
+	bucket.Methods("POST").HandlerFunc(timedAPIHandler("DeleteMultipleObjects", api.DeleteMultipleObjectsHandler))
 	// DeleteBucketPolicy
-	bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketPolicyHandler).Queries("policy", "")
+	bucket.Methods("DELETE").HandlerFunc(timedAPIHandler("DeleteBucketPolicy", api.DeleteBucketPolicyHandler)).Queries("policy", "")
 	// DeleteBucket
-	bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketHandler)
+	bucket.Methods("DELETE").HandlerFunc(timedAPIHandler("DeleteBucket", api.DeleteBucketHandler))
 
 	/// Root operation
 
 	// ListBuckets
-	apiRouter.Methods("GET").HandlerFunc(api.ListBucketsHandler)
+	apiRouter.Methods("GET").HandlerFunc(timedAPIHandler("ListBuckets", api.ListBucketsHandler))
 }
@@ -106,7 +106,17 @@ func getRequestAuthType(r *http.Request) authType {
 	return authTypeUnknown
 }
 
-func checkRequestAuthType(r *http.Request, bucket, policyAction, region string) APIErrorCode {
+// checkRequestAuthType - authenticates r and checks it is allowed
+// policyAction, timed as the auth phase of r.
+func checkRequestAuthType(r *http.Request, bucket, policyAction, region string) (s3Error APIErrorCode) {
+	timeRequestPhase(r, requestPhaseAuth, func() {
+		s3Error = checkRequestAuth(r, bucket, policyAction, region)
+	})
+	return s3Error
+}
+
+// checkRequestAuth - checkRequestAuthType without timing.
+func checkRequestAuth(r *http.Request, bucket, policyAction, region string) APIErrorCode {
 	reqAuthType := getRequestAuthType(r)
 
 	switch reqAuthType {
@@ -138,9 +148,9 @@ func checkRequestAuthType(r *http.Request, bucket, policyAction, region string)
 // Verify if request has valid AWS Signature Version '2'.
 func isReqAuthenticatedV2(r *http.Request) (s3Error APIErrorCode) {
 	if isRequestSignatureV2(r) {
-		return doesSignV2Match(r)
+		return credentialUsed(doesSignV2Match(r))
 	}
-	return doesPresignV2SignatureMatch(r)
+	return credentialUsed(doesPresignV2SignatureMatch(r))
 }
 
 func reqSignatureV4Verify(r *http.Request) (s3Error APIErrorCode) {
@@ -151,9 +161,9 @@ func reqSignatureV4Verify(r *http.Request) (s3Error APIErrorCode) {
 		sha256sum = unsignedPayload
 	}
 	if isRequestSignatureV4(r) {
-		return doesSignatureMatch(sha256sum, r, serverConfig.GetRegion())
+		return credentialUsed(doesSignatureMatch(sha256sum, r, serverConfig.GetRegion()))
 	} else if isRequestPresignedSignatureV4(r) {
-		return doesPresignedSignatureMatch(sha256sum, r, serverConfig.GetRegion())
+		return credentialUsed(doesPresignedSignatureMatch(sha256sum, r, serverConfig.GetRegion()))
 	}
 	return ErrAccessDenied
 }
@@ -184,9 +194,9 @@ func isReqAuthenticated(r *http.Request, region string) (s3Error APIErrorCode) {
 		sha256sum = getSHA256Hash(payload)
 	}
 	if isRequestSignatureV4(r) {
-		return doesSignatureMatch(sha256sum, r, region)
+		return credentialUsed(doesSignatureMatch(sha256sum, r, region))
 	} else if isRequestPresignedSignatureV4(r) {
-		return doesPresignedSignatureMatch(sha256sum, r, region)
+		return credentialUsed(doesPresignedSignatureMatch(sha256sum, r, region))
 	}
 	return ErrAccessDenied
 }
@@ -222,6 +232,11 @@ func isSupportedS3AuthType(aType authType) bool {
 func (a authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	aType := getRequestAuthType(r)
 	if isSupportedS3AuthType(aType) {
+		// Reject signature versions disabled by the administrator.
+		if !globalAllowedSignatures.allows(aType) {
+			writeErrorResponse(w, ErrAccessDenied, r.URL)
+			return
+		}
 		// Let top level caller validate for anonymous and known signed requests.
 		a.handler.ServeHTTP(w, r)
 		return
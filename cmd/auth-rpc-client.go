@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"context"
 	"net/rpc"
 	"sync"
 	"time"
@@ -80,6 +81,11 @@ func newAuthRPCClient(config authConfig) *AuthRPCClient {
 
 // Login - a jwt based authentication is performed with rpc server.
 func (authClient *AuthRPCClient) Login() (err error) {
+	return authClient.login(context.Background())
+}
+
+// login - like Login but gives up once ctx is done.
+func (authClient *AuthRPCClient) login(ctx context.Context) (err error) {
 	authClient.Lock()
 	defer authClient.Unlock()
 
@@ -98,7 +104,7 @@ func (authClient *AuthRPCClient) Login() (err error) {
 
 	reply := LoginRPCReply{}
 	serviceMethod := authClient.config.serviceName + loginMethodName
-	if err = authClient.rpcClient.Call(serviceMethod, &args, &reply); err != nil {
+	if err = authClient.rpcClient.CallContext(ctx, serviceMethod, &args, &reply); err != nil {
 		return err
 	}
 
@@ -109,18 +115,18 @@ func (authClient *AuthRPCClient) Login() (err error) {
 }
 
 // call makes a RPC call after logs into the server.
-func (authClient *AuthRPCClient) call(serviceMethod string, args interface {
+func (authClient *AuthRPCClient) call(ctx context.Context, serviceMethod string, args interface {
 	SetAuthToken(authToken string)
 }, reply interface{}) (err error) {
 	// On successful login, execute RPC call.
-	if err = authClient.Login(); err == nil {
+	if err = authClient.login(ctx); err == nil {
 		authClient.Lock()
 		// Set token and timestamp before the rpc call.
 		args.SetAuthToken(authClient.authToken)
 		authClient.Unlock()
 
 		// Do RPC call.
-		err = authClient.rpcClient.Call(serviceMethod, args, reply)
+		err = authClient.rpcClient.CallContext(ctx, serviceMethod, args, reply)
 	}
 	return err
 }
@@ -129,6 +135,14 @@ func (authClient *AuthRPCClient) call(serviceMethod string, args interface {
 func (authClient *AuthRPCClient) Call(serviceMethod string, args interface {
 	SetAuthToken(authToken string)
 }, reply interface{}) (err error) {
+	return authClient.CallContext(context.Background(), serviceMethod, args, reply)
+}
+
+// CallContext is like Call but gives up once ctx is done, returning
+// ctx.Err() then.
+func (authClient *AuthRPCClient) CallContext(ctx context.Context, serviceMethod string, args interface {
+	SetAuthToken(authToken string)
+}, reply interface{}) (err error) {
 
 	// Done channel is used to close any lingering retry routine, as soon
 	// as this function returns.
@@ -136,7 +150,7 @@ func (authClient *AuthRPCClient) Call(serviceMethod string, args interface {
 	defer close(doneCh)
 
 	for i := range newRetryTimer(authClient.config.retryUnit, authClient.config.retryCap, doneCh) {
-		if err = authClient.call(serviceMethod, args, reply); err == rpc.ErrShutdown {
+		if err = authClient.call(ctx, serviceMethod, args, reply); err == rpc.ErrShutdown {
 			// As connection at server side is closed, close the rpc client.
 			authClient.Close()
 
@@ -0,0 +1,108 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "errors"
+
+// errMixedBackends - servers of a cluster run different backends.
+var errMixedBackends = errors.New("Servers run different backends")
+
+// BackendCapabilities - bitset of features offered by a backend.
+type BackendCapabilities uint32
+
+// Features a backend may offer. Versioning and object locking are not
+// implemented by any backend yet, they are listed so that clients can
+// rely on the flags once they are.
+const (
+	// Objects and buckets can be healed.
+	backendCapHealing BackendCapabilities = 1 << iota
+	// Buckets can be versioned.
+	backendCapVersioning
+	// Objects can be locked against deletion.
+	backendCapObjectLock
+)
+
+// Names of the capabilities, in bit order.
+var backendCapabilityNames = []struct {
+	capability BackendCapabilities
+	name       string
+}{
+	{backendCapHealing, "healing"},
+	{backendCapVersioning, "versioning"},
+	{backendCapObjectLock, "object-lock"},
+}
+
+// names - returns the names of the capabilities set in c.
+func (c BackendCapabilities) names() []string {
+	names := []string{}
+	for _, cn := range backendCapabilityNames {
+		if c&cn.capability != 0 {
+			names = append(names, cn.name)
+		}
+	}
+	return names
+}
+
+// backendTypeName - returns the name of backend type t.
+func backendTypeName(t BackendType) string {
+	switch t {
+	case FS:
+		return "FS"
+	case Erasure:
+		return "Erasure"
+	}
+	return "Unknown"
+}
+
+// BackendInfo - type and capabilities of the backend of a server.
+type BackendInfo struct {
+	Type         BackendType
+	Capabilities BackendCapabilities
+}
+
+// getLocalBackendInfo - returns the backend of the local server.
+func getLocalBackendInfo() (BackendInfo, error) {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return BackendInfo{}, errServerNotInitialized
+	}
+
+	switch objLayer.(type) {
+	case *xlObjects:
+		return BackendInfo{Type: Erasure, Capabilities: backendCapHealing}, nil
+	case *fsObjects:
+		return BackendInfo{Type: FS}, nil
+	}
+	return BackendInfo{Type: Unknown}, nil
+}
+
+// NodeBackend - backend reported by a server.
+type NodeBackend struct {
+	Addr         string   `json:"addr"`
+	Backend      string   `json:"backend"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// ClusterBackend - backend of the cluster as reported by the first
+// responding server. Consistent is false when servers report different
+// backends or capabilities, which means they were misconfigured.
+type ClusterBackend struct {
+	Backend      string        `json:"backend"`
+	Capabilities []string      `json:"capabilities"`
+	Consistent   bool          `json:"consistent"`
+	Nodes        []NodeBackend `json:"nodes"`
+}
@@ -0,0 +1,65 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Tests names of capability bitsets.
+func TestBackendCapabilitiesNames(t *testing.T) {
+	testCases := []struct {
+		capabilities BackendCapabilities
+		names        []string
+	}{
+		{0, []string{}},
+		{backendCapHealing, []string{"healing"}},
+		{backendCapObjectLock | backendCapHealing, []string{"healing", "object-lock"}},
+		{backendCapHealing | backendCapVersioning | backendCapObjectLock, []string{"healing", "versioning", "object-lock"}},
+	}
+	for i, testCase := range testCases {
+		if names := testCase.capabilities.names(); !reflect.DeepEqual(names, testCase.names) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.names, names)
+		}
+	}
+}
+
+// Wrapper for calling local backend tests for both XL multiple disks
+// and single node setup.
+func TestGetLocalBackendInfo(t *testing.T) {
+	ExecObjectLayerTest(t, testGetLocalBackendInfo)
+}
+
+func testGetLocalBackendInfo(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer resetGlobalObjectAPI()
+
+	expected := BackendInfo{Type: FS}
+	if instanceType == XLTestStr {
+		expected = BackendInfo{Type: Erasure, Capabilities: backendCapHealing}
+	}
+	info, err := getLocalBackendInfo()
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if info != expected {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, expected, info)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Startup phases of the server, in the order they run.
+const (
+	// Initialization of distributed and namespace locking.
+	bootPhaseLockInit = "lock-init"
+	// Wait for enough disks to come online.
+	bootPhaseFirstQuorum = "first-quorum"
+	// Validation of format.json, or formatting of fresh disks.
+	bootPhaseFormatCheck = "disk-format-check"
+	// Initialization of the object layer along with bucket
+	// policies and event notifications.
+	bootPhaseObjectLayerInit = "object-layer-init"
+)
+
+var bootPhases = []string{
+	bootPhaseLockInit,
+	bootPhaseFirstQuorum,
+	bootPhaseFormatCheck,
+	bootPhaseObjectLayerInit,
+}
+
+// BootPhase - time taken by a startup phase. Pending is set for
+// phases which have not completed yet, Duration of a phase in
+// progress is the time spent in it so far.
+type BootPhase struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Pending  bool          `json:"pending"`
+	// Set on the server which took the longest to complete
+	// this phase.
+	Slowest bool `json:"slowest"`
+}
+
+// BootTimings - time taken by each startup phase of a server.
+type BootTimings struct {
+	Phases []BootPhase `json:"phases"`
+}
+
+// NodeBootTimings - startup timings of a server.
+type NodeBootTimings struct {
+	Addr    string      `json:"addr"`
+	Timings BootTimings `json:"timings"`
+}
+
+// StartupError - error a server started in spite of, such as a disk
+// which was offline.
+type StartupError struct {
+	Addr   string `json:"addr,omitempty"`
+	Phase  string `json:"phase"`
+	Source string `json:"source"` // e.g. the endpoint of a disk.
+	Error  string `json:"error"`
+}
+
+// bootPhaseTimes - start and end of a startup phase, zero if not
+// reached yet.
+type bootPhaseTimes struct {
+	start time.Time
+	end   time.Time
+}
+
+// bootDiagnostics - records startup phases of this server. Each phase
+// is recorded once, re-initialization of disks or object layer later
+// on doesn't overwrite boot timings.
+type bootDiagnostics struct {
+	mutex  sync.Mutex
+	phases map[string]*bootPhaseTimes
+	errors []StartupError
+}
+
+var globalBootDiagnostics = newBootDiagnostics()
+
+func newBootDiagnostics() *bootDiagnostics {
+	d := &bootDiagnostics{phases: make(map[string]*bootPhaseTimes)}
+	for _, name := range bootPhases {
+		d.phases[name] = &bootPhaseTimes{}
+	}
+	return d
+}
+
+// begin - marks the start of phase.
+func (d *bootDiagnostics) begin(phase string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if times := d.phases[phase]; times.start.IsZero() {
+		times.start = time.Now().UTC()
+	}
+}
+
+// end - marks the end of phase.
+func (d *bootDiagnostics) end(phase string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if times := d.phases[phase]; !times.start.IsZero() && times.end.IsZero() {
+		times.end = time.Now().UTC()
+	}
+}
+
+// next - marks the end of phase and the start of the following one.
+func (d *bootDiagnostics) next(phase, nextPhase string) {
+	d.end(phase)
+	d.begin(nextPhase)
+}
+
+// timings - returns time taken by every startup phase so far.
+func (d *bootDiagnostics) timings() BootTimings {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	now := time.Now().UTC()
+	timings := BootTimings{Phases: []BootPhase{}}
+	for _, name := range bootPhases {
+		times := d.phases[name]
+		phase := BootPhase{Name: name, Pending: times.end.IsZero()}
+		switch {
+		case !times.end.IsZero():
+			phase.Duration = times.end.Sub(times.start)
+		case !times.start.IsZero():
+			phase.Duration = now.Sub(times.start)
+		}
+		timings.Phases = append(timings.Phases, phase)
+	}
+	return timings
+}
+
+// recordError - records an error startup went on in spite of.
+func (d *bootDiagnostics) recordError(phase, source string, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.errors = append(d.errors, StartupError{Phase: phase, Source: source, Error: err.Error()})
+}
+
+// recordDiskErrors - records the disks of endpoints which are not
+// available, errs, as startup goes on after waiting for disks, and
+// those which were not available at first, firstErrs, but came
+// online within waited. Errors in ignored are not recorded.
+func (d *bootDiagnostics) recordDiskErrors(endpoints []*url.URL, firstErrs, errs []error, waited time.Duration, ignored ...error) {
+	for i, ep := range endpoints {
+		switch {
+		case errs[i] != nil && !isErrIgnored(errs[i], ignored...):
+			d.recordError(bootPhaseFirstQuorum, ep.String(), errs[i])
+		case firstErrs[i] != nil && !isErrIgnored(firstErrs[i], ignored...):
+			d.recordError(bootPhaseFirstQuorum, ep.String(),
+				fmt.Errorf("Disk came online after %s, first error: %v", waited, firstErrs[i]))
+		}
+	}
+}
+
+// startupErrors - returns the errors recorded during startup, an empty
+// slice if none.
+func (d *bootDiagnostics) startupErrors() []StartupError {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return append([]StartupError{}, d.errors...)
+}
+
+// markSlowestBootPhases - flags the server which took the longest to
+// complete each startup phase. Phases still pending are not taken
+// into account.
+func markSlowestBootPhases(nodes []NodeBootTimings) {
+	for p := range bootPhases {
+		slowest := -1
+		var longest time.Duration
+		for i, node := range nodes {
+			if p >= len(node.Timings.Phases) {
+				continue
+			}
+			phase := node.Timings.Phases[p]
+			if phase.Pending {
+				continue
+			}
+			if slowest < 0 || phase.Duration > longest {
+				slowest, longest = i, phase.Duration
+			}
+		}
+		if slowest >= 0 {
+			nodes[slowest].Timings.Phases[p].Slowest = true
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBootDiagnosticsTimings - tests timings of a server which is
+// still starting up.
+func TestBootDiagnosticsTimings(t *testing.T) {
+	d := newBootDiagnostics()
+
+	// Nothing started yet, all phases are pending.
+	for _, phase := range d.timings().Phases {
+		if !phase.Pending || phase.Duration != 0 {
+			t.Fatalf("Expected %s to be pending with no duration, got %v", phase.Name, phase)
+		}
+	}
+
+	d.begin(bootPhaseLockInit)
+	d.next(bootPhaseLockInit, bootPhaseFirstQuorum)
+	time.Sleep(10 * time.Millisecond)
+
+	timings := d.timings()
+	if len(timings.Phases) != len(bootPhases) {
+		t.Fatalf("Expected %d phases, got %d", len(bootPhases), len(timings.Phases))
+	}
+	for i, phase := range timings.Phases {
+		if phase.Name != bootPhases[i] {
+			t.Fatalf("Expected phase %s, got %s", bootPhases[i], phase.Name)
+		}
+	}
+	if timings.Phases[0].Pending {
+		t.Fatal("Expected lock-init to be complete")
+	}
+	// Phase in progress reports time spent so far.
+	if !timings.Phases[1].Pending || timings.Phases[1].Duration < 10*time.Millisecond {
+		t.Fatalf("Expected first-quorum to be pending for at least 10ms, got %v", timings.Phases[1])
+	}
+	for _, phase := range timings.Phases[2:] {
+		if !phase.Pending || phase.Duration != 0 {
+			t.Fatalf("Expected %s to be pending with no duration, got %v", phase.Name, phase)
+		}
+	}
+
+	// Phases are recorded once, later marks are ignored.
+	lockInit := timings.Phases[0].Duration
+	d.begin(bootPhaseLockInit)
+	d.end(bootPhaseLockInit)
+	if duration := d.timings().Phases[0].Duration; duration != lockInit {
+		t.Fatalf("Expected lock-init to remain %v, got %v", lockInit, duration)
+	}
+}
+
+// TestBootDiagnosticsStartupErrors - tests disks offline or slow to
+// come online at startup are recorded.
+func TestBootDiagnosticsStartupErrors(t *testing.T) {
+	d := newBootDiagnostics()
+
+	// A clean startup has no errors, not a nil slice.
+	if startupErrors := d.startupErrors(); startupErrors == nil || len(startupErrors) != 0 {
+		t.Fatalf("Expected no startup errors, got %#v", startupErrors)
+	}
+
+	endpoints, err := parseStorageEndpoints([]string{"/mnt/disk1", "/mnt/disk2", "/mnt/disk3", "/mnt/disk4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstErrs := []error{nil, errDiskNotFound, errDiskNotFound, errUnformattedDisk}
+	errs := []error{nil, nil, errDiskNotFound, errUnformattedDisk}
+	d.recordDiskErrors(endpoints, firstErrs, errs, time.Minute, errUnformattedDisk)
+
+	startupErrors := d.startupErrors()
+	if len(startupErrors) != 2 {
+		t.Fatalf("Expected 2 startup errors, got %v", startupErrors)
+	}
+	// The second disk came online late, the third never did, the
+	// unformatted fourth is ignored.
+	if startupErrors[0].Source != endpoints[1].String() || startupErrors[0].Phase != bootPhaseFirstQuorum {
+		t.Fatalf("Expected slow disk %s, got %v", endpoints[1], startupErrors[0])
+	}
+	if startupErrors[1].Source != endpoints[2].String() || startupErrors[1].Error != errDiskNotFound.Error() {
+		t.Fatalf("Expected offline disk %s, got %v", endpoints[2], startupErrors[1])
+	}
+}
@@ -44,8 +44,9 @@ func (br *browserPeerAPIHandlers) Login(args *LoginRPCArgs, reply *LoginRPCReply
 
 // SetAuthPeerArgs - Arguments collection for SetAuth RPC call
 type SetAuthPeerArgs struct {
-	// For Auth
-	AuthRPCArgs
+	// For Auth, along with the config change logged once the
+	// credentials are updated.
+	ConfigChangeArgs
 
 	// New credentials that receiving peer should update to.
 	Creds credential
@@ -76,11 +77,12 @@ func (br *browserPeerAPIHandlers) SetAuthPeer(args SetAuthPeerArgs, reply *AuthR
 		return err
 	}
 
-	return nil
+	return globalConfigChangeLog.logApplied(args.Change, nil)
 }
 
-// Sends SetAuthPeer RPCs to all peers in the Minio cluster
-func updateCredsOnPeers(creds credential) map[string]error {
+// Sends SetAuthPeer RPCs to all peers in the Minio cluster, along with
+// change, the config change requested through the admin API if any.
+func updateCredsOnPeers(creds credential, change *ConfigChange) map[string]error {
 	// Get list of peer addresses (from globalS3Peers)
 	peers := []string{}
 	for _, p := range globalS3Peers {
@@ -116,7 +118,10 @@ func updateCredsOnPeers(creds credential) map[string]error {
 			})
 
 			// Construct RPC call arguments.
-			args := SetAuthPeerArgs{Creds: creds}
+			args := SetAuthPeerArgs{
+				ConfigChangeArgs: ConfigChangeArgs{Change: change},
+				Creds:            creds,
+			}
 
 			// Make RPC call - we only care about error
 			// response and not the reply.
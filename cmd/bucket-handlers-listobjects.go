@@ -100,6 +100,12 @@ func (api objectAPIHandlers) ListObjectsV2Handler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Leave out entries beyond the maximum list depth.
+	listObjectsInfo, truncated := capListDepth(listObjectsInfo, delimiter, getMaxListDepth())
+	if truncated {
+		w.Header().Set(listDepthTruncatedHeader, "true")
+	}
+
 	response := generateListObjectsV2Response(bucket, prefix, token, startAfter, delimiter, fetchOwner, maxKeys, listObjectsInfo)
 
 	// Write success response.
@@ -145,6 +151,13 @@ func (api objectAPIHandlers) ListObjectsV1Handler(w http.ResponseWriter, r *http
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+
+	// Leave out entries beyond the maximum list depth.
+	listObjectsInfo, truncated := capListDepth(listObjectsInfo, delimiter, getMaxListDepth())
+	if truncated {
+		w.Header().Set(listDepthTruncatedHeader, "true")
+	}
+
 	response := generateListObjectsV1Response(bucket, prefix, marker, delimiter, maxKeys, listObjectsInfo)
 
 	// Write success response.
@@ -270,6 +270,12 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		return
 	}
 
+	// Reject the whole request before deleting any object.
+	if len(deleteObjects.Objects) > getMultiDeleteLimit() {
+		writeErrorResponse(w, ErrTooManyDeleteObjects, r.URL)
+		return
+	}
+
 	var wg = &sync.WaitGroup{} // Allocate a new wait group.
 	var dErrs = make([]error, len(deleteObjects.Objects))
 
@@ -278,10 +284,20 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		wg.Add(1)
 		go func(i int, obj ObjectIdentifier) {
 			defer wg.Done()
-			dErr := objectAPI.DeleteObject(bucket, obj.ObjectName)
+			dErr := checkObjectImmutability(objectAPI, bucket, obj.ObjectName)
+			if dErr == nil {
+				// Protected objects are reported in the errors,
+				// the others are still deleted.
+				dErr = checkDeleteProtection(objectAPI, bucket, obj.ObjectName)
+			}
+			if dErr == nil {
+				dErr = objectAPI.DeleteObject(bucket, obj.ObjectName)
+			}
 			if dErr != nil {
 				dErrs[i] = dErr
+				return
 			}
+			globalBucketObjectLimits.add(bucket, -1)
 		}(index, object)
 	}
 	wg.Wait()
@@ -327,7 +343,7 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 				Name: dobj.ObjectName,
 			},
 			ReqParams: map[string]string{
-				"sourceIPAddress": r.RemoteAddr,
+				"sourceIPAddress": getSourceIP(r),
 			},
 		})
 	}
@@ -365,7 +381,7 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	bucketLock := globalNSMutex.NewNSLock(bucket, "")
-	bucketLock.Lock()
+	timeRequestPhase(r, requestPhaseLock, bucketLock.Lock)
 	defer bucketLock.Unlock()
 
 	// Proceed to creating a bucket.
@@ -495,15 +511,30 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	sha256sum := ""
 
 	objectLock := globalNSMutex.NewNSLock(bucket, object)
-	objectLock.Lock()
+	timeRequestPhase(r, requestPhaseLock, objectLock.Lock)
 	defer objectLock.Unlock()
 
+	if err := checkObjectImmutability(objectAPI, bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	isNewObject, err := checkBucketObjectLimit(objectAPI, bucket, object)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
 	objInfo, err := objectAPI.PutObject(bucket, object, fileSize, fileBody, metadata, sha256sum)
 	if err != nil {
 		errorIf(err, "Unable to create object.")
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+	if isNewObject {
+		globalBucketObjectLimits.add(bucket, 1)
+	}
+	globalWriteAmp.addClientBytes(objInfo.Size)
 	w.Header().Set("ETag", "\""+objInfo.MD5Sum+"\"")
 	w.Header().Set("Location", getObjectLocation(bucket, object))
 
@@ -545,7 +576,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		Bucket:  bucket,
 		ObjInfo: objInfo,
 		ReqParams: map[string]string{
-			"sourceIPAddress": r.RemoteAddr,
+			"sourceIPAddress": getSourceIP(r),
 		},
 	})
 }
@@ -572,7 +603,7 @@ func (api objectAPIHandlers) HeadBucketHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	bucketLock := globalNSMutex.NewNSLock(bucket, "")
-	bucketLock.RLock()
+	timeRequestPhase(r, requestPhaseLock, bucketLock.RLock)
 	defer bucketLock.RUnlock()
 
 	if _, err := objectAPI.GetBucketInfo(bucket); err != nil {
@@ -602,7 +633,7 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 	bucket := vars["bucket"]
 
 	bucketLock := globalNSMutex.NewNSLock(bucket, "")
-	bucketLock.Lock()
+	timeRequestPhase(r, requestPhaseLock, bucketLock.Lock)
 	defer bucketLock.Unlock()
 
 	// Attempt to delete bucket.
@@ -621,6 +652,24 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 	// Delete listener config, if present - ignore any errors.
 	_ = removeListenerConfig(bucket, objectAPI)
 
+	// Delete bucket tags, if present - ignore any errors.
+	_ = removeBucketTags(bucket, objectAPI)
+
+	// Delete immutability window, if present - ignore any errors.
+	_ = removeImmutabilityWindow(bucket, objectAPI)
+
+	// Delete object limit, if present - ignore any errors.
+	_ = removeBucketObjectLimit(bucket, objectAPI)
+
+	// Delete logging config, if present - ignore any errors.
+	_ = removeBucketLogging(bucket, objectAPI)
+
+	// Delete delete protection tag, if present - ignore any errors.
+	_ = removeDeleteProtectionTag(bucket, objectAPI)
+
+	// Delete part size limits, if present.
+	globalBucketPartSizeLimits.set(bucket, PartSizeLimits{})
+
 	// Write success response.
 	writeSuccessNoContent(w)
 }
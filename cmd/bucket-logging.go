@@ -0,0 +1,344 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	router "github.com/gorilla/mux"
+)
+
+const (
+	// Bucket logging config name.
+	bucketLoggingConfig = "logging.json"
+
+	// Access logs buffered by a server are written out this often, or
+	// as soon as as many logs of a bucket are buffered.
+	accessLogFlushInterval = 5 * time.Minute
+	maxBufferedAccessLogs  = 10000
+
+	// Time format of access log entries, as in S3 server access logs.
+	accessLogTimeFormat = "02/Jan/2006:15:04:05 -0700"
+)
+
+var (
+	// errBucketLoggingToSelf - bucket access can not be logged into
+	// the bucket itself, every log written would be logged again.
+	errBucketLoggingToSelf = errors.New("Bucket access can not be logged into the bucket itself")
+
+	// errInvalidBucketLoggingPrefix - access log objects can not be
+	// named with the prefix.
+	errInvalidBucketLoggingPrefix = errors.New("Bucket logging prefix must be a valid object prefix")
+)
+
+// BucketLogging - bucket access logs of a bucket are written to as
+// objects, logging is disabled when TargetBucket is empty.
+type BucketLogging struct {
+	TargetBucket string `json:"targetBucket"`
+	TargetPrefix string `json:"targetPrefix"`
+}
+
+// validateBucketLogging - returns an error if access to bucket can not
+// be logged as configured by logging, without checking that the target
+// bucket exists.
+func validateBucketLogging(bucket string, logging BucketLogging) error {
+	if logging.TargetBucket == "" {
+		return nil
+	}
+	if logging.TargetBucket == bucket {
+		return errBucketLoggingToSelf
+	}
+	if !IsValidBucketName(logging.TargetBucket) {
+		return BucketNameInvalid{Bucket: logging.TargetBucket}
+	}
+	if logging.TargetPrefix != "" && !IsValidObjectPrefix(logging.TargetPrefix) {
+		return errInvalidBucketLoggingPrefix
+	}
+	return nil
+}
+
+// checkBucketLogging - returns an error if access to bucket can not be
+// logged as configured by logging, or its target bucket does not exist.
+func checkBucketLogging(objAPI ObjectLayer, bucket string, logging BucketLogging) error {
+	if err := validateBucketLogging(bucket, logging); err != nil {
+		return err
+	}
+	if logging.TargetBucket == "" {
+		return nil
+	}
+	return checkBucketExist(logging.TargetBucket, objAPI)
+}
+
+// bucketLogging - logging configuration of all buckets and the access
+// logs buffered by this server, kept in memory on every server and
+// persisted under the bucket config prefix. Every server writes the
+// logs of the requests it served into objects of its own.
+type bucketLogging struct {
+	mutex   sync.Mutex
+	configs map[string]BucketLogging
+	logs    map[string][]string // Buffered log entries by bucket.
+}
+
+var globalBucketLogging = newBucketLogging(nil)
+
+func newBucketLogging(configs map[string]BucketLogging) *bucketLogging {
+	l := &bucketLogging{
+		configs: make(map[string]BucketLogging),
+		logs:    make(map[string][]string),
+	}
+	for bucket, logging := range configs {
+		l.configs[bucket] = logging
+	}
+	return l
+}
+
+// get - returns the logging configuration of bucket.
+func (l *bucketLogging) get(bucket string) BucketLogging {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.configs[bucket]
+}
+
+// set - logs access to bucket as configured by logging, an empty
+// target disables logging.
+func (l *bucketLogging) set(bucket string, logging BucketLogging) error {
+	if err := validateBucketLogging(bucket, logging); err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if logging.TargetBucket == "" {
+		delete(l.configs, bucket)
+		return nil
+	}
+	l.configs[bucket] = logging
+	return nil
+}
+
+// isEnabled - returns true if access to any bucket is logged.
+func (l *bucketLogging) isEnabled() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return len(l.configs) > 0
+}
+
+// isLogged - returns true if access to bucket is logged.
+func (l *bucketLogging) isLogged(bucket string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	_, ok := l.configs[bucket]
+	return ok
+}
+
+// record - buffers entry logging access to bucket, logs are written
+// out in the background once too many are buffered.
+func (l *bucketLogging) record(bucket, entry string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if _, ok := l.configs[bucket]; !ok {
+		return
+	}
+	l.logs[bucket] = append(l.logs[bucket], entry)
+	if len(l.logs[bucket]) == maxBufferedAccessLogs {
+		if objAPI := newObjectLayerFn(); objAPI != nil {
+			go l.flush(objAPI, time.Now().UTC())
+		}
+	}
+}
+
+// flush - writes the access logs buffered for every bucket as an
+// object into its target bucket, under the target prefix. Logs which
+// can not be written are dropped.
+func (l *bucketLogging) flush(objAPI ObjectLayer, now time.Time) {
+	l.mutex.Lock()
+	logs := l.logs
+	l.logs = make(map[string][]string)
+	configs := make(map[string]BucketLogging)
+	for bucket := range logs {
+		configs[bucket] = l.configs[bucket]
+	}
+	l.mutex.Unlock()
+
+	for bucket, entries := range logs {
+		logging := configs[bucket]
+		if logging.TargetBucket == "" {
+			// Disabled meanwhile.
+			continue
+		}
+		var buf bytes.Buffer
+		for _, entry := range entries {
+			buf.WriteString(entry)
+			buf.WriteByte('\n')
+		}
+		object := logging.TargetPrefix + now.Format("2006-01-02-15-04-05-") + mustGetUUID()
+		_, err := objAPI.PutObject(logging.TargetBucket, object, int64(buf.Len()), &buf, nil, "")
+		errorIf(err, "Unable to write access logs of bucket %s to %s/%s.", bucket, logging.TargetBucket, object)
+	}
+}
+
+// accessLogEntry - returns the access log entry of r, served as api
+// with status after sending sent bytes of response.
+func accessLogEntry(api string, r *http.Request, requestID string, status int, sent int64, now time.Time) string {
+	vars := router.Vars(r)
+	object := vars["object"]
+	if object == "" {
+		object = "-"
+	}
+	if requestID == "" {
+		requestID = "-"
+	}
+	return fmt.Sprintf("%s [%s] %s %s %s %s \"%s %s %s\" %d %d",
+		vars["bucket"], now.Format(accessLogTimeFormat), getSourceIP(r), requestID, api,
+		object, r.Method, r.URL.RequestURI(), r.Proto, status, sent)
+}
+
+// accessLoggedHandler - logs requests to buckets with logging enabled
+// served by f as api.
+func accessLoggedHandler(api string, f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Most servers log no bucket at all, spare them the lookup.
+		if !globalBucketLogging.isEnabled() {
+			f(w, r)
+			return
+		}
+		bucket := router.Vars(r)["bucket"]
+		if bucket == "" || !globalBucketLogging.isLogged(bucket) {
+			f(w, r)
+			return
+		}
+
+		ww := &httpResponseRecorder{ResponseWriter: w}
+		f(ww, r)
+		status := ww.respStatusCode
+		if status == 0 {
+			status = http.StatusOK
+		}
+		requestID := w.Header().Get(globalRequestIDConfig.get().Header)
+		globalBucketLogging.record(bucket, accessLogEntry(api, r, requestID, status, ww.bytesWritten, time.Now().UTC()))
+	}
+}
+
+// startAccessLogFlusher - periodically writes out buffered access logs,
+// runs for the lifetime of the server.
+func startAccessLogFlusher() {
+	ticker := time.NewTicker(accessLogFlushInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if objAPI := newObjectLayerFn(); objAPI != nil {
+			globalBucketLogging.flush(objAPI, time.Now().UTC())
+		}
+	}
+}
+
+// readBucketLogging - reads the persisted logging configuration of
+// bucket, an empty target if none was set.
+func readBucketLogging(bucket string, objAPI ObjectLayer) (BucketLogging, error) {
+	loggingPath := pathJoin(bucketConfigPrefix, bucket, bucketLoggingConfig)
+
+	// Acquire a read lock on logging config before reading.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, loggingPath)
+	objLock.RLock()
+	defer objLock.RUnlock()
+
+	var buffer bytes.Buffer
+	if err := objAPI.GetObject(minioMetaBucket, loggingPath, 0, -1, &buffer); err != nil {
+		if isErrObjectNotFound(err) || isErrIncompleteBody(err) {
+			return BucketLogging{}, nil
+		}
+		errorIf(err, "Unable to load logging config for the bucket %s.", bucket)
+		return BucketLogging{}, errorCause(err)
+	}
+
+	var logging BucketLogging
+	if err := json.Unmarshal(buffer.Bytes(), &logging); err != nil {
+		return BucketLogging{}, err
+	}
+	return logging, nil
+}
+
+// writeBucketLogging - persists the logging configuration of bucket,
+// an empty target removes any previously persisted configuration.
+func writeBucketLogging(bucket string, objAPI ObjectLayer, logging BucketLogging) error {
+	loggingPath := pathJoin(bucketConfigPrefix, bucket, bucketLoggingConfig)
+
+	// Acquire a write lock on logging config before modifying.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, loggingPath)
+	objLock.Lock()
+	defer objLock.Unlock()
+
+	if logging.TargetBucket == "" {
+		if err := objAPI.DeleteObject(minioMetaBucket, loggingPath); err != nil && !isErrObjectNotFound(err) {
+			errorIf(err, "Unable to remove logging config of the bucket %s.", bucket)
+			return errorCause(err)
+		}
+		return nil
+	}
+
+	buf, err := json.Marshal(logging)
+	if err != nil {
+		return err
+	}
+	if _, err = objAPI.PutObject(minioMetaBucket, loggingPath, int64(len(buf)), bytes.NewReader(buf), nil, ""); err != nil {
+		errorIf(err, "Unable to set logging config for the bucket %s.", bucket)
+		return errorCause(err)
+	}
+	return nil
+}
+
+// removeBucketLogging - removes persisted and in-memory logging
+// configuration of a bucket being deleted.
+func removeBucketLogging(bucket string, objAPI ObjectLayer) error {
+	globalBucketLogging.set(bucket, BucketLogging{})
+	return writeBucketLogging(bucket, objAPI, BucketLogging{})
+}
+
+// initBucketLogging - loads the logging configuration of all buckets.
+func initBucketLogging(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	buckets, err := objAPI.ListBuckets()
+	if err != nil {
+		errorIf(err, "Unable to list buckets.")
+		return errorCause(err)
+	}
+
+	configs := make(map[string]BucketLogging)
+	for _, bucket := range buckets {
+		logging, lErr := readBucketLogging(bucket.Name, objAPI)
+		if lErr != nil {
+			if isErrIgnored(lErr, errDiskNotFound) {
+				continue
+			}
+			return lErr
+		}
+		if logging.TargetBucket != "" {
+			configs[bucket.Name] = logging
+		}
+	}
+
+	globalBucketLogging = newBucketLogging(configs)
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	router "github.com/gorilla/mux"
+)
+
+// TestCheckBucketLogging - tests validation of bucket logging
+// configurations.
+func TestCheckBucketLogging(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	for _, bucket := range []string{"source", "target"} {
+		if err = obj.MakeBucket(bucket); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		logging BucketLogging
+		err     error
+	}{
+		// Test 1: logging disabled.
+		{BucketLogging{}, nil},
+		// Test 2: logged into another bucket.
+		{BucketLogging{TargetBucket: "target", TargetPrefix: "logs/"}, nil},
+		// Test 3: logged into itself.
+		{BucketLogging{TargetBucket: "source"}, errBucketLoggingToSelf},
+		// Test 4: missing target bucket.
+		{BucketLogging{TargetBucket: "missing"}, BucketNotFound{Bucket: "missing"}},
+		// Test 5: invalid target bucket name.
+		{BucketLogging{TargetBucket: "Invalid_Bucket"}, BucketNameInvalid{Bucket: "Invalid_Bucket"}},
+		// Test 6: invalid prefix.
+		{BucketLogging{TargetBucket: "target", TargetPrefix: "logs\\"}, errInvalidBucketLoggingPrefix},
+	}
+
+	for i, testCase := range testCases {
+		if err = checkBucketLogging(obj, "source", testCase.logging); err != testCase.err {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+		}
+	}
+
+	// Logging into itself is refused even without an object layer.
+	l := newBucketLogging(nil)
+	if err = l.set("source", BucketLogging{TargetBucket: "source"}); err != errBucketLoggingToSelf {
+		t.Fatalf("Expected %v, got %v", errBucketLoggingToSelf, err)
+	}
+	if l.isLogged("source") {
+		t.Fatal("Expected source not to be logged")
+	}
+}
+
+// TestBucketAccessLogs - tests that access to a logged bucket is
+// written into its target bucket.
+func TestBucketAccessLogs(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+	defer resetGlobalBucketLogging()
+
+	for _, bucket := range []string{"source", "target", "unlogged"} {
+		if err = obj.MakeBucket(bucket); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = globalBucketLogging.set("source", BucketLogging{TargetBucket: "target", TargetPrefix: "logs/"}); err != nil {
+		t.Fatal(err)
+	}
+
+	mux := router.NewRouter()
+	mux.Methods("GET").Path("/{bucket}/{object:.+}").HandlerFunc(timedAPIHandler("GetObject", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	mux.Methods("DELETE").Path("/{bucket}/{object:.+}").HandlerFunc(timedAPIHandler("DeleteObject", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	for _, req := range []*http.Request{
+		httptest.NewRequest("GET", "/source/dir/object", nil),
+		httptest.NewRequest("DELETE", "/source/object", nil),
+		httptest.NewRequest("GET", "/unlogged/object", nil),
+	} {
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	globalBucketLogging.flush(obj, time.Now().UTC())
+
+	result, err := obj.ListObjects("target", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || !strings.HasPrefix(result.Objects[0].Name, "logs/") {
+		t.Fatalf("Expected a single access log under logs/, got %v", result.Objects)
+	}
+
+	var buffer bytes.Buffer
+	if err = obj.GetObject("target", result.Objects[0].Name, 0, -1, &buffer); err != nil {
+		t.Fatal(err)
+	}
+	entries := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 access log entries, got %q", entries)
+	}
+	for i, expected := range []string{
+		"GetObject dir/object \"GET /source/dir/object HTTP/1.1\" 200 5",
+		"DeleteObject object \"DELETE /source/object HTTP/1.1\" 204 0",
+	} {
+		if !strings.HasPrefix(entries[i], "source [") || !strings.HasSuffix(entries[i], expected) {
+			t.Errorf("Entry %d: expected %q, got %q", i+1, expected, entries[i])
+		}
+	}
+
+	// Nothing is left to write.
+	globalBucketLogging.flush(obj, time.Now().UTC())
+	if result, err = obj.ListObjects("target", "", "", "", 1000); err != nil || len(result.Objects) != 1 {
+		t.Fatalf("Expected no new access log, got %v, %v", result.Objects, err)
+	}
+}
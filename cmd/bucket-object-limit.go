@@ -0,0 +1,308 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// Bucket object limit config name.
+	bucketObjectLimitConfig = "object-limit.json"
+
+	// Objects of a bucket with a limit are recounted this often, so
+	// that objects written and deleted through other servers are
+	// accounted for.
+	objectLimitRecountInterval = 5 * time.Minute
+)
+
+var (
+	// errInvalidObjectLimit - object limit is negative.
+	errInvalidObjectLimit = errors.New("Object limit must not be negative")
+
+	// errBucketObjectLimit - bucket holds as many objects as its limit
+	// allows.
+	errBucketObjectLimit = errors.New("Bucket holds the maximum number of objects allowed")
+)
+
+// BucketObjectLimit - maximum number of objects of a bucket, zero if
+// unlimited, along with the objects counted by the server.
+type BucketObjectLimit struct {
+	MaxObjects int64 `json:"maxObjects"`
+	Objects    int64 `json:"objects"`
+	// False until the objects of the bucket were counted once, the
+	// limit is not enforced meanwhile.
+	Counted bool `json:"counted"`
+}
+
+// objectLimitConfig - object limit of a bucket as persisted.
+type objectLimitConfig struct {
+	MaxObjects int64 `json:"maxObjects"`
+}
+
+// bucketObjectCount - objects of a bucket with a limit, counted by the
+// usage scanner and kept up to date with the objects written and
+// deleted through this server between scans.
+type bucketObjectCount struct {
+	maxObjects int64
+	objects    int64
+	counted    time.Time // When the last scan finished.
+	scanning   bool
+}
+
+// bucketObjectLimits - object limits of all buckets, kept in memory on
+// every server and persisted under the bucket config prefix.
+type bucketObjectLimits struct {
+	mutex   sync.Mutex
+	buckets map[string]*bucketObjectCount
+}
+
+var globalBucketObjectLimits = newBucketObjectLimits(nil)
+
+// newBucketObjectLimits - returns object limits initialized with
+// limits, objects are counted in the background.
+func newBucketObjectLimits(limits map[string]int64) *bucketObjectLimits {
+	l := &bucketObjectLimits{buckets: make(map[string]*bucketObjectCount)}
+	for bucket, maxObjects := range limits {
+		l.set(bucket, maxObjects)
+	}
+	return l
+}
+
+// get - returns the object limit of bucket.
+func (l *bucketObjectLimits) get(bucket string) BucketObjectLimit {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	count, ok := l.buckets[bucket]
+	if !ok {
+		return BucketObjectLimit{}
+	}
+	return BucketObjectLimit{
+		MaxObjects: count.maxObjects,
+		Objects:    count.objects,
+		Counted:    !count.counted.IsZero(),
+	}
+}
+
+// set - sets the object limit of bucket, zero removes it. Objects are
+// counted in the background when a limit is first set.
+func (l *bucketObjectLimits) set(bucket string, maxObjects int64) error {
+	if maxObjects < 0 {
+		return errInvalidObjectLimit
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if maxObjects == 0 {
+		delete(l.buckets, bucket)
+		return nil
+	}
+	count, ok := l.buckets[bucket]
+	if !ok {
+		count = &bucketObjectCount{}
+		l.buckets[bucket] = count
+		l.recountLocked(bucket, count)
+	}
+	count.maxObjects = maxObjects
+	return nil
+}
+
+// recountLocked - counts the objects of bucket in the background with
+// the usage scanner, must be called with the mutex held.
+func (l *bucketObjectLimits) recountLocked(bucket string, count *bucketObjectCount) {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil || count.scanning {
+		return
+	}
+	count.scanning = true
+	go func() {
+		var objects int64
+		globalScannerActivity.begin()
+		err := scanBucketUsage(objLayer, bucket, 0, 1, usageScanPageInterval, func(size int64) {
+			objects++
+		})
+		globalScannerActivity.end()
+		errorIf(err, "Unable to count objects of bucket %s.", bucket)
+
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		count.scanning = false
+		if err != nil || l.buckets[bucket] != count {
+			// Failed, or the limit was removed meanwhile.
+			return
+		}
+		count.objects = objects
+		count.counted = time.Now().UTC()
+	}()
+}
+
+// check - returns errBucketObjectLimit if bucket holds as many objects
+// as allowed, starting a recount if the count is stale or missing, as
+// for limits loaded before the object layer was initialized.
+func (l *bucketObjectLimits) check(bucket string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	count, ok := l.buckets[bucket]
+	if !ok {
+		return nil
+	}
+	if count.counted.IsZero() || time.Since(count.counted) > objectLimitRecountInterval {
+		l.recountLocked(bucket, count)
+	}
+	if !count.counted.IsZero() && count.objects >= count.maxObjects {
+		return errBucketObjectLimit
+	}
+	return nil
+}
+
+// add - adjusts the count of objects of bucket by n, if it has a
+// limit.
+func (l *bucketObjectLimits) add(bucket string, n int64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if count, ok := l.buckets[bucket]; ok {
+		count.objects += n
+	}
+}
+
+// hasLimit - returns true if bucket has an object limit.
+func (l *bucketObjectLimits) hasLimit(bucket string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	_, ok := l.buckets[bucket]
+	return ok
+}
+
+// checkBucketObjectLimit - returns errBucketObjectLimit if object does
+// not exist and bucket holds as many objects as allowed, overwriting
+// existing objects is always allowed. isNew is true if bucket has a
+// limit and object does not exist, the caller is expected to count the
+// object once written. Must be called with a write lock held on object.
+//
+// Concurrent writes of new objects, and writes through other servers
+// until the next recount, may exceed the limit by as many objects.
+func checkBucketObjectLimit(objAPI ObjectLayer, bucket, object string) (isNew bool, err error) {
+	if !globalBucketObjectLimits.hasLimit(bucket) {
+		return false, nil
+	}
+
+	if _, err = objAPI.GetObjectInfo(bucket, object); err == nil {
+		return false, nil
+	}
+	if !isErrObjectNotFound(err) {
+		return false, err
+	}
+	if err = globalBucketObjectLimits.check(bucket); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// readBucketObjectLimit - reads the persisted object limit of bucket,
+// zero if none was set.
+func readBucketObjectLimit(bucket string, objAPI ObjectLayer) (int64, error) {
+	limitPath := pathJoin(bucketConfigPrefix, bucket, bucketObjectLimitConfig)
+
+	// Acquire a read lock on object limit config before reading.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, limitPath)
+	objLock.RLock()
+	defer objLock.RUnlock()
+
+	var buffer bytes.Buffer
+	if err := objAPI.GetObject(minioMetaBucket, limitPath, 0, -1, &buffer); err != nil {
+		if isErrObjectNotFound(err) || isErrIncompleteBody(err) {
+			return 0, nil
+		}
+		errorIf(err, "Unable to load object limit for the bucket %s.", bucket)
+		return 0, errorCause(err)
+	}
+
+	var config objectLimitConfig
+	if err := json.Unmarshal(buffer.Bytes(), &config); err != nil {
+		return 0, err
+	}
+	return config.MaxObjects, nil
+}
+
+// writeBucketObjectLimit - persists the object limit of bucket, zero
+// removes any previously persisted limit.
+func writeBucketObjectLimit(bucket string, objAPI ObjectLayer, maxObjects int64) error {
+	limitPath := pathJoin(bucketConfigPrefix, bucket, bucketObjectLimitConfig)
+
+	// Acquire a write lock on object limit config before modifying.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, limitPath)
+	objLock.Lock()
+	defer objLock.Unlock()
+
+	if maxObjects == 0 {
+		if err := objAPI.DeleteObject(minioMetaBucket, limitPath); err != nil && !isErrObjectNotFound(err) {
+			errorIf(err, "Unable to remove object limit of the bucket %s.", bucket)
+			return errorCause(err)
+		}
+		return nil
+	}
+
+	buf, err := json.Marshal(objectLimitConfig{MaxObjects: maxObjects})
+	if err != nil {
+		return err
+	}
+	if _, err = objAPI.PutObject(minioMetaBucket, limitPath, int64(len(buf)), bytes.NewReader(buf), nil, ""); err != nil {
+		errorIf(err, "Unable to set object limit for the bucket %s.", bucket)
+		return errorCause(err)
+	}
+	return nil
+}
+
+// removeBucketObjectLimit - removes persisted and in-memory object
+// limit of a bucket being deleted.
+func removeBucketObjectLimit(bucket string, objAPI ObjectLayer) error {
+	globalBucketObjectLimits.set(bucket, 0)
+	return writeBucketObjectLimit(bucket, objAPI, 0)
+}
+
+// initBucketObjectLimits - loads the object limits of all buckets.
+func initBucketObjectLimits(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	buckets, err := objAPI.ListBuckets()
+	if err != nil {
+		errorIf(err, "Unable to list buckets.")
+		return errorCause(err)
+	}
+
+	limits := make(map[string]int64)
+	for _, bucket := range buckets {
+		maxObjects, lErr := readBucketObjectLimit(bucket.Name, objAPI)
+		if lErr != nil {
+			if isErrIgnored(lErr, errDiskNotFound) {
+				continue
+			}
+			return lErr
+		}
+		if maxObjects > 0 {
+			limits[bucket.Name] = maxObjects
+		}
+	}
+
+	globalBucketObjectLimits = newBucketObjectLimits(limits)
+	return nil
+}
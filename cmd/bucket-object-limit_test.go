@@ -0,0 +1,75 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// waitForObjectCount - polls the object limit of bucket until its
+// objects were counted.
+func waitForObjectCount(t *testing.T, bucket string) BucketObjectLimit {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		limit := globalBucketObjectLimits.get(bucket)
+		if limit.Counted {
+			return limit
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Objects of %s not counted in time: %v", bucket, limit)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestBucketObjectLimitsSet - tests setting bucket object limits.
+func TestBucketObjectLimitsSet(t *testing.T) {
+	l := newBucketObjectLimits(nil)
+	if err := l.set("bucket", -1); err != errInvalidObjectLimit {
+		t.Fatalf("Expected %v, got %v", errInvalidObjectLimit, err)
+	}
+	if l.hasLimit("bucket") {
+		t.Fatal("Expected no limit")
+	}
+
+	// Without an object layer objects can not be counted, the limit
+	// is not enforced meanwhile.
+	if err := l.set("bucket", 1); err != nil {
+		t.Fatal(err)
+	}
+	l.add("bucket", 5)
+	if err := l.check("bucket"); err != nil {
+		t.Fatalf("Expected limit not to be enforced before counting, got %v", err)
+	}
+	if limit := l.get("bucket"); limit.MaxObjects != 1 || limit.Counted {
+		t.Fatalf("Unexpected limit %v", limit)
+	}
+
+	// Counts of buckets without a limit are not tracked.
+	l.add("other", 1)
+	if limit := l.get("other"); limit != (BucketObjectLimit{}) {
+		t.Fatalf("Expected no limit, got %v", limit)
+	}
+
+	if err := l.set("bucket", 0); err != nil {
+		t.Fatal(err)
+	}
+	if l.hasLimit("bucket") {
+		t.Fatal("Expected limit to be removed")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+)
+
+// errInvalidPartSizeLimits - part size limits are out of the S3 bounds
+// or the minimum is above the maximum.
+var errInvalidPartSizeLimits = errors.New("Part size limits must be within 5MiB and 5GiB, the minimum not above the maximum")
+
+// PartSizeLimits - sizes allowed for the parts of multipart uploads.
+// The last part of an upload may be smaller than the minimum.
+type PartSizeLimits struct {
+	MinPartSize int64 `json:"minPartSize"`
+	MaxPartSize int64 `json:"maxPartSize"`
+}
+
+// globalPartSizeLimits - part size limits of buckets without limits of
+// their own, the S3 bounds.
+var globalPartSizeLimits = PartSizeLimits{MinPartSize: minPartSize, MaxPartSize: maxObjectSize}
+
+// validatePartSizeLimits - returns errInvalidPartSizeLimits unless
+// limits are within the S3 bounds, which are the global limits.
+func validatePartSizeLimits(limits PartSizeLimits) error {
+	if limits.MinPartSize < globalPartSizeLimits.MinPartSize ||
+		limits.MaxPartSize > globalPartSizeLimits.MaxPartSize ||
+		limits.MinPartSize > limits.MaxPartSize {
+		return errInvalidPartSizeLimits
+	}
+	return nil
+}
+
+// bucketPartSizeLimits - part size limits of buckets, can be changed at
+// runtime via admin RPC.
+type bucketPartSizeLimits struct {
+	mutex   sync.RWMutex
+	buckets map[string]PartSizeLimits
+}
+
+func newBucketPartSizeLimits() *bucketPartSizeLimits {
+	return &bucketPartSizeLimits{buckets: make(map[string]PartSizeLimits)}
+}
+
+var globalBucketPartSizeLimits = newBucketPartSizeLimits()
+
+// get - returns the part size limits of bucket, the global limits if
+// it has none of its own.
+func (l *bucketPartSizeLimits) get(bucket string) PartSizeLimits {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	if limits, ok := l.buckets[bucket]; ok {
+		return limits
+	}
+	return globalPartSizeLimits
+}
+
+// set - sets the part size limits of bucket, both zero removes them.
+func (l *bucketPartSizeLimits) set(bucket string, limits PartSizeLimits) error {
+	if limits == (PartSizeLimits{}) {
+		l.mutex.Lock()
+		delete(l.buckets, bucket)
+		l.mutex.Unlock()
+		return nil
+	}
+	if err := validatePartSizeLimits(limits); err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.buckets[bucket] = limits
+	return nil
+}
+
+// isMinAllowedBucketPartSize - checks if a part of an upload to bucket,
+// other than the last one, is at least the minimum part size.
+func isMinAllowedBucketPartSize(bucket string, size int64) bool {
+	return size >= globalBucketPartSizeLimits.get(bucket).MinPartSize
+}
+
+// isMaxBucketPartSize - checks if a part of an upload to bucket is
+// above the maximum part size.
+func isMaxBucketPartSize(bucket string, size int64) bool {
+	return size > globalBucketPartSizeLimits.get(bucket).MaxPartSize
+}
@@ -0,0 +1,123 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestBucketPartSizeLimits - tests bucket limits override the global
+// ones and limits out of the S3 bounds are refused.
+func TestBucketPartSizeLimits(t *testing.T) {
+	limits := newBucketPartSizeLimits()
+	if got := limits.get("bucket"); got != globalPartSizeLimits {
+		t.Fatalf("Expected global limits %v, got %v", globalPartSizeLimits, got)
+	}
+
+	bucketLimits := PartSizeLimits{MinPartSize: 2 * minPartSize, MaxPartSize: maxObjectSize / 2}
+	if err := limits.set("bucket", bucketLimits); err != nil {
+		t.Fatal(err)
+	}
+	if got := limits.get("bucket"); got != bucketLimits {
+		t.Fatalf("Expected bucket limits %v, got %v", bucketLimits, got)
+	}
+	if got := limits.get("other"); got != globalPartSizeLimits {
+		t.Fatalf("Expected global limits %v, got %v", globalPartSizeLimits, got)
+	}
+
+	invalidLimits := []PartSizeLimits{
+		{MinPartSize: minPartSize - 1, MaxPartSize: maxObjectSize},
+		{MinPartSize: minPartSize, MaxPartSize: maxObjectSize + 1},
+		{MinPartSize: 3 * minPartSize, MaxPartSize: 2 * minPartSize},
+		{MinPartSize: 0, MaxPartSize: maxObjectSize},
+	}
+	for i, invalid := range invalidLimits {
+		if err := limits.set("bucket", invalid); err != errInvalidPartSizeLimits {
+			t.Errorf("Test %d: expected %v, got %v", i+1, errInvalidPartSizeLimits, err)
+		}
+	}
+	// Refused limits leave the previous ones in place.
+	if got := limits.get("bucket"); got != bucketLimits {
+		t.Fatalf("Expected bucket limits %v, got %v", bucketLimits, got)
+	}
+
+	if err := limits.set("bucket", PartSizeLimits{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := limits.get("bucket"); got != globalPartSizeLimits {
+		t.Fatalf("Expected global limits %v, got %v", globalPartSizeLimits, got)
+	}
+}
+
+// TestCompleteMultipartBucketPartSize - tests completing multipart
+// uploads validates parts against the limits of the bucket.
+func TestCompleteMultipartBucketPartSize(t *testing.T) {
+	defer resetGlobalBucketPartSizeLimits()
+
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	// Uploads two parts of the minimum part size.
+	upload := func() (string, []completePart) {
+		uploadID, uErr := obj.NewMultipartUpload(bucket, object, nil)
+		if uErr != nil {
+			t.Fatal(uErr)
+		}
+		data := bytes.Repeat([]byte("a"), minPartSize)
+		var parts []completePart
+		for partID := 1; partID <= 2; partID++ {
+			info, pErr := obj.PutObjectPart(bucket, object, uploadID, partID, int64(len(data)), bytes.NewReader(data), "", "")
+			if pErr != nil {
+				t.Fatal(pErr)
+			}
+			parts = append(parts, completePart{PartNumber: partID, ETag: info.ETag})
+		}
+		return uploadID, parts
+	}
+
+	uploadID, parts := upload()
+	if _, err = obj.CompleteMultipartUpload(bucket, object, uploadID, parts); err != nil {
+		t.Fatalf("Expected parts of the global minimum size to be allowed, got %v", err)
+	}
+
+	if err = globalBucketPartSizeLimits.set(bucket, PartSizeLimits{MinPartSize: 2 * minPartSize, MaxPartSize: maxObjectSize / 2}); err != nil {
+		t.Fatal(err)
+	}
+	uploadID, parts = upload()
+	_, err = obj.CompleteMultipartUpload(bucket, object, uploadID, parts)
+	if _, ok := errorCause(err).(PartTooSmall); !ok {
+		t.Fatalf("Expected PartTooSmall, got %v", err)
+	}
+	if !isMaxBucketPartSize(bucket, maxObjectSize/2+1) || isMaxBucketPartSize("other", maxObjectSize/2+1) {
+		t.Fatal("Expected the maximum part size of the bucket to apply to it only")
+	}
+}
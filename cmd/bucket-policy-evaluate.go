@@ -0,0 +1,69 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"strings"
+)
+
+// errInvalidPolicyEvaluation - the principal, action or resource to
+// evaluate the bucket policies for is not supported.
+var errInvalidPolicyEvaluation = errors.New("Policy evaluation needs principal * or the server access key, a supported action and a bucket resource")
+
+// PolicyDecision - outcome of evaluating the bucket policies for a
+// request without sending it.
+type PolicyDecision struct {
+	Allowed bool `json:"allowed"`
+	// Statements which decided the outcome, none when no statement
+	// matched or the principal is not subject to bucket policies.
+	Statements []policyStatement `json:"statements,omitempty"`
+}
+
+// evaluateBucketPolicy - evaluates policies as if principal sent a
+// request for action on resource, without any condition keys. Minio
+// does not implement IAM: bucket policies only apply to anonymous
+// requests, principal "*", while requests signed with the server
+// credential are allowed everything.
+func evaluateBucketPolicy(policies *bucketPolicies, principal, action, resource string) (PolicyDecision, error) {
+	if action == "*" || action == "s3:*" || !supportedActionMap.Contains(action) {
+		return PolicyDecision{}, errInvalidPolicyEvaluation
+	}
+
+	// Resource is either 'arn:aws:s3:::bucket/object' or 'bucket/object'.
+	resource = strings.TrimPrefix(resource, bucketARNPrefix)
+	bucket := strings.SplitN(resource, "/", 2)[0]
+	if !IsValidBucketName(bucket) {
+		return PolicyDecision{}, errInvalidPolicyEvaluation
+	}
+
+	switch principal {
+	case "*":
+	case serverConfig.GetCredential().AccessKey:
+		return PolicyDecision{Allowed: true}, nil
+	default:
+		return PolicyDecision{}, errInvalidPolicyEvaluation
+	}
+
+	policy := policies.GetBucketPolicy(bucket)
+	if policy == nil {
+		// No policy denies anonymous requests.
+		return PolicyDecision{}, nil
+	}
+	allowed, statements := bucketPolicyDecide(action, bucketARNPrefix+resource, nil, policy.Statements)
+	return PolicyDecision{Allowed: allowed, Statements: statements}, nil
+}
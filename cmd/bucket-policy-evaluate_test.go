@@ -0,0 +1,116 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/minio/minio-go/pkg/set"
+)
+
+// TestEvaluateBucketPolicy - tests the policy dry-run with overlapping
+// Allow and Deny statements.
+func TestEvaluateBucketPolicy(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	allowAll := policyStatement{
+		Sid:       "allow-all",
+		Actions:   set.CreateStringSet("s3:GetObject", "s3:PutObject"),
+		Effect:    "Allow",
+		Principal: "*",
+		Resources: set.CreateStringSet(bucketARNPrefix + "bucket/*"),
+	}
+	denyPrivate := policyStatement{
+		Sid:       "deny-private",
+		Actions:   set.CreateStringSet("s3:GetObject"),
+		Effect:    "Deny",
+		Principal: "*",
+		Resources: set.CreateStringSet(bucketARNPrefix + "bucket/private/*"),
+	}
+	allowPrivate := policyStatement{
+		Sid:       "allow-private",
+		Actions:   set.CreateStringSet("s3:GetObject"),
+		Effect:    "Allow",
+		Principal: "*",
+		Resources: set.CreateStringSet(bucketARNPrefix + "bucket/private/*"),
+	}
+	policies := &bucketPolicies{
+		rwMutex: &sync.RWMutex{},
+		bucketPolicyConfigs: map[string]*bucketPolicy{
+			// The Deny is listed last, it still wins.
+			"bucket": {Statements: []policyStatement{allowAll, allowPrivate, denyPrivate}},
+		},
+	}
+
+	testCases := []struct {
+		principal, action, resource string
+		allowed                     bool
+		sids                        []string
+	}{
+		{"*", "s3:GetObject", "bucket/public/object", true, []string{"allow-all"}},
+		{"*", "s3:GetObject", bucketARNPrefix + "bucket/public/object", true, []string{"allow-all"}},
+		{"*", "s3:GetObject", "bucket/private/object", false, []string{"deny-private"}},
+		{"*", "s3:PutObject", "bucket/private/object", true, []string{"allow-all"}},
+		// No matching statement denies.
+		{"*", "s3:DeleteObject", "bucket/public/object", false, nil},
+		// No policy on the bucket denies.
+		{"*", "s3:GetObject", "other/object", false, nil},
+		// The server credential is not subject to bucket policies.
+		{serverConfig.GetCredential().AccessKey, "s3:GetObject", "bucket/private/object", true, nil},
+	}
+	for i, testCase := range testCases {
+		decision, err := evaluateBucketPolicy(policies, testCase.principal, testCase.action, testCase.resource)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if decision.Allowed != testCase.allowed {
+			t.Errorf("Test %d: expected allowed %v, got %v", i+1, testCase.allowed, decision.Allowed)
+		}
+		var sids []string
+		for _, statement := range decision.Statements {
+			sids = append(sids, statement.Sid)
+		}
+		if len(sids) != len(testCase.sids) || (len(sids) > 0 && sids[0] != testCase.sids[0]) {
+			t.Errorf("Test %d: expected statements %v, got %v", i+1, testCase.sids, sids)
+		}
+	}
+
+	// Real requests are decided the same way.
+	if bucketPolicyEvalStatements("s3:GetObject", bucketARNPrefix+"bucket/private/object", nil,
+		policies.GetBucketPolicy("bucket").Statements) {
+		t.Fatal("Expected the explicit Deny to win")
+	}
+
+	invalidCases := []struct {
+		principal, action, resource string
+	}{
+		{"someuser", "s3:GetObject", "bucket/object"},
+		{"*", "s3:*", "bucket/object"},
+		{"*", "s3:GetBucketPolicy", "bucket/object"},
+		{"*", "s3:GetObject", ""},
+	}
+	for i, testCase := range invalidCases {
+		if _, err := evaluateBucketPolicy(policies, testCase.principal, testCase.action, testCase.resource); err != errInvalidPolicyEvaluation {
+			t.Errorf("Invalid case %d: expected %v, got %v", i+1, errInvalidPolicyEvaluation, err)
+		}
+	}
+}
@@ -36,18 +36,31 @@ const maxAccessPolicySize = 20 * humanize.KiByte
 // Verify if a given action is valid for the url path based on the
 // existing bucket access policy.
 func bucketPolicyEvalStatements(action string, resource string, conditions map[string]set.StringSet, statements []policyStatement) bool {
+	allowed, _ := bucketPolicyDecide(action, resource, conditions, statements)
+	return allowed
+}
+
+// bucketPolicyDecide - returns true if the statements allow action on
+// resource, along with the statements which decided so. An explicit
+// Deny wins over any Allow whatever the order of the statements, the
+// matching Deny statements are returned then. Otherwise the matching
+// Allow statements are returned, none match denies.
+func bucketPolicyDecide(action string, resource string, conditions map[string]set.StringSet, statements []policyStatement) (bool, []policyStatement) {
+	var allows, denies []policyStatement
 	for _, statement := range statements {
-		if bucketPolicyMatchStatement(action, resource, conditions, statement) {
-			if statement.Effect == "Allow" {
-				return true
-			}
-			// Do not uncomment kept here for readability.
-			// else statement.Effect == "Deny"
-			return false
+		if !bucketPolicyMatchStatement(action, resource, conditions, statement) {
+			continue
 		}
+		if statement.Effect == "Allow" {
+			allows = append(allows, statement)
+		} else {
+			denies = append(denies, statement)
+		}
+	}
+	if len(denies) > 0 {
+		return false, denies
 	}
-	// None match so deny.
-	return false
+	return len(allows) > 0, allows
 }
 
 // Verify if action, resource and conditions match input policy statement.
@@ -0,0 +1,186 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// Bucket tags config name.
+	bucketTagsConfig = "tagging.json"
+
+	// Limits on bucket tags, same as S3.
+	maxBucketTags        = 50
+	maxBucketTagKeyLen   = 128
+	maxBucketTagValueLen = 256
+)
+
+// errInvalidBucketTags - bucket tags exceed the S3 limits.
+var errInvalidBucketTags = errors.New("Bucket tags must be at most 50, with non empty keys of at most 128 and values of at most 256 characters")
+
+// validateBucketTags - validates tags against the S3 bucket tag limits.
+func validateBucketTags(tags map[string]string) error {
+	if len(tags) > maxBucketTags {
+		return errInvalidBucketTags
+	}
+	for key, value := range tags {
+		keyLen := utf8.RuneCountInString(key)
+		if keyLen == 0 || keyLen > maxBucketTagKeyLen {
+			return errInvalidBucketTags
+		}
+		if utf8.RuneCountInString(value) > maxBucketTagValueLen {
+			return errInvalidBucketTags
+		}
+	}
+	return nil
+}
+
+// bucketTags - tags of all buckets, kept in memory on every server and
+// persisted under the bucket config prefix.
+type bucketTags struct {
+	mutex sync.RWMutex
+	tags  map[string]map[string]string
+}
+
+var globalBucketTags = newBucketTags(nil)
+
+// newBucketTags - returns bucket tags initialized with tags.
+func newBucketTags(tags map[string]map[string]string) *bucketTags {
+	if tags == nil {
+		tags = make(map[string]map[string]string)
+	}
+	return &bucketTags{tags: tags}
+}
+
+// get - returns a copy of the tags of bucket.
+func (b *bucketTags) get(bucket string) map[string]string {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	tags := make(map[string]string, len(b.tags[bucket]))
+	for key, value := range b.tags[bucket] {
+		tags[key] = value
+	}
+	return tags
+}
+
+// set - replaces the tags of bucket, no tags removes them.
+func (b *bucketTags) set(bucket string, tags map[string]string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if len(tags) == 0 {
+		delete(b.tags, bucket)
+		return
+	}
+	b.tags[bucket] = tags
+}
+
+// readBucketTags - reads the persisted tags of bucket, no tags if none
+// were set.
+func readBucketTags(bucket string, objAPI ObjectLayer) (map[string]string, error) {
+	tagsPath := pathJoin(bucketConfigPrefix, bucket, bucketTagsConfig)
+
+	// Acquire a read lock on tags config before reading.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, tagsPath)
+	objLock.RLock()
+	defer objLock.RUnlock()
+
+	var buffer bytes.Buffer
+	if err := objAPI.GetObject(minioMetaBucket, tagsPath, 0, -1, &buffer); err != nil {
+		if isErrObjectNotFound(err) || isErrIncompleteBody(err) {
+			return nil, nil
+		}
+		errorIf(err, "Unable to load tags for the bucket %s.", bucket)
+		return nil, errorCause(err)
+	}
+
+	tags := make(map[string]string)
+	if err := json.Unmarshal(buffer.Bytes(), &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// writeBucketTags - persists tags of bucket, assumed to be validated.
+// No tags removes any previously persisted tags.
+func writeBucketTags(bucket string, objAPI ObjectLayer, tags map[string]string) error {
+	tagsPath := pathJoin(bucketConfigPrefix, bucket, bucketTagsConfig)
+
+	// Acquire a write lock on tags config before modifying.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, tagsPath)
+	objLock.Lock()
+	defer objLock.Unlock()
+
+	if len(tags) == 0 {
+		if err := objAPI.DeleteObject(minioMetaBucket, tagsPath); err != nil && !isErrObjectNotFound(err) {
+			errorIf(err, "Unable to remove tags of the bucket %s.", bucket)
+			return errorCause(err)
+		}
+		return nil
+	}
+
+	buf, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+	if _, err = objAPI.PutObject(minioMetaBucket, tagsPath, int64(len(buf)), bytes.NewReader(buf), nil, ""); err != nil {
+		errorIf(err, "Unable to set tags for the bucket %s.", bucket)
+		return errorCause(err)
+	}
+	return nil
+}
+
+// removeBucketTags - removes persisted and in-memory tags of a bucket
+// being deleted.
+func removeBucketTags(bucket string, objAPI ObjectLayer) error {
+	globalBucketTags.set(bucket, nil)
+	return writeBucketTags(bucket, objAPI, nil)
+}
+
+// initBucketTags - loads the tags of all buckets.
+func initBucketTags(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	buckets, err := objAPI.ListBuckets()
+	if err != nil {
+		errorIf(err, "Unable to list buckets.")
+		return errorCause(err)
+	}
+
+	allTags := make(map[string]map[string]string)
+	for _, bucket := range buckets {
+		tags, tErr := readBucketTags(bucket.Name, objAPI)
+		if tErr != nil {
+			if isErrIgnored(tErr, errDiskNotFound) {
+				continue
+			}
+			return tErr
+		}
+		if len(tags) > 0 {
+			allTags[bucket.Name] = tags
+		}
+	}
+
+	globalBucketTags = newBucketTags(allTags)
+	return nil
+}
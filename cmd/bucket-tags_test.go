@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestValidateBucketTags - tests bucket tags against S3 limits.
+func TestValidateBucketTags(t *testing.T) {
+	tooManyTags := make(map[string]string)
+	for i := 0; i <= maxBucketTags; i++ {
+		tooManyTags[fmt.Sprintf("key%d", i)] = "value"
+	}
+	maxTags := make(map[string]string)
+	for i := 0; i < maxBucketTags; i++ {
+		maxTags[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	testCases := []struct {
+		tags map[string]string
+		err  error
+	}{
+		{nil, nil},
+		{map[string]string{"team": "storage", "cost-center": ""}, nil},
+		{maxTags, nil},
+		{map[string]string{strings.Repeat("k", maxBucketTagKeyLen): strings.Repeat("v", maxBucketTagValueLen)}, nil},
+		// Multi-byte characters count once.
+		{map[string]string{strings.Repeat("ü", maxBucketTagKeyLen): "value"}, nil},
+		{tooManyTags, errInvalidBucketTags},
+		{map[string]string{"": "value"}, errInvalidBucketTags},
+		{map[string]string{strings.Repeat("k", maxBucketTagKeyLen+1): "value"}, errInvalidBucketTags},
+		{map[string]string{"key": strings.Repeat("v", maxBucketTagValueLen+1)}, errInvalidBucketTags},
+	}
+	for i, testCase := range testCases {
+		if err := validateBucketTags(testCase.tags); err != testCase.err {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.err, err)
+		}
+	}
+}
+
+// Wrapper for calling bucket tags persistence tests for both XL
+// multiple disks and single node setup.
+func TestBucketTagsPersistence(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketTagsPersistence)
+}
+
+func testBucketTagsPersistence(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucket := "tagged-bucket"
+	if err := obj.MakeBucket(bucket); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	tags, err := readBucketTags(bucket, obj)
+	if err != nil || len(tags) != 0 {
+		t.Fatalf("%s: Expected no tags, got %v, %v", instanceType, tags, err)
+	}
+
+	expected := map[string]string{"team": "storage", "env": "prod"}
+	if err = writeBucketTags(bucket, obj, expected); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if tags, err = readBucketTags(bucket, obj); err != nil || !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("%s: Expected %v, got %v, %v", instanceType, expected, tags, err)
+	}
+
+	// Tags are loaded into memory on startup.
+	if err = initBucketTags(obj); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if tags = globalBucketTags.get(bucket); !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, expected, tags)
+	}
+
+	// Removing tags, as done when deleting the bucket.
+	if err = removeBucketTags(bucket, obj); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if tags = globalBucketTags.get(bucket); len(tags) != 0 {
+		t.Fatalf("%s: Expected no tags in memory, got %v", instanceType, tags)
+	}
+	if tags, err = readBucketTags(bucket, obj); err != nil || len(tags) != 0 {
+		t.Fatalf("%s: Expected no persisted tags, got %v, %v", instanceType, tags, err)
+	}
+	// Removing twice is not an error.
+	if err = removeBucketTags(bucket, obj); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+}
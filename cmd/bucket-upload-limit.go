@@ -0,0 +1,120 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// errInvalidUploadLimit - concurrent upload limit is negative.
+	errInvalidUploadLimit = errors.New("Concurrent upload limit must not be negative")
+
+	// errTooManyUploads - bucket has as many multipart uploads in
+	// progress as its limit allows.
+	errTooManyUploads = errors.New("Bucket has the maximum number of multipart uploads in progress")
+)
+
+// BucketUploadLimit - maximum number of multipart uploads in progress
+// in a bucket, zero if unlimited.
+type BucketUploadLimit struct {
+	MaxUploads int `json:"maxUploads"`
+}
+
+// bucketUploadLimits - concurrent upload limits of all buckets, can be
+// changed at runtime via admin RPC. Limits are kept in memory only, a
+// restart forgets them.
+type bucketUploadLimits struct {
+	mutex  sync.RWMutex
+	limits map[string]int
+}
+
+func newBucketUploadLimits() *bucketUploadLimits {
+	return &bucketUploadLimits{limits: make(map[string]int)}
+}
+
+var globalBucketUploadLimits = newBucketUploadLimits()
+
+// get - returns the concurrent upload limit of bucket, zero if
+// unlimited.
+func (l *bucketUploadLimits) get(bucket string) int {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.limits[bucket]
+}
+
+// set - sets the concurrent upload limit of bucket, zero removes it.
+func (l *bucketUploadLimits) set(bucket string, maxUploads int) error {
+	if maxUploads < 0 {
+		return errInvalidUploadLimit
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if maxUploads == 0 {
+		delete(l.limits, bucket)
+		return nil
+	}
+	l.limits[bucket] = maxUploads
+	return nil
+}
+
+// countMultipartUploads - returns the number of multipart uploads in
+// progress in bucket, counting no further than upTo.
+func countMultipartUploads(objAPI ObjectLayer, bucket string, upTo int) (int, error) {
+	count := 0
+	keyMarker, uploadIDMarker := "", ""
+	for count < upTo {
+		result, err := objAPI.ListMultipartUploads(bucket, "", keyMarker, uploadIDMarker, "", maxUploadsList)
+		if err != nil {
+			return 0, err
+		}
+		count += len(result.Uploads)
+		if !result.IsTruncated {
+			break
+		}
+		keyMarker, uploadIDMarker = result.NextKeyMarker, result.NextUploadIDMarker
+	}
+	return count, nil
+}
+
+// newLimitedMultipartUpload - initiates a multipart upload of object,
+// unless bucket has as many uploads in progress as its limit allows in
+// which case errTooManyUploads is returned. Uploads in progress are
+// those listed by the object layer, which every server shares, so the
+// limit holds across the cluster. Initiations of a bucket with a limit
+// are serialized by a lock on the bucket, completed, aborted and
+// expired uploads free their slot.
+func newLimitedMultipartUpload(objAPI ObjectLayer, bucket, object string, metadata map[string]string) (string, error) {
+	maxUploads := globalBucketUploadLimits.get(bucket)
+	if maxUploads == 0 {
+		return objAPI.NewMultipartUpload(bucket, object, metadata)
+	}
+
+	uploadsLock := globalNSMutex.NewNSLock(minioMetaMultipartBucket, bucket)
+	uploadsLock.Lock()
+	defer uploadsLock.Unlock()
+
+	count, err := countMultipartUploads(objAPI, bucket, maxUploads)
+	if err != nil {
+		return "", err
+	}
+	if count >= maxUploads {
+		return "", errTooManyUploads
+	}
+	return objAPI.NewMultipartUpload(bucket, object, metadata)
+}
@@ -0,0 +1,107 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestBucketUploadLimitsSet - tests setting and removing limits.
+func TestBucketUploadLimitsSet(t *testing.T) {
+	limits := newBucketUploadLimits()
+	if err := limits.set("bucket", -1); err != errInvalidUploadLimit {
+		t.Fatalf("Expected %v, got %v", errInvalidUploadLimit, err)
+	}
+	if err := limits.set("bucket", 5); err != nil {
+		t.Fatal(err)
+	}
+	if maxUploads := limits.get("bucket"); maxUploads != 5 {
+		t.Fatalf("Expected 5, got %d", maxUploads)
+	}
+	if err := limits.set("bucket", 0); err != nil {
+		t.Fatal(err)
+	}
+	if maxUploads := limits.get("bucket"); maxUploads != 0 {
+		t.Fatalf("Expected no limit, got %d", maxUploads)
+	}
+}
+
+// Wrapper for calling newLimitedMultipartUpload tests for both XL
+// multiple disks and single node setup.
+func TestNewLimitedMultipartUpload(t *testing.T) {
+	ExecObjectLayerTest(t, testNewLimitedMultipartUpload)
+}
+
+// testNewLimitedMultipartUpload - tests new uploads are rejected at
+// the limit, and completed or aborted uploads free their slot.
+func testNewLimitedMultipartUpload(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	defer resetGlobalBucketUploadLimits()
+
+	bucket, other := getRandomBucketName(), getRandomBucketName()
+	for _, b := range []string{bucket, other} {
+		if err := obj.MakeBucket(b); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+	if err := globalBucketUploadLimits.set(bucket, 2); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	var uploadIDs []string
+	for _, object := range []string{"object1", "object2"} {
+		uploadID, err := newLimitedMultipartUpload(obj, bucket, object, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		uploadIDs = append(uploadIDs, uploadID)
+	}
+	if _, err := newLimitedMultipartUpload(obj, bucket, "object3", nil); err != errTooManyUploads {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, errTooManyUploads, err)
+	}
+	if code := toAPIErrorCode(errTooManyUploads); getAPIError(code).HTTPStatusCode != 503 {
+		t.Fatalf("%s: Expected a retryable error, got %v", instanceType, getAPIError(code))
+	}
+	// Other buckets are not limited.
+	if _, err := newLimitedMultipartUpload(obj, other, "object3", nil); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	// Completing an upload frees its slot.
+	data := []byte("part")
+	md5Hex := getMD5Hash(data)
+	if _, err := obj.PutObjectPart(bucket, "object1", uploadIDs[0], 1, int64(len(data)), bytes.NewReader(data), md5Hex, ""); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err := obj.CompleteMultipartUpload(bucket, "object1", uploadIDs[0], []completePart{{PartNumber: 1, ETag: md5Hex}}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err := newLimitedMultipartUpload(obj, bucket, "object3", nil); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err := newLimitedMultipartUpload(obj, bucket, "object4", nil); err != errTooManyUploads {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, errTooManyUploads, err)
+	}
+
+	// Aborting an upload frees its slot.
+	if err := obj.AbortMultipartUpload(bucket, "object2", uploadIDs[1]); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err := newLimitedMultipartUpload(obj, bucket, "object4", nil); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+}
@@ -0,0 +1,166 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"time"
+)
+
+// Status of a capacity forecast.
+const (
+	capacityForecastOK               = "ok"
+	capacityForecastInsufficientData = "insufficient data"
+	capacityForecastNoGrowth         = "no growth"
+)
+
+// Growth is measured, and fullness projected, in days.
+const oneDay = 24 * time.Hour
+
+// CapacityForecast - projection of the days left until storage is full
+// at the growth rate measured by usage scans. DaysUntilFull is only set
+// with status ok.
+type CapacityForecast struct {
+	Status        string  `json:"status"`
+	Free          int64   `json:"free"`
+	GrowthPerDay  int64   `json:"growthPerDay"`
+	DaysUntilFull float64 `json:"daysUntilFull,omitempty"`
+}
+
+// NodeCapacityForecast - projection of the days left until the local
+// disks of a server are full.
+type NodeCapacityForecast struct {
+	Addr string `json:"addr"`
+	CapacityForecast
+}
+
+// ClusterCapacityForecast - projection of the days left until the
+// cluster is full, out of the usage scans started within Window, along
+// with the projection of every server.
+type ClusterCapacityForecast struct {
+	Window time.Duration `json:"window"`
+	CapacityForecast
+	Nodes []NodeCapacityForecast `json:"nodes"`
+	// Servers which could not be reached, growth of the parts of
+	// the buckets they scanned is not accounted for.
+	Unreachable []string `json:"unreachable,omitempty"`
+}
+
+// nodeCapacity - usage scans run by a server and the free space of its
+// local disks.
+type nodeCapacity struct {
+	Addr  string
+	Scans []UsageScanStatus
+	// Local disks, zero if the backend is not erasure coded.
+	Disks int
+	Free  int64
+}
+
+// scanGrowthPerDay - returns the growth per day of the bytes counted by
+// scans started within window before now, summing the growth of every
+// bucket between its first and its last complete scan. Returns false
+// if no bucket was scanned twice.
+func scanGrowthPerDay(scans []UsageScanStatus, window time.Duration, now time.Time) (float64, bool) {
+	first := make(map[string]UsageScanStatus)
+	last := make(map[string]UsageScanStatus)
+	for _, scan := range scans {
+		if !scan.Done || scan.Error != "" || now.Sub(scan.Started) > window {
+			continue
+		}
+		if f, ok := first[scan.Bucket]; !ok || scan.Started.Before(f.Started) {
+			first[scan.Bucket] = scan
+		}
+		if l, ok := last[scan.Bucket]; !ok || scan.Started.After(l.Started) {
+			last[scan.Bucket] = scan
+		}
+	}
+
+	var growth float64
+	measured := false
+	for bucket, f := range first {
+		l := last[bucket]
+		elapsed := l.Started.Sub(f.Started)
+		if elapsed <= 0 {
+			continue
+		}
+		growth += float64(l.Bytes-f.Bytes) / (float64(elapsed) / float64(oneDay))
+		measured = true
+	}
+	return growth, measured
+}
+
+// newCapacityForecastOf - returns the forecast of free bytes growing
+// by growthPerDay, nothing is projected for flat or shrinking usage.
+func newCapacityForecastOf(free int64, growthPerDay float64) CapacityForecast {
+	forecast := CapacityForecast{Status: capacityForecastOK, Free: free, GrowthPerDay: int64(growthPerDay)}
+	if growthPerDay < 1 {
+		forecast.Status = capacityForecastNoGrowth
+		return forecast
+	}
+	forecast.DaysUntilFull = float64(free) / growthPerDay
+	return forecast
+}
+
+// newClusterCapacityForecast - returns the forecast of a cluster made
+// of nodes whose usable space is storage. Every server scans a part of
+// a bucket, so the growth of the cluster is the sum of the growth of
+// the parts. Erasure coded objects take twice their size spread evenly
+// over all disks, so a server fills at its share of that.
+func newClusterCapacityForecast(nodes []nodeCapacity, unreachable []string, storage StorageInfo, window time.Duration, now time.Time) ClusterCapacityForecast {
+	cluster := ClusterCapacityForecast{
+		Window:      window,
+		Nodes:       []NodeCapacityForecast{},
+		Unreachable: unreachable,
+	}
+
+	var growth float64
+	measured := false
+	for _, node := range nodes {
+		if nodeGrowth, ok := scanGrowthPerDay(node.Scans, window, now); ok {
+			growth += nodeGrowth
+			measured = true
+		}
+	}
+	if !measured {
+		cluster.CapacityForecast = CapacityForecast{Status: capacityForecastInsufficientData, Free: storage.Free}
+	} else {
+		cluster.CapacityForecast = newCapacityForecastOf(storage.Free, growth)
+	}
+
+	totalDisks := storage.Backend.OnlineDisks + storage.Backend.OfflineDisks
+	for _, node := range nodes {
+		forecast := NodeCapacityForecast{Addr: node.Addr}
+		switch {
+		case !measured:
+			forecast.CapacityForecast = CapacityForecast{Status: capacityForecastInsufficientData, Free: node.Free}
+		case node.Disks == 0 || totalDisks == 0:
+			forecast.CapacityForecast = newCapacityForecastOf(node.Free, growth)
+		default:
+			forecast.CapacityForecast = newCapacityForecastOf(node.Free, 2*growth*float64(node.Disks)/float64(totalDisks))
+		}
+		cluster.Nodes = append(cluster.Nodes, forecast)
+	}
+	sort.Sort(byNodeForecastAddr(cluster.Nodes))
+	return cluster
+}
+
+// byNodeForecastAddr - sorts node forecasts by address.
+type byNodeForecastAddr []NodeCapacityForecast
+
+func (n byNodeForecastAddr) Len() int           { return len(n) }
+func (n byNodeForecastAddr) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+func (n byNodeForecastAddr) Less(i, j int) bool { return n[i].Addr < n[j].Addr }
@@ -0,0 +1,122 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNewClusterCapacityForecast - tests the days until full projected
+// out of synthetic usage growth.
+func TestNewClusterCapacityForecast(t *testing.T) {
+	const gib = int64(1 << 30)
+	now := time.Now().UTC()
+	// scans - daily scans of bucket over the last days, counting
+	// bytes(i) on day i.
+	scans := func(bucket string, days int, bytes func(i int) int64) []UsageScanStatus {
+		var series []UsageScanStatus
+		for i := 0; i < days; i++ {
+			series = append(series, UsageScanStatus{
+				ID:      bucket + string(rune('a'+i)),
+				Bucket:  bucket,
+				Started: now.Add(-time.Duration(days-1-i) * oneDay),
+				Bytes:   bytes(i),
+				Done:    true,
+			})
+		}
+		return series
+	}
+	growing := func(start, perDay int64) func(int) int64 {
+		return func(i int) int64 { return start + int64(i)*perDay }
+	}
+
+	// 16 disks over 2 servers, 100GiB usable space left.
+	storage := StorageInfo{Total: 400 * gib, Free: 100 * gib}
+	storage.Backend.Type = Erasure
+	storage.Backend.OnlineDisks = 16
+
+	// Each server counted its part of two buckets, growing by
+	// 1GiB + 2GiB and 1GiB + 1GiB a day, 5GiB in all.
+	nodes := []nodeCapacity{
+		{
+			Addr:  "server1:9000",
+			Scans: append(scans("bucket1", 7, growing(10*gib, gib)), scans("bucket2", 7, growing(0, 2*gib))...),
+			Disks: 8,
+			Free:  40 * gib,
+		},
+		{
+			Addr:  "server0:9000",
+			Scans: append(scans("bucket1", 7, growing(10*gib, gib)), scans("bucket2", 7, growing(0, gib))...),
+			Disks: 8,
+			Free:  100 * gib,
+		},
+	}
+	forecast := newClusterCapacityForecast(nodes, nil, storage, 7*oneDay, now)
+	if forecast.Status != capacityForecastOK || forecast.GrowthPerDay != 5*gib || forecast.DaysUntilFull != 20 {
+		t.Fatalf("Expected the cluster full in 20 days at 5GiB a day, got %#v", forecast.CapacityForecast)
+	}
+	// Each server holds half of the disks, filling by 5GiB a day,
+	// twice the growth of the objects.
+	if len(forecast.Nodes) != 2 || forecast.Nodes[0].Addr != "server0:9000" {
+		t.Fatalf("Expected forecasts of both servers sorted, got %v", forecast.Nodes)
+	}
+	if forecast.Nodes[0].DaysUntilFull != 20 || forecast.Nodes[1].DaysUntilFull != 8 {
+		t.Fatalf("Expected servers full in 20 and 8 days, got %v", forecast.Nodes)
+	}
+
+	// Growth is measured over the window only, it was flat in the
+	// last 3 days.
+	nodes = []nodeCapacity{{
+		Addr: "server0:9000",
+		Scans: scans("bucket1", 7, func(i int) int64 {
+			if i < 4 {
+				return int64(i) * gib
+			}
+			return 3 * gib
+		}),
+	}}
+	forecast = newClusterCapacityForecast(nodes, nil, storage, 3*oneDay, now)
+	if forecast.Status != capacityForecastNoGrowth || forecast.DaysUntilFull != 0 {
+		t.Fatalf("Expected no growth, got %#v", forecast.CapacityForecast)
+	}
+	forecast = newClusterCapacityForecast(nodes, nil, storage, 7*oneDay, now)
+	if forecast.Status != capacityForecastOK || forecast.GrowthPerDay != gib/2 {
+		t.Fatalf("Expected growth of 0.5GiB a day, got %#v", forecast.CapacityForecast)
+	}
+
+	// Shrinking usage projects nothing either.
+	nodes[0].Scans = scans("bucket1", 3, growing(10*gib, -gib))
+	forecast = newClusterCapacityForecast(nodes, nil, storage, 7*oneDay, now)
+	if forecast.Status != capacityForecastNoGrowth || forecast.DaysUntilFull != 0 || forecast.Nodes[0].Status != capacityForecastNoGrowth {
+		t.Fatalf("Expected no growth, got %#v", forecast)
+	}
+
+	// A single scan, or scans which failed or still run, tell
+	// nothing about growth.
+	nodes[0].Scans = scans("bucket1", 1, growing(gib, 0))
+	nodes[0].Scans = append(nodes[0].Scans,
+		UsageScanStatus{ID: "failed", Bucket: "bucket1", Started: now, Bytes: 2 * gib, Done: true, Error: "disk not found"},
+		UsageScanStatus{ID: "running", Bucket: "bucket1", Started: now, Bytes: 3 * gib})
+	forecast = newClusterCapacityForecast(nodes, []string{"server1:9000"}, storage, 7*oneDay, now)
+	if forecast.Status != capacityForecastInsufficientData || forecast.DaysUntilFull != 0 {
+		t.Fatalf("Expected insufficient data, got %#v", forecast.CapacityForecast)
+	}
+	if forecast.Nodes[0].Status != capacityForecastInsufficientData || len(forecast.Unreachable) != 1 {
+		t.Fatalf("Expected insufficient data for the server, got %#v", forecast)
+	}
+}
@@ -0,0 +1,264 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Checksum policy config name.
+const checksumPolicyConfig = "checksum-policy.json"
+
+// Algorithms of the object checksums clients may send in a
+// x-amz-checksum-<algorithm> header, as the base64 encoded digest.
+var supportedChecksumAlgorithms = []string{"CRC32", "CRC32C", "SHA1", "SHA256"}
+
+var (
+	// errInvalidChecksumPolicy - checksum policy names no algorithm
+	// or an unsupported one.
+	errInvalidChecksumPolicy = errors.New("Checksum policy needs one or more supported algorithms")
+
+	// errMissingChecksum - the checksum policy requires an object
+	// checksum which was not sent.
+	errMissingChecksum = errors.New("Object checksum is required")
+
+	// errInvalidChecksum - object checksum is not the base64 encoded
+	// digest of its algorithm.
+	errInvalidChecksum = errors.New("Object checksum is malformed")
+
+	// errChecksumMismatch - object checksum does not match the
+	// received data.
+	errChecksumMismatch = errors.New("Object checksum does not match the received data")
+)
+
+// ChecksumPolicy - algorithms of the object checksums verified and
+// stored on upload, and whether uploads must send one.
+type ChecksumPolicy struct {
+	Required   bool     `json:"required"`
+	Algorithms []string `json:"algorithms"`
+}
+
+// checksumPolicy - checksum policy of this server, can be changed at
+// runtime via admin RPC. Checksums of all supported algorithms are
+// verified, but not required, by default.
+type checksumPolicy struct {
+	mutex  sync.RWMutex
+	policy ChecksumPolicy
+}
+
+func newChecksumPolicy() *checksumPolicy {
+	return &checksumPolicy{policy: ChecksumPolicy{Algorithms: supportedChecksumAlgorithms}}
+}
+
+var globalChecksumPolicy = newChecksumPolicy()
+
+// get - returns a copy of the current policy.
+func (c *checksumPolicy) get() ChecksumPolicy {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return ChecksumPolicy{
+		Required:   c.policy.Required,
+		Algorithms: append([]string{}, c.policy.Algorithms...),
+	}
+}
+
+// set - sets the policy, algorithms are case insensitive.
+func (c *checksumPolicy) set(required bool, algorithms []string) error {
+	algorithms, err := parseChecksumAlgorithms(algorithms)
+	if err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.policy = ChecksumPolicy{Required: required, Algorithms: algorithms}
+	return nil
+}
+
+// parseChecksumAlgorithms - returns algorithms upper cased without
+// duplicates, errInvalidChecksumPolicy if none or an unsupported one
+// is given.
+func parseChecksumAlgorithms(algorithms []string) ([]string, error) {
+	var parsed []string
+	for _, algorithm := range algorithms {
+		algorithm = strings.ToUpper(strings.TrimSpace(algorithm))
+		if !isChecksumAlgorithmSupported(algorithm) {
+			return nil, errInvalidChecksumPolicy
+		}
+		if !isChecksumAlgorithmIn(algorithm, parsed) {
+			parsed = append(parsed, algorithm)
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, errInvalidChecksumPolicy
+	}
+	return parsed, nil
+}
+
+func isChecksumAlgorithmSupported(algorithm string) bool {
+	return isChecksumAlgorithmIn(algorithm, supportedChecksumAlgorithms)
+}
+
+func isChecksumAlgorithmIn(algorithm string, algorithms []string) bool {
+	for _, a := range algorithms {
+		if a == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// writeChecksumPolicy - persists the checksum policy, the default
+// policy verifying all supported algorithms without requiring any
+// removes any previously persisted policy.
+func writeChecksumPolicy(objAPI ObjectLayer, required bool, algorithms []string) error {
+	algorithms, err := parseChecksumAlgorithms(algorithms)
+	if err != nil {
+		return err
+	}
+	if !required && len(algorithms) == len(supportedChecksumAlgorithms) {
+		return writeServerSetting(objAPI, checksumPolicyConfig, nil)
+	}
+	return writeServerSetting(objAPI, checksumPolicyConfig, ChecksumPolicy{Required: required, Algorithms: algorithms})
+}
+
+// initChecksumPolicy - loads the checksum policy, so that a restarted
+// server rejects the same uploads as its peers.
+func initChecksumPolicy(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	var policy ChecksumPolicy
+	ok, err := readServerSetting(objAPI, checksumPolicyConfig, &policy)
+	if err != nil {
+		if isErrIgnored(err, errDiskNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	return globalChecksumPolicy.set(policy.Required, policy.Algorithms)
+}
+
+// newChecksumHash - returns a new hash of a supported algorithm.
+func newChecksumHash(algorithm string) hash.Hash {
+	switch algorithm {
+	case "CRC32":
+		return crc32.NewIEEE()
+	case "CRC32C":
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case "SHA1":
+		return sha1.New()
+	}
+	return sha256.New()
+}
+
+// checksumHeader - returns the header carrying the checksum of
+// algorithm, the checksum is stored in the object metadata under the
+// same name.
+func checksumHeader(algorithm string) string {
+	return http.CanonicalHeaderKey("x-amz-checksum-" + algorithm)
+}
+
+// objectChecksum - object checksum sent by a client.
+type objectChecksum struct {
+	algorithm string
+	value     string // As sent, base64 encoded.
+	digest    []byte
+}
+
+// getObjectChecksum - returns the checksum of the first algorithm of
+// policy found in header, nil if none is sent and none is required.
+func getObjectChecksum(header http.Header, policy ChecksumPolicy) (*objectChecksum, error) {
+	for _, algorithm := range policy.Algorithms {
+		value := header.Get(checksumHeader(algorithm))
+		if value == "" {
+			continue
+		}
+		digest, err := base64.StdEncoding.DecodeString(value)
+		if err != nil || len(digest) != newChecksumHash(algorithm).Size() {
+			return nil, errInvalidChecksum
+		}
+		return &objectChecksum{algorithm: algorithm, value: value, digest: digest}, nil
+	}
+	if policy.Required {
+		return nil, errMissingChecksum
+	}
+	return nil, nil
+}
+
+// checksumReader - reads size bytes of an object, verifying its
+// checksum once the last byte is read. Object layers stop reading at
+// size bytes, so a mismatch is returned along with the last bytes
+// rather than at EOF, failing the upload before the object is stored.
+type checksumReader struct {
+	reader    io.Reader
+	hash      hash.Hash
+	digest    []byte
+	remaining int64
+}
+
+// newChecksumReader - returns reader verifying checksum, reader as is
+// if checksum is nil.
+func newChecksumReader(reader io.Reader, size int64, checksum *objectChecksum) io.Reader {
+	if checksum == nil {
+		return reader
+	}
+	return &checksumReader{
+		reader:    reader,
+		hash:      newChecksumHash(checksum.algorithm),
+		digest:    checksum.digest,
+		remaining: size,
+	}
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	if c.remaining <= 0 {
+		return 0, c.verify(io.EOF)
+	}
+	if int64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err := c.reader.Read(p)
+	c.hash.Write(p[:n])
+	c.remaining -= int64(n)
+	if c.remaining == 0 {
+		return n, c.verify(err)
+	}
+	return n, err
+}
+
+// verify - returns errChecksumMismatch if the data read does not match
+// the checksum, err otherwise.
+func (c *checksumReader) verify(err error) error {
+	if !bytes.Equal(c.hash.Sum(nil), c.digest) {
+		return errChecksumMismatch
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestChecksumPolicySet(t *testing.T) {
+	c := newChecksumPolicy()
+	if policy := c.get(); policy.Required || !reflect.DeepEqual(policy.Algorithms, supportedChecksumAlgorithms) {
+		t.Fatalf("Expected all algorithms optional by default, got %v", policy)
+	}
+
+	for _, algorithms := range [][]string{nil, {""}, {"md5"}, {"sha256", "crc64"}} {
+		if err := c.set(true, algorithms); err != errInvalidChecksumPolicy {
+			t.Errorf("%v: expected %v, got %v", algorithms, errInvalidChecksumPolicy, err)
+		}
+	}
+
+	if err := c.set(true, []string{"sha256", " crc32c", "SHA256"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := ChecksumPolicy{Required: true, Algorithms: []string{"SHA256", "CRC32C"}}
+	if policy := c.get(); !reflect.DeepEqual(policy, expected) {
+		t.Fatalf("Expected %v, got %v", expected, policy)
+	}
+}
+
+// TestChecksumPolicyRestart - tests the checksum policy is loaded again
+// by a restarted server.
+func TestChecksumPolicyRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalChecksumPolicy()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	if err = writeChecksumPolicy(objLayer, true, []string{"md5"}); err != errInvalidChecksumPolicy {
+		t.Fatalf("Expected %v, got %v", errInvalidChecksumPolicy, err)
+	}
+	if err = writeChecksumPolicy(objLayer, true, []string{"sha256"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, the policy is loaded again.
+	resetGlobalChecksumPolicy()
+	if err = initChecksumPolicy(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	expected := ChecksumPolicy{Required: true, Algorithms: []string{"SHA256"}}
+	if policy := globalChecksumPolicy.get(); !reflect.DeepEqual(policy, expected) {
+		t.Fatalf("Expected policy %v after restart, got %v", expected, policy)
+	}
+
+	// Going back to the default policy removes the persisted policy.
+	if err = writeChecksumPolicy(objLayer, false, supportedChecksumAlgorithms); err != nil {
+		t.Fatal(err)
+	}
+	resetGlobalChecksumPolicy()
+	if err = initChecksumPolicy(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if policy := globalChecksumPolicy.get(); !reflect.DeepEqual(policy, newChecksumPolicy().get()) {
+		t.Fatalf("Expected the default policy, got %v", policy)
+	}
+}
+
+func TestGetObjectChecksum(t *testing.T) {
+	sum := sha256.Sum256([]byte("data"))
+	value := base64.StdEncoding.EncodeToString(sum[:])
+	policy := ChecksumPolicy{Required: true, Algorithms: []string{"CRC32C", "SHA256"}}
+
+	testCases := []struct {
+		header    http.Header
+		policy    ChecksumPolicy
+		algorithm string
+		err       error
+	}{
+		{http.Header{"X-Amz-Checksum-Sha256": {value}}, policy, "SHA256", nil},
+		// Missing checksum, only an error when required.
+		{http.Header{}, policy, "", errMissingChecksum},
+		{http.Header{}, ChecksumPolicy{Algorithms: policy.Algorithms}, "", nil},
+		// Checksums of algorithms out of the policy are not looked at.
+		{http.Header{"X-Amz-Checksum-Sha256": {value}}, ChecksumPolicy{Required: true, Algorithms: []string{"CRC32"}}, "", errMissingChecksum},
+		// Not base64, or not a digest of the algorithm.
+		{http.Header{"X-Amz-Checksum-Sha256": {"not base64!"}}, policy, "", errInvalidChecksum},
+		{http.Header{"X-Amz-Checksum-Crc32c": {value}}, policy, "", errInvalidChecksum},
+	}
+	for i, testCase := range testCases {
+		checksum, err := getObjectChecksum(testCase.header, testCase.policy)
+		if err != testCase.err {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+		}
+		if algorithm := ""; checksum != nil {
+			algorithm = checksum.algorithm
+			if algorithm != testCase.algorithm {
+				t.Errorf("Test %d: expected %s, got %s", i+1, testCase.algorithm, algorithm)
+			}
+		} else if testCase.algorithm != "" {
+			t.Errorf("Test %d: expected a %s checksum, got none", i+1, testCase.algorithm)
+		}
+	}
+}
+
+// Tests that a mismatch is returned along with the last bytes, object
+// layers read no further than the object size.
+func TestChecksumReader(t *testing.T) {
+	data := []byte("checksummed data")
+	sum := sha256.Sum256(data)
+	checksum := &objectChecksum{algorithm: "SHA256", digest: sum[:]}
+	size := int64(len(data))
+
+	reader := newChecksumReader(bytes.NewReader(data), size, checksum)
+	if got, err := ioutil.ReadAll(io.LimitReader(reader, size)); err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("Expected %q, got %q, %v", data, got, err)
+	}
+
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	reader = newChecksumReader(bytes.NewReader(corrupted), size, checksum)
+	if _, err := ioutil.ReadAll(io.LimitReader(reader, size)); err != errChecksumMismatch {
+		t.Fatalf("Expected %v, got %v", errChecksumMismatch, err)
+	}
+
+	// Empty objects are verified too.
+	reader = newChecksumReader(bytes.NewReader(nil), 0, checksum)
+	if _, err := ioutil.ReadAll(reader); err != errChecksumMismatch {
+		t.Fatalf("Expected %v, got %v", errChecksumMismatch, err)
+	}
+
+	// No checksum, nothing to verify.
+	if r := bytes.NewReader(data); newChecksumReader(r, size, nil) != r {
+		t.Fatal("Expected the reader to be returned as is")
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Maximum number of clients tracked by a server, beyond which the
+// client idle for the longest is forgotten.
+const clientConnStatsMaxClients = 10000
+
+// ClientConnStats - activity of a client, as seen by one or more
+// servers. Connections counts the connections currently carrying
+// requests of the client, idle keep-alive connections are not seen.
+type ClientConnStats struct {
+	Connections    int   `json:"connections"`
+	ActiveRequests int   `json:"activeRequests"`
+	Requests       int64 `json:"requests"`
+	BytesIn        int64 `json:"bytesIn"`
+	BytesOut       int64 `json:"bytesOut"`
+}
+
+// add - adds the activity in other to s.
+func (s *ClientConnStats) add(other ClientConnStats) {
+	s.Connections += other.Connections
+	s.ActiveRequests += other.ActiveRequests
+	s.Requests += other.Requests
+	s.BytesIn += other.BytesIn
+	s.BytesOut += other.BytesOut
+}
+
+// clientConns - activity of a client on this server.
+type clientConns struct {
+	stats ClientConnStats
+	// Requests in progress per connection, keyed by remote address.
+	conns    map[string]int
+	lastSeen time.Time
+}
+
+// clientConnTracker - activity of the clients of this server, grouped
+// by client IP as forwarded by trusted proxies.
+type clientConnTracker struct {
+	mutex   sync.Mutex
+	clients map[string]*clientConns
+}
+
+func newClientConnTracker() *clientConnTracker {
+	return &clientConnTracker{clients: make(map[string]*clientConns)}
+}
+
+var globalClientConns = newClientConnTracker()
+
+// getClientIP - returns the IP of the client which sent r, as
+// forwarded by trusted proxies, without the port.
+func getClientIP(r *http.Request) string {
+	addr := getSourceIP(r)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// begin - records the start of a request of client sent over the
+// connection from remoteAddr.
+func (t *clientConnTracker) begin(client, remoteAddr string, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	c, ok := t.clients[client]
+	if !ok {
+		if len(t.clients) >= clientConnStatsMaxClients {
+			t.evictIdle()
+		}
+		c = &clientConns{conns: make(map[string]int)}
+		t.clients[client] = c
+	}
+	if c.conns[remoteAddr] == 0 {
+		c.stats.Connections++
+	}
+	c.conns[remoteAddr]++
+	c.stats.ActiveRequests++
+	c.stats.Requests++
+	c.lastSeen = now
+}
+
+// end - records the end of a request of client sent over the
+// connection from remoteAddr, which transferred bytesIn and bytesOut.
+func (t *clientConnTracker) end(client, remoteAddr string, bytesIn, bytesOut int64, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	c, ok := t.clients[client]
+	if !ok {
+		return
+	}
+	c.conns[remoteAddr]--
+	if c.conns[remoteAddr] <= 0 {
+		delete(c.conns, remoteAddr)
+		c.stats.Connections--
+	}
+	c.stats.ActiveRequests--
+	c.stats.BytesIn += bytesIn
+	c.stats.BytesOut += bytesOut
+	c.lastSeen = now
+}
+
+// evictIdle - forgets the client without requests in progress idle
+// for the longest, callers hold the mutex.
+func (t *clientConnTracker) evictIdle() {
+	oldest := ""
+	var oldestSeen time.Time
+	for client, c := range t.clients {
+		if c.stats.ActiveRequests > 0 {
+			continue
+		}
+		if oldest == "" || c.lastSeen.Before(oldestSeen) {
+			oldest, oldestSeen = client, c.lastSeen
+		}
+	}
+	if oldest != "" {
+		delete(t.clients, oldest)
+	}
+}
+
+// get - returns the activity of every client.
+func (t *clientConnTracker) get() map[string]ClientConnStats {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	stats := make(map[string]ClientConnStats, len(t.clients))
+	for client, c := range t.clients {
+		stats[client] = c.stats
+	}
+	return stats
+}
+
+// getLocalClientConnections - returns the activity of the clients of
+// this server.
+func getLocalClientConnections() map[string]ClientConnStats {
+	return globalClientConns.get()
+}
+
+// mergeClientConnections - adds up the activity of every client seen
+// by the servers, a client may send requests to several of them.
+func mergeClientConnections(views []map[string]ClientConnStats) map[string]ClientConnStats {
+	merged := make(map[string]ClientConnStats)
+	for _, view := range views {
+		for client, stats := range view {
+			m := merged[client]
+			m.add(stats)
+			merged[client] = m
+		}
+	}
+	return merged
+}
+
+// countingReadCloser - counts the bytes read out of a request body.
+type countingReadCloser struct {
+	io.ReadCloser
+	bytesRead int64
+}
+
+func (c *countingReadCloser) Read(b []byte) (int, error) {
+	n, err := c.ReadCloser.Read(b)
+	atomic.AddInt64(&c.bytesRead, int64(n))
+	return n, err
+}
+
+// clientConnHandler - records the activity of the client of every
+// request served by f.
+func clientConnHandler(f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		client := getClientIP(r)
+		globalClientConns.begin(client, r.RemoteAddr, time.Now())
+
+		var body *countingReadCloser
+		if r.Body != nil {
+			body = &countingReadCloser{ReadCloser: r.Body}
+			r.Body = body
+		}
+		ww := &httpResponseRecorder{ResponseWriter: w}
+		f(ww, r)
+
+		var bytesIn int64
+		if body != nil {
+			bytesIn = atomic.LoadInt64(&body.bytesRead)
+		}
+		globalClientConns.end(client, r.RemoteAddr, bytesIn, ww.bytesWritten, time.Now())
+	}
+}
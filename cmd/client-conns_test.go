@@ -0,0 +1,133 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClientConnTracker - tests connection and request accounting.
+func TestClientConnTracker(t *testing.T) {
+	tracker := newClientConnTracker()
+	now := time.Now()
+
+	tracker.begin("10.0.0.1", "10.0.0.1:5000", now)
+	tracker.begin("10.0.0.1", "10.0.0.1:5000", now)
+	tracker.begin("10.0.0.1", "10.0.0.1:5001", now)
+	expected := ClientConnStats{Connections: 2, ActiveRequests: 3, Requests: 3}
+	if stats := tracker.get()["10.0.0.1"]; stats != expected {
+		t.Fatalf("Expected %v, got %v", expected, stats)
+	}
+
+	tracker.end("10.0.0.1", "10.0.0.1:5000", 10, 100, now)
+	tracker.end("10.0.0.1", "10.0.0.1:5001", 20, 200, now)
+	expected = ClientConnStats{Connections: 1, ActiveRequests: 1, Requests: 3, BytesIn: 30, BytesOut: 300}
+	if stats := tracker.get()["10.0.0.1"]; stats != expected {
+		t.Fatalf("Expected %v, got %v", expected, stats)
+	}
+}
+
+// TestClientConnTrackerEvictIdle - tests that the client idle for the
+// longest is forgotten once too many clients are tracked.
+func TestClientConnTrackerEvictIdle(t *testing.T) {
+	tracker := newClientConnTracker()
+	now := time.Now()
+	for i := 0; i < clientConnStatsMaxClients; i++ {
+		client := "client" + string(rune('a'+i%26)) + strings.Repeat("x", i/26)
+		tracker.begin(client, client, now.Add(time.Duration(i)*time.Second))
+		if i != 0 {
+			tracker.end(client, client, 0, 0, now.Add(time.Duration(i)*time.Second))
+		}
+	}
+	// The first client still has a request in progress, the second
+	// is the one idle for the longest.
+	tracker.begin("new", "new", now.Add(time.Hour))
+	clients := tracker.get()
+	if len(clients) != clientConnStatsMaxClients {
+		t.Fatalf("Expected %d clients, got %d", clientConnStatsMaxClients, len(clients))
+	}
+	if _, ok := clients["clienta"]; !ok {
+		t.Fatal("Client with a request in progress was evicted")
+	}
+	if _, ok := clients["clientb"]; ok {
+		t.Fatal("Expected the client idle for the longest to be evicted")
+	}
+}
+
+// TestClientConnHandler - tests that requests are grouped by client IP
+// as forwarded by trusted proxies.
+func TestClientConnHandler(t *testing.T) {
+	defer resetGlobalClientConns()
+	defer resetGlobalTrustedProxies()
+	resetGlobalClientConns()
+
+	if err := globalTrustedProxies.set([]string{"192.168.1.0/24"}); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := clientConnHandler(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Write([]byte("hello"))
+	})
+	testCases := []struct {
+		remoteAddr string
+		forwarded  string
+	}{
+		// Through a trusted proxy, the forwarded IP is the client.
+		{"192.168.1.10:4000", "10.0.0.1"},
+		{"192.168.1.11:4000", "10.0.0.1, 192.168.1.10"},
+		// Forwarded headers of untrusted hosts are ignored.
+		{"172.16.0.1:4000", "10.0.0.1"},
+	}
+	for _, testCase := range testCases {
+		req := httptest.NewRequest("PUT", "/bucket/object", strings.NewReader("abc"))
+		req.RemoteAddr = testCase.remoteAddr
+		req.Header.Set("X-Forwarded-For", testCase.forwarded)
+		handler(httptest.NewRecorder(), req)
+	}
+
+	expected := map[string]ClientConnStats{
+		"10.0.0.1":   {Requests: 2, BytesIn: 6, BytesOut: 10},
+		"172.16.0.1": {Requests: 1, BytesIn: 3, BytesOut: 5},
+	}
+	if clients := globalClientConns.get(); !reflect.DeepEqual(clients, expected) {
+		t.Fatalf("Expected %v, got %v", expected, clients)
+	}
+}
+
+// TestMergeClientConnections - tests that the activity of a client on
+// several servers is added up.
+func TestMergeClientConnections(t *testing.T) {
+	merged := mergeClientConnections([]map[string]ClientConnStats{
+		{"10.0.0.1": {Connections: 1, ActiveRequests: 1, Requests: 2, BytesIn: 3, BytesOut: 4}},
+		nil,
+		{"10.0.0.1": {Connections: 2, Requests: 1, BytesOut: 1}, "10.0.0.2": {Requests: 1}},
+	})
+	expected := map[string]ClientConnStats{
+		"10.0.0.1": {Connections: 3, ActiveRequests: 1, Requests: 3, BytesIn: 3, BytesOut: 5},
+		"10.0.0.2": {Requests: 1},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("Expected %v, got %v", expected, merged)
+	}
+}
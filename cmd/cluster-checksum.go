@@ -0,0 +1,212 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Objects listed per page while checksumming a bucket through the
+// object layer.
+const bucketChecksumMaxKeys = 1000
+
+// BucketChecksum - checksum of the metadata of all objects of a bucket
+// as seen by a server. Servers which saw the same writes report the
+// same checksum.
+type BucketChecksum struct {
+	Checksum string `json:"checksum"`
+	Objects  int64  `json:"objects"`
+}
+
+// NodeBucketChecksum - checksum of a bucket on a server.
+type NodeBucketChecksum struct {
+	Addr string `json:"addr"`
+	BucketChecksum
+}
+
+// ClusterChecksum - checksums of a bucket on all servers, Consistent is
+// false when servers diverged.
+type ClusterChecksum struct {
+	Bucket     string               `json:"bucket"`
+	Consistent bool                 `json:"consistent"`
+	Nodes      []NodeBucketChecksum `json:"nodes"`
+}
+
+// bucketChecksum - combines the checksums of objects by XOR, so that
+// the result does not depend on the order objects are listed in.
+type bucketChecksum struct {
+	sum     [sha256.Size]byte
+	objects int64
+}
+
+// add - adds an object to the checksum. Objects are identified by
+// name, ETag, size and modification time, the latter standing in for
+// a version since objects are not versioned.
+func (c *bucketChecksum) add(object, etag string, size int64, modTime time.Time) {
+	h := sha256.New()
+	h.Write([]byte(object))
+	h.Write([]byte{0})
+	h.Write([]byte(etag))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(size, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(modTime.UnixNano(), 10)))
+	for i, b := range h.Sum(nil) {
+		c.sum[i] ^= b
+	}
+	c.objects++
+}
+
+// result - returns the checksum of all added objects.
+func (c bucketChecksum) result() BucketChecksum {
+	return BucketChecksum{Checksum: hex.EncodeToString(c.sum[:]), Objects: c.objects}
+}
+
+// objectMetaEntry - metadata of an object read from a disk.
+type objectMetaEntry struct {
+	etag    string
+	size    int64
+	modTime time.Time
+}
+
+// walkDiskObjects - calls fn with every object of bucket under prefix
+// on disk, an object being a directory holding `xl.json`.
+func walkDiskObjects(disk StorageAPI, bucket, prefix string, fn func(object string) error) error {
+	entries, err := disk.ListDir(bucket, prefix)
+	if err != nil {
+		if err == errFileNotFound {
+			// Removed meanwhile.
+			return nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry, slashSeparator) {
+			continue
+		}
+		entryPath := pathJoin(prefix, entry)
+		if _, err = disk.StatFile(bucket, pathJoin(entryPath, xlMetaJSONFile)); err == nil {
+			if err = fn(strings.TrimSuffix(entryPath, slashSeparator)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = walkDiskObjects(disk, bucket, entryPath, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xlBucketChecksum - returns the checksum of bucket out of the object
+// metadata on disks. Each object is accounted for once, with the most
+// recent metadata found on any of disks, skipping disks which can not
+// be read.
+func xlBucketChecksum(disks []StorageAPI, bucket string) (BucketChecksum, error) {
+	entries := make(map[string]objectMetaEntry)
+	var lastErr error
+	read := 0
+	for _, disk := range disks {
+		err := walkDiskObjects(disk, bucket, "", func(object string) error {
+			stat, meta, err := readXLMetaStat(disk, bucket, object)
+			if err != nil {
+				if errorCause(err) == errFileNotFound {
+					// Deleted meanwhile.
+					return nil
+				}
+				return errorCause(err)
+			}
+			if entry, ok := entries[object]; ok && !stat.ModTime.After(entry.modTime) {
+				return nil
+			}
+			entries[object] = objectMetaEntry{etag: meta["md5Sum"], size: stat.Size, modTime: stat.ModTime}
+			return nil
+		})
+		if err != nil {
+			errorIf(err, "Unable to checksum bucket %s on %s", bucket, disk)
+			lastErr = err
+			continue
+		}
+		read++
+	}
+	if read == 0 && lastErr != nil {
+		return BucketChecksum{}, lastErr
+	}
+
+	var c bucketChecksum
+	for object, entry := range entries {
+		c.add(object, entry.etag, entry.size, entry.modTime)
+	}
+	return c.result(), nil
+}
+
+// objLayerBucketChecksum - returns the checksum of bucket out of the
+// objects listed by objLayer.
+func objLayerBucketChecksum(objLayer ObjectLayer, bucket string) (BucketChecksum, error) {
+	var c bucketChecksum
+	marker := ""
+	for {
+		result, err := objLayer.ListObjects(bucket, "", marker, "", bucketChecksumMaxKeys)
+		if err != nil {
+			return BucketChecksum{}, errorCause(err)
+		}
+		for _, obj := range result.Objects {
+			c.add(obj.Name, obj.MD5Sum, obj.Size, obj.ModTime)
+		}
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			return c.result(), nil
+		}
+		marker = result.Objects[len(result.Objects)-1].Name
+	}
+}
+
+// getLocalBucketChecksum - returns the checksum of bucket as seen by
+// this server. In erasure mode only the local disks are read, listing
+// through the object layer would merge in the view of other servers.
+func getLocalBucketChecksum(bucket string) (BucketChecksum, error) {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return BucketChecksum{}, errServerNotInitialized
+	}
+	if err := checkBucketExist(bucket, objLayer); err != nil {
+		return BucketChecksum{}, err
+	}
+	if _, ok := objLayer.(*xlObjects); !ok {
+		return objLayerBucketChecksum(objLayer, bucket)
+	}
+
+	var disks []StorageAPI
+	for _, ep := range globalEndpoints {
+		if !isLocalStorage(ep) {
+			continue
+		}
+		disk, err := newStorageAPI(ep)
+		if err != nil {
+			errorIf(err, "Unable to open disk %s", ep)
+			continue
+		}
+		disks = append(disks, disk)
+	}
+	if len(disks) == 0 {
+		return BucketChecksum{}, errDiskNotFound
+	}
+	return xlBucketChecksum(disks, bucket)
+}
@@ -0,0 +1,149 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests bucket checksums do not depend on the order of objects.
+func TestBucketChecksumOrder(t *testing.T) {
+	modTime := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	objects := []struct {
+		name, etag string
+		size       int64
+	}{
+		{"a", "etag-a", 1},
+		{"dir/b", "etag-b", 2},
+		{"dir/c", "etag-c", 3},
+	}
+
+	var forward, backward bucketChecksum
+	for i := range objects {
+		o := objects[i]
+		forward.add(o.name, o.etag, o.size, modTime)
+		o = objects[len(objects)-1-i]
+		backward.add(o.name, o.etag, o.size, modTime)
+	}
+	if forward.result() != backward.result() {
+		t.Fatalf("Expected %v, got %v", forward.result(), backward.result())
+	}
+	if forward.result().Objects != int64(len(objects)) {
+		t.Fatalf("Expected %d objects, got %d", len(objects), forward.result().Objects)
+	}
+
+	// Any change of an object changes the checksum.
+	changes := []func(c *bucketChecksum){
+		func(c *bucketChecksum) { c.add("dir/c", "etag-d", 3, modTime) },
+		func(c *bucketChecksum) { c.add("dir/c", "etag-c", 4, modTime) },
+		func(c *bucketChecksum) { c.add("dir/c", "etag-c", 3, modTime.Add(time.Second)) },
+		func(c *bucketChecksum) { c.add("dir/d", "etag-c", 3, modTime) },
+	}
+	for i, change := range changes {
+		var c bucketChecksum
+		c.add("a", "etag-a", 1, modTime)
+		c.add("dir/b", "etag-b", 2, modTime)
+		change(&c)
+		if c.result().Checksum == forward.result().Checksum {
+			t.Errorf("Test %d: expected checksum to change", i+1)
+		}
+	}
+}
+
+// Tests checksums computed out of erasure coded disks, a disk holding
+// an extra object diverges from the others.
+func TestXLBucketChecksum(t *testing.T) {
+	root, err := newTestConfig("us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	objLayer, dirs, err := prepareXL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(dirs)
+
+	bucket := "checksum-bucket"
+	if err = objLayer.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"a", "dir/b", "dir/sub/c"} {
+		if _, err = objLayer.PutObject(bucket, object, 4, strings.NewReader("data"), nil, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	disks := make([]StorageAPI, 2)
+	for i := range disks {
+		if disks[i], err = newPosix(dirs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := objLayerBucketChecksum(objLayer, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected.Objects != 3 {
+		t.Fatalf("Expected 3 objects, got %d", expected.Objects)
+	}
+	for i, disk := range disks {
+		checksum, cErr := xlBucketChecksum([]StorageAPI{disk}, bucket)
+		if cErr != nil {
+			t.Fatal(cErr)
+		}
+		if checksum != expected {
+			t.Fatalf("Disk %d: expected %v, got %v", i, expected, checksum)
+		}
+	}
+
+	// An object written to the first disk only.
+	if _, err = objLayer.PutObject(bucket, "dir/extra", 4, strings.NewReader("data"), nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err = disks[1].DeleteFile(bucket, pathJoin("dir/extra", xlMetaJSONFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := xlBucketChecksum(disks[:1], bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := xlBucketChecksum(disks[1:], bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Checksum == second.Checksum || first.Objects != 4 || second.Objects != 3 {
+		t.Fatalf("Expected checksums to diverge, got %v and %v", first, second)
+	}
+	if second != expected {
+		t.Fatalf("Expected %v, got %v", expected, second)
+	}
+
+	// Objects found on several disks of a server count once.
+	both, err := xlBucketChecksum(disks, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if both != first {
+		t.Fatalf("Expected %v, got %v", first, both)
+	}
+}
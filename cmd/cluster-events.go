@@ -0,0 +1,174 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// Cluster event types.
+const (
+	clusterEventNodeUp         = "node-up"
+	clusterEventNodeDown       = "node-down"
+	clusterEventHealStart      = "heal-start"
+	clusterEventHealEnd        = "heal-end"
+	clusterEventConfigChange   = "config-change"
+	clusterEventQuorumLost     = "quorum-lost"
+	clusterEventQuorumRestored = "quorum-restored"
+)
+
+const (
+	// Number of most recent events kept by each server.
+	maxClusterEvents = 1000
+
+	// Interval at which peers are polled for new events.
+	clusterEventPollInterval = time.Second
+
+	// Interval at which this server checks for write quorum.
+	quorumMonitorInterval = 10 * time.Second
+)
+
+// ClusterEvent - an event of cluster interest. Node is the server the
+// event originated from, for cluster wide events the first server
+// that reported it.
+type ClusterEvent struct {
+	Seq    uint64    `json:"-"`
+	Type   string    `json:"type"`
+	Time   time.Time `json:"time"`
+	Node   string    `json:"node"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// ClusterEventBatch - events of a server following a sequence number,
+// along with the sequence number of its latest event.
+type ClusterEventBatch struct {
+	Events []ClusterEvent
+	Last   uint64
+}
+
+// clusterEventLog - most recent events of this server.
+type clusterEventLog struct {
+	mutex  sync.Mutex
+	events []ClusterEvent
+	seq    uint64
+}
+
+var globalClusterEvents = &clusterEventLog{}
+
+// emit - records an event of eventType.
+func (l *clusterEventLog) emit(eventType, detail string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.seq++
+	l.events = append(l.events, ClusterEvent{
+		Seq:    l.seq,
+		Type:   eventType,
+		Time:   time.Now().UTC(),
+		Detail: detail,
+	})
+	if len(l.events) > maxClusterEvents {
+		l.events = l.events[len(l.events)-maxClusterEvents:]
+	}
+}
+
+// since - returns the events recorded after seq. A seq ahead of the
+// log means the caller saw a previous run of this server, all events
+// are returned then.
+func (l *clusterEventLog) since(seq uint64) ClusterEventBatch {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if seq > l.seq {
+		seq = 0
+	}
+	batch := ClusterEventBatch{Last: l.seq}
+	for _, event := range l.events {
+		if event.Seq > seq {
+			batch.Events = append(batch.Events, event)
+		}
+	}
+	return batch
+}
+
+// clusterEventMerger - de-duplicates cluster wide events reported by
+// every server. Quorum is a property of the cluster, it is reported
+// lost with the first server losing it and restored once every server
+// that lost it has it again.
+type clusterEventMerger struct {
+	quorumLost map[string]bool
+}
+
+// merge - returns true if event must be passed on.
+func (m *clusterEventMerger) merge(event ClusterEvent) bool {
+	switch event.Type {
+	case clusterEventQuorumLost:
+		if m.quorumLost == nil {
+			m.quorumLost = make(map[string]bool)
+		}
+		wasLost := len(m.quorumLost) > 0
+		m.quorumLost[event.Node] = true
+		return !wasLost
+	case clusterEventQuorumRestored:
+		if !m.quorumLost[event.Node] {
+			return false
+		}
+		delete(m.quorumLost, event.Node)
+		return len(m.quorumLost) == 0
+	}
+	return true
+}
+
+// hasLocalWriteQuorum - returns true if every erasure set seen from
+// this server has write quorum.
+func hasLocalWriteQuorum() (bool, error) {
+	sets, err := getLocalErasureSetStatus()
+	if err != nil {
+		return false, err
+	}
+	for _, set := range sets {
+		if !set.HasWriteQuorum {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// startQuorumMonitor - periodically records quorum lost and restored
+// events, never returns for erasure coded backends.
+func startQuorumMonitor() {
+	if !globalIsXL {
+		return
+	}
+
+	hasQuorum := true
+	ticker := time.NewTicker(quorumMonitorInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		quorum, err := hasLocalWriteQuorum()
+		if err != nil || quorum == hasQuorum {
+			continue
+		}
+		hasQuorum = quorum
+		if hasQuorum {
+			globalClusterEvents.emit(clusterEventQuorumRestored, "")
+		} else {
+			globalClusterEvents.emit(clusterEventQuorumLost, "")
+		}
+	}
+}
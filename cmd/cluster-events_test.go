@@ -0,0 +1,79 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+// Tests reading events of the event log.
+func TestClusterEventLogSince(t *testing.T) {
+	log := &clusterEventLog{}
+	if batch := log.since(0); len(batch.Events) != 0 || batch.Last != 0 {
+		t.Fatalf("Expected empty batch, got %v", batch)
+	}
+
+	for i := 0; i < maxClusterEvents+10; i++ {
+		log.emit(clusterEventHealStart, "bucket")
+	}
+	batch := log.since(0)
+	if len(batch.Events) != maxClusterEvents {
+		t.Fatalf("Expected %d events, got %d", maxClusterEvents, len(batch.Events))
+	}
+	if batch.Last != maxClusterEvents+10 {
+		t.Fatalf("Expected last sequence %d, got %d", maxClusterEvents+10, batch.Last)
+	}
+
+	log.emit(clusterEventHealEnd, "bucket")
+	batch = log.since(batch.Last)
+	if len(batch.Events) != 1 || batch.Events[0].Type != clusterEventHealEnd {
+		t.Fatalf("Expected only the new event, got %v", batch.Events)
+	}
+
+	// Sequence ahead of the log, as seen before a restart.
+	log = &clusterEventLog{}
+	log.emit(clusterEventConfigChange, "credentials")
+	if batch = log.since(batch.Last); len(batch.Events) != 1 {
+		t.Fatalf("Expected all events after restart, got %v", batch.Events)
+	}
+}
+
+// Tests de-duplication of cluster wide events.
+func TestClusterEventMerger(t *testing.T) {
+	testCases := []struct {
+		eventType string
+		node      string
+		passed    bool
+	}{
+		{clusterEventQuorumRestored, "server0", false},
+		{clusterEventQuorumLost, "server0", true},
+		{clusterEventHealStart, "server0", true},
+		{clusterEventQuorumLost, "server1", false},
+		{clusterEventHealStart, "server1", true},
+		{clusterEventQuorumRestored, "server0", false},
+		// Lost again by a server that had it restored already.
+		{clusterEventQuorumLost, "server0", false},
+		{clusterEventQuorumRestored, "server1", false},
+		{clusterEventQuorumRestored, "server0", true},
+		{clusterEventQuorumRestored, "server1", false},
+		{clusterEventQuorumLost, "server1", true},
+	}
+	merger := clusterEventMerger{}
+	for i, testCase := range testCases {
+		if passed := merger.merge(ClusterEvent{Type: testCase.eventType, Node: testCase.node}); passed != testCase.passed {
+			t.Errorf("Test %d: Expected %s of %s to be passed on %v, got %v", i+1, testCase.eventType, testCase.node, testCase.passed, passed)
+		}
+	}
+}
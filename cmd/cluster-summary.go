@@ -0,0 +1,186 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"time"
+)
+
+// Fields of a cluster summary which may be computed out of the
+// servers which could be reached only.
+const (
+	summaryObjects           = "objects"
+	summaryActiveHeals       = "activeHeals"
+	summaryOldestLockAge     = "oldestLockAge"
+	summaryVersionConsistent = "versionConsistent"
+)
+
+// ServerSummary - state of a server summarized for a dashboard.
+type ServerSummary struct {
+	Version  string
+	CommitID string
+	// Disks as seen by the server, every server of an erasure coded
+	// setup sees all disks.
+	Storage StorageInfo
+	// Heal jobs running on the server.
+	HealJobs []HealJobStatus
+	// Time the oldest lock held or waited upon by an operation of the
+	// server was taken, zero if none.
+	OldestLock time.Time
+	// Usage scans run by the server.
+	UsageScans []UsageScanStatus
+}
+
+// getLocalServerSummary - returns the summary of the local server.
+func getLocalServerSummary() (ServerSummary, error) {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return ServerSummary{}, errServerNotInitialized
+	}
+
+	summary := ServerSummary{
+		Version:    Version,
+		CommitID:   CommitID,
+		Storage:    objLayer.StorageInfo(),
+		UsageScans: globalUsageScans.list(),
+	}
+	for _, job := range globalHealJobs.list() {
+		if !job.Done {
+			summary.HealJobs = append(summary.HealJobs, job)
+		}
+	}
+	for _, volLock := range dumpLocksInfo() {
+		for _, lock := range volLock.LockDetailsOnObject {
+			if summary.OldestLock.IsZero() || lock.Since.Before(summary.OldestLock) {
+				summary.OldestLock = lock.Since
+			}
+		}
+	}
+	return summary, nil
+}
+
+// ClusterSummary - state of the whole cluster gathered in one call for
+// a dashboard. Fields listed in Partial were computed out of the
+// servers which could be reached only.
+type ClusterSummary struct {
+	Nodes        int      `json:"nodes"`
+	OnlineNodes  int      `json:"onlineNodes"`
+	OfflineNodes []string `json:"offlineNodes,omitempty"`
+
+	// Disk space and disks as seen by the first server reached.
+	Total        int64 `json:"total"`
+	Used         int64 `json:"used"`
+	OnlineDisks  int   `json:"onlineDisks"`
+	OfflineDisks int   `json:"offlineDisks"`
+
+	// Objects counted by the latest complete usage scan of every
+	// bucket, buckets never scanned are not counted.
+	Objects int64 `json:"objects"`
+	// Heal jobs running on any server.
+	ActiveHeals int `json:"activeHeals"`
+	// Age of the oldest lock held or waited upon, zero if none.
+	OldestLockAge time.Duration `json:"oldestLockAge"`
+
+	// Distinct server versions, set if all servers run the same one.
+	Versions          []string `json:"versions"`
+	VersionConsistent bool     `json:"versionConsistent"`
+
+	Partial []string `json:"partial,omitempty"`
+}
+
+// newClusterSummary - merges the summaries of the servers at addrs
+// reached at now, summaries of servers with errs are skipped.
+func newClusterSummary(addrs []string, summaries []ServerSummary, errs []error, now time.Time) ClusterSummary {
+	cluster := ClusterSummary{Nodes: len(addrs), Versions: []string{}}
+	healJobs := make(map[string]struct{})
+	versions := make(map[string]struct{})
+	var scans [][]UsageScanStatus
+	var oldestLock time.Time
+	for i, addr := range addrs {
+		if errs[i] != nil {
+			cluster.OfflineNodes = append(cluster.OfflineNodes, addr)
+			continue
+		}
+		summary := summaries[i]
+		if cluster.OnlineNodes == 0 {
+			cluster.Total = summary.Storage.Total
+			cluster.Used = summary.Storage.Total - summary.Storage.Free
+			cluster.OnlineDisks = summary.Storage.Backend.OnlineDisks
+			cluster.OfflineDisks = summary.Storage.Backend.OfflineDisks
+		}
+		cluster.OnlineNodes++
+		// Parts of a heal job run on every server under the same ID.
+		for _, job := range summary.HealJobs {
+			healJobs[job.ID] = struct{}{}
+		}
+		if !summary.OldestLock.IsZero() && (oldestLock.IsZero() || summary.OldestLock.Before(oldestLock)) {
+			oldestLock = summary.OldestLock
+		}
+		versions[summary.Version+" ("+summary.CommitID+")"] = struct{}{}
+		scans = append(scans, summary.UsageScans)
+	}
+
+	cluster.ActiveHeals = len(healJobs)
+	if !oldestLock.IsZero() {
+		cluster.OldestLockAge = now.Sub(oldestLock)
+	}
+	for version := range versions {
+		cluster.Versions = append(cluster.Versions, version)
+	}
+	sort.Strings(cluster.Versions)
+	cluster.VersionConsistent = len(cluster.Versions) == 1
+	cluster.Objects = countScannedObjects(scans)
+	if len(cluster.OfflineNodes) > 0 {
+		cluster.Partial = []string{summaryObjects, summaryActiveHeals, summaryOldestLockAge, summaryVersionConsistent}
+	}
+	return cluster
+}
+
+// countScannedObjects - returns the objects counted by the latest
+// usage scan of every bucket which completed without error, summing
+// the parts of the scan run by every server.
+func countScannedObjects(scans [][]UsageScanStatus) int64 {
+	merged := make(map[string]*UsageScanStatus)
+	for _, serverScans := range scans {
+		for _, scan := range serverScans {
+			status, ok := merged[scan.ID]
+			if !ok {
+				status = &UsageScanStatus{ID: scan.ID, Bucket: scan.Bucket, Started: scan.Started, Done: true}
+				merged[scan.ID] = status
+			}
+			status.Objects += scan.Objects
+			status.Done = status.Done && scan.Done && scan.Error == ""
+		}
+	}
+
+	latest := make(map[string]*UsageScanStatus)
+	for _, status := range merged {
+		if !status.Done {
+			continue
+		}
+		if last, ok := latest[status.Bucket]; !ok || status.Started.After(last.Started) {
+			latest[status.Bucket] = status
+		}
+	}
+
+	var objects int64
+	for _, status := range latest {
+		objects += status.Objects
+	}
+	return objects
+}
@@ -0,0 +1,126 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"sync"
+)
+
+// Conditional writes config name.
+const conditionalWritesConfig = "conditional-writes.json"
+
+// conditionalWrites - whether If-Match and If-None-Match headers are
+// honored on writes, can be changed at runtime via admin RPC.
+// Enabled by default.
+type conditionalWrites struct {
+	mutex   sync.RWMutex
+	enabled bool
+}
+
+var globalConditionalWrites = &conditionalWrites{enabled: true}
+
+// get - returns true if conditional writes are honored.
+func (c *conditionalWrites) get() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.enabled
+}
+
+// set - enables or disables conditional writes.
+func (c *conditionalWrites) set(enabled bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.enabled = enabled
+}
+
+// writeConditionalWrites - persists whether conditional writes are
+// honored, enabling them removes any previously persisted setting.
+func writeConditionalWrites(objAPI ObjectLayer, enabled bool) error {
+	if enabled {
+		return writeServerSetting(objAPI, conditionalWritesConfig, nil)
+	}
+	return writeServerSetting(objAPI, conditionalWritesConfig, ConditionalWrites{Enabled: enabled})
+}
+
+// initConditionalWrites - loads whether conditional writes are
+// honored, so that a restarted server rejects the same writes as its
+// peers.
+func initConditionalWrites(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	var setting ConditionalWrites
+	ok, err := readServerSetting(objAPI, conditionalWritesConfig, &setting)
+	if err != nil {
+		if isErrIgnored(err, errDiskNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	globalConditionalWrites.set(setting.Enabled)
+	return nil
+}
+
+// checkWritePreconditions - validates If-Match and If-None-Match
+// headers of a write against the object being overwritten. Writes
+// error response and returns true if the write must not proceed.
+// Callers must hold the write lock on the object, so that no other
+// write can slip in between the check and the write.
+//
+//	If-Match      - object must exist and match the ETag, `*` matches any ETag.
+//	If-None-Match - object must not match the ETag, `*` matches any ETag.
+func checkWritePreconditions(w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer, bucket, object string) bool {
+	ifMatchETagHeader := r.Header.Get("If-Match")
+	ifNoneMatchETagHeader := r.Header.Get("If-None-Match")
+	if ifMatchETagHeader == "" && ifNoneMatchETagHeader == "" {
+		return false
+	}
+	if !globalConditionalWrites.get() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return true
+	}
+
+	objectExists := true
+	objInfo, err := objectAPI.GetObjectInfo(bucket, object)
+	if err != nil {
+		if _, ok := errorCause(err).(ObjectNotFound); !ok {
+			errorIf(err, "Unable to fetch object info.")
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return true
+		}
+		objectExists = false
+	}
+
+	if ifMatchETagHeader != "" {
+		if !objectExists || (ifMatchETagHeader != "*" && !isETagEqual(objInfo.MD5Sum, ifMatchETagHeader)) {
+			writeErrorResponse(w, ErrPreconditionFailed, r.URL)
+			return true
+		}
+	}
+	if ifNoneMatchETagHeader != "" {
+		if objectExists && (ifNoneMatchETagHeader == "*" || isETagEqual(objInfo.MD5Sum, ifNoneMatchETagHeader)) {
+			writeErrorResponse(w, ErrPreconditionFailed, r.URL)
+			return true
+		}
+	}
+	return false
+}
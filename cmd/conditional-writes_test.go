@@ -0,0 +1,61 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+// TestConditionalWritesRestart - tests whether conditional writes are
+// honored is loaded again by a restarted server.
+func TestConditionalWritesRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalConditionalWrites()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	if err = writeConditionalWrites(objLayer, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, conditional writes stay disabled.
+	resetGlobalConditionalWrites()
+	if err = initConditionalWrites(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if globalConditionalWrites.get() {
+		t.Fatal("Expected conditional writes to be disabled after restart")
+	}
+
+	// Enabling them again removes the persisted setting.
+	if err = writeConditionalWrites(objLayer, true); err != nil {
+		t.Fatal(err)
+	}
+	resetGlobalConditionalWrites()
+	if err = initConditionalWrites(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if !globalConditionalWrites.get() {
+		t.Fatal("Expected conditional writes to be enabled")
+	}
+}
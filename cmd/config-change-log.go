@@ -0,0 +1,213 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Config changes kept in the log of every server, older ones are
+	// dropped.
+	configChangeLogSize = 1000
+
+	// Value logged in place of secrets.
+	redactedConfigValue = "REDACTED"
+)
+
+// Parameters of config changes whose name contains any of these are
+// never logged in the clear.
+var redactedConfigParams = []string{"secret", "password", "token"}
+
+// Query parameters selecting the admin API resource of config changes,
+// not part of the changes.
+var configChangeResources = []string{"config", "service", "heal"}
+
+// ConfigChange - change made to the config of all servers through the
+// admin API.
+type ConfigChange struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	AccessKey string    `json:"accessKey"`
+	Operation string    `json:"operation"`
+	// Parameters of the change, the settings changed and their new
+	// values, with secrets redacted.
+	Diff map[string]string `json:"diff"`
+}
+
+// newConfigChange - returns the change made by the config request r
+// at now, by the access key r is signed with.
+func newConfigChange(r *http.Request, now time.Time) ConfigChange {
+	change := ConfigChange{
+		ID:        mustGetUUID(),
+		Time:      now,
+		AccessKey: getReqAccessKeyV4(r),
+		Operation: r.Header.Get(minioAdminOpHeader),
+		Diff:      make(map[string]string),
+	}
+	for param, values := range r.URL.Query() {
+		if contains(configChangeResources, param) {
+			continue
+		}
+		change.Diff[param] = redactConfigParam(param, strings.Join(values, ","))
+	}
+	return change
+}
+
+// redactConfigParam - returns value, redacted if param names a secret.
+func redactConfigParam(param, value string) string {
+	param = strings.ToLower(param)
+	for _, secret := range redactedConfigParams {
+		if strings.Contains(param, secret) {
+			return redactedConfigValue
+		}
+	}
+	return value
+}
+
+// configChangeLog - append-only log of the config changes applied on
+// this server, kept in memory.
+type configChangeLog struct {
+	mutex   sync.Mutex
+	changes []ConfigChange
+}
+
+var globalConfigChangeLog = &configChangeLog{}
+
+// append - logs change, dropping the oldest change once the log is
+// full.
+func (l *configChangeLog) append(change ConfigChange) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.changes = append(l.changes, change)
+	if len(l.changes) > configChangeLogSize {
+		l.changes = append([]ConfigChange{}, l.changes[len(l.changes)-configChangeLogSize:]...)
+	}
+}
+
+// logApplied - logs change unless err, the error applying it, is not
+// nil. Returns err. Changes not made through the admin API are nil and
+// not logged.
+func (l *configChangeLog) logApplied(change *ConfigChange, err error) error {
+	if err == nil && change != nil {
+		l.append(*change)
+	}
+	return err
+}
+
+// last - returns the last n changes, latest first.
+func (l *configChangeLog) last(n int) []ConfigChange {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if n > len(l.changes) {
+		n = len(l.changes)
+	}
+	changes := make([]ConfigChange, 0, n)
+	for i := len(l.changes) - 1; i >= len(l.changes)-n; i-- {
+		changes = append(changes, l.changes[i])
+	}
+	return changes
+}
+
+// configChangeKey - context key of the config change requested.
+type configChangeKey struct{}
+
+// configChangeFromContext - returns the config change requested
+// through the admin API ctx is carrying, nil if none.
+func configChangeFromContext(ctx context.Context) *ConfigChange {
+	change, _ := ctx.Value(configChangeKey{}).(*ConfigChange)
+	return change
+}
+
+// logConfigChange - wraps a handler changing the config of all servers
+// to pass the change down to the servers, each logging it once it
+// applied it.
+func logConfigChange(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		change := newConfigChange(r, time.Now().UTC())
+		handler(w, r.WithContext(context.WithValue(r.Context(), configChangeKey{}, &change)))
+	}
+}
+
+// ConfigChangeStatus - config change along with the servers it was
+// logged on. A change which was not logged on all servers reached is
+// inconsistent, it may have been applied partially.
+type ConfigChangeStatus struct {
+	ConfigChange
+	Nodes        []string `json:"nodes"`
+	MissingNodes []string `json:"missingNodes,omitempty"`
+	Inconsistent bool     `json:"inconsistent"`
+}
+
+// byConfigChangeTime - sorts config changes latest first.
+type byConfigChangeTime []ConfigChangeStatus
+
+func (s byConfigChangeTime) Len() int           { return len(s) }
+func (s byConfigChangeTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byConfigChangeTime) Less(i, j int) bool { return s[i].Time.After(s[j].Time) }
+
+// reconcileConfigChanges - merges the last n changes logged by the
+// servers at addrs, logs of servers with errs are skipped. A server
+// returning a full log of n changes may have dropped older changes
+// from its answer, it only misses changes newer than its oldest one.
+func reconcileConfigChanges(addrs []string, logs [][]ConfigChange, errs []error, n int) []ConfigChangeStatus {
+	merged := make(map[string]*ConfigChangeStatus)
+	for i, addr := range addrs {
+		if errs[i] != nil {
+			continue
+		}
+		for _, change := range logs[i] {
+			status, ok := merged[change.ID]
+			if !ok {
+				status = &ConfigChangeStatus{ConfigChange: change}
+				merged[change.ID] = status
+			}
+			status.Nodes = append(status.Nodes, addr)
+		}
+	}
+
+	statuses := []ConfigChangeStatus{}
+	for _, status := range merged {
+		statuses = append(statuses, *status)
+	}
+	sort.Sort(byConfigChangeTime(statuses))
+	if len(statuses) > n {
+		statuses = statuses[:n]
+	}
+
+	for i := range statuses {
+		status := &statuses[i]
+		for j, addr := range addrs {
+			if errs[j] != nil || contains(status.Nodes, addr) {
+				continue
+			}
+			log := logs[j]
+			if len(log) == n && status.Time.Before(log[len(log)-1].Time) {
+				// Older than the answer of the server.
+				continue
+			}
+			status.MissingNodes = append(status.MissingNodes, addr)
+		}
+		status.Inconsistent = len(status.MissingNodes) > 0
+	}
+	return statuses
+}
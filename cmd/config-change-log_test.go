@@ -0,0 +1,131 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestConfigChangeLog - tests the log keeps the latest changes.
+func TestConfigChangeLog(t *testing.T) {
+	log := &configChangeLog{}
+	for i := 0; i < configChangeLogSize+10; i++ {
+		log.append(ConfigChange{ID: fmt.Sprint(i)})
+	}
+
+	changes := log.last(3)
+	var ids []string
+	for _, change := range changes {
+		ids = append(ids, change.ID)
+	}
+	expected := []string{fmt.Sprint(configChangeLogSize + 9), fmt.Sprint(configChangeLogSize + 8), fmt.Sprint(configChangeLogSize + 7)}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+
+	changes = log.last(2 * configChangeLogSize)
+	if len(changes) != configChangeLogSize || changes[len(changes)-1].ID != "10" {
+		t.Fatalf("Expected the oldest changes to be dropped, got %d changes", len(changes))
+	}
+}
+
+// TestNewConfigChange - tests config changes are logged with the
+// access key of the requester and their parameters, secrets redacted.
+func TestNewConfigChange(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	req, err := newTestRequest("POST", "/?config&depth=3&secret-key=abc", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(minioAdminOpHeader, "set-max-list-depth")
+	if err = signRequestV4(req, "requester", "requester-secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	change := newConfigChange(req, now)
+	if change.ID == "" || !change.Time.Equal(now) || change.Operation != "set-max-list-depth" {
+		t.Fatalf("Unexpected change %#v", change)
+	}
+	if change.AccessKey != "requester" {
+		t.Fatalf("Expected access key %s, got %s", "requester", change.AccessKey)
+	}
+	expected := map[string]string{"depth": "3", "secret-key": redactedConfigValue}
+	if !reflect.DeepEqual(change.Diff, expected) {
+		t.Fatalf("Expected diff %v, got %v", expected, change.Diff)
+	}
+}
+
+// TestReconcileConfigChanges - tests changes logged by some servers
+// only are flagged inconsistent.
+func TestReconcileConfigChanges(t *testing.T) {
+	now := time.Now().UTC()
+	first := ConfigChange{ID: "first", Time: now.Add(-2 * time.Minute)}
+	partial := ConfigChange{ID: "partial", Time: now.Add(-time.Minute)}
+	last := ConfigChange{ID: "last", Time: now}
+
+	addrs := []string{"server0:9000", "server1:9000", "server2:9000", "server3:9000"}
+	logs := [][]ConfigChange{
+		{last, partial, first},
+		{last, first},
+		{last, partial, first},
+		nil,
+	}
+	errs := []error{nil, nil, nil, errDiskNotFound}
+
+	statuses := reconcileConfigChanges(addrs, logs, errs, 3)
+	if len(statuses) != 3 {
+		t.Fatalf("Expected 3 changes, got %v", statuses)
+	}
+	for i, id := range []string{"last", "partial", "first"} {
+		if statuses[i].ID != id {
+			t.Fatalf("Change %d: expected %s, got %s", i, id, statuses[i].ID)
+		}
+		// The unreachable server is not taken as missing the change.
+		if id == "partial" {
+			if !statuses[i].Inconsistent || !reflect.DeepEqual(statuses[i].MissingNodes, []string{"server1:9000"}) {
+				t.Fatalf("Expected the partial change to be missing on server1:9000, got %#v", statuses[i])
+			}
+			continue
+		}
+		if statuses[i].Inconsistent || len(statuses[i].Nodes) != 3 {
+			t.Fatalf("Expected change %s to be consistent, got %#v", id, statuses[i])
+		}
+	}
+
+	// A full answer does not miss changes older than its oldest one.
+	statuses = reconcileConfigChanges(addrs, [][]ConfigChange{
+		{last, partial},
+		{last, first},
+		{last, partial},
+		nil,
+	}, errs, 2)
+	if len(statuses) != 2 || statuses[1].ID != "partial" {
+		t.Fatalf("Expected the last two changes, got %v", statuses)
+	}
+	if !reflect.DeepEqual(statuses[1].MissingNodes, []string{"server1:9000"}) {
+		t.Fatalf("Expected the partial change to be missing on server1:9000, got %#v", statuses[1])
+	}
+}
@@ -17,8 +17,11 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/minio/minio/pkg/quick"
 )
@@ -40,6 +43,13 @@ type serverConfigV13 struct {
 
 	// Notification queue configuration.
 	Notify *notifier `json:"notify"`
+
+	// TLS cipher suites set by the admin API, the default suites
+	// if empty.
+	TLSCiphers []string `json:"tlsCiphers,omitempty"`
+
+	// Expiry of the credential set by the admin API, by access key.
+	CredentialExpiry map[string]time.Time `json:"credentialExpiry,omitempty"`
 }
 
 // newConfig - initialize a new server config, saves creds from env
@@ -184,6 +194,38 @@ func (s serverConfigV13) GetCredential() credential {
 	return s.Credential
 }
 
+// SetTLSCiphers set the TLS cipher suites offered.
+func (s *serverConfigV13) SetTLSCiphers(names []string) {
+	serverConfigMu.Lock()
+	defer serverConfigMu.Unlock()
+
+	s.TLSCiphers = names
+}
+
+// GetTLSCiphers get the TLS cipher suites offered.
+func (s serverConfigV13) GetTLSCiphers() []string {
+	serverConfigMu.RLock()
+	defer serverConfigMu.RUnlock()
+
+	return s.TLSCiphers
+}
+
+// SetCredentialExpiry set the expiry of access keys.
+func (s *serverConfigV13) SetCredentialExpiry(expiry map[string]time.Time) {
+	serverConfigMu.Lock()
+	defer serverConfigMu.Unlock()
+
+	s.CredentialExpiry = expiry
+}
+
+// GetCredentialExpiry get the expiry of access keys.
+func (s serverConfigV13) GetCredentialExpiry() map[string]time.Time {
+	serverConfigMu.RLock()
+	defer serverConfigMu.RUnlock()
+
+	return s.CredentialExpiry
+}
+
 // Save config.
 func (s serverConfigV13) Save() error {
 	serverConfigMu.RLock()
@@ -204,3 +246,66 @@ func (s serverConfigV13) Save() error {
 	// Save config file.
 	return qc.Save(configFile)
 }
+
+// errInvalidConfig - config.json sent to a server is malformed, of
+// another version or with invalid credentials.
+var errInvalidConfig = errors.New("config.json is malformed, of another version or has invalid credentials")
+
+// errConfigCredentialChanged - config.json sent to a server has
+// credentials other than the ones of the server. Peers authenticate
+// each other with the credentials they started with, so they can not
+// be changed by setting config.json.
+var errConfigCredentialChanged = errors.New("config.json can not change the credentials of the server")
+
+// checkConfigCredential - returns errConfigCredentialChanged unless
+// srvCfg has the credentials of this server.
+func checkConfigCredential(srvCfg *serverConfigV13) error {
+	cred := serverConfig.GetCredential()
+	if srvCfg.Credential.AccessKey != cred.AccessKey || srvCfg.Credential.SecretKey != cred.SecretKey {
+		return errConfigCredentialChanged
+	}
+	return nil
+}
+
+// parseServerConfig - unmarshals and validates config.json.
+func parseServerConfig(configBytes []byte) (*serverConfigV13, error) {
+	srvCfg := &serverConfigV13{}
+	if err := json.Unmarshal(configBytes, srvCfg); err != nil {
+		return nil, errInvalidConfig
+	}
+	if srvCfg.Version != globalMinioConfigVersion || srvCfg.Logger == nil || srvCfg.Notify == nil {
+		return nil, errInvalidConfig
+	}
+	if validateAuthKeys(srvCfg.Credential.AccessKey, srvCfg.Credential.SecretKey) != nil {
+		return nil, errInvalidConfig
+	}
+	return srvCfg, nil
+}
+
+// setServerConfig - validates config.json, saves it and makes it the
+// config of this server. Credentials can not be changed, TLS cipher
+// suites and credential expiries, changed by their own admin API, are
+// kept. Loggers and notification targets are set up again on restart
+// only.
+func setServerConfig(configBytes []byte) error {
+	srvCfg, err := parseServerConfig(configBytes)
+	if err != nil {
+		return err
+	}
+	if err = checkConfigCredential(srvCfg); err != nil {
+		return err
+	}
+	srvCfg.TLSCiphers = serverConfig.GetTLSCiphers()
+	srvCfg.CredentialExpiry = serverConfig.GetCredentialExpiry()
+	srvCfg.SetCredential(srvCfg.Credential)
+
+	// Save before swapping, a config which could not be saved is not
+	// applied.
+	if err = srvCfg.Save(); err != nil {
+		return err
+	}
+	serverConfigMu.Lock()
+	serverConfig = srvCfg
+	serverConfigMu.Unlock()
+	return nil
+}
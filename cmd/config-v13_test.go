@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -118,3 +119,70 @@ func TestServerConfig(t *testing.T) {
 		t.Fatalf("Unable to initialize from updated config file %s", err)
 	}
 }
+
+// TestSetServerConfig - tests a valid config.json is saved and applied,
+// and an invalid one rejected.
+func TestSetServerConfig(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("Init Test config failed")
+	}
+	// remove the root directory after the test ends.
+	defer removeAll(rootPath)
+
+	valid := *serverConfig
+	valid.Region = "us-west-1"
+	validBytes, err := json.Marshal(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalidConfigs := []serverConfigV13{valid, valid, valid}
+	invalidConfigs[0].Version = "12"
+	invalidConfigs[1].Credential.SecretKey = "short"
+	invalidConfigs[2].Notify = nil
+	invalidBytes := [][]byte{[]byte("{"), []byte("{}")}
+	for _, config := range invalidConfigs {
+		configBytes, mErr := json.Marshal(config)
+		if mErr != nil {
+			t.Fatal(mErr)
+		}
+		invalidBytes = append(invalidBytes, configBytes)
+	}
+	for i, configBytes := range invalidBytes {
+		if err = setServerConfig(configBytes); err != errInvalidConfig {
+			t.Errorf("Test %d: expected %v, got %v", i+1, errInvalidConfig, err)
+		}
+		if serverConfig.GetRegion() != globalMinioDefaultRegion {
+			t.Errorf("Test %d: expected region %s, got %s", i+1, globalMinioDefaultRegion, serverConfig.GetRegion())
+		}
+	}
+
+	// Credentials can not be changed.
+	changed := valid
+	changed.Credential.SecretKey = "changed-secret-key"
+	changedBytes, err := json.Marshal(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = setServerConfig(changedBytes); err != errConfigCredentialChanged {
+		t.Errorf("Expected %v, got %v", errConfigCredentialChanged, err)
+	}
+	if serverConfig.GetRegion() != globalMinioDefaultRegion {
+		t.Errorf("Expected region %s, got %s", globalMinioDefaultRegion, serverConfig.GetRegion())
+	}
+
+	if err = setServerConfig(validBytes); err != nil {
+		t.Fatal(err)
+	}
+	if serverConfig.GetRegion() != "us-west-1" {
+		t.Errorf("Expected region us-west-1, got %s", serverConfig.GetRegion())
+	}
+	// The config is saved.
+	if err = loadConfig(credential{}); err != nil {
+		t.Fatal(err)
+	}
+	if serverConfig.GetRegion() != "us-west-1" {
+		t.Errorf("Expected saved region us-west-1, got %s", serverConfig.GetRegion())
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// errCredentialExpired - the access key authenticating a request has
+// expired.
+var errCredentialExpired = errors.New("The access key has expired")
+
+// credentialExpiry - time after which every access key is rejected,
+// can be changed at runtime via admin RPC. Expiries are saved in
+// config.json, a restarted server keeps rejecting an expired key.
+type credentialExpiry struct {
+	mutex  sync.RWMutex
+	expiry map[string]time.Time
+}
+
+func newCredentialExpiry() *credentialExpiry {
+	return &credentialExpiry{expiry: make(map[string]time.Time)}
+}
+
+var globalCredentialExpiry = newCredentialExpiry()
+
+// get - returns the expiry of accessKey, false if it never expires.
+func (c *credentialExpiry) get(accessKey string) (time.Time, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	expiry, ok := c.expiry[accessKey]
+	return expiry, ok
+}
+
+// set - sets the expiry of accessKey, the zero time removes it.
+func (c *credentialExpiry) set(accessKey string, expiry time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if expiry.IsZero() {
+		delete(c.expiry, accessKey)
+		return
+	}
+	c.expiry[accessKey] = expiry
+}
+
+// isExpired - returns true if accessKey expired at or before now.
+func (c *credentialExpiry) isExpired(accessKey string, now time.Time) bool {
+	expiry, ok := c.get(accessKey)
+	return ok && !now.Before(expiry)
+}
+
+// setCredentialExpiry - sets the expiry of accessKey, an expiry sent by
+// a peer whose clock read sentAt. The expiry is moved by the skew
+// between both clocks, so that every server rejects the key at the
+// same moment whatever the time its clock shows. Only the server
+// credential can expire.
+func setCredentialExpiry(accessKey string, expiry, sentAt time.Time) error {
+	if accessKey != serverConfig.GetCredential().AccessKey {
+		return errInvalidAccessKeyID
+	}
+	// Expiries of former server credentials are dropped.
+	var saved map[string]time.Time
+	if !expiry.IsZero() {
+		expiry = time.Now().UTC().Add(expiry.Sub(sentAt))
+		saved = map[string]time.Time{accessKey: expiry}
+	}
+
+	prevSaved := serverConfig.GetCredentialExpiry()
+	serverConfig.SetCredentialExpiry(saved)
+	if err := serverConfig.Save(); err != nil {
+		serverConfig.SetCredentialExpiry(prevSaved)
+		return err
+	}
+	globalCredentialExpiry.set(accessKey, expiry)
+	return nil
+}
+
+// initCredentialExpiry - loads the expiries saved in config.json.
+func initCredentialExpiry() {
+	for accessKey, expiry := range serverConfig.GetCredentialExpiry() {
+		globalCredentialExpiry.set(accessKey, expiry)
+	}
+}
+
+// checkCredentialExpiry - returns errCredentialExpired if accessKey has
+// expired. Requests are checked once authenticated, requests in
+// progress complete.
+func checkCredentialExpiry(accessKey string) error {
+	if globalCredentialExpiry.isExpired(accessKey, time.Now().UTC()) {
+		return errCredentialExpired
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSetCredentialExpiry - tests expiries are corrected for the clock
+// skew of the sender and enforced on authentication.
+func TestSetCredentialExpiry(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalCredentialExpiry()
+
+	accessKey := serverConfig.GetCredential().AccessKey
+	if err = setCredentialExpiry("unknown", time.Now().UTC(), time.Now().UTC()); err != errInvalidAccessKeyID {
+		t.Fatalf("Expected %v, got %v", errInvalidAccessKeyID, err)
+	}
+
+	// The sender's clock is 10 minutes ahead, the key still expires
+	// an hour from now.
+	now := time.Now().UTC()
+	sentAt := now.Add(10 * time.Minute)
+	if err = setCredentialExpiry(accessKey, sentAt.Add(time.Hour), sentAt); err != nil {
+		t.Fatal(err)
+	}
+	expiry, ok := globalCredentialExpiry.get(accessKey)
+	if !ok || expiry.Before(now.Add(time.Hour)) || expiry.After(time.Now().UTC().Add(time.Hour)) {
+		t.Fatalf("Expected expiry an hour from now, got %v", expiry)
+	}
+	if checkCredentialExpiry(accessKey) != nil || credentialUsed(ErrNone) != ErrNone {
+		t.Fatal("Expected the access key to be valid until it expires")
+	}
+	if !globalCredentialExpiry.isExpired(accessKey, expiry) {
+		t.Fatal("Expected the access key to be expired at its expiry")
+	}
+
+	// An expiry in the past invalidates the key right away.
+	if err = setCredentialExpiry(accessKey, now.Add(-time.Minute), now); err != nil {
+		t.Fatal(err)
+	}
+	if checkCredentialExpiry(accessKey) != errCredentialExpired {
+		t.Fatal("Expected the access key to be expired")
+	}
+	if s3Error := credentialUsed(ErrNone); s3Error != ErrAccessKeyExpired {
+		t.Fatalf("Expected %v, got %v", ErrAccessKeyExpired, s3Error)
+	}
+	// Failed authentications are reported as they are.
+	if s3Error := credentialUsed(ErrSignatureDoesNotMatch); s3Error != ErrSignatureDoesNotMatch {
+		t.Fatalf("Expected %v, got %v", ErrSignatureDoesNotMatch, s3Error)
+	}
+	if _, err = authenticateWeb(accessKey, serverConfig.GetCredential().SecretKey); err != errCredentialExpired {
+		t.Fatalf("Expected %v, got %v", errCredentialExpired, err)
+	}
+	// Nodes keep authenticating each other.
+	if _, err = authenticateNode(accessKey, serverConfig.GetCredential().SecretKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// The zero time removes the expiry.
+	if err = setCredentialExpiry(accessKey, time.Time{}, now); err != nil {
+		t.Fatal(err)
+	}
+	if checkCredentialExpiry(accessKey) != nil {
+		t.Fatal("Expected the access key to never expire")
+	}
+}
+
+// TestCredentialExpiryRestart - tests an expired credential is still
+// rejected once the server restarts.
+func TestCredentialExpiryRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalCredentialExpiry()
+
+	accessKey := serverConfig.GetCredential().AccessKey
+	now := time.Now().UTC()
+	if err = setCredentialExpiry(accessKey, now.Add(-time.Minute), now); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, config.json is loaded again.
+	resetGlobalCredentialExpiry()
+	if err = loadConfig(credential{}); err != nil {
+		t.Fatal(err)
+	}
+	initCredentialExpiry()
+	if checkCredentialExpiry(accessKey) != errCredentialExpired {
+		t.Fatal("Expected the access key to stay expired after restart")
+	}
+
+	// Removing the expiry removes it from config.json.
+	if err = setCredentialExpiry(accessKey, time.Time{}, now); err != nil {
+		t.Fatal(err)
+	}
+	resetGlobalCredentialExpiry()
+	if err = loadConfig(credential{}); err != nil {
+		t.Fatal(err)
+	}
+	initCredentialExpiry()
+	if checkCredentialExpiry(accessKey) != nil {
+		t.Fatal("Expected the access key to never expire after restart")
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// CredUsage - last time an access key authenticated a client request.
+// Uses are only remembered in memory, NeverUsed means the key was not
+// used since the servers started.
+type CredUsage struct {
+	AccessKey string    `json:"accessKey"`
+	LastUsed  time.Time `json:"lastUsed"`
+	NeverUsed bool      `json:"neverUsed"`
+}
+
+// isUnused - returns true if the key was never used, or last used
+// more than olderThan before now.
+func (u CredUsage) isUnused(olderThan time.Duration, now time.Time) bool {
+	return u.NeverUsed || now.Sub(u.LastUsed) > olderThan
+}
+
+// credentialUsage - last use of every access key which authenticated a
+// client request on this server. Requests of peers authenticating each
+// other are not uses.
+type credentialUsage struct {
+	mutex    sync.Mutex
+	lastUsed map[string]time.Time
+}
+
+func newCredentialUsage() *credentialUsage {
+	return &credentialUsage{lastUsed: make(map[string]time.Time)}
+}
+
+var globalCredentialUsage = newCredentialUsage()
+
+// record - records a use of accessKey at now.
+func (c *credentialUsage) record(accessKey string, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if now.After(c.lastUsed[accessKey]) {
+		c.lastUsed[accessKey] = now
+	}
+}
+
+// get - returns the usage of the access keys in accessKeys.
+func (c *credentialUsage) get(accessKeys []string) []CredUsage {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	usages := make([]CredUsage, 0, len(accessKeys))
+	for _, accessKey := range accessKeys {
+		lastUsed, ok := c.lastUsed[accessKey]
+		usages = append(usages, CredUsage{AccessKey: accessKey, LastUsed: lastUsed, NeverUsed: !ok})
+	}
+	return usages
+}
+
+// serverCredentialUsage - returns the usage of the access keys which
+// are currently valid on this server.
+func serverCredentialUsage() []CredUsage {
+	return globalCredentialUsage.get([]string{serverConfig.GetCredential().AccessKey})
+}
+
+// credentialUsed - records a use of the server credential if s3Error
+// is ErrNone, returns s3Error. Uses of an expired server credential are
+// rejected with ErrAccessKeyExpired instead.
+func credentialUsed(s3Error APIErrorCode) APIErrorCode {
+	if s3Error == ErrNone {
+		accessKey := serverConfig.GetCredential().AccessKey
+		if checkCredentialExpiry(accessKey) != nil {
+			return ErrAccessKeyExpired
+		}
+		globalCredentialUsage.record(accessKey, time.Now().UTC())
+	}
+	return s3Error
+}
@@ -0,0 +1,66 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestCredentialUsage - tests recording the last use of access keys.
+func TestCredentialUsage(t *testing.T) {
+	c := newCredentialUsage()
+	used := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c.record("key1", used)
+	// Uses recorded late do not move the last use back.
+	c.record("key1", used.Add(-time.Hour))
+
+	expected := []CredUsage{
+		{AccessKey: "key1", LastUsed: used},
+		{AccessKey: "key2", NeverUsed: true},
+	}
+	if usages := c.get([]string{"key1", "key2"}); !reflect.DeepEqual(usages, expected) {
+		t.Fatalf("Expected %v, got %v", expected, usages)
+	}
+}
+
+// TestCredentialUsed - tests that only successful authentications are
+// uses of the server credential.
+func TestCredentialUsed(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalCredentialUsage()
+
+	if s3Error := credentialUsed(ErrSignatureDoesNotMatch); s3Error != ErrSignatureDoesNotMatch {
+		t.Fatalf("Expected %v, got %v", ErrSignatureDoesNotMatch, s3Error)
+	}
+	if usages := serverCredentialUsage(); len(usages) != 1 || !usages[0].NeverUsed {
+		t.Fatalf("Expected the server credential never to be used, got %v", usages)
+	}
+
+	if s3Error := credentialUsed(ErrNone); s3Error != ErrNone {
+		t.Fatalf("Expected %v, got %v", ErrNone, s3Error)
+	}
+	usages := serverCredentialUsage()
+	if len(usages) != 1 || usages[0].NeverUsed || usages[0].AccessKey != serverConfig.GetCredential().AccessKey {
+		t.Fatalf("Expected the server credential to be used, got %v", usages)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Number of decode failures kept by every server.
+	decodeFailureLogSize = 1000
+
+	// Number of object heals kept by every server, to tell which
+	// failures were resolved.
+	objectHealLogSize = 10000
+)
+
+// DecodeFailure - failure to read a part of an erasure coded object,
+// too many of its shards being missing or corrupt to decode it. A
+// failure is resolved once the object was healed afterwards, through
+// any server.
+type DecodeFailure struct {
+	Node       string    `json:"node"`
+	Time       time.Time `json:"time"`
+	Bucket     string    `json:"bucket"`
+	Object     string    `json:"object"`
+	Part       string    `json:"part"`
+	Missing    int       `json:"missing"`
+	Corrupt    int       `json:"corrupt"`
+	Resolved   bool      `json:"resolved"`
+	ResolvedAt time.Time `json:"resolvedAt"`
+}
+
+// ObjectHeal - successful heal of an object.
+type ObjectHeal struct {
+	Time   time.Time
+	Bucket string
+	Object string
+}
+
+// NodeDecodeFailures - decode failures and object heals of a server,
+// heals resolve the failures of every server.
+type NodeDecodeFailures struct {
+	Failures []DecodeFailure
+	Heals    []ObjectHeal
+}
+
+// decodeFailureLog - last decode failures of this server and last
+// objects it healed.
+type decodeFailureLog struct {
+	mutex    sync.Mutex
+	failures []DecodeFailure
+	heals    []ObjectHeal
+}
+
+var globalDecodeFailures = &decodeFailureLog{}
+
+// record - keeps failure, forgetting the oldest failure if the log is
+// full.
+func (l *decodeFailureLog) record(failure DecodeFailure) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.failures = append(l.failures, failure)
+	if len(l.failures) > decodeFailureLogSize {
+		l.failures = append([]DecodeFailure{}, l.failures[len(l.failures)-decodeFailureLogSize:]...)
+	}
+}
+
+// healed - keeps heal, forgetting the oldest heal if the log is full.
+func (l *decodeFailureLog) healed(heal ObjectHeal) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.heals = append(l.heals, heal)
+	if len(l.heals) > objectHealLogSize {
+		l.heals = append([]ObjectHeal{}, l.heals[len(l.heals)-objectHealLogSize:]...)
+	}
+}
+
+// last - returns the last n failures, latest first, along with all
+// heals kept.
+func (l *decodeFailureLog) last(n int) NodeDecodeFailures {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if n > len(l.failures) {
+		n = len(l.failures)
+	}
+	failures := make([]DecodeFailure, 0, n)
+	for i := len(l.failures) - 1; i >= len(l.failures)-n; i-- {
+		failures = append(failures, l.failures[i])
+	}
+	return NodeDecodeFailures{
+		Failures: failures,
+		Heals:    append([]ObjectHeal{}, l.heals...),
+	}
+}
+
+// recordDecodeFailure - records the failure to decode path of volume
+// out of disks, offline disks and disks whose shard could not be read
+// being nil. Shards of disks flagged in corrupt failed their checksum.
+func recordDecodeFailure(volume, path string, disks []StorageAPI, corrupt []bool) {
+	failure := DecodeFailure{Time: time.Now().UTC(), Bucket: volume, Object: path}
+	if i := strings.LastIndex(path, slashSeparator); i != -1 {
+		failure.Object, failure.Part = path[:i], path[i+1:]
+	}
+	for i, disk := range disks {
+		switch {
+		case corrupt[i]:
+			failure.Corrupt++
+		case disk == nil:
+			failure.Missing++
+		}
+	}
+	globalDecodeFailures.record(failure)
+}
+
+// objectHealed - records a successful heal of object.
+func objectHealed(bucket, object string) {
+	globalDecodeFailures.healed(ObjectHeal{Time: time.Now().UTC(), Bucket: bucket, Object: object})
+}
+
+// getLocalDecodeFailureLog - returns the last n decode failures of
+// this server and the objects it healed.
+func getLocalDecodeFailureLog(n int) (NodeDecodeFailures, error) {
+	if n <= 0 || n > decodeFailureLogSize {
+		return NodeDecodeFailures{}, errInvalidArgument
+	}
+	return globalDecodeFailures.last(n), nil
+}
+
+// mergeDecodeFailures - returns the last n failures of all servers,
+// latest first. Failures of objects healed afterwards by any server
+// are marked resolved, by the first heal which followed them.
+func mergeDecodeFailures(views []NodeDecodeFailures, n int) []DecodeFailure {
+	heals := make(map[string][]time.Time)
+	failures := []DecodeFailure{}
+	for _, view := range views {
+		for _, heal := range view.Heals {
+			key := pathJoin(heal.Bucket, heal.Object)
+			heals[key] = append(heals[key], heal.Time)
+		}
+		failures = append(failures, view.Failures...)
+	}
+
+	for i := range failures {
+		failure := &failures[i]
+		for _, healed := range heals[pathJoin(failure.Bucket, failure.Object)] {
+			if !healed.After(failure.Time) {
+				continue
+			}
+			if !failure.Resolved || healed.Before(failure.ResolvedAt) {
+				failure.Resolved = true
+				failure.ResolvedAt = healed
+			}
+		}
+	}
+
+	sort.Sort(byDecodeFailureTime(failures))
+	if len(failures) > n {
+		failures = failures[:n]
+	}
+	return failures
+}
+
+// byDecodeFailureTime - sorts decode failures latest first.
+type byDecodeFailureTime []DecodeFailure
+
+func (d byDecodeFailureTime) Len() int           { return len(d) }
+func (d byDecodeFailureTime) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+func (d byDecodeFailureTime) Less(i, j int) bool { return d[i].Time.After(d[j].Time) }
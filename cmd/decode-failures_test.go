@@ -0,0 +1,156 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestDecodeFailureLog - tests the log keeps the latest failures.
+func TestDecodeFailureLog(t *testing.T) {
+	log := &decodeFailureLog{}
+	now := time.Now().UTC()
+	for i := 0; i < decodeFailureLogSize+1; i++ {
+		log.record(DecodeFailure{Time: now.Add(time.Duration(i) * time.Second)})
+	}
+	log.healed(ObjectHeal{Time: now, Bucket: "bucket", Object: "object"})
+
+	view := log.last(2)
+	if len(view.Failures) != 2 || !view.Failures[0].Time.Equal(now.Add(decodeFailureLogSize*time.Second)) {
+		t.Fatalf("Expected the last 2 failures latest first, got %v", view.Failures)
+	}
+	if len(view.Heals) != 1 {
+		t.Fatalf("Expected 1 heal, got %v", view.Heals)
+	}
+	if n := len(log.last(decodeFailureLogSize + 1).Failures); n != decodeFailureLogSize {
+		t.Fatalf("Expected %d failures, got %d", decodeFailureLogSize, n)
+	}
+}
+
+// TestMergeDecodeFailures - tests failures followed by a heal on any
+// server are marked resolved.
+func TestMergeDecodeFailures(t *testing.T) {
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	views := []NodeDecodeFailures{
+		{
+			Failures: []DecodeFailure{
+				{Node: "server0", Time: now, Bucket: "bucket", Object: "healed"},
+				{Node: "server0", Time: now.Add(2 * time.Minute), Bucket: "bucket", Object: "lost"},
+			},
+		},
+		{
+			Failures: []DecodeFailure{
+				// Failed again after the heal.
+				{Node: "server1", Time: now.Add(3 * time.Minute), Bucket: "bucket", Object: "healed"},
+			},
+			Heals: []ObjectHeal{
+				{Time: now.Add(time.Minute), Bucket: "bucket", Object: "healed"},
+				{Time: now.Add(time.Hour), Bucket: "bucket", Object: "healed"},
+				// Heals before the failure do not resolve it.
+				{Time: now.Add(time.Minute), Bucket: "bucket", Object: "lost"},
+			},
+		},
+	}
+
+	failures := mergeDecodeFailures(views, 3)
+	expected := []DecodeFailure{
+		{Node: "server1", Time: now.Add(3 * time.Minute), Bucket: "bucket", Object: "healed", Resolved: true, ResolvedAt: now.Add(time.Hour)},
+		{Node: "server0", Time: now.Add(2 * time.Minute), Bucket: "bucket", Object: "lost"},
+		{Node: "server0", Time: now, Bucket: "bucket", Object: "healed", Resolved: true, ResolvedAt: now.Add(time.Minute)},
+	}
+	if !reflect.DeepEqual(failures, expected) {
+		t.Fatalf("Expected %v, got %v", expected, failures)
+	}
+
+	if failures = mergeDecodeFailures(views, 1); !reflect.DeepEqual(failures, expected[:1]) {
+		t.Fatalf("Expected %v, got %v", expected[:1], failures)
+	}
+}
+
+// TestXLDecodeFailure - tests reads failing to decode an object are
+// logged with their missing and corrupt shards, and heals are logged.
+func TestXLDecodeFailure(t *testing.T) {
+	root, err := newTestConfig("us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalDecodeFailures()
+	resetGlobalDecodeFailures()
+
+	obj, fsDirs, err := prepareXL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	xl := obj.(*xlObjects)
+	xl.objCacheEnabled = false
+
+	bucket, object := "bucket", "dir/object"
+	if err = obj.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(bucket, object, int64(len("abcd")), bytes.NewReader([]byte("abcd")), nil, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// A healthy object heals fine.
+	if err = obj.HealObject(bucket, object); err != nil {
+		t.Fatal(err)
+	}
+
+	// Out of 8 data and 8 parity shards, lose 5 and corrupt 4.
+	for i, dir := range fsDirs[:9] {
+		part := filepath.Join(dir, bucket, object, "part.1")
+		if i < 5 {
+			err = os.Remove(part)
+		} else {
+			err = ioutil.WriteFile(part, []byte("corrupt"), 0644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = obj.GetObject(bucket, object, 0, int64(len("abcd")), ioutil.Discard); err == nil {
+		t.Fatal("Expected the read to fail")
+	}
+
+	view, err := getLocalDecodeFailureLog(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(view.Failures) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", view.Failures)
+	}
+	failure := view.Failures[0]
+	if failure.Bucket != bucket || failure.Object != object || failure.Part != "part.1" ||
+		failure.Missing != 5 || failure.Corrupt != 4 {
+		t.Fatalf("Unexpected failure %v", failure)
+	}
+	if len(view.Heals) != 1 || view.Heals[0].Bucket != bucket || view.Heals[0].Object != object {
+		t.Fatalf("Expected the heal to be logged, got %v", view.Heals)
+	}
+	if failures := mergeDecodeFailures([]NodeDecodeFailures{view}, 10); failures[0].Resolved {
+		t.Fatal("Expected a failure following the heal not to be resolved")
+	}
+}
@@ -0,0 +1,225 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Bucket delete protection config name.
+const bucketDeleteProtectionConfig = "delete-protection.json"
+
+var (
+	// errInvalidDeleteProtection - delete protection tag has a value
+	// but no key, or a key which is not a valid header name.
+	errInvalidDeleteProtection = errors.New("Delete protection tag needs a key valid as a header name")
+
+	// errObjectDeleteProtected - object carries the delete protection
+	// tag of its bucket.
+	errObjectDeleteProtected = errors.New("Object carries the delete protection tag of its bucket and can not be deleted")
+)
+
+// DeleteProtectionTag - tag protecting the objects of a bucket carrying
+// it from deletion. Object tagging is not supported, objects are tagged
+// with user-defined metadata, x-amz-meta-<key>. An empty value protects
+// objects carrying the key with any value.
+type DeleteProtectionTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// validateDeleteProtectionTag - returns errInvalidDeleteProtection if
+// tag can not be carried by an object, no key removes the protection.
+func validateDeleteProtectionTag(tag DeleteProtectionTag) error {
+	if tag.Key == "" && tag.Value != "" {
+		return errInvalidDeleteProtection
+	}
+	if strings.ContainsAny(tag.Key, " \t\r\n:") {
+		return errInvalidDeleteProtection
+	}
+	return nil
+}
+
+// protects - returns true if an object with metadata carries tag.
+func (tag DeleteProtectionTag) protects(metadata map[string]string) bool {
+	if tag.Key == "" {
+		return false
+	}
+	value, ok := metadata[http.CanonicalHeaderKey("X-Amz-Meta-"+tag.Key)]
+	return ok && (tag.Value == "" || value == tag.Value)
+}
+
+// deleteProtectionTags - delete protection tags of all buckets, kept in
+// memory on every server and persisted under the bucket config prefix.
+type deleteProtectionTags struct {
+	mutex sync.RWMutex
+	tags  map[string]DeleteProtectionTag
+}
+
+var globalDeleteProtectionTags = newDeleteProtectionTags(nil)
+
+// newDeleteProtectionTags - returns delete protection tags initialized
+// with tags.
+func newDeleteProtectionTags(tags map[string]DeleteProtectionTag) *deleteProtectionTags {
+	if tags == nil {
+		tags = make(map[string]DeleteProtectionTag)
+	}
+	return &deleteProtectionTags{tags: tags}
+}
+
+// get - returns the delete protection tag of bucket, no key if none.
+func (d *deleteProtectionTags) get(bucket string) DeleteProtectionTag {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.tags[bucket]
+}
+
+// set - sets the delete protection tag of bucket, no key removes it.
+func (d *deleteProtectionTags) set(bucket string, tag DeleteProtectionTag) error {
+	if err := validateDeleteProtectionTag(tag); err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if tag.Key == "" {
+		delete(d.tags, bucket)
+		return nil
+	}
+	d.tags[bucket] = tag
+	return nil
+}
+
+// checkDeleteProtection - returns errObjectDeleteProtected if object
+// exists and carries the delete protection tag of bucket. Overwriting
+// the object without the tag lifts the protection. Must be called with
+// a write lock held on object.
+func checkDeleteProtection(objAPI ObjectLayer, bucket, object string) error {
+	tag := globalDeleteProtectionTags.get(bucket)
+	if tag.Key == "" {
+		return nil
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(bucket, object)
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if tag.protects(objInfo.UserDefined) {
+		return errObjectDeleteProtected
+	}
+	return nil
+}
+
+// readDeleteProtectionTag - reads the persisted delete protection tag
+// of bucket, no key if none was set.
+func readDeleteProtectionTag(bucket string, objAPI ObjectLayer) (DeleteProtectionTag, error) {
+	tagPath := pathJoin(bucketConfigPrefix, bucket, bucketDeleteProtectionConfig)
+
+	// Acquire a read lock on delete protection config before reading.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, tagPath)
+	objLock.RLock()
+	defer objLock.RUnlock()
+
+	var buffer bytes.Buffer
+	if err := objAPI.GetObject(minioMetaBucket, tagPath, 0, -1, &buffer); err != nil {
+		if isErrObjectNotFound(err) || isErrIncompleteBody(err) {
+			return DeleteProtectionTag{}, nil
+		}
+		errorIf(err, "Unable to load delete protection for the bucket %s.", bucket)
+		return DeleteProtectionTag{}, errorCause(err)
+	}
+
+	var tag DeleteProtectionTag
+	if err := json.Unmarshal(buffer.Bytes(), &tag); err != nil {
+		return DeleteProtectionTag{}, err
+	}
+	return tag, nil
+}
+
+// writeDeleteProtectionTag - persists the delete protection tag of
+// bucket, no key removes any previously persisted tag.
+func writeDeleteProtectionTag(bucket string, objAPI ObjectLayer, tag DeleteProtectionTag) error {
+	tagPath := pathJoin(bucketConfigPrefix, bucket, bucketDeleteProtectionConfig)
+
+	// Acquire a write lock on delete protection config before modifying.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, tagPath)
+	objLock.Lock()
+	defer objLock.Unlock()
+
+	if tag.Key == "" {
+		if err := objAPI.DeleteObject(minioMetaBucket, tagPath); err != nil && !isErrObjectNotFound(err) {
+			errorIf(err, "Unable to remove delete protection of the bucket %s.", bucket)
+			return errorCause(err)
+		}
+		return nil
+	}
+
+	buf, err := json.Marshal(tag)
+	if err != nil {
+		return err
+	}
+	if _, err = objAPI.PutObject(minioMetaBucket, tagPath, int64(len(buf)), bytes.NewReader(buf), nil, ""); err != nil {
+		errorIf(err, "Unable to set delete protection for the bucket %s.", bucket)
+		return errorCause(err)
+	}
+	return nil
+}
+
+// removeDeleteProtectionTag - removes persisted and in-memory delete
+// protection tag of a bucket being deleted.
+func removeDeleteProtectionTag(bucket string, objAPI ObjectLayer) error {
+	globalDeleteProtectionTags.set(bucket, DeleteProtectionTag{})
+	return writeDeleteProtectionTag(bucket, objAPI, DeleteProtectionTag{})
+}
+
+// initDeleteProtectionTags - loads the delete protection tags of all
+// buckets.
+func initDeleteProtectionTags(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	buckets, err := objAPI.ListBuckets()
+	if err != nil {
+		errorIf(err, "Unable to list buckets.")
+		return errorCause(err)
+	}
+
+	tags := make(map[string]DeleteProtectionTag)
+	for _, bucket := range buckets {
+		tag, tErr := readDeleteProtectionTag(bucket.Name, objAPI)
+		if tErr != nil {
+			if isErrIgnored(tErr, errDiskNotFound) {
+				continue
+			}
+			return tErr
+		}
+		if tag.Key != "" {
+			tags[bucket.Name] = tag
+		}
+	}
+
+	globalDeleteProtectionTags = newDeleteProtectionTags(tags)
+	return nil
+}
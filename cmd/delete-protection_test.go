@@ -0,0 +1,113 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+// TestDeleteProtectionTagProtects - tests which objects carry the
+// delete protection tag.
+func TestDeleteProtectionTagProtects(t *testing.T) {
+	testCases := []struct {
+		tag       DeleteProtectionTag
+		metadata  map[string]string
+		protected bool
+	}{
+		{DeleteProtectionTag{Key: "retain", Value: "yes"}, map[string]string{"X-Amz-Meta-Retain": "yes"}, true},
+		{DeleteProtectionTag{Key: "retain", Value: "yes"}, map[string]string{"X-Amz-Meta-Retain": "no"}, false},
+		{DeleteProtectionTag{Key: "retain", Value: "yes"}, map[string]string{"X-Amz-Meta-Other": "yes"}, false},
+		{DeleteProtectionTag{Key: "retain", Value: "yes"}, nil, false},
+		// Any value is protected without a tag value.
+		{DeleteProtectionTag{Key: "retain"}, map[string]string{"X-Amz-Meta-Retain": "no"}, true},
+		// Keys are case insensitive.
+		{DeleteProtectionTag{Key: "RETAIN", Value: "yes"}, map[string]string{"X-Amz-Meta-Retain": "yes"}, true},
+		// No tag protects nothing.
+		{DeleteProtectionTag{}, map[string]string{"X-Amz-Meta-Retain": "yes"}, false},
+	}
+	for i, testCase := range testCases {
+		if protected := testCase.tag.protects(testCase.metadata); protected != testCase.protected {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.protected, protected)
+		}
+	}
+}
+
+// TestDeleteProtectionTagsSet - tests setting delete protection tags.
+func TestDeleteProtectionTagsSet(t *testing.T) {
+	tags := newDeleteProtectionTags(nil)
+	for _, invalid := range []DeleteProtectionTag{{Value: "yes"}, {Key: "re tain"}, {Key: "retain:"}} {
+		if err := tags.set("bucket", invalid); err != errInvalidDeleteProtection {
+			t.Fatalf("Expected %v for %v, got %v", errInvalidDeleteProtection, invalid, err)
+		}
+	}
+	tag := DeleteProtectionTag{Key: "retain", Value: "yes"}
+	if err := tags.set("bucket", tag); err != nil {
+		t.Fatal(err)
+	}
+	if got := tags.get("bucket"); got != tag {
+		t.Fatalf("Expected %v, got %v", tag, got)
+	}
+	if err := tags.set("bucket", DeleteProtectionTag{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := tags.get("bucket"); got.Key != "" {
+		t.Fatalf("Expected no tag, got %v", got)
+	}
+}
+
+// Wrapper for calling delete protection persistence tests for both
+// XL multiple disks and single node setup.
+func TestDeleteProtectionTagPersistence(t *testing.T) {
+	ExecObjectLayerTest(t, testDeleteProtectionTagPersistence)
+}
+
+func testDeleteProtectionTagPersistence(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucket := "protected-bucket"
+	if err := obj.MakeBucket(bucket); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	tag, err := readDeleteProtectionTag(bucket, obj)
+	if err != nil || tag.Key != "" {
+		t.Fatalf("%s: Expected no tag, got %v, %v", instanceType, tag, err)
+	}
+
+	protection := DeleteProtectionTag{Key: "retain", Value: "yes"}
+	if err = writeDeleteProtectionTag(bucket, obj, protection); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if tag, err = readDeleteProtectionTag(bucket, obj); err != nil || tag != protection {
+		t.Fatalf("%s: Expected %v, got %v, %v", instanceType, protection, tag, err)
+	}
+
+	// Tags are loaded into memory on startup.
+	if err = initDeleteProtectionTags(obj); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if tag = globalDeleteProtectionTags.get(bucket); tag != protection {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, protection, tag)
+	}
+
+	// Removing the tag, as done when deleting the bucket.
+	if err = removeDeleteProtectionTag(bucket, obj); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if tag = globalDeleteProtectionTags.get(bucket); tag.Key != "" {
+		t.Fatalf("%s: Expected no tag in memory, got %v", instanceType, tag)
+	}
+	if tag, err = readDeleteProtectionTag(bucket, obj); err != nil || tag.Key != "" {
+		t.Fatalf("%s: Expected no persisted tag, got %v, %v", instanceType, tag, err)
+	}
+}
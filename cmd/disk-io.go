@@ -0,0 +1,178 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// errDiskIOUnavailable - the platform or the device of a disk does not
+// provide IO counters.
+var errDiskIOUnavailable = errors.New("Disk IO counters are not available")
+
+const (
+	// IO counters of every disk are read diskIOSamples+1 times, this
+	// far apart.
+	diskIOSampleInterval = 500 * time.Millisecond
+	diskIOSamples        = 3
+
+	// Average number of requests queued on a disk from which it is
+	// reported contended, if it stays there for all samples.
+	diskIOHighQueueDepth = 8
+)
+
+// Status of the IO stats of a disk.
+const (
+	diskIOAvailable   = "ok"
+	diskIOUnavailable = "unavailable"
+)
+
+// diskIOCounters - cumulative IO counters of the device of a disk, as
+// kept by the OS.
+type diskIOCounters struct {
+	Ops   uint64 // Reads and writes completed.
+	Bytes uint64 // Bytes read and written.
+	// Time the device had IO in flight, and that time weighted by the
+	// number of requests in flight.
+	BusyTime  time.Duration
+	QueueTime time.Duration
+}
+
+// readDiskIOCounters - returns the IO counters of the device holding
+// path, errDiskIOUnavailable if there are none. Replaced by tests.
+var readDiskIOCounters = getDiskIOCounters
+
+// DiskIO - IO activity of a disk averaged over a few samples. Status is
+// unavailable, and the figures left out, on platforms or devices
+// without IO counters. HighQueueDepth is set when requests queued on
+// the disk in every sample.
+type DiskIO struct {
+	Node           string  `json:"node,omitempty"`
+	Endpoint       string  `json:"endpoint"`
+	Status         string  `json:"status"`
+	IOPS           float64 `json:"iops,omitempty"`
+	Throughput     float64 `json:"throughput,omitempty"` // Bytes per second.
+	QueueDepth     float64 `json:"queueDepth,omitempty"`
+	IOWaitPercent  float64 `json:"ioWaitPercent,omitempty"` // Time the disk had IO in flight.
+	HighQueueDepth bool    `json:"highQueueDepth"`
+}
+
+// sampleDiskIO - returns the IO activity of the disk at path out of
+// samples+1 readings of its counters, interval apart.
+func sampleDiskIO(path string, samples int, interval time.Duration) DiskIO {
+	unavailable := DiskIO{Endpoint: path, Status: diskIOUnavailable}
+
+	readings := make([]diskIOCounters, 0, samples+1)
+	for i := 0; i <= samples; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		counters, err := readDiskIOCounters(path)
+		if err != nil {
+			if err != errDiskIOUnavailable {
+				errorIf(err, "Unable to read IO counters of %s.", path)
+			}
+			return unavailable
+		}
+		readings = append(readings, counters)
+	}
+	return newDiskIO(path, readings, interval)
+}
+
+// newDiskIO - returns the IO activity of the disk at path out of
+// readings of its counters, interval apart.
+func newDiskIO(path string, readings []diskIOCounters, interval time.Duration) DiskIO {
+	stats := DiskIO{Endpoint: path, Status: diskIOAvailable}
+	if len(readings) < 2 || interval <= 0 {
+		stats.Status = diskIOUnavailable
+		return stats
+	}
+
+	first, last := readings[0], readings[len(readings)-1]
+	elapsed := time.Duration(len(readings)-1) * interval
+	seconds := elapsed.Seconds()
+	stats.IOPS = float64(last.Ops-first.Ops) / seconds
+	stats.Throughput = float64(last.Bytes-first.Bytes) / seconds
+	stats.QueueDepth = float64(last.QueueTime-first.QueueTime) / float64(elapsed)
+	stats.IOWaitPercent = 100 * float64(last.BusyTime-first.BusyTime) / float64(elapsed)
+
+	// A single busy sample is a burst, only report disks contended
+	// over all of them.
+	stats.HighQueueDepth = true
+	for i := 1; i < len(readings); i++ {
+		depth := float64(readings[i].QueueTime-readings[i-1].QueueTime) / float64(interval)
+		if depth < diskIOHighQueueDepth {
+			stats.HighQueueDepth = false
+			break
+		}
+	}
+	return stats
+}
+
+// getLocalDiskIOStats - returns the IO activity of the local disks of
+// this server, sampled in parallel.
+func getLocalDiskIOStats() ([]DiskIO, error) {
+	var eps []*url.URL
+	for _, ep := range globalEndpoints {
+		if isLocalStorage(ep) {
+			eps = append(eps, ep)
+		}
+	}
+
+	var wg = &sync.WaitGroup{}
+	stats := make([]DiskIO, len(eps))
+	for i, ep := range eps {
+		wg.Add(1)
+		go func(i int, ep *url.URL) {
+			defer wg.Done()
+			stats[i] = sampleDiskIO(getPath(ep), diskIOSamples, diskIOSampleInterval)
+			stats[i].Endpoint = ep.String()
+		}(i, ep)
+	}
+	wg.Wait()
+	return stats, nil
+}
+
+// byDiskIONode - sorts disk IO stats by node and endpoint.
+type byDiskIONode []DiskIO
+
+func (d byDiskIONode) Len() int      { return len(d) }
+func (d byDiskIONode) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+func (d byDiskIONode) Less(i, j int) bool {
+	if d[i].Node != d[j].Node {
+		return d[i].Node < d[j].Node
+	}
+	return d[i].Endpoint < d[j].Endpoint
+}
+
+// mergeDiskIOStats - returns the IO stats of the disks of all nodes,
+// keyed by node address, sorted by node and endpoint.
+func mergeDiskIOStats(nodes map[string][]DiskIO) []DiskIO {
+	merged := []DiskIO{}
+	for addr, stats := range nodes {
+		for _, disk := range stats {
+			disk.Node = addr
+			merged = append(merged, disk)
+		}
+	}
+	sort.Sort(byDiskIONode(merged))
+	return merged
+}
@@ -0,0 +1,80 @@
+// +build linux
+
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Linux counts sectors of 512 bytes whatever the device.
+const diskStatSectorSize = 512
+
+// getDiskIOCounters - returns the IO counters of the block device
+// holding path, out of /sys/dev/block/<major>:<minor>/stat. Paths on
+// filesystems without a block device, like tmpfs, have none.
+func getDiskIOCounters(path string) (diskIOCounters, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return diskIOCounters{}, err
+	}
+	dev := uint64(st.Dev)
+	major := (dev>>8)&0xfff | (dev>>32)&^0xfff
+	minor := dev&0xff | (dev>>12)&^0xff
+
+	data, err := ioutil.ReadFile(fmt.Sprintf("/sys/dev/block/%d:%d/stat", major, minor))
+	if os.IsNotExist(err) {
+		return diskIOCounters{}, errDiskIOUnavailable
+	}
+	if err != nil {
+		return diskIOCounters{}, err
+	}
+	return parseDiskStat(string(data))
+}
+
+// parseDiskStat - returns the IO counters out of the content of a
+// block device stat file, see Documentation/block/stat.txt.
+func parseDiskStat(stat string) (diskIOCounters, error) {
+	fields := strings.Fields(stat)
+	if len(fields) < 11 {
+		return diskIOCounters{}, errDiskIOUnavailable
+	}
+	values := make([]uint64, 11)
+	for i := range values {
+		value, err := strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			return diskIOCounters{}, errDiskIOUnavailable
+		}
+		values[i] = value
+	}
+	// Fields: reads, reads merged, sectors read, read ticks, writes,
+	// writes merged, sectors written, write ticks, in flight, io
+	// ticks and time in queue, times in milliseconds.
+	return diskIOCounters{
+		Ops:       values[0] + values[4],
+		Bytes:     (values[2] + values[6]) * diskStatSectorSize,
+		BusyTime:  time.Duration(values[9]) * time.Millisecond,
+		QueueTime: time.Duration(values[10]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// stubDiskIOCounters - replaces the IO counters of disks by readings
+// returned in turn per path, paths without readings have no counters.
+func stubDiskIOCounters(readings map[string][]diskIOCounters) func() {
+	next := make(map[string]int)
+	readDiskIOCounters = func(path string) (diskIOCounters, error) {
+		r, ok := readings[path]
+		if !ok {
+			return diskIOCounters{}, errDiskIOUnavailable
+		}
+		i := next[path]
+		next[path]++
+		return r[i], nil
+	}
+	return func() { readDiskIOCounters = getDiskIOCounters }
+}
+
+// Tests disks are flagged contended only when their queue stayed deep
+// over all samples, and disks without counters reported unavailable.
+func TestSampleDiskIO(t *testing.T) {
+	interval := time.Millisecond
+	// Queue time grows by depth intervals per sample.
+	queued := func(depths ...int) []diskIOCounters {
+		readings := []diskIOCounters{{}}
+		for _, depth := range depths {
+			last := readings[len(readings)-1]
+			readings = append(readings, diskIOCounters{
+				Ops:       last.Ops + 10,
+				Bytes:     last.Bytes + 4096,
+				BusyTime:  last.BusyTime + interval/2,
+				QueueTime: last.QueueTime + time.Duration(depth)*interval,
+			})
+		}
+		return readings
+	}
+	defer stubDiskIOCounters(map[string][]diskIOCounters{
+		"/busy":  queued(10, 12, 9),
+		"/burst": queued(1, 30, 1),
+		"/idle":  queued(0, 0, 0),
+	})()
+
+	testCases := []struct {
+		path       string
+		status     string
+		queueDepth float64
+		high       bool
+	}{
+		{"/busy", diskIOAvailable, 31.0 / 3, true},
+		{"/burst", diskIOAvailable, 32.0 / 3, false},
+		{"/idle", diskIOAvailable, 0, false},
+		{"/tmpfs", diskIOUnavailable, 0, false},
+	}
+	for i, testCase := range testCases {
+		stats := sampleDiskIO(testCase.path, 3, interval)
+		if stats.Status != testCase.status || stats.HighQueueDepth != testCase.high {
+			t.Errorf("Test %d: expected status %s and high queue depth %v, got %#v", i+1, testCase.status, testCase.high, stats)
+		}
+		if diff := stats.QueueDepth - testCase.queueDepth; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("Test %d: expected queue depth %v, got %v", i+1, testCase.queueDepth, stats.QueueDepth)
+		}
+		if testCase.status == diskIOAvailable && (stats.IOWaitPercent != 50 || stats.IOPS != 10/interval.Seconds()) {
+			t.Errorf("Test %d: expected 50%% IO wait and %v IOPS, got %#v", i+1, 10/interval.Seconds(), stats)
+		}
+	}
+}
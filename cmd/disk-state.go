@@ -0,0 +1,142 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"strings"
+)
+
+// Disk states reported by disks by state API.
+const (
+	// Disk is reachable and part of this deployment.
+	diskStateOnline = "online"
+	// Disk is not reachable.
+	diskStateOffline = "offline"
+	// Disk is reachable but its format is missing or corrupted,
+	// needs healing.
+	diskStateDegraded = "degraded"
+	// Disk is formatted for a different deployment.
+	diskStateForeign = "foreign"
+)
+
+// errInvalidDiskState - requested disk state is not a known state.
+var errInvalidDiskState = errors.New("Disk state must be one of online, offline, degraded or foreign")
+
+// isValidDiskState - returns true if state is a known disk state.
+func isValidDiskState(state string) bool {
+	switch state {
+	case diskStateOnline, diskStateOffline, diskStateDegraded, diskStateForeign:
+		return true
+	}
+	return false
+}
+
+// DiskInfoMsg - state and usage of a disk.
+type DiskInfoMsg struct {
+	Addr     string `json:"addr"`
+	Endpoint string `json:"endpoint"`
+	State    string `json:"state"`
+	Total    int64  `json:"total"`
+	Free     int64  `json:"free"`
+	Error    string `json:"error,omitempty"`
+}
+
+// referenceJBOD - returns the disk order agreed on by most formatted
+// disks, nil if no disk is formatted.
+func referenceJBOD(formats []*formatConfigV1) []string {
+	counts := make(map[string]int)
+	var jbod []string
+	maxCount := 0
+	for _, format := range formats {
+		if format == nil {
+			continue
+		}
+		key := strings.Join(format.XL.JBOD, ",")
+		counts[key]++
+		if counts[key] > maxCount {
+			maxCount = counts[key]
+			jbod = format.XL.JBOD
+		}
+	}
+	return jbod
+}
+
+// getDiskState - returns state and usage of disk, jbod is the disk
+// order of this deployment.
+func getDiskState(disk StorageAPI, jbod []string) DiskInfoMsg {
+	info, err := disk.DiskInfo()
+	if err != nil {
+		return DiskInfoMsg{State: diskStateOffline, Error: err.Error()}
+	}
+	msg := DiskInfoMsg{Total: info.Total, Free: info.Free}
+
+	format, err := loadFormat(disk)
+	switch {
+	case err == errUnformattedDisk || err == errCorruptedFormat:
+		msg.State = diskStateDegraded
+		msg.Error = err.Error()
+	case err != nil:
+		msg.State = diskStateOffline
+		msg.Error = err.Error()
+	case findDiskIndex(format.XL.Disk, jbod) == -1:
+		msg.State = diskStateForeign
+	default:
+		msg.State = diskStateOnline
+	}
+	return msg
+}
+
+// getLocalDisksByState - returns the disks of this server in the
+// given state.
+func getLocalDisksByState(state string) ([]DiskInfoMsg, error) {
+	if !isValidDiskState(state) {
+		return nil, errInvalidDiskState
+	}
+
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return nil, errServerNotInitialized
+	}
+	xl, ok := objLayer.(*xlObjects)
+	if !ok {
+		return nil, errUnsupportedBackend
+	}
+
+	formats, _ := loadAllFormats(xl.storageDisks)
+	jbod := referenceJBOD(formats)
+
+	disks := []DiskInfoMsg{}
+	for _, ep := range globalEndpoints {
+		if !isLocalStorage(ep) {
+			continue
+		}
+		var msg DiskInfoMsg
+		disk, err := newStorageAPI(ep)
+		if err != nil {
+			msg = DiskInfoMsg{State: diskStateOffline, Error: err.Error()}
+		} else {
+			msg = getDiskState(disk, jbod)
+		}
+		if msg.State != state {
+			continue
+		}
+		msg.Endpoint = ep.String()
+		disks = append(disks, msg)
+	}
+	return disks, nil
+}
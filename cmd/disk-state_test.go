@@ -0,0 +1,109 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+// newTestFormattedDisks - returns nDisks posix disks formatted as one
+// deployment.
+func newTestFormattedDisks(t *testing.T, nDisks int) ([]string, []StorageAPI) {
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disks := make([]StorageAPI, nDisks)
+	for i, fsDir := range fsDirs {
+		if disks[i], err = newPosix(fsDir); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = initFormatXL(disks); err != nil {
+		t.Fatal(err)
+	}
+	return fsDirs, disks
+}
+
+// Tests classification of disks into states.
+func TestGetDiskState(t *testing.T) {
+	clusterDirs, clusterDisks := newTestFormattedDisks(t, 4)
+	defer removeRoots(clusterDirs)
+	foreignDirs, foreignDisks := newTestFormattedDisks(t, 4)
+	defer removeRoots(foreignDirs)
+
+	freshDirs, err := getRandomDisks(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(freshDirs)
+	freshDisk, err := newPosix(freshDirs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	offlineDisk, err := newPosix(freshDirs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.RemoveAll(freshDirs[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	formats, _ := loadAllFormats(clusterDisks)
+	// A single foreign disk must not change the reference order.
+	formats = append(formats, nil, &formatConfigV1{XL: &xlFormat{JBOD: []string{"foreign"}}})
+	jbod := referenceJBOD(formats)
+	if len(jbod) != len(clusterDisks) {
+		t.Fatalf("Expected reference JBOD of %d disks, got %v", len(clusterDisks), jbod)
+	}
+
+	testCases := []struct {
+		disk  StorageAPI
+		state string
+	}{
+		{clusterDisks[0], diskStateOnline},
+		{clusterDisks[3], diskStateOnline},
+		{foreignDisks[0], diskStateForeign},
+		{freshDisk, diskStateDegraded},
+		{offlineDisk, diskStateOffline},
+	}
+	for i, testCase := range testCases {
+		msg := getDiskState(testCase.disk, jbod)
+		if msg.State != testCase.state {
+			t.Errorf("Test %d: Expected state %s, got %s (%s)", i+1, testCase.state, msg.State, msg.Error)
+		}
+	}
+
+	if referenceJBOD(nil) != nil {
+		t.Fatal("Expected no reference JBOD without formatted disks")
+	}
+}
+
+// Tests validation of disk states.
+func TestIsValidDiskState(t *testing.T) {
+	for _, state := range []string{diskStateOnline, diskStateOffline, diskStateDegraded, diskStateForeign} {
+		if !isValidDiskState(state) {
+			t.Errorf("Expected %s to be a valid disk state", state)
+		}
+	}
+	for _, state := range []string{"", "Offline", "healing"} {
+		if isValidDiskState(state) {
+			t.Errorf("Expected %q to be an invalid disk state", state)
+		}
+	}
+}
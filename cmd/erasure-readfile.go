@@ -172,6 +172,10 @@ func erasureReadFile(writer io.Writer, disks []StorageAPI, volume string, path s
 	// chunkSize is the amount of data that needs to be read from each disk at a time.
 	chunkSize := getChunkSize(blockSize, dataBlocks)
 
+	// Disks whose shard failed its checksum, reported along with
+	// decode failures.
+	corrupt := make([]bool, len(disks))
+
 	// bitRotVerify verifies if the file on a particular disk doesn't have bitrot
 	// by verifying the hash of the contents of the file.
 	bitRotVerify := func() func(diskIndex int) bool {
@@ -187,6 +191,12 @@ func erasureReadFile(writer io.Writer, disks []StorageAPI, volume string, path s
 			// Is this a valid block?
 			isValid := isValidBlock(disks[diskIndex], volume, path, checkSums[diskIndex], algo)
 			verified[diskIndex] = isValid
+			if !isValid {
+				// Shards which can be found but fail their
+				// checksum are corrupt, the others missing.
+				_, err := disks[diskIndex].StatFile(volume, path)
+				corrupt[diskIndex] = err == nil
+			}
 			return isValid
 		}
 	}()
@@ -238,6 +248,9 @@ func erasureReadFile(writer io.Writer, disks []StorageAPI, volume string, path s
 			// get readable disks slice from which we can read parallelly.
 			readDisks, nextIndex, err = getReadDisks(disks, nextIndex, dataBlocks)
 			if err != nil {
+				if errorCause(err) == errXLReadQuorum {
+					recordDecodeFailure(volume, path, disks, corrupt)
+				}
 				return bytesWritten, err
 			}
 			// Issue a parallel read across the disks specified in readDisks.
@@ -248,6 +261,7 @@ func erasureReadFile(writer io.Writer, disks []StorageAPI, volume string, path s
 			}
 			if nextIndex == len(disks) {
 				// No more disks to read from.
+				recordDecodeFailure(volume, path, disks, corrupt)
 				return bytesWritten, traceError(errXLReadQuorum)
 			}
 			// We do not have enough enough data blocks to reconstruct the data
@@ -273,14 +273,11 @@ func eventNotifyForBucketNotifications(eventType, objectName, bucketName string,
 		eventMatch := eventMatch(eventType, qConfig.Events)
 		ruleMatch := filterRuleMatch(objectName, qConfig.Filter.Key.FilterRules)
 		if eventMatch && ruleMatch {
-			targetLog := globalEventNotifier.GetExternalTarget(qConfig.QueueARN)
-			if targetLog != nil {
-				targetLog.WithFields(logrus.Fields{
-					"Key":       path.Join(bucketName, objectName),
-					"EventType": eventType,
-					"Records":   nEvent,
-				}).Info()
-			}
+			sendNotification(qConfig.QueueARN, logrus.Fields{
+				"Key":       path.Join(bucketName, objectName),
+				"EventType": eventType,
+				"Records":   nEvent,
+			})
 		}
 	}
 }
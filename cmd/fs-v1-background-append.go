@@ -37,11 +37,42 @@ var errAppendPartsTimeout = errors.New("appendParts go-routine timeout")
 // Timeout value for the appendParts go-routine.
 var appendPartsTimeout = 24 * 60 * 60 * time.Second // 24 Hours.
 
+// backgroundAppendToggle - whether the parts of new multipart uploads
+// are appended in the background as they are uploaded, can be changed
+// at runtime via admin RPC. Enabled by default, when disabled parts are
+// only put together on complete-multipart-upload.
+type backgroundAppendToggle struct {
+	mutex   sync.RWMutex
+	enabled bool
+}
+
+var globalBackgroundAppend = &backgroundAppendToggle{enabled: true}
+
+// get - returns true if parts of new uploads are appended in the
+// background.
+func (b *backgroundAppendToggle) get() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.enabled
+}
+
+// set - enables or disables background append of the parts of new
+// uploads.
+func (b *backgroundAppendToggle) set(enabled bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.enabled = enabled
+}
+
 // Holds a map of uploadID->appendParts go-routine
 type backgroundAppend struct {
 	sync.Mutex
 	infoMap    map[string]bgAppendPartsInfo
 	appendFile io.WriteCloser
+	// Uploads started while background append was disabled, along
+	// with the time they started. Their parts are not appended in
+	// the background even if it is enabled again meanwhile.
+	skipMap map[string]time.Time
 }
 
 // Input to the appendParts go-routine
@@ -58,9 +89,35 @@ type bgAppendPartsInfo struct {
 	completeCh chan struct{} // closed after complete of upload to end the appendParts go-routine
 }
 
+// Called when a multipart upload is started, the parts of uploads
+// started while background append is disabled are appended on
+// complete-multipart-upload only. Uploads neither completed nor
+// aborted are forgotten after appendPartsTimeout.
+func (fs fsObjects) startAppend(uploadID string, now time.Time) {
+	if globalBackgroundAppend.get() {
+		return
+	}
+
+	fs.bgAppend.Lock()
+	defer fs.bgAppend.Unlock()
+	for id, started := range fs.bgAppend.skipMap {
+		if now.Sub(started) > appendPartsTimeout {
+			delete(fs.bgAppend.skipMap, id)
+		}
+	}
+	fs.bgAppend.skipMap[uploadID] = now
+}
+
 // Called after a part is uploaded so that it can be appended in the background.
 func (fs fsObjects) append(bucket, object, uploadID string, meta fsMetaV1) chan error {
 	fs.bgAppend.Lock()
+	if _, ok := fs.bgAppend.skipMap[uploadID]; ok {
+		fs.bgAppend.Unlock()
+		// Parts of this upload are appended on complete only.
+		errCh := make(chan error, 1)
+		errCh <- errPartsMissing
+		return errCh
+	}
 	info, ok := fs.bgAppend.infoMap[uploadID]
 	if !ok {
 		// Corresponding appendParts go-routine was not found, create a new one. Would happen when the first
@@ -100,6 +157,7 @@ func (fs *fsObjects) complete(bucket, object, uploadID string, meta fsMetaV1) er
 
 	info, ok := fs.bgAppend.infoMap[uploadID]
 	delete(fs.bgAppend.infoMap, uploadID)
+	delete(fs.bgAppend.skipMap, uploadID)
 	if !ok {
 		return errPartsMissing
 	}
@@ -126,6 +184,7 @@ func (fs fsObjects) abort(uploadID string) {
 	fs.bgAppend.Lock()
 	defer fs.bgAppend.Unlock()
 
+	delete(fs.bgAppend.skipMap, uploadID)
 	info, ok := fs.bgAppend.infoMap[uploadID]
 	if !ok {
 		return
@@ -235,6 +294,7 @@ func (fs fsObjects) appendPart(bucket, object, uploadID string, part objectPartI
 		return err
 	}
 
-	_, err = io.CopyBuffer(wfile, file, buf)
+	n, err := io.CopyBuffer(wfile, file, buf)
+	globalWriteAmp.addDiskBytes(n)
 	return err
 }
@@ -0,0 +1,145 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+// isAppending - returns true if parts of uploadID are appended in the
+// background.
+func isAppending(fs *fsObjects, uploadID string) bool {
+	fs.bgAppend.Lock()
+	defer fs.bgAppend.Unlock()
+	_, ok := fs.bgAppend.infoMap[uploadID]
+	return ok
+}
+
+// TestFSBackgroundAppendToggle - tests new uploads honor the background
+// append toggle, and uploads in progress complete whatever it is
+// switched to meanwhile.
+func TestFSBackgroundAppendToggle(t *testing.T) {
+	defer resetGlobalBackgroundAppend()
+
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer removeAll(disk)
+	obj := initFSObjects(disk, t)
+	fs := obj.(*fsObjects)
+
+	bucketName := "bucket"
+	if err := obj.MakeBucket(bucketName); err != nil {
+		t.Fatal(err)
+	}
+
+	parts := [][]byte{
+		bytes.Repeat([]byte("a"), 5*humanize.MiByte),
+		[]byte("last part"),
+	}
+	putPart := func(object, uploadID string, partID int) completePart {
+		data := parts[partID-1]
+		md5Hex := getMD5Hash(data)
+		if _, err := obj.PutObjectPart(bucketName, object, uploadID, partID, int64(len(data)), bytes.NewReader(data), md5Hex, ""); err != nil {
+			t.Fatal(err)
+		}
+		return completePart{PartNumber: partID, ETag: md5Hex}
+	}
+	complete := func(object, uploadID string, completed []completePart) {
+		if _, err := obj.CompleteMultipartUpload(bucketName, object, uploadID, completed); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := obj.GetObject(bucketName, object, 0, int64(len(parts[0])+len(parts[1])), &buf); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), append(append([]byte{}, parts[0]...), parts[1]...)) {
+			t.Fatalf("%s: content mismatch", object)
+		}
+	}
+
+	// Upload started with background append enabled, disabled
+	// after its first part.
+	appended, err := obj.NewMultipartUpload(bucketName, "appended", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendedParts := []completePart{putPart("appended", appended, 1)}
+	if !isAppending(fs, appended) {
+		t.Fatal("Expected parts to be appended in the background")
+	}
+
+	globalBackgroundAppend.set(false)
+
+	// Upload started with background append disabled, enabled after
+	// its first part.
+	skipped, err := obj.NewMultipartUpload(bucketName, "skipped", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skippedParts := []completePart{putPart("skipped", skipped, 1)}
+
+	globalBackgroundAppend.set(true)
+
+	appendedParts = append(appendedParts, putPart("appended", appended, 2))
+	skippedParts = append(skippedParts, putPart("skipped", skipped, 2))
+	if !isAppending(fs, appended) {
+		t.Fatal("Expected upload in progress to keep appending in the background")
+	}
+	if isAppending(fs, skipped) {
+		t.Fatal("Expected upload started while disabled not to append in the background")
+	}
+
+	complete("appended", appended, appendedParts)
+	complete("skipped", skipped, skippedParts)
+
+	fs.bgAppend.Lock()
+	defer fs.bgAppend.Unlock()
+	if len(fs.bgAppend.infoMap) != 0 || len(fs.bgAppend.skipMap) != 0 {
+		t.Fatalf("Expected completed uploads to be forgotten, got %v %v", fs.bgAppend.infoMap, fs.bgAppend.skipMap)
+	}
+}
+
+// TestFSStartAppendExpiry - tests uploads neither completed nor aborted
+// are forgotten after appendPartsTimeout.
+func TestFSStartAppendExpiry(t *testing.T) {
+	defer resetGlobalBackgroundAppend()
+
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer removeAll(disk)
+	fs := initFSObjects(disk, t).(*fsObjects)
+
+	globalBackgroundAppend.set(false)
+	now := time.Now().UTC()
+	fs.startAppend("stale", now.Add(-appendPartsTimeout-time.Minute))
+	fs.startAppend("fresh", now)
+	if _, ok := fs.bgAppend.skipMap["stale"]; ok {
+		t.Fatal("Expected stale upload to be forgotten")
+	}
+	if _, ok := fs.bgAppend.skipMap["fresh"]; !ok {
+		t.Fatal("Expected fresh upload to be remembered")
+	}
+
+	// Abort forgets the upload too.
+	fs.abort("fresh")
+	if len(fs.bgAppend.skipMap) != 0 {
+		t.Fatalf("Expected no upload left, got %v", fs.bgAppend.skipMap)
+	}
+}
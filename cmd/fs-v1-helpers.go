@@ -259,6 +259,7 @@ func fsCreateFile(tempObjPath string, reader io.Reader, buf []byte, fallocSize i
 	}
 
 	bytesWritten, err := io.CopyBuffer(writer, reader, buf)
+	globalWriteAmp.addDiskBytes(bytesWritten)
 	if err != nil {
 		return 0, traceError(err)
 	}
@@ -139,6 +139,7 @@ func (m *fsMetaV1) WriteTo(lk *lock.LockedFile) (n int64, err error) {
 	if _, err = lk.Write(metadataBytes); err != nil {
 		return 0, traceError(err)
 	}
+	globalWriteAmp.addDiskBytes(int64(len(metadataBytes)))
 
 	// Success.
 	return int64(len(metadataBytes)), nil
@@ -408,6 +408,10 @@ func (fs fsObjects) newMultipartUpload(bucket string, object string, meta map[st
 		return "", toObjectErr(err, bucket, object)
 	}
 
+	// Decide once for all whether parts of this upload are appended
+	// in the background.
+	fs.startAppend(uploadID, initiated)
+
 	// Return success.
 	return uploadID, nil
 }
@@ -418,7 +422,7 @@ func (fs fsObjects) newMultipartUpload(bucket string, object string, meta map[st
 //
 // Implements S3 compatible initiate multipart API.
 func (fs fsObjects) NewMultipartUpload(bucket, object string, meta map[string]string) (string, error) {
-	if err := checkNewMultipartArgs(bucket, object, fs); err != nil {
+	if err := checkNewObjectArgs(bucket, object, fs); err != nil {
 		return "", err
 	}
 
@@ -795,6 +799,21 @@ func (fs fsObjects) CompleteMultipartUpload(bucket string, object string, upload
 		return ObjectInfo{}, toObjectErr(err, minioMetaMultipartBucket, fsMetaPathMultipart)
 	}
 
+	// All parts except the last part has to be atleast the minimum part
+	// size of the bucket, checked before the parts appended in the
+	// background are used.
+	for i, part := range parts {
+		partIdx := fsMeta.ObjectPartIndex(part.PartNumber)
+		if (i < len(parts)-1) && partIdx != -1 && !isMinAllowedBucketPartSize(bucket, fsMeta.Parts[partIdx].Size) {
+			fs.rwPool.Close(fsMetaPathMultipart)
+			return ObjectInfo{}, traceError(PartTooSmall{
+				PartNumber: part.PartNumber,
+				PartSize:   fsMeta.Parts[partIdx].Size,
+				PartETag:   part.ETag,
+			})
+		}
+	}
+
 	// Wait for any competing PutObject() operation on bucket/object, since same namespace
 	// would be acquired for `fs.json`.
 	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fsMetaJSONFile)
@@ -837,7 +856,7 @@ func (fs fsObjects) CompleteMultipartUpload(bucket string, object string, upload
 		var buf = make([]byte, readSizeV1)
 
 		// Validate all parts and then commit to disk.
-		for i, part := range parts {
+		for _, part := range parts {
 			partIdx := fsMeta.ObjectPartIndex(part.PartNumber)
 			if partIdx == -1 {
 				fs.rwPool.Close(fsMetaPathMultipart)
@@ -849,16 +868,6 @@ func (fs fsObjects) CompleteMultipartUpload(bucket string, object string, upload
 				return ObjectInfo{}, traceError(BadDigest{})
 			}
 
-			// All parts except the last part has to be atleast 5MB.
-			if (i < len(parts)-1) && !isMinAllowedPartSize(fsMeta.Parts[partIdx].Size) {
-				fs.rwPool.Close(fsMetaPathMultipart)
-				return ObjectInfo{}, traceError(PartTooSmall{
-					PartNumber: part.PartNumber,
-					PartSize:   fsMeta.Parts[partIdx].Size,
-					PartETag:   part.ETag,
-				})
-			}
-
 			// Construct part suffix.
 			partSuffix := fmt.Sprintf("object%d", part.PartNumber)
 			multipartPartFile := pathJoin(fs.fsPath, minioMetaMultipartBucket, uploadIDPath, partSuffix)
@@ -884,7 +893,9 @@ func (fs fsObjects) CompleteMultipartUpload(bucket string, object string, upload
 				return ObjectInfo{}, toObjectErr(traceError(err), bucket, object)
 			}
 
-			_, err = io.CopyBuffer(wfile, reader, buf)
+			var n int64
+			n, err = io.CopyBuffer(wfile, reader, buf)
+			globalWriteAmp.addDiskBytes(n)
 			if err != nil {
 				wfile.Close()
 				reader.Close()
@@ -27,6 +27,7 @@ import (
 	"runtime"
 	"sort"
 	"syscall"
+	"time"
 
 	"github.com/minio/minio/pkg/disk"
 	"github.com/minio/minio/pkg/lock"
@@ -149,6 +150,7 @@ func newFSObjectLayer(fsPath string) (ObjectLayer, error) {
 		listPool: newTreeWalkPool(globalLookupTimeout),
 		bgAppend: &backgroundAppend{
 			infoMap: make(map[string]bgAppendPartsInfo),
+			skipMap: make(map[string]time.Time),
 		},
 	}
 
@@ -158,6 +160,36 @@ func newFSObjectLayer(fsPath string) (ObjectLayer, error) {
 		return nil, fmt.Errorf("Unable to load all bucket policies. %s", err)
 	}
 
+	// Initialize and load bucket tags.
+	err = initBucketTags(fs)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load all bucket tags. %s", err)
+	}
+
+	// Initialize and load bucket immutability windows.
+	err = initImmutabilityWindows(fs)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load all bucket immutability windows. %s", err)
+	}
+
+	// Initialize and load bucket object limits.
+	err = initBucketObjectLimits(fs)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load all bucket object limits. %s", err)
+	}
+
+	// Initialize and load bucket logging configs.
+	err = initBucketLogging(fs)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load all bucket logging configs. %s", err)
+	}
+
+	// Initialize and load bucket delete protection tags.
+	err = initDeleteProtectionTags(fs)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load all bucket delete protection tags. %s", err)
+	}
+
 	// Initialize a new event notifier.
 	err = initEventNotifier(fs)
 	if err != nil {
@@ -539,7 +571,7 @@ func (fs fsObjects) PutObject(bucket string, object string, size int64, data io.
 	if isObjectDir(object, size) {
 		return dirObjectInfo(bucket, object, size, metadata), nil
 	}
-	if err = checkPutObjectArgs(bucket, object, fs); err != nil {
+	if err = checkNewObjectArgs(bucket, object, fs); err != nil {
 		return ObjectInfo{}, err
 	}
 
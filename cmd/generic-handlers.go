@@ -365,11 +365,15 @@ func (h resourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 type httpResponseRecorder struct {
 	http.ResponseWriter
 	respStatusCode int
+	bytesWritten   int64
 }
 
-// Wraps ResponseWriter's Write()
+// Wraps ResponseWriter's Write() and record the
+// number of bytes written
 func (rww *httpResponseRecorder) Write(b []byte) (int, error) {
-	return rww.ResponseWriter.Write(b)
+	n, err := rww.ResponseWriter.Write(b)
+	rww.bytesWritten += int64(n)
+	return n, err
 }
 
 // Wraps ResponseWriter's Flush()
@@ -0,0 +1,78 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "sync"
+
+// healLimiter - caps the number of object heals running at once on
+// this server, heals beyond the cap wait for a running heal to finish.
+// The cap can be changed at runtime via admin RPC, 0 means no cap.
+type healLimiter struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	max     int
+	running int
+}
+
+func newHealLimiter(max int) *healLimiter {
+	l := &healLimiter{max: max}
+	l.cond = sync.NewCond(&l.mutex)
+	return l
+}
+
+// Object heals are not capped until set via admin RPC.
+var globalHealLimiter = newHealLimiter(0)
+
+// get - returns the current cap.
+func (l *healLimiter) get() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.max
+}
+
+// set - sets the cap, max must not be negative. Heals already running
+// beyond a lowered cap are left to finish, new heals wait until fewer
+// than max are running.
+func (l *healLimiter) set(max int) error {
+	if max < 0 {
+		return errInvalidArgument
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.max = max
+	// A raised cap may let several waiting heals run.
+	l.cond.Broadcast()
+	return nil
+}
+
+// acquire - waits until a heal may run and counts it as running.
+func (l *healLimiter) acquire() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for l.max > 0 && l.running >= l.max {
+		l.cond.Wait()
+	}
+	l.running++
+}
+
+// release - counts a heal started with acquire as finished.
+func (l *healLimiter) release() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.running--
+	l.cond.Signal()
+}
@@ -0,0 +1,116 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Tests that no more heals than the cap run at once.
+func TestHealLimiterCap(t *testing.T) {
+	l := newHealLimiter(2)
+	var running, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.acquire()
+			defer l.release()
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+	if peak > 2 {
+		t.Fatalf("Expected at most 2 heals at once, got %d", peak)
+	}
+
+	if err := l.set(-1); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+	if max := l.get(); max != 2 {
+		t.Fatalf("Expected cap 2, got %d", max)
+	}
+}
+
+// Tests that lowering the cap lets running heals finish while new
+// heals wait for a free slot.
+func TestHealLimiterLowerCap(t *testing.T) {
+	l := newHealLimiter(3)
+	started := make(chan struct{}, 5)
+	finish := make(chan struct{})
+	var wg sync.WaitGroup
+	heal := func() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.acquire()
+			defer l.release()
+			started <- struct{}{}
+			<-finish
+		}()
+	}
+	expectStarted := func(n int) {
+		for i := 0; i < n; i++ {
+			select {
+			case <-started:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Expected %d heals to start, %d did", n, i)
+			}
+		}
+		select {
+		case <-started:
+			t.Fatalf("Expected only %d heals to start", n)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		heal()
+	}
+	expectStarted(3)
+
+	if err := l.set(1); err != nil {
+		t.Fatal(err)
+	}
+	heal()
+	heal()
+	// Running heals are not aborted and new heals are queued.
+	expectStarted(0)
+
+	// Queued heals wait until fewer heals than the new cap run.
+	finish <- struct{}{}
+	expectStarted(0)
+	finish <- struct{}{}
+	finish <- struct{}{}
+	expectStarted(1)
+	finish <- struct{}{}
+	expectStarted(1)
+	finish <- struct{}{}
+	wg.Wait()
+}
@@ -0,0 +1,61 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+)
+
+// Modes of dealing with orphaned objects found during heal.
+const (
+	// Orphaned objects are only reported, this is the default.
+	healDeleteReportOnly = "report-only"
+	// Orphaned objects are removed from the disks holding them.
+	healDeleteRemove = "delete"
+)
+
+// healDeletePolicy - mode of dealing with orphaned objects, can be
+// changed at runtime via admin RPC.
+type healDeletePolicy struct {
+	mutex sync.RWMutex
+	mode  string
+}
+
+// errOrphanedObject - object can not be read from any quorum of disks
+// while some disks still hold it.
+var errOrphanedObject = errors.New("Object is orphaned")
+
+var globalHealDeletePolicy = &healDeletePolicy{mode: healDeleteReportOnly}
+
+// get - returns the current mode.
+func (p *healDeletePolicy) get() string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.mode
+}
+
+// set - sets the mode, mode must be one of report-only or delete.
+func (p *healDeletePolicy) set(mode string) error {
+	if mode != healDeleteReportOnly && mode != healDeleteRemove {
+		return errInvalidArgument
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.mode = mode
+	return nil
+}
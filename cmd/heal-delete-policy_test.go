@@ -0,0 +1,44 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+// TestHealDeletePolicy - tests setting heal delete modes.
+func TestHealDeletePolicy(t *testing.T) {
+	policy := &healDeletePolicy{mode: healDeleteReportOnly}
+
+	testCases := []struct {
+		mode         string
+		expectedErr  error
+		expectedMode string
+	}{
+		{healDeleteRemove, nil, healDeleteRemove},
+		{"", errInvalidArgument, healDeleteRemove},
+		{"remove-all", errInvalidArgument, healDeleteRemove},
+		{healDeleteReportOnly, nil, healDeleteReportOnly},
+	}
+
+	for i, test := range testCases {
+		if err := policy.set(test.mode); err != test.expectedErr {
+			t.Errorf("Test %d: Expected error %v, got %v", i+1, test.expectedErr, err)
+		}
+		if mode := policy.get(); mode != test.expectedMode {
+			t.Errorf("Test %d: Expected mode %s, got %s", i+1, test.expectedMode, mode)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// Completed heal jobs remembered by a server. History is kept in
+// memory only and starts empty on every restart.
+const maxHealHistory = 100
+
+// Outcomes of a completed heal job.
+const (
+	// Every object was healed.
+	healOutcomeSuccess = "success"
+	// The job ran through but some objects could not be healed.
+	healOutcomePartial = "partial"
+	// The job was aborted by an error.
+	healOutcomeFailed = "failed"
+)
+
+// HealRecord - summary of a completed heal job.
+type HealRecord struct {
+	ID       string        `json:"id"`
+	Bucket   string        `json:"bucket"`
+	Started  time.Time     `json:"started"`
+	Finished time.Time     `json:"finished"`
+	Duration time.Duration `json:"duration"`
+
+	Healed int   `json:"healed"`
+	Failed int   `json:"failed"`
+	Bytes  int64 `json:"bytes"` // Size of the healed objects.
+
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+}
+
+// newHealRecord - summarizes the completed heal job described by status.
+func newHealRecord(status HealJobStatus, bytes int64, finished time.Time) HealRecord {
+	record := HealRecord{
+		ID:       status.ID,
+		Bucket:   status.Bucket,
+		Started:  status.Started,
+		Finished: finished,
+		Duration: finished.Sub(status.Started),
+		Healed:   status.Healed,
+		Failed:   status.Failed,
+		Bytes:    bytes,
+		Error:    status.Error,
+	}
+	record.Outcome = healOutcome(record)
+	return record
+}
+
+// healOutcome - returns the outcome of the heal job summarized by
+// record.
+func healOutcome(record HealRecord) string {
+	switch {
+	case record.Error != "":
+		return healOutcomeFailed
+	case record.Failed > 0:
+		return healOutcomePartial
+	}
+	return healOutcomeSuccess
+}
+
+// byHealFinished - sorts heal records newest first.
+type byHealFinished []HealRecord
+
+func (r byHealFinished) Len() int           { return len(r) }
+func (r byHealFinished) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r byHealFinished) Less(i, j int) bool { return r[i].Finished.After(r[j].Finished) }
+
+// healHistory - ring buffer of the last maxHealHistory heal jobs
+// completed by this server.
+type healHistory struct {
+	mutex   sync.Mutex
+	records []HealRecord
+	next    int // Index the next record is stored at.
+	full    bool
+}
+
+func newHealHistory() *healHistory {
+	return &healHistory{records: make([]HealRecord, maxHealHistory)}
+}
+
+// add - remembers record, forgetting the oldest record when full.
+func (h *healHistory) add(record HealRecord) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.records[h.next] = record
+	h.next = (h.next + 1) % len(h.records)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// last - returns up to the last n records, newest first.
+func (h *healHistory) last(n int) []HealRecord {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	count := h.next
+	if h.full {
+		count = len(h.records)
+	}
+	if n > count {
+		n = count
+	}
+	records := make([]HealRecord, 0, n)
+	for i := 1; i <= n; i++ {
+		records = append(records, h.records[(h.next-i+len(h.records))%len(h.records)])
+	}
+	return records
+}
@@ -0,0 +1,79 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Tests the heal history ring buffer.
+func TestHealHistory(t *testing.T) {
+	h := newHealHistory()
+	if records := h.last(10); len(records) != 0 {
+		t.Fatalf("Expected empty history, got %v", records)
+	}
+
+	// Records beyond maxHealHistory evict the oldest ones.
+	total := maxHealHistory + 10
+	for i := 0; i < total; i++ {
+		h.add(HealRecord{ID: fmt.Sprintf("job%d", i)})
+	}
+	testCases := []struct {
+		n        int
+		expected int
+	}{
+		{1, 1},
+		{5, 5},
+		{maxHealHistory, maxHealHistory},
+		{2 * maxHealHistory, maxHealHistory},
+	}
+	for i, testCase := range testCases {
+		records := h.last(testCase.n)
+		if len(records) != testCase.expected {
+			t.Fatalf("Test %d: expected %d records, got %d", i+1, testCase.expected, len(records))
+		}
+		// Newest first.
+		for j, record := range records {
+			if id := fmt.Sprintf("job%d", total-1-j); record.ID != id {
+				t.Fatalf("Test %d: expected %s at %d, got %s", i+1, id, j, record.ID)
+			}
+		}
+	}
+}
+
+// Tests heal records of completed jobs.
+func TestNewHealRecord(t *testing.T) {
+	started := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	finished := started.Add(time.Minute)
+	testCases := []struct {
+		status  HealJobStatus
+		outcome string
+	}{
+		{HealJobStatus{Healed: 5}, healOutcomeSuccess},
+		{HealJobStatus{Healed: 4, Failed: 1}, healOutcomePartial},
+		{HealJobStatus{Healed: 4, Error: "disk not found"}, healOutcomeFailed},
+	}
+	for i, testCase := range testCases {
+		testCase.status.Started = started
+		record := newHealRecord(testCase.status, 10, finished)
+		if record.Outcome != testCase.outcome || record.Duration != time.Minute || record.Bytes != 10 {
+			t.Errorf("Test %d: expected %s outcome in a minute, got %v", i+1, testCase.outcome, record)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"hash/crc32"
+	"sync"
+	"time"
+)
+
+const (
+	// Finished heal jobs kept around so that their status can
+	// still be polled.
+	maxHealJobs = 100
+
+	// Objects listed per page while walking a bucket to heal.
+	healJobListMaxKeys = 1000
+
+	// Prefix in minioMetaBucket of the locks serializing heal
+	// jobs of a bucket.
+	healJobsPrefix = "heal-jobs"
+)
+
+// errNoSuchHealJob - no server knows about the requested heal job.
+var errNoSuchHealJob = errors.New("No such heal job")
+
+// HealJobStatus - progress of a heal job of all objects of a bucket.
+type HealJobStatus struct {
+	ID      string    `json:"id"`
+	Bucket  string    `json:"bucket"`
+	Started time.Time `json:"started"`
+
+	Queued int `json:"queued"` // Objects found in the bucket so far.
+	Healed int `json:"healed"`
+	Failed int `json:"failed"`
+
+	Done  bool   `json:"done"`
+	Error string `json:"error,omitempty"`
+}
+
+// healJobs - heal jobs run by this server, keyed by job ID.
+type healJobs struct {
+	mutex sync.Mutex
+	jobs  map[string]*HealJobStatus
+	// Jobs are summarized here once done.
+	history *healHistory
+}
+
+func newHealJobs() *healJobs {
+	return &healJobs{jobs: make(map[string]*HealJobStatus), history: newHealHistory()}
+}
+
+var globalHealJobs = newHealJobs()
+
+// start - starts job id on bucket in the background, run is passed a
+// function to record the size and outcome of healing every object it
+// queues. Starting a job id twice is a no-op.
+func (h *healJobs) start(id, bucket string, run func(record func(size int64, err error)) error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if _, ok := h.jobs[id]; ok {
+		return
+	}
+	h.pruneLocked()
+	job := &HealJobStatus{ID: id, Bucket: bucket, Started: time.Now().UTC()}
+	h.jobs[id] = job
+
+	// Size of the healed objects, only reported in the history.
+	var bytes int64
+	record := func(size int64, err error) {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		job.Queued++
+		if err != nil {
+			job.Failed++
+		} else {
+			job.Healed++
+			bytes += size
+		}
+	}
+
+	go func() {
+		globalScannerActivity.begin()
+		err := run(record)
+		globalScannerActivity.end()
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		job.Done = true
+		if err != nil {
+			job.Error = err.Error()
+		}
+		h.history.add(newHealRecord(*job, bytes, time.Now().UTC()))
+	}()
+}
+
+// pruneLocked - drops the oldest finished jobs beyond maxHealJobs,
+// must be called with the mutex held.
+func (h *healJobs) pruneLocked() {
+	for len(h.jobs) >= maxHealJobs {
+		var oldest *HealJobStatus
+		for _, job := range h.jobs {
+			if job.Done && (oldest == nil || job.Started.Before(oldest.Started)) {
+				oldest = job
+			}
+		}
+		if oldest == nil {
+			// Every job is still running.
+			return
+		}
+		delete(h.jobs, oldest.ID)
+	}
+}
+
+// list - returns a copy of all jobs.
+func (h *healJobs) list() []HealJobStatus {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	jobs := make([]HealJobStatus, 0, len(h.jobs))
+	for _, job := range h.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+// isObjectInPart - returns true if object belongs to part out of parts
+// in which the objects of a bucket are spread among servers.
+func isObjectInPart(object string, part, parts int) bool {
+	return int(crc32.ChecksumIEEE([]byte(object))%uint32(parts)) == part
+}
+
+// healBucketObjects - heals the objects of bucket which belong to part
+// out of parts, the first part heals the bucket itself too.
+func healBucketObjects(objLayer ObjectLayer, bucket string, part, parts int, record func(size int64, err error)) error {
+	globalClusterEvents.emit(clusterEventHealStart, bucket)
+	defer globalClusterEvents.emit(clusterEventHealEnd, bucket)
+
+	if part == 0 {
+		if err := objLayer.HealBucket(bucket); err != nil {
+			return errorCause(err)
+		}
+	}
+
+	marker := ""
+	for {
+		result, err := objLayer.ListObjects(bucket, "", marker, "", healJobListMaxKeys)
+		if err != nil {
+			return errorCause(err)
+		}
+		for _, obj := range result.Objects {
+			if !isObjectInPart(obj.Name, part, parts) {
+				continue
+			}
+			err = objLayer.HealObject(bucket, obj.Name)
+			errorIf(err, "Unable to heal object %s/%s.", bucket, obj.Name)
+			record(obj.Size, errorCause(err))
+		}
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			return nil
+		}
+		marker = result.Objects[len(result.Objects)-1].Name
+	}
+}
+
+// startHealJob - starts healing the part of bucket assigned to this
+// server as job id.
+func startHealJob(h *healJobs, id, bucket string, part, parts int) error {
+	if parts <= 0 || part < 0 || part >= parts {
+		return errInvalidArgument
+	}
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return errServerNotInitialized
+	}
+	h.start(id, bucket, func(record func(size int64, err error)) error {
+		return healBucketObjects(objLayer, bucket, part, parts, record)
+	})
+	return nil
+}
@@ -94,6 +94,9 @@ func authenticateNode(accessKey, secretKey string) (string, error) {
 }
 
 func authenticateWeb(accessKey, secretKey string) (string, error) {
+	if err := checkCredentialExpiry(strings.TrimSpace(accessKey)); err != nil {
+		return "", err
+	}
 	return authenticateJWT(accessKey, secretKey, defaultJWTExpiry)
 }
 
@@ -134,5 +137,10 @@ func webRequestAuthenticate(req *http.Request) error {
 	if !jwtToken.Valid {
 		return errAuthentication
 	}
+	if claims, ok := jwtToken.Claims.(jwtgo.MapClaims); ok {
+		if accessKey, _ := claims["sub"].(string); checkCredentialExpiry(accessKey) != nil {
+			return errAuthentication
+		}
+	}
 	return nil
 }
@@ -0,0 +1,136 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Object naming policy config name.
+const keyNamingPolicyConfig = "key-naming-policy.json"
+
+// KeyPolicy - restrictions on names of new objects, in addition to
+// the ones imposed by IsValidObjectName.
+type KeyPolicy struct {
+	// Reject names with control characters.
+	ForbidControlChars bool `json:"forbidControlChars"`
+	// Maximum length of names in bytes, zero means no limit.
+	MaxLength int `json:"maxLength"`
+	// Reject names containing any of these.
+	ForbiddenSubstrings []string `json:"forbiddenSubstrings"`
+}
+
+// isValid - returns true if the policy itself is well formed.
+func (p KeyPolicy) isValid() bool {
+	if p.MaxLength < 0 {
+		return false
+	}
+	for _, substr := range p.ForbiddenSubstrings {
+		if substr == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// allows - returns true if object complies with the policy.
+func (p KeyPolicy) allows(object string) bool {
+	if p.MaxLength > 0 && len(object) > p.MaxLength {
+		return false
+	}
+	if p.ForbidControlChars && strings.IndexFunc(object, unicode.IsControl) >= 0 {
+		return false
+	}
+	for _, substr := range p.ForbiddenSubstrings {
+		if strings.Contains(object, substr) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyNamingPolicy - object naming policy of this server, can be
+// changed at runtime via admin RPC.
+type keyNamingPolicy struct {
+	mutex  sync.RWMutex
+	policy KeyPolicy
+}
+
+var globalKeyNamingPolicy = &keyNamingPolicy{}
+
+// get - returns the current policy.
+func (k *keyNamingPolicy) get() KeyPolicy {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	return k.policy
+}
+
+// set - sets the policy, applies only to objects created from now
+// on. Existing objects remain accessible whatever their name.
+func (k *keyNamingPolicy) set(policy KeyPolicy) error {
+	if !policy.isValid() {
+		return errInvalidArgument
+	}
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	k.policy = policy
+	return nil
+}
+
+// check - returns an error if a new object can not be created with
+// the given name.
+func (k *keyNamingPolicy) check(bucket, object string) error {
+	if !k.get().allows(object) {
+		return traceError(ObjectNameNotAllowed{Bucket: bucket, Object: object})
+	}
+	return nil
+}
+
+// writeKeyNamingPolicy - persists the object naming policy, a policy
+// without restrictions removes any previously persisted policy.
+func writeKeyNamingPolicy(objAPI ObjectLayer, policy KeyPolicy) error {
+	if !policy.isValid() {
+		return errInvalidArgument
+	}
+	if !policy.ForbidControlChars && policy.MaxLength == 0 && len(policy.ForbiddenSubstrings) == 0 {
+		return writeServerSetting(objAPI, keyNamingPolicyConfig, nil)
+	}
+	return writeServerSetting(objAPI, keyNamingPolicyConfig, policy)
+}
+
+// initKeyNamingPolicy - loads the object naming policy, so that a
+// restarted server rejects the same object names as its peers.
+func initKeyNamingPolicy(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	var policy KeyPolicy
+	ok, err := readServerSetting(objAPI, keyNamingPolicyConfig, &policy)
+	if err != nil {
+		if isErrIgnored(err, errDiskNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	return globalKeyNamingPolicy.set(policy)
+}
@@ -0,0 +1,168 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// TestKeyPolicyAllows - tests object names against naming policies.
+func TestKeyPolicyAllows(t *testing.T) {
+	testCases := []struct {
+		policy  KeyPolicy
+		object  string
+		allowed bool
+	}{
+		// Empty policy allows anything.
+		{KeyPolicy{}, "dir/obj\x07", true},
+		{KeyPolicy{ForbidControlChars: true}, "dir/obj", true},
+		{KeyPolicy{ForbidControlChars: true}, "dir/obj\x07", false},
+		{KeyPolicy{ForbidControlChars: true}, "dir/\nobj", false},
+		{KeyPolicy{ForbidControlChars: true}, "dir/obj\u0085", false},
+		{KeyPolicy{MaxLength: 7}, "dir/obj", true},
+		{KeyPolicy{MaxLength: 7}, "dir/obj1", false},
+		{KeyPolicy{ForbiddenSubstrings: []string{"..", "~"}}, "dir/obj", true},
+		{KeyPolicy{ForbiddenSubstrings: []string{"..", "~"}}, "dir/../obj", false},
+		{KeyPolicy{ForbiddenSubstrings: []string{"..", "~"}}, "dir/obj~", false},
+	}
+
+	for i, test := range testCases {
+		if allowed := test.policy.allows(test.object); allowed != test.allowed {
+			t.Errorf("Test %d: Expected allowed to be %v for %q", i+1, test.allowed, test.object)
+		}
+	}
+}
+
+// TestKeyNamingPolicySet - tests that malformed policies are rejected.
+func TestKeyNamingPolicySet(t *testing.T) {
+	k := &keyNamingPolicy{}
+	testCases := []struct {
+		policy      KeyPolicy
+		expectedErr error
+	}{
+		{KeyPolicy{}, nil},
+		{KeyPolicy{ForbidControlChars: true, MaxLength: 512, ForbiddenSubstrings: []string{"~"}}, nil},
+		{KeyPolicy{MaxLength: -1}, errInvalidArgument},
+		{KeyPolicy{ForbiddenSubstrings: []string{"~", ""}}, errInvalidArgument},
+	}
+
+	for i, test := range testCases {
+		if err := k.set(test.policy); err != test.expectedErr {
+			t.Errorf("Test %d: Expected error %v, got %v", i+1, test.expectedErr, err)
+		}
+	}
+}
+
+// Wrapper for calling testKeyNamingPolicy for both XL and FS.
+func TestKeyNamingPolicy(t *testing.T) {
+	ExecObjectLayerTest(t, testKeyNamingPolicy)
+}
+
+// Tests that the naming policy rejects new objects while existing
+// objects with the same kind of name stay accessible.
+func testKeyNamingPolicy(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	defer resetGlobalKeyNamingPolicy()
+
+	bucket := "bucket"
+	existing := "dir/existing\x07"
+	data := []byte("hello")
+	if err := obj.MakeBucket(bucket); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, err := obj.PutObject(bucket, existing, int64(len(data)), bytes.NewReader(data), nil, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	if err := globalKeyNamingPolicy.set(KeyPolicy{ForbidControlChars: true}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	// New objects with control characters are rejected.
+	_, err := obj.PutObject(bucket, "dir/new\x07", int64(len(data)), bytes.NewReader(data), nil, "")
+	if _, ok := errorCause(err).(ObjectNameNotAllowed); !ok {
+		t.Fatalf("%s: Expected ObjectNameNotAllowed, got %v", instanceType, err)
+	}
+	if toAPIErrorCode(err) != ErrObjectNameNotAllowed {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, ErrObjectNameNotAllowed, toAPIErrorCode(err))
+	}
+	_, err = obj.NewMultipartUpload(bucket, "dir/new\x07", nil)
+	if _, ok := errorCause(err).(ObjectNameNotAllowed); !ok {
+		t.Fatalf("%s: Expected ObjectNameNotAllowed, got %v", instanceType, err)
+	}
+
+	// Existing object is still readable.
+	var buffer bytes.Buffer
+	if err = obj.GetObject(bucket, existing, 0, int64(len(data)), &buffer); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !bytes.Equal(buffer.Bytes(), data) {
+		t.Fatalf("%s: Expected %q, got %q", instanceType, data, buffer.Bytes())
+	}
+
+	// Objects allowed by the policy can still be created.
+	if _, err = obj.PutObject(bucket, "dir/new", int64(len(data)), bytes.NewReader(data), nil, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+}
+
+// TestKeyNamingPolicyRestart - tests the object naming policy is
+// loaded again by a restarted server.
+func TestKeyNamingPolicyRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalKeyNamingPolicy()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	if err = writeKeyNamingPolicy(objLayer, KeyPolicy{MaxLength: -1}); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+	policy := KeyPolicy{ForbidControlChars: true, MaxLength: 64, ForbiddenSubstrings: []string{".."}}
+	if err = writeKeyNamingPolicy(objLayer, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, the policy is loaded again.
+	resetGlobalKeyNamingPolicy()
+	if err = initKeyNamingPolicy(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if got := globalKeyNamingPolicy.get(); !reflect.DeepEqual(got, policy) {
+		t.Fatalf("Expected policy %v after restart, got %v", policy, got)
+	}
+
+	// A policy without restrictions removes the persisted policy.
+	if err = writeKeyNamingPolicy(objLayer, KeyPolicy{}); err != nil {
+		t.Fatal(err)
+	}
+	resetGlobalKeyNamingPolicy()
+	if err = initKeyNamingPolicy(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if got := globalKeyNamingPolicy.get(); !reflect.DeepEqual(got, KeyPolicy{}) {
+		t.Fatalf("Expected no policy, got %v", got)
+	}
+}
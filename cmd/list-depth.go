@@ -0,0 +1,81 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Response header set on listings with a delimiter which left out
+// entries deeper than the maximum list depth.
+const listDepthTruncatedHeader = "X-Minio-List-Depth-Truncated"
+
+// Maximum depth of the entries of listings with a delimiter, can be
+// changed at runtime via admin RPC. 0 means no maximum.
+var globalMaxListDepth int64
+
+// getMaxListDepth - returns the current maximum list depth.
+func getMaxListDepth() int {
+	return int(atomic.LoadInt64(&globalMaxListDepth))
+}
+
+// setMaxListDepth - sets the maximum list depth, 0 removes it.
+func setMaxListDepth(depth int) error {
+	if depth < 0 {
+		return errInvalidArgument
+	}
+	atomic.StoreInt64(&globalMaxListDepth, int64(depth))
+	return nil
+}
+
+// listEntryDepth - returns the depth of an object name or common
+// prefix, i.e the number of path elements in it: "a" and "a/" are at
+// depth 1, "a/b" and "a/b/" at depth 2.
+func listEntryDepth(name, delimiter string) int {
+	return strings.Count(strings.TrimSuffix(name, delimiter), delimiter) + 1
+}
+
+// capListDepth - leaves out of a listing with delimiter the objects
+// and common prefixes deeper than maxDepth, returning true if any was.
+// Listings without delimiter and a maxDepth of 0 are left as is.
+func capListDepth(info ListObjectsInfo, delimiter string, maxDepth int) (ListObjectsInfo, bool) {
+	if delimiter == "" || maxDepth == 0 {
+		return info, false
+	}
+
+	truncated := false
+	objects := make([]ObjectInfo, 0, len(info.Objects))
+	for _, object := range info.Objects {
+		if listEntryDepth(object.Name, delimiter) > maxDepth {
+			truncated = true
+			continue
+		}
+		objects = append(objects, object)
+	}
+	prefixes := make([]string, 0, len(info.Prefixes))
+	for _, prefix := range info.Prefixes {
+		if listEntryDepth(prefix, delimiter) > maxDepth {
+			truncated = true
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	info.Objects = objects
+	info.Prefixes = prefixes
+	return info, truncated
+}
@@ -0,0 +1,130 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestCapListDepth - tests entries beyond the maximum depth are left
+// out of listings with a delimiter only.
+func TestCapListDepth(t *testing.T) {
+	info := ListObjectsInfo{
+		Objects:  []ObjectInfo{{Name: "a"}, {Name: "a/b"}, {Name: "a/b/c"}},
+		Prefixes: []string{"a/", "a/b/", "a/b/c/"},
+	}
+	testCases := []struct {
+		delimiter string
+		maxDepth  int
+		objects   []string
+		prefixes  []string
+		truncated bool
+	}{
+		{"/", 0, []string{"a", "a/b", "a/b/c"}, []string{"a/", "a/b/", "a/b/c/"}, false},
+		{"/", 1, []string{"a"}, []string{"a/"}, true},
+		{"/", 2, []string{"a", "a/b"}, []string{"a/", "a/b/"}, true},
+		{"/", 3, []string{"a", "a/b", "a/b/c"}, []string{"a/", "a/b/", "a/b/c/"}, false},
+		// Flat listings are never capped.
+		{"", 1, []string{"a", "a/b", "a/b/c"}, []string{"a/", "a/b/", "a/b/c/"}, false},
+	}
+	for i, testCase := range testCases {
+		capped, truncated := capListDepth(info, testCase.delimiter, testCase.maxDepth)
+		var objects []string
+		for _, object := range capped.Objects {
+			objects = append(objects, object.Name)
+		}
+		if !reflect.DeepEqual(objects, testCase.objects) || !reflect.DeepEqual(capped.Prefixes, testCase.prefixes) {
+			t.Errorf("Test %d: expected %v and %v, got %v and %v", i+1,
+				testCase.objects, testCase.prefixes, objects, capped.Prefixes)
+		}
+		if truncated != testCase.truncated {
+			t.Errorf("Test %d: expected truncated %v, got %v", i+1, testCase.truncated, truncated)
+		}
+	}
+
+	if err := setMaxListDepth(-1); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+}
+
+// TestListObjectsMaxDepth - tests a deep listing with a delimiter is
+// truncated at the maximum depth while a flat listing is not.
+func TestListObjectsMaxDepth(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testListObjectsMaxDepth, []string{"ListObjectsV1"})
+}
+
+func testListObjectsMaxDepth(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials credential, t *testing.T) {
+	defer resetGlobalMaxListDepth()
+
+	objects := []string{"a/b/c/d/object", "a/b/object", "a/object"}
+	for _, object := range objects {
+		if _, err := obj.PutObject(bucketName, object, 4, bytes.NewReader([]byte("data")), nil, ""); err != nil {
+			t.Fatalf("Minio %s: %v", instanceType, err)
+		}
+	}
+	if err := setMaxListDepth(2); err != nil {
+		t.Fatal(err)
+	}
+
+	listObjects := func(prefix, delimiter string) (ListObjectsResponse, bool) {
+		values := url.Values{}
+		values.Set("prefix", prefix)
+		values.Set("delimiter", delimiter)
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("GET", makeTestTargetURL("", bucketName, "", values),
+			0, nil, credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("Minio %s: Failed to create HTTP request for List Objects: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Minio %s: Expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+		}
+		var response ListObjectsResponse
+		if err = xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Minio %s: %v", instanceType, err)
+		}
+		return response, rec.Header().Get(listDepthTruncatedHeader) == "true"
+	}
+
+	// Within the maximum depth.
+	response, truncated := listObjects("a/", "/")
+	if truncated || len(response.Contents) != 1 || len(response.CommonPrefixes) != 1 ||
+		response.CommonPrefixes[0].Prefix != "a/b/" {
+		t.Fatalf("Minio %s: Expected a/object and a/b/, got %v truncated %v", instanceType, response, truncated)
+	}
+
+	// a/b/object and a/b/c/ are at depth 3.
+	response, truncated = listObjects("a/b/", "/")
+	if !truncated || len(response.Contents) != 0 || len(response.CommonPrefixes) != 0 {
+		t.Fatalf("Minio %s: Expected truncated empty listing, got %v truncated %v", instanceType, response, truncated)
+	}
+
+	// Flat listings return all objects.
+	response, truncated = listObjects("a/", "")
+	if truncated || len(response.Contents) != len(objects) {
+		t.Fatalf("Minio %s: Expected %d objects, got %v truncated %v", instanceType, len(objects), response, truncated)
+	}
+}
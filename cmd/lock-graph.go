@@ -0,0 +1,170 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "sort"
+
+// LockGraphNode - an operation holding or waiting for a lock on
+// <bucket, object>, identified by the server it runs on and its
+// operation ID.
+type LockGraphNode struct {
+	Node        string `json:"node"`
+	Bucket      string `json:"bucket"`
+	Object      string `json:"object"`
+	OperationID string `json:"id"`
+}
+
+// LockGraphEdge - Waiter is blocked on a lock currently held by
+// Holder.
+type LockGraphEdge struct {
+	Waiter LockGraphNode `json:"waiter"`
+	Holder LockGraphNode `json:"holder"`
+}
+
+// LockGraph - wait-for graph of all operations across servers along
+// with the deadlock cycles found in it. Each cycle lists the blocked
+// operations in the order they wait on each other, the last one
+// waiting on the first.
+type LockGraph struct {
+	Edges  []LockGraphEdge   `json:"edges"`
+	Cycles [][]LockGraphNode `json:"cycles"`
+}
+
+// lockOpKey - uniquely identifies an operation across servers.
+type lockOpKey struct {
+	node  string
+	opsID string
+}
+
+// lockOp - an operation holding or waiting on a lock.
+type lockOp struct {
+	key   lockOpKey
+	lType lockType
+}
+
+// Visit states of an operation during cycle detection.
+const (
+	lockOpUnvisited = iota
+	lockOpVisiting
+	lockOpVisited
+)
+
+// buildLockGraph - builds the wait-for graph out of lock information
+// gathered from every server, keyed by server address. Locks on a
+// <bucket, object> are cluster wide, so an operation blocked on one
+// server waits for conflicting holders on all servers.
+func buildLockGraph(nodeLocks map[string][]VolumeLockInfo) LockGraph {
+	// Walk servers in a fixed order to keep the output stable.
+	var addrs []string
+	for addr := range nodeLocks {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	type lockWait struct {
+		op    lockOp
+		param nsParam
+	}
+	holders := make(map[nsParam][]lockOp)
+	var waits []lockWait
+	for _, addr := range addrs {
+		for _, volLock := range nodeLocks[addr] {
+			param := nsParam{volume: volLock.Bucket, path: volLock.Object}
+			for _, state := range volLock.LockDetailsOnObject {
+				op := lockOp{
+					key:   lockOpKey{node: addr, opsID: state.OperationID},
+					lType: state.LockType,
+				}
+				switch state.Status {
+				case runningStatus:
+					holders[param] = append(holders[param], op)
+				case blockedStatus:
+					waits = append(waits, lockWait{op, param})
+				}
+			}
+		}
+	}
+
+	graph := LockGraph{Edges: []LockGraphEdge{}, Cycles: [][]LockGraphNode{}}
+	// Outgoing edges of every operation, as indices into graph.Edges.
+	adj := make(map[lockOpKey][]int)
+	// Operation each edge points to.
+	var edgeHolders []lockOpKey
+	var waiters []lockOpKey
+	for _, wait := range waits {
+		waiters = append(waiters, wait.op.key)
+		for _, holder := range holders[wait.param] {
+			if holder.key == wait.op.key {
+				continue
+			}
+			// Read locks do not block each other.
+			if wait.op.lType == debugRLockStr && holder.lType == debugRLockStr {
+				continue
+			}
+			adj[wait.op.key] = append(adj[wait.op.key], len(graph.Edges))
+			edgeHolders = append(edgeHolders, holder.key)
+			graph.Edges = append(graph.Edges, LockGraphEdge{
+				Waiter: newLockGraphNode(wait.op.key, wait.param),
+				Holder: newLockGraphNode(holder.key, wait.param),
+			})
+		}
+	}
+
+	// Depth first search, every edge leading back to an operation
+	// on the current path closes a cycle.
+	state := make(map[lockOpKey]int)
+	// Position in path of the edge being followed out of an operation.
+	pos := make(map[lockOpKey]int)
+	var path []int
+	var visit func(key lockOpKey)
+	visit = func(key lockOpKey) {
+		state[key] = lockOpVisiting
+		for _, e := range adj[key] {
+			pos[key] = len(path)
+			path = append(path, e)
+			next := edgeHolders[e]
+			switch state[next] {
+			case lockOpUnvisited:
+				visit(next)
+			case lockOpVisiting:
+				var cycle []LockGraphNode
+				for _, c := range path[pos[next]:] {
+					cycle = append(cycle, graph.Edges[c].Waiter)
+				}
+				graph.Cycles = append(graph.Cycles, cycle)
+			}
+			path = path[:len(path)-1]
+		}
+		state[key] = lockOpVisited
+	}
+	for _, key := range waiters {
+		if state[key] == lockOpUnvisited {
+			visit(key)
+		}
+	}
+	return graph
+}
+
+// newLockGraphNode - returns the graph node of an operation on param.
+func newLockGraphNode(key lockOpKey, param nsParam) LockGraphNode {
+	return LockGraphNode{
+		Node:        key.node,
+		Bucket:      param.volume,
+		Object:      param.path,
+		OperationID: key.opsID,
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBuildLockGraph - tests wait-for graph construction and cycle
+// detection over lock information from multiple servers.
+func TestBuildLockGraph(t *testing.T) {
+	// lockState - returns the lock information of a single
+	// operation on bucket/object.
+	lockState := func(object, opsID string, lType lockType, status statusType) VolumeLockInfo {
+		return VolumeLockInfo{
+			Bucket: "bucket",
+			Object: object,
+			LockDetailsOnObject: []OpsLockState{
+				{OperationID: opsID, LockType: lType, Status: status},
+			},
+		}
+	}
+	node := func(addr, object, opsID string) LockGraphNode {
+		return LockGraphNode{Node: addr, Bucket: "bucket", Object: object, OperationID: opsID}
+	}
+
+	testCases := []struct {
+		nodeLocks map[string][]VolumeLockInfo
+		edges     []LockGraphEdge
+		cycles    [][]LockGraphNode
+	}{
+		// Test 1: no locks.
+		{
+			nodeLocks: map[string][]VolumeLockInfo{},
+			edges:     []LockGraphEdge{},
+			cycles:    [][]LockGraphNode{},
+		},
+		// Test 2: op1 on server1 holds obj1 and waits for obj2,
+		// op2 on server2 holds obj2 and waits for obj1.
+		{
+			nodeLocks: map[string][]VolumeLockInfo{
+				"server1:9000": {
+					lockState("obj1", "op1", debugWLockStr, runningStatus),
+					lockState("obj2", "op1", debugWLockStr, blockedStatus),
+				},
+				"server2:9000": {
+					lockState("obj2", "op2", debugWLockStr, runningStatus),
+					lockState("obj1", "op2", debugWLockStr, blockedStatus),
+				},
+			},
+			edges: []LockGraphEdge{
+				{Waiter: node("server1:9000", "obj2", "op1"), Holder: node("server2:9000", "obj2", "op2")},
+				{Waiter: node("server2:9000", "obj1", "op2"), Holder: node("server1:9000", "obj1", "op1")},
+			},
+			cycles: [][]LockGraphNode{
+				{node("server1:9000", "obj2", "op1"), node("server2:9000", "obj1", "op2")},
+			},
+		},
+		// Test 3: operations with the same ID on different servers
+		// are different operations, the chain op1 -> op2 -> op3 on
+		// server1 doesn't close a cycle.
+		{
+			nodeLocks: map[string][]VolumeLockInfo{
+				"server1:9000": {
+					lockState("obj1", "op1", debugWLockStr, runningStatus),
+					lockState("obj2", "op1", debugWLockStr, blockedStatus),
+				},
+				"server2:9000": {
+					lockState("obj2", "op1", debugWLockStr, runningStatus),
+					lockState("obj3", "op1", debugWLockStr, blockedStatus),
+				},
+				"server3:9000": {
+					lockState("obj3", "op3", debugWLockStr, runningStatus),
+				},
+			},
+			edges: []LockGraphEdge{
+				{Waiter: node("server1:9000", "obj2", "op1"), Holder: node("server2:9000", "obj2", "op1")},
+				{Waiter: node("server2:9000", "obj3", "op1"), Holder: node("server3:9000", "obj3", "op3")},
+			},
+			cycles: [][]LockGraphNode{},
+		},
+		// Test 4: read locks don't wait on each other, so there is
+		// no cycle even though both operations are blocked.
+		{
+			nodeLocks: map[string][]VolumeLockInfo{
+				"server1:9000": {
+					lockState("obj1", "op1", debugRLockStr, runningStatus),
+					lockState("obj2", "op1", debugRLockStr, blockedStatus),
+				},
+				"server2:9000": {
+					lockState("obj2", "op2", debugRLockStr, runningStatus),
+					lockState("obj1", "op2", debugRLockStr, blockedStatus),
+				},
+			},
+			edges:  []LockGraphEdge{},
+			cycles: [][]LockGraphNode{},
+		},
+		// Test 5: a cycle of three operations across three servers
+		// with an unrelated waiter hanging off of it.
+		{
+			nodeLocks: map[string][]VolumeLockInfo{
+				"server1:9000": {
+					lockState("obj1", "op1", debugWLockStr, runningStatus),
+					lockState("obj2", "op1", debugRLockStr, blockedStatus),
+					lockState("obj1", "op4", debugRLockStr, blockedStatus),
+				},
+				"server2:9000": {
+					lockState("obj2", "op2", debugWLockStr, runningStatus),
+					lockState("obj3", "op2", debugWLockStr, blockedStatus),
+				},
+				"server3:9000": {
+					lockState("obj3", "op3", debugRLockStr, runningStatus),
+					lockState("obj1", "op3", debugWLockStr, blockedStatus),
+				},
+			},
+			edges: []LockGraphEdge{
+				{Waiter: node("server1:9000", "obj2", "op1"), Holder: node("server2:9000", "obj2", "op2")},
+				{Waiter: node("server1:9000", "obj1", "op4"), Holder: node("server1:9000", "obj1", "op1")},
+				{Waiter: node("server2:9000", "obj3", "op2"), Holder: node("server3:9000", "obj3", "op3")},
+				{Waiter: node("server3:9000", "obj1", "op3"), Holder: node("server1:9000", "obj1", "op1")},
+			},
+			cycles: [][]LockGraphNode{
+				{
+					node("server1:9000", "obj2", "op1"),
+					node("server2:9000", "obj3", "op2"),
+					node("server3:9000", "obj1", "op3"),
+				},
+			},
+		},
+	}
+
+	for i, test := range testCases {
+		graph := buildLockGraph(test.nodeLocks)
+		if !reflect.DeepEqual(graph.Edges, test.edges) {
+			t.Errorf("Test %d: Expected edges %v, got %v", i+1, test.edges, graph.Edges)
+		}
+		if !reflect.DeepEqual(graph.Cycles, test.cycles) {
+			t.Errorf("Test %d: Expected cycles %v, got %v", i+1, test.cycles, graph.Cycles)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// LockHoldHistograms - how long namespace locks taken by a server are
+// held. Completed counts locks by how long they were held until
+// released, Held counts locks still held by how long they have been
+// held so far.
+type LockHoldHistograms struct {
+	Completed LatencyHistogram
+	Held      LatencyHistogram
+}
+
+// newLockHoldHistograms - returns empty histograms.
+func newLockHoldHistograms() LockHoldHistograms {
+	return LockHoldHistograms{
+		Completed: newLatencyHistogram(),
+		Held:      newLatencyHistogram(),
+	}
+}
+
+// merge - adds counts of other to h.
+func (h LockHoldHistograms) merge(other LockHoldHistograms) {
+	h.Completed.merge(other.Completed)
+	h.Held.merge(other.Held)
+}
+
+// lockHolds - durations of released namespace locks, uses the same
+// buckets as API latency.
+type lockHolds struct {
+	mutex     sync.Mutex
+	completed LatencyHistogram
+}
+
+var globalLockHolds = &lockHolds{completed: newLatencyHistogram()}
+
+// record - records a lock released after being held for d.
+func (l *lockHolds) record(d time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.completed.add(d)
+}
+
+// snapshot - returns a copy of the histogram of released locks.
+func (l *lockHolds) snapshot() LatencyHistogram {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	h := newLatencyHistogram()
+	h.merge(l.completed)
+	return h
+}
+
+// heldLocks - returns a histogram of how long locks still held have
+// been held at now. Locks waited on are not counted.
+func (n *nsLockMap) heldLocks(now time.Time) LatencyHistogram {
+	n.lockMapMutex.Lock()
+	defer n.lockMapMutex.Unlock()
+
+	h := newLatencyHistogram()
+	for _, volumePathLocks := range n.debugLockMap {
+		for _, lockInfo := range volumePathLocks.lockInfo {
+			if lockInfo.status == runningStatus {
+				h.add(now.Sub(lockInfo.since))
+			}
+		}
+	}
+	return h
+}
+
+// getLocalLockHoldHistograms - returns how long locks taken by this
+// server were and are held.
+func getLocalLockHoldHistograms() LockHoldHistograms {
+	histograms := LockHoldHistograms{
+		Completed: globalLockHolds.snapshot(),
+		Held:      newLatencyHistogram(),
+	}
+	if globalNSMutex != nil {
+		histograms.Held = globalNSMutex.heldLocks(time.Now().UTC())
+	}
+	return histograms
+}
+
+// LockHoldPercentiles - percentiles of how long namespace locks are
+// held across all servers.
+type LockHoldPercentiles struct {
+	Completed LatencyPercentiles `json:"completed"`
+	Held      LatencyPercentiles `json:"held"`
+}
@@ -0,0 +1,61 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests that released locks are recorded by how long they were held
+// and that locks still held are kept out of them.
+func TestGetLocalLockHoldHistograms(t *testing.T) {
+	initNSLock(false)
+	resetGlobalLockHolds()
+	defer resetGlobalLockHolds()
+
+	holdTime := 5 * time.Millisecond
+	released := globalNSMutex.NewNSLock("bucket", "released")
+	released.Lock()
+	time.Sleep(holdTime)
+	released.Unlock()
+
+	held := globalNSMutex.NewNSLock("bucket", "held")
+	held.RLock()
+
+	histograms := getLocalLockHoldHistograms()
+	if samples := histograms.Completed.samples(); samples != 1 {
+		t.Fatalf("Expected 1 released lock, got %d", samples)
+	}
+	for i, bound := range latencyBuckets {
+		if bound < holdTime && histograms.Completed.Counts[i] != 0 {
+			t.Fatalf("Expected released lock to be held at least %v, got %v", holdTime, histograms.Completed.Counts)
+		}
+	}
+	if samples := histograms.Held.samples(); samples != 1 {
+		t.Fatalf("Expected 1 held lock, got %d", samples)
+	}
+
+	held.RUnlock()
+	histograms = getLocalLockHoldHistograms()
+	if samples := histograms.Completed.samples(); samples != 2 {
+		t.Fatalf("Expected 2 released locks, got %d", samples)
+	}
+	if samples := histograms.Held.samples(); samples != 0 {
+		t.Fatalf("Expected no held locks, got %d", samples)
+	}
+}
@@ -220,6 +220,10 @@ func (n *nsLockMap) deleteLockInfoEntryForOps(param nsParam, opsID string) error
 	granted := opsIDLock.status == runningStatus
 	n.counters.lockRemoved(granted)
 	infoMap.counters.lockRemoved(granted)
+	if granted {
+		// Status changed to running when the lock was granted.
+		globalLockHolds.record(time.Now().UTC().Sub(opsIDLock.since))
+	}
 	delete(infoMap.lockInfo, opsID)
 	return nil
 }
@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+)
+
+// Origin of locks whose node, operation or type is not known.
+const unknownLockOrigin = "unknown"
+
+// LockOrigin - locks held or waited upon by operations of a kind on a
+// server.
+type LockOrigin struct {
+	Node      string `json:"node"`
+	Operation string `json:"operation"` // e.g. GetObject, PutObject.
+	Type      string `json:"type"`      // RLock or WLock.
+	Count     int64  `json:"count"`
+}
+
+// lockOperation - returns the operation which took a lock out of its
+// source, as recorded by callerSource i.e [file:line:function()].
+// Receivers, closures and the Handler suffix of API handlers are
+// stripped, so that GetObjectHandler and xlObjects.GetObject both
+// give GetObject.
+func lockOperation(source string) string {
+	source = strings.TrimSuffix(strings.TrimPrefix(source, "["), "]")
+	fields := strings.Split(source, ":")
+	if len(fields) != 3 {
+		return unknownLockOrigin
+	}
+	names := strings.Split(strings.TrimSuffix(fields[2], "()"), ".")
+	for len(names) > 1 && strings.HasPrefix(names[len(names)-1], "func") {
+		names = names[:len(names)-1]
+	}
+	operation := strings.TrimSuffix(names[len(names)-1], "Handler")
+	if operation == "" {
+		return unknownLockOrigin
+	}
+	return operation
+}
+
+// newLockOriginBreakdown - counts the locks gathered from every
+// server, keyed by server address, per server, operation and lock
+// type. Busiest origins come first.
+func newLockOriginBreakdown(nodeLocks map[string][]VolumeLockInfo) []LockOrigin {
+	counts := make(map[LockOrigin]int64)
+	for addr, volLocks := range nodeLocks {
+		node := addr
+		if node == "" {
+			node = unknownLockOrigin
+		}
+		for _, volLock := range volLocks {
+			for _, state := range volLock.LockDetailsOnObject {
+				lType := string(state.LockType)
+				if lType == "" {
+					lType = unknownLockOrigin
+				}
+				origin := LockOrigin{Node: node, Operation: lockOperation(state.LockSource), Type: lType}
+				counts[origin]++
+			}
+		}
+	}
+
+	origins := []LockOrigin{}
+	for origin, count := range counts {
+		origin.Count = count
+		origins = append(origins, origin)
+	}
+	sort.Sort(byLockCount(origins))
+	return origins
+}
+
+// byLockCount - sorts lock origins by decreasing count, then by node,
+// operation and type.
+type byLockCount []LockOrigin
+
+func (b byLockCount) Len() int      { return len(b) }
+func (b byLockCount) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b byLockCount) Less(i, j int) bool {
+	if b[i].Count != b[j].Count {
+		return b[i].Count > b[j].Count
+	}
+	if b[i].Node != b[j].Node {
+		return b[i].Node < b[j].Node
+	}
+	if b[i].Operation != b[j].Operation {
+		return b[i].Operation < b[j].Operation
+	}
+	return b[i].Type < b[j].Type
+}
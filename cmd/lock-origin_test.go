@@ -0,0 +1,103 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestLockOperation - tests the operation taking a lock is found out
+// of its source.
+func TestLockOperation(t *testing.T) {
+	testCases := []struct {
+		source    string
+		operation string
+	}{
+		{"[object-handlers.go:97:objectAPIHandlers.GetObjectHandler()]", "GetObject"},
+		{"[xl-v1-object.go:420:(*xlObjects).PutObject()]", "PutObject"},
+		{"[xl-v1-healing.go:50:xlObjects.HealObject.func1()]", "HealObject"},
+		{"[main.go:10:main()]", "main"},
+		{"", unknownLockOrigin},
+		{"[<unknown>:0]", unknownLockOrigin},
+		{"[x.go:1:()]", unknownLockOrigin},
+	}
+	for i, testCase := range testCases {
+		if operation := lockOperation(testCase.source); operation != testCase.operation {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.operation, operation)
+		}
+	}
+
+	// Sources recorded by the namespace lock are understood.
+	initNSLock(false)
+	lock := globalNSMutex.NewNSLock("bucket", "object")
+	lock.RLock()
+	defer lock.RUnlock()
+	volLocks := listLocksInfo("bucket", "object", 0)
+	if len(volLocks) != 1 || len(volLocks[0].LockDetailsOnObject) != 1 {
+		t.Fatalf("Expected a single lock, got %v", volLocks)
+	}
+	if operation := lockOperation(volLocks[0].LockDetailsOnObject[0].LockSource); operation != "TestLockOperation" {
+		t.Fatalf("Expected TestLockOperation, got %s", operation)
+	}
+}
+
+// TestNewLockOriginBreakdown - tests locks are counted per server,
+// operation and type, locks of unknown origin included.
+func TestNewLockOriginBreakdown(t *testing.T) {
+	lockState := func(source string, lType lockType) OpsLockState {
+		return OpsLockState{LockSource: source, LockType: lType, Status: runningStatus}
+	}
+	getObject := "[object-handlers.go:97:objectAPIHandlers.GetObjectHandler()]"
+	putObject := "[object-handlers.go:480:objectAPIHandlers.PutObjectHandler()]"
+
+	nodeLocks := map[string][]VolumeLockInfo{
+		"server1:9000": {
+			{Bucket: "bucket", Object: "obj1", LockDetailsOnObject: []OpsLockState{
+				lockState(getObject, debugRLockStr),
+				lockState(getObject, debugRLockStr),
+				lockState(putObject, debugWLockStr),
+			}},
+			{Bucket: "bucket", Object: "obj2", LockDetailsOnObject: []OpsLockState{
+				lockState(getObject, debugRLockStr),
+				// Missing origin.
+				lockState("", ""),
+			}},
+		},
+		"server2:9000": {
+			{Bucket: "bucket", Object: "obj1", LockDetailsOnObject: []OpsLockState{
+				lockState(getObject, debugRLockStr),
+				lockState("", debugWLockStr),
+			}},
+		},
+		"server3:9000": {},
+	}
+	expected := []LockOrigin{
+		{Node: "server1:9000", Operation: "GetObject", Type: "RLock", Count: 3},
+		{Node: "server1:9000", Operation: "PutObject", Type: "WLock", Count: 1},
+		{Node: "server1:9000", Operation: unknownLockOrigin, Type: unknownLockOrigin, Count: 1},
+		{Node: "server2:9000", Operation: "GetObject", Type: "RLock", Count: 1},
+		{Node: "server2:9000", Operation: unknownLockOrigin, Type: "WLock", Count: 1},
+	}
+	if origins := newLockOriginBreakdown(nodeLocks); !reflect.DeepEqual(origins, expected) {
+		t.Fatalf("Expected %v, got %v", expected, origins)
+	}
+
+	if origins := newLockOriginBreakdown(nil); len(origins) != 0 {
+		t.Fatalf("Expected no lock origins, got %v", origins)
+	}
+}
@@ -112,3 +112,35 @@ func listLocksInfo(bucket, prefix string, duration time.Duration) []VolumeLockIn
 	}
 	return volumeLocks
 }
+
+// dumpLocksInfo - Fetches state of all locks held or waited upon by
+// operations on this server.
+func dumpLocksInfo() []VolumeLockInfo {
+	globalNSMutex.lockMapMutex.Lock()
+	defer globalNSMutex.lockMapMutex.Unlock()
+
+	timeNow := time.Now().UTC()
+	volumeLocks := []VolumeLockInfo{}
+	for param, debugLock := range globalNSMutex.debugLockMap {
+		volLockInfo := VolumeLockInfo{
+			Bucket:                param.volume,
+			Object:                param.path,
+			LocksOnObject:         debugLock.counters.total,
+			TotalBlockedLocks:     debugLock.counters.blocked,
+			LocksAcquiredOnObject: debugLock.counters.granted,
+		}
+		for opsID, lockInfo := range debugLock.lockInfo {
+			volLockInfo.LockDetailsOnObject = append(volLockInfo.LockDetailsOnObject,
+				OpsLockState{
+					OperationID: opsID,
+					LockSource:  lockInfo.lockSource,
+					LockType:    lockInfo.lType,
+					Status:      lockInfo.status,
+					Since:       lockInfo.since,
+					Duration:    timeNow.Sub(lockInfo.since),
+				})
+		}
+		volumeLocks = append(volumeLocks, volLockInfo)
+	}
+	return volumeLocks
+}
@@ -0,0 +1,344 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Maintenance schedule config name.
+const maintenanceScheduleConfig = "maintenance.json"
+
+// Actions taken during maintenance windows.
+const (
+	// Reject S3 requests writing to the object store.
+	maintenanceReadOnly = "read-only"
+	// Run usage scans without pausing between listing pages.
+	maintenanceScannerFast = "scanner-fast"
+)
+
+const maintenanceWeek = 7 * 24 * time.Hour
+
+// errInvalidMaintenanceSchedule - maintenance schedule is malformed, or
+// its windows overlap.
+var errInvalidMaintenanceSchedule = errors.New("Maintenance schedule is malformed or has overlapping windows")
+
+// MaintenanceWindow - recurring maintenance window, in UTC. Cron is a
+// cron-like "minute hour day-of-month month day-of-week" line of which
+// day-of-month and month must be "*", and day-of-week is "*" or a list
+// of days and ranges of days, 0 being sunday. The window lasts Duration
+// from every time Cron matches.
+type MaintenanceWindow struct {
+	Cron     string        `json:"cron"`
+	Duration time.Duration `json:"duration"`
+}
+
+// MaintenanceSchedule - recurring windows during which Action is taken
+// on all servers. No windows removes the schedule.
+type MaintenanceSchedule struct {
+	Windows []MaintenanceWindow `json:"windows"`
+	Action  string              `json:"action"`
+}
+
+// MaintenanceStatus - maintenance schedule of a server and whether one
+// of its windows is in progress.
+type MaintenanceStatus struct {
+	MaintenanceSchedule
+	Active bool `json:"active"`
+}
+
+// maintenanceSpan - an occurrence of a window, starting start after
+// the beginning of the week on sunday.
+type maintenanceSpan struct {
+	start    time.Duration
+	duration time.Duration
+}
+
+// parseMaintenanceCron - returns the starts of window within a week.
+func parseMaintenanceCron(cron string) ([]time.Duration, error) {
+	fields := strings.Fields(cron)
+	if len(fields) != 5 || fields[2] != "*" || fields[3] != "*" {
+		return nil, errInvalidMaintenanceSchedule
+	}
+	minute, err := strconv.Atoi(fields[0])
+	if err != nil || minute < 0 || minute > 59 {
+		return nil, errInvalidMaintenanceSchedule
+	}
+	hour, err := strconv.Atoi(fields[1])
+	if err != nil || hour < 0 || hour > 23 {
+		return nil, errInvalidMaintenanceSchedule
+	}
+
+	days := make(map[int]bool)
+	if fields[4] == "*" {
+		fields[4] = "0-6"
+	}
+	for _, field := range strings.Split(fields[4], ",") {
+		first, last := field, field
+		if i := strings.Index(field, "-"); i != -1 {
+			first, last = field[:i], field[i+1:]
+		}
+		from, fErr := strconv.Atoi(first)
+		to, tErr := strconv.Atoi(last)
+		if fErr != nil || tErr != nil || from < 0 || to > 6 || from > to {
+			return nil, errInvalidMaintenanceSchedule
+		}
+		for day := from; day <= to; day++ {
+			days[day] = true
+		}
+	}
+
+	starts := make([]time.Duration, 0, len(days))
+	for day := range days {
+		starts = append(starts, time.Duration(day)*24*time.Hour+time.Duration(hour)*time.Hour+time.Duration(minute)*time.Minute)
+	}
+	return starts, nil
+}
+
+// maintenanceSpans - returns the occurrences of the windows of
+// schedule within a week, ordered by start, or
+// errInvalidMaintenanceSchedule if schedule is malformed or any two
+// occurrences overlap.
+func maintenanceSpans(schedule MaintenanceSchedule) ([]maintenanceSpan, error) {
+	if len(schedule.Windows) == 0 {
+		return nil, nil
+	}
+	if schedule.Action != maintenanceReadOnly && schedule.Action != maintenanceScannerFast {
+		return nil, errInvalidMaintenanceSchedule
+	}
+
+	var spans []maintenanceSpan
+	for _, window := range schedule.Windows {
+		if window.Duration < time.Minute || window.Duration >= maintenanceWeek {
+			return nil, errInvalidMaintenanceSchedule
+		}
+		starts, err := parseMaintenanceCron(window.Cron)
+		if err != nil {
+			return nil, err
+		}
+		for _, start := range starts {
+			spans = append(spans, maintenanceSpan{start, window.Duration})
+		}
+	}
+	sort.Sort(bySpanStart(spans))
+
+	// Spans are ordered by start, a span overlapping any other overlaps
+	// the next one, the last one wrapping around to the first.
+	for i, span := range spans {
+		next := spans[(i+1)%len(spans)].start
+		if i == len(spans)-1 {
+			next += maintenanceWeek
+		}
+		if span.start+span.duration > next {
+			return nil, errInvalidMaintenanceSchedule
+		}
+	}
+	return spans, nil
+}
+
+// maintenance - maintenance schedule of this server. Whether a window
+// is in progress is worked out from the clock on every check, a server
+// restarting within a window enters or leaves maintenance on time.
+type maintenance struct {
+	mutex    sync.RWMutex
+	schedule MaintenanceSchedule
+	spans    []maintenanceSpan
+}
+
+// newMaintenance - returns a maintenance following schedule.
+func newMaintenance(schedule MaintenanceSchedule) (*maintenance, error) {
+	m := &maintenance{}
+	if err := m.set(schedule); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var globalMaintenance = &maintenance{}
+
+// get - returns the current schedule.
+func (m *maintenance) get() MaintenanceSchedule {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.schedule
+}
+
+// set - replaces the schedule, no windows removes it.
+func (m *maintenance) set(schedule MaintenanceSchedule) error {
+	spans, err := maintenanceSpans(schedule)
+	if err != nil {
+		return err
+	}
+	if len(spans) == 0 {
+		schedule = MaintenanceSchedule{}
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.schedule = schedule
+	m.spans = spans
+	return nil
+}
+
+// active - returns true if a window taking action is in progress at
+// now.
+func (m *maintenance) active(action string, now time.Time) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.schedule.Action != action {
+		return false
+	}
+	// Time since the beginning of the week, on sunday.
+	now = now.UTC()
+	sinceSunday := time.Duration(now.Weekday())*24*time.Hour +
+		time.Duration(now.Hour())*time.Hour +
+		time.Duration(now.Minute())*time.Minute +
+		time.Duration(now.Second())*time.Second
+	for _, span := range m.spans {
+		if (sinceSunday-span.start+maintenanceWeek)%maintenanceWeek < span.duration {
+			return true
+		}
+	}
+	return false
+}
+
+// status - returns the schedule and whether a window is in progress
+// at now.
+func (m *maintenance) status(now time.Time) MaintenanceStatus {
+	schedule := m.get()
+	return MaintenanceStatus{
+		MaintenanceSchedule: schedule,
+		Active:              m.active(schedule.Action, now),
+	}
+}
+
+// readMaintenanceSchedule - reads the persisted maintenance schedule,
+// no windows if none was set.
+func readMaintenanceSchedule(objAPI ObjectLayer) (MaintenanceSchedule, error) {
+	// Acquire a read lock on maintenance config before reading.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, maintenanceScheduleConfig)
+	objLock.RLock()
+	defer objLock.RUnlock()
+
+	var buffer bytes.Buffer
+	if err := objAPI.GetObject(minioMetaBucket, maintenanceScheduleConfig, 0, -1, &buffer); err != nil {
+		if isErrObjectNotFound(err) || isErrIncompleteBody(err) {
+			return MaintenanceSchedule{}, nil
+		}
+		errorIf(err, "Unable to load maintenance schedule.")
+		return MaintenanceSchedule{}, errorCause(err)
+	}
+
+	var schedule MaintenanceSchedule
+	if err := json.Unmarshal(buffer.Bytes(), &schedule); err != nil {
+		return MaintenanceSchedule{}, err
+	}
+	return schedule, nil
+}
+
+// writeMaintenanceSchedule - persists the maintenance schedule, no
+// windows removes any previously persisted schedule.
+func writeMaintenanceSchedule(objAPI ObjectLayer, schedule MaintenanceSchedule) error {
+	// Acquire a write lock on maintenance config before modifying.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, maintenanceScheduleConfig)
+	objLock.Lock()
+	defer objLock.Unlock()
+
+	if len(schedule.Windows) == 0 {
+		if err := objAPI.DeleteObject(minioMetaBucket, maintenanceScheduleConfig); err != nil && !isErrObjectNotFound(err) {
+			errorIf(err, "Unable to remove maintenance schedule.")
+			return errorCause(err)
+		}
+		return nil
+	}
+
+	buf, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	if _, err = objAPI.PutObject(minioMetaBucket, maintenanceScheduleConfig, int64(len(buf)), bytes.NewReader(buf), nil, ""); err != nil {
+		errorIf(err, "Unable to set maintenance schedule.")
+		return errorCause(err)
+	}
+	return nil
+}
+
+// initMaintenanceSchedule - loads the maintenance schedule.
+func initMaintenanceSchedule(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	schedule, err := readMaintenanceSchedule(objAPI)
+	if err != nil {
+		if isErrIgnored(err, errDiskNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	m, err := newMaintenance(schedule)
+	if err != nil {
+		return err
+	}
+	globalMaintenance = m
+	return nil
+}
+
+// isWriteRequest - returns true if r is an S3 request writing to the
+// object store, every PUT, POST and DELETE.
+func isWriteRequest(r *http.Request) bool {
+	switch r.Method {
+	case httpPUT, httpPOST, httpDELETE:
+		return true
+	}
+	return false
+}
+
+// maintenanceHandler - rejects S3 requests writing to the object store
+// while a read-only maintenance window is in progress. Admin, browser
+// and inter-node RPC requests are served.
+type maintenanceHandler struct {
+	handler http.Handler
+}
+
+func setMaintenanceHandler(h http.Handler) http.Handler {
+	return maintenanceHandler{handler: h}
+}
+
+func (h maintenanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !hasPrefix(r.URL.Path, minioReservedBucketPath+"/") && isWriteRequest(r) &&
+		globalMaintenance.active(maintenanceReadOnly, time.Now()) {
+		writeErrorResponse(w, ErrMaintenanceReadOnly, r.URL)
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// bySpanStart - sorts maintenance spans by start.
+type bySpanStart []maintenanceSpan
+
+func (s bySpanStart) Len() int           { return len(s) }
+func (s bySpanStart) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s bySpanStart) Less(i, j int) bool { return s[i].start < s[j].start }
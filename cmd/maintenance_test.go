@@ -0,0 +1,224 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestMaintenanceSpans - tests malformed and overlapping schedules are
+// rejected.
+func TestMaintenanceSpans(t *testing.T) {
+	window := func(cron string, duration time.Duration) MaintenanceWindow {
+		return MaintenanceWindow{Cron: cron, Duration: duration}
+	}
+	testCases := []struct {
+		windows []MaintenanceWindow
+		action  string
+		spans   int
+		valid   bool
+	}{
+		// No windows removes the schedule.
+		{nil, "", 0, true},
+		{[]MaintenanceWindow{window("0 2 * * 6", 2*time.Hour)}, maintenanceReadOnly, 1, true},
+		{[]MaintenanceWindow{window("30 1 * * 1-5", time.Hour), window("0 4 * * 0,6", 3*time.Hour)}, maintenanceScannerFast, 7, true},
+		// Back to back daily windows cover the whole week.
+		{[]MaintenanceWindow{window("0 0 * * *", 24*time.Hour)}, maintenanceReadOnly, 7, true},
+		// Malformed.
+		{[]MaintenanceWindow{window("0 2 * * 6", 2*time.Hour)}, "freeze", 0, false},
+		{[]MaintenanceWindow{window("0 2 * * 6", 0)}, maintenanceReadOnly, 0, false},
+		{[]MaintenanceWindow{window("0 2 * * 6", 7*24*time.Hour)}, maintenanceReadOnly, 0, false},
+		{[]MaintenanceWindow{window("0 2 * *", time.Hour)}, maintenanceReadOnly, 0, false},
+		{[]MaintenanceWindow{window("0 2 1 * 6", time.Hour)}, maintenanceReadOnly, 0, false},
+		{[]MaintenanceWindow{window("60 2 * * 6", time.Hour)}, maintenanceReadOnly, 0, false},
+		{[]MaintenanceWindow{window("0 24 * * 6", time.Hour)}, maintenanceReadOnly, 0, false},
+		{[]MaintenanceWindow{window("0 2 * * 7", time.Hour)}, maintenanceReadOnly, 0, false},
+		{[]MaintenanceWindow{window("0 2 * * 5-1", time.Hour)}, maintenanceReadOnly, 0, false},
+		{[]MaintenanceWindow{window("*/5 2 * * 6", time.Hour)}, maintenanceReadOnly, 0, false},
+		// Overlapping.
+		{[]MaintenanceWindow{window("0 0 * * *", 25*time.Hour)}, maintenanceReadOnly, 0, false},
+		{[]MaintenanceWindow{window("0 2 * * 6", 2*time.Hour), window("0 3 * * 6", time.Hour)}, maintenanceReadOnly, 0, false},
+		// Saturday night into sunday morning, wrapping around the week.
+		{[]MaintenanceWindow{window("0 23 * * 6", 2*time.Hour), window("30 0 * * 0", time.Hour)}, maintenanceReadOnly, 0, false},
+	}
+
+	for i, testCase := range testCases {
+		spans, err := maintenanceSpans(MaintenanceSchedule{Windows: testCase.windows, Action: testCase.action})
+		if testCase.valid != (err == nil) {
+			t.Fatalf("Test %d: expected valid %v, got %v", i+1, testCase.valid, err)
+		}
+		if len(spans) != testCase.spans {
+			t.Fatalf("Test %d: expected %d spans, got %d", i+1, testCase.spans, len(spans))
+		}
+	}
+}
+
+// TestMaintenanceActive - tests the action is taken within windows
+// only.
+func TestMaintenanceActive(t *testing.T) {
+	m, err := newMaintenance(MaintenanceSchedule{
+		Windows: []MaintenanceWindow{
+			{Cron: "0 2 * * 3", Duration: 2 * time.Hour},
+			{Cron: "0 23 * * 6", Duration: 2 * time.Hour},
+		},
+		Action: maintenanceReadOnly,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2017-01-04 is a wednesday, 2017-01-07 a saturday.
+	testCases := []struct {
+		now    time.Time
+		active bool
+	}{
+		{time.Date(2017, 1, 4, 1, 59, 59, 0, time.UTC), false},
+		{time.Date(2017, 1, 4, 2, 0, 0, 0, time.UTC), true},
+		{time.Date(2017, 1, 4, 3, 59, 59, 0, time.UTC), true},
+		{time.Date(2017, 1, 4, 4, 0, 0, 0, time.UTC), false},
+		{time.Date(2017, 1, 5, 2, 30, 0, 0, time.UTC), false},
+		// Same instant in another time zone.
+		{time.Date(2017, 1, 4, 4, 30, 0, 0, time.FixedZone("EET", 2*60*60)), true},
+		// Across the end of the week.
+		{time.Date(2017, 1, 7, 23, 30, 0, 0, time.UTC), true},
+		{time.Date(2017, 1, 8, 0, 59, 59, 0, time.UTC), true},
+		{time.Date(2017, 1, 8, 1, 0, 0, 0, time.UTC), false},
+	}
+	for i, testCase := range testCases {
+		if active := m.active(maintenanceReadOnly, testCase.now); active != testCase.active {
+			t.Fatalf("Test %d: expected active %v at %v, got %v", i+1, testCase.active, testCase.now, active)
+		}
+		if m.active(maintenanceScannerFast, testCase.now) {
+			t.Fatalf("Test %d: expected no scanner-fast window", i+1)
+		}
+	}
+
+	// Removing the schedule ends the window.
+	if err = m.set(MaintenanceSchedule{}); err != nil {
+		t.Fatal(err)
+	}
+	if m.active(maintenanceReadOnly, testCases[1].now) {
+		t.Fatal("Expected no window once the schedule is removed")
+	}
+}
+
+// TestMaintenanceScheduleRestart - tests a server restarting within a
+// window loads the schedule and leaves maintenance once it is over.
+func TestMaintenanceScheduleRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalMaintenance()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	schedule := MaintenanceSchedule{
+		Windows: []MaintenanceWindow{{Cron: "0 2 * * 3", Duration: 2 * time.Hour}},
+		Action:  maintenanceReadOnly,
+	}
+	if err = writeMaintenanceSchedule(objLayer, schedule); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, the schedule is loaded again.
+	resetGlobalMaintenance()
+	if err = initMaintenanceSchedule(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if !globalMaintenance.status(time.Date(2017, 1, 4, 3, 0, 0, 0, time.UTC)).Active {
+		t.Fatal("Expected the window to be in progress after restart")
+	}
+	if globalMaintenance.status(time.Date(2017, 1, 4, 4, 0, 0, 0, time.UTC)).Active {
+		t.Fatal("Expected the window to be over")
+	}
+
+	// Removing the schedule removes it across restarts.
+	if err = writeMaintenanceSchedule(objLayer, MaintenanceSchedule{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = initMaintenanceSchedule(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if windows := globalMaintenance.get().Windows; len(windows) != 0 {
+		t.Fatalf("Expected no windows, got %v", windows)
+	}
+}
+
+// TestMaintenanceHandler - tests S3 writes are rejected within
+// read-only windows, and reads, admin requests and writes outside of
+// windows are served.
+func TestMaintenanceHandler(t *testing.T) {
+	defer resetGlobalMaintenance()
+
+	handler := setMaintenanceHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(method, path string) int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w.Code
+	}
+
+	if code := serve(httpPUT, "/bucket/object"); code != http.StatusOK {
+		t.Fatalf("Expected writes to be served without a schedule, got %d", code)
+	}
+
+	// A window all week long.
+	if err := globalMaintenance.set(MaintenanceSchedule{
+		Windows: []MaintenanceWindow{{Cron: "0 0 * * *", Duration: 24 * time.Hour}},
+		Action:  maintenanceReadOnly,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{httpPUT, "/bucket/object", http.StatusServiceUnavailable},
+		{httpPOST, "/bucket/object?uploads", http.StatusServiceUnavailable},
+		{httpDELETE, "/bucket/object", http.StatusServiceUnavailable},
+		{httpGET, "/bucket/object", http.StatusOK},
+		{httpHEAD, "/bucket", http.StatusOK},
+		{httpPOST, "/minio/admin/v1/config", http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		if code := serve(testCase.method, testCase.path); code != testCase.code {
+			t.Fatalf("Test %d: expected %d for %s %s, got %d", i+1, testCase.code, testCase.method, testCase.path, code)
+		}
+	}
+
+	// Scanner-fast windows do not reject writes.
+	if err := globalMaintenance.set(MaintenanceSchedule{
+		Windows: []MaintenanceWindow{{Cron: "0 0 * * *", Duration: 24 * time.Hour}},
+		Action:  maintenanceScannerFast,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if code := serve(httpPUT, "/bucket/object"); code != http.StatusOK {
+		t.Fatalf("Expected writes to be served within a scanner-fast window, got %d", code)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync/atomic"
+)
+
+// Bytes of a rejected complete multipart upload manifest which are
+// read and discarded, so that the client gets the error response and
+// the connection can be reused. Connections sending more are closed.
+const completeMultipartDrainLimit = 1024 * 1024 // 1MiB.
+
+// errTooManyParts - a complete multipart upload manifest lists more
+// parts than allowed.
+var errTooManyParts = errors.New("Number of parts exceeds the maximum allowed")
+
+// Maximum number of parts accepted in a complete multipart upload
+// manifest, can be lowered at runtime via admin RPC.
+var globalMaxUploadParts = int64(maxPartID)
+
+// getMaxUploadParts - returns the current limit of parts per upload.
+func getMaxUploadParts() int {
+	return int(atomic.LoadInt64(&globalMaxUploadParts))
+}
+
+// setMaxUploadParts - sets the limit of parts per upload, the limit
+// must be positive and can not exceed the S3 maximum of 10000 parts.
+func setMaxUploadParts(parts int) error {
+	if parts <= 0 || parts > maxPartID {
+		return errInvalidArgument
+	}
+	atomic.StoreInt64(&globalMaxUploadParts, int64(parts))
+	return nil
+}
+
+// decodeCompleteMultipartUpload - decodes a complete multipart upload
+// manifest from reader one part at a time. errTooManyParts is returned
+// as soon as more than maxParts parts are found, without reading the
+// rest of the manifest.
+func decodeCompleteMultipartUpload(reader io.Reader, maxParts int) (*completeMultipartUpload, error) {
+	decoder := xml.NewDecoder(reader)
+	upload := &completeMultipartUpload{}
+	inRoot := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF && inRoot {
+				// Unterminated root element.
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		switch elem := token.(type) {
+		case xml.StartElement:
+			if !inRoot {
+				inRoot = true
+				continue
+			}
+			if elem.Name.Local != "Part" {
+				if err = decoder.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if len(upload.Parts) >= maxParts {
+				return nil, errTooManyParts
+			}
+			var part completePart
+			if err = decoder.DecodeElement(&part, &elem); err != nil {
+				return nil, err
+			}
+			upload.Parts = append(upload.Parts, part)
+		case xml.EndElement:
+			// Only the end of the root element is left, nested
+			// elements were consumed whole.
+			return upload, nil
+		}
+	}
+}
+
+// drainRequestBody - discards up to completeMultipartDrainLimit bytes
+// left in body and closes it.
+func drainRequestBody(body io.ReadCloser) {
+	io.CopyN(ioutil.Discard, body, completeMultipartDrainLimit)
+	body.Close()
+}
@@ -0,0 +1,173 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests decoding complete multipart upload manifests.
+func TestDecodeCompleteMultipartUpload(t *testing.T) {
+	// Stands for any decoding error.
+	errMalformedManifest := errors.New("malformed manifest")
+
+	testCases := []struct {
+		manifest string
+		maxParts int
+		parts    []completePart
+		err      error
+	}{
+		{
+			`<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>"a"</ETag></Part>` +
+				`<Part><PartNumber>2</PartNumber><ETag>b</ETag></Part></CompleteMultipartUpload>`,
+			2, []completePart{{PartNumber: 1, ETag: `"a"`}, {PartNumber: 2, ETag: "b"}}, nil,
+		},
+		// Unknown elements are skipped along with their children.
+		{
+			`<?xml version="1.0"?>` + "\n" + `<CompleteMultipartUpload xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` +
+				`<Other><Part><PartNumber>9</PartNumber></Part></Other>` +
+				`<Part><PartNumber>1</PartNumber><ETag>a</ETag></Part></CompleteMultipartUpload>`,
+			1, []completePart{{PartNumber: 1, ETag: "a"}}, nil,
+		},
+		{`<CompleteMultipartUpload></CompleteMultipartUpload>`, 1, nil, nil},
+		// Truncated manifests.
+		{`<CompleteMultipartUpload><Part><PartNumber>1</PartNumber>`, 1, nil, errMalformedManifest},
+		{`<CompleteMultipartUpload>`, 1, nil, errMalformedManifest},
+		{``, 1, nil, errMalformedManifest},
+		{
+			`<CompleteMultipartUpload><Part><PartNumber>1</PartNumber></Part>` +
+				`<Part><PartNumber>2</PartNumber></Part><Part><PartNumber>3</PartNumber></Part></CompleteMultipartUpload>`,
+			2, nil, errTooManyParts,
+		},
+	}
+	for i, testCase := range testCases {
+		upload, err := decodeCompleteMultipartUpload(strings.NewReader(testCase.manifest), testCase.maxParts)
+		if testCase.err == errMalformedManifest {
+			if err == nil || err == errTooManyParts {
+				t.Errorf("Test %d: expected a decoding error, got %v", i+1, err)
+			}
+			continue
+		}
+		if err != testCase.err {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(upload.Parts, testCase.parts) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.parts, upload.Parts)
+		}
+	}
+}
+
+// partsManifest - reader of a complete multipart upload manifest
+// listing parts parts, generated as it is read. Counts the bytes read
+// and whether it was closed.
+type partsManifest struct {
+	reader io.Reader
+	read   int64
+	closed bool
+}
+
+func newPartsManifest(parts int) *partsManifest {
+	readers := []io.Reader{strings.NewReader("<CompleteMultipartUpload>")}
+	for i := 1; i <= parts; i++ {
+		readers = append(readers, strings.NewReader(fmt.Sprintf(`<Part><PartNumber>%d</PartNumber><ETag>"%032x"</ETag></Part>`, i, i)))
+	}
+	readers = append(readers, strings.NewReader("</CompleteMultipartUpload>"))
+	return &partsManifest{reader: io.MultiReader(readers...)}
+}
+
+func (m *partsManifest) Read(p []byte) (int, error) {
+	n, err := m.reader.Read(p)
+	m.read += int64(n)
+	return n, err
+}
+
+func (m *partsManifest) Close() error {
+	m.closed = true
+	return nil
+}
+
+// Tests oversized manifests are rejected before being read whole, and
+// what is left of them is drained up to the limit.
+func TestDecodeCompleteMultipartUploadEarlyRejection(t *testing.T) {
+	parts := 100000
+	manifest := newPartsManifest(parts)
+	if _, err := decodeCompleteMultipartUpload(manifest, 10); err != errTooManyParts {
+		t.Fatalf("Expected %v, got %v", errTooManyParts, err)
+	}
+	// Only the first parts and the decoder's read ahead are read.
+	if manifest.read > 64*1024 {
+		t.Fatalf("Expected early rejection, but %d bytes were read", manifest.read)
+	}
+
+	read := manifest.read
+	drainRequestBody(manifest)
+	if !manifest.closed {
+		t.Fatal("Expected body to be closed")
+	}
+	if drained := manifest.read - read; drained != completeMultipartDrainLimit {
+		t.Fatalf("Expected %d bytes to be drained, got %d", completeMultipartDrainLimit, drained)
+	}
+
+	// Manifests shorter than the limit are drained whole.
+	manifest = newPartsManifest(100)
+	if _, err := decodeCompleteMultipartUpload(manifest, 10); err != errTooManyParts {
+		t.Fatalf("Expected %v, got %v", errTooManyParts, err)
+	}
+	drainRequestBody(manifest)
+	if n, _ := manifest.Read(make([]byte, 1)); n != 0 || !manifest.closed {
+		t.Fatal("Expected body to be drained and closed")
+	}
+
+	// Manifests within the limit are decoded whole.
+	manifest = newPartsManifest(maxPartID)
+	upload, err := decodeCompleteMultipartUpload(manifest, maxPartID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(upload.Parts) != maxPartID {
+		t.Fatalf("Expected %d parts, got %d", maxPartID, len(upload.Parts))
+	}
+}
+
+// Tests setting the limit of parts per upload.
+func TestSetMaxUploadParts(t *testing.T) {
+	defer resetGlobalMaxUploadParts()
+
+	for _, parts := range []int{0, -1, maxPartID + 1} {
+		if err := setMaxUploadParts(parts); err != errInvalidArgument {
+			t.Errorf("%d parts: expected %v, got %v", parts, errInvalidArgument, err)
+		}
+	}
+	if getMaxUploadParts() != maxPartID {
+		t.Fatalf("Expected %d, got %d", maxPartID, getMaxUploadParts())
+	}
+	if err := setMaxUploadParts(100); err != nil {
+		t.Fatal(err)
+	}
+	if getMaxUploadParts() != 100 {
+		t.Fatalf("Expected %d, got %d", 100, getMaxUploadParts())
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"runtime"
+	"sort"
+	"time"
+)
+
+const (
+	// Allocation sites reported per server.
+	memSummaryTopSites = 10
+
+	// Most recent GC pauses reported per server.
+	memSummaryGCPauses = 10
+)
+
+// AllocSite - code allocating memory still in use, as sampled by the
+// runtime memory profiler.
+type AllocSite struct {
+	Function     string `json:"function"`
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+	InUseBytes   int64  `json:"inUseBytes"`
+	InUseObjects int64  `json:"inUseObjects"`
+}
+
+// MemStats - summary of the memory used by a server.
+type MemStats struct {
+	Addr      string `json:"addr"`
+	HeapAlloc uint64 `json:"heapAlloc"`
+	HeapSys   uint64 `json:"heapSys"`
+	NumGC     uint32 `json:"numGC"`
+	// Most recent GC pauses, latest first.
+	GCPauses []time.Duration `json:"gcPauses"`
+	// Allocation sites using the most memory, largest first.
+	TopSites []AllocSite `json:"topSites"`
+}
+
+// memSource - source of the memory statistics of a server and of the
+// allocations sampled by the memory profiler, one per stack.
+type memSource interface {
+	readMemStats(m *runtime.MemStats)
+	allocSamples() []AllocSite
+}
+
+// runtimeMemSource - memory source of this server. Neither reading the
+// statistics nor the profile forces a GC, the profile is as of the
+// latest GC.
+type runtimeMemSource struct{}
+
+func (runtimeMemSource) readMemStats(m *runtime.MemStats) {
+	runtime.ReadMemStats(m)
+}
+
+func (runtimeMemSource) allocSamples() []AllocSite {
+	// Allocations may be sampled between both calls, retry with
+	// more room until all records fit.
+	n, _ := runtime.MemProfile(nil, false)
+	var records []runtime.MemProfileRecord
+	for {
+		records = make([]runtime.MemProfileRecord, n+50)
+		var ok bool
+		if n, ok = runtime.MemProfile(records, false); ok {
+			records = records[:n]
+			break
+		}
+	}
+
+	samples := make([]AllocSite, 0, len(records))
+	for _, record := range records {
+		// The site is the innermost frame of the stack.
+		frame, _ := runtime.CallersFrames(record.Stack()).Next()
+		samples = append(samples, AllocSite{
+			Function:     frame.Function,
+			File:         frame.File,
+			Line:         frame.Line,
+			InUseBytes:   record.InUseBytes(),
+			InUseObjects: record.InUseObjects(),
+		})
+	}
+	return samples
+}
+
+// byInUseBytes - sorts allocation sites using the most memory first.
+type byInUseBytes []AllocSite
+
+func (s byInUseBytes) Len() int      { return len(s) }
+func (s byInUseBytes) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byInUseBytes) Less(i, j int) bool {
+	if s[i].InUseBytes != s[j].InUseBytes {
+		return s[i].InUseBytes > s[j].InUseBytes
+	}
+	return s[i].Function < s[j].Function
+}
+
+// newMemStats - summarizes the memory of source, merging samples of
+// the same site reached through different stacks.
+func newMemStats(source memSource) MemStats {
+	var m runtime.MemStats
+	source.readMemStats(&m)
+	stats := MemStats{
+		HeapAlloc: m.HeapAlloc,
+		HeapSys:   m.HeapSys,
+		NumGC:     m.NumGC,
+		GCPauses:  []time.Duration{},
+		TopSites:  []AllocSite{},
+	}
+
+	// PauseNs is a circular buffer, the latest pause is at
+	// (NumGC+255)%256.
+	for i := uint32(0); i < m.NumGC && i < memSummaryGCPauses; i++ {
+		pause := m.PauseNs[(m.NumGC-1-i)%uint32(len(m.PauseNs))]
+		stats.GCPauses = append(stats.GCPauses, time.Duration(pause))
+	}
+
+	type siteKey struct {
+		function, file string
+		line           int
+	}
+	sites := make(map[siteKey]int)
+	for _, sample := range source.allocSamples() {
+		key := siteKey{sample.Function, sample.File, sample.Line}
+		if idx, ok := sites[key]; ok {
+			stats.TopSites[idx].InUseBytes += sample.InUseBytes
+			stats.TopSites[idx].InUseObjects += sample.InUseObjects
+			continue
+		}
+		sites[key] = len(stats.TopSites)
+		stats.TopSites = append(stats.TopSites, sample)
+	}
+	sort.Sort(byInUseBytes(stats.TopSites))
+	if len(stats.TopSites) > memSummaryTopSites {
+		stats.TopSites = stats.TopSites[:memSummaryTopSites]
+	}
+	return stats
+}
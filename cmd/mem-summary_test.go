@@ -0,0 +1,105 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// memSourceStub - memory source with fixed statistics and samples.
+type memSourceStub struct {
+	stats   runtime.MemStats
+	samples []AllocSite
+}
+
+func (s memSourceStub) readMemStats(m *runtime.MemStats) {
+	*m = s.stats
+}
+
+func (s memSourceStub) allocSamples() []AllocSite {
+	return s.samples
+}
+
+// TestNewMemStats - tests the heap figures, GC pauses and the ranking
+// of allocation sites.
+func TestNewMemStats(t *testing.T) {
+	source := memSourceStub{}
+	source.stats.HeapAlloc = 1 << 20
+	source.stats.HeapSys = 4 << 20
+	source.stats.NumGC = 3
+	source.stats.PauseNs[0] = 100
+	source.stats.PauseNs[1] = 200
+	source.stats.PauseNs[2] = 300
+	source.samples = []AllocSite{
+		{Function: "cmd.small", File: "small.go", Line: 1, InUseBytes: 10, InUseObjects: 1},
+		{Function: "cmd.large", File: "large.go", Line: 2, InUseBytes: 500, InUseObjects: 5},
+		// Same site reached through another stack.
+		{Function: "cmd.medium", File: "medium.go", Line: 3, InUseBytes: 200, InUseObjects: 2},
+		{Function: "cmd.medium", File: "medium.go", Line: 3, InUseBytes: 400, InUseObjects: 4},
+	}
+
+	stats := newMemStats(source)
+	if stats.HeapAlloc != 1<<20 || stats.HeapSys != 4<<20 || stats.NumGC != 3 {
+		t.Fatalf("Unexpected heap figures %#v", stats)
+	}
+	if expected := []time.Duration{300, 200, 100}; !reflect.DeepEqual(stats.GCPauses, expected) {
+		t.Fatalf("Expected GC pauses %v, got %v", expected, stats.GCPauses)
+	}
+	expected := []AllocSite{
+		{Function: "cmd.medium", File: "medium.go", Line: 3, InUseBytes: 600, InUseObjects: 6},
+		{Function: "cmd.large", File: "large.go", Line: 2, InUseBytes: 500, InUseObjects: 5},
+		{Function: "cmd.small", File: "small.go", Line: 1, InUseBytes: 10, InUseObjects: 1},
+	}
+	if !reflect.DeepEqual(stats.TopSites, expected) {
+		t.Fatalf("Expected sites %v, got %v", expected, stats.TopSites)
+	}
+
+	// Only the top sites and latest pauses are reported, PauseNs
+	// wraps around.
+	source.stats.NumGC = uint32(len(source.stats.PauseNs)) + 1
+	source.stats.PauseNs[0] = 1000
+	source.samples = nil
+	for i := 0; i < 2*memSummaryTopSites; i++ {
+		source.samples = append(source.samples, AllocSite{Function: fmt.Sprintf("cmd.f%02d", i), InUseBytes: int64(i)})
+	}
+	stats = newMemStats(source)
+	if len(stats.GCPauses) != memSummaryGCPauses || stats.GCPauses[0] != 1000 {
+		t.Fatalf("Expected %d pauses, latest 1000, got %v", memSummaryGCPauses, stats.GCPauses)
+	}
+	if len(stats.TopSites) != memSummaryTopSites || stats.TopSites[0].InUseBytes != 2*memSummaryTopSites-1 {
+		t.Fatalf("Expected %d top sites, got %v", memSummaryTopSites, stats.TopSites)
+	}
+}
+
+// TestRuntimeMemSourceNoGC - tests summarizing the memory of this
+// server does not force a GC.
+func TestRuntimeMemSourceNoGC(t *testing.T) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	stats := newMemStats(runtimeMemSource{})
+	runtime.ReadMemStats(&after)
+	if after.NumForcedGC != before.NumForcedGC {
+		t.Fatalf("Expected no forced GC, got %d", after.NumForcedGC-before.NumForcedGC)
+	}
+	if stats.HeapAlloc == 0 || stats.HeapSys == 0 {
+		t.Fatalf("Expected heap figures, got %#v", stats)
+	}
+}
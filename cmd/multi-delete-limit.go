@@ -0,0 +1,78 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "sync/atomic"
+
+// Maximum number of keys of a multi-delete request allowed by S3.
+// http://docs.aws.amazon.com/AmazonS3/latest/API/multiobjectdeleteapi.html
+const maxDeleteObjects = 1000
+
+// Multi-delete limit config name.
+const multiDeleteLimitConfig = "multi-delete-limit.json"
+
+// Maximum number of keys of a multi-delete request, can be lowered at
+// runtime via admin RPC. Never above maxDeleteObjects.
+var globalMultiDeleteLimit int64 = maxDeleteObjects
+
+// getMultiDeleteLimit - returns the current multi-delete limit.
+func getMultiDeleteLimit() int {
+	return int(atomic.LoadInt64(&globalMultiDeleteLimit))
+}
+
+// setMultiDeleteLimit - sets the multi-delete limit, which must be
+// within 1 and maxDeleteObjects.
+func setMultiDeleteLimit(maxKeys int) error {
+	if maxKeys < 1 || maxKeys > maxDeleteObjects {
+		return errInvalidArgument
+	}
+	atomic.StoreInt64(&globalMultiDeleteLimit, int64(maxKeys))
+	return nil
+}
+
+// writeMultiDeleteLimit - persists the multi-delete limit, the S3
+// maximum removes any previously persisted limit.
+func writeMultiDeleteLimit(objAPI ObjectLayer, maxKeys int) error {
+	if maxKeys < 1 || maxKeys > maxDeleteObjects {
+		return errInvalidArgument
+	}
+	if maxKeys == maxDeleteObjects {
+		return writeServerSetting(objAPI, multiDeleteLimitConfig, nil)
+	}
+	return writeServerSetting(objAPI, multiDeleteLimitConfig, MultiDeleteLimit{MaxKeys: maxKeys})
+}
+
+// initMultiDeleteLimit - loads the multi-delete limit, so that a
+// restarted server rejects the same requests as its peers.
+func initMultiDeleteLimit(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	var limit MultiDeleteLimit
+	ok, err := readServerSetting(objAPI, multiDeleteLimitConfig, &limit)
+	if err != nil {
+		if isErrIgnored(err, errDiskNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	return setMultiDeleteLimit(limit.MaxKeys)
+}
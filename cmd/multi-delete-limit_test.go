@@ -0,0 +1,155 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// TestSetMultiDeleteLimit - tests the limit can not exceed what S3
+// allows.
+func TestSetMultiDeleteLimit(t *testing.T) {
+	defer resetGlobalMultiDeleteLimit()
+
+	if limit := getMultiDeleteLimit(); limit != maxDeleteObjects {
+		t.Fatalf("Expected a default limit of %d, got %d", maxDeleteObjects, limit)
+	}
+	for _, maxKeys := range []int{-1, 0, maxDeleteObjects + 1} {
+		if err := setMultiDeleteLimit(maxKeys); err != errInvalidArgument {
+			t.Fatalf("Expected %v for %d, got %v", errInvalidArgument, maxKeys, err)
+		}
+	}
+	if err := setMultiDeleteLimit(10); err != nil {
+		t.Fatal(err)
+	}
+	if limit := getMultiDeleteLimit(); limit != 10 {
+		t.Fatalf("Expected a limit of 10, got %d", limit)
+	}
+}
+
+// TestMultiDeleteLimitRestart - tests the multi-delete limit is loaded
+// again by a restarted server.
+func TestMultiDeleteLimitRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalMultiDeleteLimit()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	if err = writeMultiDeleteLimit(objLayer, maxDeleteObjects+1); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+	if err = writeMultiDeleteLimit(objLayer, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, the limit is loaded again.
+	resetGlobalMultiDeleteLimit()
+	if err = initMultiDeleteLimit(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if limit := getMultiDeleteLimit(); limit != 10 {
+		t.Fatalf("Expected a limit of 10 after restart, got %d", limit)
+	}
+
+	// Going back to the S3 maximum removes the persisted limit.
+	if err = writeMultiDeleteLimit(objLayer, maxDeleteObjects); err != nil {
+		t.Fatal(err)
+	}
+	resetGlobalMultiDeleteLimit()
+	if err = initMultiDeleteLimit(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if limit := getMultiDeleteLimit(); limit != maxDeleteObjects {
+		t.Fatalf("Expected a limit of %d, got %d", maxDeleteObjects, limit)
+	}
+}
+
+// TestMultiDeleteLimit - tests a multi-delete request over the limit is
+// rejected before any object is deleted while one within it succeeds.
+func TestMultiDeleteLimit(t *testing.T) {
+	ExecObjectLayerAPITest(t, testMultiDeleteLimit, []string{"DeleteMultipleObjects"})
+}
+
+func testMultiDeleteLimit(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials credential, t *testing.T) {
+	defer resetGlobalMultiDeleteLimit()
+
+	var objects []ObjectIdentifier
+	for i := 0; i < 3; i++ {
+		objectName := "object-" + strconv.Itoa(i)
+		if _, err := obj.PutObject(bucketName, objectName, 4, bytes.NewReader([]byte("data")), nil, ""); err != nil {
+			t.Fatalf("Minio %s: %v", instanceType, err)
+		}
+		objects = append(objects, ObjectIdentifier{objectName})
+	}
+	if err := setMultiDeleteLimit(2); err != nil {
+		t.Fatal(err)
+	}
+
+	deleteObjects := func(objects []ObjectIdentifier) *httptest.ResponseRecorder {
+		body := encodeResponse(DeleteObjectsRequest{Quiet: true, Objects: objects})
+		req, err := newTestSignedRequestV4("POST", getDeleteMultipleObjectsURL("", bucketName),
+			int64(len(body)), bytes.NewReader(body), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("Minio %s: Failed to create HTTP request for DeleteMultipleObjects: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Over the limit, nothing is deleted.
+	rec := deleteObjects(objects)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Minio %s: Expected %d, got %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	var errResponse APIErrorResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &errResponse); err != nil {
+		t.Fatalf("Minio %s: %v", instanceType, err)
+	}
+	if errResponse.Code != "XMinioTooManyDeleteObjects" {
+		t.Fatalf("Minio %s: Expected XMinioTooManyDeleteObjects, got %s", instanceType, errResponse.Code)
+	}
+	for _, object := range objects {
+		if _, err := obj.GetObjectInfo(bucketName, object.ObjectName); err != nil {
+			t.Fatalf("Minio %s: Expected %s not to be deleted, got %v", instanceType, object.ObjectName, err)
+		}
+	}
+
+	// Within the limit.
+	if rec = deleteObjects(objects[:2]); rec.Code != http.StatusOK {
+		t.Fatalf("Minio %s: Expected %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+	for _, object := range objects[:2] {
+		if _, err := obj.GetObjectInfo(bucketName, object.ObjectName); !isErrObjectNotFound(err) {
+			t.Fatalf("Minio %s: Expected %s to be deleted, got %v", instanceType, object.ObjectName, err)
+		}
+	}
+}
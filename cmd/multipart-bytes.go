@@ -0,0 +1,98 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/url"
+	"strings"
+)
+
+// NodeMultipartBytes - bytes held by in-progress multipart uploads on
+// the disks of a server.
+type NodeMultipartBytes struct {
+	Addr  string `json:"addr"`
+	Bytes int64  `json:"bytes"`
+}
+
+// ClusterMultipartBytes - bytes held by in-progress multipart uploads
+// on the disks of all servers.
+type ClusterMultipartBytes struct {
+	Total int64                `json:"total"`
+	Nodes []NodeMultipartBytes `json:"nodes"`
+}
+
+// diskMultipartBytes - returns the size of all files under dirPath of
+// the multipart uploads volume on disk. Uploads are removed from this
+// volume once completed or aborted, so only in-progress uploads are
+// accounted for.
+func diskMultipartBytes(disk StorageAPI, dirPath string) (int64, error) {
+	entries, err := disk.ListDir(minioMetaMultipartBucket, dirPath)
+	if err != nil {
+		if err == errFileNotFound || err == errVolumeNotFound {
+			// Removed by a completed or aborted upload
+			// meanwhile.
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var total int64
+	for _, entry := range entries {
+		entryPath := pathJoin(dirPath, entry)
+		if strings.HasSuffix(entry, slashSeparator) {
+			size, err := diskMultipartBytes(disk, entryPath)
+			if err != nil {
+				return 0, err
+			}
+			total += size
+			continue
+		}
+		fi, err := disk.StatFile(minioMetaMultipartBucket, entryPath)
+		if err != nil {
+			if err == errFileNotFound {
+				continue
+			}
+			return 0, err
+		}
+		total += fi.Size
+	}
+	return total, nil
+}
+
+// getLocalMultipartBytes - returns the bytes held by in-progress
+// multipart uploads on the local disks among endpoints, skipping disks
+// which can not be read.
+func getLocalMultipartBytes(endpoints []*url.URL) int64 {
+	var total int64
+	for _, ep := range endpoints {
+		if !isLocalStorage(ep) {
+			continue
+		}
+		disk, err := newStorageAPI(ep)
+		if err != nil {
+			errorIf(err, "Unable to open disk %s", ep)
+			continue
+		}
+		size, err := diskMultipartBytes(disk, "")
+		if err != nil {
+			errorIf(err, "Unable to account multipart uploads on %s", ep)
+			continue
+		}
+		total += size
+	}
+	return total
+}
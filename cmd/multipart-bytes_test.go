@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+// Tests accounting of bytes held by in-progress multipart uploads for
+// both XL multiple disks and single node setup.
+func TestGetLocalMultipartBytes(t *testing.T) {
+	root, err := newTestConfig("us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	fsObj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots([]string{fsDir})
+	fsEndpoints, err := parseStorageEndpoints([]string{fsDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testGetLocalMultipartBytes(t, "FS", fsObj, fsEndpoints)
+
+	xlObj, xlDirs, err := prepareXL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(xlDirs)
+	xlEndpoints, err := parseStorageEndpoints(xlDirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testGetLocalMultipartBytes(t, "XL", xlObj, xlEndpoints)
+}
+
+func testGetLocalMultipartBytes(t *testing.T, instanceType string, obj ObjectLayer, endpoints []*url.URL) {
+	bucket := "multipart-bucket"
+	if err := obj.MakeBucket(bucket); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if size := getLocalMultipartBytes(endpoints); size != 0 {
+		t.Fatalf("%s: Expected no multipart bytes, got %d", instanceType, size)
+	}
+
+	uploadIDs := make([]string, 2)
+	parts := make([]PartInfo, 2)
+	prev := int64(0)
+	for i := range uploadIDs {
+		object := fmt.Sprintf("object%d", i)
+		uploadID, err := obj.NewMultipartUpload(bucket, object, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		data := bytes.Repeat([]byte("a"), (i+1)*1024)
+		if parts[i], err = obj.PutObjectPart(bucket, object, uploadID, 1, int64(len(data)), bytes.NewReader(data), "", ""); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		uploadIDs[i] = uploadID
+
+		// Every upload adds to the total.
+		size := getLocalMultipartBytes(endpoints)
+		if size < prev+int64(len(data)) {
+			t.Fatalf("%s: Expected more than %d multipart bytes, got %d", instanceType, prev+int64(len(data)), size)
+		}
+		prev = size
+	}
+
+	// Completed uploads drop out of the total.
+	if _, err := obj.CompleteMultipartUpload(bucket, "object0", uploadIDs[0], []completePart{{PartNumber: 1, ETag: parts[0].ETag}}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	size := getLocalMultipartBytes(endpoints)
+	if size >= prev || size < 2*1024 {
+		t.Fatalf("%s: Expected multipart bytes of the remaining upload only, got %d of %d", instanceType, size, prev)
+	}
+
+	// Aborted uploads too.
+	if err := obj.AbortMultipartUpload(bucket, "object1", uploadIDs[1]); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if size = getLocalMultipartBytes(endpoints); size != 0 {
+		t.Fatalf("%s: Expected no multipart bytes, got %d", instanceType, size)
+	}
+}
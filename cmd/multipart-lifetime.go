@@ -0,0 +1,132 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// Interval at which abandoned multipart uploads are looked for.
+const multipartJanitorInterval = 15 * time.Minute
+
+// multipartLifetime - time after which an idle multipart upload is
+// aborted, can be changed at runtime via admin RPC. Zero, the
+// default, keeps uploads forever.
+type multipartLifetime struct {
+	mutex    sync.RWMutex
+	lifetime time.Duration
+}
+
+var globalMultipartLifetime = &multipartLifetime{}
+
+// get - returns the current lifetime.
+func (m *multipartLifetime) get() time.Duration {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.lifetime
+}
+
+// set - sets the lifetime, zero disables expiry.
+func (m *multipartLifetime) set(lifetime time.Duration) error {
+	if lifetime < 0 {
+		return errInvalidArgument
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.lifetime = lifetime
+	return nil
+}
+
+// uploadLastActivity - returns the time an upload last made progress,
+// which is when its latest part was uploaded or when it was initiated
+// if it has no parts yet.
+func uploadLastActivity(obj ObjectLayer, bucket string, upload uploadMetadata) (time.Time, error) {
+	lastActivity := upload.Initiated
+	partNumberMarker := 0
+	for {
+		result, err := obj.ListObjectParts(bucket, upload.Object, upload.UploadID, partNumberMarker, maxPartsList)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, part := range result.Parts {
+			if part.LastModified.After(lastActivity) {
+				lastActivity = part.LastModified
+			}
+		}
+		if !result.IsTruncated {
+			return lastActivity, nil
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+}
+
+// abortExpiredUploads - aborts multipart uploads in all buckets which
+// made no progress for longer than lifetime. Uploads still receiving
+// parts are spared however long ago they were initiated.
+func abortExpiredUploads(obj ObjectLayer, lifetime time.Duration, now time.Time) error {
+	buckets, err := obj.ListBuckets()
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range buckets {
+		keyMarker, uploadIDMarker := "", ""
+		for {
+			result, err := obj.ListMultipartUploads(bucket.Name, "", keyMarker, uploadIDMarker, "", maxUploadsList)
+			if err != nil {
+				return err
+			}
+			for _, upload := range result.Uploads {
+				lastActivity, err := uploadLastActivity(obj, bucket.Name, upload)
+				if err != nil {
+					errorIf(err, "Unable to list parts of %s/%s", bucket.Name, upload.Object)
+					continue
+				}
+				if now.Sub(lastActivity) <= lifetime {
+					continue
+				}
+				// Other servers may have aborted it already.
+				err = obj.AbortMultipartUpload(bucket.Name, upload.Object, upload.UploadID)
+				if _, ok := errorCause(err).(InvalidUploadID); err != nil && !ok {
+					errorIf(err, "Unable to abort expired upload of %s/%s", bucket.Name, upload.Object)
+				}
+			}
+			if !result.IsTruncated {
+				break
+			}
+			keyMarker, uploadIDMarker = result.NextKeyMarker, result.NextUploadIDMarker
+		}
+	}
+	return nil
+}
+
+// startMultipartJanitor - periodically aborts expired multipart
+// uploads, runs for the lifetime of the server.
+func startMultipartJanitor() {
+	ticker := time.NewTicker(multipartJanitorInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		lifetime := globalMultipartLifetime.get()
+		objAPI := newObjectLayerFn()
+		if lifetime == 0 || objAPI == nil {
+			continue
+		}
+		errorIf(abortExpiredUploads(objAPI, lifetime, time.Now().UTC()), "Unable to abort expired multipart uploads.")
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestMultipartLifetimeSet - tests setting multipart lifetime.
+func TestMultipartLifetimeSet(t *testing.T) {
+	m := &multipartLifetime{}
+	testCases := []struct {
+		lifetime    time.Duration
+		expectedErr error
+	}{
+		{24 * time.Hour, nil},
+		{0, nil},
+		{-time.Second, errInvalidArgument},
+	}
+
+	for i, test := range testCases {
+		if err := m.set(test.lifetime); err != test.expectedErr {
+			t.Errorf("Test %d: Expected error %v, got %v", i+1, test.expectedErr, err)
+		}
+	}
+}
+
+// Wrapper for calling testAbortExpiredUploads for both XL and FS.
+func TestAbortExpiredUploads(t *testing.T) {
+	ExecObjectLayerTest(t, testAbortExpiredUploads)
+}
+
+// Tests that idle uploads past the lifetime are aborted while uploads
+// still receiving parts are spared.
+func testAbortExpiredUploads(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucket := "bucket"
+	data := []byte("hello")
+	if err := obj.MakeBucket(bucket); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	newUpload := func(object string) string {
+		uploadID, err := obj.NewMultipartUpload(bucket, object, nil)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		return uploadID
+	}
+	putPart := func(object, uploadID string, partID int) {
+		_, err := obj.PutObjectPart(bucket, object, uploadID, partID, int64(len(data)), bytes.NewReader(data), "", "")
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+
+	idleID := newUpload("idle")
+	putPart("idle", idleID, 1)
+	activeID := newUpload("active")
+	putPart("active", activeID, 1)
+	emptyID := newUpload("empty")
+
+	// All uploads were initiated longer than the lifetime ago, only
+	// the active one made progress since.
+	lifetime := 500 * time.Millisecond
+	time.Sleep(time.Second)
+	putPart("active", activeID, 2)
+
+	if err := abortExpiredUploads(obj, lifetime, time.Now().UTC()); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	for _, upload := range []struct {
+		object, uploadID string
+	}{{"idle", idleID}, {"empty", emptyID}} {
+		_, err := obj.ListObjectParts(bucket, upload.object, upload.uploadID, 0, maxPartsList)
+		if _, ok := errorCause(err).(InvalidUploadID); !ok {
+			t.Fatalf("%s: Expected upload of %s to be aborted, got %v", instanceType, upload.object, err)
+		}
+	}
+	result, err := obj.ListObjectParts(bucket, "active", activeID, 0, maxPartsList)
+	if err != nil {
+		t.Fatalf("%s: Expected active upload to be spared, got %v", instanceType, err)
+	}
+	if len(result.Parts) != 2 {
+		t.Fatalf("%s: Expected 2 parts in active upload, got %d", instanceType, len(result.Parts))
+	}
+}
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -27,6 +28,7 @@ import (
 	"net/http"
 	"net/rpc"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -35,6 +37,13 @@ const defaultDialTimeout = 3 * time.Second
 
 // RPCClient is a reconnectable RPC client on Call().
 type RPCClient struct {
+	// Call statistics, only accessed atomically. Kept first for
+	// 64-bit alignment.
+	calls     int64 // Calls made.
+	inFlight  int64 // Calls waiting for a reply.
+	waitNanos int64 // Total time calls waited for a connection.
+	connected int32 // 1 while a connection is established.
+
 	sync.Mutex                  // Mutex to lock net rpc client.
 	netRPCClient    *rpc.Client // Base RPC client to make any RPC call.
 	serverAddr      string      // RPC server address.
@@ -45,16 +54,19 @@ type RPCClient struct {
 // newRPCClient returns new RPCClient object with given serverAddr and serviceEndpoint.
 // It does lazy connect to the remote endpoint on Call().
 func newRPCClient(serverAddr, serviceEndpoint string, secureConn bool) *RPCClient {
-	return &RPCClient{
+	rpcClient := &RPCClient{
 		serverAddr:      serverAddr,
 		serviceEndpoint: serviceEndpoint,
 		secureConn:      secureConn,
 	}
+	globalRPCClients.register(rpcClient)
+	return rpcClient
 }
 
 // dial tries to establish a connection to serverAddr in a safe manner.
 // If there is a valid rpc.Cliemt, it returns that else creates a new one.
-func (rpcClient *RPCClient) dial() (netRPCClient *rpc.Client, err error) {
+// Connecting gives up by the deadline of ctx, if any.
+func (rpcClient *RPCClient) dial(ctx context.Context) (netRPCClient *rpc.Client, err error) {
 	rpcClient.Lock()
 	defer rpcClient.Unlock()
 
@@ -63,6 +75,9 @@ func (rpcClient *RPCClient) dial() (netRPCClient *rpc.Client, err error) {
 		return rpcClient.netRPCClient, nil
 	}
 
+	deadline, _ := ctx.Deadline()
+	dialer := &net.Dialer{Deadline: deadline}
+
 	var conn net.Conn
 	if rpcClient.secureConn {
 		var hostname string
@@ -78,10 +93,11 @@ func (rpcClient *RPCClient) dial() (netRPCClient *rpc.Client, err error) {
 		}
 
 		// ServerName in tls.Config needs to be specified to support SNI certificates.
-		conn, err = tls.Dial("tcp", rpcClient.serverAddr, &tls.Config{ServerName: hostname, RootCAs: globalRootCAs})
+		conn, err = tls.DialWithDialer(dialer, "tcp", rpcClient.serverAddr, &tls.Config{ServerName: hostname, RootCAs: globalRootCAs})
 	} else {
 		// Dial with a timeout.
-		conn, err = net.DialTimeout("tcp", rpcClient.serverAddr, defaultDialTimeout)
+		dialer.Timeout = defaultDialTimeout
+		conn, err = dialer.Dial("tcp", rpcClient.serverAddr)
 	}
 
 	if err != nil {
@@ -99,11 +115,15 @@ func (rpcClient *RPCClient) dial() (netRPCClient *rpc.Client, err error) {
 		}
 	}
 
+	// Servers accepting the connection but never replying are given
+	// up on by the deadline too.
+	conn.SetDeadline(deadline)
 	io.WriteString(conn, "CONNECT "+rpcClient.serviceEndpoint+" HTTP/1.0\n\n")
 
 	// Require successful HTTP response before switching to RPC protocol.
 	resp, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: "CONNECT"})
 	if err == nil && resp.Status == "200 Connected to Go RPC" {
+		conn.SetDeadline(time.Time{})
 		netRPCClient := rpc.NewClient(conn)
 
 		if netRPCClient == nil {
@@ -116,6 +136,7 @@ func (rpcClient *RPCClient) dial() (netRPCClient *rpc.Client, err error) {
 		}
 
 		rpcClient.netRPCClient = netRPCClient
+		atomic.StoreInt32(&rpcClient.connected, 1)
 
 		return netRPCClient, nil
 	}
@@ -136,13 +157,40 @@ func (rpcClient *RPCClient) dial() (netRPCClient *rpc.Client, err error) {
 
 // Call makes a RPC call to the remote endpoint using the default codec, namely encoding/gob.
 func (rpcClient *RPCClient) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return rpcClient.CallContext(context.Background(), serviceMethod, args, reply)
+}
+
+// CallContext is like Call but returns ctx.Err() once ctx is done. A
+// net/rpc call can not be cancelled, it is abandoned instead and
+// completes into its buffered Done channel, leaving the connection
+// shared with other calls in use.
+func (rpcClient *RPCClient) CallContext(ctx context.Context, serviceMethod string, args interface{}, reply interface{}) error {
+	atomic.AddInt64(&rpcClient.calls, 1)
+
 	// Get a new or existing rpc.Client.
-	netRPCClient, err := rpcClient.dial()
+	start := time.Now()
+	netRPCClient, err := rpcClient.dial(ctx)
+	atomic.AddInt64(&rpcClient.waitNanos, int64(time.Since(start)))
 	if err != nil {
+		// Failed by the deadline of ctx, ctx is done right after.
+		if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+			<-ctx.Done()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		return err
 	}
 
-	return netRPCClient.Call(serviceMethod, args, reply)
+	atomic.AddInt64(&rpcClient.inFlight, 1)
+	defer atomic.AddInt64(&rpcClient.inFlight, -1)
+	call := netRPCClient.Go(serviceMethod, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+		return call.Error
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // Close closes underlying rpc.Client.
@@ -154,6 +202,7 @@ func (rpcClient *RPCClient) Close() error {
 		// goroutine could try to dial or close in parallel.
 		netRPCClient := rpcClient.netRPCClient
 		rpcClient.netRPCClient = nil
+		atomic.StoreInt32(&rpcClient.connected, 0)
 		rpcClient.Unlock()
 
 		return netRPCClient.Close()
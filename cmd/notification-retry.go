@@ -0,0 +1,138 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// errInvalidNotificationRetry - retries or backoff are negative, or
+// the dead-letter target is not an enabled notification target.
+var errInvalidNotificationRetry = errors.New("Notification retry policy needs non-negative retries and backoff, and an enabled dead-letter target")
+
+// NotificationRetryPolicy - how events an external notification
+// target failed to receive are sent again.
+type NotificationRetryPolicy struct {
+	// Attempts after the first one, 0 gives up at once.
+	MaxRetries int `json:"maxRetries"`
+	// Wait between two attempts.
+	Backoff time.Duration `json:"backoff"`
+	// ARN of the target receiving events all attempts failed to
+	// send, empty drops them.
+	DeadLetterTarget string `json:"deadLetterTarget,omitempty"`
+}
+
+// notificationRetry - notification retry policy of this server, can
+// be changed at runtime via admin RPC. Events are sent once and
+// dropped on failure by default.
+type notificationRetry struct {
+	mutex  sync.RWMutex
+	policy NotificationRetryPolicy
+}
+
+var globalNotificationRetry = &notificationRetry{}
+
+// get - returns the current policy.
+func (n *notificationRetry) get() NotificationRetryPolicy {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return n.policy
+}
+
+// set - sets the policy.
+func (n *notificationRetry) set(policy NotificationRetryPolicy) error {
+	if err := validateNotificationRetry(policy); err != nil {
+		return err
+	}
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.policy = policy
+	return nil
+}
+
+// validateNotificationRetry - returns errInvalidNotificationRetry if
+// policy cannot be applied.
+func validateNotificationRetry(policy NotificationRetryPolicy) error {
+	if policy.MaxRetries < 0 || policy.Backoff < 0 {
+		return errInvalidNotificationRetry
+	}
+	if policy.DeadLetterTarget != "" && !isValidQueueID(policy.DeadLetterTarget) {
+		return errInvalidNotificationRetry
+	}
+	return nil
+}
+
+// fireNotification - sends fields to target, returning the error of
+// the target. Logging through target would drop it.
+func fireNotification(target *logrus.Logger, fields logrus.Fields) error {
+	entry := target.WithFields(fields)
+	entry.Time = time.Now()
+	entry.Level = logrus.InfoLevel
+	return target.Hooks.Fire(logrus.InfoLevel, entry)
+}
+
+// sendNotification - sends fields to the external target queueARN.
+// The first attempt is made right away, further attempts are made in
+// the background as per the retry policy.
+func sendNotification(queueARN string, fields logrus.Fields) {
+	target := globalEventNotifier.GetExternalTarget(queueARN)
+	if target == nil {
+		return
+	}
+	err := fireNotification(target, fields)
+	if err == nil {
+		return
+	}
+
+	policy := globalNotificationRetry.get()
+	if policy.MaxRetries == 0 && policy.DeadLetterTarget == "" {
+		errorIf(err, "Unable to send event to %s", queueARN)
+		return
+	}
+	go retryNotification(queueARN, target, fields, policy)
+}
+
+// retryNotification - sends fields to target, which failed once
+// already, at most policy.MaxRetries more times. Once all attempts
+// failed, fields are sent to the dead-letter target of policy a
+// single time, and dropped if it fails too.
+func retryNotification(queueARN string, target *logrus.Logger, fields logrus.Fields, policy NotificationRetryPolicy) {
+	var err error
+	for i := 0; i < policy.MaxRetries; i++ {
+		time.Sleep(policy.Backoff)
+		if err = fireNotification(target, fields); err == nil {
+			return
+		}
+	}
+	errorIf(err, "Unable to send event to %s after %d retries", queueARN, policy.MaxRetries)
+
+	if policy.DeadLetterTarget == "" {
+		return
+	}
+	deadLetter := globalEventNotifier.GetExternalTarget(policy.DeadLetterTarget)
+	if deadLetter == nil {
+		errorIf(errInvalidNotificationRetry, "Dropping event for %s, dead-letter target %s is not loaded", queueARN, policy.DeadLetterTarget)
+		return
+	}
+	// Never retried, nor sent to a dead-letter target of its own.
+	err = fireNotification(deadLetter, fields)
+	errorIf(err, "Dropping event for %s, unable to send it to dead-letter target %s", queueARN, policy.DeadLetterTarget)
+}
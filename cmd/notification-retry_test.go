@@ -0,0 +1,159 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// failingHook - notification target hook failing its first fails
+// events and counting all of them.
+type failingHook struct {
+	fails int
+	calls int
+}
+
+func (h *failingHook) Fire(entry *logrus.Entry) error {
+	h.calls++
+	if h.calls <= h.fails {
+		return errors.New("target unavailable")
+	}
+	return nil
+}
+
+func (h *failingHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.InfoLevel}
+}
+
+// newFailingTarget - returns a notification target sending events to
+// hook.
+func newFailingTarget(hook *failingHook) *logrus.Logger {
+	target := logrus.New()
+	target.Hooks.Add(hook)
+	return target
+}
+
+// TestRetryNotification - tests events are retried as per the policy,
+// then sent to the dead-letter target which is never retried.
+func TestRetryNotification(t *testing.T) {
+	defer resetGlobalEventNotifier()
+
+	const (
+		queueARN      = "arn:minio:sqs:us-east-1:1:webhook"
+		deadLetterARN = "arn:minio:sqs:us-east-1:2:webhook"
+	)
+	fields := logrus.Fields{"Key": "bucket/object"}
+	testCases := []struct {
+		targetFails     int
+		deadLetterFails int
+		policy          NotificationRetryPolicy
+		targetCalls     int
+		deadLetterCalls int
+	}{
+		// First retry fails, received on the second one.
+		{1, 0, NotificationRetryPolicy{MaxRetries: 3, DeadLetterTarget: deadLetterARN}, 2, 0},
+		// Retries exhausted, received by the dead-letter target.
+		{10, 0, NotificationRetryPolicy{MaxRetries: 3, DeadLetterTarget: deadLetterARN}, 3, 1},
+		// Retries exhausted without a dead-letter target, dropped.
+		{10, 0, NotificationRetryPolicy{MaxRetries: 3}, 3, 0},
+		// A failing dead-letter target is tried a single time.
+		{10, 10, NotificationRetryPolicy{MaxRetries: 2, DeadLetterTarget: deadLetterARN}, 2, 1},
+	}
+	for i, testCase := range testCases {
+		target := &failingHook{fails: testCase.targetFails}
+		deadLetter := &failingHook{fails: testCase.deadLetterFails}
+		globalEventNotifier = &eventNotifier{
+			external: externalNotifier{
+				targets: map[string]*logrus.Logger{
+					queueARN:      newFailingTarget(target),
+					deadLetterARN: newFailingTarget(deadLetter),
+				},
+				rwMutex: &sync.RWMutex{},
+			},
+		}
+		testCase.policy.Backoff = time.Millisecond
+
+		retryNotification(queueARN, globalEventNotifier.GetExternalTarget(queueARN), fields, testCase.policy)
+		if target.calls != testCase.targetCalls {
+			t.Errorf("Test %d: expected %d retries, got %d", i+1, testCase.targetCalls, target.calls)
+		}
+		if deadLetter.calls != testCase.deadLetterCalls {
+			t.Errorf("Test %d: expected %d dead-letter attempts, got %d", i+1, testCase.deadLetterCalls, deadLetter.calls)
+		}
+	}
+
+	// A failing target being its own dead-letter target does not
+	// loop.
+	target := &failingHook{fails: 10}
+	globalEventNotifier = &eventNotifier{
+		external: externalNotifier{
+			targets: map[string]*logrus.Logger{queueARN: newFailingTarget(target)},
+			rwMutex: &sync.RWMutex{},
+		},
+	}
+	policy := NotificationRetryPolicy{MaxRetries: 2, Backoff: time.Millisecond, DeadLetterTarget: queueARN}
+	retryNotification(queueARN, globalEventNotifier.GetExternalTarget(queueARN), fields, policy)
+	if target.calls != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", target.calls)
+	}
+
+	// Events are sent once without retry policy.
+	target.calls = 0
+	sendNotification(queueARN, fields)
+	if target.calls != 1 {
+		t.Fatalf("Expected 1 attempt, got %d", target.calls)
+	}
+}
+
+// TestSetNotificationRetry - tests validation of notification retry
+// policies.
+func TestSetNotificationRetry(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalNotificationRetry()
+
+	serverConfig.Notify.SetWebhookByID("1", webhookNotify{Enable: true, Endpoint: "http://localhost:8080"})
+	webhookARN := minioSqs + serverConfig.GetRegion() + ":1:" + queueTypeWebhook
+
+	testCases := []struct {
+		policy NotificationRetryPolicy
+		err    error
+	}{
+		{NotificationRetryPolicy{}, nil},
+		{NotificationRetryPolicy{MaxRetries: 5, Backoff: time.Second, DeadLetterTarget: webhookARN}, nil},
+		{NotificationRetryPolicy{MaxRetries: -1}, errInvalidNotificationRetry},
+		{NotificationRetryPolicy{Backoff: -time.Second}, errInvalidNotificationRetry},
+		// Dead-letter target not configured.
+		{NotificationRetryPolicy{DeadLetterTarget: minioSqs + serverConfig.GetRegion() + ":2:" + queueTypeWebhook}, errInvalidNotificationRetry},
+	}
+	for i, testCase := range testCases {
+		if err = globalNotificationRetry.set(testCase.policy); err != testCase.err {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+		}
+		if err == nil && globalNotificationRetry.get() != testCase.policy {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.policy, globalNotificationRetry.get())
+		}
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Maximum number of objects whose last access a server remembers. Once
+// reached the half accessed the longest ago is forgotten, those objects
+// fall back to their creation time.
+const objectAccessMaxEntries = 100000
+
+// ColdObject - an object not read within the window of a last access
+// report. Accesses are only remembered in memory, objects not read
+// since the servers started report their creation time as last access
+// and NeverAccessed.
+type ColdObject struct {
+	Object        string    `json:"object"`
+	Size          int64     `json:"size"`
+	LastAccess    time.Time `json:"lastAccess"`
+	NeverAccessed bool      `json:"neverAccessed"`
+}
+
+// objectAccess - last time every object was read by a GetObject request
+// served by this server.
+type objectAccess struct {
+	mutex      sync.Mutex
+	lastAccess map[string]map[string]time.Time
+	entries    int
+}
+
+func newObjectAccess() *objectAccess {
+	return &objectAccess{lastAccess: make(map[string]map[string]time.Time)}
+}
+
+var globalObjectAccess = newObjectAccess()
+
+// record - records a read of object in bucket at now.
+func (a *objectAccess) record(bucket, object string, now time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if last, ok := a.lastAccess[bucket][object]; ok {
+		if now.After(last) {
+			a.lastAccess[bucket][object] = now
+		}
+		return
+	}
+	if a.entries >= objectAccessMaxEntries {
+		a.forgetOldest()
+	}
+	objects, ok := a.lastAccess[bucket]
+	if !ok {
+		objects = make(map[string]time.Time)
+		a.lastAccess[bucket] = objects
+	}
+	objects[object] = now
+	a.entries++
+}
+
+// forgetOldest - forgets the half of the accesses which are the
+// oldest, callers hold the mutex.
+func (a *objectAccess) forgetOldest() {
+	times := make([]time.Time, 0, a.entries)
+	for _, objects := range a.lastAccess {
+		for _, last := range objects {
+			times = append(times, last)
+		}
+	}
+	sort.Sort(byTime(times))
+	cutoff := times[len(times)/2]
+	for bucket, objects := range a.lastAccess {
+		for object, last := range objects {
+			if !last.After(cutoff) {
+				delete(objects, object)
+				a.entries--
+			}
+		}
+		if len(objects) == 0 {
+			delete(a.lastAccess, bucket)
+		}
+	}
+}
+
+// get - returns the last read of the objects of bucket under prefix.
+func (a *objectAccess) get(bucket, prefix string) map[string]time.Time {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	accesses := make(map[string]time.Time)
+	for object, last := range a.lastAccess[bucket] {
+		if strings.HasPrefix(object, prefix) {
+			accesses[object] = last
+		}
+	}
+	return accesses
+}
+
+// objectAccessed - records a read of object in bucket now.
+func objectAccessed(bucket, object string) {
+	globalObjectAccess.record(bucket, object, time.Now().UTC())
+}
+
+// getLocalLastAccessTimes - returns the last read of the objects of
+// bucket under prefix served by this server.
+func getLocalLastAccessTimes(bucket, prefix string) map[string]time.Time {
+	return globalObjectAccess.get(bucket, prefix)
+}
+
+// mergeLastAccessTimes - returns the most recent read of every object
+// across the views of all servers, clients may read an object through
+// any of them.
+func mergeLastAccessTimes(views []map[string]time.Time) map[string]time.Time {
+	merged := make(map[string]time.Time)
+	for _, view := range views {
+		for object, last := range view {
+			if last.After(merged[object]) {
+				merged[object] = last
+			}
+		}
+	}
+	return merged
+}
+
+// newColdObject - returns the last access of obj, its creation time if
+// it was not read since.
+func newColdObject(obj ObjectInfo, accesses map[string]time.Time) ColdObject {
+	cold := ColdObject{Object: obj.Name, Size: obj.Size, LastAccess: obj.ModTime}
+	last, ok := accesses[obj.Name]
+	if !ok || !last.After(obj.ModTime) {
+		// Never read, or read before being overwritten.
+		cold.NeverAccessed = true
+		return cold
+	}
+	cold.LastAccess = last
+	return cold
+}
+
+// lastAccessReport - returns the objects of bucket under prefix not
+// read within olderThan before now, given the last reads accesses.
+func lastAccessReport(objLayer ObjectLayer, bucket, prefix string, accesses map[string]time.Time, olderThan time.Duration, now time.Time) ([]ColdObject, error) {
+	coldObjects := []ColdObject{}
+	marker := ""
+	for {
+		result, err := objLayer.ListObjects(bucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range result.Objects {
+			if cold := newColdObject(obj, accesses); now.Sub(cold.LastAccess) > olderThan {
+				coldObjects = append(coldObjects, cold)
+			}
+		}
+		if !result.IsTruncated {
+			return coldObjects, nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// byTime - sorts times from the oldest.
+type byTime []time.Time
+
+func (t byTime) Len() int           { return len(t) }
+func (t byTime) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t byTime) Less(i, j int) bool { return t[i].Before(t[j]) }
@@ -0,0 +1,110 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestObjectAccessRecord - tests the last read of objects is kept.
+func TestObjectAccessRecord(t *testing.T) {
+	access := newObjectAccess()
+	now := time.Now().UTC()
+
+	access.record("bucket", "dir/object", now)
+	// A read reported late does not move the last read back.
+	access.record("bucket", "dir/object", now.Add(-time.Minute))
+	access.record("bucket", "other", now)
+	access.record("other-bucket", "dir/object", now)
+
+	expected := map[string]time.Time{"dir/object": now}
+	if accesses := access.get("bucket", "dir/"); !reflect.DeepEqual(accesses, expected) {
+		t.Fatalf("Expected %v, got %v", expected, accesses)
+	}
+}
+
+// TestObjectAccessForgetOldest - tests the oldest reads are forgotten
+// once too many objects are tracked.
+func TestObjectAccessForgetOldest(t *testing.T) {
+	access := newObjectAccess()
+	now := time.Now().UTC()
+	for i := 0; i < objectAccessMaxEntries; i++ {
+		access.record("bucket", fmt.Sprintf("object%d", i), now.Add(time.Duration(i)*time.Millisecond))
+	}
+	access.record("bucket", "new", now.Add(time.Hour))
+
+	accesses := access.get("bucket", "")
+	if len(accesses) > objectAccessMaxEntries/2+1 {
+		t.Fatalf("Expected at most %d objects, got %d", objectAccessMaxEntries/2+1, len(accesses))
+	}
+	if _, ok := accesses["object0"]; ok {
+		t.Fatal("Expected the oldest read to be forgotten")
+	}
+	for _, object := range []string{"new", fmt.Sprintf("object%d", objectAccessMaxEntries-1)} {
+		if _, ok := accesses[object]; !ok {
+			t.Fatalf("Expected %s to be kept", object)
+		}
+	}
+}
+
+// TestNewColdObject - tests objects not read since created report
+// their creation time.
+func TestNewColdObject(t *testing.T) {
+	created := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	obj := ObjectInfo{Name: "object", Size: 10, ModTime: created}
+
+	testCases := []struct {
+		accesses map[string]time.Time
+		expected ColdObject
+	}{
+		// Never read.
+		{nil, ColdObject{Object: "object", Size: 10, LastAccess: created, NeverAccessed: true}},
+		// Read before being overwritten.
+		{
+			map[string]time.Time{"object": created.Add(-time.Hour)},
+			ColdObject{Object: "object", Size: 10, LastAccess: created, NeverAccessed: true},
+		},
+		// Read since created.
+		{
+			map[string]time.Time{"object": created.Add(time.Hour)},
+			ColdObject{Object: "object", Size: 10, LastAccess: created.Add(time.Hour)},
+		},
+	}
+	for i, testCase := range testCases {
+		if cold := newColdObject(obj, testCase.accesses); cold != testCase.expected {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.expected, cold)
+		}
+	}
+}
+
+// TestMergeLastAccessTimes - tests the most recent read across servers
+// wins.
+func TestMergeLastAccessTimes(t *testing.T) {
+	now := time.Now().UTC()
+	merged := mergeLastAccessTimes([]map[string]time.Time{
+		{"a": now.Add(-time.Hour), "b": now},
+		nil,
+		{"a": now, "b": now.Add(-time.Hour), "c": now},
+	})
+	expected := map[string]time.Time{"a": now, "b": now, "c": now}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("Expected %v, got %v", expected, merged)
+	}
+}
@@ -50,6 +50,27 @@ type StorageInfo struct {
 	}
 }
 
+// SetStatus - represents the live health of an erasure set.
+type SetStatus struct {
+	// Index of the erasure set.
+	Index int `json:"index"`
+	// Online state of every drive in the set, in format.json order.
+	Drives []bool `json:"drives"`
+
+	OnlineDrives    int `json:"onlineDrives"`
+	ParityDrives    int `json:"parityDrives"`
+	ParityRemaining int `json:"parityRemaining"` // Drives that can still be lost without losing data.
+	ReadQuorum      int `json:"readQuorum"`      // Minimum drives required for reads.
+	WriteQuorum     int `json:"writeQuorum"`     // Minimum drives required for writes.
+
+	HasReadQuorum  bool `json:"hasReadQuorum"`
+	HasWriteQuorum bool `json:"hasWriteQuorum"`
+
+	// Set to false when servers disagree on the state of any
+	// drive in this set.
+	Consistent bool `json:"consistent"`
+}
+
 type healStatus int
 
 const (
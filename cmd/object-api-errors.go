@@ -244,6 +244,15 @@ func (e ObjectNameInvalid) Error() string {
 	return "Object name invalid: " + e.Bucket + "#" + e.Object
 }
 
+// ObjectNameNotAllowed - object name is not allowed by the object
+// naming policy.
+type ObjectNameNotAllowed GenericError
+
+// Return string an error formatted as the given text.
+func (e ObjectNameNotAllowed) Error() string {
+	return "Object name not allowed by naming policy: " + e.Bucket + "#" + e.Object
+}
+
 // IncompleteBody You did not provide the number of bytes specified by the Content-Length HTTP header.
 type IncompleteBody GenericError
 
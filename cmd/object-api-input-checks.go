@@ -144,6 +144,16 @@ func checkPutObjectArgs(bucket, object string, obj ObjectLayer) error {
 	return nil
 }
 
+// Checks arguments validity of operations creating a new object,
+// in addition to checkPutObjectArgs the object name must be allowed
+// by the object naming policy.
+func checkNewObjectArgs(bucket, object string, obj ObjectLayer) error {
+	if err := checkPutObjectArgs(bucket, object, obj); err != nil {
+		return err
+	}
+	return globalKeyNamingPolicy.check(bucket, object)
+}
+
 // Checks whether bucket exists and returns appropriate error if not.
 func checkBucketExist(bucket string, obj ObjectLayer) error {
 	if !IsValidBucketName(bucket) {
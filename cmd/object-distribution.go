@@ -0,0 +1,168 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+)
+
+// Difference, in percentage points, between the share of the objects
+// of a bucket stored on an erasure set and an even share beyond which
+// the bucket is reported imbalanced.
+const objectDistributionMaxSkew = 10.0
+
+// SetObjects - objects of a bucket stored on an erasure set, as seen
+// by the disk of the set holding the most of them.
+type SetObjects struct {
+	Set     int   `json:"set"`
+	Objects int64 `json:"objects"`
+	Bytes   int64 `json:"bytes"`
+}
+
+// SetShare - objects of a bucket stored on an erasure set and their
+// share of all objects of the bucket.
+type SetShare struct {
+	SetObjects
+	Percent float64 `json:"percent"`
+}
+
+// ObjectDistribution - spread of the objects of a bucket across the
+// erasure sets.
+type ObjectDistribution struct {
+	Bucket  string     `json:"bucket"`
+	Objects int64      `json:"objects"`
+	Bytes   int64      `json:"bytes"`
+	Sets    []SetShare `json:"sets"`
+	// Set when the share of a set differs from an even share by more
+	// than objectDistributionMaxSkew.
+	Imbalanced bool `json:"imbalanced"`
+}
+
+// getLocalObjectDistribution - returns the objects of bucket stored on
+// the erasure sets of the disks of this server, offline disks are
+// skipped. Every disk of a set holds a part of every object of the set,
+// the disk holding the most objects, the others may still need heal,
+// stands for its set.
+func getLocalObjectDistribution(bucket string) ([]SetObjects, error) {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return nil, errServerNotInitialized
+	}
+	if _, ok := objLayer.(*xlObjects); !ok {
+		return nil, errUnsupportedBackend
+	}
+
+	globalScannerActivity.begin()
+	defer globalScannerActivity.end()
+
+	sets := make(map[int]SetObjects)
+	for _, ep := range globalEndpoints {
+		if !isLocalStorage(ep) {
+			continue
+		}
+		disk, err := newStorageAPI(ep)
+		if err != nil {
+			continue
+		}
+		// XL has a single set made of all disks.
+		view := SetObjects{Set: 0}
+		if err = countDiskObjects(disk, bucket, "", &view); err != nil {
+			continue
+		}
+		mergeSetObjects(sets, view)
+	}
+
+	views := []SetObjects{}
+	for _, view := range sets {
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// countDiskObjects - adds the objects of bucket under prefix stored on
+// disk to view.
+func countDiskObjects(disk StorageAPI, bucket, prefix string, view *SetObjects) error {
+	entries, err := disk.ListDir(bucket, prefix)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry, slashSeparator) {
+			continue
+		}
+		stat, _, err := readXLMetaStat(disk, bucket, prefix+entry)
+		if err == nil {
+			view.Objects++
+			view.Bytes += stat.Size
+			continue
+		}
+		if err = countDiskObjects(disk, bucket, prefix+entry, view); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeSetObjects - keeps in sets the view of the set of view holding
+// the most objects.
+func mergeSetObjects(sets map[int]SetObjects, view SetObjects) {
+	if cur, ok := sets[view.Set]; !ok || view.Objects > cur.Objects {
+		sets[view.Set] = view
+	}
+}
+
+// newObjectDistribution - merges the views of the servers of the
+// objects of bucket on every set. The disks of a set span servers, and
+// each holds a part of every object of the set, so views of a set are
+// not added up: the server seeing the most objects stands for it.
+func newObjectDistribution(bucket string, views [][]SetObjects) ObjectDistribution {
+	merged := make(map[int]SetObjects)
+	for _, serverViews := range views {
+		for _, view := range serverViews {
+			mergeSetObjects(merged, view)
+		}
+	}
+
+	distribution := ObjectDistribution{Bucket: bucket, Sets: []SetShare{}}
+	for _, view := range merged {
+		distribution.Objects += view.Objects
+		distribution.Bytes += view.Bytes
+		distribution.Sets = append(distribution.Sets, SetShare{SetObjects: view})
+	}
+	sort.Sort(bySetIndex(distribution.Sets))
+	if distribution.Objects == 0 {
+		return distribution
+	}
+
+	even := 100 / float64(len(distribution.Sets))
+	for i := range distribution.Sets {
+		share := &distribution.Sets[i]
+		share.Percent = 100 * float64(share.Objects) / float64(distribution.Objects)
+		if share.Percent-even > objectDistributionMaxSkew || even-share.Percent > objectDistributionMaxSkew {
+			distribution.Imbalanced = true
+		}
+	}
+	return distribution
+}
+
+// bySetIndex - sorts set shares by set index.
+type bySetIndex []SetShare
+
+func (s bySetIndex) Len() int           { return len(s) }
+func (s bySetIndex) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s bySetIndex) Less(i, j int) bool { return s[i].Set < s[j].Set }
@@ -0,0 +1,87 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+)
+
+// TestNewObjectDistribution - tests the imbalance of sets is reported.
+func TestNewObjectDistribution(t *testing.T) {
+	testCases := []struct {
+		views      [][]SetObjects
+		imbalanced bool
+	}{
+		// Empty bucket.
+		{[][]SetObjects{{{Set: 0}, {Set: 1}}}, false},
+		// Even spread.
+		{[][]SetObjects{{{Set: 0, Objects: 52}, {Set: 1, Objects: 48}}}, false},
+		// Every object on one set.
+		{[][]SetObjects{{{Set: 0, Objects: 100}}, {{Set: 1}}}, true},
+		// Skewed to one of three sets.
+		{[][]SetObjects{{{Set: 0, Objects: 60}, {Set: 1, Objects: 20}, {Set: 2, Objects: 20}}}, true},
+	}
+	for i, testCase := range testCases {
+		distribution := newObjectDistribution("bucket", testCase.views)
+		if distribution.Imbalanced != testCase.imbalanced {
+			t.Errorf("Test %d: Expected imbalanced %v, got %#v", i+1, testCase.imbalanced, distribution)
+		}
+	}
+}
+
+// TestGetLocalObjectDistribution - tests objects of a bucket on the
+// local disks are counted once per set.
+func TestGetLocalObjectDistribution(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	obj, xlDirs, err := initTestXLObjLayer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(xlDirs)
+
+	// Set globalEndpoints for a single node XL setup.
+	defer resetGlobalEndpoints()
+	for _, xlDir := range xlDirs {
+		globalEndpoints = append(globalEndpoints, &url.URL{Path: xlDir})
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("hello")
+	for _, object := range []string{"a", "dir/b", "dir/sub/c"} {
+		if _, err = obj.PutObject(bucket, object, int64(len(data)), bytes.NewReader(data), nil, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sets, err := getLocalObjectDistribution(bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 1 || sets[0].Objects != 3 || sets[0].Bytes != 3*int64(len(data)) {
+		t.Fatalf("Expected 3 objects of %d bytes on set 0, got %#v", 3*len(data), sets)
+	}
+}
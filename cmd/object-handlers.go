@@ -18,8 +18,6 @@ package cmd
 
 import (
 	"encoding/hex"
-	"encoding/xml"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
@@ -168,6 +166,7 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 		return
 	}
+	objectAccessed(bucket, object)
 	if !dataWritten {
 		// If ObjectAPI.GetObject did not return error and no data has
 		// been written it would mean that it is a 0-byte object.
@@ -295,6 +294,17 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	objectDWLock.Lock()
 	defer objectDWLock.Unlock()
 
+	if err := checkObjectImmutability(objectAPI, dstBucket, dstObject); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	isNewObject, err := checkBucketObjectLimit(objectAPI, dstBucket, dstObject)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
 	// if source and destination are different, we have to hold
 	// additional read lock as well to protect against writes on
 	// source.
@@ -349,6 +359,9 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+	if isNewObject {
+		globalBucketObjectLimits.add(dstBucket, 1)
+	}
 
 	md5Sum := objInfo.MD5Sum
 	response := generateCopyObjectResponse(md5Sum, objInfo.ModTime)
@@ -363,7 +376,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		Bucket:  dstBucket,
 		ObjInfo: objInfo,
 		ReqParams: map[string]string{
-			"sourceIPAddress": r.RemoteAddr,
+			"sourceIPAddress": getSourceIP(r),
 		},
 	})
 }
@@ -396,6 +409,14 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Get the object checksum sent by client, verified while the
+	// object is stored.
+	checksum, err := getObjectChecksum(r.Header, globalChecksumPolicy.get())
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
 	/// if Content-Length is unknown/missing, deny the request
 	size := r.ContentLength
 	rAuthType := getRequestAuthType(r)
@@ -431,6 +452,11 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	// Make sure we hex encode md5sum here.
 	metadata["md5Sum"] = hex.EncodeToString(md5Bytes)
 
+	// Store the checksum along with the object.
+	if checksum != nil {
+		metadata[checksumHeader(checksum.algorithm)] = checksum.value
+	}
+
 	sha256sum := ""
 
 	// Lock the object.
@@ -438,6 +464,21 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	objectLock.Lock()
 	defer objectLock.Unlock()
 
+	if checkWritePreconditions(w, r, objectAPI, bucket, object) {
+		return
+	}
+
+	if err := checkObjectImmutability(objectAPI, bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	isNewObject, err := checkBucketObjectLimit(objectAPI, bucket, object)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
 	var objInfo ObjectInfo
 	switch rAuthType {
 	default:
@@ -452,7 +493,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			return
 		}
 		// Create anonymous object.
-		objInfo, err = objectAPI.PutObject(bucket, object, size, r.Body, metadata, sha256sum)
+		objInfo, err = objectAPI.PutObject(bucket, object, size, newChecksumReader(r.Body, size, checksum), metadata, sha256sum)
 	case authTypeStreamingSigned:
 		// Initialize stream signature verifier.
 		reader, s3Error := newSignV4ChunkedReader(r)
@@ -461,7 +502,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			writeErrorResponse(w, s3Error, r.URL)
 			return
 		}
-		objInfo, err = objectAPI.PutObject(bucket, object, size, reader, metadata, sha256sum)
+		objInfo, err = objectAPI.PutObject(bucket, object, size, newChecksumReader(reader, size, checksum), metadata, sha256sum)
 	case authTypeSignedV2, authTypePresignedV2:
 		s3Error := isReqAuthenticatedV2(r)
 		if s3Error != ErrNone {
@@ -469,7 +510,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			writeErrorResponse(w, s3Error, r.URL)
 			return
 		}
-		objInfo, err = objectAPI.PutObject(bucket, object, size, r.Body, metadata, sha256sum)
+		objInfo, err = objectAPI.PutObject(bucket, object, size, newChecksumReader(r.Body, size, checksum), metadata, sha256sum)
 	case authTypePresigned, authTypeSigned:
 		if s3Error := reqSignatureV4Verify(r); s3Error != ErrNone {
 			errorIf(errSignatureMismatch, dumpRequest(r))
@@ -480,13 +521,17 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			sha256sum = r.Header.Get("X-Amz-Content-Sha256")
 		}
 		// Create object.
-		objInfo, err = objectAPI.PutObject(bucket, object, size, r.Body, metadata, sha256sum)
+		objInfo, err = objectAPI.PutObject(bucket, object, size, newChecksumReader(r.Body, size, checksum), metadata, sha256sum)
 	}
 	if err != nil {
 		errorIf(err, "Unable to create an object. %s", r.URL.Path)
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+	if isNewObject {
+		globalBucketObjectLimits.add(bucket, 1)
+	}
+	globalWriteAmp.addClientBytes(objInfo.Size)
 	w.Header().Set("ETag", "\""+objInfo.MD5Sum+"\"")
 	writeSuccessResponseHeadersOnly(w)
 
@@ -496,7 +541,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		Bucket:  bucket,
 		ObjInfo: objInfo,
 		ReqParams: map[string]string{
-			"sourceIPAddress": r.RemoteAddr,
+			"sourceIPAddress": getSourceIP(r),
 		},
 	})
 }
@@ -524,7 +569,7 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 	// Extract metadata that needs to be saved.
 	metadata := extractMetadataFromHeader(r.Header)
 
-	uploadID, err := objectAPI.NewMultipartUpload(bucket, object, metadata)
+	uploadID, err := newLimitedMultipartUpload(objectAPI, bucket, object, metadata)
 	if err != nil {
 		errorIf(err, "Unable to initiate new multipart upload id.")
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
@@ -628,7 +673,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	/// maximum copy size for multipart objects in a single operation
-	if isMaxObjectSize(length) {
+	if isMaxBucketPartSize(dstBucket, length) {
 		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
 		return
 	}
@@ -687,7 +732,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	}
 
 	/// maximum Upload size for multipart objects in a single operation
-	if isMaxObjectSize(size) {
+	if isMaxBucketPartSize(bucket, size) {
 		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
 		return
 	}
@@ -759,6 +804,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+	globalWriteAmp.addClientBytes(partInfo.Size)
 	if partInfo.ETag != "" {
 		w.Header().Set("ETag", "\""+partInfo.ETag+"\"")
 	}
@@ -851,14 +897,15 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	// Get upload id.
 	uploadID, _, _, _ := getObjectResources(r.URL.Query())
 
-	completeMultipartBytes, err := ioutil.ReadAll(r.Body)
+	// Parts are decoded as they are read, so that manifests listing
+	// too many parts are rejected without buffering them.
+	complMultipartUpload, err := decodeCompleteMultipartUpload(r.Body, getMaxUploadParts())
 	if err != nil {
-		errorIf(err, "Unable to complete multipart upload.")
-		writeErrorResponse(w, ErrInternalError, r.URL)
-		return
-	}
-	complMultipartUpload := &completeMultipartUpload{}
-	if err = xml.Unmarshal(completeMultipartBytes, complMultipartUpload); err != nil {
+		drainRequestBody(r.Body)
+		if err == errTooManyParts {
+			writeErrorResponse(w, ErrTooManyParts, r.URL)
+			return
+		}
 		errorIf(err, "Unable to parse complete multipart upload XML.")
 		writeErrorResponse(w, ErrMalformedXML, r.URL)
 		return
@@ -885,6 +932,21 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	destLock.Lock()
 	defer destLock.Unlock()
 
+	if checkWritePreconditions(w, r, objectAPI, bucket, object) {
+		return
+	}
+
+	if err := checkObjectImmutability(objectAPI, bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	isNewObject, err := checkBucketObjectLimit(objectAPI, bucket, object)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
 	objInfo, err := objectAPI.CompleteMultipartUpload(bucket, object, uploadID, completeParts)
 	if err != nil {
 		errorIf(err, "Unable to complete multipart upload.")
@@ -892,13 +954,16 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		switch oErr := err.(type) {
 		case PartTooSmall:
 			// Write part too small error.
-			writePartSmallErrorResponse(w, r, oErr)
+			writePartSmallErrorResponse(w, r, bucket, oErr)
 		default:
 			// Handle all other generic issues.
 			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		}
 		return
 	}
+	if isNewObject {
+		globalBucketObjectLimits.add(bucket, 1)
+	}
 
 	// Get object location.
 	location := getLocation(r)
@@ -923,7 +988,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		Bucket:  bucket,
 		ObjInfo: objInfo,
 		ReqParams: map[string]string{
-			"sourceIPAddress": r.RemoteAddr,
+			"sourceIPAddress": getSourceIP(r),
 		},
 	})
 }
@@ -951,6 +1016,16 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 	objectLock.Lock()
 	defer objectLock.Unlock()
 
+	if err := checkObjectImmutability(objectAPI, bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := checkDeleteProtection(objectAPI, bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
 	/// http://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectDELETE.html
 	/// Ignore delete object errors, since we are suppposed to reply
 	/// only 204.
@@ -958,6 +1033,7 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		writeSuccessNoContent(w)
 		return
 	}
+	globalBucketObjectLimits.add(bucket, -1)
 	writeSuccessNoContent(w)
 
 	// Notify object deleted event.
@@ -968,7 +1044,7 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 			Name: object,
 		},
 		ReqParams: map[string]string{
-			"sourceIPAddress": r.RemoteAddr,
+			"sourceIPAddress": getSourceIP(r),
 		},
 	})
 }
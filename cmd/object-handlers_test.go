@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -28,6 +30,7 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 )
@@ -939,6 +942,114 @@ func testAPIPutObjectHandler(obj ObjectLayer, instanceType, bucketName string, a
 
 }
 
+// Wrapper for calling conditional Put Object API handler tests for both XL multiple disks and FS single drive setup.
+func TestAPIPutObjectConditionalHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectConditionalHandler, []string{"PutObject"})
+}
+
+func testAPIPutObjectConditionalHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials credential, t *testing.T) {
+
+	// register event notifier.
+	if err := initEventNotifier(obj); err != nil {
+		t.Fatal("Notifier initialization failed.")
+	}
+	objectName := "test-object"
+	firstData := []byte("first version")
+	secondData := []byte("second version")
+
+	testCases := []struct {
+		data        []byte
+		ifMatch     string
+		ifNoneMatch string
+		disabled    bool
+
+		expectedRespStatus int
+	}{
+		// Test case - 1.
+		// Create only if absent, object doesn't exist yet.
+		{data: firstData, ifNoneMatch: "*", expectedRespStatus: http.StatusOK},
+		// Test case - 2.
+		// Create only if absent, object exists now.
+		{data: secondData, ifNoneMatch: "*", expectedRespStatus: http.StatusPreconditionFailed},
+		// Test case - 3.
+		// Overwrite a stale version.
+		{data: secondData, ifMatch: getMD5Hash(secondData), expectedRespStatus: http.StatusPreconditionFailed},
+		// Test case - 4.
+		// Overwrite the current version.
+		{data: secondData, ifMatch: "\"" + getMD5Hash(firstData) + "\"", expectedRespStatus: http.StatusOK},
+		// Test case - 5.
+		// Overwrite anything but the current version.
+		{data: firstData, ifNoneMatch: getMD5Hash(secondData), expectedRespStatus: http.StatusPreconditionFailed},
+		// Test case - 6.
+		// Conditional writes disabled.
+		{data: firstData, ifMatch: getMD5Hash(secondData), disabled: true, expectedRespStatus: http.StatusNotImplemented},
+		// Test case - 7.
+		// Unconditional writes are unaffected when disabled.
+		{data: firstData, disabled: true, expectedRespStatus: http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		globalConditionalWrites.set(!testCase.disabled)
+
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, objectName),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for Put Object: <ERROR> %v", i+1, err)
+		}
+		if testCase.ifMatch != "" {
+			req.Header.Set("If-Match", testCase.ifMatch)
+		}
+		if testCase.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", testCase.ifNoneMatch)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+	globalConditionalWrites.set(true)
+
+	// Concurrent create only if absent writes, exactly one must win.
+	concurrentObject := "concurrent-object"
+	var wg sync.WaitGroup
+	statuses := make([]int, 10)
+	for i := range statuses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data := []byte(fmt.Sprintf("writer %d", i))
+			req, err := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, concurrentObject),
+				int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+			if err != nil {
+				t.Errorf("Failed to create HTTP request for Put Object: <ERROR> %v", err)
+				return
+			}
+			req.Header.Set("If-None-Match", "*")
+			rec := httptest.NewRecorder()
+			apiRouter.ServeHTTP(rec, req)
+			statuses[i] = rec.Code
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, status := range statuses {
+		switch status {
+		case http.StatusOK:
+			succeeded++
+		case http.StatusPreconditionFailed:
+		default:
+			t.Errorf("%s: Unexpected response status `%d` for concurrent write", instanceType, status)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("%s: Expected exactly one concurrent write to succeed, %d did", instanceType, succeeded)
+	}
+}
+
 // Wrapper for calling Copy Object Part API handler tests for both XL multiple disks and single node setup.
 func TestAPICopyObjectPartHandler(t *testing.T) {
 	defer DetectTestLeak(t)()
@@ -2485,6 +2596,329 @@ func testAPIDeleteObjectHandler(obj ObjectLayer, instanceType, bucketName string
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
+// TestAPIDeleteObjectImmutableHandler - tests deletes and overwrites
+// within the immutability window of a bucket are rejected.
+func TestAPIDeleteObjectImmutableHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIDeleteObjectImmutableHandler, []string{"DeleteObject", "PutObject"})
+}
+
+func testAPIDeleteObjectImmutableHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials credential, t *testing.T) {
+
+	// register event notifier.
+	if err := initEventNotifier(obj); err != nil {
+		t.Fatal("Notifier initialization failed.")
+	}
+
+	// Windows are not extended by clock skew on a single server.
+	defer func(skew time.Duration) { immutabilityClockSkew = skew }(immutabilityClockSkew)
+	immutabilityClockSkew = 0
+
+	window := 500 * time.Millisecond
+	if err := globalImmutabilityWindows.set(bucketName, window); err != nil {
+		t.Fatal(err)
+	}
+	defer globalImmutabilityWindows.set(bucketName, 0)
+
+	objectName := "test-object"
+	data := []byte("immutable data")
+	objInfo, err := obj.PutObject(bucketName, objectName, int64(len(data)), bytes.NewReader(data), nil, "")
+	if err != nil {
+		t.Fatalf("Minio %s: Error uploading object: <ERROR> %v", instanceType, err)
+	}
+
+	deleteObject := func() int {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestSignedRequestV4("DELETE", getDeleteObjectURL("", bucketName, objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey)
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request for Delete Object: <ERROR> %v", rErr)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	putObject := func() int {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request for Put Object: <ERROR> %v", rErr)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Within the window.
+	if code := deleteObject(); code != http.StatusForbidden {
+		t.Fatalf("Minio %s: Expected delete within the window to return %d, got %d", instanceType, http.StatusForbidden, code)
+	}
+	if code := putObject(); code != http.StatusForbidden {
+		t.Fatalf("Minio %s: Expected overwrite within the window to return %d, got %d", instanceType, http.StatusForbidden, code)
+	}
+	if _, err = obj.GetObjectInfo(bucketName, objectName); err != nil {
+		t.Fatalf("Minio %s: Expected object to be kept: %v", instanceType, err)
+	}
+
+	// After the window.
+	time.Sleep(objInfo.ModTime.Add(window).Sub(time.Now().UTC()) + 50*time.Millisecond)
+	if code := deleteObject(); code != http.StatusNoContent {
+		t.Fatalf("Minio %s: Expected delete after the window to return %d, got %d", instanceType, http.StatusNoContent, code)
+	}
+	if _, err = obj.GetObjectInfo(bucketName, objectName); !isErrObjectNotFound(err) {
+		t.Fatalf("Minio %s: Expected object to be deleted, got %v", instanceType, err)
+	}
+}
+
+// TestAPIPutObjectLimitHandler - tests new objects are rejected once a
+// bucket holds as many objects as its limit allows, while existing
+// objects can still be overwritten.
+func TestAPIPutObjectLimitHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectLimitHandler, []string{"DeleteObject", "PutObject"})
+}
+
+func testAPIPutObjectLimitHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials credential, t *testing.T) {
+
+	// register event notifier.
+	if err := initEventNotifier(obj); err != nil {
+		t.Fatal("Notifier initialization failed.")
+	}
+
+	// Objects are counted through the global object layer.
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer resetGlobalObjectAPI()
+	defer resetGlobalBucketObjectLimits()
+
+	data := []byte("limited data")
+	for _, objectName := range []string{"object1", "object2"} {
+		if _, err := obj.PutObject(bucketName, objectName, int64(len(data)), bytes.NewReader(data), nil, ""); err != nil {
+			t.Fatalf("Minio %s: Error uploading object: <ERROR> %v", instanceType, err)
+		}
+	}
+
+	if err := globalBucketObjectLimits.set(bucketName, 2); err != nil {
+		t.Fatal(err)
+	}
+	waitForObjectCount(t, bucketName)
+
+	putObject := func(objectName string) int {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request for Put Object: <ERROR> %v", rErr)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	deleteObject := func(objectName string) int {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestSignedRequestV4("DELETE", getDeleteObjectURL("", bucketName, objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey)
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request for Delete Object: <ERROR> %v", rErr)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// At the limit.
+	if code := putObject("object3"); code != http.StatusForbidden {
+		t.Fatalf("Minio %s: Expected new object at the limit to return %d, got %d", instanceType, http.StatusForbidden, code)
+	}
+	if _, err := obj.GetObjectInfo(bucketName, "object3"); !isErrObjectNotFound(err) {
+		t.Fatalf("Minio %s: Expected object not to be created, got %v", instanceType, err)
+	}
+	if code := putObject("object1"); code != http.StatusOK {
+		t.Fatalf("Minio %s: Expected overwrite at the limit to return %d, got %d", instanceType, http.StatusOK, code)
+	}
+
+	// Deleting an object makes room for one new object.
+	if code := deleteObject("object2"); code != http.StatusNoContent {
+		t.Fatalf("Minio %s: Expected delete to return %d, got %d", instanceType, http.StatusNoContent, code)
+	}
+	if code := putObject("object3"); code != http.StatusOK {
+		t.Fatalf("Minio %s: Expected new object below the limit to return %d, got %d", instanceType, http.StatusOK, code)
+	}
+	if code := putObject("object4"); code != http.StatusForbidden {
+		t.Fatalf("Minio %s: Expected new object at the limit to return %d, got %d", instanceType, http.StatusForbidden, code)
+	}
+	if limit := globalBucketObjectLimits.get(bucketName); limit.Objects != 2 {
+		t.Fatalf("Minio %s: Expected 2 objects counted, got %v", instanceType, limit)
+	}
+}
+
+// TestAPIPutObjectChecksumHandler - tests object checksums are verified
+// against the received data and stored, and required when the checksum
+// policy says so.
+func TestAPIPutObjectChecksumHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectChecksumHandler, []string{"PutObject"})
+}
+
+func testAPIPutObjectChecksumHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials credential, t *testing.T) {
+
+	// register event notifier.
+	if err := initEventNotifier(obj); err != nil {
+		t.Fatal("Notifier initialization failed.")
+	}
+	defer resetGlobalChecksumPolicy()
+
+	data := []byte("checksummed data")
+	sum := sha256.Sum256(data)
+	checksum := base64.StdEncoding.EncodeToString(sum[:])
+	corrupted := append([]byte{}, data...)
+	corrupted[0] ^= 0xff
+
+	putObject := func(objectName string, data []byte, checksum string) int {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestRequest("PUT", getPutObjectURL("", bucketName, objectName),
+			int64(len(data)), bytes.NewReader(data))
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request for Put Object: <ERROR> %v", rErr)
+		}
+		if checksum != "" {
+			req.Header.Set("X-Amz-Checksum-Sha256", checksum)
+		}
+		if rErr = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); rErr != nil {
+			t.Fatalf("Failed to sign HTTP request for Put Object: <ERROR> %v", rErr)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// A matching checksum is stored with the object.
+	if code := putObject("object1", data, checksum); code != http.StatusOK {
+		t.Fatalf("Minio %s: Expected matching checksum to return %d, got %d", instanceType, http.StatusOK, code)
+	}
+	objInfo, err := obj.GetObjectInfo(bucketName, "object1")
+	if err != nil {
+		t.Fatalf("Minio %s: %v", instanceType, err)
+	}
+	if stored := objInfo.UserDefined["X-Amz-Checksum-Sha256"]; stored != checksum {
+		t.Fatalf("Minio %s: Expected checksum %s to be stored, got %s", instanceType, checksum, stored)
+	}
+
+	// A mismatching checksum is rejected and nothing is stored.
+	if code := putObject("object2", corrupted, checksum); code != http.StatusBadRequest {
+		t.Fatalf("Minio %s: Expected mismatching checksum to return %d, got %d", instanceType, http.StatusBadRequest, code)
+	}
+	if _, err = obj.GetObjectInfo(bucketName, "object2"); !isErrObjectNotFound(err) {
+		t.Fatalf("Minio %s: Expected object not to be created, got %v", instanceType, err)
+	}
+
+	// Checksums are optional until required.
+	if code := putObject("object3", data, ""); code != http.StatusOK {
+		t.Fatalf("Minio %s: Expected optional checksum to return %d, got %d", instanceType, http.StatusOK, code)
+	}
+	if err = globalChecksumPolicy.set(true, []string{"SHA256"}); err != nil {
+		t.Fatal(err)
+	}
+	if code := putObject("object4", data, ""); code != http.StatusBadRequest {
+		t.Fatalf("Minio %s: Expected missing checksum to return %d, got %d", instanceType, http.StatusBadRequest, code)
+	}
+	if code := putObject("object4", data, checksum); code != http.StatusOK {
+		t.Fatalf("Minio %s: Expected required checksum to return %d, got %d", instanceType, http.StatusOK, code)
+	}
+}
+
+// TestAPIDeleteObjectProtectedHandler - tests deletes of objects
+// carrying the delete protection tag of their bucket are rejected.
+func TestAPIDeleteObjectProtectedHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIDeleteObjectProtectedHandler, []string{"DeleteObject", "DeleteMultipleObjects"})
+}
+
+func testAPIDeleteObjectProtectedHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials credential, t *testing.T) {
+
+	// register event notifier.
+	if err := initEventNotifier(obj); err != nil {
+		t.Fatal("Notifier initialization failed.")
+	}
+
+	if err := globalDeleteProtectionTags.set(bucketName, DeleteProtectionTag{Key: "retain", Value: "yes"}); err != nil {
+		t.Fatal(err)
+	}
+	defer globalDeleteProtectionTags.set(bucketName, DeleteProtectionTag{})
+
+	data := []byte("protected data")
+	putObject := func(objectName string, metadata map[string]string) {
+		if _, err := obj.PutObject(bucketName, objectName, int64(len(data)), bytes.NewReader(data), metadata, ""); err != nil {
+			t.Fatalf("Minio %s: Error uploading object: <ERROR> %v", instanceType, err)
+		}
+	}
+	tagged := map[string]string{"X-Amz-Meta-Retain": "yes"}
+	putObject("protected", tagged)
+	putObject("other-value", map[string]string{"X-Amz-Meta-Retain": "no"})
+	putObject("untagged", nil)
+
+	deleteObject := func(objectName string) int {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestSignedRequestV4("DELETE", getDeleteObjectURL("", bucketName, objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey)
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request for Delete Object: <ERROR> %v", rErr)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// The tagged object is kept.
+	if code := deleteObject("protected"); code != http.StatusForbidden {
+		t.Fatalf("Minio %s: Expected delete of tagged object to return %d, got %d", instanceType, http.StatusForbidden, code)
+	}
+	if _, err := obj.GetObjectInfo(bucketName, "protected"); err != nil {
+		t.Fatalf("Minio %s: Expected object to be kept: %v", instanceType, err)
+	}
+	// The tag value must match.
+	if code := deleteObject("other-value"); code != http.StatusNoContent {
+		t.Fatalf("Minio %s: Expected delete of object with another tag value to return %d, got %d", instanceType, http.StatusNoContent, code)
+	}
+
+	// Bulk deletes skip the protected object and delete the others.
+	putObject("untagged", nil)
+	request := encodeResponse(DeleteObjectsRequest{Objects: []ObjectIdentifier{{"protected"}, {"untagged"}}})
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4("POST", getDeleteMultipleObjectsURL("", bucketName),
+		int64(len(request)), bytes.NewReader(request), credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request for DeleteMultipleObjects: <ERROR> %v", err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Minio %s: Expected bulk delete to return %d, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+	response := DeleteObjectsResponse{}
+	if err = xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.DeletedObjects) != 1 || response.DeletedObjects[0].ObjectName != "untagged" {
+		t.Fatalf("Minio %s: Expected only the untagged object to be deleted, got %v", instanceType, response.DeletedObjects)
+	}
+	if len(response.Errors) != 1 || response.Errors[0].Key != "protected" ||
+		response.Errors[0].Code != getAPIError(ErrObjectDeleteProtected).Code {
+		t.Fatalf("Minio %s: Expected an error for the protected object, got %v", instanceType, response.Errors)
+	}
+	if _, err = obj.GetObjectInfo(bucketName, "protected"); err != nil {
+		t.Fatalf("Minio %s: Expected object to be kept: %v", instanceType, err)
+	}
+
+	// Removing the tag lifts the protection.
+	putObject("protected", nil)
+	if code := deleteObject("protected"); code != http.StatusNoContent {
+		t.Fatalf("Minio %s: Expected delete of untagged object to return %d, got %d", instanceType, http.StatusNoContent, code)
+	}
+	if _, err = obj.GetObjectInfo(bucketName, "protected"); !isErrObjectNotFound(err) {
+		t.Fatalf("Minio %s: Expected object to be deleted, got %v", instanceType, err)
+	}
+}
+
 // TestAPIPutObjectPartHandlerPreSign - Tests validate the response of PutObjectPart HTTP handler
 // when the request signature type is PreSign.
 func TestAPIPutObjectPartHandlerPreSign(t *testing.T) {
@@ -3257,3 +3691,56 @@ func testAPIListObjectPartsHandler(obj ObjectLayer, instanceType, bucketName str
 	// `ExecObjectLayerAPINilTest` sets the Object Layer to `nil` and calls the handler.
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
+
+// Tests complete multipart upload rejects manifests listing more parts
+// than allowed.
+func TestAPICompleteMultipartTooManyPartsHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPICompleteMultipartTooManyPartsHandler, []string{"CompleteMultipart"})
+}
+
+func testAPICompleteMultipartTooManyPartsHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials credential, t *testing.T) {
+
+	defer resetGlobalMaxUploadParts()
+	if err := setMaxUploadParts(2); err != nil {
+		t.Fatal(err)
+	}
+
+	objectName := "test-object-too-many-parts"
+	uploadID, err := obj.NewMultipartUpload(bucketName, objectName, nil)
+	if err != nil {
+		t.Fatalf("Minio %s: <ERROR> %s", instanceType, err)
+	}
+
+	completeUpload := &completeMultipartUpload{}
+	for i := 1; i <= 3; i++ {
+		completeUpload.Parts = append(completeUpload.Parts, completePart{PartNumber: i, ETag: "abcd"})
+	}
+	completeBytes, err := xml.Marshal(completeUpload)
+	if err != nil {
+		t.Fatalf("Error XML encoding of parts: <ERROR> %s.", err)
+	}
+
+	req, err := newTestSignedRequestV4("POST", getCompleteMultipartUploadURL("", bucketName, objectName, uploadID),
+		int64(len(completeBytes)), bytes.NewReader(completeBytes), credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request for CompleteMultipartUpload: <ERROR> %v", err)
+	}
+	rec := httptest.NewRecorder()
+	apiRouter.ServeHTTP(rec, req)
+
+	expectedContent := encodeResponse(getAPIErrorResponse(getAPIError(ErrTooManyParts),
+		getGetObjectURL("", bucketName, objectName)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Minio %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if !bytes.Equal(rec.Body.Bytes(), expectedContent) {
+		t.Fatalf("Minio %s: Expected %s, got %s", instanceType, expectedContent, rec.Body.Bytes())
+	}
+
+	// The upload is left untouched.
+	if _, err = obj.ListObjectParts(bucketName, objectName, uploadID, 0, 1); err != nil {
+		t.Fatalf("Minio %s: Expected upload %s to still exist: %v", instanceType, uploadID, err)
+	}
+}
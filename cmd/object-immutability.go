@@ -0,0 +1,214 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Bucket immutability window config name.
+const bucketImmutabilityConfig = "immutability.json"
+
+var (
+	// errInvalidImmutabilityWindow - immutability window is negative.
+	errInvalidImmutabilityWindow = errors.New("Immutability window must not be negative")
+
+	// errObjectImmutable - object is still within the immutability
+	// window of its bucket.
+	errObjectImmutable = errors.New("Object can not be deleted or overwritten within the immutability window of its bucket")
+)
+
+// Objects are stamped with the clock of the server which wrote them,
+// which may be behind the clock of the server checking the window.
+// Windows are extended by the largest skew tolerated between servers,
+// see getPeerVersionClockMatrix, so that objects are never released
+// early by a server whose clock is ahead.
+var immutabilityClockSkew = globalMaxSkewTime
+
+// ImmutabilityWindow - time after creation during which objects of a
+// bucket can not be deleted or overwritten, zero if they can always be.
+type ImmutabilityWindow struct {
+	Window time.Duration `json:"window"`
+}
+
+// immutabilityWindows - immutability windows of all buckets, kept in
+// memory on every server and persisted under the bucket config prefix.
+type immutabilityWindows struct {
+	mutex   sync.RWMutex
+	windows map[string]time.Duration
+}
+
+var globalImmutabilityWindows = newImmutabilityWindows(nil)
+
+// newImmutabilityWindows - returns immutability windows initialized
+// with windows.
+func newImmutabilityWindows(windows map[string]time.Duration) *immutabilityWindows {
+	if windows == nil {
+		windows = make(map[string]time.Duration)
+	}
+	return &immutabilityWindows{windows: windows}
+}
+
+// get - returns the immutability window of bucket.
+func (i *immutabilityWindows) get(bucket string) time.Duration {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	return i.windows[bucket]
+}
+
+// set - sets the immutability window of bucket, zero removes it.
+func (i *immutabilityWindows) set(bucket string, window time.Duration) error {
+	if window < 0 {
+		return errInvalidImmutabilityWindow
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if window == 0 {
+		delete(i.windows, bucket)
+		return nil
+	}
+	i.windows[bucket] = window
+	return nil
+}
+
+// isObjectImmutable - returns true if an object created at modTime is
+// still within window at now, accounting for clock skew.
+func isObjectImmutable(modTime time.Time, window time.Duration, now time.Time) bool {
+	if window <= 0 {
+		return false
+	}
+	return now.Before(modTime.Add(window + immutabilityClockSkew))
+}
+
+// checkObjectImmutability - returns errObjectImmutable if object exists
+// and is within the immutability window of bucket. Must be called with
+// a write lock held on object.
+func checkObjectImmutability(objAPI ObjectLayer, bucket, object string) error {
+	window := globalImmutabilityWindows.get(bucket)
+	if window <= 0 {
+		return nil
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(bucket, object)
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if isObjectImmutable(objInfo.ModTime, window, time.Now().UTC()) {
+		return errObjectImmutable
+	}
+	return nil
+}
+
+// readImmutabilityWindow - reads the persisted immutability window of
+// bucket, zero if none was set.
+func readImmutabilityWindow(bucket string, objAPI ObjectLayer) (time.Duration, error) {
+	windowPath := pathJoin(bucketConfigPrefix, bucket, bucketImmutabilityConfig)
+
+	// Acquire a read lock on immutability config before reading.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, windowPath)
+	objLock.RLock()
+	defer objLock.RUnlock()
+
+	var buffer bytes.Buffer
+	if err := objAPI.GetObject(minioMetaBucket, windowPath, 0, -1, &buffer); err != nil {
+		if isErrObjectNotFound(err) || isErrIncompleteBody(err) {
+			return 0, nil
+		}
+		errorIf(err, "Unable to load immutability window for the bucket %s.", bucket)
+		return 0, errorCause(err)
+	}
+
+	var window ImmutabilityWindow
+	if err := json.Unmarshal(buffer.Bytes(), &window); err != nil {
+		return 0, err
+	}
+	return window.Window, nil
+}
+
+// writeImmutabilityWindow - persists the immutability window of
+// bucket, zero removes any previously persisted window.
+func writeImmutabilityWindow(bucket string, objAPI ObjectLayer, window time.Duration) error {
+	windowPath := pathJoin(bucketConfigPrefix, bucket, bucketImmutabilityConfig)
+
+	// Acquire a write lock on immutability config before modifying.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, windowPath)
+	objLock.Lock()
+	defer objLock.Unlock()
+
+	if window == 0 {
+		if err := objAPI.DeleteObject(minioMetaBucket, windowPath); err != nil && !isErrObjectNotFound(err) {
+			errorIf(err, "Unable to remove immutability window of the bucket %s.", bucket)
+			return errorCause(err)
+		}
+		return nil
+	}
+
+	buf, err := json.Marshal(ImmutabilityWindow{Window: window})
+	if err != nil {
+		return err
+	}
+	if _, err = objAPI.PutObject(minioMetaBucket, windowPath, int64(len(buf)), bytes.NewReader(buf), nil, ""); err != nil {
+		errorIf(err, "Unable to set immutability window for the bucket %s.", bucket)
+		return errorCause(err)
+	}
+	return nil
+}
+
+// removeImmutabilityWindow - removes persisted and in-memory
+// immutability window of a bucket being deleted.
+func removeImmutabilityWindow(bucket string, objAPI ObjectLayer) error {
+	globalImmutabilityWindows.set(bucket, 0)
+	return writeImmutabilityWindow(bucket, objAPI, 0)
+}
+
+// initImmutabilityWindows - loads the immutability windows of all
+// buckets.
+func initImmutabilityWindows(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	buckets, err := objAPI.ListBuckets()
+	if err != nil {
+		errorIf(err, "Unable to list buckets.")
+		return errorCause(err)
+	}
+
+	windows := make(map[string]time.Duration)
+	for _, bucket := range buckets {
+		window, wErr := readImmutabilityWindow(bucket.Name, objAPI)
+		if wErr != nil {
+			if isErrIgnored(wErr, errDiskNotFound) {
+				continue
+			}
+			return wErr
+		}
+		if window > 0 {
+			windows[bucket.Name] = window
+		}
+	}
+
+	globalImmutabilityWindows = newImmutabilityWindows(windows)
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestIsObjectImmutable - tests the immutability window, extended by
+// the tolerated clock skew.
+func TestIsObjectImmutable(t *testing.T) {
+	now := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	window := time.Hour
+
+	testCases := []struct {
+		modTime   time.Time
+		window    time.Duration
+		immutable bool
+	}{
+		// No window.
+		{now, 0, false},
+		// Created just now.
+		{now, window, true},
+		// Created by a server whose clock is ahead.
+		{now.Add(time.Minute), window, true},
+		// Window elapsed, but not the tolerated skew.
+		{now.Add(-window - immutabilityClockSkew/2), window, true},
+		// Window and tolerated skew elapsed.
+		{now.Add(-window - immutabilityClockSkew), window, false},
+		{now.Add(-24 * time.Hour), window, false},
+	}
+	for i, testCase := range testCases {
+		if immutable := isObjectImmutable(testCase.modTime, testCase.window, now); immutable != testCase.immutable {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.immutable, immutable)
+		}
+	}
+}
+
+// TestImmutabilityWindowsSet - tests setting immutability windows.
+func TestImmutabilityWindowsSet(t *testing.T) {
+	windows := newImmutabilityWindows(nil)
+	if err := windows.set("bucket", -time.Second); err != errInvalidImmutabilityWindow {
+		t.Fatalf("Expected %v, got %v", errInvalidImmutabilityWindow, err)
+	}
+	if err := windows.set("bucket", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if window := windows.get("bucket"); window != time.Hour {
+		t.Fatalf("Expected %v, got %v", time.Hour, window)
+	}
+	if err := windows.set("bucket", 0); err != nil {
+		t.Fatal(err)
+	}
+	if window := windows.get("bucket"); window != 0 {
+		t.Fatalf("Expected no window, got %v", window)
+	}
+}
+
+// Wrapper for calling immutability window persistence tests for both
+// XL multiple disks and single node setup.
+func TestImmutabilityWindowPersistence(t *testing.T) {
+	ExecObjectLayerTest(t, testImmutabilityWindowPersistence)
+}
+
+func testImmutabilityWindowPersistence(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucket := "immutable-bucket"
+	if err := obj.MakeBucket(bucket); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	window, err := readImmutabilityWindow(bucket, obj)
+	if err != nil || window != 0 {
+		t.Fatalf("%s: Expected no window, got %v, %v", instanceType, window, err)
+	}
+
+	if err = writeImmutabilityWindow(bucket, obj, time.Hour); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if window, err = readImmutabilityWindow(bucket, obj); err != nil || window != time.Hour {
+		t.Fatalf("%s: Expected %v, got %v, %v", instanceType, time.Hour, window, err)
+	}
+
+	// Windows are loaded into memory on startup.
+	if err = initImmutabilityWindows(obj); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if window = globalImmutabilityWindows.get(bucket); window != time.Hour {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, time.Hour, window)
+	}
+
+	// Removing the window, as done when deleting the bucket.
+	if err = removeImmutabilityWindow(bucket, obj); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if window = globalImmutabilityWindows.get(bucket); window != 0 {
+		t.Fatalf("%s: Expected no window in memory, got %v", instanceType, window)
+	}
+	if window, err = readImmutabilityWindow(bucket, obj); err != nil || window != 0 {
+		t.Fatalf("%s: Expected no persisted window, got %v, %v", instanceType, window, err)
+	}
+}
@@ -737,7 +737,8 @@ func (s *posix) AppendFile(volume, path string, buf []byte) (err error) {
 	defer s.pool.Put(bufp)
 
 	// Return io.Copy
-	_, err = io.CopyBuffer(w, bytes.NewReader(buf), *bufp)
+	n, err := io.CopyBuffer(w, bytes.NewReader(buf), *bufp)
+	globalWriteAmp.addDiskBytes(n)
 	return err
 }
 
@@ -212,6 +212,10 @@ func retryFormattingXLDisks(firstDisk bool, endpoints []*url.URL, storageDisks [
 		return time.Now().Round(time.Second).Sub(formatStartTime).String()
 	}
 
+	// Errors of disks on the first attempt, disks coming online
+	// later are recorded as startup errors.
+	var firstErrs []error
+
 	// Wait on the jitter retry loop.
 	retryTimerCh := newRetryTimerSimple(doneCh)
 	for {
@@ -219,6 +223,9 @@ func retryFormattingXLDisks(firstDisk bool, endpoints []*url.URL, storageDisks [
 		case retryCount := <-retryTimerCh:
 			// Attempt to load all `format.json` from all disks.
 			formatConfigs, sErrs := loadAllFormats(storageDisks)
+			if firstErrs == nil {
+				firstErrs = sErrs
+			}
 			if retryCount > 5 {
 				// After 5 retry attempts we start printing actual errors
 				// for disks not being available.
@@ -237,10 +244,15 @@ func retryFormattingXLDisks(firstDisk bool, endpoints []*url.URL, storageDisks [
 			case Abort:
 				return errCorruptedFormat
 			case FormatDisks:
+				// Fresh disks are not formatted yet.
+				globalBootDiagnostics.recordDiskErrors(endpoints, firstErrs, sErrs, time.Since(formatStartTime), errUnformattedDisk)
+				globalBootDiagnostics.next(bootPhaseFirstQuorum, bootPhaseFormatCheck)
 				console.Eraseline()
 				printFormatMsg(endpoints, storageDisks, printOnceFn())
 				return initFormatXL(storageDisks)
 			case InitObjectLayer:
+				globalBootDiagnostics.recordDiskErrors(endpoints, firstErrs, sErrs, time.Since(formatStartTime))
+				globalBootDiagnostics.next(bootPhaseFirstQuorum, bootPhaseFormatCheck)
 				console.Eraseline()
 				// Validate formats loaded before proceeding forward.
 				err := genericFormatCheckXL(formatConfigs, sErrs)
@@ -249,6 +261,8 @@ func retryFormattingXLDisks(firstDisk bool, endpoints []*url.URL, storageDisks [
 				}
 				return err
 			case WaitForHeal:
+				globalBootDiagnostics.recordDiskErrors(endpoints, firstErrs, sErrs, time.Since(formatStartTime))
+				globalBootDiagnostics.next(bootPhaseFirstQuorum, bootPhaseFormatCheck)
 				// Validate formats loaded before proceeding forward.
 				err := genericFormatCheckXL(formatConfigs, sErrs)
 				if err == nil {
@@ -321,10 +335,12 @@ func waitForFormatXLDisks(firstDisk bool, endpoints []*url.URL, storageDisks []S
 
 	// Start retry loop retrying until disks are formatted properly, until we have reached
 	// a conditional quorum of formatted disks.
+	globalBootDiagnostics.begin(bootPhaseFirstQuorum)
 	err = retryFormattingXLDisks(firstDisk, endpoints, retryDisks)
 	if err != nil {
 		return nil, err
 	}
+	globalBootDiagnostics.end(bootPhaseFormatCheck)
 
 	// Initialize the disk into a formatted disks wrapper.
 	formattedDisks = make([]StorageAPI, len(storageDisks))
@@ -0,0 +1,83 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// maxPresignExpiry - the largest expiry S3 allows on a presigned URL.
+const maxPresignExpiry = 7 * 24 * time.Hour
+
+// Presigned URL expiry limit config name.
+const presignExpiryConfig = "presign-expiry.json"
+
+// Maximum expiry accepted for presigned URLs in nanoseconds, can be
+// lowered at runtime via admin RPC.
+var globalMaxPresignExpiry = int64(maxPresignExpiry)
+
+// getMaxPresignExpiry - returns the current presigned URL expiry limit.
+func getMaxPresignExpiry() time.Duration {
+	return time.Duration(atomic.LoadInt64(&globalMaxPresignExpiry))
+}
+
+// setMaxPresignExpiry - sets the presigned URL expiry limit, the
+// limit must be positive and can not exceed the S3 maximum of 7 days.
+//
+// The limit is applied when a presigned URL is validated, not only
+// when it is generated. Lowering it therefore rejects URLs that were
+// issued earlier with a longer expiry, even if they have not expired
+// yet. This is intentional, tightening the limit should take effect
+// on every outstanding URL.
+func setMaxPresignExpiry(expiry time.Duration) error {
+	if expiry <= 0 || expiry > maxPresignExpiry {
+		return errInvalidArgument
+	}
+	atomic.StoreInt64(&globalMaxPresignExpiry, int64(expiry))
+	return nil
+}
+
+// writeMaxPresignExpiry - persists the presigned URL expiry limit, the
+// S3 maximum removes any previously persisted limit.
+func writeMaxPresignExpiry(objAPI ObjectLayer, expiry time.Duration) error {
+	if expiry == maxPresignExpiry {
+		return writeServerSetting(objAPI, presignExpiryConfig, nil)
+	}
+	return writeServerSetting(objAPI, presignExpiryConfig, PresignExpiry{Expiry: expiry})
+}
+
+// initMaxPresignExpiry - loads the presigned URL expiry limit, so that
+// a restarted server rejects the same URLs as its peers.
+func initMaxPresignExpiry(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	var limit PresignExpiry
+	ok, err := readServerSetting(objAPI, presignExpiryConfig, &limit)
+	if err != nil {
+		if isErrIgnored(err, errDiskNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	return setMaxPresignExpiry(limit.Expiry)
+}
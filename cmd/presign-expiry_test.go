@@ -0,0 +1,155 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// Tests validation of the presigned URL expiry limit.
+func TestSetMaxPresignExpiry(t *testing.T) {
+	defer resetGlobalMaxPresignExpiry()
+
+	testCases := []struct {
+		expiry      time.Duration
+		expectedErr error
+	}{
+		{time.Hour, nil},
+		{maxPresignExpiry, nil},
+		{0, errInvalidArgument},
+		{-time.Hour, errInvalidArgument},
+		{maxPresignExpiry + time.Second, errInvalidArgument},
+	}
+	for i, testCase := range testCases {
+		err := setMaxPresignExpiry(testCase.expiry)
+		if err != testCase.expectedErr {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && getMaxPresignExpiry() != testCase.expiry {
+			t.Errorf("Test %d: Expected limit %v, got %v", i+1, testCase.expiry, getMaxPresignExpiry())
+		}
+	}
+}
+
+// Tests that generated presigned URLs are capped and that URLs
+// issued with a longer expiry are rejected under a tighter limit.
+func TestPresignExpiryLimit(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(rootPath)
+	defer resetGlobalMaxPresignExpiry()
+
+	cred := serverConfig.GetCredential()
+	newPresignedReq := func(presign func(*http.Request, string, string, int64) error, expires time.Duration) *http.Request {
+		req, rerr := newTestRequest("GET", "/bucket/object", 0, nil)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		if rerr = presign(req, cred.AccessKey, cred.SecretKey, int64(expires.Seconds())); rerr != nil {
+			t.Fatal(rerr)
+		}
+		// Set by the http server on incoming requests, signature
+		// V2 verification depends on it.
+		req.RequestURI = req.URL.RequestURI()
+		return req
+	}
+
+	// Issued while the limit is still the default 7 days.
+	longV4 := newPresignedReq(preSignV4, 2*time.Hour)
+	longV2 := newPresignedReq(preSignV2, 2*time.Hour)
+	if code := doesPresignedSignatureMatch(unsignedPayload, longV4, globalMinioDefaultRegion); code != ErrNone {
+		t.Fatalf("Expected presigned V4 URL to be valid, got %v", code)
+	}
+	if code := doesPresignV2SignatureMatch(longV2); code != ErrNone {
+		t.Fatalf("Expected presigned V2 URL to be valid, got %v", code)
+	}
+
+	if err = setMaxPresignExpiry(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	// Previously issued URLs valid for longer than the new limit
+	// must now be rejected.
+	if code := doesPresignedSignatureMatch(unsignedPayload, longV4, globalMinioDefaultRegion); code != ErrMaximumExpires {
+		t.Errorf("Expected presigned V4 URL to be rejected with %v, got %v", ErrMaximumExpires, code)
+	}
+	if code := doesPresignV2SignatureMatch(longV2); code != ErrMaximumExpires {
+		t.Errorf("Expected presigned V2 URL to be rejected with %v, got %v", ErrMaximumExpires, code)
+	}
+
+	// URLs within the limit continue to work.
+	shortV4 := newPresignedReq(preSignV4, 30*time.Minute)
+	if code := doesPresignedSignatureMatch(unsignedPayload, shortV4, globalMinioDefaultRegion); code != ErrNone {
+		t.Errorf("Expected presigned V4 URL within limit to be valid, got %v", code)
+	}
+
+	// Generated URLs are capped at the limit.
+	presignedURL, err := url.Parse("http://" + presignedGet("127.0.0.1:9000", "bucket", "object", int64((48*time.Hour).Seconds())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expires := presignedURL.Query().Get("X-Amz-Expires"); expires != "3600" {
+		t.Errorf("Expected presigned URL expiry to be capped at 3600, got %s", expires)
+	}
+}
+
+// TestMaxPresignExpiryRestart - tests a restarted server loads the
+// persisted presigned URL expiry limit.
+func TestMaxPresignExpiryRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalMaxPresignExpiry()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	if err = writeMaxPresignExpiry(objLayer, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, the limit is loaded again.
+	resetGlobalMaxPresignExpiry()
+	if err = initMaxPresignExpiry(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if expiry := getMaxPresignExpiry(); expiry != time.Hour {
+		t.Fatalf("Expected limit %v after restart, got %v", time.Hour, expiry)
+	}
+
+	// Going back to the S3 maximum removes the persisted limit.
+	if err = writeMaxPresignExpiry(objLayer, maxPresignExpiry); err != nil {
+		t.Fatal(err)
+	}
+	resetGlobalMaxPresignExpiry()
+	if err = initMaxPresignExpiry(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if expiry := getMaxPresignExpiry(); expiry != maxPresignExpiry {
+		t.Fatalf("Expected limit %v, got %v", maxPresignExpiry, expiry)
+	}
+}
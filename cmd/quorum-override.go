@@ -0,0 +1,158 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Longest a quorum override lasts before it expires.
+const maxQuorumOverrideTTL = 24 * time.Hour
+
+var (
+	// errInvalidQuorumOverride - quorum override is below the number of
+	// data drives, above the number of drives, or lasts too long.
+	errInvalidQuorumOverride = errors.New("Quorum override must be within the number of data drives and of all drives, and last at most 24 hours")
+
+	// errQuorumOverridden - logged while the quorum computed by XL is
+	// overridden.
+	errQuorumOverridden = errors.New("XL quorum is overridden for emergency recovery")
+)
+
+// QuorumOverride - read and write quorum used by XL instead of the
+// ones it computes, until Expiry. A zero quorum keeps the computed one.
+//
+// Quorums may not go below the number of data drives: an object read
+// from fewer drives can not be decoded, and an object written to fewer
+// drives is lost. Lowering the write quorum down to the number of data
+// drives lets servers write with half of the drives, at the risk of
+// two halves of the cluster writing different versions of an object.
+type QuorumOverride struct {
+	ReadQuorum  int       `json:"readQuorum"`
+	WriteQuorum int       `json:"writeQuorum"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// quorumOverride - quorum override of this server, can be changed at
+// runtime via admin RPC. It is kept in memory only, a restart forgets
+// it.
+type quorumOverride struct {
+	mutex    sync.Mutex
+	override QuorumOverride
+}
+
+var globalQuorumOverride = &quorumOverride{}
+
+// get - returns the override in effect at now, false if none. An
+// expired override is dropped.
+func (q *quorumOverride) get(now time.Time) (QuorumOverride, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.override.Expiry.IsZero() {
+		return QuorumOverride{}, false
+	}
+	if !now.Before(q.override.Expiry) {
+		errorIf(errQuorumOverridden, "Quorum override expired at %s, using the computed quorum again.", q.override.Expiry)
+		q.override = QuorumOverride{}
+		return QuorumOverride{}, false
+	}
+	return q.override, true
+}
+
+// set - replaces the override, a zero override removes it.
+func (q *quorumOverride) set(override QuorumOverride) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.override = override
+}
+
+// validateQuorumOverride - returns errInvalidQuorumOverride if
+// readQuorum or writeQuorum is negative or ttl out of range. A zero ttl
+// removes the override.
+func validateQuorumOverride(readQuorum, writeQuorum int, ttl time.Duration) error {
+	if readQuorum < 0 || writeQuorum < 0 || ttl < 0 || ttl > maxQuorumOverrideTTL {
+		return errInvalidQuorumOverride
+	}
+	if ttl > 0 && readQuorum == 0 && writeQuorum == 0 {
+		return errInvalidQuorumOverride
+	}
+	return nil
+}
+
+// setQuorumOverride - overrides the quorum of xl with readQuorum and
+// writeQuorum for ttl, rejecting quorums below the number of data
+// drives. A zero ttl removes the override.
+func setQuorumOverride(xl *xlObjects, readQuorum, writeQuorum int, ttl time.Duration) error {
+	if err := validateQuorumOverride(readQuorum, writeQuorum, ttl); err != nil {
+		return err
+	}
+	if ttl == 0 {
+		globalQuorumOverride.set(QuorumOverride{})
+		return nil
+	}
+
+	drives := len(xl.storageDisks)
+	for _, quorum := range []int{readQuorum, writeQuorum} {
+		if quorum != 0 && (quorum < xl.dataBlocks || quorum > drives) {
+			return errInvalidQuorumOverride
+		}
+	}
+
+	override := QuorumOverride{
+		ReadQuorum:  readQuorum,
+		WriteQuorum: writeQuorum,
+		Expiry:      time.Now().UTC().Add(ttl),
+	}
+	globalQuorumOverride.set(override)
+	errorIf(errQuorumOverridden, "Quorum overridden to read %d, write %d of %d drives until %s, objects written meanwhile may be inconsistent.",
+		override.ReadQuorum, override.WriteQuorum, drives, override.Expiry)
+	return nil
+}
+
+// setLocalQuorumOverride - overrides the quorum of the object layer of
+// this server.
+func setLocalQuorumOverride(readQuorum, writeQuorum int, ttl time.Duration) error {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return errServerNotInitialized
+	}
+	xl, ok := objLayer.(*xlObjects)
+	if !ok {
+		return errUnsupportedBackend
+	}
+	return setQuorumOverride(xl, readQuorum, writeQuorum, ttl)
+}
+
+// getReadQuorum - returns the read quorum, overridden during emergency
+// recovery.
+func (xl xlObjects) getReadQuorum() int {
+	if override, ok := globalQuorumOverride.get(time.Now()); ok && override.ReadQuorum != 0 {
+		return override.ReadQuorum
+	}
+	return xl.readQuorum
+}
+
+// getWriteQuorum - returns the write quorum, overridden during
+// emergency recovery.
+func (xl xlObjects) getWriteQuorum() int {
+	if override, ok := globalQuorumOverride.get(time.Now()); ok && override.WriteQuorum != 0 {
+		return override.WriteQuorum
+	}
+	return xl.writeQuorum
+}
@@ -0,0 +1,160 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestSetQuorumOverride - tests overrides below the number of data
+// drives, above the number of drives or lasting too long are rejected.
+func TestSetQuorumOverride(t *testing.T) {
+	defer resetGlobalQuorumOverride()
+
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	obj, fsDirs, err := prepareXL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	xl := obj.(*xlObjects)
+
+	testCases := []struct {
+		readQuorum  int
+		writeQuorum int
+		ttl         time.Duration
+		expectedErr error
+	}{
+		{8, 8, time.Hour, nil},
+		{0, 8, maxQuorumOverrideTTL, nil},
+		{16, 0, time.Minute, nil},
+		// Zero ttl removes the override.
+		{0, 0, 0, nil},
+		// Below the number of data drives.
+		{7, 8, time.Hour, errInvalidQuorumOverride},
+		{0, 1, time.Hour, errInvalidQuorumOverride},
+		// Above the number of drives.
+		{17, 0, time.Hour, errInvalidQuorumOverride},
+		{-1, 8, time.Hour, errInvalidQuorumOverride},
+		// Too long, or overriding nothing.
+		{8, 8, maxQuorumOverrideTTL + time.Second, errInvalidQuorumOverride},
+		{8, 8, -time.Hour, errInvalidQuorumOverride},
+		{0, 0, time.Hour, errInvalidQuorumOverride},
+	}
+	for i, testCase := range testCases {
+		resetGlobalQuorumOverride()
+		err := setQuorumOverride(xl, testCase.readQuorum, testCase.writeQuorum, testCase.ttl)
+		if err != testCase.expectedErr {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		_, ok := globalQuorumOverride.get(time.Now())
+		if expected := err == nil && testCase.ttl > 0; ok != expected {
+			t.Fatalf("Test %d: expected override %v, got %v", i+1, expected, ok)
+		}
+	}
+}
+
+// TestQuorumOverrideExpiry - tests an override is dropped once expired.
+func TestQuorumOverrideExpiry(t *testing.T) {
+	q := &quorumOverride{}
+	expiry := time.Now().UTC().Add(time.Hour)
+	q.set(QuorumOverride{WriteQuorum: 8, Expiry: expiry})
+
+	if override, ok := q.get(expiry.Add(-time.Second)); !ok || override.WriteQuorum != 8 {
+		t.Fatalf("Expected write quorum 8 in effect, got %v, %v", override, ok)
+	}
+	if _, ok := q.get(expiry); ok {
+		t.Fatal("Expected override to expire")
+	}
+	// Dropped for good, even when asked about an earlier time.
+	if _, ok := q.get(expiry.Add(-time.Second)); ok {
+		t.Fatal("Expected expired override to be dropped")
+	}
+}
+
+// TestQuorumOverrideXL - tests XL writes with half of its drives while
+// the write quorum is overridden, and not once the override expires.
+func TestQuorumOverrideXL(t *testing.T) {
+	defer resetGlobalQuorumOverride()
+
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	obj, fsDirs, err := prepareXL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	xl := obj.(*xlObjects)
+
+	bucket := "bucket"
+	if err = obj.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	// Take 8 of the 16 drives offline, one short of the write quorum.
+	for i := range xl.storageDisks[:8] {
+		xl.storageDisks[i] = newNaughtyDisk(xl.storageDisks[i].(*retryStorage), nil, errFaultyDisk)
+	}
+	data := []byte("abcd")
+	_, err = obj.PutObject(bucket, "object", int64(len(data)), bytes.NewReader(data), nil, "")
+	if errorCause(err) != toObjectErr(errXLWriteQuorum, bucket, "object") {
+		t.Fatalf("Expected %v, got %v", errXLWriteQuorum, err)
+	}
+
+	if err = setQuorumOverride(xl, 0, 8, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if quorum := xl.getWriteQuorum(); quorum != 8 {
+		t.Fatalf("Expected write quorum 8, got %d", quorum)
+	}
+	if quorum := xl.getReadQuorum(); quorum != xl.readQuorum {
+		t.Fatalf("Expected read quorum %d, got %d", xl.readQuorum, quorum)
+	}
+	if _, err = obj.PutObject(bucket, "object", int64(len(data)), bytes.NewReader(data), nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = obj.GetObject(bucket, "object", 0, int64(len(data)), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("Expected %q, got %q", data, buf.Bytes())
+	}
+
+	// Expire the override.
+	override, _ := globalQuorumOverride.get(time.Now())
+	override.Expiry = time.Now().UTC().Add(-time.Second)
+	globalQuorumOverride.set(override)
+	if quorum := xl.getWriteQuorum(); quorum != xl.writeQuorum {
+		t.Fatalf("Expected write quorum %d, got %d", xl.writeQuorum, quorum)
+	}
+	_, err = obj.PutObject(bucket, "object2", int64(len(data)), bytes.NewReader(data), nil, "")
+	if errorCause(err) != toObjectErr(errXLWriteQuorum, bucket, "object2") {
+		t.Fatalf("Expected %v, got %v", errXLWriteQuorum, err)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// Difference, in percentage points, between the fullest and the
+	// emptiest disk of a set beyond which rebalancing is recommended.
+	rebalanceSkewThreshold = 10.0
+
+	// Fill percentage of a set below which rebalancing is never
+	// recommended, skew of nearly empty disks is a few bytes at most.
+	rebalanceMinSetFill = 10.0
+)
+
+// DiskFill - space used on a disk of an erasure set.
+type DiskFill struct {
+	Set      int
+	Endpoint string
+	Total    int64
+	Used     int64
+}
+
+// SkewReport - spread of the fill percentages of the disks of an
+// erasure set.
+type SkewReport struct {
+	Set   int `json:"set"`
+	Disks int `json:"disks"` // Disks reporting their usage.
+
+	MinFill    float64 `json:"minFill"`
+	MaxFill    float64 `json:"maxFill"`
+	StdDevFill float64 `json:"stdDevFill"`
+	SetFill    float64 `json:"setFill"` // Fill of all disks taken together.
+
+	RebalanceRecommended bool `json:"rebalanceRecommended"`
+}
+
+// getLocalDiskFill - returns the space used on the disks of this
+// server, offline disks are skipped.
+func getLocalDiskFill() ([]DiskFill, error) {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return nil, errServerNotInitialized
+	}
+	if _, ok := objLayer.(*xlObjects); !ok {
+		return nil, errUnsupportedBackend
+	}
+
+	fills := []DiskFill{}
+	for _, ep := range globalEndpoints {
+		if !isLocalStorage(ep) {
+			continue
+		}
+		disk, err := newStorageAPI(ep)
+		if err != nil {
+			continue
+		}
+		info, err := disk.DiskInfo()
+		if err != nil || info.Total <= 0 {
+			continue
+		}
+		// XL has a single set made of all disks.
+		fills = append(fills, DiskFill{
+			Set:      0,
+			Endpoint: ep.String(),
+			Total:    info.Total,
+			Used:     info.Total - info.Free,
+		})
+	}
+	return fills, nil
+}
+
+// newSkewReports - returns the skew of every set the disks in fills
+// belong to, in set order.
+func newSkewReports(fills []DiskFill) []SkewReport {
+	sets := make(map[int][]DiskFill)
+	for _, fill := range fills {
+		sets[fill.Set] = append(sets[fill.Set], fill)
+	}
+
+	var indices []int
+	for set := range sets {
+		indices = append(indices, set)
+	}
+	sort.Ints(indices)
+
+	reports := []SkewReport{}
+	for _, set := range indices {
+		reports = append(reports, newSkewReport(set, sets[set]))
+	}
+	return reports
+}
+
+// newSkewReport - returns the skew of the disks of set.
+func newSkewReport(set int, disks []DiskFill) SkewReport {
+	report := SkewReport{Set: set, Disks: len(disks)}
+
+	var total, used int64
+	var sum float64
+	percents := make([]float64, len(disks))
+	for i, disk := range disks {
+		total += disk.Total
+		used += disk.Used
+		percents[i] = 100 * float64(disk.Used) / float64(disk.Total)
+		sum += percents[i]
+		if i == 0 || percents[i] < report.MinFill {
+			report.MinFill = percents[i]
+		}
+		if i == 0 || percents[i] > report.MaxFill {
+			report.MaxFill = percents[i]
+		}
+	}
+	if len(disks) == 0 || total == 0 {
+		return report
+	}
+
+	mean := sum / float64(len(disks))
+	var variance float64
+	for _, percent := range percents {
+		variance += (percent - mean) * (percent - mean)
+	}
+	report.StdDevFill = math.Sqrt(variance / float64(len(disks)))
+	report.SetFill = 100 * float64(used) / float64(total)
+	report.RebalanceRecommended = report.SetFill >= rebalanceMinSetFill &&
+		report.MaxFill-report.MinFill > rebalanceSkewThreshold
+	return report
+}
@@ -0,0 +1,90 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"math"
+	"testing"
+)
+
+// diskFills - returns disks of set with the given used space out of
+// 1000 bytes each.
+func diskFills(set int, used ...int64) []DiskFill {
+	fills := make([]DiskFill, len(used))
+	for i, u := range used {
+		fills[i] = DiskFill{Set: set, Total: 1000, Used: u}
+	}
+	return fills
+}
+
+// TestNewSkewReports - tests the rebalance recommendation with skewed,
+// balanced and nearly empty sets.
+func TestNewSkewReports(t *testing.T) {
+	testCases := []struct {
+		fills       []DiskFill
+		minFill     float64
+		maxFill     float64
+		stdDevFill  float64
+		recommended bool
+	}{
+		// Balanced disks.
+		{diskFills(0, 500, 520, 480, 500), 48, 52, math.Sqrt(2), false},
+		// One disk 40 points fuller than the others.
+		{diskFills(0, 300, 300, 300, 700), 30, 70, math.Sqrt(300), true},
+		// Skew exactly at the threshold is tolerated.
+		{diskFills(0, 400, 500), 40, 50, 5, false},
+		// Huge relative skew of nearly empty disks is not worth
+		// moving data for.
+		{diskFills(0, 0, 0, 0, 200), 0, 20, math.Sqrt(75), false},
+		// A single disk has no skew.
+		{diskFills(0, 900), 90, 90, 0, false},
+	}
+	for i, testCase := range testCases {
+		reports := newSkewReports(testCase.fills)
+		if len(reports) != 1 {
+			t.Fatalf("Test %d: expected 1 report, got %d", i+1, len(reports))
+		}
+		report := reports[0]
+		if report.Disks != len(testCase.fills) {
+			t.Errorf("Test %d: expected %d disks, got %d", i+1, len(testCase.fills), report.Disks)
+		}
+		if report.MinFill != testCase.minFill || report.MaxFill != testCase.maxFill {
+			t.Errorf("Test %d: expected fill between %v and %v, got %v and %v", i+1,
+				testCase.minFill, testCase.maxFill, report.MinFill, report.MaxFill)
+		}
+		if math.Abs(report.StdDevFill-testCase.stdDevFill) > 1e-9 {
+			t.Errorf("Test %d: expected standard deviation %v, got %v", i+1, testCase.stdDevFill, report.StdDevFill)
+		}
+		if report.RebalanceRecommended != testCase.recommended {
+			t.Errorf("Test %d: expected recommendation %v, got %v", i+1, testCase.recommended, report.RebalanceRecommended)
+		}
+	}
+
+	// Disks are grouped per set, reported in set order.
+	fills := append(diskFills(1, 300, 700), diskFills(0, 500, 500)...)
+	reports := newSkewReports(fills)
+	if len(reports) != 2 || reports[0].Set != 0 || reports[1].Set != 1 {
+		t.Fatalf("Expected reports of sets 0 and 1, got %v", reports)
+	}
+	if reports[0].RebalanceRecommended || !reports[1].RebalanceRecommended {
+		t.Fatalf("Expected rebalancing of set 1 only, got %v", reports)
+	}
+
+	if reports = newSkewReports(nil); len(reports) != 0 {
+		t.Fatalf("Expected no reports, got %v", reports)
+	}
+}
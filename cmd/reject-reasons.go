@@ -0,0 +1,134 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// Rejected requests are kept for this long, the longest window
+	// they can be counted over.
+	rejectReasonMaxWindow = time.Hour
+
+	// Rejections are counted in slots of this duration, the oldest
+	// slot is dropped as a whole when the window moves on.
+	rejectReasonSlotDuration = time.Minute
+)
+
+// Reasons requests are rejected for.
+const (
+	rejectQuotaExceeded = iota
+	rejectAuthFailed
+	rejectNotFound
+	rejectQuorumUnavailable
+	rejectOther
+	rejectReasons
+)
+
+// Names of the reasons requests are rejected for, as reported.
+var rejectReasonNames = [rejectReasons]string{
+	rejectQuotaExceeded:     "quota-exceeded",
+	rejectAuthFailed:        "auth-failed",
+	rejectNotFound:          "not-found",
+	rejectQuorumUnavailable: "quorum-unavailable",
+	rejectOther:             "other",
+}
+
+// rejectReason - returns the reason a request answered with errorCode
+// was rejected for. No API of this server rate limits requests.
+func rejectReason(errorCode APIErrorCode) int {
+	switch errorCode {
+	case ErrBucketObjectLimitExceeded, ErrStorageFull, ErrTooManyParts, ErrTooManyDeleteObjects:
+		return rejectQuotaExceeded
+	case ErrAccessDenied, ErrInvalidAccessKeyID, ErrSignatureDoesNotMatch, ErrAccessKeyExpired,
+		ErrExpiredPresignRequest, ErrRequestTimeTooSkewed, ErrAllAccessDisabled:
+		return rejectAuthFailed
+	case ErrNoSuchBucket, ErrNoSuchKey, ErrNoSuchUpload, ErrNoSuchBucketPolicy:
+		return rejectNotFound
+	case ErrReadQuorum, ErrWriteQuorum, ErrAdminQuorumTimeout, ErrServerNotInitialized:
+		return rejectQuorumUnavailable
+	}
+	return rejectOther
+}
+
+// rejectReasonSlot - rejections counted during the slot starting at
+// start, by reason.
+type rejectReasonSlot struct {
+	start  time.Time
+	counts [rejectReasons]int64
+}
+
+// rejectReasonCounters - requests rejected by this server over the
+// last rejectReasonMaxWindow, by reason. Successful requests are not
+// counted.
+type rejectReasonCounters struct {
+	mutex sync.Mutex
+	slots []rejectReasonSlot
+}
+
+func newRejectReasonCounters() *rejectReasonCounters {
+	return &rejectReasonCounters{
+		slots: make([]rejectReasonSlot, rejectReasonMaxWindow/rejectReasonSlotDuration),
+	}
+}
+
+var globalRejectReasons = newRejectReasonCounters()
+
+// add - counts a request rejected with errorCode at now.
+func (c *rejectReasonCounters) add(now time.Time, errorCode APIErrorCode) {
+	start := now.Truncate(rejectReasonSlotDuration)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	slot := &c.slots[int(start.UnixNano()/int64(rejectReasonSlotDuration))%len(c.slots)]
+	if !slot.start.Equal(start) {
+		// Reused slot, drop rejections counted a window ago.
+		*slot = rejectReasonSlot{start: start}
+	}
+	slot.counts[rejectReason(errorCode)]++
+}
+
+// record - counts a request rejected with errorCode.
+func (c *rejectReasonCounters) record(errorCode APIErrorCode) {
+	c.add(time.Now().UTC(), errorCode)
+}
+
+// get - returns the requests rejected over window ending at now, by
+// reason. Reasons no request was rejected for are left out.
+func (c *rejectReasonCounters) get(window time.Duration, now time.Time) (map[string]int, error) {
+	if window <= 0 || window > rejectReasonMaxWindow {
+		return nil, errInvalidArgument
+	}
+	oldest := now.Truncate(rejectReasonSlotDuration).Add(-window + rejectReasonSlotDuration)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	reasons := make(map[string]int)
+	for _, slot := range c.slots {
+		if slot.start.Before(oldest) || slot.start.After(now) {
+			continue
+		}
+		for i, n := range slot.counts {
+			if n > 0 {
+				reasons[rejectReasonNames[i]] += int(n)
+			}
+		}
+	}
+	return reasons, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Tests counting rejected requests by reason over a window.
+func TestRejectReasonCounters(t *testing.T) {
+	c := newRejectReasonCounters()
+	now := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Kept longer than any window, dropped.
+	c.add(now.Add(-rejectReasonMaxWindow), ErrWriteQuorum)
+	c.add(now.Add(-10*time.Minute), ErrNoSuchKey)
+	c.add(now, ErrNoSuchBucket)
+	c.add(now, ErrSignatureDoesNotMatch)
+	c.add(now, ErrAccessKeyExpired)
+	c.add(now, ErrBucketObjectLimitExceeded)
+	c.add(now, ErrMalformedXML)
+
+	expected := map[string]int{
+		"not-found":      2,
+		"auth-failed":    2,
+		"quota-exceeded": 1,
+		"other":          1,
+	}
+	reasons, err := c.get(rejectReasonMaxWindow, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Fatalf("Expected %v, got %v", expected, reasons)
+	}
+
+	// A shorter window leaves out older rejections.
+	expected["not-found"] = 1
+	if reasons, err = c.get(5*time.Minute, now); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Fatalf("Expected %v, got %v", expected, reasons)
+	}
+
+	for _, window := range []time.Duration{0, rejectReasonMaxWindow + time.Minute} {
+		if _, err = c.get(window, now); err != errInvalidArgument {
+			t.Fatalf("Expected %v for window %v, got %v", errInvalidArgument, window, err)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Longest client supplied request ID echoed back, longer IDs are
+// truncated.
+const maxClientRequestIDLength = 128
+
+// errInvalidRequestIDHeader - request ID header name is not a valid
+// HTTP header name, or names a header the server sets itself.
+var errInvalidRequestIDHeader = errors.New("Request ID header must be a valid header name not used by the server")
+
+// Headers which carry protocol or object meaning and can not be used
+// to carry the request ID.
+var reservedRequestIDHeaders = map[string]bool{
+	"Accept-Ranges":     true,
+	"Authorization":     true,
+	"Connection":        true,
+	"Content-Length":    true,
+	"Content-Range":     true,
+	"Content-Type":      true,
+	"Date":              true,
+	"Etag":              true,
+	"Last-Modified":     true,
+	"Location":          true,
+	"Server":            true,
+	"Set-Cookie":        true,
+	"Transfer-Encoding": true,
+}
+
+// isValidRequestIDHeader - returns true if name is an HTTP token, as
+// defined in RFC 7230, and not a reserved header.
+func isValidRequestIDHeader(name string) bool {
+	if name == "" || reservedRequestIDHeaders[http.CanonicalHeaderKey(name)] {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeRequestID - returns id with everything but letters, digits,
+// '-', '_' and '.' removed, truncated to maxClientRequestIDLength. Client
+// IDs end up in logs and response headers, line breaks and other
+// control characters would let a client forge log entries and headers.
+func sanitizeRequestID(id string) string {
+	sanitized := make([]byte, 0, len(id))
+	for i := 0; i < len(id) && len(sanitized) < maxClientRequestIDLength; i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.':
+		default:
+			continue
+		}
+		sanitized = append(sanitized, c)
+	}
+	return string(sanitized)
+}
+
+// RequestIDConfig - header carrying the request ID in responses, and
+// whether an ID sent by the client in that header is echoed back
+// instead of generating one.
+type RequestIDConfig struct {
+	Header        string `json:"header"`
+	HonorClientID bool   `json:"honorClientID"`
+}
+
+// requestIDConfig - request ID configuration of this server, can be
+// changed at runtime via admin RPC.
+type requestIDConfig struct {
+	mutex  sync.RWMutex
+	config RequestIDConfig
+}
+
+func newRequestIDConfig() *requestIDConfig {
+	return &requestIDConfig{config: RequestIDConfig{Header: responseRequestIDKey}}
+}
+
+var globalRequestIDConfig = newRequestIDConfig()
+
+// get - returns the current request ID configuration.
+func (c *requestIDConfig) get() RequestIDConfig {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.config
+}
+
+// set - changes the header carrying the request ID, and whether client
+// supplied IDs are honored.
+func (c *requestIDConfig) set(header string, honorClientID bool) error {
+	if !isValidRequestIDHeader(header) {
+		return errInvalidRequestIDHeader
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.config = RequestIDConfig{Header: http.CanonicalHeaderKey(header), HonorClientID: honorClientID}
+	return nil
+}
+
+// requestID - returns the ID of r, the sanitized ID sent by the client
+// if honored and not empty once sanitized, a generated one otherwise.
+func (c *requestIDConfig) requestID(r *http.Request) (header, id string) {
+	config := c.get()
+	if config.HonorClientID {
+		if id = sanitizeRequestID(r.Header.Get(config.Header)); id != "" {
+			return config.Header, id
+		}
+	}
+	return config.Header, mustGetRequestID(time.Now().UTC())
+}
+
+// requestIDHandler - sets the request ID header of responses before
+// serving requests, so that it is set even by handlers not writing
+// common headers.
+type requestIDHandler struct {
+	handler http.Handler
+}
+
+func setRequestIDHandler(h http.Handler) http.Handler {
+	return requestIDHandler{handler: h}
+}
+
+func (h requestIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	header, id := globalRequestIDConfig.requestID(r)
+	w.Header().Set(header, id)
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,130 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Tests sanitizing client supplied request IDs.
+func TestSanitizeRequestID(t *testing.T) {
+	testCases := []struct {
+		id       string
+		expected string
+	}{
+		{"3L137", "3L137"},
+		{"trace-id_1.2", "trace-id_1.2"},
+		// Line breaks would forge log entries and headers.
+		{"abc\r\nX-Injected: 1", "abcX-Injected1"},
+		{"abc\n2017/01/01 forged entry", "abc20170101forgedentry"},
+		{"\x00\x1b[31m", "31m"},
+		{"<script>", "script"},
+		{"\r\n", ""},
+		{strings.Repeat("a", 2*maxClientRequestIDLength), strings.Repeat("a", maxClientRequestIDLength)},
+	}
+	for i, testCase := range testCases {
+		if id := sanitizeRequestID(testCase.id); id != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, id)
+		}
+	}
+}
+
+// Tests validating request ID header names.
+func TestRequestIDConfigSet(t *testing.T) {
+	c := newRequestIDConfig()
+	for _, header := range []string{"", "X Request", "X-Request:", "X-Req\r\nId", "content-length", "Server"} {
+		if err := c.set(header, true); err != errInvalidRequestIDHeader {
+			t.Errorf("%q: expected %v, got %v", header, errInvalidRequestIDHeader, err)
+		}
+	}
+	if config := c.get(); config.Header != responseRequestIDKey || config.HonorClientID {
+		t.Fatalf("Expected unchanged config, got %+v", config)
+	}
+
+	if err := c.set("x-request-id", true); err != nil {
+		t.Fatal(err)
+	}
+	if config := c.get(); config.Header != "X-Request-Id" || !config.HonorClientID {
+		t.Fatalf("Unexpected config %+v", config)
+	}
+}
+
+// Tests the request ID header set on responses.
+func TestRequestIDHandler(t *testing.T) {
+	defer resetGlobalRequestIDConfig()
+
+	handler := setRequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeSuccessResponseHeadersOnly(w)
+	}))
+	serve := func(clientID string) http.Header {
+		req, err := http.NewRequest("GET", "/bucket", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if clientID != "" {
+			req.Header.Set("X-Request-Id", clientID)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Header()
+	}
+
+	// Default header, client IDs ignored.
+	header := serve("client-id")
+	if header.Get(responseRequestIDKey) == "" {
+		t.Fatalf("Expected %s to be set", responseRequestIDKey)
+	}
+	if header.Get("X-Request-Id") != "" {
+		t.Fatal("Expected client ID not to be echoed")
+	}
+
+	// Custom header, client IDs ignored.
+	if err := globalRequestIDConfig.set("X-Request-Id", false); err != nil {
+		t.Fatal(err)
+	}
+	header = serve("client-id")
+	if id := header.Get("X-Request-Id"); id == "" || id == "client-id" {
+		t.Fatalf("Expected a generated ID, got %q", id)
+	}
+	if header.Get(responseRequestIDKey) != "" {
+		t.Fatalf("Expected %s not to be set", responseRequestIDKey)
+	}
+	if len(header["X-Request-Id"]) != 1 {
+		t.Fatalf("Expected a single request ID, got %v", header["X-Request-Id"])
+	}
+
+	// Client IDs echoed once sanitized.
+	if err := globalRequestIDConfig.set("X-Request-Id", true); err != nil {
+		t.Fatal(err)
+	}
+	if id := serve("client-id").Get("X-Request-Id"); id != "client-id" {
+		t.Fatalf("Expected client ID to be echoed, got %q", id)
+	}
+	if id := serve("client\r\n-id").Get("X-Request-Id"); id != "client-id" {
+		t.Fatalf("Expected sanitized client ID, got %q", id)
+	}
+	// Nothing left once sanitized, an ID is generated.
+	if id := serve("\r\n").Get("X-Request-Id"); id == "" {
+		t.Fatal("Expected a generated ID")
+	}
+	if id := serve("").Get("X-Request-Id"); id == "" {
+		t.Fatal("Expected a generated ID")
+	}
+}
@@ -103,10 +103,15 @@ func configureServerHandler(srvCmdConfig serverCmdConfig) (http.Handler, error)
 		// Validates all incoming URL resources, for invalid/unsupported
 		// resources client receives a HTTP error.
 		setIgnoreResourcesHandler,
+		// Rejects writes during read-only maintenance windows.
+		setMaintenanceHandler,
 		// Auth handler verifies incoming authorization headers and
 		// routes them accordingly. Client receives a HTTP error for
 		// invalid/unsupported signatures.
 		setAuthHandler,
+		// Sets the request ID header of all responses, including
+		// requests rejected by the handlers above.
+		setRequestIDHandler,
 		// Add new handlers here.
 	}
 
@@ -0,0 +1,103 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RPCConnStats - usage of the RPC clients of a server connecting to
+// one remote server. Each client multiplexes its calls over a single
+// connection, calls only wait while a connection is being established.
+type RPCConnStats struct {
+	Addr      string `json:"addr"`
+	Clients   int    `json:"clients"`   // RPC clients, one per remote service.
+	Connected int    `json:"connected"` // Clients with an established connection.
+	Busy      int64  `json:"busy"`      // Calls waiting for a reply.
+	Calls     int64  `json:"calls"`     // Calls made.
+
+	// Average time calls waited for a connection.
+	AvgConnWait time.Duration `json:"avgConnWait"`
+}
+
+// NodeRPCConnStats - usage of the RPC clients of a server.
+type NodeRPCConnStats struct {
+	Addr  string         `json:"addr"`
+	Conns []RPCConnStats `json:"conns"`
+}
+
+// byRPCConnAddr - sorts RPC connection stats by remote address.
+type byRPCConnAddr []RPCConnStats
+
+func (s byRPCConnAddr) Len() int           { return len(s) }
+func (s byRPCConnAddr) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byRPCConnAddr) Less(i, j int) bool { return s[i].Addr < s[j].Addr }
+
+// rpcClientRegistry - all RPC clients of this server, by remote
+// address and service endpoint.
+type rpcClientRegistry struct {
+	mutex   sync.Mutex
+	clients map[string]*RPCClient
+}
+
+var globalRPCClients = &rpcClientRegistry{clients: make(map[string]*RPCClient)}
+
+// register - adds rpcClient, replacing any previous client of the
+// same remote service.
+func (r *rpcClientRegistry) register(rpcClient *RPCClient) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.clients[rpcClient.serverAddr+rpcClient.serviceEndpoint] = rpcClient
+}
+
+// stats - returns usage of the RPC clients grouped by remote address.
+// Only atomic counters are read, so that reporting never waits on a
+// client busy connecting.
+func (r *rpcClientRegistry) stats() []RPCConnStats {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	byAddr := make(map[string]*RPCConnStats)
+	waits := make(map[string]int64)
+	for _, rpcClient := range r.clients {
+		stats, ok := byAddr[rpcClient.serverAddr]
+		if !ok {
+			stats = &RPCConnStats{Addr: rpcClient.serverAddr}
+			byAddr[rpcClient.serverAddr] = stats
+		}
+		stats.Clients++
+		if atomic.LoadInt32(&rpcClient.connected) == 1 {
+			stats.Connected++
+		}
+		stats.Busy += atomic.LoadInt64(&rpcClient.inFlight)
+		stats.Calls += atomic.LoadInt64(&rpcClient.calls)
+		waits[rpcClient.serverAddr] += atomic.LoadInt64(&rpcClient.waitNanos)
+	}
+
+	conns := []RPCConnStats{}
+	for addr, stats := range byAddr {
+		if stats.Calls > 0 {
+			stats.AvgConnWait = time.Duration(waits[addr] / stats.Calls)
+		}
+		conns = append(conns, *stats)
+	}
+	sort.Sort(byRPCConnAddr(conns))
+	return conns
+}
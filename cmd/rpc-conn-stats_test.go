@@ -0,0 +1,75 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Tests RPC client usage is reported per remote server.
+func TestRPCClientRegistryStats(t *testing.T) {
+	registry := &rpcClientRegistry{clients: make(map[string]*RPCClient)}
+	if stats := registry.stats(); len(stats) != 0 {
+		t.Fatalf("Expected no stats, got %v", stats)
+	}
+
+	newClient := func(addr, endpoint string, connected int32, inFlight, calls int64, wait time.Duration) {
+		registry.register(&RPCClient{
+			serverAddr:      addr,
+			serviceEndpoint: endpoint,
+			connected:       connected,
+			inFlight:        inFlight,
+			calls:           calls,
+			waitNanos:       int64(wait),
+		})
+	}
+	// Idle server.
+	newClient("server1:9000", "/storage/disk1", 1, 0, 10, 10*time.Millisecond)
+	// Saturated server, every client busy and calls waiting on connecting.
+	newClient("server2:9000", "/storage/disk1", 1, 8, 100, time.Second)
+	newClient("server2:9000", "/storage/disk2", 1, 12, 100, 3*time.Second)
+	newClient("server2:9000", "/lock", 0, 0, 0, 0)
+	// Replaced client of a remote service is no longer counted.
+	newClient("server3:9000", "/admin", 1, 5, 5, time.Second)
+	newClient("server3:9000", "/admin", 0, 0, 0, 0)
+
+	expected := []RPCConnStats{
+		{Addr: "server1:9000", Clients: 1, Connected: 1, Busy: 0, Calls: 10, AvgConnWait: time.Millisecond},
+		{Addr: "server2:9000", Clients: 3, Connected: 2, Busy: 20, Calls: 200, AvgConnWait: 20 * time.Millisecond},
+		{Addr: "server3:9000", Clients: 1},
+	}
+	if stats := registry.stats(); !reflect.DeepEqual(stats, expected) {
+		t.Fatalf("Expected %v, got %v", expected, stats)
+	}
+}
+
+// Tests call statistics of an RPC client.
+func TestRPCClientCallStats(t *testing.T) {
+	// Nothing listens on port 1, connecting fails.
+	rpcClient := newRPCClient("127.0.0.1:1", "/storage", false)
+	if err := rpcClient.Call("Storage.DiskInfo", &AuthRPCArgs{}, &AuthRPCReply{}); err == nil {
+		t.Fatal("Expected call to fail")
+	}
+	if rpcClient.calls != 1 || rpcClient.inFlight != 0 || rpcClient.connected != 0 {
+		t.Fatalf("Unexpected stats calls=%d inFlight=%d connected=%d", rpcClient.calls, rpcClient.inFlight, rpcClient.connected)
+	}
+	if rpcClient.waitNanos <= 0 {
+		t.Fatal("Expected time waited for connection to be recorded")
+	}
+}
@@ -0,0 +1,200 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/rpc"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// Admin RPC errors are counted over the errors of this window.
+	rpcErrorWindow = 15 * time.Minute
+
+	// Errors are counted in slots of this duration, the oldest slot
+	// is dropped as a whole when the window moves on.
+	rpcErrorSlotDuration = time.Minute
+
+	// Authentication failures calling a peer within the window from
+	// which, if they are also its most common error, its credentials
+	// likely drifted from ours.
+	rpcAuthFailureSpike = 3
+)
+
+// Categories of admin RPC errors.
+const (
+	rpcErrorTimeout = iota
+	rpcErrorConnection
+	rpcErrorAuth
+	rpcErrorApplication
+	rpcErrorCategories
+)
+
+// rpcErrorCategory - returns the category of err returned by an admin
+// RPC call.
+func rpcErrorCategory(err error) int {
+	if err == context.DeadlineExceeded {
+		return rpcErrorTimeout
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return rpcErrorTimeout
+	}
+	switch err.(type) {
+	case *net.OpError:
+		return rpcErrorConnection
+	}
+	if err == rpc.ErrShutdown || err == io.EOF || err == io.ErrUnexpectedEOF {
+		return rpcErrorConnection
+	}
+	// Errors of the remote server lose their identity over RPC.
+	switch err.Error() {
+	case errAuthentication.Error(), errInvalidToken.Error():
+		return rpcErrorAuth
+	}
+	return rpcErrorApplication
+}
+
+// RPCErrorCounts - admin RPC errors by category.
+type RPCErrorCounts struct {
+	Timeout           int64 `json:"timeout"`
+	ConnectionRefused int64 `json:"connectionRefused"`
+	AuthFailure       int64 `json:"authFailure"`
+	ApplicationError  int64 `json:"applicationError"`
+}
+
+// total - returns the number of errors of all categories.
+func (c RPCErrorCounts) total() int64 {
+	return c.Timeout + c.ConnectionRefused + c.AuthFailure + c.ApplicationError
+}
+
+// add - adds the counts of other.
+func (c *RPCErrorCounts) add(other RPCErrorCounts) {
+	c.Timeout += other.Timeout
+	c.ConnectionRefused += other.ConnectionRefused
+	c.AuthFailure += other.AuthFailure
+	c.ApplicationError += other.ApplicationError
+}
+
+// PeerRPCErrors - admin RPC errors calling a peer. CredentialDrift is
+// set when calls mostly fail to authenticate, as happens when the peer
+// runs with other credentials.
+type PeerRPCErrors struct {
+	Addr string `json:"addr"`
+	RPCErrorCounts
+	CredentialDrift bool `json:"credentialDrift"`
+}
+
+// byPeerRPCErrorsAddr - sorts RPC errors by peer address.
+type byPeerRPCErrorsAddr []PeerRPCErrors
+
+func (p byPeerRPCErrorsAddr) Len() int           { return len(p) }
+func (p byPeerRPCErrorsAddr) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p byPeerRPCErrorsAddr) Less(i, j int) bool { return p[i].Addr < p[j].Addr }
+
+// isCredentialDrift - returns true if counts look like the peer does
+// not accept our credentials: repeated authentication failures which
+// are its most common error.
+func isCredentialDrift(counts RPCErrorCounts) bool {
+	return counts.AuthFailure >= rpcAuthFailureSpike && 2*counts.AuthFailure >= counts.total()
+}
+
+// rpcErrorSlot - errors counted during the slot starting at start.
+type rpcErrorSlot struct {
+	start  time.Time
+	counts [rpcErrorCategories]int64
+}
+
+// rpcErrorCounters - errors of admin RPC calls of this server to its
+// peers over the last rpcErrorWindow, by peer address.
+type rpcErrorCounters struct {
+	mutex sync.Mutex
+	peers map[string][]rpcErrorSlot
+}
+
+func newRPCErrorCounters() *rpcErrorCounters {
+	return &rpcErrorCounters{peers: make(map[string][]rpcErrorSlot)}
+}
+
+var globalRPCErrors = newRPCErrorCounters()
+
+// add - counts an error of category calling addr at now.
+func (c *rpcErrorCounters) add(now time.Time, addr string, category int) {
+	start := now.Truncate(rpcErrorSlotDuration)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	slots, ok := c.peers[addr]
+	if !ok {
+		slots = make([]rpcErrorSlot, rpcErrorWindow/rpcErrorSlotDuration)
+		c.peers[addr] = slots
+	}
+	slot := &slots[int(start.UnixNano()/int64(rpcErrorSlotDuration))%len(slots)]
+	if !slot.start.Equal(start) {
+		// Reused slot, drop errors counted a window ago.
+		*slot = rpcErrorSlot{start: start}
+	}
+	slot.counts[category]++
+}
+
+// record - counts err returned by an admin RPC call to addr, if any.
+// Calls cancelled by the caller are not counted, they tell nothing of
+// the peer.
+func (c *rpcErrorCounters) record(addr string, err error) {
+	if err == nil || err == context.Canceled {
+		return
+	}
+	c.add(time.Now().UTC(), addr, rpcErrorCategory(err))
+}
+
+// get - returns the errors calling each peer over the window ending
+// at now, sorted by address. Peers without errors are left out.
+func (c *rpcErrorCounters) get(now time.Time) []PeerRPCErrors {
+	oldest := now.Truncate(rpcErrorSlotDuration).Add(-rpcErrorWindow + rpcErrorSlotDuration)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	peers := []PeerRPCErrors{}
+	for addr, slots := range c.peers {
+		var counts [rpcErrorCategories]int64
+		for _, slot := range slots {
+			if slot.start.Before(oldest) || slot.start.After(now) {
+				continue
+			}
+			for i, n := range slot.counts {
+				counts[i] += n
+			}
+		}
+		peer := PeerRPCErrors{Addr: addr, RPCErrorCounts: RPCErrorCounts{
+			Timeout:           counts[rpcErrorTimeout],
+			ConnectionRefused: counts[rpcErrorConnection],
+			AuthFailure:       counts[rpcErrorAuth],
+			ApplicationError:  counts[rpcErrorApplication],
+		}}
+		if peer.total() == 0 {
+			continue
+		}
+		peer.CredentialDrift = isCredentialDrift(peer.RPCErrorCounts)
+		peers = append(peers, peer)
+	}
+	sort.Sort(byPeerRPCErrorsAddr(peers))
+	return peers
+}
@@ -0,0 +1,117 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/rpc"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// timeoutError - net.Error timing out.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// Tests categorizing admin RPC errors.
+func TestRPCErrorCategory(t *testing.T) {
+	testCases := []struct {
+		err      error
+		category int
+	}{
+		{timeoutError{}, rpcErrorTimeout},
+		{context.DeadlineExceeded, rpcErrorTimeout},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: timeoutError{}}, rpcErrorTimeout},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, rpcErrorConnection},
+		{rpc.ErrShutdown, rpcErrorConnection},
+		// Errors of the remote server arrive as rpc.ServerError.
+		{rpc.ServerError(errAuthentication.Error()), rpcErrorAuth},
+		{rpc.ServerError(errInvalidToken.Error()), rpcErrorAuth},
+		{rpc.ServerError(errServerTimeMismatch.Error()), rpcErrorApplication},
+		{rpc.ServerError(errDiskNotFound.Error()), rpcErrorApplication},
+	}
+	for i, testCase := range testCases {
+		if category := rpcErrorCategory(testCase.err); category != testCase.category {
+			t.Errorf("Test %d: expected category %d for %v, got %d", i+1, testCase.category, testCase.err, category)
+		}
+	}
+}
+
+// Tests counting admin RPC errors over the window.
+func TestRPCErrorCounters(t *testing.T) {
+	c := newRPCErrorCounters()
+	now := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Counted a window ago, dropped.
+	c.add(now.Add(-rpcErrorWindow), "server0:9000", rpcErrorTimeout)
+	c.add(now.Add(-rpcErrorWindow+rpcErrorSlotDuration), "server0:9000", rpcErrorTimeout)
+	c.add(now, "server0:9000", rpcErrorConnection)
+	for i := 0; i < rpcAuthFailureSpike; i++ {
+		c.add(now, "server1:9000", rpcErrorAuth)
+	}
+	c.add(now, "server1:9000", rpcErrorApplication)
+	// Calls cancelled by the caller are not counted.
+	c.record("server2:9000", context.Canceled)
+
+	expected := []PeerRPCErrors{
+		{Addr: "server0:9000", RPCErrorCounts: RPCErrorCounts{Timeout: 1, ConnectionRefused: 1}},
+		{Addr: "server1:9000", RPCErrorCounts: RPCErrorCounts{AuthFailure: rpcAuthFailureSpike, ApplicationError: 1}, CredentialDrift: true},
+	}
+	if peers := c.get(now); !reflect.DeepEqual(peers, expected) {
+		t.Fatalf("Expected %v, got %v", expected, peers)
+	}
+
+	// Peers without errors in the window are left out.
+	if peers := c.get(now.Add(rpcErrorWindow)); len(peers) != 0 {
+		t.Fatalf("Expected no errors, got %v", peers)
+	}
+}
+
+// Tests that errors of remote admin calls are counted.
+func TestRemoteAdminClientRPCErrors(t *testing.T) {
+	defer resetGlobalRPCErrors()
+
+	// Nothing listens on the address once closed.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	rc := remoteAdminClient{newAuthRPCClient(authConfig{
+		serverAddr:       addr,
+		serviceEndpoint:  path.Join(minioReservedBucketPath, adminPath),
+		serviceName:      "Admin",
+		disableReconnect: true,
+	})}
+	if _, err = rc.RPCErrors(context.Background()); err == nil {
+		t.Fatal("Expected call to fail")
+	}
+
+	expected := []PeerRPCErrors{{Addr: addr, RPCErrorCounts: RPCErrorCounts{ConnectionRefused: 1}}}
+	if peers := globalRPCErrors.get(time.Now().UTC()); !reflect.DeepEqual(peers, expected) {
+		t.Fatalf("Expected %v, got %v", expected, peers)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+)
+
+// errWriteVerification - a shard read back right after being written
+// does not match the checksum of the data sent to the disk.
+var errWriteVerification = errors.New("Written object failed integrity verification")
+
+// scanOnWrite - whether erasure coded shards are read back and
+// verified against their checksums before a write is acknowledged,
+// can be changed at runtime via admin RPC. Disabled by default,
+// shards are otherwise verified when read or healed only.
+type scanOnWrite struct {
+	mutex   sync.RWMutex
+	enabled bool
+}
+
+var globalScanOnWrite = &scanOnWrite{}
+
+// get - returns true if writes are verified.
+func (s *scanOnWrite) get() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.enabled
+}
+
+// set - enables or disables verification of writes.
+func (s *scanOnWrite) set(enabled bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.enabled = enabled
+}
+
+// verifyErasureFile - reads back the shards of path written to disks
+// and verifies them against checkSums, disks which failed the write
+// are nil and skipped. Returns errWriteVerification if any shard does
+// not match.
+func verifyErasureFile(disks []StorageAPI, volume, path string, checkSums []string, algo string) error {
+	var wg = &sync.WaitGroup{}
+	var valid = make([]bool, len(disks))
+	for index, disk := range disks {
+		if disk == nil {
+			valid[index] = true
+			continue
+		}
+		wg.Add(1)
+		go func(index int, disk StorageAPI) {
+			defer wg.Done()
+			valid[index] = isValidBlock(disk, volume, path, checkSums[index], algo)
+		}(index, disk)
+	}
+	wg.Wait()
+
+	for _, ok := range valid {
+		if !ok {
+			return traceError(errWriteVerification)
+		}
+	}
+	return nil
+}
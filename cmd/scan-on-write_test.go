@@ -0,0 +1,138 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// scanOnWriteDisk - StorageAPI counting the reads of temporary files
+// and, if corrupt is set, writing every appended block of data
+// corrupted. Metadata is written as is.
+type scanOnWriteDisk struct {
+	StorageAPI
+	corrupt bool
+	reads   *int32
+}
+
+func (d scanOnWriteDisk) AppendFile(volume, path string, buf []byte) error {
+	if d.corrupt && len(buf) > 0 && !strings.HasSuffix(path, xlMetaJSONFile) {
+		buf = append([]byte{}, buf...)
+		buf[0] ^= 0xff
+	}
+	return d.StorageAPI.AppendFile(volume, path, buf)
+}
+
+func (d scanOnWriteDisk) ReadFile(volume, path string, offset int64, buf []byte) (int64, error) {
+	if volume == minioMetaTmpBucket {
+		atomic.AddInt32(d.reads, 1)
+	}
+	return d.StorageAPI.ReadFile(volume, path, offset, buf)
+}
+
+// TestXLScanOnWrite - tests objects and parts are verified right after
+// being written only when scan on write is enabled.
+func TestXLScanOnWrite(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalScanOnWrite()
+
+	obj, fsDirs, err := prepareXL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1024)
+
+	xl := obj.(*xlObjects)
+	disks := append([]StorageAPI{}, xl.storageDisks...)
+	var reads int32
+	setDisks := func(corrupt bool) {
+		reads = 0
+		for i, disk := range disks {
+			xl.storageDisks[i] = scanOnWriteDisk{StorageAPI: disk, reads: &reads}
+		}
+		xl.storageDisks[0] = scanOnWriteDisk{StorageAPI: disks[0], corrupt: corrupt, reads: &reads}
+	}
+	tmpEntries := func() int {
+		entries, lerr := disks[0].ListDir(minioMetaTmpBucket, "")
+		if lerr != nil {
+			t.Fatal(lerr)
+		}
+		return len(entries)
+	}
+
+	// Writes are not read back when disabled, the corrupted shard
+	// goes unnoticed until the object is read or healed.
+	setDisks(true)
+	if _, err = obj.PutObject(bucket, "unverified", int64(len(data)), bytes.NewReader(data), nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if reads != 0 {
+		t.Fatalf("Expected no reads of the written shards, got %d", reads)
+	}
+
+	// Written shards are read back when enabled.
+	globalScanOnWrite.set(true)
+	setDisks(false)
+	if _, err = obj.PutObject(bucket, "verified", int64(len(data)), bytes.NewReader(data), nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if int(reads) < len(disks) {
+		t.Fatalf("Expected the %d written shards to be read back, got %d reads", len(disks), reads)
+	}
+
+	// A shard failing verification fails the write, nothing is left
+	// behind.
+	entries := tmpEntries()
+	setDisks(true)
+	_, err = obj.PutObject(bucket, "corrupted", int64(len(data)), bytes.NewReader(data), nil, "")
+	if errorCause(err) != errWriteVerification {
+		t.Fatalf("Expected %v, got %v", errWriteVerification, err)
+	}
+	if _, err = obj.GetObjectInfo(bucket, "corrupted"); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected the corrupted object not to be stored, got %v", err)
+	}
+	if n := tmpEntries(); n != entries {
+		t.Fatalf("Expected %d temporary entries, got %d", entries, n)
+	}
+
+	// Parts are verified the same way.
+	uploadID, err := obj.NewMultipartUpload(bucket, "multipart", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = obj.PutObjectPart(bucket, "multipart", uploadID, 1, int64(len(data)), bytes.NewReader(data), "", "")
+	if errorCause(err) != errWriteVerification {
+		t.Fatalf("Expected %v, got %v", errWriteVerification, err)
+	}
+	setDisks(false)
+	if _, err = obj.PutObjectPart(bucket, "multipart", uploadID, 1, int64(len(data)), bytes.NewReader(data), "", ""); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Requests served while idle, and while scanning, below which the
+// scanner impact is only a rough estimate.
+const minScannerImpactSamples = 100
+
+// scannerActivity - number of background scans walking buckets on this
+// server: heal jobs, usage scans and object recounts.
+type scannerActivity struct {
+	scans int32
+}
+
+var globalScannerActivity = &scannerActivity{}
+
+// begin - marks the start of a scan.
+func (s *scannerActivity) begin() {
+	atomic.AddInt32(&s.scans, 1)
+}
+
+// end - marks the end of a scan started with begin.
+func (s *scannerActivity) end() {
+	atomic.AddInt32(&s.scans, -1)
+}
+
+// active - returns true if any scan is running.
+func (s *scannerActivity) active() bool {
+	return atomic.LoadInt32(&s.scans) > 0
+}
+
+// ImpactEstimate - latency of client requests served while background
+// scans were running compared to requests served while idle. Requests
+// of all API operations are compared together, so the estimate is only
+// meaningful if the mix of requests does not depend on scans running.
+// LowConfidence is set when too few requests were served in either
+// state to tell scan impact apart from noise.
+type ImpactEstimate struct {
+	ScanningSamples uint64            `json:"scanningSamples"`
+	IdleSamples     uint64            `json:"idleSamples"`
+	ScanningP50     LatencyPercentile `json:"scanningP50"`
+	IdleP50         LatencyPercentile `json:"idleP50"`
+	ScanningP99     LatencyPercentile `json:"scanningP99"`
+	IdleP99         LatencyPercentile `json:"idleP99"`
+
+	// Latency added by scans, zero where a percentile could not be
+	// computed in either state.
+	P50Delta time.Duration `json:"p50Delta"`
+	P99Delta time.Duration `json:"p99Delta"`
+
+	LowConfidence bool `json:"lowConfidence"`
+
+	// Histograms the estimate was computed from, sent over RPC so
+	// that estimates of servers can be merged.
+	Scanning LatencyHistogram `json:"-"`
+	Idle     LatencyHistogram `json:"-"`
+}
+
+// latencyDelta - returns how much slower scanning is than idle, zero if
+// either is unknown.
+func latencyDelta(scanning, idle LatencyPercentile) time.Duration {
+	if scanning.InsufficientData || idle.InsufficientData {
+		return 0
+	}
+	return scanning.Latency - idle.Latency
+}
+
+// newImpactEstimate - estimates the scanner impact out of the latency
+// of requests served while scanning and while idle.
+func newImpactEstimate(scanning, idle LatencyHistogram) ImpactEstimate {
+	e := ImpactEstimate{
+		ScanningSamples: scanning.samples(),
+		IdleSamples:     idle.samples(),
+		ScanningP50:     scanning.percentile(0.5),
+		IdleP50:         idle.percentile(0.5),
+		ScanningP99:     scanning.percentile(0.99),
+		IdleP99:         idle.percentile(0.99),
+		Scanning:        scanning,
+		Idle:            idle,
+	}
+	e.P50Delta = latencyDelta(e.ScanningP50, e.IdleP50)
+	e.P99Delta = latencyDelta(e.ScanningP99, e.IdleP99)
+	e.LowConfidence = e.IdleSamples < minScannerImpactSamples || e.ScanningSamples < minScannerImpactSamples
+	return e
+}
+
+// NodeImpactEstimate - scanner impact on a server.
+type NodeImpactEstimate struct {
+	Addr string `json:"addr"`
+	ImpactEstimate
+}
+
+// ClusterImpactEstimate - scanner impact on all servers.
+type ClusterImpactEstimate struct {
+	ImpactEstimate
+	Nodes []NodeImpactEstimate `json:"nodes"`
+}
+
+// scannerLatency - latency of requests served by this server, split by
+// whether a scan was running when they came in.
+type scannerLatency struct {
+	mutex    sync.Mutex
+	scanning LatencyHistogram
+	idle     LatencyHistogram
+}
+
+func newScannerLatency() *scannerLatency {
+	return &scannerLatency{scanning: newLatencyHistogram(), idle: newLatencyHistogram()}
+}
+
+var globalScannerLatency = newScannerLatency()
+
+// record - records a request which took latency.
+func (l *scannerLatency) record(scanning bool, latency time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if scanning {
+		l.scanning.add(latency)
+		return
+	}
+	l.idle.add(latency)
+}
+
+// estimate - returns the scanner impact out of all requests recorded.
+func (l *scannerLatency) estimate() ImpactEstimate {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	scanning, idle := newLatencyHistogram(), newLatencyHistogram()
+	scanning.merge(l.scanning)
+	idle.merge(l.idle)
+	return newImpactEstimate(scanning, idle)
+}
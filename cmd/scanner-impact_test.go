@@ -0,0 +1,113 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestNewImpactEstimate - tests the scanner impact estimate out of
+// synthetic latencies with and without scans running.
+func TestNewImpactEstimate(t *testing.T) {
+	ms := time.Millisecond
+	testCases := []struct {
+		scanning      map[time.Duration]uint64
+		idle          map[time.Duration]uint64
+		p50Delta      time.Duration
+		p99Delta      time.Duration
+		lowConfidence bool
+	}{
+		// Test 1: no requests at all.
+		{nil, nil, 0, 0, true},
+		// Test 2: scans double the median latency, slow tail unchanged.
+		{
+			scanning:      map[time.Duration]uint64{20 * ms: 980, 100 * ms: 20},
+			idle:          map[time.Duration]uint64{10 * ms: 980, 100 * ms: 20},
+			p50Delta:      latencyBound(20*ms).Latency - latencyBound(10*ms).Latency,
+			p99Delta:      0,
+			lowConfidence: false,
+		},
+		// Test 3: too few idle requests, still estimated but flagged.
+		{
+			scanning:      map[time.Duration]uint64{20 * ms: 980, 100 * ms: 20},
+			idle:          map[time.Duration]uint64{10 * ms: 50},
+			p50Delta:      latencyBound(20*ms).Latency - latencyBound(10*ms).Latency,
+			p99Delta:      0,
+			lowConfidence: true,
+		},
+		// Test 4: too few requests while scanning.
+		{
+			scanning:      map[time.Duration]uint64{20 * ms: 30},
+			idle:          map[time.Duration]uint64{10 * ms: 1000},
+			p50Delta:      latencyBound(20*ms).Latency - latencyBound(10*ms).Latency,
+			p99Delta:      0,
+			lowConfidence: true,
+		},
+		// Test 5: scans do not slow down requests.
+		{
+			scanning:      map[time.Duration]uint64{10 * ms: 500},
+			idle:          map[time.Duration]uint64{10 * ms: 500},
+			p50Delta:      0,
+			p99Delta:      0,
+			lowConfidence: false,
+		},
+	}
+
+	for i, testCase := range testCases {
+		e := newImpactEstimate(newTestLatencyHistogram(testCase.scanning), newTestLatencyHistogram(testCase.idle))
+		if e.P50Delta != testCase.p50Delta {
+			t.Errorf("Test %d: expected p50 delta %v, got %v", i+1, testCase.p50Delta, e.P50Delta)
+		}
+		if e.P99Delta != testCase.p99Delta {
+			t.Errorf("Test %d: expected p99 delta %v, got %v", i+1, testCase.p99Delta, e.P99Delta)
+		}
+		if e.LowConfidence != testCase.lowConfidence {
+			t.Errorf("Test %d: expected low confidence %v, got %v", i+1, testCase.lowConfidence, e.LowConfidence)
+		}
+	}
+}
+
+// TestTimedAPIHandlerScannerLatency - tests that requests are recorded
+// as served while scanning or idle.
+func TestTimedAPIHandlerScannerLatency(t *testing.T) {
+	resetGlobalScannerLatency()
+	defer resetGlobalScannerLatency()
+	defer resetGlobalAPILatency()
+
+	handler := timedAPIHandler("GetObject", func(w http.ResponseWriter, r *http.Request) {})
+	serve := func() {
+		handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/bucket/object", nil))
+	}
+
+	serve()
+	globalScannerActivity.begin()
+	serve()
+	serve()
+	globalScannerActivity.end()
+	serve()
+
+	e := globalScannerLatency.estimate()
+	if e.ScanningSamples != 2 || e.IdleSamples != 2 {
+		t.Fatalf("Expected 2 scanning and 2 idle samples, got %d and %d", e.ScanningSamples, e.IdleSamples)
+	}
+	if !e.LowConfidence {
+		t.Fatal("Expected low confidence out of 4 requests")
+	}
+}
@@ -62,6 +62,9 @@ ENVIRONMENT VARIABLES:
   BROWSER:
      MINIO_BROWSER: To disable web browser access, set this value to "off".
 
+  ADMIN:
+     MINIO_ADMIN_FANOUT: Maximum number of admin calls to other servers in flight at once, defaults to 32.
+
 EXAMPLES:
   1. Start minio server on "/home/shared" directory.
       $ {{.HelpName}} /home/shared
@@ -410,6 +413,7 @@ func serverMain(c *cli.Context) {
 	globalIsDistXL = isDistributedSetup(endpoints)
 
 	// Set nodes for dsync for distributed setup.
+	globalBootDiagnostics.begin(bootPhaseLockInit)
 	if globalIsDistXL {
 		fatalIf(initDsyncNodes(endpoints), "Unable to initialize distributed locking clients")
 	}
@@ -422,11 +426,18 @@ func serverMain(c *cli.Context) {
 
 	// Initialize name space lock.
 	initNSLock(globalIsDistXL)
+	globalBootDiagnostics.end(bootPhaseLockInit)
 
 	// Configure server.
 	handler, err := configureServerHandler(srvConfig)
 	fatalIf(err, "Unable to configure one of server's RPC services.")
 
+	// Keep rejecting a credential expired before a restart.
+	initCredentialExpiry()
+
+	// Offer the TLS cipher suites set by the admin API from the start.
+	fatalIf(initTLSCiphers(), "Unable to load the TLS cipher suites.")
+
 	// Initialize a new HTTP server.
 	apiServer := NewServerMux(serverAddr, handler)
 
@@ -465,6 +476,49 @@ func serverMain(c *cli.Context) {
 	globalObjectAPI = newObject
 	globalObjLayerMutex.Unlock()
 
+	// Load the maintenance schedule, a server restarting within a
+	// window enters maintenance right away.
+	err = initMaintenanceSchedule(newObject)
+	fatalIf(err, "Unable to load the maintenance schedule.")
+
+	// Load the presigned URL expiry limit set by the admin API.
+	err = initMaxPresignExpiry(newObject)
+	fatalIf(err, "Unable to load the presigned URL expiry limit.")
+
+	// Load the signature versions allowed by the admin API.
+	err = initAllowedSignatureVersions(newObject)
+	fatalIf(err, "Unable to load the allowed signature versions.")
+
+	// Load the proxies trusted by the admin API.
+	err = initTrustedProxies(newObject)
+	fatalIf(err, "Unable to load the trusted proxies.")
+
+	// Load the object naming policy set by the admin API.
+	err = initKeyNamingPolicy(newObject)
+	fatalIf(err, "Unable to load the object naming policy.")
+
+	// Load the checksum policy set by the admin API.
+	err = initChecksumPolicy(newObject)
+	fatalIf(err, "Unable to load the checksum policy.")
+
+	// Load the multi-delete limit set by the admin API.
+	err = initMultiDeleteLimit(newObject)
+	fatalIf(err, "Unable to load the multi-delete limit.")
+
+	// Load whether conditional writes are honored, as set by the admin
+	// API.
+	err = initConditionalWrites(newObject)
+	fatalIf(err, "Unable to load the conditional writes setting.")
+
+	// Abort abandoned multipart uploads in background.
+	go startMultipartJanitor()
+
+	// Write out buffered bucket access logs in background.
+	go startAccessLogFlusher()
+
+	// Record loss and restoration of quorum in background.
+	go startQuorumMonitor()
+
 	// Prints the formatted startup message once object layer is initialized.
 	printStartupMessage(apiEndPoints)
 
@@ -488,11 +542,18 @@ func newObjectLayer(srvCmdCfg serverCmdConfig) (newObject ObjectLayer, err error
 			return nil, err
 		}
 
+		// A single local disk needs no waiting for quorum, its
+		// format is checked while initializing FS object layer.
+		globalBootDiagnostics.begin(bootPhaseFirstQuorum)
+		globalBootDiagnostics.next(bootPhaseFirstQuorum, bootPhaseFormatCheck)
+		globalBootDiagnostics.next(bootPhaseFormatCheck, bootPhaseObjectLayerInit)
+
 		// Initialize new FS object layer.
 		newObject, err = newFSObjectLayer(fsPath)
 		if err != nil {
 			return nil, err
 		}
+		globalBootDiagnostics.end(bootPhaseObjectLayerInit)
 
 		// FS initialized, return.
 		return newObject, nil
@@ -515,6 +576,7 @@ func newObjectLayer(srvCmdCfg serverCmdConfig) (newObject ObjectLayer, err error
 	}
 
 	// Cleanup objects that weren't successfully written into the namespace.
+	globalBootDiagnostics.begin(bootPhaseObjectLayerInit)
 	if err = houseKeeping(storageDisks); err != nil {
 		return nil, err
 	}
@@ -524,6 +586,7 @@ func newObjectLayer(srvCmdCfg serverCmdConfig) (newObject ObjectLayer, err error
 	if err != nil {
 		return nil, err
 	}
+	globalBootDiagnostics.end(bootPhaseObjectLayerInit)
 
 	// XL initialized, return.
 	return newObject, nil
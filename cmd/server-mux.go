@@ -170,7 +170,9 @@ func (c *ConnMux) Close() (err error) {
 // from returning from Close till Accept returns an error to the user.
 type ListenerMux struct {
 	net.Listener
-	config *tls.Config
+	// Guards config, swapped when the TLS cipher suites change.
+	configMu sync.RWMutex
+	config   *tls.Config
 	// acceptResCh is a channel for transporting wrapped net.Conn (regular or tls)
 	// after peeking the content of the latter
 	acceptResCh chan ListenerMuxAcceptRes
@@ -179,6 +181,21 @@ type ListenerMux struct {
 	refs int
 }
 
+// tlsConfig - returns the TLS config to wrap new connections with.
+func (l *ListenerMux) tlsConfig() *tls.Config {
+	l.configMu.RLock()
+	defer l.configMu.RUnlock()
+	return l.config
+}
+
+// setTLSConfig - wraps new connections with config, connections already
+// wrapped keep the config they were wrapped with.
+func (l *ListenerMux) setTLSConfig(config *tls.Config) {
+	l.configMu.Lock()
+	defer l.configMu.Unlock()
+	l.config = config
+}
+
 // ListenerMuxAcceptRes contains then final net.Conn data (wrapper by tls or not) to be sent to the http handler
 type ListenerMuxAcceptRes struct {
 	conn net.Conn
@@ -248,7 +265,7 @@ func newListenerMux(listener net.Listener, config *tls.Config) *ListenerMux {
 				}
 				switch protocol {
 				case protocolTLS:
-					tlsConn := tls.Server(connMux, l.config)
+					tlsConn := tls.Server(connMux, l.tlsConfig())
 					// Make sure to handshake so that we know that this
 					// is a TLS connection, if not we should close and reject
 					// such a connection.
@@ -331,11 +348,12 @@ type ServerMux struct {
 
 	// Current number of concurrent http requests
 	currentReqs int32
-	// Time to wait before forcing server shutdown
-	gracefulTimeout time.Duration
 
-	mu      sync.Mutex // guards closing, and listeners
+	mu      sync.Mutex // guards closing, listeners and certificates
 	closing bool
+
+	// Certificates served when TLS is enabled.
+	certificates []tls.Certificate
 }
 
 // NewServerMux constructor to create a ServerMux
@@ -343,9 +361,6 @@ func NewServerMux(addr string, handler http.Handler) *ServerMux {
 	m := &ServerMux{
 		Addr:    addr,
 		handler: handler,
-		// Wait for 5 seconds for new incoming connnections, otherwise
-		// forcibly close them during graceful stop or restart.
-		gracefulTimeout: 5 * time.Second,
 	}
 
 	// Returns configured HTTP server.
@@ -391,12 +406,9 @@ func initListeners(serverAddr string, tls *tls.Config) ([]*ListenerMux, error) {
 	return listeners, nil
 }
 
-// ListenAndServe - serve HTTP requests with protocol multiplexing support
-// TLS is actived when certFile and keyFile parameters are not empty.
-func (m *ServerMux) ListenAndServe(certFile, keyFile string) (err error) {
-
-	tlsEnabled := certFile != "" && keyFile != ""
-
+// newServerTLSConfig - returns the TLS config offering suites, TLS is
+// configured only if certificates are not empty.
+func newServerTLSConfig(suites []uint16, certificates []tls.Certificate) *tls.Config {
 	config := &tls.Config{
 		// Causes servers to use Go's default ciphersuite preferences,
 		// which are tuned to avoid attacks. Does nothing on clients.
@@ -406,34 +418,48 @@ func (m *ServerMux) ListenAndServe(certFile, keyFile string) (err error) {
 			tls.CurveP256,
 		},
 		// Set minimum version to TLS 1.2
-		MinVersion: tls.VersionTLS12,
-		CipherSuites: []uint16{
-			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
-			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
-			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
-			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
-
-			// Best disabled, as they don't provide Forward Secrecy,
-			// but might be necessary for some clients
-			// tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
-			// tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
-		},
+		MinVersion:   tls.VersionTLS12,
+		CipherSuites: suites,
 	} // Always instantiate.
 
-	if tlsEnabled {
+	if len(certificates) > 0 {
 		// Configure TLS in the server
-		if config.NextProtos == nil {
-			config.NextProtos = []string{"http/1.1", "h2"}
-		}
-		config.Certificates = make([]tls.Certificate, 1)
-		config.Certificates[0], err = tls.LoadX509KeyPair(certFile, keyFile)
+		config.NextProtos = []string{"http/1.1", "h2"}
+		config.Certificates = certificates
+	}
+	return config
+}
+
+// setCipherSuites - offers only suites on new connections to all
+// listeners.
+func (m *ServerMux) setCipherSuites(suites []uint16) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	config := newServerTLSConfig(suites, m.certificates)
+	for _, listener := range m.listeners {
+		listener.setTLSConfig(config)
+	}
+}
+
+// ListenAndServe - serve HTTP requests with protocol multiplexing support
+// TLS is actived when certFile and keyFile parameters are not empty.
+func (m *ServerMux) ListenAndServe(certFile, keyFile string) (err error) {
+
+	tlsEnabled := certFile != "" && keyFile != ""
+
+	var certificates []tls.Certificate
+	if tlsEnabled {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
 		if err != nil {
 			return err
 		}
+		certificates = []tls.Certificate{certificate}
 	}
 
 	go m.handleServiceSignals()
 
+	config := newServerTLSConfig(globalTLSCiphers.suites(), certificates)
 	listeners, err := initListeners(m.Addr, config)
 	if err != nil {
 		return err
@@ -441,8 +467,12 @@ func (m *ServerMux) ListenAndServe(certFile, keyFile string) (err error) {
 
 	m.mu.Lock()
 	m.listeners = listeners
+	m.certificates = certificates
 	m.mu.Unlock()
 
+	// Reconfigure listeners on TLS cipher suites changes.
+	globalTLSCiphers.serve(m)
+
 	// All http requests start to be processed by httpHandler
 	httpHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if tlsEnabled && r.TLS == nil {
@@ -514,18 +544,28 @@ func (m *ServerMux) Close() error {
 	}
 	m.mu.Unlock()
 
-	// Starting graceful shutdown. Check if all requests are finished
-	// in regular interval or force the shutdown
+	// Starting graceful shutdown, wait for requests in progress to
+	// finish or force the shutdown after the shutdown timeout.
+	m.drainRequests(getShutdownTimeout())
+	return nil
+}
+
+// drainRequests - waits until no request is in progress, checking in
+// regular interval, for at most timeout. Returns at once if timeout is
+// 0.
+func (m *ServerMux) drainRequests(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
 	ticker := time.NewTicker(serverShutdownPoll)
 	defer ticker.Stop()
-	for {
+	for atomic.LoadInt32(&m.currentReqs) > 0 {
 		select {
-		case <-time.After(m.gracefulTimeout):
-			return nil
+		case <-deadline.C:
+			return
 		case <-ticker.C:
-			if atomic.LoadInt32(&m.currentReqs) <= 0 {
-				return nil
-			}
 		}
 	}
 }
@@ -32,6 +32,7 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -179,6 +180,36 @@ func TestClose(t *testing.T) {
 	}
 }
 
+// Tests that requests in progress are waited for at most the given
+// timeout, and not at all with a zero timeout.
+func TestServerMuxDrainRequests(t *testing.T) {
+	m := NewServerMux("", nil)
+	m.currentReqs = 1
+
+	start := time.Now()
+	m.drainRequests(200 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond || elapsed > 2*time.Second {
+		t.Fatalf("Expected to wait for the 200ms timeout, waited %v", elapsed)
+	}
+
+	start = time.Now()
+	m.drainRequests(0)
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("Expected no wait with a zero timeout, waited %v", elapsed)
+	}
+
+	// Draining ends as soon as the last request finishes.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		atomic.AddInt32(&m.currentReqs, -1)
+	}()
+	start = time.Now()
+	m.drainRequests(time.Minute)
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Expected to stop waiting once requests finished, waited %v", elapsed)
+	}
+}
+
 func TestServerMux(t *testing.T) {
 	var err error
 	var got []byte
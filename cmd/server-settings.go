@@ -0,0 +1,74 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// readServerSetting - reads the server wide setting persisted as
+// config in the meta bucket into v. Returns false if it was never set,
+// v is then left untouched.
+func readServerSetting(objAPI ObjectLayer, config string, v interface{}) (bool, error) {
+	// Acquire a read lock on the setting before reading.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, config)
+	objLock.RLock()
+	defer objLock.RUnlock()
+
+	var buffer bytes.Buffer
+	if err := objAPI.GetObject(minioMetaBucket, config, 0, -1, &buffer); err != nil {
+		if isErrObjectNotFound(err) || isErrIncompleteBody(err) {
+			return false, nil
+		}
+		errorIf(err, "Unable to load %s.", config)
+		return false, errorCause(err)
+	}
+
+	if err := json.Unmarshal(buffer.Bytes(), v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// writeServerSetting - persists v as the server wide setting config in
+// the meta bucket, so that servers restarting load it again. A nil v
+// removes the setting.
+func writeServerSetting(objAPI ObjectLayer, config string, v interface{}) error {
+	// Acquire a write lock on the setting before modifying.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, config)
+	objLock.Lock()
+	defer objLock.Unlock()
+
+	if v == nil {
+		if err := objAPI.DeleteObject(minioMetaBucket, config); err != nil && !isErrObjectNotFound(err) {
+			errorIf(err, "Unable to remove %s.", config)
+			return errorCause(err)
+		}
+		return nil
+	}
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err = objAPI.PutObject(minioMetaBucket, config, int64(len(buf)), bytes.NewReader(buf), nil, ""); err != nil {
+		errorIf(err, "Unable to save %s.", config)
+		return errorCause(err)
+	}
+	return nil
+}
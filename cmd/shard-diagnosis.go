@@ -0,0 +1,241 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Health of a shard of an object.
+const (
+	shardHealthy  = "healthy"
+	shardOffline  = "offline"  // Disk of the shard is unreachable.
+	shardMissing  = "missing"  // xl.json or a part is not on the disk.
+	shardCorrupt  = "corrupt"  // A part failed its checksum.
+	shardOutdated = "outdated" // Shard of an older version of the object.
+)
+
+// Verdicts of a shard diagnosis.
+const (
+	shardDiagHealthy       = "healthy"
+	shardDiagDegraded      = "degraded"
+	shardDiagUnrecoverable = "unrecoverable"
+)
+
+// ShardHealth - location and health of a shard of an object.
+type ShardHealth struct {
+	// Erasure index of the shard, from 1.
+	Shard   int       `json:"shard"`
+	Disk    string    `json:"disk"`
+	State   string    `json:"state"`
+	ModTime time.Time `json:"modTime"`
+
+	// Position of the disk in the endpoints, and erasure layout read
+	// from its xl.json, used to merge the views of all servers.
+	DiskIndex    int   `json:"-"`
+	DataBlocks   int   `json:"-"`
+	Distribution []int `json:"-"`
+}
+
+// ShardDiag - health of every shard of an object, whether the object
+// can be decoded and what to do about it.
+type ShardDiag struct {
+	Bucket  string        `json:"bucket"`
+	Object  string        `json:"object"`
+	Shards  []ShardHealth `json:"shards"`
+	Verdict string        `json:"verdict"`
+	// Shards a heal reconstructs, set when the object is degraded.
+	Reconstruct []int  `json:"reconstruct"`
+	Suggestion  string `json:"suggestion"`
+}
+
+// getLocalShardHealth - returns the health of the shards of object
+// stored on the disks of this server.
+func getLocalShardHealth(bucket, object string) ([]ShardHealth, error) {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return nil, errServerNotInitialized
+	}
+	if _, ok := objLayer.(*xlObjects); !ok {
+		return nil, errUnsupportedBackend
+	}
+
+	shards := []ShardHealth{}
+	for i, ep := range globalEndpoints {
+		if !isLocalStorage(ep) {
+			continue
+		}
+		shard := ShardHealth{Disk: ep.String(), DiskIndex: i, State: shardOffline}
+		if disk, err := newStorageAPI(ep); err == nil {
+			diagnoseShard(disk, bucket, object, &shard)
+		}
+		shards = append(shards, shard)
+	}
+	return shards, nil
+}
+
+// diagnoseShard - sets the health of the shard of object stored on
+// disk, verifying the checksum of every part.
+func diagnoseShard(disk StorageAPI, bucket, object string, shard *ShardHealth) {
+	xlMeta, err := readXLMeta(disk, bucket, object)
+	if err != nil {
+		shard.State = shardErrState(err)
+		return
+	}
+	shard.Shard = xlMeta.Erasure.Index
+	shard.ModTime = xlMeta.Stat.ModTime
+	shard.DataBlocks = xlMeta.Erasure.DataBlocks
+	shard.Distribution = xlMeta.Erasure.Distribution
+
+	for _, part := range xlMeta.Parts {
+		partPath := pathJoin(object, part.Name)
+		if _, err = disk.StatFile(bucket, partPath); err != nil {
+			shard.State = shardErrState(err)
+			return
+		}
+		ckSum := xlMeta.Erasure.GetCheckSumInfo(part.Name)
+		if !isValidBlock(disk, bucket, partPath, ckSum.Hash, ckSum.Algorithm) {
+			shard.State = shardCorrupt
+			return
+		}
+	}
+	shard.State = shardHealthy
+}
+
+// shardErrState - returns the health of a shard which could not be
+// read because of err.
+func shardErrState(err error) string {
+	switch errorCause(err) {
+	case errFileNotFound, errVolumeNotFound:
+		return shardMissing
+	case errDiskNotFound, errFaultyDisk, errFaultyRemoteDisk:
+		return shardOffline
+	}
+	// Unreadable xl.json.
+	return shardCorrupt
+}
+
+// newShardDiag - merges the views of the servers of the shards of
+// object, disks no server reported on are offline. Shards not of the
+// version most disks agree on are outdated. The object is degraded if
+// it still has enough healthy shards to decode it, unrecoverable
+// otherwise.
+func newShardDiag(bucket, object string, endpoints []*url.URL, views [][]ShardHealth) (ShardDiag, error) {
+	shards := make([]ShardHealth, len(endpoints))
+	for i, ep := range endpoints {
+		shards[i] = ShardHealth{Disk: ep.String(), DiskIndex: i, State: shardOffline}
+	}
+	var modTimes []time.Time
+	for _, view := range views {
+		for _, shard := range view {
+			if shard.DiskIndex < 0 || shard.DiskIndex >= len(shards) {
+				continue
+			}
+			shard.ModTime = shard.ModTime.UTC()
+			shards[shard.DiskIndex] = shard
+			if !shard.ModTime.IsZero() {
+				modTimes = append(modTimes, shard.ModTime)
+			}
+		}
+	}
+
+	modTime, _ := commonTime(modTimes)
+	var latest *ShardHealth
+	for i := range shards {
+		if shards[i].ModTime.Equal(modTime) && len(shards[i].Distribution) == len(shards) {
+			latest = &shards[i]
+			break
+		}
+	}
+	if latest == nil {
+		return ShardDiag{}, traceError(ObjectNotFound{Bucket: bucket, Object: object})
+	}
+	dataBlocks, distribution := latest.DataBlocks, latest.Distribution
+
+	diag := ShardDiag{Bucket: bucket, Object: object, Reconstruct: []int{}}
+	// Heal only rewrites shards without an xl.json or with an outdated
+	// one, damaged shards need their xl.json removed first.
+	var offline, damaged []int
+	healthy := 0
+	for i := range shards {
+		shard := &shards[i]
+		shard.Shard = distribution[i]
+		if !shard.ModTime.IsZero() && !shard.ModTime.Equal(modTime) {
+			shard.State = shardOutdated
+		}
+		switch shard.State {
+		case shardHealthy:
+			healthy++
+			continue
+		case shardOffline:
+			offline = append(offline, shard.Shard)
+		case shardCorrupt:
+			damaged = append(damaged, shard.Shard)
+		case shardMissing:
+			if !shard.ModTime.IsZero() {
+				damaged = append(damaged, shard.Shard)
+			}
+		}
+		diag.Reconstruct = append(diag.Reconstruct, shard.Shard)
+	}
+	sort.Sort(byShardIndex(shards))
+	sort.Ints(diag.Reconstruct)
+	sort.Ints(offline)
+	sort.Ints(damaged)
+	diag.Shards = shards
+
+	switch {
+	case healthy == len(shards):
+		diag.Verdict = shardDiagHealthy
+		diag.Suggestion = "no action needed"
+	case healthy >= dataBlocks:
+		diag.Verdict = shardDiagDegraded
+		diag.Suggestion = "heal the object to reconstruct shards " + joinInts(diag.Reconstruct)
+		if len(damaged) > 0 {
+			diag.Suggestion += ", remove xl.json of shards " + joinInts(damaged) + " from their disks first"
+		}
+		if len(offline) > 0 {
+			diag.Suggestion += ", bring the disks of shards " + joinInts(offline) + " back online first"
+		}
+	default:
+		diag.Verdict = shardDiagUnrecoverable
+		diag.Reconstruct = []int{}
+		diag.Suggestion = fmt.Sprintf("unrecoverable, %d healthy shards left out of the %d needed to decode the object", healthy, dataBlocks)
+	}
+	return diag, nil
+}
+
+// joinInts - returns ints separated by commas.
+func joinInts(ints []int) string {
+	s := make([]string, len(ints))
+	for i, n := range ints {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
+
+// byShardIndex - sorts shards by erasure index.
+type byShardIndex []ShardHealth
+
+func (s byShardIndex) Len() int           { return len(s) }
+func (s byShardIndex) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byShardIndex) Less(i, j int) bool { return s[i].Shard < s[j].Shard }
@@ -0,0 +1,152 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestShardDiagnosis - tests the verdict and suggestion of healthy,
+// degraded and unrecoverable objects.
+func TestShardDiagnosis(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+
+	obj, xlDirs, err := initTestXLObjLayer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(xlDirs)
+
+	// Set globalEndpoints for a single node XL setup.
+	defer resetGlobalEndpoints()
+	for _, xlDir := range xlDirs {
+		globalEndpoints = append(globalEndpoints, &url.URL{Path: xlDir})
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("hello")
+	for _, object := range []string{"healthy", "degraded", "unrecoverable"} {
+		if _, err = obj.PutObject(bucket, object, int64(len(data)), bytes.NewReader(data), nil, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Corrupts the part of object on disk i.
+	corrupt := func(i int, object string) {
+		if err = ioutil.WriteFile(filepath.Join(xlDirs[i], bucket, object, "part.1"), []byte("corrupt"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Lose the part on disk 0, xl.json on disk 1 and corrupt disk 2.
+	if err = os.Remove(filepath.Join(xlDirs[0], bucket, "degraded", "part.1")); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Remove(filepath.Join(xlDirs[1], bucket, "degraded", xlMetaJSONFile)); err != nil {
+		t.Fatal(err)
+	}
+	corrupt(2, "degraded")
+	// 9 out of 8 data and 8 parity shards are corrupt.
+	for i := 0; i < 9; i++ {
+		corrupt(i, "unrecoverable")
+	}
+
+	peers := adminPeers{{addr: "server0:9000", cmdRunner: localAdminClient{}}}
+
+	diag, err := getPeerShardDiagnosis(context.Background(), peers, bucket, "healthy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diag.Verdict != shardDiagHealthy || diag.Suggestion != "no action needed" || len(diag.Reconstruct) != 0 {
+		t.Fatalf("Expected a healthy object, got %#v", diag)
+	}
+	if len(diag.Shards) != len(xlDirs) {
+		t.Fatalf("Expected %d shards, got %d", len(xlDirs), len(diag.Shards))
+	}
+	for i, shard := range diag.Shards {
+		if shard.Shard != i+1 || shard.State != shardHealthy {
+			t.Fatalf("Expected shard %d to be healthy, got %#v", i+1, shard)
+		}
+	}
+
+	diag, err = getPeerShardDiagnosis(context.Background(), peers, bucket, "degraded")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shards := make(map[string]ShardHealth)
+	var expected []int
+	for _, shard := range diag.Shards {
+		shards[shard.Disk] = shard
+		if shard.State != shardHealthy {
+			expected = append(expected, shard.Shard)
+		}
+	}
+	sort.Ints(expected)
+	if shards[xlDirs[0]].State != shardMissing || shards[xlDirs[1]].State != shardMissing || shards[xlDirs[2]].State != shardCorrupt {
+		t.Fatalf("Expected shards on disks 0 and 1 missing and 2 corrupt, got %v", shards)
+	}
+	damaged := []int{shards[xlDirs[0]].Shard, shards[xlDirs[2]].Shard}
+	sort.Ints(damaged)
+	suggestion := "heal the object to reconstruct shards " + joinInts(expected) +
+		", remove xl.json of shards " + joinInts(damaged) + " from their disks first"
+	if diag.Verdict != shardDiagDegraded || !reflect.DeepEqual(diag.Reconstruct, expected) || diag.Suggestion != suggestion {
+		t.Fatalf("Expected a heal reconstructing shards %v, got %#v", expected, diag)
+	}
+
+	// Following the suggestion makes the object healthy.
+	for _, i := range []int{0, 2} {
+		if err = os.Remove(filepath.Join(xlDirs[i], bucket, "degraded", xlMetaJSONFile)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = obj.HealObject(bucket, "degraded"); err != nil {
+		t.Fatal(err)
+	}
+	if diag, err = getPeerShardDiagnosis(context.Background(), peers, bucket, "degraded"); err != nil {
+		t.Fatal(err)
+	}
+	if diag.Verdict != shardDiagHealthy {
+		t.Fatalf("Expected a healthy object after heal, got %#v", diag)
+	}
+
+	diag, err = getPeerShardDiagnosis(context.Background(), peers, bucket, "unrecoverable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diag.Verdict != shardDiagUnrecoverable || len(diag.Reconstruct) != 0 ||
+		diag.Suggestion != "unrecoverable, 7 healthy shards left out of the 8 needed to decode the object" {
+		t.Fatalf("Expected an unrecoverable object, got %#v", diag)
+	}
+
+	if _, err = getPeerShardDiagnosis(context.Background(), peers, bucket, "absent"); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected object not found, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// Time to wait for requests in progress to finish before
+	// forcing a stop or restart.
+	defaultShutdownTimeout = 5 * time.Second
+
+	// Longer timeouts are lowered to this, a server which never
+	// stops draining would never stop at all.
+	maxShutdownTimeout = 5 * time.Minute
+)
+
+// Shutdown timeout, can be changed at runtime via admin RPC.
+var globalShutdownTimeout = int64(defaultShutdownTimeout)
+
+// getShutdownTimeout - returns the current shutdown timeout.
+func getShutdownTimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&globalShutdownTimeout))
+}
+
+// setShutdownTimeout - sets the shutdown timeout, 0 stops without
+// waiting for requests in progress. Timeouts beyond maxShutdownTimeout
+// are lowered to it.
+func setShutdownTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return errInvalidArgument
+	}
+	if timeout > maxShutdownTimeout {
+		timeout = maxShutdownTimeout
+	}
+	atomic.StoreInt64(&globalShutdownTimeout, int64(timeout))
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetShutdownTimeout(t *testing.T) {
+	defer resetGlobalShutdownTimeout()
+
+	if err := setShutdownTimeout(-time.Second); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+	if timeout := getShutdownTimeout(); timeout != defaultShutdownTimeout {
+		t.Fatalf("Expected %v, got %v", defaultShutdownTimeout, timeout)
+	}
+
+	testCases := []struct {
+		timeout  time.Duration
+		expected time.Duration
+	}{
+		// Zero stops without draining.
+		{0, 0},
+		{30 * time.Second, 30 * time.Second},
+		{maxShutdownTimeout, maxShutdownTimeout},
+		// Excessive timeouts are clamped.
+		{24 * time.Hour, maxShutdownTimeout},
+	}
+	for i, testCase := range testCases {
+		if err := setShutdownTimeout(testCase.timeout); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if timeout := getShutdownTimeout(); timeout != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, timeout)
+		}
+	}
+}
@@ -140,6 +140,12 @@ func doesPresignV2SignatureMatch(r *http.Request) APIErrorCode {
 		return ErrExpiredPresignRequest
 	}
 
+	// Signature V2 carries an absolute expiry, reject URLs which
+	// remain valid for longer than the configured limit.
+	if time.Unix(expiresInt, 0).Sub(time.Now().UTC()) > getMaxPresignExpiry() {
+		return ErrMaximumExpires
+	}
+
 	expectedSignature := preSignatureV2(r.Method, encodedResource, strings.Join(filteredQueries, "&"), r.Header, expires)
 	if gotSignature != expectedSignature {
 		return ErrSignatureDoesNotMatch
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -189,6 +190,11 @@ func parsePreSignV4(query url.Values) (preSignValues, APIErrorCode) {
 	if preSignV4Values.Expires < 0 {
 		return preSignValues{}, ErrNegativeExpires
 	}
+
+	// Reject URLs signed with an expiry beyond the configured limit.
+	if preSignV4Values.Expires > getMaxPresignExpiry() {
+		return preSignValues{}, ErrMaximumExpires
+	}
 	// Save signed headers.
 	preSignV4Values.SignedHeaders, err = parseSignedHeader("SignedHeaders=" + query.Get("X-Amz-SignedHeaders"))
 	if err != ErrNone {
@@ -205,6 +211,18 @@ func parsePreSignV4(query url.Values) (preSignValues, APIErrorCode) {
 	return preSignV4Values, ErrNone
 }
 
+// getReqAccessKeyV4 - returns the access key of the signature version
+// '4' credential r is signed with, empty if r is not signed with V4.
+func getReqAccessKeyV4(r *http.Request) string {
+	if signV4Values, err := parseSignV4(r.Header.Get("Authorization")); err == ErrNone {
+		return signV4Values.Credential.accessKey
+	}
+	if preSignV4Values, err := parsePreSignV4(r.URL.Query()); err == ErrNone {
+		return preSignV4Values.Credential.accessKey
+	}
+	return ""
+}
+
 // Parses signature version '4' header of the following form.
 //
 //    Authorization: algorithm Credential=accessKeyID/credScope, \
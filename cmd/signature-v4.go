@@ -150,9 +150,9 @@ func getSignature(signingKey []byte, stringToSign string) string {
 func doesPolicySignatureMatch(formValues map[string]string) APIErrorCode {
 	// For SignV2 - Signature field will be valid
 	if formValues["Signature"] != "" {
-		return doesPolicySignatureV2Match(formValues)
+		return credentialUsed(doesPolicySignatureV2Match(formValues))
 	}
-	return doesPolicySignatureV4Match(formValues)
+	return credentialUsed(doesPolicySignatureV4Match(formValues))
 }
 
 // doesPolicySignatureMatch - Verify query headers with post policy
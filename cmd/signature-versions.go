@@ -0,0 +1,143 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+)
+
+// Signature versions that can be allowed.
+const (
+	signatureVersionV2 = "v2"
+	signatureVersionV4 = "v4"
+)
+
+// Allowed signature versions config name.
+const signatureVersionsConfig = "signature-versions.json"
+
+// errInvalidSignatureVersions - allowed signature versions are empty
+// or contain an unknown version.
+var errInvalidSignatureVersions = errors.New("Allowed signature versions must be a non empty list of v2 and v4")
+
+// validateSignatureVersions - validates a list of signature versions
+// to allow. At least one version is required, allowing none would
+// reject every signed request, including the one to allow them again.
+func validateSignatureVersions(versions []string) error {
+	if len(versions) == 0 {
+		return errInvalidSignatureVersions
+	}
+	for _, version := range versions {
+		if version != signatureVersionV2 && version != signatureVersionV4 {
+			return errInvalidSignatureVersions
+		}
+	}
+	return nil
+}
+
+// allowedSignatures - signature versions accepted on signed and
+// presigned requests, can be changed at runtime via admin RPC. All
+// versions are allowed by default.
+type allowedSignatures struct {
+	mutex sync.RWMutex
+	v2    bool
+	v4    bool
+}
+
+var globalAllowedSignatures = &allowedSignatures{v2: true, v4: true}
+
+// get - returns the allowed signature versions.
+func (a *allowedSignatures) get() []string {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	versions := []string{}
+	if a.v2 {
+		versions = append(versions, signatureVersionV2)
+	}
+	if a.v4 {
+		versions = append(versions, signatureVersionV4)
+	}
+	return versions
+}
+
+// set - allows only the given signature versions.
+func (a *allowedSignatures) set(versions []string) error {
+	if err := validateSignatureVersions(versions); err != nil {
+		return err
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.v2, a.v4 = false, false
+	for _, version := range versions {
+		switch version {
+		case signatureVersionV2:
+			a.v2 = true
+		case signatureVersionV4:
+			a.v4 = true
+		}
+	}
+	return nil
+}
+
+// allows - returns true if requests of aType are allowed, requests not
+// carrying a signature are always allowed.
+func (a *allowedSignatures) allows(aType authType) bool {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	switch aType {
+	case authTypeSignedV2, authTypePresignedV2:
+		return a.v2
+	case authTypeSigned, authTypePresigned, authTypeStreamingSigned, authTypePostPolicy:
+		return a.v4
+	}
+	return true
+}
+
+// writeAllowedSignatureVersions - persists the allowed signature
+// versions, allowing all of them removes any previously persisted
+// versions.
+func writeAllowedSignatureVersions(objAPI ObjectLayer, versions []string) error {
+	allowed := &allowedSignatures{}
+	if err := allowed.set(versions); err != nil {
+		return err
+	}
+	if allowed.v2 && allowed.v4 {
+		return writeServerSetting(objAPI, signatureVersionsConfig, nil)
+	}
+	return writeServerSetting(objAPI, signatureVersionsConfig, SignatureVersions{Versions: versions})
+}
+
+// initAllowedSignatureVersions - loads the allowed signature versions,
+// so that a restarted server rejects the same requests as its peers.
+func initAllowedSignatureVersions(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	var allowed SignatureVersions
+	ok, err := readServerSetting(objAPI, signatureVersionsConfig, &allowed)
+	if err != nil {
+		if isErrIgnored(err, errDiskNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	return globalAllowedSignatures.set(allowed.Versions)
+}
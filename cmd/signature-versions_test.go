@@ -0,0 +1,158 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestAllowedSignaturesSet - tests changing allowed signature versions.
+func TestAllowedSignaturesSet(t *testing.T) {
+	signatures := &allowedSignatures{v2: true, v4: true}
+	testCases := []struct {
+		versions []string
+		err      error
+		expected []string
+	}{
+		{[]string{signatureVersionV4}, nil, []string{signatureVersionV4}},
+		// No version would lock everyone out.
+		{nil, errInvalidSignatureVersions, []string{signatureVersionV4}},
+		{[]string{}, errInvalidSignatureVersions, []string{signatureVersionV4}},
+		{[]string{signatureVersionV2, "v3"}, errInvalidSignatureVersions, []string{signatureVersionV4}},
+		{[]string{signatureVersionV4, signatureVersionV2}, nil, []string{signatureVersionV2, signatureVersionV4}},
+		{[]string{signatureVersionV2}, nil, []string{signatureVersionV2}},
+	}
+	for i, testCase := range testCases {
+		if err := signatures.set(testCase.versions); err != testCase.err {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.err, err)
+		}
+		if versions := signatures.get(); !reflect.DeepEqual(versions, testCase.expected) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.expected, versions)
+		}
+	}
+}
+
+// TestSignatureVersionsRestriction - tests requests are rejected when
+// their signature version is not allowed.
+func TestSignatureVersionsRestriction(t *testing.T) {
+	root, err := newTestConfig("us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalAllowedSignatures()
+
+	handler := setAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	creds := serverConfig.GetCredential()
+	newRequest := func(signer string) *http.Request {
+		req, rErr := newTestRequest("GET", "http://127.0.0.1:9000/bucket/object", 0, nil)
+		if rErr != nil {
+			t.Fatal(rErr)
+		}
+		switch signer {
+		case "v2":
+			rErr = signRequestV2(req, creds.AccessKey, creds.SecretKey)
+		case "presigned-v2":
+			rErr = preSignV2(req, creds.AccessKey, creds.SecretKey, 60)
+		case "v4":
+			rErr = signRequestV4(req, creds.AccessKey, creds.SecretKey)
+		case "presigned-v4":
+			rErr = preSignV4(req, creds.AccessKey, creds.SecretKey, 60)
+		}
+		if rErr != nil {
+			t.Fatal(rErr)
+		}
+		return req
+	}
+
+	testCases := []struct {
+		allowed        []string
+		signer         string
+		expectedStatus int
+	}{
+		{[]string{signatureVersionV2, signatureVersionV4}, "v2", http.StatusOK},
+		{[]string{signatureVersionV4}, "v2", http.StatusForbidden},
+		{[]string{signatureVersionV4}, "presigned-v2", http.StatusForbidden},
+		{[]string{signatureVersionV4}, "v4", http.StatusOK},
+		{[]string{signatureVersionV4}, "presigned-v4", http.StatusOK},
+		{[]string{signatureVersionV4}, "anonymous", http.StatusOK},
+		{[]string{signatureVersionV2}, "v4", http.StatusForbidden},
+		{[]string{signatureVersionV2}, "presigned-v4", http.StatusForbidden},
+		{[]string{signatureVersionV2}, "presigned-v2", http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		if err = globalAllowedSignatures.set(testCase.allowed); err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newRequest(testCase.signer))
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: Expected %s request to get %d with %v allowed, got %d",
+				i+1, testCase.signer, testCase.expectedStatus, testCase.allowed, rec.Code)
+		}
+	}
+}
+
+// TestAllowedSignatureVersionsRestart - tests a restarted server loads
+// the persisted signature versions.
+func TestAllowedSignatureVersionsRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalAllowedSignatures()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	if err = writeAllowedSignatureVersions(objLayer, nil); err != errInvalidSignatureVersions {
+		t.Fatalf("Expected %v, got %v", errInvalidSignatureVersions, err)
+	}
+	if err = writeAllowedSignatureVersions(objLayer, []string{signatureVersionV4}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, the versions are loaded again.
+	resetGlobalAllowedSignatures()
+	if err = initAllowedSignatureVersions(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if versions := globalAllowedSignatures.get(); !reflect.DeepEqual(versions, []string{signatureVersionV4}) {
+		t.Fatalf("Expected only v4 after restart, got %v", versions)
+	}
+
+	// Allowing all versions again removes the persisted versions.
+	if err = writeAllowedSignatureVersions(objLayer, []string{signatureVersionV2, signatureVersionV4}); err != nil {
+		t.Fatal(err)
+	}
+	resetGlobalAllowedSignatures()
+	if err = initAllowedSignatureVersions(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if versions := globalAllowedSignatures.get(); !reflect.DeepEqual(versions, []string{signatureVersionV2, signatureVersionV4}) {
+		t.Fatalf("Expected all versions, got %v", versions)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// Number of slow requests kept by every server.
+	slowRequestLogSize = 1000
+
+	// Requests faster than this are not kept, the threshold of a
+	// query can not go below it.
+	slowRequestMinThreshold = 100 * time.Millisecond
+)
+
+// Phases of the processing of a request.
+const (
+	requestPhaseAuth = iota
+	requestPhaseLock
+	requestPhaseDisk
+	requestPhases
+)
+
+// Names of the phases of a request, as reported.
+var requestPhaseNames = [requestPhases]string{
+	requestPhaseAuth: "auth",
+	requestPhaseLock: "lock",
+	requestPhaseDisk: "disk",
+}
+
+// requestPhaseTimes - time spent by a request in each phase, updated
+// while it is served.
+type requestPhaseTimes struct {
+	durations [requestPhases]int64
+}
+
+// requestPhaseTimesKey - context key of the phase times of a request.
+type requestPhaseTimesKey struct{}
+
+// withRequestPhaseTimes - returns r carrying new phase times, along
+// with them.
+func withRequestPhaseTimes(r *http.Request) (*http.Request, *requestPhaseTimes) {
+	times := &requestPhaseTimes{}
+	return r.WithContext(context.WithValue(r.Context(), requestPhaseTimesKey{}, times)), times
+}
+
+// timeRequestPhase - runs f, adding the time it took to phase of r.
+// Requests without phase times, not served through timedAPIHandler,
+// just run f.
+func timeRequestPhase(r *http.Request, phase int, f func()) {
+	times, ok := r.Context().Value(requestPhaseTimesKey{}).(*requestPhaseTimes)
+	if !ok {
+		f()
+		return
+	}
+	start := time.Now()
+	f()
+	atomic.AddInt64(&times.durations[phase], int64(time.Since(start)))
+}
+
+// get - returns the time spent in each phase by a request which took
+// duration. Only authentication and namespace locks taken by handlers
+// are timed, the object layer does not know which request it serves:
+// the rest, disk access and the locks taken by the object layer, is
+// accounted to disk.
+func (t *requestPhaseTimes) get(duration time.Duration) [requestPhases]time.Duration {
+	var phases [requestPhases]time.Duration
+	phases[requestPhaseAuth] = time.Duration(atomic.LoadInt64(&t.durations[requestPhaseAuth]))
+	phases[requestPhaseLock] = time.Duration(atomic.LoadInt64(&t.durations[requestPhaseLock]))
+	phases[requestPhaseDisk] = duration - phases[requestPhaseAuth] - phases[requestPhaseLock]
+	if phases[requestPhaseDisk] < 0 {
+		phases[requestPhaseDisk] = 0
+	}
+	return phases
+}
+
+// SlowRequest - a request which took at least slowRequestMinThreshold,
+// Phase is the phase it spent the most time in.
+type SlowRequest struct {
+	Node     string                   `json:"node,omitempty"`
+	Time     time.Time                `json:"time"`
+	API      string                   `json:"api"`
+	Method   string                   `json:"method"`
+	Path     string                   `json:"path"`
+	Duration time.Duration            `json:"duration"`
+	ClientIP string                   `json:"clientIP"`
+	Phase    string                   `json:"phase"`
+	Phases   map[string]time.Duration `json:"phases"`
+}
+
+// newSlowRequest - returns the slow request r served as api, started
+// at start and which took duration.
+func newSlowRequest(api string, r *http.Request, start time.Time, duration time.Duration, times *requestPhaseTimes) SlowRequest {
+	req := SlowRequest{
+		Time:     start.UTC(),
+		API:      api,
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Duration: duration,
+		ClientIP: getSourceIP(r),
+		Phases:   make(map[string]time.Duration),
+	}
+	slowest := time.Duration(-1)
+	for phase, d := range times.get(duration) {
+		req.Phases[requestPhaseNames[phase]] = d
+		if d > slowest {
+			slowest = d
+			req.Phase = requestPhaseNames[phase]
+		}
+	}
+	return req
+}
+
+// slowRequestLog - the last slowRequestLogSize slow requests served by
+// this server, kept in memory.
+type slowRequestLog struct {
+	mutex    sync.Mutex
+	requests []SlowRequest
+}
+
+var globalSlowRequests = &slowRequestLog{}
+
+// record - logs req if it is slow, dropping the oldest request once
+// the log is full.
+func (l *slowRequestLog) record(req SlowRequest) {
+	if req.Duration < slowRequestMinThreshold {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.requests = append(l.requests, req)
+	if len(l.requests) > slowRequestLogSize {
+		l.requests = append([]SlowRequest{}, l.requests[len(l.requests)-slowRequestLogSize:]...)
+	}
+}
+
+// last - returns the last n requests which took at least threshold,
+// latest first.
+func (l *slowRequestLog) last(threshold time.Duration, n int) []SlowRequest {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	requests := []SlowRequest{}
+	for i := len(l.requests) - 1; i >= 0 && len(requests) < n; i-- {
+		if l.requests[i].Duration >= threshold {
+			requests = append(requests, l.requests[i])
+		}
+	}
+	return requests
+}
+
+// getSlowRequestLog - returns the last n requests served by this
+// server which took at least thresholdMs milliseconds, latest first.
+func getSlowRequestLog(thresholdMs, n int) ([]SlowRequest, error) {
+	threshold := time.Duration(thresholdMs) * time.Millisecond
+	if threshold < slowRequestMinThreshold || n <= 0 || n > slowRequestLogSize {
+		return nil, errInvalidArgument
+	}
+	return globalSlowRequests.last(threshold, n), nil
+}
+
+// mergeSlowRequests - returns the last n requests out of the slow
+// requests of every server, latest first.
+func mergeSlowRequests(logs [][]SlowRequest, n int) []SlowRequest {
+	requests := []SlowRequest{}
+	for _, log := range logs {
+		requests = append(requests, log...)
+	}
+	sort.Sort(bySlowRequestTime(requests))
+	if len(requests) > n {
+		requests = requests[:n]
+	}
+	return requests
+}
+
+// bySlowRequestTime - sorts slow requests latest first.
+type bySlowRequestTime []SlowRequest
+
+func (s bySlowRequestTime) Len() int           { return len(s) }
+func (s bySlowRequestTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s bySlowRequestTime) Less(i, j int) bool { return s[i].Time.After(s[j].Time) }
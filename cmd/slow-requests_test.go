@@ -0,0 +1,136 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Tests only requests over the threshold of a query are returned,
+// latest first.
+func TestSlowRequestLog(t *testing.T) {
+	defer resetGlobalSlowRequests()
+
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	durations := []time.Duration{
+		50 * time.Millisecond, // Too fast to be kept.
+		300 * time.Millisecond,
+		150 * time.Millisecond,
+		2 * time.Second,
+		100 * time.Millisecond,
+	}
+	for i, d := range durations {
+		globalSlowRequests.record(SlowRequest{Time: start.Add(time.Duration(i) * time.Second), Duration: d})
+	}
+
+	testCases := []struct {
+		thresholdMs int
+		n           int
+		expected    []time.Duration
+	}{
+		{100, 10, []time.Duration{100 * time.Millisecond, 2 * time.Second, 150 * time.Millisecond, 300 * time.Millisecond}},
+		{200, 10, []time.Duration{2 * time.Second, 300 * time.Millisecond}},
+		{100, 2, []time.Duration{100 * time.Millisecond, 2 * time.Second}},
+		{5000, 10, nil},
+	}
+	for i, testCase := range testCases {
+		requests, err := getSlowRequestLog(testCase.thresholdMs, testCase.n)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if len(requests) != len(testCase.expected) {
+			t.Fatalf("Test %d: expected %d requests, got %d", i+1, len(testCase.expected), len(requests))
+		}
+		for j, req := range requests {
+			if req.Duration != testCase.expected[j] {
+				t.Errorf("Test %d: expected request %d to take %v, got %v", i+1, j, testCase.expected[j], req.Duration)
+			}
+		}
+	}
+
+	// The threshold can not go below what is kept.
+	if _, err := getSlowRequestLog(10, 10); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+	if _, err := getSlowRequestLog(100, slowRequestLogSize+1); err != errInvalidArgument {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+
+	// The log is bounded.
+	for i := 0; i < 2*slowRequestLogSize; i++ {
+		globalSlowRequests.record(SlowRequest{Duration: time.Second})
+	}
+	if n := len(globalSlowRequests.requests); n != slowRequestLogSize {
+		t.Fatalf("Expected %d requests kept, got %d", slowRequestLogSize, n)
+	}
+}
+
+// Tests slow requests are attributed to the phase they spent the most
+// time in.
+func TestSlowRequestPhase(t *testing.T) {
+	testCases := []struct {
+		auth, lock time.Duration
+		phase      string
+	}{
+		{600 * time.Millisecond, 100 * time.Millisecond, "auth"},
+		{100 * time.Millisecond, 600 * time.Millisecond, "lock"},
+		{100 * time.Millisecond, 100 * time.Millisecond, "disk"},
+	}
+	for i, testCase := range testCases {
+		r, times := withRequestPhaseTimes(httptest.NewRequest("GET", "/bucket/object", nil))
+		times.durations[requestPhaseAuth] = int64(testCase.auth)
+		times.durations[requestPhaseLock] = int64(testCase.lock)
+		req := newSlowRequest("GetObject", r, time.Now(), time.Second, times)
+		if req.Phase != testCase.phase {
+			t.Errorf("Test %d: expected phase %s, got %s", i+1, testCase.phase, req.Phase)
+		}
+		if disk := req.Phases["disk"]; disk != time.Second-testCase.auth-testCase.lock {
+			t.Errorf("Test %d: expected %v on disk, got %v", i+1, time.Second-testCase.auth-testCase.lock, disk)
+		}
+	}
+}
+
+// Tests requests served through timedAPIHandler are logged when slow,
+// with the time of the phases timed by the handler.
+func TestTimedAPIHandlerSlowRequest(t *testing.T) {
+	defer resetGlobalSlowRequests()
+
+	handler := timedAPIHandler("PutObject", func(w http.ResponseWriter, r *http.Request) {
+		timeRequestPhase(r, requestPhaseLock, func() {
+			time.Sleep(slowRequestMinThreshold)
+		})
+	})
+	handler(httptest.NewRecorder(), httptest.NewRequest("PUT", "/bucket/object", nil))
+	// Fast requests are not logged.
+	timedAPIHandler("GetObject", func(w http.ResponseWriter, r *http.Request) {})(
+		httptest.NewRecorder(), httptest.NewRequest("GET", "/bucket/object", nil))
+
+	requests, err := getSlowRequestLog(int(slowRequestMinThreshold/time.Millisecond), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 slow request, got %v", requests)
+	}
+	req := requests[0]
+	if req.API != "PutObject" || req.Method != "PUT" || req.Path != "/bucket/object" || req.Phase != "lock" {
+		t.Fatalf("Expected PutObject slowed down by locks, got %#v", req)
+	}
+}
@@ -172,6 +172,9 @@ func newSignV4ChunkedReader(req *http.Request) (io.Reader, APIErrorCode) {
 	if errCode != ErrNone {
 		return nil, errCode
 	}
+	if errCode = credentialUsed(errCode); errCode != ErrNone {
+		return nil, errCode
+	}
 	return &s3ChunkedReader{
 		reader:            bufio.NewReader(req.Body),
 		seedSignature:     seedSignature,
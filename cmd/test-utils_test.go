@@ -500,6 +500,174 @@ func resetGlobalIsEnvs() {
 	globalIsEnvCreds = false
 }
 
+func resetGlobalMaxPresignExpiry() {
+	globalMaxPresignExpiry = int64(maxPresignExpiry)
+}
+
+func resetGlobalHealDeletePolicy() {
+	globalHealDeletePolicy = &healDeletePolicy{mode: healDeleteReportOnly}
+}
+
+func resetGlobalAPILatency() {
+	globalAPILatency = &apiLatency{histograms: make(map[string]LatencyHistogram)}
+}
+
+func resetGlobalKeyNamingPolicy() {
+	globalKeyNamingPolicy = &keyNamingPolicy{}
+}
+
+func resetGlobalBootDiagnostics() {
+	globalBootDiagnostics = newBootDiagnostics()
+}
+
+func resetGlobalMultipartLifetime() {
+	globalMultipartLifetime = &multipartLifetime{}
+}
+
+func resetGlobalConditionalWrites() {
+	globalConditionalWrites = &conditionalWrites{enabled: true}
+}
+
+func resetGlobalClusterEvents() {
+	globalClusterEvents = &clusterEventLog{}
+}
+
+func resetGlobalBucketTags() {
+	globalBucketTags = newBucketTags(nil)
+}
+
+func resetGlobalAllowedSignatures() {
+	globalAllowedSignatures = &allowedSignatures{v2: true, v4: true}
+}
+
+func resetGlobalImmutabilityWindows() {
+	globalImmutabilityWindows = newImmutabilityWindows(nil)
+}
+
+func resetGlobalLockHolds() {
+	globalLockHolds = &lockHolds{completed: newLatencyHistogram()}
+}
+
+func resetGlobalTLSCiphers() {
+	globalTLSCiphers = newTLSCiphers()
+}
+
+func resetGlobalWriteAmp() {
+	globalWriteAmp = newWriteAmpCounters()
+}
+
+func resetGlobalMaxUploadParts() {
+	globalMaxUploadParts = int64(maxPartID)
+}
+
+func resetGlobalRequestIDConfig() {
+	globalRequestIDConfig = newRequestIDConfig()
+}
+
+func resetGlobalRPCErrors() {
+	globalRPCErrors = newRPCErrorCounters()
+}
+
+func resetGlobalBucketObjectLimits() {
+	globalBucketObjectLimits = newBucketObjectLimits(nil)
+}
+
+func resetGlobalScannerLatency() {
+	globalScannerLatency = newScannerLatency()
+}
+
+func resetGlobalTrustedProxies() {
+	globalTrustedProxies = &trustedProxies{}
+}
+
+func resetGlobalBucketLogging() {
+	globalBucketLogging = newBucketLogging(nil)
+}
+
+func resetGlobalChecksumPolicy() {
+	globalChecksumPolicy = newChecksumPolicy()
+}
+
+func resetGlobalNotificationRetry() {
+	globalNotificationRetry = &notificationRetry{}
+}
+
+func resetGlobalMaxListDepth() {
+	globalMaxListDepth = 0
+}
+
+func resetGlobalBucketPartSizeLimits() {
+	globalBucketPartSizeLimits = newBucketPartSizeLimits()
+}
+
+func resetGlobalDeleteProtectionTags() {
+	globalDeleteProtectionTags = newDeleteProtectionTags(nil)
+}
+
+func resetGlobalConfigChangeLog() {
+	globalConfigChangeLog = &configChangeLog{}
+}
+
+func resetGlobalScanOnWrite() {
+	globalScanOnWrite = &scanOnWrite{}
+}
+
+func resetGlobalCredentialExpiry() {
+	globalCredentialExpiry = newCredentialExpiry()
+}
+
+func resetGlobalMultiDeleteLimit() {
+	globalMultiDeleteLimit = maxDeleteObjects
+}
+
+func resetGlobalRejectReasons() {
+	globalRejectReasons = newRejectReasonCounters()
+}
+
+func resetGlobalSlowRequests() {
+	globalSlowRequests = &slowRequestLog{}
+}
+
+func resetGlobalClientConns() {
+	globalClientConns = newClientConnTracker()
+}
+
+func resetGlobalBackgroundAppend() {
+	globalBackgroundAppend = &backgroundAppendToggle{enabled: true}
+}
+
+func resetGlobalObjectAccess() {
+	globalObjectAccess = newObjectAccess()
+}
+
+func resetGlobalBucketUploadLimits() {
+	globalBucketUploadLimits = newBucketUploadLimits()
+}
+
+func resetGlobalDecodeFailures() {
+	globalDecodeFailures = &decodeFailureLog{}
+}
+
+func resetGlobalMaintenance() {
+	globalMaintenance = &maintenance{}
+}
+
+func resetGlobalQuorumOverride() {
+	globalQuorumOverride = &quorumOverride{}
+}
+
+func resetGlobalShutdownTimeout() {
+	globalShutdownTimeout = int64(defaultShutdownTimeout)
+}
+
+func resetGlobalHealLimiter() {
+	globalHealLimiter = newHealLimiter(0)
+}
+
+func resetGlobalCredentialUsage() {
+	globalCredentialUsage = newCredentialUsage()
+}
+
 // Resets all the globals used modified in tests.
 // Resetting ensures that the changes made to globals by one test doesn't affect others.
 func resetTestGlobals() {
@@ -519,6 +687,90 @@ func resetTestGlobals() {
 	resetGlobalIsXL()
 	// Reset global isEnvCreds flag.
 	resetGlobalIsEnvs()
+	// Reset presigned URL expiry limit.
+	resetGlobalMaxPresignExpiry()
+	// Reset heal delete policy.
+	resetGlobalHealDeletePolicy()
+	// Reset API latency histograms.
+	resetGlobalAPILatency()
+	// Reset object naming policy.
+	resetGlobalKeyNamingPolicy()
+	// Reset boot diagnostics.
+	resetGlobalBootDiagnostics()
+	// Reset multipart upload lifetime.
+	resetGlobalMultipartLifetime()
+	// Reset conditional writes toggle.
+	resetGlobalConditionalWrites()
+	// Reset cluster events.
+	resetGlobalClusterEvents()
+	// Reset bucket tags.
+	resetGlobalBucketTags()
+	// Reset allowed signature versions.
+	resetGlobalAllowedSignatures()
+	// Reset immutability windows.
+	resetGlobalImmutabilityWindows()
+	// Reset lock hold histogram.
+	resetGlobalLockHolds()
+	// Reset TLS cipher suites.
+	resetGlobalTLSCiphers()
+	// Reset write amplification counters.
+	resetGlobalWriteAmp()
+	// Reset limit of parts per upload.
+	resetGlobalMaxUploadParts()
+	// Reset request ID configuration.
+	resetGlobalRequestIDConfig()
+	// Reset admin RPC error counters.
+	resetGlobalRPCErrors()
+	// Reset bucket object limits.
+	resetGlobalBucketObjectLimits()
+	// Reset latency of requests served while scanning and idle.
+	resetGlobalScannerLatency()
+	// Reset trusted proxies.
+	resetGlobalTrustedProxies()
+	// Reset bucket logging.
+	resetGlobalBucketLogging()
+	// Reset credential usage.
+	resetGlobalCredentialUsage()
+	// Reset heal limiter.
+	resetGlobalHealLimiter()
+	// Reset shutdown timeout.
+	resetGlobalShutdownTimeout()
+	// Reset checksum policy.
+	resetGlobalChecksumPolicy()
+	// Reset notification retry policy.
+	resetGlobalNotificationRetry()
+	// Reset max list depth.
+	resetGlobalMaxListDepth()
+	// Reset bucket part size limits.
+	resetGlobalBucketPartSizeLimits()
+	// Reset delete protection tags.
+	resetGlobalDeleteProtectionTags()
+	// Reset config change log.
+	resetGlobalConfigChangeLog()
+	// Reset scan on write.
+	resetGlobalScanOnWrite()
+	// Reset access key expiries.
+	resetGlobalCredentialExpiry()
+	// Reset multi-delete limit.
+	resetGlobalMultiDeleteLimit()
+	// Reset rejected request counters.
+	resetGlobalRejectReasons()
+	// Reset slow request log.
+	resetGlobalSlowRequests()
+	// Reset client connection stats.
+	resetGlobalClientConns()
+	// Reset background append toggle.
+	resetGlobalBackgroundAppend()
+	// Reset object last access times.
+	resetGlobalObjectAccess()
+	// Reset bucket concurrent upload limits.
+	resetGlobalBucketUploadLimits()
+	// Reset decode failure log.
+	resetGlobalDecodeFailures()
+	// Reset maintenance schedule.
+	resetGlobalMaintenance()
+	// Reset quorum override.
+	resetGlobalQuorumOverride()
 }
 
 // Configure the server for the test run.
@@ -2139,6 +2391,13 @@ func registerBucketLevelFunc(bucket *router.Router, api objectAPIHandlers, apiFu
 		case "ListenBucketNotification":
 			// Register ListenBucketNotification Handler.
 			bucket.Methods("GET").HandlerFunc(api.ListenBucketNotificationHandler).Queries("events", "{events:.*}")
+		case "ListObjectsV2":
+			// Register ListObjectsV2 Handler.
+			bucket.Methods("GET").HandlerFunc(api.ListObjectsV2Handler).Queries("list-type", "2")
+		case "ListObjectsV1":
+			// Register ListObjectsV1 Handler, matches any GET on the
+			// bucket so it has to be registered last.
+			bucket.Methods("GET").HandlerFunc(api.ListObjectsV1Handler)
 		}
 	}
 }
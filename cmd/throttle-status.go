@@ -0,0 +1,90 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"sync"
+)
+
+// ThrottleStatus - represents the state of a single limit enforced
+// by a server.
+type ThrottleStatus struct {
+	Name        string  `json:"name"`
+	Limit       int64   `json:"limit"`
+	InUse       int64   `json:"inUse"`
+	Utilization float64 `json:"utilization"` // InUse as a fraction of Limit.
+}
+
+// NodeThrottleStatus - represents all active limits on a server.
+type NodeThrottleStatus struct {
+	Addr      string           `json:"addr"`
+	Throttles []ThrottleStatus `json:"throttles"`
+}
+
+// throttler - implemented by limits which report their state.
+type throttler interface {
+	// Returns the configured limit and its current usage, limit
+	// is zero or less when the throttle is not configured.
+	throttleUsage() (limit int64, inUse int64)
+}
+
+// throttlers - registry of all limits on this server.
+type throttlers struct {
+	mutex  sync.Mutex
+	byName map[string]throttler
+}
+
+// Global registry of limits enforced by this server.
+var globalThrottlers = &throttlers{byName: make(map[string]throttler)}
+
+// register - adds a limit to the registry under name.
+func (ts *throttlers) register(name string, t throttler) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	ts.byName[name] = t
+}
+
+// status - returns the state of all configured limits sorted by
+// name. Limits which are not configured are left out.
+func (ts *throttlers) status() []ThrottleStatus {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	statuses := []ThrottleStatus{}
+	for name, t := range ts.byName {
+		limit, inUse := t.throttleUsage()
+		if limit <= 0 {
+			continue
+		}
+		statuses = append(statuses, ThrottleStatus{
+			Name:        name,
+			Limit:       limit,
+			InUse:       inUse,
+			Utilization: float64(inUse) / float64(limit),
+		})
+	}
+	sort.Sort(byThrottleName(statuses))
+	return statuses
+}
+
+// byThrottleName is a collection satisfying sort.Interface.
+type byThrottleName []ThrottleStatus
+
+func (t byThrottleName) Len() int           { return len(t) }
+func (t byThrottleName) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t byThrottleName) Less(i, j int) bool { return t[i].Name < t[j].Name }
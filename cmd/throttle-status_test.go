@@ -0,0 +1,85 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testThrottler - throttler reporting a fixed limit and usage.
+type testThrottler struct {
+	limit int64
+	inUse int64
+}
+
+func (t testThrottler) throttleUsage() (int64, int64) {
+	return t.limit, t.inUse
+}
+
+// newTestThrottlers - returns a registry with the given throttlers.
+func newTestThrottlers(byName map[string]throttler) *throttlers {
+	ts := &throttlers{byName: make(map[string]throttler)}
+	for name, t := range byName {
+		ts.register(name, t)
+	}
+	return ts
+}
+
+// TestThrottlersStatus - tests that only configured limits are
+// reported, sorted by name.
+func TestThrottlersStatus(t *testing.T) {
+	testCases := []struct {
+		byName   map[string]throttler
+		expected []ThrottleStatus
+	}{
+		// Test 1: nothing registered.
+		{
+			byName:   nil,
+			expected: []ThrottleStatus{},
+		},
+		// Test 2: nothing configured.
+		{
+			byName: map[string]throttler{
+				"anonymous": testThrottler{0, 0},
+				"bandwidth": testThrottler{-1, 10},
+			},
+			expected: []ThrottleStatus{},
+		},
+		// Test 3: mix of configured and unconfigured limits.
+		{
+			byName: map[string]throttler{
+				"prefix":    testThrottler{200, 50},
+				"anonymous": testThrottler{100, 100},
+				"bandwidth": testThrottler{0, 0},
+				"requests":  testThrottler{10, 0},
+			},
+			expected: []ThrottleStatus{
+				{Name: "anonymous", Limit: 100, InUse: 100, Utilization: 1},
+				{Name: "prefix", Limit: 200, InUse: 50, Utilization: 0.25},
+				{Name: "requests", Limit: 10, InUse: 0, Utilization: 0},
+			},
+		},
+	}
+
+	for i, test := range testCases {
+		statuses := newTestThrottlers(test.byName).status()
+		if !reflect.DeepEqual(statuses, test.expected) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, test.expected, statuses)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+var (
+	// errInvalidTLSCiphers - TLS cipher suites are empty or contain
+	// an unsupported suite.
+	errInvalidTLSCiphers = errors.New("TLS cipher suites must be a non empty list of supported suites")
+
+	// errWeakTLSCiphers - all TLS cipher suites are weak.
+	errWeakTLSCiphers = errors.New("TLS cipher suites must contain at least one strong suite")
+)
+
+// TLS cipher suites the server can offer, by name.
+var supportedTLSCiphers = map[string]uint16{
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	"TLS_RSA_WITH_AES_256_GCM_SHA384":         tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_RSA_WITH_AES_128_GCM_SHA256":         tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_RSA_WITH_AES_256_CBC_SHA":            tls.TLS_RSA_WITH_AES_256_CBC_SHA,
+	"TLS_RSA_WITH_AES_128_CBC_SHA":            tls.TLS_RSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA":     tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA,
+	"TLS_RSA_WITH_3DES_EDE_CBC_SHA":           tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
+	"TLS_ECDHE_ECDSA_WITH_RC4_128_SHA":        tls.TLS_ECDHE_ECDSA_WITH_RC4_128_SHA,
+	"TLS_ECDHE_RSA_WITH_RC4_128_SHA":          tls.TLS_ECDHE_RSA_WITH_RC4_128_SHA,
+	"TLS_RSA_WITH_RC4_128_SHA":                tls.TLS_RSA_WITH_RC4_128_SHA,
+}
+
+// Supported TLS cipher suites which are broken, or refused by modern
+// clients. They can be offered along with strong suites for legacy
+// clients, but never alone.
+var weakTLSCiphers = map[string]bool{
+	"TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA": true,
+	"TLS_RSA_WITH_3DES_EDE_CBC_SHA":       true,
+	"TLS_ECDHE_ECDSA_WITH_RC4_128_SHA":    true,
+	"TLS_ECDHE_RSA_WITH_RC4_128_SHA":      true,
+	"TLS_RSA_WITH_RC4_128_SHA":            true,
+}
+
+// TLS cipher suites offered by default, with forward secrecy only.
+var defaultTLSCiphers = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+}
+
+// parseTLSCiphers - returns the TLS cipher suites named by names, in
+// order of preference. At least one strong suite is required, offering
+// none would break connectivity with every client, including the one
+// to change them again.
+func parseTLSCiphers(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, errInvalidTLSCiphers
+	}
+	suites := make([]uint16, 0, len(names))
+	strong := false
+	for _, name := range names {
+		suite, ok := supportedTLSCiphers[name]
+		if !ok {
+			return nil, errInvalidTLSCiphers
+		}
+		if !weakTLSCiphers[name] {
+			strong = true
+		}
+		suites = append(suites, suite)
+	}
+	if !strong {
+		return nil, errWeakTLSCiphers
+	}
+	return suites, nil
+}
+
+// tlsCiphers - TLS cipher suites offered by the server, can be changed
+// at runtime via admin RPC.
+type tlsCiphers struct {
+	mutex sync.RWMutex
+	names []string
+	// Server reconfigured on changes, nil until it listens.
+	server *ServerMux
+}
+
+var globalTLSCiphers = newTLSCiphers()
+
+func newTLSCiphers() *tlsCiphers {
+	return &tlsCiphers{names: defaultTLSCiphers}
+}
+
+// get - returns the names of the offered TLS cipher suites.
+func (c *tlsCiphers) get() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return append([]string{}, c.names...)
+}
+
+// suites - returns the offered TLS cipher suites.
+func (c *tlsCiphers) suites() []uint16 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	// Names were validated when set.
+	suites, _ := parseTLSCiphers(c.names)
+	return suites
+}
+
+// set - offers only the named TLS cipher suites, new connections to the
+// server are negotiated with them while established connections are
+// left untouched.
+func (c *tlsCiphers) set(names []string) error {
+	suites, err := parseTLSCiphers(names)
+	if err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.names = append([]string{}, names...)
+	if c.server != nil {
+		c.server.setCipherSuites(suites)
+	}
+	return nil
+}
+
+// serve - registers m to be reconfigured on changes, and applies the
+// current suites in case they changed since m read them.
+func (c *tlsCiphers) serve(m *ServerMux) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.server = m
+	suites, _ := parseTLSCiphers(c.names)
+	m.setCipherSuites(suites)
+}
+
+// setLocalTLSCiphers - offers only the named TLS cipher suites on this
+// server and saves them in config.json, so that the server offers them
+// again once restarted. The default suites are not saved.
+func setLocalTLSCiphers(names []string) error {
+	if _, err := parseTLSCiphers(names); err != nil {
+		return err
+	}
+	saved := names
+	if reflect.DeepEqual(names, defaultTLSCiphers) {
+		saved = nil
+	}
+
+	prevNames := serverConfig.GetTLSCiphers()
+	serverConfig.SetTLSCiphers(saved)
+	if err := serverConfig.Save(); err != nil {
+		serverConfig.SetTLSCiphers(prevNames)
+		return err
+	}
+	return globalTLSCiphers.set(names)
+}
+
+// initTLSCiphers - offers the TLS cipher suites saved in config.json,
+// before the server starts listening.
+func initTLSCiphers() error {
+	names := serverConfig.GetTLSCiphers()
+	if len(names) == 0 {
+		return nil
+	}
+	return globalTLSCiphers.set(names)
+}
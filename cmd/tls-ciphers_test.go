@@ -0,0 +1,181 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"net"
+	"reflect"
+	"testing"
+)
+
+// Tests validating TLS cipher suite names.
+func TestParseTLSCiphers(t *testing.T) {
+	testCases := []struct {
+		names  []string
+		suites []uint16
+		err    error
+	}{
+		{nil, nil, errInvalidTLSCiphers},
+		{[]string{}, nil, errInvalidTLSCiphers},
+		{[]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_UNKNOWN"}, nil, errInvalidTLSCiphers},
+		{[]string{"TLS_RSA_WITH_RC4_128_SHA", "TLS_RSA_WITH_3DES_EDE_CBC_SHA"}, nil, errWeakTLSCiphers},
+		{
+			[]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_RSA_WITH_3DES_EDE_CBC_SHA"},
+			[]uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA},
+			nil,
+		},
+	}
+	for i, testCase := range testCases {
+		suites, err := parseTLSCiphers(testCase.names)
+		if err != testCase.err {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+		}
+		if !reflect.DeepEqual(suites, testCase.suites) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.suites, suites)
+		}
+	}
+
+	// Defaults must always be valid.
+	if _, err := parseTLSCiphers(defaultTLSCiphers); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Tests changing TLS cipher suites reconfigures the listeners of a
+// serving server, and invalid suites leave them untouched.
+func TestTLSCiphersSet(t *testing.T) {
+	certPEM, keyPEM, err := generateTLSCertKey("127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newTLSCiphers()
+	certificates := []tls.Certificate{cert}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener := newListenerMux(ln, newServerTLSConfig(c.suites(), certificates))
+	defer listener.Close()
+	go func() {
+		for {
+			conn, aerr := listener.Accept()
+			if aerr != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	m := NewServerMux(ln.Addr().String(), nil)
+	m.listeners = []*ListenerMux{listener}
+	m.certificates = certificates
+	c.serve(m)
+
+	// handshake - negotiates a TLS connection offering only suite.
+	handshake := func(suite uint16) error {
+		conn, derr := tls.Dial("tcp", ln.Addr().String(), &tls.Config{
+			InsecureSkipVerify: true,
+			MaxVersion:         tls.VersionTLS12,
+			CipherSuites:       []uint16{suite},
+		})
+		if derr != nil {
+			return derr
+		}
+		defer conn.Close()
+		if negotiated := conn.ConnectionState().CipherSuite; negotiated != suite {
+			t.Fatalf("Expected suite %x, got %x", suite, negotiated)
+		}
+		return nil
+	}
+
+	cbc := "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA"
+	if err = handshake(supportedTLSCiphers[cbc]); err == nil {
+		t.Fatalf("Expected %s not to be offered by default", cbc)
+	}
+
+	if err = c.set([]string{cbc}); err != nil {
+		t.Fatal(err)
+	}
+	if err = handshake(supportedTLSCiphers[cbc]); err != nil {
+		t.Fatal(err)
+	}
+	if err = handshake(tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256); err == nil {
+		t.Fatal("Expected default suites not to be offered anymore")
+	}
+
+	// Empty and weak suites are refused, keeping the current ones.
+	if err = c.set(nil); err != errInvalidTLSCiphers {
+		t.Fatalf("Expected %v, got %v", errInvalidTLSCiphers, err)
+	}
+	if err = c.set([]string{"TLS_RSA_WITH_RC4_128_SHA"}); err != errWeakTLSCiphers {
+		t.Fatalf("Expected %v, got %v", errWeakTLSCiphers, err)
+	}
+	if names := c.get(); !reflect.DeepEqual(names, []string{cbc}) {
+		t.Fatalf("Expected %v, got %v", []string{cbc}, names)
+	}
+	if err = handshake(supportedTLSCiphers[cbc]); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestTLSCiphersRestart - tests the TLS cipher suites set on a server
+// are offered again once it restarts.
+func TestTLSCiphersRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalTLSCiphers()
+
+	names := []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}
+	if err = setLocalTLSCiphers([]string{"TLS_RSA_WITH_RC4_128_SHA"}); err != errWeakTLSCiphers {
+		t.Fatalf("Expected %v, got %v", errWeakTLSCiphers, err)
+	}
+	if err = setLocalTLSCiphers(names); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, config.json is loaded before listening.
+	resetGlobalTLSCiphers()
+	if err = loadConfig(credential{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = initTLSCiphers(); err != nil {
+		t.Fatal(err)
+	}
+	if suites := globalTLSCiphers.get(); !reflect.DeepEqual(suites, names) {
+		t.Fatalf("Expected %v after restart, got %v", names, suites)
+	}
+
+	// Going back to the default suites removes them from config.json.
+	if err = setLocalTLSCiphers(defaultTLSCiphers); err != nil {
+		t.Fatal(err)
+	}
+	if err = loadConfig(credential{}); err != nil {
+		t.Fatal(err)
+	}
+	if saved := serverConfig.GetTLSCiphers(); len(saved) != 0 {
+		t.Fatalf("Expected no saved suites, got %v", saved)
+	}
+}
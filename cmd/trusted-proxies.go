@@ -0,0 +1,175 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Trusted proxies config name.
+const trustedProxiesConfig = "trusted-proxies.json"
+
+// errInvalidTrustedProxies - a trusted proxy is neither a CIDR nor an
+// IP address.
+var errInvalidTrustedProxies = errors.New("Trusted proxies must be CIDRs or IP addresses")
+
+// parseTrustedProxies - returns the networks named by cidrs, a bare IP
+// address is a network of its own.
+func parseTrustedProxies(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if ip := net.ParseIP(cidr); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errInvalidTrustedProxies
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// trustedProxies - proxies whose X-Forwarded-For and X-Real-Ip headers
+// are honored, can be changed at runtime via admin RPC. No proxy is
+// trusted by default, as anyone could otherwise forge their address.
+type trustedProxies struct {
+	mutex    sync.RWMutex
+	cidrs    []string
+	networks []*net.IPNet
+}
+
+var globalTrustedProxies = &trustedProxies{}
+
+// get - returns the CIDRs of the trusted proxies.
+func (p *trustedProxies) get() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return append([]string{}, p.cidrs...)
+}
+
+// set - trusts only the proxies within cidrs, none if empty.
+func (p *trustedProxies) set(cidrs []string) error {
+	networks, err := parseTrustedProxies(cidrs)
+	if err != nil {
+		return err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.cidrs = append([]string{}, cidrs...)
+	p.networks = networks
+	return nil
+}
+
+// isTrusted - returns true if ip is within a trusted proxy network.
+func (p *trustedProxies) isTrusted(ip net.IP) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	for _, network := range p.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientAddr - returns the address of the client which sent r. If r
+// came from a trusted proxy, X-Forwarded-For is walked from the right,
+// where our proxies appended the addresses they got requests from, up
+// to the first address which is not a trusted proxy. Forwarded headers
+// of requests from anyone else are ignored, they could be forged.
+func (p *trustedProxies) clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip == nil || !p.isTrusted(ip) {
+		return r.RemoteAddr
+	}
+
+	var forwarded []string
+	for _, value := range r.Header["X-Forwarded-For"] {
+		forwarded = append(forwarded, strings.Split(value, ",")...)
+	}
+	client := ""
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if ip == nil {
+			// Addresses left of garbage can not be trusted.
+			break
+		}
+		client = ip.String()
+		if !p.isTrusted(ip) {
+			break
+		}
+	}
+	if client != "" {
+		return client
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-Ip"))); ip != nil {
+		return ip.String()
+	}
+	return r.RemoteAddr
+}
+
+// writeTrustedProxies - persists the trusted proxies, none removes any
+// previously persisted proxies.
+func writeTrustedProxies(objAPI ObjectLayer, cidrs []string) error {
+	if _, err := parseTrustedProxies(cidrs); err != nil {
+		return err
+	}
+	if len(cidrs) == 0 {
+		return writeServerSetting(objAPI, trustedProxiesConfig, nil)
+	}
+	return writeServerSetting(objAPI, trustedProxiesConfig, TrustedProxies{CIDRs: cidrs})
+}
+
+// initTrustedProxies - loads the trusted proxies, so that a restarted
+// server finds the same client addresses as its peers.
+func initTrustedProxies(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	var proxies TrustedProxies
+	ok, err := readServerSetting(objAPI, trustedProxiesConfig, &proxies)
+	if err != nil {
+		if isErrIgnored(err, errDiskNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	return globalTrustedProxies.set(proxies.CIDRs)
+}
+
+// getSourceIP - returns the address of the client which sent r, as
+// forwarded by trusted proxies.
+func getSourceIP(r *http.Request) string {
+	return globalTrustedProxies.clientAddr(r)
+}
@@ -0,0 +1,135 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestParseTrustedProxies - tests parsing of trusted proxy CIDRs.
+func TestParseTrustedProxies(t *testing.T) {
+	testCases := []struct {
+		cidrs []string
+		err   error
+	}{
+		{nil, nil},
+		{[]string{"10.0.0.0/8", "192.168.1.10", "fd00::/8", "::1"}, nil},
+		{[]string{"10.0.0.0/33"}, errInvalidTrustedProxies},
+		{[]string{"proxy.example.com"}, errInvalidTrustedProxies},
+		{[]string{"10.0.0.0/8", ""}, errInvalidTrustedProxies},
+	}
+
+	for i, testCase := range testCases {
+		if _, err := parseTrustedProxies(testCase.cidrs); err != testCase.err {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+		}
+	}
+}
+
+// TestTrustedProxiesClientAddr - tests that forwarded headers are
+// honored from trusted proxies only.
+func TestTrustedProxiesClientAddr(t *testing.T) {
+	proxies := &trustedProxies{}
+	if err := proxies.set([]string{"10.0.0.0/8", "192.168.1.10"}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		remoteAddr string
+		header     http.Header
+		expected   string
+	}{
+		// Test 1: no forwarded headers.
+		{"10.0.0.1:1234", http.Header{}, "10.0.0.1:1234"},
+		// Test 2: forwarded by a trusted proxy.
+		{"10.0.0.1:1234", http.Header{"X-Forwarded-For": {"203.0.113.7"}}, "203.0.113.7"},
+		// Test 3: forwarded by a chain of trusted proxies.
+		{"10.0.0.1:1234", http.Header{"X-Forwarded-For": {"203.0.113.7, 192.168.1.10", "10.1.1.1"}}, "203.0.113.7"},
+		// Test 4: client forged addresses left of its own.
+		{"10.0.0.1:1234", http.Header{"X-Forwarded-For": {"10.9.9.9, 198.51.100.1, 203.0.113.7"}}, "203.0.113.7"},
+		// Test 5: garbage in the chain stops the walk.
+		{"10.0.0.1:1234", http.Header{"X-Forwarded-For": {"203.0.113.7, garbage, 10.1.1.1"}}, "10.1.1.1"},
+		// Test 6: X-Real-Ip from a trusted proxy.
+		{"192.168.1.10:1234", http.Header{"X-Real-Ip": {"203.0.113.7"}}, "203.0.113.7"},
+		// Test 7: forwarded headers from an untrusted client are ignored.
+		{"198.51.100.1:1234", http.Header{"X-Forwarded-For": {"10.0.0.1"}, "X-Real-Ip": {"203.0.113.7"}}, "198.51.100.1:1234"},
+		// Test 8: not trusting a neighbour of a trusted address.
+		{"192.168.1.11:1234", http.Header{"X-Forwarded-For": {"203.0.113.7"}}, "192.168.1.11:1234"},
+	}
+
+	for i, testCase := range testCases {
+		r := &http.Request{RemoteAddr: testCase.remoteAddr, Header: testCase.header}
+		if addr := proxies.clientAddr(r); addr != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, addr)
+		}
+	}
+
+	// Trusting no proxy ignores forwarded headers of everyone.
+	if err := proxies.set(nil); err != nil {
+		t.Fatal(err)
+	}
+	r := &http.Request{RemoteAddr: "10.0.0.1:1234", Header: http.Header{"X-Forwarded-For": {"203.0.113.7"}}}
+	if addr := proxies.clientAddr(r); addr != r.RemoteAddr {
+		t.Fatalf("Expected %s, got %s", r.RemoteAddr, addr)
+	}
+}
+
+// TestTrustedProxiesRestart - tests a restarted server loads the
+// persisted trusted proxies.
+func TestTrustedProxiesRestart(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalTrustedProxies()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(fsDir)
+
+	if err = writeTrustedProxies(objLayer, []string{"10.0.0.0/33"}); err != errInvalidTrustedProxies {
+		t.Fatalf("Expected %v, got %v", errInvalidTrustedProxies, err)
+	}
+	if err = writeTrustedProxies(objLayer, []string{"10.0.0.0/8", "192.168.1.1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, the proxies are loaded again.
+	resetGlobalTrustedProxies()
+	if err = initTrustedProxies(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if cidrs := globalTrustedProxies.get(); len(cidrs) != 2 || cidrs[0] != "10.0.0.0/8" || cidrs[1] != "192.168.1.1" {
+		t.Fatalf("Expected the proxies after restart, got %v", cidrs)
+	}
+
+	// Trusting no proxy removes the persisted proxies.
+	if err = writeTrustedProxies(objLayer, []string{}); err != nil {
+		t.Fatal(err)
+	}
+	resetGlobalTrustedProxies()
+	if err = initTrustedProxies(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if cidrs := globalTrustedProxies.get(); len(cidrs) != 0 {
+		t.Fatalf("Expected no proxies, got %v", cidrs)
+	}
+}
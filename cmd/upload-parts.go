@@ -0,0 +1,48 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+// partsByNumber - a collection of parts satisfying sort.Interface.
+type partsByNumber []PartInfo
+
+func (p partsByNumber) Len() int           { return len(p) }
+func (p partsByNumber) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p partsByNumber) Less(i, j int) bool { return p[i].PartNumber < p[j].PartNumber }
+
+// listLocalUploadParts - returns all parts of a multipart upload as
+// seen by the local server, InvalidUploadID if it does not know the
+// upload.
+func listLocalUploadParts(bucket, object, uploadID string) ([]PartInfo, error) {
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return nil, errServerNotInitialized
+	}
+
+	parts := []PartInfo{}
+	partNumberMarker := 0
+	for {
+		result, err := objLayer.ListObjectParts(bucket, object, uploadID, partNumberMarker, maxPartsList)
+		if err != nil {
+			return nil, errorCause(err)
+		}
+		parts = append(parts, result.Parts...)
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+}
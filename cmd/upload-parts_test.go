@@ -0,0 +1,70 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Wrapper for calling upload parts listing tests for both XL multiple
+// disks and single node setup.
+func TestListLocalUploadParts(t *testing.T) {
+	ExecObjectLayerTest(t, testListLocalUploadParts)
+}
+
+func testListLocalUploadParts(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer resetGlobalObjectAPI()
+
+	bucket, object := "bucket", "object"
+	if err := obj.MakeBucket(bucket); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	uploadID, err := obj.NewMultipartUpload(bucket, object, nil)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	// Parts uploaded out of order.
+	for _, partID := range []int{3, 1, 2} {
+		data := bytes.Repeat([]byte("a"), partID*10)
+		if _, err = obj.PutObjectPart(bucket, object, uploadID, partID, int64(len(data)), bytes.NewReader(data), "", ""); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	parts, err := listLocalUploadParts(bucket, object, uploadID)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(parts) != 3 {
+		t.Fatalf("%s: Expected 3 parts, got %v", instanceType, parts)
+	}
+	for i, part := range parts {
+		if part.PartNumber != i+1 || part.Size != int64((i+1)*10) || part.ETag == "" {
+			t.Fatalf("%s: Unexpected part %d: %v", instanceType, i+1, part)
+		}
+	}
+
+	if _, err = listLocalUploadParts(bucket, object, "unknown-upload"); err == nil {
+		t.Fatalf("%s: Expected an error for an unknown upload", instanceType)
+	} else if _, ok := err.(InvalidUploadID); !ok {
+		t.Fatalf("%s: Expected %v, got %v", instanceType, InvalidUploadID{UploadID: "unknown-upload"}, err)
+	}
+}
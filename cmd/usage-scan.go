@@ -0,0 +1,174 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// Finished usage scans kept around so that their result can
+	// still be polled.
+	maxUsageScans = 100
+
+	// Objects listed per page while scanning a bucket.
+	usageScanMaxKeys = 1000
+
+	// Pause between listing pages, so that a scan of a large bucket
+	// does not starve client requests of disk I/O.
+	usageScanPageInterval = 100 * time.Millisecond
+
+	// Prefix in minioMetaBucket of the locks serializing usage scans
+	// of a bucket.
+	usageScansPrefix = "usage-scans"
+)
+
+// errNoSuchUsageScan - no server knows about the requested usage scan.
+var errNoSuchUsageScan = errors.New("No such usage scan")
+
+// UsageScanStatus - progress of a scan counting the objects of a bucket
+// and their size.
+type UsageScanStatus struct {
+	ID      string    `json:"id"`
+	Bucket  string    `json:"bucket"`
+	Started time.Time `json:"started"`
+
+	Objects int64 `json:"objects"` // Objects counted so far.
+	Bytes   int64 `json:"bytes"`   // Size of the objects counted so far.
+
+	Done  bool   `json:"done"`
+	Error string `json:"error,omitempty"`
+}
+
+// usageScans - usage scans run by this server, keyed by scan ID.
+type usageScans struct {
+	mutex sync.Mutex
+	scans map[string]*UsageScanStatus
+}
+
+func newUsageScans() *usageScans {
+	return &usageScans{scans: make(map[string]*UsageScanStatus)}
+}
+
+var globalUsageScans = newUsageScans()
+
+// start - starts scan id of bucket in the background, run is passed a
+// function to record the size of every object it counts. Starting a
+// scan id twice is a no-op.
+func (u *usageScans) start(id, bucket string, run func(record func(size int64)) error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if _, ok := u.scans[id]; ok {
+		return
+	}
+	u.pruneLocked()
+	scan := &UsageScanStatus{ID: id, Bucket: bucket, Started: time.Now().UTC()}
+	u.scans[id] = scan
+
+	record := func(size int64) {
+		u.mutex.Lock()
+		defer u.mutex.Unlock()
+		scan.Objects++
+		scan.Bytes += size
+	}
+
+	go func() {
+		globalScannerActivity.begin()
+		err := run(record)
+		globalScannerActivity.end()
+		u.mutex.Lock()
+		defer u.mutex.Unlock()
+		scan.Done = true
+		if err != nil {
+			scan.Error = err.Error()
+		}
+	}()
+}
+
+// pruneLocked - drops the oldest finished scans beyond maxUsageScans,
+// must be called with the mutex held.
+func (u *usageScans) pruneLocked() {
+	for len(u.scans) >= maxUsageScans {
+		var oldest *UsageScanStatus
+		for _, scan := range u.scans {
+			if scan.Done && (oldest == nil || scan.Started.Before(oldest.Started)) {
+				oldest = scan
+			}
+		}
+		if oldest == nil {
+			// Every scan is still running.
+			return
+		}
+		delete(u.scans, oldest.ID)
+	}
+}
+
+// list - returns a copy of all scans.
+func (u *usageScans) list() []UsageScanStatus {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	scans := make([]UsageScanStatus, 0, len(u.scans))
+	for _, scan := range u.scans {
+		scans = append(scans, *scan)
+	}
+	return scans
+}
+
+// scanBucketUsage - counts the objects of bucket which belong to part
+// out of parts, pausing for interval between listing pages unless a
+// scanner-fast maintenance window is in progress.
+func scanBucketUsage(objLayer ObjectLayer, bucket string, part, parts int, interval time.Duration, record func(size int64)) error {
+	marker := ""
+	for {
+		result, err := objLayer.ListObjects(bucket, "", marker, "", usageScanMaxKeys)
+		if err != nil {
+			return errorCause(err)
+		}
+		for _, obj := range result.Objects {
+			if isObjectInPart(obj.Name, part, parts) {
+				record(obj.Size)
+			}
+		}
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			return nil
+		}
+		marker = result.Objects[len(result.Objects)-1].Name
+		if !globalMaintenance.active(maintenanceScannerFast, time.Now()) {
+			time.Sleep(interval)
+		}
+	}
+}
+
+// startUsageScan - starts counting the part of bucket assigned to this
+// server as scan id.
+func startUsageScan(u *usageScans, id, bucket string, part, parts int) error {
+	if parts <= 0 || part < 0 || part >= parts {
+		return errInvalidArgument
+	}
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		return errServerNotInitialized
+	}
+	u.start(id, bucket, func(record func(size int64)) error {
+		return scanBucketUsage(objLayer, bucket, part, parts, usageScanPageInterval, record)
+	})
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests scanBucketUsage pages through the bucket and counts every
+// object exactly once over all parts.
+func TestScanBucketUsage(t *testing.T) {
+	ExecObjectLayerTest(t, testScanBucketUsage)
+}
+
+func testScanBucketUsage(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucket := "usage-bucket"
+	if err := obj.MakeBucket(bucket); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	objects := 12
+	for i := 0; i < objects; i++ {
+		data := strings.Repeat("a", i)
+		if _, err := obj.PutObject(bucket, fmt.Sprintf("object%d", i), int64(len(data)), strings.NewReader(data), nil, ""); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	// 0 + 1 + ... + 11 bytes.
+	wantBytes := int64(objects * (objects - 1) / 2)
+	for _, parts := range []int{1, 3} {
+		var count, size int64
+		for part := 0; part < parts; part++ {
+			err := scanBucketUsage(obj, bucket, part, parts, time.Millisecond, func(n int64) {
+				count++
+				size += n
+			})
+			if err != nil {
+				t.Fatalf("%s: %v", instanceType, err)
+			}
+		}
+		if count != int64(objects) || size != wantBytes {
+			t.Errorf("%s: Expected %d objects and %d bytes over %d parts, got %d and %d", instanceType, objects, wantBytes, parts, count, size)
+		}
+	}
+
+	if err := scanBucketUsage(obj, "missing-bucket", 0, 1, 0, func(int64) {}); err == nil {
+		t.Errorf("%s: Expected an error scanning a missing bucket", instanceType)
+	}
+}
+
+// Tests starting a usage scan validates the part assigned to the server.
+func TestStartUsageScanInvalidPart(t *testing.T) {
+	scans := newUsageScans()
+	for _, c := range []struct{ part, parts int }{{0, 0}, {-1, 2}, {2, 2}} {
+		if err := startUsageScan(scans, "id", "bucket", c.part, c.parts); err != errInvalidArgument {
+			t.Errorf("Part %d of %d: expected %v, got %v", c.part, c.parts, errInvalidArgument, err)
+		}
+	}
+	if len(scans.list()) != 0 {
+		t.Errorf("Expected no scans, got %v", scans.list())
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "time"
+
+// ServerVersionClock - version and clock of a server. BootTime is
+// zero while the server is starting up, CurrentTime is zero if the
+// server could not read its clock.
+type ServerVersionClock struct {
+	Version     string    `json:"version"`
+	CommitID    string    `json:"commitID"`
+	BootTime    time.Time `json:"bootTime"`
+	CurrentTime time.Time `json:"currentTime"`
+}
+
+// PeerVersionClock - version and clock of a server compared against
+// the rest of the cluster.
+type PeerVersionClock struct {
+	Addr string `json:"addr"`
+	ServerVersionClock
+
+	// Set when version or commit differ from the reference server.
+	VersionMismatch bool `json:"versionMismatch"`
+	// Offset of the server clock, corrected for network latency.
+	ClockSkew time.Duration `json:"clockSkew"`
+	// Set when ClockSkew exceeds what request signatures tolerate.
+	ClockSkewed bool `json:"clockSkewed"`
+	// Set when the server could not read its clock.
+	ClockUnavailable bool `json:"clockUnavailable"`
+	// Set when the server could not be reached at all.
+	Error string `json:"error,omitempty"`
+}
+
+// getLocalVersionClock - returns version and clock of this server.
+func getLocalVersionClock() ServerVersionClock {
+	return ServerVersionClock{
+		Version:     Version,
+		CommitID:    CommitID,
+		BootTime:    globalBootTime,
+		CurrentTime: time.Now().UTC(),
+	}
+}
+
+// newPeerVersionClock - returns the matrix entry of a server, whose
+// clock was read between sent and received. Versions are compared
+// against ref.
+func newPeerVersionClock(addr string, info ServerVersionClock, ref ServerVersionClock, sent, received time.Time) PeerVersionClock {
+	entry := PeerVersionClock{
+		Addr:               addr,
+		ServerVersionClock: info,
+		VersionMismatch:    info.Version != ref.Version || info.CommitID != ref.CommitID,
+	}
+	if info.CurrentTime.IsZero() {
+		entry.ClockUnavailable = true
+		return entry
+	}
+
+	// Assume the clock was read halfway through the round trip.
+	entry.ClockSkew = info.CurrentTime.Sub(sent.Add(received.Sub(sent) / 2))
+	entry.ClockSkewed = entry.ClockSkew > globalMaxSkewTime || -entry.ClockSkew > globalMaxSkewTime
+	return entry
+}
@@ -0,0 +1,152 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "sort"
+
+// Status of a version skew report.
+const (
+	versionSkewNoUpgrade = "no upgrade in progress"
+	versionSkewUpgrading = "upgrade in progress"
+)
+
+// serverTopology - version of a server and the number of online drives
+// it hosts in every erasure set.
+type serverTopology struct {
+	Addr    string
+	Version string
+	Drives  map[int]int
+}
+
+// VersionSkewReport - versions run by the servers of the cluster and,
+// while they differ, the order in which to upgrade the remaining
+// servers without losing write quorum.
+type VersionSkewReport struct {
+	Status string `json:"status"`
+	// Servers running every version.
+	Versions map[string][]string `json:"versions"`
+	// Latest version, the one every server should be upgraded to.
+	TargetVersion string `json:"targetVersion,omitempty"`
+	// Batches of servers to upgrade one after the other, servers of
+	// a batch host drives of different erasure sets and may be
+	// upgraded together.
+	UpgradeOrder [][]string `json:"upgradeOrder,omitempty"`
+	// Servers hosting more drives of a set than the set can lose
+	// without losing write quorum, they can not be upgraded while the
+	// cluster is serving writes.
+	Blocked []string `json:"blocked,omitempty"`
+	// Servers which could not be reached, their version is unknown.
+	Unreachable []string `json:"unreachable,omitempty"`
+}
+
+// newVersionSkewReport - returns the version skew of servers, whose
+// sets need writeQuorum online drives. Release versions are UTC
+// timestamps, the latest one sorts last.
+func newVersionSkewReport(servers []serverTopology, unreachable []string, writeQuorum int) VersionSkewReport {
+	report := VersionSkewReport{
+		Status:      versionSkewNoUpgrade,
+		Versions:    make(map[string][]string),
+		Unreachable: unreachable,
+	}
+	for _, server := range servers {
+		report.Versions[server.Version] = append(report.Versions[server.Version], server.Addr)
+		if server.Version > report.TargetVersion {
+			report.TargetVersion = server.Version
+		}
+	}
+	if len(report.Versions) <= 1 {
+		report.TargetVersion = ""
+		return report
+	}
+	report.Status = versionSkewUpgrading
+
+	// Drives every set can lose while keeping write quorum.
+	spare := make(map[int]int)
+	for _, server := range servers {
+		for set, drives := range server.Drives {
+			spare[set] += drives
+		}
+	}
+	for set := range spare {
+		spare[set] -= writeQuorum
+	}
+
+	var pending []serverTopology
+	for _, server := range servers {
+		if server.Version == report.TargetVersion {
+			continue
+		}
+		if !canTakeOffline(server, spare) {
+			report.Blocked = append(report.Blocked, server.Addr)
+			continue
+		}
+		pending = append(pending, server)
+	}
+	sort.Sort(byServerAddr(pending))
+	sort.Strings(report.Blocked)
+
+	// Upgrade at most one server of every set at a time, a set only
+	// ever loses the drives of a single server.
+	for len(pending) > 0 {
+		var batch []string
+		var rest []serverTopology
+		busy := make(map[int]bool)
+		for _, server := range pending {
+			if hostsDrivesOf(server, busy) {
+				rest = append(rest, server)
+				continue
+			}
+			batch = append(batch, server.Addr)
+			for set, drives := range server.Drives {
+				if drives > 0 {
+					busy[set] = true
+				}
+			}
+		}
+		report.UpgradeOrder = append(report.UpgradeOrder, batch)
+		pending = rest
+	}
+	return report
+}
+
+// canTakeOffline - returns true if every set keeps write quorum while
+// the drives of server are offline.
+func canTakeOffline(server serverTopology, spare map[int]int) bool {
+	for set, drives := range server.Drives {
+		if drives > spare[set] {
+			return false
+		}
+	}
+	return true
+}
+
+// hostsDrivesOf - returns true if server hosts drives of any of sets.
+func hostsDrivesOf(server serverTopology, sets map[int]bool) bool {
+	for set, drives := range server.Drives {
+		if drives > 0 && sets[set] {
+			return true
+		}
+	}
+	return false
+}
+
+// byServerAddr - sorts servers by address.
+type byServerAddr []serverTopology
+
+func (s byServerAddr) Len() int           { return len(s) }
+func (s byServerAddr) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byServerAddr) Less(i, j int) bool { return s[i].Addr < s[j].Addr }
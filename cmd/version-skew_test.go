@@ -0,0 +1,127 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewVersionSkewReport - tests the upgrade order of a mixed
+// version cluster of two erasure sets of 8 drives.
+func TestNewVersionSkewReport(t *testing.T) {
+	const (
+		oldVersion = "2017-06-13T19-01-01Z"
+		newVersion = "2017-07-24T18-27-35Z"
+		// 4 data and 4 parity drives per set.
+		writeQuorum = 5
+	)
+	// Servers 1 to 4 host drives of set 0, servers 5 to 8 drives of
+	// set 1 and server9 a drive of both sets.
+	newServers := func() []serverTopology {
+		servers := []serverTopology{
+			{Addr: "server1:9000", Version: newVersion, Drives: map[int]int{0: 2}},
+			{Addr: "server2:9000", Version: oldVersion, Drives: map[int]int{0: 2}},
+			{Addr: "server3:9000", Version: oldVersion, Drives: map[int]int{0: 2}},
+			{Addr: "server4:9000", Version: oldVersion, Drives: map[int]int{0: 1}},
+			{Addr: "server5:9000", Version: newVersion, Drives: map[int]int{1: 2}},
+			{Addr: "server6:9000", Version: oldVersion, Drives: map[int]int{1: 2}},
+			{Addr: "server7:9000", Version: oldVersion, Drives: map[int]int{1: 2}},
+			{Addr: "server8:9000", Version: oldVersion, Drives: map[int]int{1: 1}},
+			{Addr: "server9:9000", Version: oldVersion, Drives: map[int]int{0: 1, 1: 1}},
+		}
+		return servers
+	}
+
+	servers := newServers()
+	report := newVersionSkewReport(servers, []string{"server10:9000"}, writeQuorum)
+	if report.Status != versionSkewUpgrading || report.TargetVersion != newVersion {
+		t.Fatalf("Expected an upgrade to %s in progress, got %#v", newVersion, report)
+	}
+	versions := map[string][]string{
+		newVersion: {"server1:9000", "server5:9000"},
+		oldVersion: {"server2:9000", "server3:9000", "server4:9000", "server6:9000", "server7:9000", "server8:9000", "server9:9000"},
+	}
+	if !reflect.DeepEqual(report.Versions, versions) {
+		t.Fatalf("Expected versions %v, got %v", versions, report.Versions)
+	}
+	order := [][]string{
+		{"server2:9000", "server6:9000"},
+		{"server3:9000", "server7:9000"},
+		{"server4:9000", "server8:9000"},
+		{"server9:9000"},
+	}
+	if !reflect.DeepEqual(report.UpgradeOrder, order) {
+		t.Fatalf("Expected upgrade order %v, got %v", order, report.UpgradeOrder)
+	}
+	if len(report.Blocked) != 0 || !reflect.DeepEqual(report.Unreachable, []string{"server10:9000"}) {
+		t.Fatalf("Expected no blocked and one unreachable server, got %#v", report)
+	}
+
+	// Every batch takes at most one server of a set offline, which
+	// never loses the write quorum of the set.
+	drives := make(map[string]map[int]int)
+	setDrives := make(map[int]int)
+	for _, server := range servers {
+		drives[server.Addr] = server.Drives
+		for set, n := range server.Drives {
+			setDrives[set] += n
+		}
+	}
+	for i, batch := range report.UpgradeOrder {
+		offline := make(map[int]int)
+		servers := make(map[int]int)
+		for _, addr := range batch {
+			for set, n := range drives[addr] {
+				offline[set] += n
+				servers[set]++
+			}
+		}
+		for set := range offline {
+			if servers[set] > 1 || setDrives[set]-offline[set] < writeQuorum {
+				t.Fatalf("Batch %d loses write quorum of set %d", i+1, set)
+			}
+		}
+	}
+
+	// With 2 drives of set 0 already offline, the set can lose a
+	// single drive only.
+	servers = newServers()
+	servers[0].Drives = map[int]int{}
+	report = newVersionSkewReport(servers, nil, writeQuorum)
+	blocked := []string{"server2:9000", "server3:9000"}
+	if !reflect.DeepEqual(report.Blocked, blocked) {
+		t.Fatalf("Expected blocked servers %v, got %v", blocked, report.Blocked)
+	}
+	order = [][]string{{"server4:9000", "server6:9000"}, {"server7:9000"}, {"server8:9000"}, {"server9:9000"}}
+	if !reflect.DeepEqual(report.UpgradeOrder, order) {
+		t.Fatalf("Expected upgrade order %v, got %v", order, report.UpgradeOrder)
+	}
+
+	// No upgrade is in progress once all servers run the same version.
+	servers = newServers()
+	for i := range servers {
+		servers[i].Version = newVersion
+	}
+	report = newVersionSkewReport(servers, nil, writeQuorum)
+	if report.Status != versionSkewNoUpgrade || report.TargetVersion != "" || len(report.UpgradeOrder) != 0 {
+		t.Fatalf("Expected no upgrade in progress, got %#v", report)
+	}
+	if len(report.Versions[newVersion]) != len(servers) {
+		t.Fatalf("Expected all servers on %s, got %v", newVersion, report.Versions)
+	}
+}
@@ -269,6 +269,14 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 	objectLock.Lock()
 	defer objectLock.Unlock()
 
+	if err := checkObjectImmutability(objectAPI, args.BucketName, args.ObjectName); err != nil {
+		return toJSONError(err, args.BucketName, args.ObjectName)
+	}
+
+	if err := checkDeleteProtection(objectAPI, args.BucketName, args.ObjectName); err != nil {
+		return toJSONError(err, args.BucketName, args.ObjectName)
+	}
+
 	if err := objectAPI.DeleteObject(args.BucketName, args.ObjectName); err != nil {
 		if isErrObjectNotFound(err) {
 			// Ignore object not found error.
@@ -277,6 +285,7 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 		}
 		return toJSONError(err, args.BucketName, args.ObjectName)
 	}
+	globalBucketObjectLimits.add(args.BucketName, -1)
 
 	// Notify object deleted event.
 	eventNotify(eventData{
@@ -286,7 +295,7 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 			Name: args.ObjectName,
 		},
 		ReqParams: map[string]string{
-			"sourceIPAddress": r.RemoteAddr,
+			"sourceIPAddress": getSourceIP(r),
 		},
 	})
 
@@ -312,9 +321,10 @@ func (web *webAPIHandlers) Login(r *http.Request, args *LoginArgs, reply *LoginR
 	if err != nil {
 		// Make sure to log errors related to browser login,
 		// for security and auditing reasons.
-		errorIf(err, "Unable to login request from %s", r.RemoteAddr)
+		errorIf(err, "Unable to login request from %s", getSourceIP(r))
 		return toJSONError(err)
 	}
+	globalCredentialUsage.record(args.Username, time.Now().UTC())
 
 	reply.Token = token
 	reply.UIVersion = browser.UIVersion
@@ -374,7 +384,7 @@ func (web *webAPIHandlers) SetAuth(r *http.Request, args *SetAuthArgs, reply *Se
 	}
 
 	// Notify all other Minio peers to update credentials
-	errsMap := updateCredsOnPeers(creds)
+	errsMap := updateCredsOnPeers(creds, nil)
 
 	// Update local credentials
 	serverConfig.SetCredential(creds)
@@ -383,6 +393,7 @@ func (web *webAPIHandlers) SetAuth(r *http.Request, args *SetAuthArgs, reply *Se
 	if err := serverConfig.Save(); err != nil {
 		errsMap[globalMinioAddr] = err
 	}
+	globalClusterEvents.emit(clusterEventConfigChange, "credentials")
 
 	// Log all the peer related error messages, and populate the
 	// PeerErrMsgs map.
@@ -476,12 +487,27 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 	objectLock.Lock()
 	defer objectLock.Unlock()
 
+	if err := checkObjectImmutability(objectAPI, bucket, object); err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
+	isNewObject, err := checkBucketObjectLimit(objectAPI, bucket, object)
+	if err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
 	sha256sum := ""
 	objInfo, err := objectAPI.PutObject(bucket, object, size, r.Body, metadata, sha256sum)
 	if err != nil {
 		writeWebErrorResponse(w, err)
 		return
 	}
+	if isNewObject {
+		globalBucketObjectLimits.add(bucket, 1)
+	}
+	globalWriteAmp.addClientBytes(objInfo.Size)
 
 	// Notify object created event.
 	eventNotify(eventData{
@@ -489,7 +515,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		Bucket:  bucket,
 		ObjInfo: objInfo,
 		ReqParams: map[string]string{
-			"sourceIPAddress": r.RemoteAddr,
+			"sourceIPAddress": getSourceIP(r),
 		},
 	})
 }
@@ -820,8 +846,10 @@ func presignedGet(host, bucket, object string, expiry int64) string {
 	dateStr := date.Format(iso8601Format)
 	credential := fmt.Sprintf("%s/%s", accessKey, getScope(date, region))
 
-	var expiryStr = "604800" // Default set to be expire in 7days.
-	if expiry < 604800 && expiry > 0 {
+	// Default to, and cap at, the maximum presigned URL expiry.
+	maxExpiry := int64(getMaxPresignExpiry() / time.Second)
+	var expiryStr = strconv.FormatInt(maxExpiry, 10)
+	if expiry < maxExpiry && expiry > 0 {
 		expiryStr = strconv.FormatInt(expiry, 10)
 	}
 	query := strings.Join([]string{
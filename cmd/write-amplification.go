@@ -0,0 +1,131 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// Write amplification is computed over the bytes written during
+	// this window.
+	writeAmpWindow = 15 * time.Minute
+
+	// Bytes are counted in slots of this duration, the oldest slot
+	// is dropped as a whole when the window moves on.
+	writeAmpSlotDuration = time.Minute
+)
+
+// WriteAmplification - bytes written to disk per byte of object data
+// received from clients. When no object data was received in the
+// window the ratio is undefined and NoData is set instead.
+type WriteAmplification struct {
+	ClientBytes int64   `json:"clientBytes"`
+	DiskBytes   int64   `json:"diskBytes"`
+	Ratio       float64 `json:"ratio"`
+	NoData      bool    `json:"noData"`
+}
+
+// newWriteAmplification - computes the write amplification of disk
+// bytes written for client bytes received.
+func newWriteAmplification(clientBytes, diskBytes int64) WriteAmplification {
+	w := WriteAmplification{ClientBytes: clientBytes, DiskBytes: diskBytes}
+	if clientBytes <= 0 {
+		w.NoData = true
+		return w
+	}
+	w.Ratio = float64(diskBytes) / float64(clientBytes)
+	return w
+}
+
+// NodeWriteAmplification - write amplification of a server. Disks of
+// a server are written to on behalf of clients of other servers too,
+// so only the cluster figure is exact in a distributed setup.
+type NodeWriteAmplification struct {
+	Addr string `json:"addr"`
+	WriteAmplification
+}
+
+// ClusterWriteAmplification - write amplification of all servers.
+type ClusterWriteAmplification struct {
+	WriteAmplification
+	Nodes []NodeWriteAmplification `json:"nodes"`
+}
+
+// writeAmpSlot - bytes counted during the slot starting at start.
+type writeAmpSlot struct {
+	start       time.Time
+	clientBytes int64
+	diskBytes   int64
+}
+
+// writeAmpCounters - bytes received from clients and written to the
+// local disks of this server over the last writeAmpWindow.
+type writeAmpCounters struct {
+	mutex sync.Mutex
+	slots []writeAmpSlot
+}
+
+func newWriteAmpCounters() *writeAmpCounters {
+	return &writeAmpCounters{slots: make([]writeAmpSlot, writeAmpWindow/writeAmpSlotDuration)}
+}
+
+var globalWriteAmp = newWriteAmpCounters()
+
+// add - counts bytes received from clients and written to disk at now.
+func (c *writeAmpCounters) add(now time.Time, clientBytes, diskBytes int64) {
+	start := now.Truncate(writeAmpSlotDuration)
+	idx := int(start.UnixNano()/int64(writeAmpSlotDuration)) % len(c.slots)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	slot := &c.slots[idx]
+	if !slot.start.Equal(start) {
+		// Reused slot, drop bytes counted a window ago.
+		*slot = writeAmpSlot{start: start}
+	}
+	slot.clientBytes += clientBytes
+	slot.diskBytes += diskBytes
+}
+
+// addClientBytes - counts object data received from a client.
+func (c *writeAmpCounters) addClientBytes(n int64) {
+	c.add(time.Now().UTC(), n, 0)
+}
+
+// addDiskBytes - counts bytes written to a local disk.
+func (c *writeAmpCounters) addDiskBytes(n int64) {
+	c.add(time.Now().UTC(), 0, n)
+}
+
+// get - returns the write amplification over the window ending at now.
+func (c *writeAmpCounters) get(now time.Time) WriteAmplification {
+	oldest := now.Truncate(writeAmpSlotDuration).Add(-writeAmpWindow + writeAmpSlotDuration)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var clientBytes, diskBytes int64
+	for _, slot := range c.slots {
+		if slot.start.Before(oldest) || slot.start.After(now) {
+			continue
+		}
+		clientBytes += slot.clientBytes
+		diskBytes += slot.diskBytes
+	}
+	return newWriteAmplification(clientBytes, diskBytes)
+}
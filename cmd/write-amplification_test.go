@@ -0,0 +1,73 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Tests computing write amplification out of byte counters.
+func TestNewWriteAmplification(t *testing.T) {
+	testCases := []struct {
+		clientBytes, diskBytes int64
+		expected               WriteAmplification
+	}{
+		// Erasure coded with as many parity as data blocks.
+		{1024, 2048, WriteAmplification{ClientBytes: 1024, DiskBytes: 2048, Ratio: 2}},
+		{4096, 5120, WriteAmplification{ClientBytes: 4096, DiskBytes: 5120, Ratio: 1.25}},
+		// Nothing received, nothing to divide by.
+		{0, 0, WriteAmplification{NoData: true}},
+		// Healing alone writes to disk.
+		{0, 4096, WriteAmplification{DiskBytes: 4096, NoData: true}},
+	}
+	for i, testCase := range testCases {
+		w := newWriteAmplification(testCase.clientBytes, testCase.diskBytes)
+		if !reflect.DeepEqual(w, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, w)
+		}
+	}
+}
+
+// Tests bytes are only accounted for during the window.
+func TestWriteAmpCountersWindow(t *testing.T) {
+	c := newWriteAmpCounters()
+	now := time.Date(2017, 3, 1, 12, 0, 30, 0, time.UTC)
+
+	if w := c.get(now); !w.NoData {
+		t.Fatalf("Expected no data, got %v", w)
+	}
+
+	c.add(now.Add(-writeAmpWindow), 4096, 4096)
+	c.add(now.Add(-time.Minute), 1024, 1536)
+	c.add(now, 1024, 2560)
+	c.add(now, 0, 2048)
+	expected := WriteAmplification{ClientBytes: 2048, DiskBytes: 6144, Ratio: 3}
+	if w := c.get(now); !reflect.DeepEqual(w, expected) {
+		t.Fatalf("Expected %v, got %v", expected, w)
+	}
+
+	// Once the window moved past all client writes only disk writes
+	// remain, which is no data.
+	later := now.Add(writeAmpWindow)
+	c.add(later, 0, 1024)
+	expected = WriteAmplification{DiskBytes: 1024, NoData: true}
+	if w := c.get(later); !reflect.DeepEqual(w, expected) {
+		t.Fatalf("Expected %v, got %v", expected, w)
+	}
+}
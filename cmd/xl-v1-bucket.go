@@ -62,7 +62,7 @@ func (xl xlObjects) MakeBucket(bucket string) error {
 	// Wait for all make vol to finish.
 	wg.Wait()
 
-	err := reduceWriteQuorumErrs(dErrs, bucketOpIgnoredErrs, xl.writeQuorum)
+	err := reduceWriteQuorumErrs(dErrs, bucketOpIgnoredErrs, xl.getWriteQuorum())
 	if errorCause(err) == errXLWriteQuorum {
 		// Purge successfully created buckets if we don't have writeQuorum.
 		undoMakeBucket(xl.storageDisks, bucket)
@@ -241,7 +241,7 @@ func (xl xlObjects) DeleteBucket(bucket string) error {
 	// Wait for all the delete vols to finish.
 	wg.Wait()
 
-	err := reduceWriteQuorumErrs(dErrs, bucketOpIgnoredErrs, xl.writeQuorum)
+	err := reduceWriteQuorumErrs(dErrs, bucketOpIgnoredErrs, xl.getWriteQuorum())
 	if errorCause(err) == errXLWriteQuorum {
 		xl.undoDeleteBucket(bucket)
 	}
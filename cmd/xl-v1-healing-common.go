@@ -145,7 +145,7 @@ func xlHealStat(xl xlObjects, partsMetadata []xlMetaV1, errs []error) HealObject
 	// Less than quorum erasure coded blocks of the object have the same create time.
 	// This object can't be healed with the information we have.
 	modTime, count := commonTime(listObjectModtimes(partsMetadata, errs))
-	if count < xl.readQuorum {
+	if count < xl.getReadQuorum() {
 		return HealObjectInfo{
 			Status:              quorumUnavailable,
 			MissingDataCount:    0,
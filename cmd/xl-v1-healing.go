@@ -64,12 +64,12 @@ func (xl xlObjects) HealBucket(bucket string) error {
 	}
 
 	// Heal bucket.
-	if err := healBucket(xl.storageDisks, bucket, xl.writeQuorum); err != nil {
+	if err := healBucket(xl.storageDisks, bucket, xl.getWriteQuorum()); err != nil {
 		return err
 	}
 
 	// Proceed to heal bucket metadata.
-	return healBucketMetadata(xl.storageDisks, bucket, xl.readQuorum)
+	return healBucketMetadata(xl.storageDisks, bucket, xl.getReadQuorum())
 }
 
 // Heal bucket - create buckets on disks where it does not exist.
@@ -312,6 +312,9 @@ func quickHeal(storageDisks []StorageAPI, writeQuorum int, readQuorum int) error
 func healObject(storageDisks []StorageAPI, bucket string, object string, quorum int) error {
 	partsMetadata, errs := readAllXLMetadata(storageDisks, bucket, object)
 	if reducedErr := reduceReadQuorumErrs(errs, nil, quorum); reducedErr != nil {
+		if isObjectOrphaned(errs, quorum) {
+			return healOrphanedObject(storageDisks, bucket, object, partsMetadata, errs)
+		}
 		return toObjectErr(reducedErr, bucket, object)
 	}
 
@@ -442,20 +445,76 @@ func healObject(storageDisks []StorageAPI, bucket string, object string, quorum
 	return nil
 }
 
-// HealObject heals a given object for all its missing entries.
-// FIXME: If an object object was deleted and one disk was down,
-// and later the disk comes back up again, heal on the object
-// should delete it.
+// isObjectOrphaned - returns true if the object is present on some
+// disks but is known to be missing on so many others that it can
+// never be read, such as an object that was deleted while a disk
+// was down. Disks that could not be reached may still hold the
+// object, so they are counted as holding it.
+func isObjectOrphaned(errs []error, quorum int) bool {
+	present := 0
+	missing := 0
+	for _, err := range errs {
+		if err == nil {
+			present++
+		} else if isErr(err, errFileNotFound) {
+			missing++
+		}
+	}
+	return present > 0 && len(errs)-missing < quorum
+}
+
+// healOrphanedObject - deals with an orphaned object according to
+// the heal delete policy. In report-only mode the object is left in
+// place and reported, in delete mode it is removed from all disks
+// still holding it.
+func healOrphanedObject(storageDisks []StorageAPI, bucket, object string, partsMetadata []xlMetaV1, errs []error) error {
+	if globalHealDeletePolicy.get() != healDeleteRemove {
+		errorIf(errOrphanedObject, "Leaving %s/%s in place as per heal delete policy.", bucket, object)
+		return toObjectErr(traceError(errFileNotFound), bucket, object)
+	}
+
+	for index, disk := range storageDisks {
+		if disk == nil || errs[index] != nil {
+			continue
+		}
+		// Delete all the parts. Ignore if parts are not found.
+		for _, part := range partsMetadata[index].Parts {
+			err := disk.DeleteFile(bucket, pathJoin(object, part.Name))
+			if err != nil && !isErr(err, errFileNotFound) {
+				return traceError(err)
+			}
+		}
+		// Delete xl.json file. Ignore if xl.json not found.
+		err := disk.DeleteFile(bucket, pathJoin(object, xlMetaJSONFile))
+		if err != nil && !isErr(err, errFileNotFound) {
+			return traceError(err)
+		}
+	}
+	return nil
+}
+
+// HealObject heals a given object for all its missing entries. An
+// object which was deleted while a disk was down is left behind on
+// that disk, it is removed or only reported depending on the heal
+// delete policy.
 func (xl xlObjects) HealObject(bucket, object string) error {
 	if err := checkGetObjArgs(bucket, object); err != nil {
 		return err
 	}
 
+	// Wait for a free heal slot before locking the object.
+	globalHealLimiter.acquire()
+	defer globalHealLimiter.release()
+
 	// Lock the object before healing.
 	objectLock := globalNSMutex.NewNSLock(bucket, object)
 	objectLock.RLock()
 	defer objectLock.RUnlock()
 
 	// Heal the object.
-	return healObject(xl.storageDisks, bucket, object, xl.readQuorum)
+	if err := healObject(xl.storageDisks, bucket, object, xl.getReadQuorum()); err != nil {
+		return err
+	}
+	objectHealed(bucket, object)
+	return nil
 }
@@ -17,7 +17,10 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"path"
 	"testing"
 )
 
@@ -486,3 +489,97 @@ func TestListBucketsHeal(t *testing.T) {
 		t.Fatalf("Name of missing bucket is incorrect, expected: %s, found: %s", corruptedBucketName, buckets[0].Name)
 	}
 }
+
+// Tests healing of orphaned objects under both heal delete modes.
+func TestHealOrphanedObject(t *testing.T) {
+	root, err := newTestConfig("us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(root)
+	defer resetGlobalHealDeletePolicy()
+
+	obj, fsDirs, err := prepareXL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	xl := obj.(*xlObjects)
+
+	bucket := "bucket"
+	if err = obj.MakeBucket(bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	// putObject - creates object and removes it from the first
+	// removeCount disks.
+	putObject := func(object string, removeCount int) {
+		data := []byte("hello, world")
+		_, err = obj.PutObject(bucket, object, int64(len(data)), bytes.NewReader(data), nil, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < removeCount; i++ {
+			if err = os.RemoveAll(path.Join(fsDirs[i], bucket, object)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// isPresent - returns true if object is present on the last disk.
+	isPresent := func(object string) bool {
+		_, statErr := os.Stat(path.Join(fsDirs[len(fsDirs)-1], bucket, object))
+		return statErr == nil
+	}
+
+	// Object deleted while 3 of the 16 disks were down.
+	putObject("orphan", len(fsDirs)-3)
+
+	// Report-only is the default, orphan must be left in place.
+	err = xl.HealObject(bucket, "orphan")
+	if !isErrObjectNotFound(err) {
+		t.Fatalf("Expected ObjectNotFound, got %v", err)
+	}
+	if !isPresent("orphan") {
+		t.Fatal("Expected orphaned object to be left in place in report-only mode")
+	}
+
+	// Object missing on 6 disks with another 6 disks offline, it
+	// may still be present on the offline disks and is not orphaned.
+	putObject("live", 6)
+	offlineDisks := make([]StorageAPI, 6)
+	copy(offlineDisks, xl.storageDisks[6:12])
+	for i := 6; i < 12; i++ {
+		xl.storageDisks[i] = nil
+	}
+
+	if err = globalHealDeletePolicy.set(healDeleteRemove); err != nil {
+		t.Fatal(err)
+	}
+
+	err = xl.HealObject(bucket, "live")
+	if _, ok := errorCause(err).(InsufficientReadQuorum); !ok {
+		t.Fatalf("Expected InsufficientReadQuorum, got %v", err)
+	}
+	if !isPresent("live") {
+		t.Fatal("Expected object which may be live to be left in place in delete mode")
+	}
+
+	// Bring the disks back, object is healed rather than deleted.
+	copy(xl.storageDisks[6:12], offlineDisks)
+	if err = xl.HealObject(bucket, "live"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetObjectInfo(bucket, "live"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Orphan is removed in delete mode.
+	if err = xl.HealObject(bucket, "orphan"); err != nil {
+		t.Fatal(err)
+	}
+	for i, fsDir := range fsDirs {
+		if _, err = os.Stat(path.Join(fsDir, bucket, "orphan")); !os.IsNotExist(err) {
+			t.Fatalf("Expected orphaned object to be removed from disk %d, got %v", i, err)
+		}
+	}
+}
@@ -28,10 +28,8 @@ import (
 
 // Returns number of errors that occurred the most (incl. nil) and the
 // corresponding error value. N B when there is more than one error value that
-// occurs maximum number of times, the error value returned depends on how
-// golang's map orders keys. This doesn't affect correctness as long as quorum
-// value is greater than or equal to simple majority, since none of the equally
-// maximal values would occur quorum or more number of times.
+// occurs maximum number of times, nil is returned if it is one of them,
+// otherwise the one occurring first in errs.
 func reduceErrs(errs []error, ignoredErrs []error) (maxCount int, maxErr error) {
 	errorCounts := make(map[error]int)
 	errs = errorsCause(errs)
@@ -42,10 +40,20 @@ func reduceErrs(errs []error, ignoredErrs []error) (maxCount int, maxErr error)
 		errorCounts[err]++
 	}
 	max := 0
-	for err, count := range errorCounts {
-		if max < count {
+	for _, err := range errs {
+		if isErrIgnored(err, ignoredErrs...) {
+			continue
+		}
+		count := errorCounts[err]
+		switch {
+		case max < count:
 			max = count
 			maxErr = err
+
+		// Prefer `nil` over other error values with the same
+		// number of occurrences.
+		case max == count && err == nil:
+			maxErr = err
 		}
 	}
 	return max, maxErr
@@ -97,6 +97,33 @@ func TestReduceErrs(t *testing.T) {
 	}
 }
 
+// TestReduceErrsTie - tests reduceErrs breaks ties the same way on
+// every call, nil first and otherwise the error occurring first.
+func TestReduceErrsTie(t *testing.T) {
+	testCases := []struct {
+		errs  []error
+		count int
+		err   error
+	}{
+		{[]error{errDiskNotFound, nil}, 1, nil},
+		{[]error{nil, errDiskNotFound}, 1, nil},
+		{[]error{errDiskFull, errDiskNotFound}, 1, errDiskFull},
+		{[]error{errDiskNotFound, errDiskFull}, 1, errDiskNotFound},
+		{[]error{errDiskNotFound, errDiskFull, errDiskFull, errDiskNotFound}, 2, errDiskNotFound},
+		{[]error{errDiskNotFound, errDiskNotFound, nil, nil}, 2, nil},
+	}
+	for i, testCase := range testCases {
+		// Map iteration order varies between runs, repeat to
+		// catch a tie broken by it.
+		for j := 0; j < 100; j++ {
+			count, err := reduceErrs(testCase.errs, nil)
+			if count != testCase.count || err != testCase.err {
+				t.Fatalf("Test %d: expected %d %v, got %d %v", i+1, testCase.count, testCase.err, count, err)
+			}
+		}
+	}
+}
+
 // TestHashOrder - test order of ints in array
 func TestHashOrder(t *testing.T) {
 	testCases := []struct {
@@ -86,6 +86,26 @@ func newXLObjectLayer(storageDisks []StorageAPI) (ObjectLayer, error) {
 	err = initBucketPolicies(objAPI)
 	fatalIf(err, "Unable to load all bucket policies.")
 
+	// Initialize and load bucket tags.
+	err = initBucketTags(objAPI)
+	fatalIf(err, "Unable to load all bucket tags.")
+
+	// Initialize and load bucket immutability windows.
+	err = initImmutabilityWindows(objAPI)
+	fatalIf(err, "Unable to load all bucket immutability windows.")
+
+	// Initialize and load bucket object limits.
+	err = initBucketObjectLimits(objAPI)
+	fatalIf(err, "Unable to load all bucket object limits.")
+
+	// Initialize and load bucket logging configs.
+	err = initBucketLogging(objAPI)
+	fatalIf(err, "Unable to load all bucket logging configs.")
+
+	// Initialize and load bucket delete protection tags.
+	err = initDeleteProtectionTags(objAPI)
+	fatalIf(err, "Unable to load all bucket delete protection tags.")
+
 	// Initialize a new event notifier.
 	err = initEventNotifier(objAPI)
 	fatalIf(err, "Unable to initialize event notification.")
@@ -253,7 +273,62 @@ func getStorageInfo(disks []StorageAPI) StorageInfo {
 // StorageInfo - returns underlying storage statistics.
 func (xl xlObjects) StorageInfo() StorageInfo {
 	storageInfo := getStorageInfo(xl.storageDisks)
-	storageInfo.Backend.ReadQuorum = xl.readQuorum
-	storageInfo.Backend.WriteQuorum = xl.writeQuorum
+	storageInfo.Backend.ReadQuorum = xl.getReadQuorum()
+	storageInfo.Backend.WriteQuorum = xl.getWriteQuorum()
 	return storageInfo
 }
+
+// newSetStatus - computes the health of an erasure set from the
+// online state of its drives.
+func newSetStatus(index int, drives []bool, parityDrives, readQuorum, writeQuorum int) SetStatus {
+	onlineDrives := 0
+	for _, online := range drives {
+		if online {
+			onlineDrives++
+		}
+	}
+
+	// Every offline drive uses up one parity block, once parity
+	// is exhausted the set can not lose any more drives.
+	parityRemaining := parityDrives - (len(drives) - onlineDrives)
+	if parityRemaining < 0 {
+		parityRemaining = 0
+	}
+
+	return SetStatus{
+		Index:           index,
+		Drives:          drives,
+		OnlineDrives:    onlineDrives,
+		ParityDrives:    parityDrives,
+		ParityRemaining: parityRemaining,
+		ReadQuorum:      readQuorum,
+		WriteQuorum:     writeQuorum,
+		HasReadQuorum:   onlineDrives >= readQuorum,
+		HasWriteQuorum:  onlineDrives >= writeQuorum,
+		Consistent:      true,
+	}
+}
+
+// getDrivesOnline - returns the online state of each of the disks.
+func getDrivesOnline(disks []StorageAPI) []bool {
+	drives := make([]bool, len(disks))
+	for i, storageDisk := range disks {
+		if storageDisk == nil {
+			continue
+		}
+		if _, err := storageDisk.DiskInfo(); err != nil && isErr(err, baseErrs...) {
+			continue
+		}
+		drives[i] = true
+	}
+	return drives
+}
+
+// ErasureSetStatus - returns the health of the erasure sets as seen
+// from this server. XL has a single set made of all disks.
+func (xl xlObjects) ErasureSetStatus() []SetStatus {
+	drives := getDrivesOnline(xl.storageDisks)
+	return []SetStatus{
+		newSetStatus(0, drives, xl.parityBlocks, xl.getReadQuorum(), xl.getWriteQuorum()),
+	}
+}
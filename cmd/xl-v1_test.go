@@ -175,3 +175,31 @@ func TestNewXL(t *testing.T) {
 		t.Fatalf("Unable to initialize erasure, %s", err)
 	}
 }
+
+// TestXLErasureSetStatus - tests the erasure set status with some
+// disks removed.
+func TestXLErasureSetStatus(t *testing.T) {
+	objLayer, fsDirs, err := prepareXL()
+	if err != nil {
+		t.Fatalf("Unable to initialize 'XL' object layer.")
+	}
+	defer removeRoots(fsDirs)
+
+	xl := objLayer.(*xlObjects)
+	sets := xl.ErasureSetStatus()
+	if len(sets) != 1 || sets[0].OnlineDrives != len(fsDirs) || !sets[0].Consistent {
+		t.Fatalf("Expected a single healthy set of %d drives, got %#v", len(fsDirs), sets)
+	}
+
+	// Take parity + 1 disks offline.
+	for i := 0; i <= xl.parityBlocks; i++ {
+		xl.storageDisks[i] = nil
+	}
+	set := xl.ErasureSetStatus()[0]
+	if set.ParityRemaining != 0 {
+		t.Errorf("Expected no parity remaining, got %d", set.ParityRemaining)
+	}
+	if set.HasReadQuorum || set.HasWriteQuorum {
+		t.Errorf("Expected read and write quorum to be lost, got %v/%v", set.HasReadQuorum, set.HasWriteQuorum)
+	}
+}